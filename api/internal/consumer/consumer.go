@@ -101,6 +101,44 @@ func (c *Consumer) InitConfigs() error {
 	return nil
 }
 
+// redactConsumer returns the consumer configuration with sensitive fields redacted, so that it can be logged
+func redactConsumer(s string) string {
+	var c csModel.Consumer
+	if err := json.Unmarshal([]byte(s), &c); err != nil {
+		// the error will be reported later
+		return s
+	}
+
+	redacted := false
+	for name, data := range c.Auth {
+		fields := plugins.LoadConsumerSensitiveFields(name)
+		if len(fields) == 0 {
+			continue
+		}
+		var conf interface{}
+		if err := json.Unmarshal([]byte(data), &conf); err != nil {
+			continue
+		}
+		plugins.RedactSensitiveFields(conf, fields)
+		b, _ := json.Marshal(conf)
+		c.Auth[name] = string(b)
+		redacted = true
+	}
+	for name, fc := range c.Filters {
+		fields := plugins.LoadSensitiveFields(name)
+		if len(fields) == 0 {
+			continue
+		}
+		plugins.RedactSensitiveFields(fc.Config, fields)
+		redacted = true
+	}
+
+	if !redacted {
+		return s
+	}
+	return c.Marshal()
+}
+
 // Implement pkg.filtermanager.api.Consumer
 func (c *Consumer) Name() string {
 	return c.name
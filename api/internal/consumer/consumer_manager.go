@@ -48,7 +48,7 @@ func UpdateConsumers(value *structpb.Struct) {
 			currValue, ok := currIdx[name]
 			if !ok || currValue.generation != v {
 				s := fields["d"].GetStringValue()
-				api.LogInfof("receive consumer configuration: %s", s)
+				api.LogInfof("receive consumer configuration: %s", redactConsumer(s))
 
 				var c Consumer
 				err := c.Unmarshal(s)
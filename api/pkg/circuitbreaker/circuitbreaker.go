@@ -0,0 +1,291 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package circuitbreaker provides a circuit breaker shared by the plugins which call external
+// services, so that they fail in the same way when the external service is down.
+package circuitbreaker
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultFailureThreshold    = 5
+	defaultOpenDuration        = 30 * time.Second
+	defaultHalfOpenMaxRequests = 1
+)
+
+// ErrOpen is returned when the call is rejected because the breaker is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	// StateClosed means the calls are allowed.
+	StateClosed State = iota
+	// StateOpen means the calls are rejected.
+	StateOpen
+	// StateHalfOpen means a limited number of calls are allowed to probe the external service.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("unknown state %d", int(s))
+}
+
+// Config is the configuration of the breaker. The zero value of each field means using the default.
+type Config struct {
+	// FailureThreshold is the number of consecutive failures which opens the breaker. Default to 5.
+	FailureThreshold uint32
+	// OpenDuration is how long the breaker stays open before probing the external service
+	// again. Default to 30s.
+	OpenDuration time.Duration
+	// HalfOpenMaxRequests is the number of probe requests allowed when the breaker is half-open.
+	// The breaker is closed once all of them succeed. Default to 1.
+	HalfOpenMaxRequests uint32
+}
+
+func (c Config) withDefault() Config {
+	if c.FailureThreshold == 0 {
+		c.FailureThreshold = defaultFailureThreshold
+	}
+	if c.OpenDuration == 0 {
+		c.OpenDuration = defaultOpenDuration
+	}
+	if c.HalfOpenMaxRequests == 0 {
+		c.HalfOpenMaxRequests = defaultHalfOpenMaxRequests
+	}
+	return c
+}
+
+// for testing
+var now = time.Now
+
+// Breaker tracks the result of the calls to an external service. It is safe for concurrent use.
+type Breaker struct {
+	name   string
+	config Config
+
+	lock     sync.Mutex
+	state    State
+	openedAt time.Time
+	// generation is increased when the state changes, so that the result of the calls started
+	// in the previous state is ignored
+	generation uint64
+	failures   uint32
+	probing    uint32
+	succeeded  uint32
+
+	rejected atomic.Uint64
+	opened   atomic.Uint64
+}
+
+func newBreaker(name string, config Config) *Breaker {
+	return &Breaker{
+		name:   name,
+		config: config,
+	}
+}
+
+// Name returns the name of the breaker.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() State {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.currentState(now())
+}
+
+// currentState should be called with the lock held
+func (b *Breaker) currentState(t time.Time) State {
+	if b.state == StateOpen && !t.Before(b.openedAt.Add(b.config.OpenDuration)) {
+		b.setState(StateHalfOpen, t)
+	}
+	return b.state
+}
+
+// setState should be called with the lock held
+func (b *Breaker) setState(state State, t time.Time) {
+	b.state = state
+	b.generation++
+	b.failures = 0
+	b.probing = 0
+	b.succeeded = 0
+	if state == StateOpen {
+		b.openedAt = t
+		b.opened.Add(1)
+	}
+}
+
+// Allow checks if the call is allowed. ErrOpen is returned if the breaker rejects the call.
+// Otherwise, the caller should report the result of the call via the returned `done` function.
+func (b *Breaker) Allow() (done func(success bool), err error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	switch b.currentState(now()) {
+	case StateOpen:
+		b.rejected.Add(1)
+		return nil, ErrOpen
+	case StateHalfOpen:
+		if b.probing+b.succeeded >= b.config.HalfOpenMaxRequests {
+			b.rejected.Add(1)
+			return nil, ErrOpen
+		}
+		b.probing++
+	}
+
+	generation := b.generation
+	return func(success bool) {
+		b.report(generation, success)
+	}, nil
+}
+
+func (b *Breaker) report(generation uint64, success bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	t := now()
+	state := b.currentState(t)
+	if generation != b.generation {
+		return
+	}
+
+	switch state {
+	case StateClosed:
+		if success {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.config.FailureThreshold {
+			b.setState(StateOpen, t)
+		}
+	case StateHalfOpen:
+		if !success {
+			b.setState(StateOpen, t)
+			return
+		}
+		b.probing--
+		b.succeeded++
+		if b.succeeded >= b.config.HalfOpenMaxRequests {
+			b.setState(StateClosed, t)
+		}
+	}
+}
+
+// Execute runs the function if the breaker allows it. The call is considered as failed if the
+// function returns an error.
+func (b *Breaker) Execute(fn func() error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+	err = fn()
+	done(err == nil)
+	return err
+}
+
+var (
+	breakersLock sync.Mutex
+	breakers     = map[string]*Breaker{}
+)
+
+// Get returns the breaker with the given name. Each external service should have its own breaker,
+// so the name is usually made up with the plugin name and the address of the external service.
+// The same breaker is shared by the configurations which use the same name, so its state is kept
+// across the configuration updates. The state is reset when the configuration of the breaker
+// is changed.
+func Get(name string, config *Config) *Breaker {
+	var c Config
+	if config != nil {
+		c = *config
+	}
+	c = c.withDefault()
+
+	breakersLock.Lock()
+	defer breakersLock.Unlock()
+
+	old, ok := breakers[name]
+	if ok && old.config == c {
+		return old
+	}
+	b := newBreaker(name, c)
+	if ok {
+		// keep the counters monotonic
+		b.rejected.Store(old.rejected.Load())
+		b.opened.Store(old.opened.Load())
+	}
+	breakers[name] = b
+	return b
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// WriteMetrics writes the metrics of the breakers in Prometheus text format.
+func WriteMetrics(w io.Writer) error {
+	breakersLock.Lock()
+	list := make([]*Breaker, 0, len(breakers))
+	for _, b := range breakers {
+		list = append(list, b)
+	}
+	breakersLock.Unlock()
+
+	if len(list) == 0 {
+		return nil
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].name < list[j].name
+	})
+
+	bw := bufio.NewWriter(w)
+	name := "htnn_circuit_breaker_state"
+	fmt.Fprintf(bw, "# HELP %s State of the circuit breaker: 0 for closed, 1 for open, 2 for half-open.\n", name)
+	fmt.Fprintf(bw, "# TYPE %s gauge\n", name)
+	for _, b := range list {
+		fmt.Fprintf(bw, "%s{name=\"%s\"} %d\n", name, labelValueEscaper.Replace(b.name), int(b.State()))
+	}
+
+	writeCounter := func(name, help string, get func(b *Breaker) uint64) {
+		fmt.Fprintf(bw, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(bw, "# TYPE %s counter\n", name)
+		for _, b := range list {
+			fmt.Fprintf(bw, "%s{name=\"%s\"} %d\n", name, labelValueEscaper.Replace(b.name), get(b))
+		}
+	}
+	writeCounter("htnn_circuit_breaker_opened_total", "Number of times the circuit breaker is opened.",
+		func(b *Breaker) uint64 { return b.opened.Load() })
+	writeCounter("htnn_circuit_breaker_rejected_requests_total", "Number of calls rejected by the circuit breaker.",
+		func(b *Breaker) uint64 { return b.rejected.Load() })
+	return bw.Flush()
+}
@@ -0,0 +1,122 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockNow(t *testing.T) *time.Time {
+	cur := time.Now()
+	now = func() time.Time {
+		return cur
+	}
+	t.Cleanup(func() {
+		now = time.Now
+	})
+	return &cur
+}
+
+func TestBreaker(t *testing.T) {
+	cur := mockNow(t)
+	b := Get("TestBreaker", &Config{
+		FailureThreshold:    2,
+		OpenDuration:        10 * time.Second,
+		HalfOpenMaxRequests: 2,
+	})
+
+	errCall := errors.New("ouch")
+	assert.ErrorIs(t, b.Execute(func() error { return errCall }), errCall)
+	// the success resets the consecutive failures
+	assert.NoError(t, b.Execute(func() error { return nil }))
+	assert.Equal(t, StateClosed, b.State())
+	b.Execute(func() error { return errCall })
+	b.Execute(func() error { return errCall })
+	assert.Equal(t, StateOpen, b.State())
+
+	called := false
+	assert.ErrorIs(t, b.Execute(func() error {
+		called = true
+		return nil
+	}), ErrOpen)
+	assert.False(t, called)
+
+	*cur = cur.Add(10 * time.Second)
+	assert.Equal(t, StateHalfOpen, b.State())
+	done1, err := b.Allow()
+	require.NoError(t, err)
+	done2, err := b.Allow()
+	require.NoError(t, err)
+	// only two probes are allowed
+	_, err = b.Allow()
+	assert.ErrorIs(t, err, ErrOpen)
+
+	done1(true)
+	assert.Equal(t, StateHalfOpen, b.State())
+	_, err = b.Allow()
+	assert.ErrorIs(t, err, ErrOpen)
+	done2(true)
+	assert.Equal(t, StateClosed, b.State())
+
+	// the failed probe opens the breaker again
+	b.Execute(func() error { return errCall })
+	b.Execute(func() error { return errCall })
+	*cur = cur.Add(10 * time.Second)
+	done, err := b.Allow()
+	require.NoError(t, err)
+	done(false)
+	assert.Equal(t, StateOpen, b.State())
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteMetrics(&buf))
+	assert.Contains(t, buf.String(), "htnn_circuit_breaker_state{name=\"TestBreaker\"} 1\n")
+	assert.Contains(t, buf.String(), "htnn_circuit_breaker_opened_total{name=\"TestBreaker\"} 3\n")
+	assert.Contains(t, buf.String(), "htnn_circuit_breaker_rejected_requests_total{name=\"TestBreaker\"} 3\n")
+}
+
+func TestBreakerIgnoreStaleResult(t *testing.T) {
+	mockNow(t)
+	b := Get("TestBreakerIgnoreStaleResult", &Config{FailureThreshold: 1})
+	done, err := b.Allow()
+	require.NoError(t, err)
+	b.Execute(func() error { return errors.New("ouch") })
+	assert.Equal(t, StateOpen, b.State())
+	// the call started before the breaker is opened doesn't close it
+	done(true)
+	assert.Equal(t, StateOpen, b.State())
+}
+
+func TestGet(t *testing.T) {
+	b := Get("TestGet", nil)
+	assert.Equal(t, "TestGet", b.Name())
+	assert.Equal(t, Config{
+		FailureThreshold:    5,
+		OpenDuration:        30 * time.Second,
+		HalfOpenMaxRequests: 1,
+	}, b.config)
+
+	assert.Same(t, b, Get("TestGet", &Config{FailureThreshold: 5}))
+
+	b.rejected.Add(1)
+	nb := Get("TestGet", &Config{FailureThreshold: 1})
+	assert.NotSame(t, b, nb)
+	assert.Equal(t, uint64(1), nb.rejected.Load())
+}
@@ -0,0 +1,259 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	pkgPlugins "mosn.io/htnn/api/pkg/plugins"
+)
+
+// The filtermanager config is parsed without knowing which route it belongs to. So we record the
+// effective plugin chain when the route is hit by a request, and dump them via the admin endpoint.
+
+var (
+	// configGeneration is increased each time a filtermanager config is created
+	configGeneration atomic.Uint64
+
+	pluginChainRecorder atomic.Pointer[pluginChains]
+)
+
+type pluginChains struct {
+	// route name -> *routePluginChain
+	routes sync.Map
+}
+
+type routePluginChain struct {
+	config *filterManagerConfig
+
+	lock sync.Mutex
+	// consumer name -> plugins from the consumer
+	consumers map[string][]string
+}
+
+func (c *pluginChains) record(route string, config *filterManagerConfig) *routePluginChain {
+	v, ok := c.routes.Load(route)
+	if ok {
+		chain := v.(*routePluginChain)
+		if chain.config == config {
+			return chain
+		}
+	}
+	// the config is changed, the recorded consumer overrides are outdated
+	chain := &routePluginChain{
+		config:    config,
+		consumers: map[string][]string{},
+	}
+	c.routes.Store(route, chain)
+	return chain
+}
+
+func (c *routePluginChain) recordConsumer(name string, plugins []string) {
+	names := make([]string, len(plugins))
+	copy(names, plugins)
+	sort.Slice(names, func(i, j int) bool {
+		return pkgPlugins.ComparePluginOrder(names[i], names[j])
+	})
+
+	c.lock.Lock()
+	c.consumers[name] = names
+	c.lock.Unlock()
+}
+
+type PluginSnapshot struct {
+	Name     string          `json:"name"`
+	Order    string          `json:"order"`
+	Config   interface{}     `json:"config,omitempty"`
+	Sampling *model.Sampling `json:"sampling,omitempty"`
+	Match    *model.Match    `json:"match,omitempty"`
+	// InitFailure is the error returned from Init. Plugins which are not initialized yet have no InitFailure.
+	InitFailure string `json:"initFailure,omitempty"`
+}
+
+type ConsumerOverrideSnapshot struct {
+	Consumer string   `json:"consumer"`
+	Plugins  []string `json:"plugins"`
+}
+
+type PluginChainSnapshot struct {
+	Route      string `json:"route"`
+	Namespace  string `json:"namespace,omitempty"`
+	Generation uint64 `json:"generation"`
+	// Plugins are sorted in the execution order
+	Plugins []*PluginSnapshot `json:"plugins"`
+	// ConsumerOverrides are the plugins configured in the consumers which are seen in this route
+	ConsumerOverrides []*ConsumerOverrideSnapshot `json:"consumerOverrides,omitempty"`
+}
+
+// snapshotPluginConfig converts the parsed config to a JSON object with sensitive fields redacted
+func snapshotPluginConfig(name string, config interface{}) interface{} {
+	if config == nil {
+		return nil
+	}
+
+	var data []byte
+	var err error
+	if msg, ok := config.(proto.Message); ok {
+		// the plugin config usually embeds the protobuf message
+		data, err = protojson.Marshal(msg)
+	} else {
+		data, err = json.Marshal(config)
+	}
+	if err != nil {
+		return "<unserializable>"
+	}
+
+	var res interface{}
+	if err := json.Unmarshal(data, &res); err != nil {
+		return "<unserializable>"
+	}
+	pkgPlugins.RedactSensitiveFields(res, pkgPlugins.LoadSensitiveFields(name))
+	return res
+}
+
+func (c *routePluginChain) snapshot(route string) *PluginChainSnapshot {
+	s := &PluginChainSnapshot{
+		Route:      route,
+		Namespace:  c.config.namespace,
+		Generation: c.config.generation,
+		Plugins:    make([]*PluginSnapshot, 0, len(c.config.parsed)),
+	}
+	for _, fc := range c.config.parsed {
+		ps := &PluginSnapshot{
+			Name:     fc.Name,
+			Config:   snapshotPluginConfig(fc.Name, fc.ParsedConfig),
+			Sampling: fc.Sampling,
+			Match:    fc.Match,
+		}
+		if p := pkgPlugins.LoadPluginType(fc.Name); p != nil {
+			ps.Order = p.Order().Position.String()
+		}
+		if fc.InitFailure != nil {
+			ps.InitFailure = fc.InitFailure.Error()
+		}
+		s.Plugins = append(s.Plugins, ps)
+	}
+
+	c.lock.Lock()
+	for name, plugins := range c.consumers {
+		s.ConsumerOverrides = append(s.ConsumerOverrides, &ConsumerOverrideSnapshot{
+			Consumer: name,
+			Plugins:  plugins,
+		})
+	}
+	c.lock.Unlock()
+	sort.Slice(s.ConsumerOverrides, func(i, j int) bool {
+		return s.ConsumerOverrides[i].Consumer < s.ConsumerOverrides[j].Consumer
+	})
+	return s
+}
+
+// EnablePluginChainDump starts recording the effective plugin chain of each route hit by the requests.
+// The recorded data will be reset after calling this function.
+func EnablePluginChainDump() {
+	pluginChainRecorder.Store(&pluginChains{})
+}
+
+// DisablePluginChainDump stops recording the plugin chain.
+func DisablePluginChainDump() {
+	pluginChainRecorder.Store(nil)
+}
+
+// SnapshotPluginChains returns the recorded plugin chains, sorted by the route name.
+func SnapshotPluginChains() []*PluginChainSnapshot {
+	c := pluginChainRecorder.Load()
+	if c == nil {
+		return nil
+	}
+
+	var res []*PluginChainSnapshot
+	c.routes.Range(func(k, v any) bool {
+		res = append(res, v.(*routePluginChain).snapshot(k.(string)))
+		return true
+	})
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Route < res[j].Route
+	})
+	return res
+}
+
+// WritePluginChains writes the recorded plugin chains in JSON format.
+func WritePluginChains(w io.Writer) error {
+	chains := SnapshotPluginChains()
+	if chains == nil {
+		chains = []*PluginChainSnapshot{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]interface{}{
+		"routes": chains,
+	})
+}
+
+// PluginChainsHandler is a http.Handler which serves the recorded plugin chains.
+func PluginChainsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := WritePluginChains(w); err != nil {
+			api.LogErrorf("failed to write plugin chains: %v", err)
+		}
+	})
+}
+
+var adminMux = http.NewServeMux()
+
+// HandleAdmin registers the handler for the given pattern in the admin endpoint, so that the plugins
+// can provide their own management APIs. The pattern should be prefixed with the plugin name to
+// avoid conflicts, like `/oidc/sessions`.
+func HandleAdmin(pattern string, handler http.Handler) {
+	adminMux.Handle(pattern, handler)
+}
+
+func initAdmin() {
+	addr := os.Getenv("HTNN_ADMIN_ADDR")
+	if addr == "" {
+		return
+	}
+
+	EnablePluginChainDump()
+
+	HandleAdmin("/plugin_chains", PluginChainsHandler())
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           adminMux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil {
+			api.LogErrorf("failed to serve admin endpoint on %s: %v", addr, err)
+		}
+	}()
+}
+
+func init() {
+	initAdmin()
+}
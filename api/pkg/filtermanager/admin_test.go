@@ -0,0 +1,162 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	pkgPlugins "mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type adminSensitiveConfig struct {
+	pkgPlugins.MockPluginConfig
+}
+
+func (c *adminSensitiveConfig) SensitiveFields() []string {
+	return []string{"password"}
+}
+
+type adminSensitivePlugin struct {
+	pkgPlugins.MockPlugin
+}
+
+func (p *adminSensitivePlugin) Config() api.PluginConfig {
+	return &adminSensitiveConfig{}
+}
+
+func (p *adminSensitivePlugin) Order() pkgPlugins.PluginOrder {
+	return pkgPlugins.PluginOrder{
+		Position: pkgPlugins.OrderPositionAuthz,
+	}
+}
+
+type adminTestConfig struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+func TestPluginChainDump(t *testing.T) {
+	pkgPlugins.RegisterPluginType("adminSensitive", &adminSensitivePlugin{})
+
+	EnablePluginChainDump()
+	defer DisablePluginChainDump()
+
+	route := "r1"
+	patches := gomonkey.ApplyMethodFunc(&envoy.StreamInfo{}, "GetRouteName", func() string {
+		return route
+	})
+	defer patches.Reset()
+
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:    "adminSensitive",
+			Factory: PassThroughFactory,
+			ParsedConfig: &adminTestConfig{
+				User:     "admin",
+				Password: "pass",
+			},
+			Sampling: &model.Sampling{Percentage: 50},
+		},
+		{
+			Name:         "unknown",
+			Factory:      PassThroughFactory,
+			ParsedConfig: func() {},
+			InitFailure:  errors.New("ouch"),
+		},
+	}
+
+	runRequest := func(config *filterManagerConfig) {
+		cb := envoy.NewCAPIFilterCallbackHandler()
+		m := FilterManagerFactory(config, cb)
+		// the plugin chain is recorded before running the plugins
+		m.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true)
+	}
+	runRequest(config)
+	route = "r0"
+	runRequest(initFilterManagerConfig(""))
+
+	chains := SnapshotPluginChains()
+	require.Equal(t, 2, len(chains))
+	assert.Equal(t, "r0", chains[0].Route)
+	assert.Empty(t, chains[0].Plugins)
+
+	chain := chains[1]
+	assert.Equal(t, "r1", chain.Route)
+	assert.Equal(t, "ns", chain.Namespace)
+	assert.Equal(t, config.generation, chain.Generation)
+	assert.Equal(t, []*PluginSnapshot{
+		{
+			Name:  "adminSensitive",
+			Order: "Authz",
+			Config: map[string]interface{}{
+				"user":     "admin",
+				"password": pkgPlugins.RedactedValue,
+			},
+			Sampling: &model.Sampling{Percentage: 50},
+		},
+		{
+			Name:        "unknown",
+			Config:      "<unserializable>",
+			InitFailure: "ouch",
+		},
+	}, chain.Plugins)
+
+	// consumer overrides are reset when the config of the route is changed
+	route = "r1"
+	rc, _ := pluginChainRecorder.Load().routes.Load("r1")
+	rc.(*routePluginChain).recordConsumer("me", []string{"b", "a"})
+	chain = SnapshotPluginChains()[1]
+	assert.Equal(t, []*ConsumerOverrideSnapshot{
+		{Consumer: "me", Plugins: []string{"a", "b"}},
+	}, chain.ConsumerOverrides)
+
+	newConfig := initFilterManagerConfig("ns")
+	runRequest(newConfig)
+	chain = SnapshotPluginChains()[1]
+	assert.Greater(t, chain.Generation, config.generation)
+	assert.Empty(t, chain.ConsumerOverrides)
+
+	rec := httptest.NewRecorder()
+	PluginChainsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/plugin_chains", nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"route": "r1"`)
+}
+
+func TestPluginChainDumpDisabled(t *testing.T) {
+	rec := httptest.NewRecorder()
+	PluginChainsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/plugin_chains", nil))
+	assert.JSONEq(t, `{"routes":[]}`, rec.Body.String())
+}
+
+func TestHandleAdmin(t *testing.T) {
+	HandleAdmin("/test/ping", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	}))
+
+	rec := httptest.NewRecorder()
+	adminMux.ServeHTTP(rec, httptest.NewRequest("GET", "/test/ping", nil))
+	assert.Equal(t, "pong", rec.Body.String())
+}
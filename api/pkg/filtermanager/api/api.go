@@ -38,6 +38,43 @@ type EncodeWholeResponseFilter interface {
 	EncodeResponse(headers ResponseHeaderMap, data BufferInstance, trailers ResponseTrailerMap) ResultAction
 }
 
+// WebSocketUpgradeFilter can be implemented by the Filter which wants to observe or deny the WebSocket upgrade
+type WebSocketUpgradeFilter interface {
+	// OnWebSocketUpgrade is called after DecodeHeaders when the request is a WebSocket upgrade request.
+	// Return a LocalResponse to deny the upgrade.
+	OnWebSocketUpgrade(headers RequestHeaderMap) ResultAction
+}
+
+// WebSocketMessageFilter can be implemented by the Filter which wants to inspect the WebSocket messages
+type WebSocketMessageFilter interface {
+	// OnWebSocketMessage is called with each message in both directions once the request is a WebSocket upgrade
+	// request. The messages are forwarded as they are, so the message can't be modified.
+	// Return a LocalResponse to close the connection. Note that the frames of a fragmented message
+	// received before the last one are already forwarded.
+	OnWebSocketMessage(msg *WebSocketMessage) ResultAction
+}
+
+type WebSocketOpcode int
+
+const (
+	WebSocketOpcodeText   WebSocketOpcode = 1
+	WebSocketOpcodeBinary WebSocketOpcode = 2
+	WebSocketOpcodeClose  WebSocketOpcode = 8
+	WebSocketOpcodePing   WebSocketOpcode = 9
+	WebSocketOpcodePong   WebSocketOpcode = 10
+)
+
+// WebSocketMessage is a message in the WebSocket connection. The fragmented message is assembled as a whole.
+type WebSocketMessage struct {
+	Opcode WebSocketOpcode
+	// Payload is the unmasked payload of the message
+	Payload []byte
+	// Compressed is true if the message is compressed by the negotiated extension, like permessage-deflate
+	Compressed bool
+	// FromDownstream is true if the message is sent by the client
+	FromDownstream bool
+}
+
 // Filter represents a collection of callbacks in which Envoy will call your Go code.
 // Every filter method (except the OnLog) is run in goroutine so it's non-blocking.
 // To know how do we run the Filter during request processing, please refer to
@@ -144,6 +181,32 @@ type IPAddress struct {
 	Port    int
 }
 
+// PeerCertificate contains the attributes of the certificate presented by the peer.
+type PeerCertificate struct {
+	// Subject is the subject field of the certificate
+	Subject string
+	// URISAN is the first URI entry in the SAN field of the certificate
+	URISAN string
+	// DNSSAN is the first DNS entry in the SAN field of the certificate
+	DNSSAN string
+	// SHA256Digest is the SHA256 digest of the certificate, in hex format
+	SHA256Digest string
+}
+
+// TLSInfo contains the TLS attributes of a connection.
+type TLSInfo struct {
+	// Version is the TLS version of the connection, like "TLSv1.3"
+	Version string
+	// ServerName is the SNI sent by the client. It's empty when the client doesn't send SNI.
+	ServerName string
+	// ALPN is the negotiated application protocol, like "h2" or "http/1.1"
+	ALPN string
+	// MTLS is true if the peer certificate was presented and verified
+	MTLS bool
+	// PeerCertificate is nil if the peer doesn't present a certificate
+	PeerCertificate *PeerCertificate
+}
+
 type StreamInfo interface {
 	GetRouteName() string
 	FilterChainName() string
@@ -178,6 +241,11 @@ type StreamInfo interface {
 
 	// DownstreamRemoteParsedAddress returns the downstream remote address, in the IPAddress struct
 	DownstreamRemoteParsedAddress() *IPAddress
+	// DownstreamLocalParsedAddress returns the downstream local address, in the IPAddress struct
+	DownstreamLocalParsedAddress() *IPAddress
+	// DownstreamTLSInfo returns the TLS attributes of the downstream connection.
+	// It returns nil if the downstream connection is not TLS.
+	DownstreamTLSInfo() *TLSInfo
 }
 
 type PluginConfig interface {
@@ -227,6 +295,19 @@ type StreamFilterCallbacks interface {
 	// PluginState returns the PluginState associated to this request.
 	PluginState() PluginState
 
+	// MirrorRequest sends a copy of the request to the given address asynchronously, which is useful
+	// for shadow testing. The address is the host and port of the upstream, like `shadow.default:8080`,
+	// or a URL like `https://shadow.default:8443`. The request is sent by the Go HTTP client, not
+	// through an Envoy cluster, as the Go filter API of Envoy provides no way to start a request to
+	// a cluster. So the cluster configuration of Envoy, like TLS and circuit breaking, doesn't apply
+	// to it, and it's not counted in the cluster stats. Use the request mirror policy of the route
+	// if these are required. Like Envoy's request mirror policy, `-shadow` is appended to
+	// the Host header of the mirrored request. The response of the mirrored request is discarded,
+	// so it doesn't affect the primary response. The body can be nil if the request body is not
+	// buffered. The headers and the body are copied before this method returns, so it's safe to
+	// modify them later. The mirrored request is dropped if too many of them are pending.
+	MirrorRequest(address string, headers RequestHeaderMap, body BufferInstance)
+
 	// WithLogArg injectes `key: value` as the suffix of application log created by this
 	// callback's Log* methods. The injected log arguments are only valid in the current request.
 	// This method can be used to inject IDs or other context information into the logs.
@@ -272,8 +353,33 @@ type FilterCallbackHandler interface {
 // This function should be a pure builder and should not have any side effect.
 type FilterFactory func(config interface{}, callbacks FilterCallbackHandler) Filter
 
-// DynamicMetadata operates the Envoy's dynamic metadata
-type DynamicMetadata = api.DynamicMetadata
+// DynamicMetadata operates the Envoy's dynamic metadata. The metadata is grouped by namespace,
+// which is usually the name of the filter that sets it. Go plugins can read the metadata set by
+// the native filters, for example, the result of RBAC filter under the `envoy.filters.http.rbac`
+// namespace, or set the metadata consumed by the native filters, like the metadata descriptors
+// used by the rate limit filter.
+type DynamicMetadata interface {
+	// Get returns all the metadata under the given namespace. Returns nil if the namespace doesn't exist.
+	Get(namespace string) map[string]interface{}
+	// Set sets the value of the key under the given namespace. The value should be able to be
+	// converted into protobuf Value, for example, string, number, bool, []interface{} and map[string]interface{}.
+	Set(namespace string, key string, value interface{})
+
+	// Methods added by HTNN
+
+	// GetString returns the string value of the key under the given namespace.
+	// The second returned value is false if the key doesn't exist or the value is not a string.
+	GetString(namespace string, key string) (string, bool)
+	// GetNumber returns the number value of the key under the given namespace.
+	// The second returned value is false if the key doesn't exist or the value is not a number.
+	GetNumber(namespace string, key string) (float64, bool)
+	// GetBool returns the bool value of the key under the given namespace.
+	// The second returned value is false if the key doesn't exist or the value is not a bool.
+	GetBool(namespace string, key string) (bool, bool)
+	// GetStruct returns the struct value of the key under the given namespace.
+	// The second returned value is false if the key doesn't exist or the value is not a struct.
+	GetStruct(namespace string, key string) (map[string]interface{}, bool)
+}
 
 // FilterState operates the Envoy's filter state
 type FilterState = api.FilterState
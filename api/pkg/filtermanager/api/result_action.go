@@ -14,7 +14,11 @@
 
 package api
 
-import "net/http"
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
 
 // ResultAction is the result returned by each Filter method
 type ResultAction interface {
@@ -52,4 +56,8 @@ type LocalResponse struct {
 	// 4. Otherwise, the Msg will be sent directly.
 	Msg    string
 	Header http.Header
+	// If the request is a gRPC request, the reply will be sent as a gRPC response with the GrpcStatus,
+	// and the Msg will be sent as the grpc-message. When the GrpcStatus is not specified (i.e. codes.OK),
+	// it will be mapped from the Code.
+	GrpcStatus codes.Code
 }
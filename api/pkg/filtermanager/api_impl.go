@@ -114,21 +114,42 @@ type filterManagerStreamInfo struct {
 
 	cacheLock sync.Mutex
 
-	ipAddress *api.IPAddress
+	// callbacks is used to fetch the attributes which are not provided by the StreamInfo
+	callbacks capi.FilterCallbackHandler
+
+	ipAddress       *api.IPAddress
+	localIPAddress  *api.IPAddress
+	tlsInfo         *api.TLSInfo
+	tlsInfoFetched  bool
+	dynamicMetadata *filterManagerDynamicMetadata
+}
+
+func (s *filterManagerStreamInfo) DynamicMetadata() api.DynamicMetadata {
+	s.cacheLock.Lock()
+	if s.dynamicMetadata == nil {
+		s.dynamicMetadata = &filterManagerDynamicMetadata{
+			DynamicMetadata: s.StreamInfo.DynamicMetadata(),
+		}
+	}
+	s.cacheLock.Unlock()
+	return s.dynamicMetadata
+}
+
+func parseIPAddress(ipport string) *api.IPAddress {
+	// the IPPort given by Envoy must be valid
+	ip, port, _ := net.SplitHostPort(ipport)
+	p, _ := strconv.Atoi(port)
+	return &api.IPAddress{
+		Address: ipport,
+		IP:      ip,
+		Port:    p,
+	}
 }
 
 func (s *filterManagerStreamInfo) DownstreamRemoteParsedAddress() *api.IPAddress {
 	s.cacheLock.Lock()
 	if s.ipAddress == nil {
-		ipport := s.StreamInfo.DownstreamRemoteAddress()
-		// the IPPort given by Envoy must be valid
-		ip, port, _ := net.SplitHostPort(ipport)
-		p, _ := strconv.Atoi(port)
-		s.ipAddress = &api.IPAddress{
-			Address: ipport,
-			IP:      ip,
-			Port:    p,
-		}
+		s.ipAddress = parseIPAddress(s.StreamInfo.DownstreamRemoteAddress())
 	}
 	s.cacheLock.Unlock()
 	return s.ipAddress
@@ -138,6 +159,123 @@ func (s *filterManagerStreamInfo) DownstreamRemoteAddress() string {
 	return s.DownstreamRemoteParsedAddress().Address
 }
 
+func (s *filterManagerStreamInfo) DownstreamLocalParsedAddress() *api.IPAddress {
+	s.cacheLock.Lock()
+	if s.localIPAddress == nil {
+		s.localIPAddress = parseIPAddress(s.StreamInfo.DownstreamLocalAddress())
+	}
+	s.cacheLock.Unlock()
+	return s.localIPAddress
+}
+
+func (s *filterManagerStreamInfo) DownstreamLocalAddress() string {
+	return s.DownstreamLocalParsedAddress().Address
+}
+
+func (s *filterManagerStreamInfo) getProperty(key string) string {
+	// The attribute doesn't exist when the connection is not TLS, or the peer doesn't
+	// present the certificate. So we treat all the errors as value not found.
+	v, err := s.callbacks.GetProperty(key)
+	if err != nil {
+		return ""
+	}
+	return v
+}
+
+// alpnFromProtocol converts the HTTP protocol to the negotiated ALPN. Envoy doesn't expose
+// the ALPN as an attribute, but the HTTP protocol is chosen according to it.
+func alpnFromProtocol(protocol string) string {
+	switch protocol {
+	case "HTTP/1.0":
+		return "http/1.0"
+	case "HTTP/1.1":
+		return "http/1.1"
+	case "HTTP/2":
+		return "h2"
+	case "HTTP/3":
+		return "h3"
+	}
+	return ""
+}
+
+func (s *filterManagerStreamInfo) DownstreamTLSInfo() *api.TLSInfo {
+	s.cacheLock.Lock()
+	defer s.cacheLock.Unlock()
+
+	if s.tlsInfoFetched {
+		return s.tlsInfo
+	}
+	s.tlsInfoFetched = true
+
+	version := s.getProperty("connection.tls_version")
+	if version == "" {
+		// not a TLS connection
+		return nil
+	}
+
+	info := &api.TLSInfo{
+		Version:    version,
+		ServerName: s.getProperty("connection.requested_server_name"),
+		MTLS:       s.getProperty("connection.mtls") == "true",
+	}
+	if protocol, ok := s.StreamInfo.Protocol(); ok {
+		info.ALPN = alpnFromProtocol(protocol)
+	}
+
+	subject := s.getProperty("connection.subject_peer_certificate")
+	if subject != "" {
+		info.PeerCertificate = &api.PeerCertificate{
+			Subject:      subject,
+			URISAN:       s.getProperty("connection.uri_san_peer_certificate"),
+			DNSSAN:       s.getProperty("connection.dns_san_peer_certificate"),
+			SHA256Digest: s.getProperty("connection.sha256_peer_certificate_digest"),
+		}
+	}
+
+	s.tlsInfo = info
+	return info
+}
+
+type filterManagerDynamicMetadata struct {
+	capi.DynamicMetadata
+}
+
+// We don't cache the metadata in the Go side, as it can be modified by the native filters
+// between the Go filter's callbacks.
+
+func (m *filterManagerDynamicMetadata) get(namespace string, key string) (interface{}, bool) {
+	md := m.DynamicMetadata.Get(namespace)
+	if md == nil {
+		return nil, false
+	}
+	v, ok := md[key]
+	return v, ok
+}
+
+func (m *filterManagerDynamicMetadata) GetString(namespace string, key string) (string, bool) {
+	v, _ := m.get(namespace, key)
+	s, ok := v.(string)
+	return s, ok
+}
+
+func (m *filterManagerDynamicMetadata) GetNumber(namespace string, key string) (float64, bool) {
+	v, _ := m.get(namespace, key)
+	n, ok := v.(float64)
+	return n, ok
+}
+
+func (m *filterManagerDynamicMetadata) GetBool(namespace string, key string) (bool, bool) {
+	v, _ := m.get(namespace, key)
+	b, ok := v.(bool)
+	return b, ok
+}
+
+func (m *filterManagerDynamicMetadata) GetStruct(namespace string, key string) (map[string]interface{}, bool) {
+	v, _ := m.get(namespace, key)
+	st, ok := v.(map[string]interface{})
+	return st, ok
+}
+
 type filterManagerCallbackHandler struct {
 	capi.FilterCallbackHandler
 
@@ -173,6 +311,7 @@ func (cb *filterManagerCallbackHandler) StreamInfo() api.StreamInfo {
 	if cb.streamInfo == nil {
 		cb.streamInfo = &filterManagerStreamInfo{
 			StreamInfo: cb.FilterCallbackHandler.StreamInfo(),
+			callbacks:  cb.FilterCallbackHandler,
 		}
 	}
 	cb.cacheLock.Unlock()
@@ -255,3 +255,111 @@ func TestReset(t *testing.T) {
 	assert.Equal(t, "", cb.logArgNames)
 	assert.Nil(t, cb.logArgs)
 }
+
+func TestDownstreamTLSInfo(t *testing.T) {
+	tests := []struct {
+		name  string
+		props map[string]string
+		info  *api.TLSInfo
+	}{
+		{
+			name:  "plaintext",
+			props: map[string]string{},
+		},
+		{
+			name: "tls",
+			props: map[string]string{
+				"connection.tls_version":           "TLSv1.3",
+				"connection.requested_server_name": "example.com",
+				"connection.mtls":                  "false",
+			},
+			info: &api.TLSInfo{
+				Version:    "TLSv1.3",
+				ServerName: "example.com",
+			},
+		},
+		{
+			name: "mtls",
+			props: map[string]string{
+				"connection.tls_version":                    "TLSv1.2",
+				"connection.mtls":                           "true",
+				"connection.subject_peer_certificate":       "CN=client",
+				"connection.uri_san_peer_certificate":       "spiffe://cluster.local/ns/default/sa/client",
+				"connection.sha256_peer_certificate_digest": "abcd",
+			},
+			info: &api.TLSInfo{
+				Version: "TLSv1.2",
+				MTLS:    true,
+				PeerCertificate: &api.PeerCertificate{
+					Subject:      "CN=client",
+					URISAN:       "spiffe://cluster.local/ns/default/sa/client",
+					SHA256Digest: "abcd",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capiCb := envoy.NewCAPIFilterCallbackHandler()
+			patches := gomonkey.ApplyMethodFunc(capiCb, "GetProperty", func(key string) (string, error) {
+				v, ok := tt.props[key]
+				if !ok {
+					return "", api.ErrValueNotFound
+				}
+				return v, nil
+			})
+			defer patches.Reset()
+
+			cb := &filterManagerCallbackHandler{
+				FilterCallbackHandler: capiCb,
+			}
+			info := cb.StreamInfo().DownstreamTLSInfo()
+			assert.Equal(t, tt.info, info)
+			// cached
+			assert.Equal(t, info, cb.StreamInfo().DownstreamTLSInfo())
+		})
+	}
+}
+
+func TestDownstreamLocalParsedAddress(t *testing.T) {
+	cb := &filterManagerCallbackHandler{
+		FilterCallbackHandler: envoy.NewCAPIFilterCallbackHandler(),
+	}
+	addr := cb.StreamInfo().DownstreamLocalParsedAddress()
+	assert.Equal(t, "0.0.0.0", addr.IP)
+	assert.Equal(t, 10000, addr.Port)
+	assert.Equal(t, "0.0.0.0:10000", cb.StreamInfo().DownstreamLocalAddress())
+}
+
+func TestDynamicMetadata(t *testing.T) {
+	capiCb := envoy.NewCAPIFilterCallbackHandler()
+	cb := &filterManagerCallbackHandler{
+		FilterCallbackHandler: capiCb,
+	}
+	md := cb.StreamInfo().DynamicMetadata()
+	md.Set("envoy.filters.http.rbac", "shadow_engine_result", "allowed")
+	md.Set("htnn", "count", float64(1))
+	md.Set("htnn", "enabled", true)
+	md.Set("htnn", "nested", map[string]interface{}{"k": "v"})
+
+	s, ok := md.GetString("envoy.filters.http.rbac", "shadow_engine_result")
+	assert.True(t, ok)
+	assert.Equal(t, "allowed", s)
+	_, ok = md.GetString("htnn", "count")
+	assert.False(t, ok)
+	_, ok = md.GetString("unknown", "key")
+	assert.False(t, ok)
+
+	n, ok := md.GetNumber("htnn", "count")
+	assert.True(t, ok)
+	assert.Equal(t, float64(1), n)
+
+	b, ok := md.GetBool("htnn", "enabled")
+	assert.True(t, ok)
+	assert.True(t, b)
+
+	st, ok := md.GetStruct("htnn", "nested")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"k": "v"}, st)
+}
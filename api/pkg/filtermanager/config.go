@@ -19,8 +19,11 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime/debug"
+	"slices"
 	"sort"
 	"sync"
+	"time"
 
 	xds "github.com/cncf/xds/go/xds/type/v3"
 	capi "github.com/envoyproxy/envoy/contrib/golang/common/go/api"
@@ -28,6 +31,7 @@ import (
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/pkg/headerops"
 	pkgPlugins "mosn.io/htnn/api/pkg/plugins"
 )
 
@@ -44,6 +48,8 @@ type FilterManagerConfig struct {
 	Namespace string `json:"namespace,omitempty"`
 
 	Plugins []*model.FilterConfig `json:"plugins"`
+	// DisabledPlugins are the plugins from the HTTP filter which are not run on the route
+	DisabledPlugins []string `json:"disabledPlugins,omitempty"`
 }
 
 type filterManagerConfig struct {
@@ -58,13 +64,26 @@ type filterManagerConfig struct {
 	pool   *sync.Pool
 
 	namespace string
+	// the plugins from the HTTP filter which are skipped during merging
+	disabledPlugins []string
+	// generation identifies the config, so that we can know if the config of a route is changed
+	generation uint64
 
 	enableDebugMode bool
+	// whether there is a plugin which is only run for part of the requests
+	enableSampling bool
+	// whether there is a plugin which is only run for the matched requests
+	enableMatch bool
+	// whether there is a plugin which does blocking I/O
+	hasBlockingPlugin bool
+	// whether there is a plugin which is initialized on the first request which runs it
+	hasLazyInit bool
 }
 
 func initFilterManagerConfig(namespace string) *filterManagerConfig {
 	config := &filterManagerConfig{
-		namespace: namespace,
+		namespace:  namespace,
+		generation: configGeneration.Add(1),
 	}
 	config.pool = &sync.Pool{
 		New: func() any {
@@ -102,7 +121,7 @@ func (conf *filterManagerConfig) Merge(another *filterManagerConfig) *filterMana
 	}
 
 	cp.enableDebugMode = conf.enableDebugMode
-	if another.enableDebugMode {
+	if another.enableDebugMode && !slices.Contains(conf.disabledPlugins, "debugMode") {
 		cp.enableDebugMode = true
 	}
 
@@ -114,11 +133,18 @@ func (conf *filterManagerConfig) Merge(another *filterManagerConfig) *filterMana
 
 	// O(n^2) is fine as n is small
 	for _, toAdd := range another.parsed {
+		if slices.Contains(conf.disabledPlugins, toAdd.Name) {
+			continue
+		}
+
 		needAdd := true
-		for _, fc := range conf.parsed {
+		for i, fc := range conf.parsed {
 			if fc.Name == toAdd.Name {
-				// The filter is already in the current config, skip it
+				// The filter is already in the current config, skip it unless it requires merging
 				needAdd = false
+				if merged := cp.mergeFilterConfig(toAdd, fc); merged != nil {
+					cp.parsed[i] = merged
+				}
 				break
 			}
 		}
@@ -135,6 +161,19 @@ func (conf *filterManagerConfig) Merge(another *filterManagerConfig) *filterMana
 	})
 
 	// recompute fields which will be different after merging
+	cp.hasLazyInit = cp.hasLazyInit || conf.hasLazyInit || another.hasLazyInit
+	for _, fc := range cp.parsed {
+		if fc.Sampling != nil {
+			cp.enableSampling = true
+		}
+		if fc.Match != nil {
+			cp.enableMatch = true
+		}
+		if pkgPlugins.IsBlockingPlugin(fc.Name) {
+			cp.hasBlockingPlugin = true
+		}
+	}
+
 	cp.consumerFiltersEndAt = len(cp.parsed)
 	for i, fc := range cp.parsed {
 		_, ok := pkgPlugins.LoadPlugin(fc.Name).(pkgPlugins.ConsumerPlugin)
@@ -153,29 +192,233 @@ func (conf *filterManagerConfig) Merge(another *filterManagerConfig) *filterMana
 	return cp
 }
 
+// mergeFilterConfig merges the plugin configuration from the HTTP filter into the one from the route,
+// according to the merge strategy. Nil is returned if the route's configuration should be used as it is.
+func (conf *filterManagerConfig) mergeFilterConfig(parent *model.ParsedFilterConfig, child *model.ParsedFilterConfig) *model.ParsedFilterConfig {
+	strategy := pkgPlugins.MergeStrategy(child.MergeStrategy)
+	if strategy == "" {
+		strategy = pkgPlugins.LoadMergeStrategy(child.Name)
+	}
+	if strategy == pkgPlugins.MergeStrategyReplace {
+		return nil
+	}
+	// skip the configuration which is failed to parse
+	if parent.ParsedConfig == nil || child.ParsedConfig == nil {
+		return nil
+	}
+	plugin := pkgPlugins.LoadHTTPFilterFactoryAndParser(child.Name)
+	if plugin == nil {
+		return nil
+	}
+
+	fc, needInit := conf.parseFilterConfig(&model.FilterConfig{
+		Name:          child.Name,
+		Config:        pkgPlugins.MergeConfig(strategy, parent.RawConfig, child.RawConfig),
+		Sampling:      child.Sampling,
+		Match:         child.Match,
+		MergeStrategy: child.MergeStrategy,
+	}, plugin)
+	if needInit && conf.initOnce == nil {
+		conf.initOnce = &sync.Once{}
+	}
+	if fc.ParsedConfig != nil {
+		// the header operations are not merged
+		fc.HeaderOps = child.HeaderOps
+	}
+	return fc
+}
+
+var (
+	// initSemaphore limits the number of plugin configurations initialized at the same time.
+	// Some plugins do network work in the Init, like OIDC discovery. When a config push contains
+	// hundreds of routes, we don't want to overwhelm the external services.
+	initSemaphore = make(chan struct{}, 16)
+	// defaultInitTimeout is the timeout of Init if the configuration doesn't implement InitTimeouter
+	defaultInitTimeout = 10 * time.Second
+)
+
+// runInit runs the Init with timeout and concurrency limit.
+func runInit(initer pkgPlugins.Initer) error {
+	timeout := defaultInitTimeout
+	if t, ok := initer.(pkgPlugins.InitTimeouter); ok {
+		timeout = t.InitTimeout()
+	}
+
+	initSemaphore <- struct{}{}
+	defer func() { <-initSemaphore }()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				api.LogErrorf("panic: %v\n%s", p, debug.Stack())
+				done <- fmt.Errorf("panic during init: %v", p)
+			}
+		}()
+
+		// For now, we have nothing to provide as config callbacks
+		done <- initer.Init(nil)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		// We can't cancel the Init, so the goroutine is left running. As the configuration
+		// is marked as failed, the result of the Init will be discarded.
+		return fmt.Errorf("init timeout after %s", timeout)
+	}
+}
+
+func initFilterConfig(fc *model.ParsedFilterConfig, initer pkgPlugins.Initer) {
+	fc.InitOnce.Do(func() {
+		fc.InitFailure = runInit(initer)
+	})
+}
+
 func (conf *filterManagerConfig) InitOnce() {
 	if conf.initOnce == nil {
 		return
 	}
 
 	conf.initOnce.Do(func() {
+		var wg sync.WaitGroup
 		for _, fc := range conf.parsed {
-			config := fc.ParsedConfig
-			if initer, ok := config.(pkgPlugins.Initer); ok {
-				fc.InitOnce.Do(func() {
-					// For now, we have nothing to provide as config callbacks
-					fc.InitFailure = initer.Init(nil)
-				})
-				if fc.InitFailure != nil {
-					conf.initFailure = fc.InitFailure
-					conf.initFailedPluginName = fc.Name
-					conf.initFailed = true
-				}
+			initer, ok := fc.ParsedConfig.(pkgPlugins.Initer)
+			if !ok {
+				continue
+			}
+
+			wg.Add(1)
+			go func(fc *model.ParsedFilterConfig) {
+				defer wg.Done()
+				initFilterConfig(fc, initer)
+			}(fc)
+		}
+		wg.Wait()
+
+		// Report all the failures instead of the first one, so that the operators can fix them
+		// at once.
+		var errs []error
+		for _, fc := range conf.parsed {
+			if fc.InitFailure == nil {
+				continue
+			}
+
+			api.LogErrorf("failed to init plugin %s: %s", fc.Name, fc.InitFailure)
+			errs = append(errs, fmt.Errorf("plugin %s: %w", fc.Name, fc.InitFailure))
+			if !conf.initFailed {
+				conf.initFailedPluginName = fc.Name
+				conf.initFailed = true
 			}
 		}
+		conf.initFailure = errors.Join(errs...)
 	})
 }
 
+// redactFilterManagerConfig returns the config with sensitive fields redacted, so that it can be logged
+func redactFilterManagerConfig(data []byte, fmConfig *FilterManagerConfig) []byte {
+	hasSensitiveFields := false
+	for _, plugin := range fmConfig.Plugins {
+		if len(pkgPlugins.LoadSensitiveFields(plugin.Name)) > 0 {
+			hasSensitiveFields = true
+			break
+		}
+	}
+	if !hasSensitiveFields {
+		return data
+	}
+
+	// unmarshal again to avoid modifying the original config
+	cp := &FilterManagerConfig{}
+	_ = json.Unmarshal(data, cp)
+	for _, plugin := range cp.Plugins {
+		pkgPlugins.RedactSensitiveFields(plugin.Config, pkgPlugins.LoadSensitiveFields(plugin.Name))
+	}
+	redacted, err := json.Marshal(cp)
+	if err != nil {
+		return []byte("<redacted>")
+	}
+	return redacted
+}
+
+// parseFilterConfig parses the configuration of a plugin, and returns whether the parsed configuration
+// needs to be initialized before handling the first request.
+func (conf *filterManagerConfig) parseFilterConfig(proto *model.FilterConfig,
+	plugin *pkgPlugins.FilterFactoryAndParser) (*model.ParsedFilterConfig, bool) {
+
+	name := proto.Name
+	config, err := plugin.ConfigParser.Parse(proto.Config)
+	var ops *headerops.HeaderOps
+	if err == nil && proto.HeaderOps != nil {
+		ops, err = headerops.Compile(proto.HeaderOps)
+		if err != nil {
+			err = fmt.Errorf("invalid headerOps: %w", err)
+		}
+	}
+	var matcher *headerops.Matcher
+	if err == nil && proto.Match != nil {
+		matcher, err = headerops.CompileConditions(proto.Match.Headers)
+		if err != nil {
+			err = fmt.Errorf("invalid match: %w", err)
+		}
+	}
+	if err != nil {
+		api.LogErrorf("%s during parsing plugin %s in filtermanager", err, name)
+
+		// Return an error from the Parse method will cause assertion failure.
+		// See https://github.com/envoyproxy/envoy/blob/f301eebf7acc680e27e03396a1be6be77e1ae3a5/contrib/golang/filters/http/source/golang_filter.cc#L1736-L1737
+		// As we can't control what is returned from a plugin, we need to
+		// avoid the failure by providing a special factory, which also
+		// indicates something is wrong.
+		return &model.ParsedFilterConfig{
+			Name:    name,
+			Factory: NewInternalErrorFactory(name, err),
+		}, false
+	}
+
+	fc := &model.ParsedFilterConfig{
+		Name:          name,
+		ParsedConfig:  config,
+		Factory:       plugin.Factory,
+		Sampling:      proto.Sampling,
+		HeaderOps:     ops,
+		Match:         proto.Match,
+		Matcher:       matcher,
+		RawConfig:     proto.Config,
+		MergeStrategy: proto.MergeStrategy,
+	}
+
+	if proto.Sampling != nil {
+		conf.enableSampling = true
+	}
+	if proto.Match != nil {
+		conf.enableMatch = true
+	}
+	if pkgPlugins.IsBlockingPlugin(name) {
+		conf.hasBlockingPlugin = true
+	}
+
+	needInit := false
+	if initer, ok := config.(pkgPlugins.Initer); ok {
+		if lazy, ok := config.(pkgPlugins.LazyIniter); ok && lazy.LazyInit() {
+			fc.Factory = NewLazyInitFactory(name, initer, plugin.Factory)
+			conf.hasLazyInit = true
+		} else {
+			needInit = true
+		}
+	}
+
+	if name == "debugMode" {
+		// we handle this plugin differently, so we can have debug behavior before
+		// executing this plugin.
+		conf.enableDebugMode = true
+	}
+	return fc, needInit
+}
+
 func (p *FilterManagerConfigParser) Parse(any *anypb.Any, callbacks capi.ConfigCallbackHandler) (interface{}, error) {
 	configStruct := &xds.TypedStruct{}
 
@@ -198,16 +441,17 @@ func (p *FilterManagerConfigParser) Parse(any *anypb.Any, callbacks capi.ConfigC
 		return nil, err
 	}
 
-	// TODO: figure out a way to identify what the config is belonged to, like using the route name
-	api.LogInfof("receive filtermanager config: %s", data)
-
 	fmConfig := &FilterManagerConfig{}
 	if err := json.Unmarshal(data, fmConfig); err != nil {
 		return nil, err
 	}
 
+	// TODO: figure out a way to identify what the config is belonged to, like using the route name
+	api.LogInfof("receive filtermanager config: %s", redactFilterManagerConfig(data, fmConfig))
+
 	plugins := fmConfig.Plugins
 	conf := initFilterManagerConfig(fmConfig.Namespace)
+	conf.disabledPlugins = fmConfig.DisabledPlugins
 	conf.parsed = make([]*model.ParsedFilterConfig, 0, len(plugins))
 
 	consumerFiltersEndAt := 0
@@ -217,40 +461,17 @@ func (p *FilterManagerConfigParser) Parse(any *anypb.Any, callbacks capi.ConfigC
 	for _, proto := range plugins {
 		name := proto.Name
 		if plugin := pkgPlugins.LoadHTTPFilterFactoryAndParser(name); plugin != nil {
-			config, err := plugin.ConfigParser.Parse(proto.Config)
-			if err != nil {
-				api.LogErrorf("%s during parsing plugin %s in filtermanager", err, name)
-
-				// Return an error from the Parse method will cause assertion failure.
-				// See https://github.com/envoyproxy/envoy/blob/f301eebf7acc680e27e03396a1be6be77e1ae3a5/contrib/golang/filters/http/source/golang_filter.cc#L1736-L1737
-				// As we can't control what is returned from a plugin, we need to
-				// avoid the failure by providing a special factory, which also
-				// indicates something is wrong.
-				conf.parsed = append(conf.parsed, &model.ParsedFilterConfig{
-					Name:    proto.Name,
-					Factory: NewInternalErrorFactory(proto.Name, err),
-				})
-			} else {
-				conf.parsed = append(conf.parsed, &model.ParsedFilterConfig{
-					Name:         proto.Name,
-					ParsedConfig: config,
-					Factory:      plugin.Factory,
-				})
+			fc, init := conf.parseFilterConfig(proto, plugin)
+			conf.parsed = append(conf.parsed, fc)
+			if init {
+				needInit = true
+			}
 
+			if fc.ParsedConfig != nil {
 				_, ok := pkgPlugins.LoadPlugin(name).(pkgPlugins.ConsumerPlugin)
 				if ok {
 					consumerFiltersEndAt = i + 1
 				}
-
-				if _, ok := config.(pkgPlugins.Initer); ok {
-					needInit = true
-				}
-
-				if name == "debugMode" {
-					// we handle this plugin differently, so we can have debug behavior before
-					// executing this plugin.
-					conf.enableDebugMode = true
-				}
 			}
 			i++
 
@@ -15,7 +15,10 @@
 package filtermanager
 
 import (
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	xds "github.com/cncf/xds/go/xds/type/v3"
 	"github.com/stretchr/testify/assert"
@@ -23,6 +26,9 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"mosn.io/htnn/api/internal/proto"
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	pkgPlugins "mosn.io/htnn/api/pkg/plugins"
 )
 
 func TestParse(t *testing.T) {
@@ -81,3 +87,194 @@ func TestMergeDebugFlag(t *testing.T) {
 	merged = parent.Merge(child)
 	assert.Equal(t, true, merged.enableDebugMode)
 }
+
+type mergeTestConfig struct {
+	*structpb.Struct
+}
+
+func (c *mergeTestConfig) Validate() error {
+	return nil
+}
+
+type mergeTestPlugin struct {
+	pkgPlugins.PluginMethodDefaultImpl
+	strategy pkgPlugins.MergeStrategy
+}
+
+func (p *mergeTestPlugin) Factory() api.FilterFactory {
+	return PassThroughFactory
+}
+
+func (p *mergeTestPlugin) Config() api.PluginConfig {
+	return &mergeTestConfig{Struct: &structpb.Struct{}}
+}
+
+func (p *mergeTestPlugin) MergeStrategy() pkgPlugins.MergeStrategy {
+	return p.strategy
+}
+
+func TestMergeWithStrategy(t *testing.T) {
+	pkgPlugins.RegisterPlugin("mergeReplace", &mergeTestPlugin{})
+	pkgPlugins.RegisterPlugin("mergeAppend", &mergeTestPlugin{strategy: pkgPlugins.MergeStrategyAppend})
+
+	parse := func(plugins ...*model.FilterConfig) *filterManagerConfig {
+		conf := initFilterManagerConfig("")
+		for _, p := range plugins {
+			fc, _ := conf.parseFilterConfig(p, pkgPlugins.LoadHTTPFilterFactoryAndParser(p.Name))
+			conf.parsed = append(conf.parsed, fc)
+		}
+		return conf
+	}
+	parentConfig := map[string]interface{}{
+		"hosts": []interface{}{"a"},
+		"limit": map[string]interface{}{"rate": 1.0, "burst": 2.0},
+	}
+	childConfig := map[string]interface{}{
+		"hosts": []interface{}{"b"},
+		"limit": map[string]interface{}{"rate": 3.0},
+	}
+
+	tests := []struct {
+		name     string
+		plugin   string
+		strategy string
+		res      map[string]interface{}
+	}{
+		{
+			name:   "default",
+			plugin: "mergeReplace",
+			res:    childConfig,
+		},
+		{
+			name:   "plugin default strategy",
+			plugin: "mergeAppend",
+			res: map[string]interface{}{
+				"hosts": []interface{}{"a", "b"},
+				"limit": map[string]interface{}{"rate": 3.0, "burst": 2.0},
+			},
+		},
+		{
+			name:     "override strategy",
+			plugin:   "mergeAppend",
+			strategy: "deepMerge",
+			res: map[string]interface{}{
+				"hosts": []interface{}{"b"},
+				"limit": map[string]interface{}{"rate": 3.0, "burst": 2.0},
+			},
+		},
+		{
+			name:     "override strategy to replace",
+			plugin:   "mergeAppend",
+			strategy: "replace",
+			res:      childConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := parse(&model.FilterConfig{Name: tt.plugin, Config: parentConfig})
+			child := parse(&model.FilterConfig{Name: tt.plugin, Config: childConfig, MergeStrategy: tt.strategy})
+			merged := child.Merge(parent)
+			assert.Equal(t, 1, len(merged.parsed))
+			conf := merged.parsed[0].ParsedConfig.(*mergeTestConfig)
+			assert.Equal(t, tt.res, conf.AsMap())
+			// the original configurations are not changed
+			assert.Equal(t, childConfig, child.parsed[0].ParsedConfig.(*mergeTestConfig).AsMap())
+		})
+	}
+}
+
+func TestMergeWithDisabledPlugins(t *testing.T) {
+	pkgPlugins.RegisterPlugin("mergeReplace", &mergeTestPlugin{})
+	pkgPlugins.RegisterPlugin("mergeAppend", &mergeTestPlugin{strategy: pkgPlugins.MergeStrategyAppend})
+
+	parent := initFilterManagerConfig("")
+	for _, name := range []string{"mergeReplace", "mergeAppend"} {
+		fc, _ := parent.parseFilterConfig(&model.FilterConfig{Name: name, Config: map[string]interface{}{}},
+			pkgPlugins.LoadHTTPFilterFactoryAndParser(name))
+		parent.parsed = append(parent.parsed, fc)
+	}
+	parent.enableDebugMode = true
+
+	child := initFilterManagerConfig("")
+	child.disabledPlugins = []string{"mergeAppend", "debugMode"}
+	merged := child.Merge(parent)
+	assert.Equal(t, 1, len(merged.parsed))
+	assert.Equal(t, "mergeReplace", merged.parsed[0].Name)
+	assert.False(t, merged.enableDebugMode)
+}
+
+type slowInitConfig struct {
+	delay   time.Duration
+	timeout time.Duration
+	err     error
+}
+
+func (c *slowInitConfig) Init(cb api.ConfigCallbackHandler) error {
+	time.Sleep(c.delay)
+	return c.err
+}
+
+type slowInitConfigWithTimeout struct {
+	slowInitConfig
+}
+
+func (c *slowInitConfigWithTimeout) InitTimeout() time.Duration {
+	return c.timeout
+}
+
+type panicInitConfig struct{}
+
+func (c *panicInitConfig) Init(cb api.ConfigCallbackHandler) error {
+	panic("ouch")
+}
+
+func TestInitOnce(t *testing.T) {
+	config := initFilterManagerConfig("ns")
+	config.initOnce = &sync.Once{}
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:         "slow",
+			ParsedConfig: &slowInitConfig{delay: 100 * time.Millisecond},
+		},
+		{
+			Name:         "slow2",
+			ParsedConfig: &slowInitConfig{delay: 100 * time.Millisecond},
+		},
+		{
+			Name:         "failed",
+			ParsedConfig: &slowInitConfig{err: errors.New("ouch")},
+		},
+		{
+			Name: "timeout",
+			ParsedConfig: &slowInitConfigWithTimeout{
+				slowInitConfig: slowInitConfig{
+					delay:   time.Second,
+					timeout: 50 * time.Millisecond,
+				},
+			},
+		},
+		{
+			Name:         "panic",
+			ParsedConfig: &panicInitConfig{},
+		},
+		{
+			Name:         "no_init",
+			ParsedConfig: struct{}{},
+		},
+	}
+
+	start := time.Now()
+	config.InitOnce()
+	// run in parallel
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+
+	assert.True(t, config.initFailed)
+	assert.Equal(t, "failed", config.initFailedPluginName)
+	assert.Nil(t, config.parsed[0].InitFailure)
+	assert.Nil(t, config.parsed[1].InitFailure)
+	// all failures are reported
+	assert.ErrorContains(t, config.initFailure, "plugin failed: ouch")
+	assert.ErrorContains(t, config.initFailure, "plugin timeout: init timeout after 50ms")
+	assert.ErrorContains(t, config.initFailure, "plugin panic: panic during init: ouch")
+}
@@ -24,11 +24,13 @@ import (
 	"sync/atomic"
 
 	capi "github.com/envoyproxy/envoy/contrib/golang/common/go/api"
+	"google.golang.org/grpc/codes"
 
 	"mosn.io/htnn/api/internal/consumer"
 	"mosn.io/htnn/api/internal/reflectx"
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/pkg/grpcx"
 	pkgPlugins "mosn.io/htnn/api/pkg/plugins"
 )
 
@@ -39,6 +41,8 @@ type filterManager struct {
 	decodeIdx           int
 	reqHdr              api.RequestHeaderMap // don't access it in Encode phases
 	contentType         string
+	// samplingID is the ID used to make the sampling decision of the plugins in this request
+	samplingID string
 
 	encodeResponseNeeded bool
 	encodeIdx            int
@@ -55,6 +59,9 @@ type filterManager struct {
 	canSkipOnLog         bool
 	canSkipMethod        map[string]bool
 
+	// pluginChain is set only when the plugin chain dump is enabled
+	pluginChain *routePluginChain
+
 	callbacks *filterManagerCallbackHandler
 	config    *filterManagerConfig
 
@@ -68,6 +75,7 @@ func (m *filterManager) Reset() {
 	m.decodeIdx = -1
 	m.reqHdr = nil
 	m.contentType = ""
+	m.samplingID = ""
 
 	m.encodeResponseNeeded = false
 	m.encodeIdx = -1
@@ -81,6 +89,8 @@ func (m *filterManager) Reset() {
 	m.canSkipEncodeData = false
 	m.canSkipOnLog = false
 
+	m.pluginChain = nil
+
 	m.callbacks.Reset()
 }
 
@@ -168,12 +178,18 @@ func FilterManagerFactory(c interface{}, cb capi.FilterCallbackHandler) (streamF
 		f := factory(config, fm.callbacks)
 		// Technically, the factory might create different f for different calls. We don't support this edge case for now.
 		if fm.canSkipMethod == nil {
+			checked := f
+			if lf, ok := f.(*lazyInitFilter); ok {
+				// The lazy init wrapper only overrides DecodeHeaders, which is handled via hasLazyInit
+				checked = lf.Filter
+			}
+
 			definedMethod := make(map[string]bool, len(canSkipMethod))
 			for meth := range canSkipMethod {
 				definedMethod[meth] = false
 			}
 			for meth := range canSkipMethod {
-				overridden, err := reflectx.IsMethodOverridden(f, meth)
+				overridden, err := reflectx.IsMethodOverridden(checked, meth)
 				if err != nil {
 					api.LogErrorf("failed to check method %s in plugin %s: %v", meth, fc.Name, err)
 					// canSkipMethod[meth] will be false
@@ -181,6 +197,8 @@ func FilterManagerFactory(c interface{}, cb capi.FilterCallbackHandler) (streamF
 				canSkipMethod[meth] = canSkipMethod[meth] && !overridden
 				definedMethod[meth] = overridden
 			}
+			webSocketSkipMethods(checked, canSkipMethod)
+			headerOpsSkipMethods(fc.HeaderOps, canSkipMethod)
 
 			if definedMethod["DecodeRequest"] {
 				if !definedMethod["DecodeHeaders"] {
@@ -200,12 +218,19 @@ func FilterManagerFactory(c interface{}, cb capi.FilterCallbackHandler) (streamF
 			}
 		}
 
+		f = NewWebSocketFilter(fc.Name, f)
+		f = NewHeaderOpsFilter(f, fc.HeaderOps, fm.callbacks)
+
 		if logExecution {
 			filters[i] = model.NewFilterWrapper(fc.Name, NewLogExecutionFilter(fc.Name, f, fm.callbacks))
 		} else {
 			filters[i] = model.NewFilterWrapper(fc.Name, f)
 		}
 
+		if phaseMetricsEnabled() {
+			filters[i] = model.NewFilterWrapper(fc.Name, NewPhaseMetricsFilter(fc.Name, filters[i].Filter, fm.callbacks))
+		}
+
 		if fm.DebugModeEnabled() {
 			filters[i] = model.NewFilterWrapper(fc.Name, NewDebugFilter(fc.Name, filters[i].Filter, fm.callbacks))
 		}
@@ -220,7 +245,8 @@ func FilterManagerFactory(c interface{}, cb capi.FilterCallbackHandler) (streamF
 
 	// The skip check is based on the compiled code. So if the DecodeRequest is defined,
 	// even it is not called, DecodeData will not be skipped. Same as EncodeResponse.
-	fm.canSkipDecodeHeaders = fm.canSkipMethod["DecodeHeaders"] && fm.canSkipMethod["DecodeRequest"] && fm.config.initOnce == nil
+	fm.canSkipDecodeHeaders = fm.canSkipMethod["DecodeHeaders"] && fm.canSkipMethod["DecodeRequest"] &&
+		fm.config.initOnce == nil && !fm.config.hasLazyInit
 	fm.canSkipDecodeData = fm.canSkipMethod["DecodeData"] && fm.canSkipMethod["DecodeRequest"]
 	fm.canSkipEncodeHeaders = fm.canSkipMethod["EncodeHeaders"]
 	fm.canSkipEncodeData = fm.canSkipMethod["EncodeData"] && fm.canSkipMethod["EncodeResponse"]
@@ -277,6 +303,23 @@ func (m *filterManager) localReply(v *api.LocalResponse, decoding bool) {
 		v.Code = 200
 	}
 
+	var cb api.FilterProcessCallbacks
+	if decoding {
+		cb = m.callbacks.DecoderFilterCallbacks()
+	} else {
+		cb = m.callbacks.EncoderFilterCallbacks()
+	}
+
+	if grpcx.IsGrpcContentType(m.contentType) {
+		// Envoy will convert the reply to a gRPC response and put the body into the grpc-message
+		grpcStatus := v.GrpcStatus
+		if grpcStatus == codes.OK {
+			grpcStatus = grpcx.HTTPStatusToGrpcStatus(v.Code)
+		}
+		cb.SendLocalReply(v.Code, v.Msg, hdr, int64(grpcStatus), "")
+		return
+	}
+
 	msg := v.Msg
 	// TODO: we can also add custom template response
 	if msg != "" && len(hdr["Content-Type"]) == 0 {
@@ -315,18 +358,16 @@ func (m *filterManager) localReply(v *api.LocalResponse, decoding bool) {
 		}
 	}
 
-	var cb api.FilterProcessCallbacks
-	if decoding {
-		cb = m.callbacks.DecoderFilterCallbacks()
-	} else {
-		cb = m.callbacks.EncoderFilterCallbacks()
-	}
 	cb.SendLocalReply(v.Code, msg, hdr, 0, "")
 }
 
 func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream bool) capi.StatusType {
 	m.contentType, _ = headers.Get("content-type")
 
+	if c := pluginChainRecorder.Load(); c != nil {
+		m.pluginChain = c.record(m.callbacks.StreamInfo().GetRouteName(), m.config)
+	}
+
 	if !supportGettingHeadersOnLog && m.DebugModeEnabled() {
 		// Ensure the headers are cached on the Go side.
 		headers := &filterManagerRequestHeaderMap{
@@ -335,20 +376,30 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 		m.reqHdr = headers
 	}
 
+	if m.config.enableSampling {
+		// The sampling decision is made before running any plugin, so that the plugins which are
+		// not sampled are skipped in all phases.
+		m.samplingID = samplingID(headers)
+		m.sampleFilters(m.filters)
+	}
+	if m.config.enableMatch {
+		m.matchFilters(headers)
+	}
+
 	if m.canSkipDecodeHeaders {
 		return capi.Continue
 	}
 
 	m.MarkRunningInGoThread(true)
 
-	go func() {
+	ok := m.runInGoThread(func() {
 		defer m.MarkRunningInGoThread(false)
 		defer m.callbacks.DecoderFilterCallbacks().RecoverPanic()
 		var res api.ResultAction
 
 		m.config.InitOnce()
 		if m.config.initFailed {
-			api.LogErrorf("error in plugin %s: %s", m.config.initFailedPluginName, m.config.initFailure)
+			api.LogErrorf("error in plugins: %s", m.config.initFailure)
 			m.recordLocalReplyPluginName(m.config.initFailedPluginName)
 			m.localReply(&api.LocalResponse{
 				Code: 500,
@@ -412,13 +463,17 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 					factory := fc.Factory
 					config := fc.ParsedConfig
 					f := factory(config, m.callbacks)
-					filterWrappers[i] = model.NewFilterWrapper(name, f)
+					filterWrappers[i] = model.NewFilterWrapper(name, NewWebSocketFilter(name, f))
 				}
 
 				c.CanSkipMethodOnce.Do(func() {
 					canSkipMethod := newSkipMethodsMap()
 					for _, fw := range filterWrappers {
 						f := fw.Filter
+						if wf, ok := f.(*webSocketFilter); ok {
+							f = wf.Filter
+							webSocketSkipMethods(f, canSkipMethod)
+						}
 						for meth := range canSkipMethod {
 							overridden, err := reflectx.IsMethodOverridden(f, meth)
 							if err != nil {
@@ -438,6 +493,13 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 					}
 				}
 
+				if phaseMetricsEnabled() {
+					for _, fw := range filterWrappers {
+						f := fw.Filter
+						fw.Filter = NewPhaseMetricsFilter(fw.Name, f, m.callbacks)
+					}
+				}
+
 				if m.DebugModeEnabled() {
 					for _, fw := range filterWrappers {
 						f := fw.Filter
@@ -445,12 +507,22 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 					}
 				}
 
+				if m.pluginChain != nil {
+					m.pluginChain.recordConsumer(c.Name(), c.FilterNames)
+				}
+
 				canSkipMethod := c.CanSkipMethod
 				m.canSkipDecodeData = m.canSkipDecodeData && canSkipMethod["DecodeData"] && canSkipMethod["DecodeRequest"]
 				m.canSkipEncodeHeaders = m.canSkipEncodeData && canSkipMethod["EncodeHeaders"]
 				m.canSkipEncodeData = m.canSkipEncodeData && canSkipMethod["EncodeData"] && canSkipMethod["EncodeResponse"]
 				m.canSkipOnLog = m.canSkipOnLog && canSkipMethod["OnLog"]
 
+				if m.config.enableSampling {
+					// the plugins from the consumer also need to be sampled, otherwise the
+					// plugins which are not sampled would run with the consumer's config
+					m.sampleFilters(filterWrappers)
+				}
+
 				// TODO: add field to control if merging is allowed
 				i := 0
 				for _, f := range m.filters {
@@ -508,7 +580,11 @@ func (m *filterManager) DecodeHeaders(headers capi.RequestHeaderMap, endStream b
 		}
 
 		m.callbacks.Continue(capi.Continue, true)
-	}()
+	}, true)
+
+	if !ok {
+		return m.shedRequest()
+	}
 
 	return capi.Running
 }
@@ -520,7 +596,7 @@ func (m *filterManager) DecodeData(buf capi.BufferInstance, endStream bool) capi
 
 	m.MarkRunningInGoThread(true)
 
-	go func() {
+	m.runInGoThread(func() {
 		defer m.MarkRunningInGoThread(false)
 		defer m.callbacks.DecoderFilterCallbacks().RecoverPanic()
 		var res api.ResultAction
@@ -605,7 +681,7 @@ func (m *filterManager) DecodeData(buf capi.BufferInstance, endStream bool) capi
 
 			m.callbacks.Continue(capi.Continue, true)
 		}
-	}()
+	}, false)
 
 	return capi.Running
 }
@@ -623,7 +699,7 @@ func (m *filterManager) EncodeHeaders(headers capi.ResponseHeaderMap, endStream
 
 	m.MarkRunningInGoThread(true)
 
-	go func() {
+	m.runInGoThread(func() {
 		defer m.MarkRunningInGoThread(false)
 		defer m.callbacks.EncoderFilterCallbacks().RecoverPanic()
 		var res api.ResultAction
@@ -656,7 +732,7 @@ func (m *filterManager) EncodeHeaders(headers capi.ResponseHeaderMap, endStream
 		}
 
 		m.callbacks.Continue(capi.Continue, false)
-	}()
+	}, false)
 
 	return capi.Running
 }
@@ -668,7 +744,7 @@ func (m *filterManager) EncodeData(buf capi.BufferInstance, endStream bool) capi
 
 	m.MarkRunningInGoThread(true)
 
-	go func() {
+	m.runInGoThread(func() {
 		defer m.MarkRunningInGoThread(false)
 		defer m.callbacks.EncoderFilterCallbacks().RecoverPanic()
 		var res api.ResultAction
@@ -736,7 +812,7 @@ func (m *filterManager) EncodeData(buf capi.BufferInstance, endStream bool) capi
 
 			m.callbacks.Continue(capi.Continue, false)
 		}
-	}()
+	}, false)
 
 	return capi.Running
 }
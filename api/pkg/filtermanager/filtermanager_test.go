@@ -24,6 +24,7 @@ import (
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 
 	internalConsumer "mosn.io/htnn/api/internal/consumer"
 	"mosn.io/htnn/api/pkg/filtermanager/api"
@@ -129,6 +130,64 @@ func TestLocalReplyJSON_UseReqHeader(t *testing.T) {
 	}
 }
 
+func TestLocalReplyGrpc(t *testing.T) {
+	tests := []struct {
+		name  string
+		resp  *api.LocalResponse
+		reply envoy.LocalResponse
+	}{
+		{
+			name: "map from HTTP status",
+			resp: &api.LocalResponse{
+				Code: 403,
+				Msg:  "denied",
+			},
+			reply: envoy.LocalResponse{
+				Code:       403,
+				Body:       "denied",
+				GrpcStatus: int64(codes.PermissionDenied),
+			},
+		},
+		{
+			name: "specified gRPC status",
+			resp: &api.LocalResponse{
+				Code:       400,
+				Msg:        "bad message",
+				GrpcStatus: codes.InvalidArgument,
+			},
+			reply: envoy.LocalResponse{
+				Code:       400,
+				Body:       "bad message",
+				GrpcStatus: int64(codes.InvalidArgument),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewCAPIFilterCallbackHandler()
+			config := initFilterManagerConfig("ns")
+			config.parsed = []*model.ParsedFilterConfig{
+				{
+					Name:    "test",
+					Factory: PassThroughFactory,
+				},
+			}
+			m := unwrapFilterManager(FilterManagerFactory(config, cb))
+			patches := gomonkey.ApplyMethodReturn(m.filters[0].Filter, "DecodeHeaders", tt.resp)
+			defer patches.Reset()
+
+			h := http.Header{}
+			h.Add("content-type", "application/grpc")
+			hdr := envoy.NewRequestHeaderMap(h)
+			m.DecodeHeaders(hdr, false)
+			cb.WaitContinued()
+			lr := cb.LocalResponse()
+			assert.Equal(t, tt.reply, lr)
+		})
+	}
+}
+
 func TestLocalReplyJSON_UseRespHeader(t *testing.T) {
 	tests := []struct {
 		name  string
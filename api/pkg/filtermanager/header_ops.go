@@ -0,0 +1,68 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/headerops"
+)
+
+// headerOpsSkipMethods marks the methods required by the header operations as not skippable
+func headerOpsSkipMethods(ops *headerops.HeaderOps, canSkipMethod map[string]bool) {
+	if ops == nil {
+		return
+	}
+	// the request headers are also required to render the value of response header operations
+	canSkipMethod["DecodeHeaders"] = false
+	if ops.HasResponseOps() {
+		canSkipMethod["EncodeHeaders"] = false
+	}
+}
+
+type headerOpsFilter struct {
+	api.Filter
+
+	ops       *headerops.HeaderOps
+	callbacks api.FilterCallbackHandler
+	reqHdr    api.RequestHeaderMap
+}
+
+// NewHeaderOpsFilter wraps the filter so that the header operations configured with the plugin
+// are applied before the plugin processes the headers.
+func NewHeaderOpsFilter(f api.Filter, ops *headerops.HeaderOps, callbacks api.FilterCallbackHandler) api.Filter {
+	if ops == nil {
+		return f
+	}
+	return &headerOpsFilter{
+		Filter:    f,
+		ops:       ops,
+		callbacks: callbacks,
+	}
+}
+
+func (f *headerOpsFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.reqHdr = headers
+	if f.ops.HasRequestOps() {
+		f.ops.ApplyToRequest(headers, f.callbacks)
+	}
+	return f.Filter.DecodeHeaders(headers, endStream)
+}
+
+func (f *headerOpsFilter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.ops.HasResponseOps() {
+		f.ops.ApplyToResponse(headers, f.reqHdr, f.callbacks)
+	}
+	return f.Filter.EncodeHeaders(headers, endStream)
+}
@@ -0,0 +1,121 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/pkg/headerops"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type headerOpsTestFilter struct {
+	api.PassThroughFilter
+
+	tenant string
+}
+
+func (f *headerOpsTestFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.tenant, _ = headers.Get("x-tenant")
+	return api.Continue
+}
+
+type headerOpsPassThroughFilter struct {
+	api.PassThroughFilter
+}
+
+func headerOpsPassThroughFactory(interface{}, api.FilterCallbackHandler) api.Filter {
+	return &headerOpsPassThroughFilter{}
+}
+
+func TestHeaderOps(t *testing.T) {
+	ops, err := headerops.Compile(&headerops.Config{
+		Request: []*headerops.Op{
+			{Action: headerops.ActionRename, Name: "x-user", NewName: "x-tenant"},
+		},
+		Response: []*headerops.Op{
+			{Action: headerops.ActionSet, Name: "x-tenant", Value: "${header.x-tenant}"},
+		},
+	})
+	require.NoError(t, err)
+
+	f := &headerOpsTestFilter{}
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name: "headerOps",
+			Factory: func(interface{}, api.FilterCallbackHandler) api.Filter {
+				return f
+			},
+			HeaderOps: ops,
+		},
+		{
+			Name:    "passthrough",
+			Factory: headerOpsPassThroughFactory,
+			// no operation
+			HeaderOps: &headerops.HeaderOps{},
+		},
+	}
+
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	m := unwrapFilterManager(FilterManagerFactory(config, cb))
+	assert.False(t, m.canSkipDecodeHeaders)
+	assert.False(t, m.canSkipEncodeHeaders)
+	assert.True(t, m.canSkipDecodeData)
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		"X-User": []string{"alice"},
+	})
+	m.DecodeHeaders(hdr, true)
+	cb.WaitContinued()
+	// the operations are applied before the plugin
+	assert.Equal(t, "alice", f.tenant)
+	_, ok := hdr.Get("x-user")
+	assert.False(t, ok)
+
+	rspHdr := envoy.NewResponseHeaderMap(http.Header{})
+	m.EncodeHeaders(rspHdr, true)
+	cb.WaitContinued()
+	tenant, _ := rspHdr.Get("x-tenant")
+	assert.Equal(t, "alice", tenant)
+}
+
+func TestHeaderOpsCanSkipEncodeHeaders(t *testing.T) {
+	ops, err := headerops.Compile(&headerops.Config{
+		Request: []*headerops.Op{
+			{Action: headerops.ActionRemove, Name: "x-user"},
+		},
+	})
+	require.NoError(t, err)
+
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:      "passthrough",
+			Factory:   headerOpsPassThroughFactory,
+			HeaderOps: ops,
+		},
+	}
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	m := unwrapFilterManager(FilterManagerFactory(config, cb))
+	assert.False(t, m.canSkipDecodeHeaders)
+	assert.True(t, m.canSkipEncodeHeaders)
+}
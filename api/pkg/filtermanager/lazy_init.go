@@ -0,0 +1,94 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	pkgPlugins "mosn.io/htnn/api/pkg/plugins"
+)
+
+// lazyInitRetryInterval is the minimum interval between two failed Init, so that we won't
+// overwhelm the external service which is unavailable.
+var lazyInitRetryInterval = time.Second
+
+type lazyInitState struct {
+	initer pkgPlugins.Initer
+
+	done atomic.Bool
+
+	lock      sync.Mutex
+	lastErr   error
+	lastTried time.Time
+}
+
+func (s *lazyInitState) init() error {
+	if s.done.Load() {
+		return nil
+	}
+
+	// Concurrent requests will wait for the same Init
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.done.Load() {
+		return nil
+	}
+	if s.lastErr != nil && time.Since(s.lastTried) < lazyInitRetryInterval {
+		return s.lastErr
+	}
+
+	s.lastTried = time.Now()
+	s.lastErr = runInit(s.initer)
+	if s.lastErr == nil {
+		s.done.Store(true)
+	}
+	return s.lastErr
+}
+
+type lazyInitFilter struct {
+	api.Filter
+
+	name  string
+	state *lazyInitState
+}
+
+func (f *lazyInitFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	if err := f.state.init(); err != nil {
+		api.LogErrorf("error in plugin %s: %s", f.name, err)
+		return &api.LocalResponse{
+			Code: 500,
+		}
+	}
+	return f.Filter.DecodeHeaders(headers, endStream)
+}
+
+// NewLazyInitFactory returns a factory which runs the Init of the given configuration on the first request
+// which executes the plugin.
+func NewLazyInitFactory(plugin string, initer pkgPlugins.Initer, factory api.FilterFactory) api.FilterFactory {
+	state := &lazyInitState{
+		initer: initer,
+	}
+	return func(config interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+		return &lazyInitFilter{
+			Filter: factory(config, callbacks),
+			name:   plugin,
+			state:  state,
+		}
+	}
+}
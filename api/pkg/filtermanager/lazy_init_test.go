@@ -0,0 +1,131 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type initResult struct {
+	err error
+}
+
+type lazyInitConfig struct {
+	count  atomic.Int32
+	result atomic.Value
+}
+
+func (c *lazyInitConfig) Init(cb api.ConfigCallbackHandler) error {
+	c.count.Add(1)
+	// make the concurrent requests wait for the same Init
+	time.Sleep(10 * time.Millisecond)
+	if res, ok := c.result.Load().(initResult); ok {
+		return res.err
+	}
+	return nil
+}
+
+func runLazyInitRequest(config *filterManagerConfig) envoy.LocalResponse {
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	m := FilterManagerFactory(config, cb)
+	h := http.Header{}
+	hdr := envoy.NewRequestHeaderMap(h)
+	m.DecodeHeaders(hdr, true)
+	cb.WaitContinued()
+	return cb.LocalResponse()
+}
+
+func TestLazyInit(t *testing.T) {
+	conf := &lazyInitConfig{}
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:         "lazy",
+			Factory:      NewLazyInitFactory("lazy", conf, initFactory),
+			ParsedConfig: conf,
+		},
+	}
+	config.hasLazyInit = true
+
+	m := unwrapFilterManager(FilterManagerFactory(config, envoy.NewCAPIFilterCallbackHandler()))
+	assert.False(t, m.canSkipDecodeHeaders)
+
+	n := 10
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			r := runLazyInitRequest(config)
+			assert.Equal(t, 0, r.Code)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), conf.count.Load())
+}
+
+func TestLazyInitFailed(t *testing.T) {
+	interval := lazyInitRetryInterval
+	lazyInitRetryInterval = 50 * time.Millisecond
+	defer func() {
+		lazyInitRetryInterval = interval
+	}()
+
+	conf := &lazyInitConfig{}
+	conf.result.Store(initResult{err: errors.New("unavailable")})
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:         "lazy",
+			Factory:      NewLazyInitFactory("lazy", conf, initFactory),
+			ParsedConfig: conf,
+		},
+	}
+	config.hasLazyInit = true
+
+	r := runLazyInitRequest(config)
+	assert.Equal(t, 500, r.Code)
+	// don't retry too frequently
+	r = runLazyInitRequest(config)
+	assert.Equal(t, 500, r.Code)
+	assert.Equal(t, int32(1), conf.count.Load())
+
+	time.Sleep(60 * time.Millisecond)
+	conf.result.Store(initResult{err: errors.New("still unavailable")})
+	r = runLazyInitRequest(config)
+	assert.Equal(t, 500, r.Code)
+	assert.Equal(t, int32(2), conf.count.Load())
+
+	time.Sleep(60 * time.Millisecond)
+	// recovered
+	conf.result.Store(initResult{})
+	r = runLazyInitRequest(config)
+	assert.Equal(t, 0, r.Code)
+	assert.Equal(t, int32(3), conf.count.Load())
+	r = runLazyInitRequest(config)
+	assert.Equal(t, 0, r.Code)
+	assert.Equal(t, int32(3), conf.count.Load())
+}
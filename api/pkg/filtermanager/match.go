@@ -0,0 +1,44 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"slices"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+)
+
+func isMatched(fc *model.ParsedFilterConfig, headers api.HeaderMap) bool {
+	if len(fc.Match.Methods) > 0 {
+		method, _ := headers.Get(":method")
+		if !slices.Contains(fc.Match.Methods, method) {
+			return false
+		}
+	}
+	return fc.Matcher == nil || fc.Matcher.Match(headers)
+}
+
+func (m *filterManager) matchFilters(headers api.HeaderMap) {
+	for i, fc := range m.config.parsed {
+		if fc.Match == nil || isMatched(fc, headers) {
+			continue
+		}
+
+		api.LogDebugf("plugin %s is skipped as the request is not matched", fc.Name)
+		// Replace the filter instead of removing it, so that the index of filters is kept.
+		m.filters[i] = model.NewFilterWrapper(fc.Name, &api.PassThroughFilter{})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/pkg/headerops"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestMatchFilters(t *testing.T) {
+	match := &model.Match{
+		Methods: []string{"POST"},
+		Headers: []*headerops.Condition{
+			{Header: "x-tenant", Regex: "^a"},
+		},
+	}
+	matcher, err := headerops.CompileConditions(match.Headers)
+	require.NoError(t, err)
+
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:    "matched",
+			Factory: addReqFactory,
+			ParsedConfig: addReqConf{
+				hdrName: "x-htnn-matched",
+			},
+			Match:   match,
+			Matcher: matcher,
+		},
+		{
+			Name:    "no_match",
+			Factory: addReqFactory,
+			ParsedConfig: addReqConf{
+				hdrName: "x-htnn-route",
+			},
+		},
+	}
+	config.enableMatch = true
+
+	tests := []struct {
+		name    string
+		method  string
+		header  http.Header
+		matched bool
+	}{
+		{
+			name:    "matched",
+			method:  "POST",
+			header:  http.Header{"X-Tenant": []string{"ab"}},
+			matched: true,
+		},
+		{
+			name:   "method mismatched",
+			method: "GET",
+			header: http.Header{"X-Tenant": []string{"ab"}},
+		},
+		{
+			name:   "header mismatched",
+			method: "POST",
+			header: http.Header{"X-Tenant": []string{"b"}},
+		},
+		{
+			name:   "header missing",
+			method: "POST",
+			header: http.Header{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewCAPIFilterCallbackHandler()
+			m := unwrapFilterManager(FilterManagerFactory(config, cb))
+			tt.header.Set(":method", tt.method)
+			hdr := envoy.NewRequestHeaderMap(tt.header)
+			m.DecodeHeaders(hdr, true)
+			cb.WaitContinued()
+			_, ok := hdr.Get("x-htnn-matched")
+			assert.Equal(t, tt.matched, ok)
+			_, ok = hdr.Get("x-htnn-route")
+			assert.True(t, ok)
+			assert.Equal(t, 2, len(m.filters))
+		})
+	}
+}
@@ -0,0 +1,396 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mosn.io/htnn/api/pkg/circuitbreaker"
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+// The Envoy Go API doesn't support histogram yet, so we record the phase execution time
+// in the Go side and expose them in Prometheus text format.
+
+const (
+	defaultPhaseMetricsMaxRoutes = 100
+	// overflowRouteLabel is used when the number of routes exceeds the limit
+	overflowRouteLabel = "__other__"
+)
+
+var (
+	phaseMetricsBuckets = []float64{
+		0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5,
+	}
+
+	phaseMetricsRecorder atomic.Pointer[phaseMetrics]
+)
+
+type phaseMetricsKey struct {
+	plugin string
+	route  string
+	phase  string
+}
+
+type histogram struct {
+	// counts[i] is the number of observations which are less than or equal to phaseMetricsBuckets[i],
+	// and the last one is for +Inf
+	counts []atomic.Uint64
+	// sum in nanoseconds
+	sum atomic.Uint64
+}
+
+func newHistogram() *histogram {
+	return &histogram{
+		counts: make([]atomic.Uint64, len(phaseMetricsBuckets)+1),
+	}
+}
+
+func (h *histogram) observe(d time.Duration) {
+	v := d.Seconds()
+	i := sort.SearchFloat64s(phaseMetricsBuckets, v)
+	h.counts[i].Add(1)
+	h.sum.Add(uint64(d.Nanoseconds()))
+}
+
+type phaseMetrics struct {
+	maxRoutes int
+
+	lock       sync.RWMutex
+	routes     map[string]struct{}
+	histograms map[phaseMetricsKey]*histogram
+}
+
+func newPhaseMetrics(maxRoutes int) *phaseMetrics {
+	return &phaseMetrics{
+		maxRoutes:  maxRoutes,
+		routes:     map[string]struct{}{},
+		histograms: map[phaseMetricsKey]*histogram{},
+	}
+}
+
+// routeLabel returns the label of the given route. To control the cardinality, only the first
+// maxRoutes routes have their own labels, the others share the same one.
+func (m *phaseMetrics) routeLabel(route string) string {
+	m.lock.RLock()
+	_, ok := m.routes[route]
+	full := len(m.routes) >= m.maxRoutes
+	m.lock.RUnlock()
+	if ok {
+		return route
+	}
+	if full {
+		return overflowRouteLabel
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.routes[route]; !ok {
+		if len(m.routes) >= m.maxRoutes {
+			return overflowRouteLabel
+		}
+		m.routes[route] = struct{}{}
+	}
+	return route
+}
+
+func (m *phaseMetrics) observe(plugin, route, phase string, d time.Duration) {
+	key := phaseMetricsKey{plugin: plugin, route: route, phase: phase}
+
+	m.lock.RLock()
+	h, ok := m.histograms[key]
+	m.lock.RUnlock()
+	if !ok {
+		m.lock.Lock()
+		h, ok = m.histograms[key]
+		if !ok {
+			h = newHistogram()
+			m.histograms[key] = h
+		}
+		m.lock.Unlock()
+	}
+	h.observe(d)
+}
+
+func escapeLabelValue(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return strings.ReplaceAll(s, `"`, `\"`)
+}
+
+func (m *phaseMetrics) write(w io.Writer) error {
+	m.lock.RLock()
+	keys := make([]phaseMetricsKey, 0, len(m.histograms))
+	for k := range m.histograms {
+		keys = append(keys, k)
+	}
+	histograms := make(map[phaseMetricsKey]*histogram, len(m.histograms))
+	for k, h := range m.histograms {
+		histograms[k] = h
+	}
+	m.lock.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.plugin != b.plugin {
+			return a.plugin < b.plugin
+		}
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		return a.phase < b.phase
+	})
+
+	bw := bufio.NewWriter(w)
+	name := "htnn_plugin_phase_duration_seconds"
+	fmt.Fprintf(bw, "# HELP %s Time spent in each phase of the Go plugin.\n", name)
+	fmt.Fprintf(bw, "# TYPE %s histogram\n", name)
+	for _, k := range keys {
+		h := histograms[k]
+		labels := fmt.Sprintf(`plugin="%s",route="%s",phase="%s"`,
+			escapeLabelValue(k.plugin), escapeLabelValue(k.route), escapeLabelValue(k.phase))
+		var cumulative uint64
+		for i, le := range phaseMetricsBuckets {
+			cumulative += h.counts[i].Load()
+			fmt.Fprintf(bw, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels,
+				strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+		}
+		cumulative += h.counts[len(phaseMetricsBuckets)].Load()
+		fmt.Fprintf(bw, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, cumulative)
+		fmt.Fprintf(bw, "%s_sum{%s} %s\n", name, labels,
+			strconv.FormatFloat(time.Duration(h.sum.Load()).Seconds(), 'g', -1, 64))
+		fmt.Fprintf(bw, "%s_count{%s} %d\n", name, labels, cumulative)
+	}
+	return bw.Flush()
+}
+
+// EnablePhaseMetrics starts recording the time spent in each phase of each plugin per route.
+// At most maxRoutes routes are recorded separately, the others are recorded as "__other__".
+// The recorded data will be reset after calling this function.
+func EnablePhaseMetrics(maxRoutes int) {
+	if maxRoutes <= 0 {
+		maxRoutes = defaultPhaseMetricsMaxRoutes
+	}
+	phaseMetricsRecorder.Store(newPhaseMetrics(maxRoutes))
+}
+
+// DisablePhaseMetrics stops recording the phase execution time.
+func DisablePhaseMetrics() {
+	phaseMetricsRecorder.Store(nil)
+}
+
+func phaseMetricsEnabled() bool {
+	return phaseMetricsRecorder.Load() != nil
+}
+
+// WritePhaseMetrics writes the recorded phase execution time in Prometheus text format.
+func WritePhaseMetrics(w io.Writer) error {
+	m := phaseMetricsRecorder.Load()
+	if m == nil {
+		return nil
+	}
+	return m.write(w)
+}
+
+// PhaseMetricsHandler is a http.Handler which serves the recorded phase execution time.
+func PhaseMetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := WritePhaseMetrics(w); err != nil {
+			api.LogErrorf("failed to write phase metrics: %v", err)
+		}
+	})
+}
+
+var (
+	pluginMetricsLock    sync.Mutex
+	pluginMetricsWriters = map[string]func(w io.Writer) error{}
+)
+
+// RegisterMetrics registers the writer which writes the metrics of the given plugin in Prometheus
+// text format, so that the plugins can expose their own metrics via the metrics endpoint. The
+// name of the metrics should be prefixed with `htnn_` to avoid conflicts.
+func RegisterMetrics(plugin string, write func(w io.Writer) error) {
+	pluginMetricsLock.Lock()
+	pluginMetricsWriters[plugin] = write
+	pluginMetricsLock.Unlock()
+}
+
+// WritePluginMetrics writes the metrics registered by the plugins, sorted by the plugin name.
+func WritePluginMetrics(w io.Writer) error {
+	pluginMetricsLock.Lock()
+	names := make([]string, 0, len(pluginMetricsWriters))
+	for name := range pluginMetricsWriters {
+		names = append(names, name)
+	}
+	writers := make(map[string]func(w io.Writer) error, len(pluginMetricsWriters))
+	for name, write := range pluginMetricsWriters {
+		writers[name] = write
+	}
+	pluginMetricsLock.Unlock()
+
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writers[name](w); err != nil {
+			return fmt.Errorf("plugin %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func initPhaseMetrics() {
+	addr := os.Getenv("HTNN_PHASE_METRICS_ADDR")
+	if addr == "" {
+		return
+	}
+
+	maxRoutes := defaultPhaseMetricsMaxRoutes
+	envMaxRoutes := os.Getenv("HTNN_PHASE_METRICS_MAX_ROUTES")
+	if envMaxRoutes != "" {
+		n, err := strconv.Atoi(envMaxRoutes)
+		if err == nil && n > 0 {
+			maxRoutes = n
+		} else {
+			api.LogErrorf("invalid env var HTNN_PHASE_METRICS_MAX_ROUTES: %s", envMaxRoutes)
+		}
+	}
+	EnablePhaseMetrics(maxRoutes)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := WritePhaseMetrics(w); err != nil {
+			api.LogErrorf("failed to write phase metrics: %v", err)
+			return
+		}
+		if err := WriteWorkerPoolMetrics(w); err != nil {
+			api.LogErrorf("failed to write worker pool metrics: %v", err)
+			return
+		}
+		if err := circuitbreaker.WriteMetrics(w); err != nil {
+			api.LogErrorf("failed to write circuit breaker metrics: %v", err)
+			return
+		}
+		if err := WriteMirrorMetrics(w); err != nil {
+			api.LogErrorf("failed to write mirror metrics: %v", err)
+			return
+		}
+		if err := WritePluginMetrics(w); err != nil {
+			api.LogErrorf("failed to write plugin metrics: %v", err)
+		}
+	}))
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil {
+			api.LogErrorf("failed to serve phase metrics on %s: %v", addr, err)
+		}
+	}()
+}
+
+func init() {
+	initPhaseMetrics()
+}
+
+type phaseMetricsFilter struct {
+	// Don't inherit the PassThroughFilter
+	name      string
+	internal  api.Filter
+	callbacks api.FilterCallbackHandler
+	metrics   *phaseMetrics
+	route     string
+	routeSet  bool
+}
+
+func NewPhaseMetricsFilter(name string, internal api.Filter, callbacks api.FilterCallbackHandler) api.Filter {
+	return &phaseMetricsFilter{
+		name:      name,
+		internal:  internal,
+		callbacks: callbacks,
+		metrics:   phaseMetricsRecorder.Load(),
+	}
+}
+
+func (f *phaseMetricsFilter) record(start time.Time, phase string) {
+	if f.metrics == nil {
+		return
+	}
+	duration := time.Since(start)
+	if !f.routeSet {
+		f.route = f.metrics.routeLabel(f.callbacks.StreamInfo().GetRouteName())
+		f.routeSet = true
+	}
+	f.metrics.observe(f.name, f.route, phase, duration)
+}
+
+func (f *phaseMetricsFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	defer f.record(time.Now(), "DecodeHeaders")
+	return f.internal.DecodeHeaders(headers, endStream)
+}
+
+func (f *phaseMetricsFilter) DecodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	defer f.record(time.Now(), "DecodeData")
+	return f.internal.DecodeData(data, endStream)
+}
+
+func (f *phaseMetricsFilter) DecodeTrailers(trailers api.RequestTrailerMap) api.ResultAction {
+	defer f.record(time.Now(), "DecodeTrailers")
+	return f.internal.DecodeTrailers(trailers)
+}
+
+func (f *phaseMetricsFilter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	defer f.record(time.Now(), "EncodeHeaders")
+	return f.internal.EncodeHeaders(headers, endStream)
+}
+
+func (f *phaseMetricsFilter) EncodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	defer f.record(time.Now(), "EncodeData")
+	return f.internal.EncodeData(data, endStream)
+}
+
+func (f *phaseMetricsFilter) EncodeTrailers(trailers api.ResponseTrailerMap) api.ResultAction {
+	defer f.record(time.Now(), "EncodeTrailers")
+	return f.internal.EncodeTrailers(trailers)
+}
+
+func (f *phaseMetricsFilter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	// The OnLog phase doesn't contribute to the request duration, so we don't need to count it
+	f.internal.OnLog(reqHeaders, reqTrailers, respHeaders, respTrailers)
+}
+
+func (f *phaseMetricsFilter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	defer f.record(time.Now(), "DecodeRequest")
+	return f.internal.DecodeRequest(headers, data, trailers)
+}
+
+func (f *phaseMetricsFilter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	defer f.record(time.Now(), "EncodeResponse")
+	return f.internal.EncodeResponse(headers, data, trailers)
+}
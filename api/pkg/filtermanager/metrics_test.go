@@ -0,0 +1,114 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestPhaseMetricsFilter(t *testing.T) {
+	EnablePhaseMetrics(2)
+	defer DisablePhaseMetrics()
+
+	route := "r1"
+	patches := gomonkey.ApplyMethodFunc(&envoy.StreamInfo{}, "GetRouteName", func() string {
+		return route
+	})
+	defer patches.Reset()
+
+	raw := &api.PassThroughFilter{}
+	patches.ApplyMethodFunc(raw, "DecodeData", func(data api.BufferInstance, endStream bool) api.ResultAction {
+		time.Sleep(2 * time.Millisecond)
+		return api.Continue
+	})
+
+	for _, r := range []string{"r1", "r2", "r3", "r4"} {
+		route = r
+		cb := envoy.NewFilterCallbackHandler()
+		f := NewPhaseMetricsFilter("one", raw, cb)
+		f.DecodeHeaders(nil, false)
+		f.DecodeData(nil, true)
+		f.EncodeHeaders(nil, true)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePhaseMetrics(&buf))
+	out := buf.String()
+	t.Logf("get metrics:\n%s", out) // for debug when test failed
+
+	assert.Contains(t, out, "# TYPE htnn_plugin_phase_duration_seconds histogram\n")
+	assert.Contains(t, out, `htnn_plugin_phase_duration_seconds_count{plugin="one",route="r1",phase="DecodeHeaders"} 1`)
+	assert.Contains(t, out, `htnn_plugin_phase_duration_seconds_count{plugin="one",route="r2",phase="EncodeHeaders"} 1`)
+	assert.NotContains(t, out, `route="r3"`)
+	// routes exceed the limit are aggregated
+	assert.Contains(t, out, `htnn_plugin_phase_duration_seconds_count{plugin="one",route="__other__",phase="DecodeData"} 2`)
+	assert.Contains(t, out, `htnn_plugin_phase_duration_seconds_bucket{plugin="one",route="r1",phase="DecodeData",le="0.001"} 0`)
+	assert.Contains(t, out, `htnn_plugin_phase_duration_seconds_bucket{plugin="one",route="r1",phase="DecodeData",le="+Inf"} 1`)
+	assert.False(t, strings.Contains(out, "OnLog"))
+}
+
+func TestPhaseMetricsHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	PhaseMetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, 200, rec.Code)
+	assert.Equal(t, "", rec.Body.String())
+
+	EnablePhaseMetrics(0)
+	defer DisablePhaseMetrics()
+	phaseMetricsRecorder.Load().observe("a\"b", "route", "DecodeHeaders", time.Millisecond)
+
+	rec = httptest.NewRecorder()
+	PhaseMetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Contains(t, rec.Body.String(), `htnn_plugin_phase_duration_seconds_sum{plugin="a\"b",route="route",phase="DecodeHeaders"} 0.001`)
+}
+
+func TestPluginMetrics(t *testing.T) {
+	RegisterMetrics("b", func(w io.Writer) error {
+		_, err := io.WriteString(w, "htnn_b_total 2\n")
+		return err
+	})
+	RegisterMetrics("a", func(w io.Writer) error {
+		_, err := io.WriteString(w, "htnn_a_total 1\n")
+		return err
+	})
+	defer func() {
+		pluginMetricsLock.Lock()
+		delete(pluginMetricsWriters, "a")
+		delete(pluginMetricsWriters, "b")
+		pluginMetricsLock.Unlock()
+	}()
+
+	var buf bytes.Buffer
+	require.NoError(t, WritePluginMetrics(&buf))
+	assert.Equal(t, "htnn_a_total 1\nhtnn_b_total 2\n", buf.String())
+
+	RegisterMetrics("b", func(w io.Writer) error {
+		return errors.New("ouch")
+	})
+	assert.ErrorContains(t, WritePluginMetrics(&buf), "plugin b: ouch")
+}
@@ -0,0 +1,193 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+var mirrorClient = &http.Client{
+	Timeout: 10 * time.Second,
+	// The response of the mirrored request is discarded, so there is no need to follow the redirect
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// maxPendingMirroredRequests limits the mirrored requests in flight. As each of them holds a
+// goroutine and a copy of the body until the mirror target responds, the new mirrored requests are
+// dropped when the limit is reached, so that a slow mirror target can't exhaust the memory.
+const maxPendingMirroredRequests = 256
+
+var mirrorSlots = make(chan struct{}, maxPendingMirroredRequests)
+
+type mirrorStats struct {
+	requests atomic.Uint64
+	errors   atomic.Uint64
+	dropped  atomic.Uint64
+}
+
+// mirrorStatsByAddress records the result of mirrored requests per address. The number of addresses
+// is bounded by the configuration, so the entries are never removed.
+var mirrorStatsByAddress sync.Map
+
+func getMirrorStats(address string) *mirrorStats {
+	if v, ok := mirrorStatsByAddress.Load(address); ok {
+		return v.(*mirrorStats)
+	}
+	v, _ := mirrorStatsByAddress.LoadOrStore(address, &mirrorStats{})
+	return v.(*mirrorStats)
+}
+
+// WriteMirrorMetrics writes the metrics of the mirrored requests in Prometheus text format.
+func WriteMirrorMetrics(w io.Writer) error {
+	type entry struct {
+		address string
+		stats   *mirrorStats
+	}
+	var list []entry
+	mirrorStatsByAddress.Range(func(k, v any) bool {
+		list = append(list, entry{address: k.(string), stats: v.(*mirrorStats)})
+		return true
+	})
+	if len(list) == 0 {
+		return nil
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].address < list[j].address
+	})
+
+	bw := bufio.NewWriter(w)
+	writeCounter := func(name, help string, get func(s *mirrorStats) uint64) {
+		fmt.Fprintf(bw, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(bw, "# TYPE %s counter\n", name)
+		for _, e := range list {
+			// the label is kept as `cluster` for the compatibility of the existing dashboards
+			fmt.Fprintf(bw, "%s{cluster=\"%s\"} %d\n", name, escapeLabelValue(e.address), get(e.stats))
+		}
+	}
+	writeCounter("htnn_mirror_requests_total", "Number of mirrored requests.",
+		func(s *mirrorStats) uint64 { return s.requests.Load() })
+	writeCounter("htnn_mirror_errors_total", "Number of mirrored requests which failed to be sent.",
+		func(s *mirrorStats) uint64 { return s.errors.Load() })
+	writeCounter("htnn_mirror_dropped_total", "Number of mirrored requests dropped as too many of them are pending.",
+		func(s *mirrorStats) uint64 { return s.dropped.Load() })
+	return bw.Flush()
+}
+
+type mirroredRequest struct {
+	address string
+	method  string
+	url     string
+	host    string
+	header  http.Header
+	body    []byte
+}
+
+func newMirroredRequest(address string, headers api.RequestHeaderMap, body api.BufferInstance) *mirroredRequest {
+	base := address
+	if !strings.Contains(base, "://") {
+		base = "http://" + base
+	}
+
+	req := &mirroredRequest{
+		address: address,
+		method:  headers.Method(),
+		url:     strings.TrimSuffix(base, "/") + headers.Path(),
+		host:    headers.Host() + "-shadow",
+		header:  http.Header{},
+	}
+	headers.Range(func(k, v string) bool {
+		if strings.HasPrefix(k, ":") || k == "host" {
+			return true
+		}
+		req.header.Add(k, v)
+		return true
+	})
+	if body != nil && body.Len() > 0 {
+		// copy the body, as the buffer will be reused by Envoy
+		req.body = append([]byte{}, body.Bytes()...)
+	}
+	return req
+}
+
+func (r *mirroredRequest) send() {
+	stats := getMirrorStats(r.address)
+	stats.requests.Add(1)
+
+	var body io.Reader
+	if len(r.body) > 0 {
+		body = bytes.NewReader(r.body)
+	}
+	req, err := http.NewRequest(r.method, r.url, body)
+	if err != nil {
+		api.LogErrorf("failed to create mirrored request: %v", err)
+		stats.errors.Add(1)
+		return
+	}
+	req.Header = r.header
+	req.Host = r.host
+
+	resp, err := mirrorClient.Do(req)
+	if err != nil {
+		api.LogInfof("failed to send mirrored request to %s: %v", r.url, err)
+		stats.errors.Add(1)
+		return
+	}
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
+
+func (cb *filterManagerCallbackHandler) MirrorRequest(address string, headers api.RequestHeaderMap, body api.BufferInstance) {
+	if headers == nil {
+		api.LogErrorf("mirror request with nil headers")
+		return
+	}
+
+	select {
+	case mirrorSlots <- struct{}{}:
+	default:
+		api.LogDebugf("drop mirrored request to %s as too many of them are pending", address)
+		getMirrorStats(address).dropped.Add(1)
+		return
+	}
+
+	req := newMirroredRequest(address, headers, body)
+	go func() {
+		defer func() {
+			<-mirrorSlots
+		}()
+		defer func() {
+			if p := recover(); p != nil {
+				api.LogErrorf("panic: %v\n%s", p, debug.Stack())
+			}
+		}()
+
+		req.send()
+	}()
+}
@@ -0,0 +1,135 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestMirrorRequest(t *testing.T) {
+	type mirrored struct {
+		method string
+		path   string
+		host   string
+		header http.Header
+		body   string
+	}
+	ch := make(chan mirrored, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ch <- mirrored{
+			method: r.Method,
+			path:   r.URL.RequestURI(),
+			host:   r.Host,
+			header: r.Header,
+			body:   string(body),
+		}
+		w.WriteHeader(500)
+	}))
+	defer srv.Close()
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":method":    []string{"POST"},
+		":path":      []string{"/echo?a=1"},
+		":authority": []string{"example.com"},
+		"x-tenant":   []string{"alice"},
+	})
+	headers := &filterManagerRequestHeaderMap{RequestHeaderMap: hdr}
+	body := envoy.NewBufferInstance([]byte("hello"))
+
+	cb := &filterManagerCallbackHandler{}
+	cb.MirrorRequest(strings.TrimPrefix(srv.URL, "http://"), headers, body)
+	// modify the original request after mirroring
+	body.SetString("changed")
+	headers.Set("x-tenant", "bob")
+
+	select {
+	case req := <-ch:
+		assert.Equal(t, "POST", req.method)
+		assert.Equal(t, "/echo?a=1", req.path)
+		assert.Equal(t, "example.com-shadow", req.host)
+		assert.Equal(t, "alice", req.header.Get("x-tenant"))
+		assert.Equal(t, "hello", req.body)
+	case <-time.After(3 * time.Second):
+		t.Fatal("mirrored request not received")
+	}
+}
+
+func TestMirrorMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(503)
+	}))
+	defer srv.Close()
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":method":    []string{"GET"},
+		":path":      []string{"/"},
+		":authority": []string{"example.com"},
+	})
+	ok := strings.TrimPrefix(srv.URL, "http://")
+	// the response status doesn't matter
+	newMirroredRequest(ok, hdr, nil).send()
+	newMirroredRequest(ok, hdr, nil).send()
+	newMirroredRequest("127.0.0.1:1", hdr, nil).send()
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteMirrorMetrics(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "# TYPE htnn_mirror_requests_total counter\n")
+	assert.Contains(t, out, fmt.Sprintf("htnn_mirror_requests_total{cluster=\"%s\"} 2\n", ok))
+	assert.Contains(t, out, fmt.Sprintf("htnn_mirror_errors_total{cluster=\"%s\"} 0\n", ok))
+	assert.Contains(t, out, "htnn_mirror_requests_total{cluster=\"127.0.0.1:1\"} 1\n")
+	assert.Contains(t, out, "htnn_mirror_errors_total{cluster=\"127.0.0.1:1\"} 1\n")
+}
+
+func TestMirrorRequestDropped(t *testing.T) {
+	block := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-block
+	}))
+	defer srv.Close()
+	defer close(block)
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":method":    []string{"GET"},
+		":path":      []string{"/"},
+		":authority": []string{"example.com"},
+	})
+	headers := &filterManagerRequestHeaderMap{RequestHeaderMap: hdr}
+	addr := strings.TrimPrefix(srv.URL, "http://")
+	cb := &filterManagerCallbackHandler{}
+	for i := 0; i < maxPendingMirroredRequests+2; i++ {
+		cb.MirrorRequest(addr, headers, nil)
+	}
+
+	stats := getMirrorStats(addr)
+	assert.Equal(t, uint64(2), stats.dropped.Load())
+	assert.Equal(t, maxPendingMirroredRequests, len(mirrorSlots))
+	var buf bytes.Buffer
+	require.NoError(t, WriteMirrorMetrics(&buf))
+	assert.Contains(t, buf.String(), fmt.Sprintf("htnn_mirror_dropped_total{cluster=\"%s\"} 2\n", addr))
+}
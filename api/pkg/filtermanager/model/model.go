@@ -22,11 +22,32 @@ import (
 	"time"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/headerops"
 )
 
 type FilterConfig struct {
-	Name   string      `json:"name,omitempty"`
-	Config interface{} `json:"config,omitempty"`
+	Name     string      `json:"name,omitempty"`
+	Config   interface{} `json:"config,omitempty"`
+	Sampling *Sampling   `json:"sampling,omitempty"`
+	// HeaderOps manipulates the headers around the plugin
+	HeaderOps *headerops.Config `json:"headerOps,omitempty"`
+	// Match makes the plugin only run for the requests which match it
+	Match *Match `json:"match,omitempty"`
+	// MergeStrategy overrides the default merge strategy of the plugin when merging with the
+	// configuration from the less specific level
+	MergeStrategy string `json:"mergeStrategy,omitempty"`
+}
+
+// Sampling controls the percentage of requests that the plugin will run for
+type Sampling struct {
+	Percentage int32 `json:"percentage"`
+}
+
+// Match contains the conditions of the requests that the plugin will run for. The request
+// matches when its method is one of the Methods if specified, and all the conditions in Headers are met.
+type Match struct {
+	Methods []string               `json:"methods,omitempty"`
+	Headers []*headerops.Condition `json:"headers,omitempty"`
 }
 
 type ParsedFilterConfig struct {
@@ -35,6 +56,15 @@ type ParsedFilterConfig struct {
 	InitOnce     sync.Once
 	InitFailure  error
 	Factory      api.FilterFactory
+	Sampling     *Sampling
+	HeaderOps    *headerops.HeaderOps
+	Match        *Match
+	// Matcher is compiled from the headers in the Match
+	Matcher *headerops.Matcher
+	// RawConfig is the configuration before parsing, which is used to merge with the configuration
+	// from the less specific level
+	RawConfig     interface{}
+	MergeStrategy string
 }
 
 type FilterWrapper struct {
@@ -0,0 +1,90 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"hash/fnv"
+	"sync/atomic"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+)
+
+// samplingCounter is used when there is no ID to make the decision. Envoy generates x-request-id
+// by default, so it's rare to use this counter.
+var samplingCounter atomic.Uint32
+
+// samplingID returns the ID used to make the sampling decision. We prefer the trace ID so that
+// the decision is consistent in the whole trace.
+func samplingID(headers api.HeaderMap) string {
+	// traceparent: version-traceid-parentid-flags
+	if tp, ok := headers.Get("traceparent"); ok && len(tp) >= 35 && tp[2] == '-' {
+		return tp[3:35]
+	}
+	if id, ok := headers.Get("x-b3-traceid"); ok && id != "" {
+		return id
+	}
+	if id, ok := headers.Get("x-request-id"); ok && id != "" {
+		return id
+	}
+	return ""
+}
+
+// isSampled decides if the plugin runs for the request. The name of the plugin is mixed into the
+// hash, so that the decisions of the plugins are independent of each other.
+func isSampled(name string, id string, percentage int32) bool {
+	if percentage >= 100 {
+		return true
+	}
+	if percentage <= 0 {
+		return false
+	}
+
+	var n uint32
+	if id == "" {
+		n = samplingCounter.Add(1)
+	} else {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(name))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(id))
+		n = h.Sum32()
+	}
+	return n%100 < uint32(percentage)
+}
+
+func (m *filterManager) samplingOf(name string) *model.Sampling {
+	for _, fc := range m.config.parsed {
+		if fc.Name == name {
+			return fc.Sampling
+		}
+	}
+	return nil
+}
+
+// sampleFilters replaces the plugins which are not sampled with PassThroughFilter. The plugins are
+// looked up by name, so it also works for the plugins merged from the consumer.
+func (m *filterManager) sampleFilters(filters []*model.FilterWrapper) {
+	for i, f := range filters {
+		sampling := m.samplingOf(f.Name)
+		if sampling == nil || isSampled(f.Name, m.samplingID, sampling.Percentage) {
+			continue
+		}
+
+		api.LogDebugf("plugin %s is skipped as the request is not sampled", f.Name)
+		// Replace the filter instead of removing it, so that the index of filters is kept.
+		filters[i] = model.NewFilterWrapper(f.Name, &api.PassThroughFilter{})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	internalConsumer "mosn.io/htnn/api/internal/consumer"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestSamplingID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		id     string
+	}{
+		{
+			name: "traceparent",
+			header: http.Header{
+				"Traceparent":  []string{"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+				"X-Request-Id": []string{"req"},
+			},
+			id: "0af7651916cd43dd8448eb211c80319c",
+		},
+		{
+			name: "bad traceparent",
+			header: http.Header{
+				"Traceparent":  []string{"00"},
+				"X-Request-Id": []string{"req"},
+			},
+			id: "req",
+		},
+		{
+			name: "b3",
+			header: http.Header{
+				"X-B3-Traceid": []string{"80f198ee56343ba864fe8b2a57d3eff7"},
+				"X-Request-Id": []string{"req"},
+			},
+			id: "80f198ee56343ba864fe8b2a57d3eff7",
+		},
+		{
+			name:   "no id",
+			header: http.Header{},
+			id:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.id, samplingID(envoy.NewRequestHeaderMap(tt.header)))
+		})
+	}
+}
+
+func TestIsSampled(t *testing.T) {
+	assert.True(t, isSampled("plugin", "id", 100))
+	assert.False(t, isSampled("plugin", "id", 0))
+
+	n := 0
+	both := 0
+	for i := 0; i < 10000; i++ {
+		id := strconv.Itoa(i)
+		sampled := isSampled("plugin", id, 30)
+		// the decision is deterministic
+		assert.Equal(t, sampled, isSampled("plugin", id, 30))
+		if sampled {
+			n++
+			// the decisions of different plugins are independent
+			if isSampled("another", id, 50) {
+				both++
+			}
+		}
+	}
+	assert.InDelta(t, 3000, n, 300)
+	assert.InDelta(t, 1500, both, 200)
+
+	n = 0
+	for i := 0; i < 100; i++ {
+		if isSampled("plugin", "", 30) {
+			n++
+		}
+	}
+	assert.Equal(t, 30, n)
+}
+
+func TestSampleFilters(t *testing.T) {
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:    "never",
+			Factory: addReqFactory,
+			ParsedConfig: addReqConf{
+				hdrName: "x-htnn-never",
+			},
+			Sampling: &model.Sampling{Percentage: 0},
+		},
+		{
+			Name:    "always",
+			Factory: addReqFactory,
+			ParsedConfig: addReqConf{
+				hdrName: "x-htnn-always",
+			},
+			Sampling: &model.Sampling{Percentage: 100},
+		},
+		{
+			Name:    "no_sampling",
+			Factory: addReqFactory,
+			ParsedConfig: addReqConf{
+				hdrName: "x-htnn-route",
+			},
+		},
+	}
+	config.enableSampling = true
+
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	m := unwrapFilterManager(FilterManagerFactory(config, cb))
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		"X-Request-Id": []string{"id"},
+	})
+	m.DecodeHeaders(hdr, true)
+	cb.WaitContinued()
+	_, ok := hdr.Get("x-htnn-never")
+	assert.False(t, ok)
+	_, ok = hdr.Get("x-htnn-always")
+	assert.True(t, ok)
+	_, ok = hdr.Get("x-htnn-route")
+	assert.True(t, ok)
+	assert.Equal(t, 3, len(m.filters))
+}
+
+func TestSampleFiltersFromConsumer(t *testing.T) {
+	config := initFilterManagerConfig("ns")
+	config.consumerFiltersEndAt = 1
+	c := &internalConsumer.Consumer{
+		FilterConfigs: map[string]*model.ParsedFilterConfig{
+			"2_add_req": {
+				Name:    "2_add_req",
+				Factory: addReqFactory,
+				ParsedConfig: addReqConf{
+					hdrName: "x-htnn-consumer",
+				},
+			},
+		},
+	}
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:    "1_set_consumer",
+			Factory: setConsumerFactory,
+			ParsedConfig: setConsumerConf{
+				Consumers: map[string]*internalConsumer.Consumer{"c": c},
+			},
+		},
+		{
+			Name:    "2_add_req",
+			Factory: addReqFactory,
+			ParsedConfig: addReqConf{
+				hdrName: "x-htnn-route",
+			},
+			Sampling: &model.Sampling{Percentage: 0},
+		},
+	}
+	config.enableSampling = true
+
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	m := unwrapFilterManager(FilterManagerFactory(config, cb))
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		"Consumer":     []string{"c"},
+		"X-Request-Id": []string{"id"},
+	})
+	m.DecodeHeaders(hdr, true)
+	cb.WaitContinued()
+	// the plugin which is not sampled doesn't run with the consumer's config
+	_, ok := hdr.Get("x-htnn-consumer")
+	assert.False(t, ok)
+	_, ok = hdr.Get("x-htnn-route")
+	assert.False(t, ok)
+	assert.Equal(t, 2, len(m.filters))
+}
@@ -0,0 +1,291 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+// maxWebSocketMessageSize is the max size of the assembled message we inspect. The connection will
+// be closed if the message is larger than it.
+var maxWebSocketMessageSize = 4 * 1024 * 1024
+
+const (
+	webSocketOpcodeContinuation = 0
+
+	webSocketFinBit  = 0x80
+	webSocketRsv1Bit = 0x40
+	webSocketMaskBit = 0x80
+)
+
+var errIncompleteWebSocketFrame = errors.New("incomplete WebSocket frame")
+
+func isWebSocketUpgrade(headers api.RequestHeaderMap) bool {
+	// Envoy converts the HTTP/2 extended CONNECT to the HTTP/1.1 upgrade style headers
+	upgrade, _ := headers.Get("upgrade")
+	return strings.EqualFold(upgrade, "websocket")
+}
+
+// webSocketSkipMethods marks the methods required by the WebSocket hooks as not skippable
+func webSocketSkipMethods(f api.Filter, canSkipMethod map[string]bool) {
+	if _, ok := f.(api.WebSocketUpgradeFilter); ok {
+		canSkipMethod["DecodeHeaders"] = false
+	}
+	if _, ok := f.(api.WebSocketMessageFilter); ok {
+		canSkipMethod["DecodeHeaders"] = false
+		canSkipMethod["DecodeData"] = false
+		canSkipMethod["EncodeHeaders"] = false
+		canSkipMethod["EncodeData"] = false
+	}
+}
+
+type webSocketFrame struct {
+	fin        bool
+	compressed bool
+	opcode     api.WebSocketOpcode
+	payload    []byte
+}
+
+func decodeWebSocketFrame(data []byte) (*webSocketFrame, int, error) {
+	if len(data) < 2 {
+		return nil, 0, errIncompleteWebSocketFrame
+	}
+
+	f := &webSocketFrame{
+		fin:        data[0]&webSocketFinBit != 0,
+		compressed: data[0]&webSocketRsv1Bit != 0,
+		opcode:     api.WebSocketOpcode(data[0] & 0x0f),
+	}
+	masked := data[1]&webSocketMaskBit != 0
+	size := uint64(data[1] & 0x7f)
+	n := 2
+	switch size {
+	case 126:
+		if len(data) < n+2 {
+			return nil, 0, errIncompleteWebSocketFrame
+		}
+		size = uint64(binary.BigEndian.Uint16(data[n:]))
+		n += 2
+	case 127:
+		if len(data) < n+8 {
+			return nil, 0, errIncompleteWebSocketFrame
+		}
+		size = binary.BigEndian.Uint64(data[n:])
+		n += 8
+	}
+	if size > uint64(maxWebSocketMessageSize) {
+		return nil, 0, fmt.Errorf("WebSocket frame size %d exceeds the limit %d", size, maxWebSocketMessageSize)
+	}
+
+	var mask []byte
+	if masked {
+		if len(data) < n+4 {
+			return nil, 0, errIncompleteWebSocketFrame
+		}
+		mask = data[n : n+4]
+		n += 4
+	}
+
+	end := n + int(size)
+	if len(data) < end {
+		return nil, 0, errIncompleteWebSocketFrame
+	}
+	f.payload = make([]byte, size)
+	copy(f.payload, data[n:end])
+	if masked {
+		for i := range f.payload {
+			f.payload[i] ^= mask[i%4]
+		}
+	}
+	return f, end, nil
+}
+
+// webSocketDecoder assembles the WebSocket messages from the data received in multiple chunks
+type webSocketDecoder struct {
+	fromDownstream bool
+
+	buf []byte
+	// msg is the fragmented message which is not finished yet
+	msg *api.WebSocketMessage
+}
+
+func (d *webSocketDecoder) onFrame(f *webSocketFrame) (*api.WebSocketMessage, error) {
+	if f.opcode >= api.WebSocketOpcodeClose {
+		// control frame can be injected in the middle of a fragmented message
+		if !f.fin {
+			return nil, errors.New("fragmented WebSocket control frame")
+		}
+		return &api.WebSocketMessage{
+			Opcode:         f.opcode,
+			Payload:        f.payload,
+			FromDownstream: d.fromDownstream,
+		}, nil
+	}
+
+	if f.opcode == webSocketOpcodeContinuation {
+		if d.msg == nil {
+			return nil, errors.New("unexpected WebSocket continuation frame")
+		}
+		if len(d.msg.Payload)+len(f.payload) > maxWebSocketMessageSize {
+			return nil, fmt.Errorf("WebSocket message size exceeds the limit %d", maxWebSocketMessageSize)
+		}
+		d.msg.Payload = append(d.msg.Payload, f.payload...)
+	} else {
+		if d.msg != nil {
+			return nil, errors.New("unfinished fragmented WebSocket message")
+		}
+		d.msg = &api.WebSocketMessage{
+			Opcode:         f.opcode,
+			Payload:        f.payload,
+			Compressed:     f.compressed,
+			FromDownstream: d.fromDownstream,
+		}
+	}
+
+	if !f.fin {
+		return nil, nil
+	}
+	msg := d.msg
+	d.msg = nil
+	return msg, nil
+}
+
+// decode appends the data to the buffered one and returns the complete messages
+func (d *webSocketDecoder) decode(data []byte) ([]*api.WebSocketMessage, error) {
+	d.buf = append(d.buf, data...)
+
+	var msgs []*api.WebSocketMessage
+	consumed := 0
+	for {
+		f, n, err := decodeWebSocketFrame(d.buf[consumed:])
+		if err != nil {
+			if errors.Is(err, errIncompleteWebSocketFrame) {
+				break
+			}
+			return nil, err
+		}
+		consumed += n
+
+		msg, err := d.onFrame(f)
+		if err != nil {
+			return nil, err
+		}
+		if msg != nil {
+			msgs = append(msgs, msg)
+		}
+	}
+	d.buf = append(d.buf[:0], d.buf[consumed:]...)
+	return msgs, nil
+}
+
+type webSocketFilter struct {
+	api.Filter
+
+	name          string
+	upgradeFilter api.WebSocketUpgradeFilter
+	messageFilter api.WebSocketMessageFilter
+
+	reqDecoder *webSocketDecoder
+	rspDecoder *webSocketDecoder
+}
+
+// NewWebSocketFilter wraps the filter which implements the WebSocket hooks, so that the hooks are called
+// during processing the WebSocket upgrade request. The filter is returned as it is if it doesn't implement them.
+func NewWebSocketFilter(name string, f api.Filter) api.Filter {
+	hooks := f
+	if lf, ok := f.(*lazyInitFilter); ok {
+		hooks = lf.Filter
+	}
+	uf, _ := hooks.(api.WebSocketUpgradeFilter)
+	mf, _ := hooks.(api.WebSocketMessageFilter)
+	if uf == nil && mf == nil {
+		return f
+	}
+	return &webSocketFilter{
+		Filter:        f,
+		name:          name,
+		upgradeFilter: uf,
+		messageFilter: mf,
+	}
+}
+
+func (f *webSocketFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	res := f.Filter.DecodeHeaders(headers, endStream)
+	if res != api.Continue || !isWebSocketUpgrade(headers) {
+		return res
+	}
+
+	if f.upgradeFilter != nil {
+		res = f.upgradeFilter.OnWebSocketUpgrade(headers)
+		if res != api.Continue {
+			return res
+		}
+	}
+	if f.messageFilter != nil {
+		f.reqDecoder = &webSocketDecoder{fromDownstream: true}
+	}
+	return res
+}
+
+func (f *webSocketFilter) handleMessages(decoder *webSocketDecoder, data api.BufferInstance) api.ResultAction {
+	if data == nil || data.Len() == 0 {
+		return api.Continue
+	}
+
+	msgs, err := decoder.decode(data.Bytes())
+	if err != nil {
+		api.LogErrorf("failed to decode WebSocket message in plugin %s: %v", f.name, err)
+		return &api.LocalResponse{Code: 400}
+	}
+	for _, msg := range msgs {
+		res := f.messageFilter.OnWebSocketMessage(msg)
+		if res != api.Continue {
+			return res
+		}
+	}
+	return api.Continue
+}
+
+func (f *webSocketFilter) DecodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	res := f.Filter.DecodeData(data, endStream)
+	if res != api.Continue || f.reqDecoder == nil {
+		return res
+	}
+	return f.handleMessages(f.reqDecoder, data)
+}
+
+func (f *webSocketFilter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	res := f.Filter.EncodeHeaders(headers, endStream)
+	if res != api.Continue || f.reqDecoder == nil {
+		return res
+	}
+	if status, _ := headers.Status(); status == 101 {
+		f.rspDecoder = &webSocketDecoder{}
+	}
+	return res
+}
+
+func (f *webSocketFilter) EncodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	res := f.Filter.EncodeData(data, endStream)
+	if res != api.Continue || f.rspDecoder == nil {
+		return res
+	}
+	return f.handleMessages(f.rspDecoder, data)
+}
@@ -0,0 +1,218 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func encodeWebSocketFrame(fin bool, opcode byte, payload []byte, mask []byte) []byte {
+	b0 := opcode
+	if fin {
+		b0 |= webSocketFinBit
+	}
+	data := []byte{b0}
+
+	var b1 byte
+	if mask != nil {
+		b1 = webSocketMaskBit
+	}
+	switch {
+	case len(payload) < 126:
+		data = append(data, b1|byte(len(payload)))
+	case len(payload) <= 0xffff:
+		data = append(data, b1|126)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(payload)))
+	default:
+		data = append(data, b1|127)
+		data = binary.BigEndian.AppendUint64(data, uint64(len(payload)))
+	}
+
+	if mask == nil {
+		return append(data, payload...)
+	}
+	data = append(data, mask...)
+	for i, c := range payload {
+		data = append(data, c^mask[i%4])
+	}
+	return data
+}
+
+func TestWebSocketDecoder(t *testing.T) {
+	mask := []byte{1, 2, 3, 4}
+	large := bytes.Repeat([]byte("a"), 300)
+
+	var data []byte
+	data = append(data, encodeWebSocketFrame(true, 1, []byte("hello"), mask)...)
+	data = append(data, encodeWebSocketFrame(false, 2, []byte("frag"), mask)...)
+	// control frame in the middle of a fragmented message
+	data = append(data, encodeWebSocketFrame(true, 9, []byte("ping"), mask)...)
+	data = append(data, encodeWebSocketFrame(true, 0, []byte("ment"), mask)...)
+	data = append(data, encodeWebSocketFrame(true, 2, large, nil)...)
+
+	d := &webSocketDecoder{fromDownstream: true}
+	var msgs []*api.WebSocketMessage
+	// feed the data byte by byte to cover the incomplete frame
+	for i := range data {
+		res, err := d.decode(data[i : i+1])
+		require.NoError(t, err)
+		msgs = append(msgs, res...)
+	}
+	assert.Empty(t, d.buf)
+
+	assert.Equal(t, []*api.WebSocketMessage{
+		{Opcode: api.WebSocketOpcodeText, Payload: []byte("hello"), FromDownstream: true},
+		{Opcode: api.WebSocketOpcodePing, Payload: []byte("ping"), FromDownstream: true},
+		{Opcode: api.WebSocketOpcodeBinary, Payload: []byte("fragment"), FromDownstream: true},
+		{Opcode: api.WebSocketOpcodeBinary, Payload: large, FromDownstream: true},
+	}, msgs)
+
+	tests := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{
+			name: "fragmented control frame",
+			data: encodeWebSocketFrame(false, 8, nil, nil),
+			err:  "fragmented WebSocket control frame",
+		},
+		{
+			name: "unexpected continuation",
+			data: encodeWebSocketFrame(true, 0, []byte("a"), nil),
+			err:  "unexpected WebSocket continuation frame",
+		},
+		{
+			name: "unfinished fragmented message",
+			data: append(encodeWebSocketFrame(false, 1, []byte("a"), nil), encodeWebSocketFrame(true, 1, []byte("b"), nil)...),
+			err:  "unfinished fragmented WebSocket message",
+		},
+		{
+			name: "too large",
+			data: []byte{0x82, 127, 0xff, 0, 0, 0, 0, 0, 0, 0},
+			err:  "exceeds the limit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &webSocketDecoder{}
+			_, err := d.decode(tt.data)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+type webSocketTestFilter struct {
+	api.PassThroughFilter
+
+	denyUpgrade bool
+	msgs        []*api.WebSocketMessage
+}
+
+func (f *webSocketTestFilter) OnWebSocketUpgrade(headers api.RequestHeaderMap) api.ResultAction {
+	if f.denyUpgrade {
+		return &api.LocalResponse{Code: 403}
+	}
+	return api.Continue
+}
+
+func (f *webSocketTestFilter) OnWebSocketMessage(msg *api.WebSocketMessage) api.ResultAction {
+	if string(msg.Payload) == "bad" {
+		return &api.LocalResponse{Code: 400}
+	}
+	f.msgs = append(f.msgs, msg)
+	return api.Continue
+}
+
+func TestWebSocketHooks(t *testing.T) {
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	newWebSocketRequest := func(f *webSocketTestFilter) *filterManager {
+		config := initFilterManagerConfig("ns")
+		config.parsed = []*model.ParsedFilterConfig{
+			{
+				Name: "websocket",
+				Factory: func(interface{}, api.FilterCallbackHandler) api.Filter {
+					return f
+				},
+			},
+		}
+		m := unwrapFilterManager(FilterManagerFactory(config, cb))
+		h := http.Header{}
+		h.Set("connection", "Upgrade")
+		h.Set("upgrade", "WebSocket")
+		m.DecodeHeaders(envoy.NewRequestHeaderMap(h), false)
+		cb.WaitContinued()
+		return m
+	}
+
+	f := &webSocketTestFilter{denyUpgrade: true}
+	newWebSocketRequest(f)
+	assert.Equal(t, 403, cb.LocalResponse().Code)
+
+	f = &webSocketTestFilter{}
+	cb = envoy.NewCAPIFilterCallbackHandler()
+	m := newWebSocketRequest(f)
+	assert.Equal(t, 0, cb.LocalResponse().Code)
+	assert.False(t, m.canSkipDecodeData)
+	assert.False(t, m.canSkipEncodeData)
+
+	m.DecodeData(envoy.NewBufferInstance(encodeWebSocketFrame(true, 1, []byte("hi"), []byte{5, 6, 7, 8})), false)
+	cb.WaitContinued()
+	h := http.Header{}
+	h.Set(":status", "101")
+	m.EncodeHeaders(envoy.NewResponseHeaderMap(h), false)
+	cb.WaitContinued()
+	m.EncodeData(envoy.NewBufferInstance(encodeWebSocketFrame(true, 1, []byte("hello"), nil)), false)
+	cb.WaitContinued()
+	assert.Equal(t, []*api.WebSocketMessage{
+		{Opcode: api.WebSocketOpcodeText, Payload: []byte("hi"), FromDownstream: true},
+		{Opcode: api.WebSocketOpcodeText, Payload: []byte("hello")},
+	}, f.msgs)
+
+	m.EncodeData(envoy.NewBufferInstance(encodeWebSocketFrame(true, 1, []byte("bad"), nil)), false)
+	cb.WaitContinued()
+	assert.Equal(t, 400, cb.LocalResponse().Code)
+}
+
+func TestWebSocketHooksNonUpgradeRequest(t *testing.T) {
+	f := &webSocketTestFilter{denyUpgrade: true}
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name: "websocket",
+			Factory: func(interface{}, api.FilterCallbackHandler) api.Filter {
+				return f
+			},
+		},
+	}
+	m := unwrapFilterManager(FilterManagerFactory(config, cb))
+	m.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), false)
+	cb.WaitContinued()
+	m.DecodeData(envoy.NewBufferInstance(encodeWebSocketFrame(true, 1, []byte("bad"), nil)), true)
+	cb.WaitContinued()
+	assert.Equal(t, 0, cb.LocalResponse().Code)
+	assert.Empty(t, f.msgs)
+}
@@ -0,0 +1,236 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	capi "github.com/envoyproxy/envoy/contrib/golang/common/go/api"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+// By default, each phase of the request is run in a new goroutine. For the plugins which do blocking
+// network I/O, a traffic spike will create a large number of goroutines waiting for the network.
+// The worker pool limits the concurrency of the requests which run such plugins, and sheds the new
+// requests when the queue is full.
+
+const defaultWorkerPoolQueueSize = 1024
+
+var workerPoolInstance atomic.Pointer[workerPool]
+
+type workerPool struct {
+	workers int
+	tasks   chan func()
+	done    chan struct{}
+
+	busy     atomic.Int64
+	rejected atomic.Uint64
+
+	// lock makes sure no task is queued after the workers start to drain the queue
+	lock   sync.RWMutex
+	closed bool
+}
+
+func newWorkerPool(workers int, queueSize int) *workerPool {
+	p := &workerPool{
+		workers: workers,
+		tasks:   make(chan func(), queueSize),
+		done:    make(chan struct{}),
+	}
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+func (p *workerPool) run(task func()) {
+	p.busy.Add(1)
+	defer p.busy.Add(-1)
+	defer func() {
+		// the task should recover the panic by itself, this is just the last line of defense
+		if r := recover(); r != nil {
+			api.LogErrorf("panic in worker pool: %v\n%s", r, debug.Stack())
+		}
+	}()
+	task()
+}
+
+func (p *workerPool) work() {
+	for {
+		select {
+		case task := <-p.tasks:
+			p.run(task)
+		case <-p.done:
+			// finish the queued tasks, otherwise the requests will hang
+			for {
+				select {
+				case task := <-p.tasks:
+					p.run(task)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// submit queues the task and returns false if the queue is full. If the pool is already stopped,
+// for example, replaced by another one, the task is run in a new goroutine.
+func (p *workerPool) submit(task func()) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.closed {
+		go task()
+		return true
+	}
+
+	select {
+	case p.tasks <- task:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *workerPool) stop() {
+	p.lock.Lock()
+	p.closed = true
+	p.lock.Unlock()
+	close(p.done)
+}
+
+func (p *workerPool) write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	writeGauge := func(name, help string, v int64) {
+		fmt.Fprintf(bw, "# HELP %s %s\n", name, help)
+		fmt.Fprintf(bw, "# TYPE %s gauge\n", name)
+		fmt.Fprintf(bw, "%s %d\n", name, v)
+	}
+	writeGauge("htnn_worker_pool_workers", "Number of workers in the pool for blocking plugins.", int64(p.workers))
+	writeGauge("htnn_worker_pool_busy_workers", "Number of workers which are running tasks.", p.busy.Load())
+	writeGauge("htnn_worker_pool_queue_length", "Number of tasks waiting in the queue.", int64(len(p.tasks)))
+
+	name := "htnn_worker_pool_rejected_requests_total"
+	fmt.Fprintf(bw, "# HELP %s Number of requests rejected with 503 because the worker pool is overloaded.\n", name)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", name)
+	fmt.Fprintf(bw, "%s %d\n", name, p.rejected.Load())
+	return bw.Flush()
+}
+
+// EnableWorkerPool runs the requests which execute blocking plugins with a pool of the given number
+// of workers. At most queueSize requests can wait in the queue, the new requests will be rejected
+// with 503 when the queue is full. It's expected to be called during the initialization.
+func EnableWorkerPool(workers int, queueSize int) {
+	if workers <= 0 {
+		DisableWorkerPool()
+		return
+	}
+	if queueSize < 0 {
+		queueSize = defaultWorkerPoolQueueSize
+	}
+	if old := workerPoolInstance.Swap(newWorkerPool(workers, queueSize)); old != nil {
+		old.stop()
+	}
+}
+
+// DisableWorkerPool runs every request in the new goroutines again.
+func DisableWorkerPool() {
+	if old := workerPoolInstance.Swap(nil); old != nil {
+		old.stop()
+	}
+}
+
+// WriteWorkerPoolMetrics writes the metrics of the worker pool in Prometheus text format.
+func WriteWorkerPoolMetrics(w io.Writer) error {
+	p := workerPoolInstance.Load()
+	if p == nil {
+		return nil
+	}
+	return p.write(w)
+}
+
+// WorkerPoolMetricsHandler is a http.Handler which serves the metrics of the worker pool.
+func WorkerPoolMetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := WriteWorkerPoolMetrics(w); err != nil {
+			api.LogErrorf("failed to write worker pool metrics: %v", err)
+		}
+	})
+}
+
+// runInGoThread runs the task in a new goroutine, or in the worker pool if the request runs blocking
+// plugins. False is returned if the request is shed because the pool is overloaded. Only the new
+// requests are shed, the later phases of the admitted requests are never rejected.
+func (m *filterManager) runInGoThread(task func(), newRequest bool) bool {
+	if m.config.hasBlockingPlugin {
+		if p := workerPoolInstance.Load(); p != nil {
+			if p.submit(task) {
+				return true
+			}
+			if newRequest {
+				p.rejected.Add(1)
+				return false
+			}
+		}
+	}
+
+	go task()
+	return true
+}
+
+func (m *filterManager) shedRequest() capi.StatusType {
+	api.LogDebugf("reject the request as the worker pool is overloaded")
+	m.MarkRunningInGoThread(false)
+	m.localReply(&api.LocalResponse{Code: 503}, true)
+	return capi.LocalReply
+}
+
+func initWorkerPool() {
+	env := os.Getenv("HTNN_WORKER_POOL_SIZE")
+	if env == "" {
+		return
+	}
+	workers, err := strconv.Atoi(env)
+	if err != nil || workers <= 0 {
+		api.LogErrorf("invalid env var HTNN_WORKER_POOL_SIZE: %s", env)
+		return
+	}
+
+	queueSize := defaultWorkerPoolQueueSize
+	env = os.Getenv("HTNN_WORKER_POOL_QUEUE_SIZE")
+	if env != "" {
+		n, err := strconv.Atoi(env)
+		if err == nil && n >= 0 {
+			queueSize = n
+		} else {
+			api.LogErrorf("invalid env var HTNN_WORKER_POOL_QUEUE_SIZE: %s", env)
+		}
+	}
+	EnableWorkerPool(workers, queueSize)
+}
+
+func init() {
+	initWorkerPool()
+}
@@ -0,0 +1,193 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filtermanager
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	capi "github.com/envoyproxy/envoy/contrib/golang/common/go/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	pkgPlugins "mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestWorkerPool(t *testing.T) {
+	p := newWorkerPool(1, 1)
+	defer p.stop()
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	require.True(t, p.submit(func() {
+		close(started)
+		<-block
+	}))
+	<-started
+
+	done := make(chan struct{})
+	require.True(t, p.submit(func() {
+		close(done)
+	}))
+	// the queue is full
+	assert.False(t, p.submit(func() {}))
+	assert.Equal(t, int64(1), p.busy.Load())
+
+	close(block)
+	<-done
+	assert.True(t, p.submit(func() {
+		panic("ouch")
+	}))
+	assert.Eventually(t, func() bool {
+		return p.busy.Load() == 0 && len(p.tasks) == 0
+	}, 1*time.Second, 10*time.Millisecond)
+}
+
+func TestWorkerPoolStopped(t *testing.T) {
+	p := newWorkerPool(1, 1)
+	block := make(chan struct{})
+	started := make(chan struct{})
+	require.True(t, p.submit(func() {
+		close(started)
+		<-block
+	}))
+	<-started
+	queued := make(chan struct{})
+	require.True(t, p.submit(func() {
+		close(queued)
+	}))
+
+	p.stop()
+	// the task submitted after the pool is stopped is not dropped
+	done := make(chan struct{})
+	require.True(t, p.submit(func() {
+		close(done)
+	}))
+	<-done
+
+	// the queued task is still run
+	close(block)
+	<-queued
+}
+
+type blockingPlugin struct {
+	pkgPlugins.MockPlugin
+}
+
+func (p *blockingPlugin) Blocking() bool {
+	return true
+}
+
+type blockingFilter struct {
+	api.PassThroughFilter
+
+	block chan struct{}
+}
+
+func (f *blockingFilter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	<-f.block
+	return api.Continue
+}
+
+func TestWorkerPoolShedRequests(t *testing.T) {
+	pkgPlugins.RegisterPluginType("blocking", &blockingPlugin{})
+	assert.True(t, pkgPlugins.IsBlockingPlugin("blocking"))
+
+	EnableWorkerPool(1, 1)
+	defer DisableWorkerPool()
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	// occupy the only worker
+	require.True(t, workerPoolInstance.Load().submit(func() {
+		close(started)
+		<-block
+	}))
+	<-started
+
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name: "blocking",
+			Factory: func(interface{}, api.FilterCallbackHandler) api.Filter {
+				return &blockingFilter{block: block}
+			},
+		},
+	}
+	config.hasBlockingPlugin = true
+
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	m := unwrapFilterManager(FilterManagerFactory(config, cb))
+	// the request is queued
+	assert.Equal(t, capi.Running, m.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true))
+
+	cb2 := envoy.NewCAPIFilterCallbackHandler()
+	m2 := unwrapFilterManager(FilterManagerFactory(config, cb2))
+	res := m2.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true)
+	assert.Equal(t, capi.LocalReply, res)
+	assert.Equal(t, 503, cb2.LocalResponse().Code)
+	assert.False(t, m2.IsRunningInGoThread())
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteWorkerPoolMetrics(&buf))
+	assert.Contains(t, buf.String(), "htnn_worker_pool_rejected_requests_total 1\n")
+	assert.Contains(t, buf.String(), "htnn_worker_pool_busy_workers 1\n")
+	assert.Contains(t, buf.String(), "htnn_worker_pool_queue_length 1\n")
+
+	close(block)
+	cb.WaitContinued()
+}
+
+func TestWorkerPoolNonBlockingPlugins(t *testing.T) {
+	EnableWorkerPool(1, 1)
+	defer DisableWorkerPool()
+
+	block := make(chan struct{})
+	defer close(block)
+	p := workerPoolInstance.Load()
+	started := make(chan struct{})
+	require.True(t, p.submit(func() {
+		close(started)
+		<-block
+	}))
+	<-started
+
+	// the requests without blocking plugins don't use the pool
+	config := initFilterManagerConfig("ns")
+	config.parsed = []*model.ParsedFilterConfig{
+		{
+			Name:    "add_req",
+			Factory: addReqFactory,
+			ParsedConfig: addReqConf{
+				hdrName: "x-htnn-route",
+			},
+		},
+	}
+	cb := envoy.NewCAPIFilterCallbackHandler()
+	m := unwrapFilterManager(FilterManagerFactory(config, cb))
+	assert.Equal(t, capi.Running, m.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true))
+	cb.WaitContinued()
+}
+
+func TestWorkerPoolMetricsDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteWorkerPoolMetrics(&buf))
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,215 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpcx provides helpers for the plugins which handle gRPC traffic.
+package grpcx
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const (
+	// frameHeaderLen is the length of the gRPC frame header: 1 byte compressed flag + 4 bytes message length
+	frameHeaderLen = 5
+	// MaxMessageSize is the max size of the message we accept, same as the default value of gRPC server.
+	MaxMessageSize = 4 * 1024 * 1024
+)
+
+var (
+	ErrIncompleteFrame = errors.New("incomplete gRPC frame")
+)
+
+// Frame is a length-prefixed gRPC message
+type Frame struct {
+	// Compressed indicates if the message is compressed with the algorithm in the grpc-encoding header
+	Compressed bool
+	Message    []byte
+}
+
+// IsGrpcRequest returns whether the request is a gRPC request according to the content-type
+func IsGrpcRequest(headers api.RequestHeaderMap) bool {
+	ct, _ := headers.Get("content-type")
+	return IsGrpcContentType(ct)
+}
+
+// IsGrpcContentType returns whether the content-type is a gRPC one, like `application/grpc+proto`.
+// Note that gRPC-Web is not included.
+func IsGrpcContentType(ct string) bool {
+	if !strings.HasPrefix(ct, "application/grpc") {
+		return false
+	}
+	rest := ct[len("application/grpc"):]
+	return rest == "" || rest[0] == '+' || rest[0] == ';'
+}
+
+// EncodeFrame encodes the frame into the length-prefixed format
+func EncodeFrame(f *Frame) []byte {
+	data := make([]byte, frameHeaderLen+len(f.Message))
+	if f.Compressed {
+		data[0] = 1
+	}
+	binary.BigEndian.PutUint32(data[1:frameHeaderLen], uint32(len(f.Message)))
+	copy(data[frameHeaderLen:], f.Message)
+	return data
+}
+
+// EncodeFrames encodes the frames into the length-prefixed format
+func EncodeFrames(frames []*Frame) []byte {
+	n := 0
+	for _, f := range frames {
+		n += frameHeaderLen + len(f.Message)
+	}
+	data := make([]byte, 0, n)
+	for _, f := range frames {
+		data = append(data, EncodeFrame(f)...)
+	}
+	return data
+}
+
+// DecodeFrame decodes a frame from the data, and returns the frame and the number of bytes consumed.
+// ErrIncompleteFrame is returned if the data doesn't contain a complete frame.
+func DecodeFrame(data []byte) (*Frame, int, error) {
+	if len(data) < frameHeaderLen {
+		return nil, 0, ErrIncompleteFrame
+	}
+
+	flag := data[0]
+	if flag > 1 {
+		return nil, 0, fmt.Errorf("invalid gRPC frame: unknown compressed flag %d", flag)
+	}
+	size := binary.BigEndian.Uint32(data[1:frameHeaderLen])
+	if size > MaxMessageSize {
+		return nil, 0, fmt.Errorf("invalid gRPC frame: message size %d exceeds the limit %d", size, MaxMessageSize)
+	}
+	end := frameHeaderLen + int(size)
+	if len(data) < end {
+		return nil, 0, ErrIncompleteFrame
+	}
+
+	return &Frame{
+		Compressed: flag == 1,
+		Message:    data[frameHeaderLen:end],
+	}, end, nil
+}
+
+// DecodeFrames decodes all the frames in the data. Error is returned if there is incomplete frame.
+func DecodeFrames(data []byte) ([]*Frame, error) {
+	var frames []*Frame
+	for len(data) > 0 {
+		f, n, err := DecodeFrame(data)
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, f)
+		data = data[n:]
+	}
+	return frames, nil
+}
+
+// FrameDecoder decodes frames from the body which is received in multiple chunks, so that the plugin
+// can handle each message in the DecodeData / EncodeData without buffering the whole body.
+type FrameDecoder struct {
+	buf []byte
+}
+
+// Decode appends the data to the buffered one and returns the complete frames.
+// The incomplete frame is kept until the next call.
+func (d *FrameDecoder) Decode(data []byte) ([]*Frame, error) {
+	d.buf = append(d.buf, data...)
+
+	var frames []*Frame
+	consumed := 0
+	for {
+		f, n, err := DecodeFrame(d.buf[consumed:])
+		if err != nil {
+			if errors.Is(err, ErrIncompleteFrame) {
+				break
+			}
+			return nil, err
+		}
+		// copy the message as the underlying buffer will be reused
+		f.Message = append([]byte(nil), f.Message...)
+		frames = append(frames, f)
+		consumed += n
+	}
+	d.buf = append(d.buf[:0], d.buf[consumed:]...)
+	return frames, nil
+}
+
+// Buffered returns the number of bytes in the incomplete frame
+func (d *FrameDecoder) Buffered() int {
+	return len(d.buf)
+}
+
+// MessageHandler handles a gRPC message. It returns the message to replace the original one.
+type MessageHandler func(f *Frame) (*Frame, error)
+
+// HandleMessages calls the handler with each message in the buffer, and replaces the buffer with the
+// returned messages. It's designed to be used in DecodeRequest / EncodeResponse, where the whole body is buffered.
+func HandleMessages(buffer api.BufferInstance, handler MessageHandler) error {
+	if buffer == nil || buffer.Len() == 0 {
+		return nil
+	}
+
+	frames, err := DecodeFrames(buffer.Bytes())
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for i, f := range frames {
+		res, err := handler(f)
+		if err != nil {
+			return err
+		}
+		if res != f {
+			frames[i] = res
+			changed = true
+		}
+	}
+
+	if changed {
+		return buffer.Set(EncodeFrames(frames))
+	}
+	return nil
+}
+
+// HTTPStatusToGrpcStatus maps the HTTP status code to the gRPC status code, according to
+// https://github.com/grpc/grpc/blob/master/doc/http-grpc-status-mapping.md
+func HTTPStatusToGrpcStatus(code int) codes.Code {
+	switch code {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.Internal
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.Unimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcx
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestIsGrpcRequest(t *testing.T) {
+	for ct, exp := range map[string]bool{
+		"application/grpc":             true,
+		"application/grpc+proto":       true,
+		"application/grpc;charset=utf": true,
+		"application/grpc-web":         false,
+		"application/json":             false,
+		"":                             false,
+	} {
+		h := http.Header{}
+		if ct != "" {
+			h.Set("content-type", ct)
+		}
+		assert.Equal(t, exp, IsGrpcRequest(envoy.NewRequestHeaderMap(h)), ct)
+	}
+}
+
+func TestEncodeDecodeFrames(t *testing.T) {
+	frames := []*Frame{
+		{Message: []byte("hello")},
+		{Compressed: true, Message: []byte("world")},
+		{Message: []byte{}},
+	}
+	data := EncodeFrames(frames)
+	assert.Equal(t, 3*frameHeaderLen+10, len(data))
+
+	res, err := DecodeFrames(data)
+	require.NoError(t, err)
+	assert.Equal(t, frames, res)
+
+	_, err = DecodeFrames(data[:len(data)-1])
+	assert.ErrorIs(t, err, ErrIncompleteFrame)
+
+	_, _, err = DecodeFrame([]byte{2, 0, 0, 0, 0})
+	assert.ErrorContains(t, err, "unknown compressed flag")
+	_, _, err = DecodeFrame([]byte{0, 0xff, 0, 0, 0})
+	assert.ErrorContains(t, err, "exceeds the limit")
+}
+
+func TestFrameDecoder(t *testing.T) {
+	data := EncodeFrames([]*Frame{
+		{Message: []byte("hello")},
+		{Message: []byte("world")},
+	})
+
+	d := &FrameDecoder{}
+	frames, err := d.Decode(data[:3])
+	require.NoError(t, err)
+	assert.Empty(t, frames)
+	assert.Equal(t, 3, d.Buffered())
+
+	frames, err = d.Decode(data[3:12])
+	require.NoError(t, err)
+	assert.Equal(t, []*Frame{{Message: []byte("hello")}}, frames)
+	assert.Equal(t, 2, d.Buffered())
+
+	frames, err = d.Decode(data[12:])
+	require.NoError(t, err)
+	assert.Equal(t, []*Frame{{Message: []byte("world")}}, frames)
+	assert.Equal(t, 0, d.Buffered())
+
+	_, err = d.Decode([]byte{3, 0, 0, 0, 0})
+	assert.Error(t, err)
+}
+
+func TestHandleMessages(t *testing.T) {
+	buf := envoy.NewBufferInstance(EncodeFrames([]*Frame{
+		{Message: []byte("hello")},
+		{Message: []byte("world")},
+	}))
+
+	var msgs []string
+	err := HandleMessages(buf, func(f *Frame) (*Frame, error) {
+		msgs = append(msgs, string(f.Message))
+		if string(f.Message) == "world" {
+			return &Frame{Message: []byte("gRPC")}, nil
+		}
+		return f, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hello", "world"}, msgs)
+
+	frames, err := DecodeFrames(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "gRPC", string(frames[1].Message))
+
+	err = HandleMessages(buf, func(f *Frame) (*Frame, error) {
+		return nil, errors.New("invalid message")
+	})
+	assert.ErrorContains(t, err, "invalid message")
+
+	assert.NoError(t, HandleMessages(nil, nil))
+}
+
+func TestHTTPStatusToGrpcStatus(t *testing.T) {
+	assert.Equal(t, codes.OK, HTTPStatusToGrpcStatus(200))
+	assert.Equal(t, codes.Unauthenticated, HTTPStatusToGrpcStatus(401))
+	assert.Equal(t, codes.PermissionDenied, HTTPStatusToGrpcStatus(403))
+	assert.Equal(t, codes.Unavailable, HTTPStatusToGrpcStatus(429))
+	assert.Equal(t, codes.Unknown, HTTPStatusToGrpcStatus(500))
+}
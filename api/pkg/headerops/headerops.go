@@ -0,0 +1,274 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package headerops provides the lightweight header manipulation which can be attached to
+// any plugin configuration, so that small header tweaks don't require the full transformer plugin.
+//
+// Each operation is one of:
+//
+//   - add: append a value to the header
+//   - set: overwrite the header with the value
+//   - remove: remove the header
+//   - rename: move the values of the header to a new name
+//
+// The value supports the variables of the interpolation package, like `${consumer.name}`.
+// An operation can have conditions, and it is applied only when all the conditions are met.
+// The conditions can also be compiled alone via CompileConditions to match the request.
+package headerops
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+)
+
+const (
+	ActionAdd    = "add"
+	ActionSet    = "set"
+	ActionRemove = "remove"
+	ActionRename = "rename"
+)
+
+// Config is the header operations applied to the request and the response
+type Config struct {
+	Request  []*Op `json:"request,omitempty"`
+	Response []*Op `json:"response,omitempty"`
+}
+
+type Op struct {
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	// Value is used by add and set
+	Value string `json:"value,omitempty"`
+	// NewName is used by rename
+	NewName string       `json:"newName,omitempty"`
+	When    []*Condition `json:"when,omitempty"`
+}
+
+// Condition matches the header which is going to be manipulated. If none of Present, Equals and
+// Regex is specified, it matches when the header is present.
+type Condition struct {
+	Header  string `json:"header"`
+	Present *bool  `json:"present,omitempty"`
+	Equals  string `json:"equals,omitempty"`
+	Regex   string `json:"regex,omitempty"`
+}
+
+type condition struct {
+	header  string
+	present bool
+	equals  string
+	regex   *regexp.Regexp
+}
+
+func (c *condition) match(headers api.HeaderMap) bool {
+	v, ok := headers.Get(c.header)
+	if c.regex != nil {
+		return ok && c.regex.MatchString(v)
+	}
+	if c.equals != "" {
+		return ok && v == c.equals
+	}
+	return ok == c.present
+}
+
+func compileConditions(conds []*Condition) ([]*condition, error) {
+	var res []*condition
+	for _, c := range conds {
+		if c.Header == "" {
+			return nil, errors.New("header name is required in the condition")
+		}
+		cond := &condition{
+			header:  strings.ToLower(c.Header),
+			present: c.Present == nil || *c.Present,
+			equals:  c.Equals,
+		}
+		if c.Regex != "" {
+			re, err := regexp.Compile(c.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid regex in the condition: %w", err)
+			}
+			cond.regex = re
+		}
+		res = append(res, cond)
+	}
+	return res, nil
+}
+
+func matchAll(conds []*condition, headers api.HeaderMap) bool {
+	for _, c := range conds {
+		if !c.match(headers) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matcher is the compiled conditions. It's safe to use it concurrently.
+type Matcher struct {
+	conditions []*condition
+}
+
+// CompileConditions validates the conditions and compiles them into a Matcher
+func CompileConditions(conds []*Condition) (*Matcher, error) {
+	res, err := compileConditions(conds)
+	if err != nil {
+		return nil, err
+	}
+	return &Matcher{conditions: res}, nil
+}
+
+// Match returns true if all the conditions are met
+func (m *Matcher) Match(headers api.HeaderMap) bool {
+	return matchAll(m.conditions, headers)
+}
+
+type op struct {
+	action  string
+	name    string
+	value   *interpolation.Template
+	newName string
+	when    []*condition
+}
+
+// HeaderOps is the compiled Config. It's safe to apply it concurrently.
+type HeaderOps struct {
+	request  []*op
+	response []*op
+}
+
+func compileOp(o *Op) (*op, error) {
+	if o.Name == "" {
+		return nil, errors.New("header name is required")
+	}
+
+	res := &op{
+		action: o.Action,
+		name:   strings.ToLower(o.Name),
+	}
+	switch o.Action {
+	case ActionAdd, ActionSet:
+		t, err := interpolation.Compile(o.Value)
+		if err != nil {
+			return nil, err
+		}
+		res.value = t
+	case ActionRemove:
+	case ActionRename:
+		if o.NewName == "" {
+			return nil, fmt.Errorf("new name is required to rename header %s", o.Name)
+		}
+		res.newName = strings.ToLower(o.NewName)
+	default:
+		return nil, fmt.Errorf("unknown header action: %s", o.Action)
+	}
+
+	when, err := compileConditions(o.When)
+	if err != nil {
+		return nil, err
+	}
+	res.when = when
+	return res, nil
+}
+
+func compileOps(ops []*Op) ([]*op, error) {
+	res := make([]*op, 0, len(ops))
+	for _, o := range ops {
+		if o == nil {
+			continue
+		}
+		compiled, err := compileOp(o)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, compiled)
+	}
+	return res, nil
+}
+
+// Compile validates the Config and compiles it into HeaderOps
+func Compile(c *Config) (*HeaderOps, error) {
+	req, err := compileOps(c.Request)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	rsp, err := compileOps(c.Response)
+	if err != nil {
+		return nil, fmt.Errorf("response: %w", err)
+	}
+	return &HeaderOps{
+		request:  req,
+		response: rsp,
+	}, nil
+}
+
+// HasRequestOps returns whether there are operations to the request
+func (h *HeaderOps) HasRequestOps() bool {
+	return len(h.request) > 0
+}
+
+// HasResponseOps returns whether there are operations to the response
+func (h *HeaderOps) HasResponseOps() bool {
+	return len(h.response) > 0
+}
+
+func apply(ops []*op, headers api.HeaderMap, reqHeaders api.RequestHeaderMap, callbacks api.StreamFilterCallbacks) {
+	for _, o := range ops {
+		if !matchAll(o.when, headers) {
+			continue
+		}
+		if o.value != nil && o.value.HasVariable() && reqHeaders == nil {
+			// the request is replied before reaching the plugin
+			api.LogDebugf("skip header operation to %s as the request headers are not available", o.name)
+			continue
+		}
+
+		switch o.action {
+		case ActionAdd:
+			headers.Add(o.name, o.value.Render(reqHeaders, callbacks))
+		case ActionSet:
+			headers.Set(o.name, o.value.Render(reqHeaders, callbacks))
+		case ActionRemove:
+			headers.Del(o.name)
+		case ActionRename:
+			// the returned slice is not a copy
+			values := append([]string{}, headers.Values(o.name)...)
+			if len(values) == 0 {
+				continue
+			}
+			headers.Del(o.name)
+			headers.Del(o.newName)
+			for _, v := range values {
+				headers.Add(o.newName, v)
+			}
+		}
+	}
+}
+
+// ApplyToRequest applies the operations to the request headers
+func (h *HeaderOps) ApplyToRequest(headers api.RequestHeaderMap, callbacks api.StreamFilterCallbacks) {
+	apply(h.request, headers, headers, callbacks)
+}
+
+// ApplyToResponse applies the operations to the response headers. The variables in the value are
+// resolved with the request headers.
+func (h *HeaderOps) ApplyToResponse(headers api.ResponseHeaderMap, reqHeaders api.RequestHeaderMap,
+	callbacks api.StreamFilterCallbacks) {
+
+	apply(h.response, headers, reqHeaders, callbacks)
+}
@@ -0,0 +1,178 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headerops
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "ok",
+			input: `{"request":[{"action":"set","name":"x-a","value":"${header.x-b}","when":[{"header":"x-c","regex":"^a"}]}]}`,
+		},
+		{
+			name:  "unknown action",
+			input: `{"request":[{"action":"append","name":"x-a"}]}`,
+			err:   "request: unknown header action: append",
+		},
+		{
+			name:  "missing name",
+			input: `{"response":[{"action":"remove"}]}`,
+			err:   "response: header name is required",
+		},
+		{
+			name:  "rename without new name",
+			input: `{"request":[{"action":"rename","name":"x-a"}]}`,
+			err:   "new name is required",
+		},
+		{
+			name:  "bad template",
+			input: `{"request":[{"action":"add","name":"x-a","value":"${unknown}"}]}`,
+			err:   "unknown variable: unknown",
+		},
+		{
+			name:  "bad condition",
+			input: `{"request":[{"action":"remove","name":"x-a","when":[{"regex":"a"}]}]}`,
+			err:   "header name is required in the condition",
+		},
+		{
+			name:  "bad regex",
+			input: `{"request":[{"action":"remove","name":"x-a","when":[{"header":"x-a","regex":"("}]}]}`,
+			err:   "invalid regex in the condition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{}
+			require.NoError(t, json.Unmarshal([]byte(tt.input), c))
+			_, err := Compile(c)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	present := false
+	ops, err := Compile(&Config{
+		Request: []*Op{
+			{Action: ActionSet, Name: "X-Host", Value: "${request.host}"},
+			{Action: ActionAdd, Name: "x-tag", Value: "b"},
+			{Action: ActionRename, Name: "x-tag", NewName: "x-tags"},
+			{Action: ActionRemove, Name: "x-secret", When: []*Condition{{Header: "x-internal", Present: &present}}},
+			{Action: ActionSet, Name: "x-env", Value: "prod", When: []*Condition{
+				{Header: "x-env"},
+				{Header: "x-env", Equals: "production"},
+			}},
+			{Action: ActionSet, Name: "x-version", Value: "v2", When: []*Condition{{Header: "x-version", Regex: "^v1\\."}}},
+			{Action: ActionRename, Name: "x-miss", NewName: "x-tags"},
+		},
+		Response: []*Op{
+			{Action: ActionSet, Name: "x-served-for", Value: "${header.x-host}"},
+			{Action: ActionRemove, Name: "server"},
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, ops.HasRequestOps())
+	assert.True(t, ops.HasResponseOps())
+
+	h := http.Header{}
+	h.Set(":authority", "example.com")
+	h.Add("x-tag", "a")
+	h.Set("x-secret", "s")
+	h.Set("x-env", "production")
+	h.Set("x-version", "v1.2")
+	reqHdr := envoy.NewRequestHeaderMap(h)
+	cb := envoy.NewFilterCallbackHandler()
+	ops.ApplyToRequest(reqHdr, cb)
+
+	host, _ := reqHdr.Get("x-host")
+	assert.Equal(t, "example.com", host)
+	assert.Equal(t, []string{"a", "b"}, reqHdr.Values("x-tags"))
+	assert.Empty(t, reqHdr.Values("x-tag"))
+	_, ok := reqHdr.Get("x-secret")
+	assert.False(t, ok)
+	env, _ := reqHdr.Get("x-env")
+	assert.Equal(t, "prod", env)
+	version, _ := reqHdr.Get("x-version")
+	assert.Equal(t, "v2", version)
+
+	h = http.Header{}
+	h.Set(":authority", "example.com")
+	h.Set("x-secret", "s")
+	h.Set("x-internal", "true")
+	h.Set("x-env", "staging")
+	reqHdr = envoy.NewRequestHeaderMap(h)
+	ops.ApplyToRequest(reqHdr, cb)
+	secret, _ := reqHdr.Get("x-secret")
+	assert.Equal(t, "s", secret)
+	env, _ = reqHdr.Get("x-env")
+	assert.Equal(t, "staging", env)
+
+	rh := http.Header{}
+	rh.Set("server", "envoy")
+	rspHdr := envoy.NewResponseHeaderMap(rh)
+	ops.ApplyToResponse(rspHdr, reqHdr, cb)
+	servedFor, _ := rspHdr.Get("x-served-for")
+	assert.Equal(t, "example.com", servedFor)
+	_, ok = rspHdr.Get("server")
+	assert.False(t, ok)
+
+	// the request headers are not available
+	rspHdr = envoy.NewResponseHeaderMap(http.Header{})
+	ops.ApplyToResponse(rspHdr, nil, cb)
+	_, ok = rspHdr.Get("x-served-for")
+	assert.False(t, ok)
+}
+
+func TestMatcher(t *testing.T) {
+	_, err := CompileConditions([]*Condition{{Header: "x-a", Regex: "("}})
+	assert.ErrorContains(t, err, "invalid regex in the condition")
+
+	absent := false
+	m, err := CompileConditions([]*Condition{
+		{Header: "X-Tenant", Equals: "a"},
+		{Header: "x-debug", Present: &absent},
+	})
+	require.NoError(t, err)
+
+	h := http.Header{}
+	h.Set("x-tenant", "a")
+	assert.True(t, m.Match(envoy.NewRequestHeaderMap(h)))
+	h.Set("x-debug", "1")
+	assert.False(t, m.Match(envoy.NewRequestHeaderMap(h)))
+
+	// no condition matches all the requests
+	m, err = CompileConditions(nil)
+	require.NoError(t, err)
+	assert.True(t, m.Match(envoy.NewRequestHeaderMap(http.Header{})))
+}
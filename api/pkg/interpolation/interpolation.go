@@ -0,0 +1,248 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package interpolation provides the variable interpolation in the plugin configuration,
+// so that plugins don't need to invent their own templating.
+//
+// A template is a string which contains variables like `${request.host}`. Supported variables:
+//
+//   - request.host, request.path, request.method, request.scheme: the request's attributes
+//   - header.$name: the first value of the request header
+//   - query.$name: the first value of the query argument
+//   - consumer.name: the name of the consumer, empty if no consumer is set
+//   - route.name: the name of the route
+//   - source.ip: the IP of the downstream
+//
+// Use `$${` to write a literal `${`. A variable which has no value will be rendered as an empty string.
+//
+// A plugin can accept its own variables via CompileWithPrefixes, and resolve them via RenderWith.
+package interpolation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+type variableResolver func(headers api.RequestHeaderMap, callbacks api.StreamFilterCallbacks) string
+
+type part struct {
+	literal  string
+	resolver variableResolver
+	// custom is the name of the variable resolved by the caller
+	custom string
+}
+
+// Template is a compiled template. It's safe to render it concurrently.
+type Template struct {
+	raw   string
+	parts []part
+}
+
+var simpleVariables = map[string]variableResolver{
+	"request.host": func(headers api.RequestHeaderMap, _ api.StreamFilterCallbacks) string {
+		return headers.Host()
+	},
+	"request.path": func(headers api.RequestHeaderMap, _ api.StreamFilterCallbacks) string {
+		return headers.Path()
+	},
+	"request.method": func(headers api.RequestHeaderMap, _ api.StreamFilterCallbacks) string {
+		return headers.Method()
+	},
+	"request.scheme": func(headers api.RequestHeaderMap, _ api.StreamFilterCallbacks) string {
+		return headers.Scheme()
+	},
+	"consumer.name": func(_ api.RequestHeaderMap, callbacks api.StreamFilterCallbacks) string {
+		c := callbacks.GetConsumer()
+		if c == nil {
+			return ""
+		}
+		return c.Name()
+	},
+	"route.name": func(_ api.RequestHeaderMap, callbacks api.StreamFilterCallbacks) string {
+		return callbacks.StreamInfo().GetRouteName()
+	},
+	"source.ip": func(_ api.RequestHeaderMap, callbacks api.StreamFilterCallbacks) string {
+		addr := callbacks.StreamInfo().DownstreamRemoteParsedAddress()
+		if addr == nil {
+			return ""
+		}
+		return addr.IP
+	},
+}
+
+func newVariableResolver(name string) (variableResolver, error) {
+	if r, ok := simpleVariables[name]; ok {
+		return r, nil
+	}
+
+	if key, ok := strings.CutPrefix(name, "header."); ok && key != "" {
+		key = strings.ToLower(key)
+		return func(headers api.RequestHeaderMap, _ api.StreamFilterCallbacks) string {
+			v, _ := headers.Get(key)
+			return v
+		}, nil
+	}
+
+	if key, ok := strings.CutPrefix(name, "query."); ok && key != "" {
+		return func(headers api.RequestHeaderMap, _ api.StreamFilterCallbacks) string {
+			return headers.URL().Query().Get(key)
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown variable: %s", name)
+}
+
+// Compile parses the given string into a Template. An error is returned if the string contains
+// unknown variables or unclosed `${`.
+func Compile(s string) (*Template, error) {
+	return CompileWithPrefixes(s)
+}
+
+func hasPrefix(name string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if name == p || (strings.HasPrefix(name, p) && name[len(p)] == '.') {
+			return true
+		}
+	}
+	return false
+}
+
+// CompileWithPrefixes is like Compile, but also accepts the variables with the given prefixes.
+// A variable is accepted if its name is the prefix, or starts with the prefix followed by a dot,
+// like `body` and `body.id` for the prefix `body`. These variables are resolved by the lookup
+// function given to RenderWith.
+func CompileWithPrefixes(s string, prefixes ...string) (*Template, error) {
+	t := &Template{raw: s}
+	var literal strings.Builder
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "$${") {
+			literal.WriteString("${")
+			i += 3
+			continue
+		}
+		if !strings.HasPrefix(s[i:], "${") {
+			literal.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		end := strings.IndexByte(s[i+2:], '}')
+		if end == -1 {
+			return nil, errors.New("unclosed variable in template: " + s)
+		}
+		name := strings.TrimSpace(s[i+2 : i+2+end])
+		var p part
+		if hasPrefix(name, prefixes) {
+			p.custom = name
+		} else {
+			resolver, err := newVariableResolver(name)
+			if err != nil {
+				return nil, err
+			}
+			p.resolver = resolver
+		}
+
+		if literal.Len() > 0 {
+			t.parts = append(t.parts, part{literal: literal.String()})
+			literal.Reset()
+		}
+		t.parts = append(t.parts, p)
+		i += 2 + end + 1
+	}
+	if literal.Len() > 0 {
+		t.parts = append(t.parts, part{literal: literal.String()})
+	}
+	return t, nil
+}
+
+// MustCompile is like Compile but panics if the string can't be compiled.
+func MustCompile(s string) *Template {
+	t, err := Compile(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// HasVariable returns true if the template contains variables.
+func (t *Template) HasVariable() bool {
+	for _, p := range t.parts {
+		if p.resolver != nil || p.custom != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomVariables returns the names of the variables accepted by CompileWithPrefixes, in the
+// order they appear in the template. It can be used to validate the variables when compiling.
+func (t *Template) CustomVariables() []string {
+	var names []string
+	for _, p := range t.parts {
+		if p.custom != "" {
+			names = append(names, p.custom)
+		}
+	}
+	return names
+}
+
+// String returns the original string of the template.
+func (t *Template) String() string {
+	return t.raw
+}
+
+// Render renders the template with the current request. It should be called in the DecodeXXX phases.
+// The variables accepted by CompileWithPrefixes are rendered as empty strings.
+func (t *Template) Render(headers api.RequestHeaderMap, callbacks api.StreamFilterCallbacks) string {
+	if len(t.parts) == 1 && t.parts[0].resolver == nil && t.parts[0].custom == "" {
+		return t.parts[0].literal
+	}
+
+	s, _ := t.RenderWith(headers, callbacks, nil, nil)
+	return s
+}
+
+// RenderWith is like Render, but the variables accepted by CompileWithPrefixes are resolved by
+// the lookup function, and its result is written as is. The values of the other variables are
+// escaped by the escape function if it is not nil, for example, to write them into XML.
+// The error returned by the lookup function is returned.
+func (t *Template) RenderWith(headers api.RequestHeaderMap, callbacks api.StreamFilterCallbacks,
+	lookup func(name string) (string, error), escape func(string) string) (string, error) {
+	var sb strings.Builder
+	for _, p := range t.parts {
+		switch {
+		case p.resolver != nil:
+			v := p.resolver(headers, callbacks)
+			if escape != nil {
+				v = escape(v)
+			}
+			sb.WriteString(v)
+		case p.custom != "":
+			if lookup == nil {
+				continue
+			}
+			v, err := lookup(p.custom)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(v)
+		default:
+			sb.WriteString(p.literal)
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,183 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package interpolation
+
+import (
+	"errors"
+	"html"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type consumer struct {
+	name string
+}
+
+func (c *consumer) Name() string {
+	return c.name
+}
+
+func (c *consumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		err      string
+		variable bool
+	}{
+		{
+			name:  "literal",
+			input: "hello",
+		},
+		{
+			name:     "variable",
+			input:    "${request.host}",
+			variable: true,
+		},
+		{
+			name:  "escaped",
+			input: "$${request.host}",
+		},
+		{
+			name:  "unknown variable",
+			input: "${request.unknown}",
+			err:   "unknown variable: request.unknown",
+		},
+		{
+			name:  "empty header name",
+			input: "${header.}",
+			err:   "unknown variable: header.",
+		},
+		{
+			name:  "unclosed",
+			input: "a${request.host",
+			err:   "unclosed variable in template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tpl, err := Compile(tt.input)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.variable, tpl.HasVariable())
+			assert.Equal(t, tt.input, tpl.String())
+		})
+	}
+
+	assert.Panics(t, func() {
+		MustCompile("${unknown}")
+	})
+}
+
+func TestRender(t *testing.T) {
+	h := http.Header{}
+	h.Set(":authority", "example.com")
+	h.Set(":path", "/echo?tenant=alice")
+	h.Set(":method", "GET")
+	h.Set(":scheme", "https")
+	h.Set("x-tenant", "bob")
+	headers := envoy.NewRequestHeaderMap(h)
+	cb := envoy.NewFilterCallbackHandler()
+
+	tests := []struct {
+		input    string
+		consumer api.Consumer
+		output   string
+	}{
+		{
+			input:  "plain text",
+			output: "plain text",
+		},
+		{
+			input:  "${request.scheme}://${request.host}${request.path}",
+			output: "https://example.com/echo?tenant=alice",
+		},
+		{
+			input:  "${request.method} from ${ source.ip }",
+			output: "GET from 183.128.130.43",
+		},
+		{
+			input:  "tenant: ${header.X-Tenant}, ${query.tenant}, ${query.miss}",
+			output: "tenant: bob, alice, ",
+		},
+		{
+			input:  "consumer: ${consumer.name}",
+			output: "consumer: ",
+		},
+		{
+			input:    "consumer: ${consumer.name}",
+			consumer: &consumer{name: "john"},
+			output:   "consumer: john",
+		},
+		{
+			input:  "$${header.x-tenant} is ${header.x-tenant}$",
+			output: "${header.x-tenant} is bob$",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if tt.consumer != nil {
+				cb.SetConsumer(tt.consumer)
+				defer cb.SetConsumer(nil)
+			}
+			tpl := MustCompile(tt.input)
+			assert.Equal(t, tt.output, tpl.Render(headers, cb))
+		})
+	}
+}
+
+func TestRenderWith(t *testing.T) {
+	h := http.Header{}
+	h.Set(":path", "/echo")
+	h.Set("x-tenant", "<bob>")
+	headers := envoy.NewRequestHeaderMap(h)
+	cb := envoy.NewFilterCallbackHandler()
+
+	_, err := CompileWithPrefixes("${body.id}")
+	assert.ErrorContains(t, err, "unknown variable: body.id")
+	_, err = CompileWithPrefixes("${bodyid}", "body")
+	assert.ErrorContains(t, err, "unknown variable: bodyid")
+
+	tpl, err := CompileWithPrefixes("<a>${body}</a><b>${body.id}</b><c>${header.x-tenant}</c>", "body")
+	assert.Nil(t, err)
+	assert.True(t, tpl.HasVariable())
+	assert.Equal(t, "<a></a><b></b><c><bob></c>", tpl.Render(headers, cb))
+	assert.Equal(t, []string{"body", "body.id"}, tpl.CustomVariables())
+
+	lookup := func(name string) (string, error) {
+		return "<" + name + "/>", nil
+	}
+	s, err := tpl.RenderWith(headers, cb, lookup, html.EscapeString)
+	assert.Nil(t, err)
+	assert.Equal(t, "<a><body/></a><b><body.id/></b><c>&lt;bob&gt;</c>", s)
+
+	_, err = tpl.RenderWith(headers, cb, func(name string) (string, error) {
+		return "", errors.New("ouch")
+	}, nil)
+	assert.ErrorContains(t, err, "ouch")
+}
@@ -0,0 +1,94 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+// MergeStrategy decides how to merge the configuration of the same plugin from different levels,
+// for example, the gateway level and the route level.
+type MergeStrategy string
+
+const (
+	// MergeStrategyReplace uses the configuration from the more specific level as a whole.
+	// It's the default strategy.
+	MergeStrategyReplace MergeStrategy = "replace"
+	// MergeStrategyDeepMerge merges the objects recursively. For the other fields, the one from
+	// the more specific level is used.
+	MergeStrategyDeepMerge MergeStrategy = "deepMerge"
+	// MergeStrategyAppend is like MergeStrategyDeepMerge, except the lists are concatenated.
+	// The elements from the less specific level come first.
+	MergeStrategyAppend MergeStrategy = "append"
+)
+
+// IsValidMergeStrategy returns whether the given strategy is a known one
+func IsValidMergeStrategy(s MergeStrategy) bool {
+	switch s {
+	case MergeStrategyReplace, MergeStrategyDeepMerge, MergeStrategyAppend:
+		return true
+	}
+	return false
+}
+
+// MergeStrategyProvider can be implemented by the plugin to change its default merge strategy
+type MergeStrategyProvider interface {
+	MergeStrategy() MergeStrategy
+}
+
+// LoadMergeStrategy returns the default merge strategy of the plugin
+func LoadMergeStrategy(name string) MergeStrategy {
+	if p, ok := LoadPluginType(name).(MergeStrategyProvider); ok {
+		s := p.MergeStrategy()
+		if IsValidMergeStrategy(s) {
+			return s
+		}
+	}
+	return MergeStrategyReplace
+}
+
+// MergeConfig merges the configuration unmarshalled from JSON with the given strategy.
+// The given configuration won't be modified.
+func MergeConfig(strategy MergeStrategy, parent interface{}, child interface{}) interface{} {
+	if strategy != MergeStrategyDeepMerge && strategy != MergeStrategyAppend {
+		return child
+	}
+
+	switch c := child.(type) {
+	case map[string]interface{}:
+		p, ok := parent.(map[string]interface{})
+		if !ok {
+			return child
+		}
+		res := make(map[string]interface{}, len(p)+len(c))
+		for k, v := range p {
+			res[k] = v
+		}
+		for k, v := range c {
+			if pv, ok := p[k]; ok {
+				res[k] = MergeConfig(strategy, pv, v)
+			} else {
+				res[k] = v
+			}
+		}
+		return res
+	case []interface{}:
+		p, ok := parent.([]interface{})
+		if !ok || strategy != MergeStrategyAppend {
+			return child
+		}
+		res := make([]interface{}, 0, len(p)+len(c))
+		res = append(res, p...)
+		return append(res, c...)
+	default:
+		return child
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeConfig(t *testing.T) {
+	parent := `{
+		"name": "parent",
+		"keys": ["a", "b"],
+		"rules": {"x": {"allow": true, "methods": ["GET"]}, "y": 1},
+		"obj": {"a": 1},
+		"list": [1]
+	}`
+	child := `{
+		"keys": ["c"],
+		"rules": {"x": {"methods": ["POST"]}, "z": 2},
+		"obj": [1],
+		"list": {"a": 1}
+	}`
+
+	tests := []struct {
+		strategy MergeStrategy
+		res      string
+	}{
+		{
+			strategy: MergeStrategyReplace,
+			res:      child,
+		},
+		{
+			strategy: MergeStrategyDeepMerge,
+			res: `{
+				"name": "parent",
+				"keys": ["c"],
+				"rules": {"x": {"allow": true, "methods": ["POST"]}, "y": 1, "z": 2},
+				"obj": [1],
+				"list": {"a": 1}
+			}`,
+		},
+		{
+			strategy: MergeStrategyAppend,
+			res: `{
+				"name": "parent",
+				"keys": ["a", "b", "c"],
+				"rules": {"x": {"allow": true, "methods": ["GET", "POST"]}, "y": 1, "z": 2},
+				"obj": [1],
+				"list": {"a": 1}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			var p, c interface{}
+			require.NoError(t, json.Unmarshal([]byte(parent), &p))
+			require.NoError(t, json.Unmarshal([]byte(child), &c))
+			res := MergeConfig(tt.strategy, p, c)
+			b, _ := json.Marshal(res)
+			assert.JSONEq(t, tt.res, string(b))
+
+			// the input is not modified
+			b, _ = json.Marshal(p)
+			assert.JSONEq(t, parent, string(b))
+			b, _ = json.Marshal(c)
+			assert.JSONEq(t, child, string(b))
+		})
+	}
+}
+
+func TestLoadMergeStrategy(t *testing.T) {
+	RegisterPluginType("mergeStrategy", &mergeStrategyPlugin{strategy: MergeStrategyAppend})
+	RegisterPluginType("badMergeStrategy", &mergeStrategyPlugin{strategy: "unknown"})
+	assert.Equal(t, MergeStrategyAppend, LoadMergeStrategy("mergeStrategy"))
+	assert.Equal(t, MergeStrategyReplace, LoadMergeStrategy("badMergeStrategy"))
+	assert.Equal(t, MergeStrategyReplace, LoadMergeStrategy("nonexistent"))
+}
+
+type mergeStrategyPlugin struct {
+	MockPlugin
+	strategy MergeStrategy
+}
+
+func (p *mergeStrategyPlugin) MergeStrategy() MergeStrategy {
+	return p.strategy
+}
@@ -18,7 +18,11 @@ import (
 	"cmp"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
 	"runtime/debug"
+	"strings"
 
 	"mosn.io/htnn/api/internal/proto"
 	"mosn.io/htnn/api/pkg/filtermanager/api"
@@ -31,6 +35,13 @@ var (
 	pluginTypes                = map[string]Plugin{}
 	plugins                    = map[string]Plugin{}
 	httpFilterFactoryAndParser = map[string]*FilterFactoryAndParser{}
+	disabledPlugins            = map[string]string{}
+
+	pluginTypeRegistrants = map[string]string{}
+	pluginRegistrants     = map[string]string{}
+	pluginCollisions      = []PluginCollision{}
+
+	pluginNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 )
 
 // Here we introduce extra struct to avoid cyclic import between pkg/filtermanager and pkg/plugins
@@ -66,7 +77,84 @@ const (
 	errInvalidConsumerPluginOrder = "invalid plugin order position: Consumer plugin should use OrderPositionAuthn"
 )
 
+// ValidatePluginName checks if the name is a valid plugin name. A plugin name is either
+// a plain name like `limitReq`, or a namespaced name like `vendor.limitReq`.
+// Third-party plugins are recommended to use the namespaced name, so that they won't
+// collide with the built-in plugins.
+func ValidatePluginName(name string) error {
+	parts := strings.Split(name, ".")
+	if len(parts) > 2 {
+		return fmt.Errorf("invalid plugin name %q: should be in the format of `name` or `vendor.name`", name)
+	}
+	for _, part := range parts {
+		if !pluginNamePattern.MatchString(part) {
+			return fmt.Errorf("invalid plugin name %q: should be in the format of `name` or `vendor.name`, "+
+				"and each part should only contain letters, digits, '_' and '-'", name)
+		}
+	}
+	return nil
+}
+
+// PluginCollision records a plugin which is registered by different registrants
+type PluginCollision struct {
+	Name string
+	// Previous is the package which registered the plugin before
+	Previous string
+	// Current is the package which overrides the plugin
+	Current string
+}
+
+// LoadPluginCollisions returns all the plugin collisions happened so far
+func LoadPluginCollisions() []PluginCollision {
+	return append([]PluginCollision(nil), pluginCollisions...)
+}
+
+// registrant returns the package which calls the exported register function
+func registrant(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+	// the function name is like "mosn.io/htnn/plugins/plugins/demo.init.0"
+	name := fn.Name()
+	idx := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[idx+1:], "."); dot != -1 {
+		name = name[:idx+1+dot]
+	}
+	return name
+}
+
+func checkPluginCollision(registrants map[string]string, kind string, name string, current string) {
+	previous, ok := registrants[name]
+	registrants[name] = current
+	if !ok || previous == current {
+		return
+	}
+
+	// override plugin is allowed, but we should report it so that the user can find out
+	// that the plugin is overridden by accident
+	pluginCollisions = append(pluginCollisions, PluginCollision{
+		Name:     name,
+		Previous: previous,
+		Current:  current,
+	})
+	logger.Info(kind+" is overridden", "name", name, "previous", previous, "current", current)
+}
+
 func RegisterPluginType(name string, plugin Plugin) {
+	if err := ValidatePluginName(name); err != nil {
+		panic(err.Error())
+	}
+
+	checkPluginCollision(pluginTypeRegistrants, "plugin type", name, registrant(1))
+	registerPluginType(name, plugin)
+}
+
+func registerPluginType(name string, plugin Plugin) {
 	if _, ok := pluginTypes[name]; !ok {
 		// As RegisterPluginType also calls RegisterPluginType, we only log for the first time.
 		// Otherwise, we will log twice for the plugins loaded in the data plane.
@@ -75,12 +163,19 @@ func RegisterPluginType(name string, plugin Plugin) {
 	// override plugin is allowed so that we can patch plugin with bugfix if upgrading
 	// the whole htnn is not available
 	pluginTypes[name] = plugin
+	delete(disabledPlugins, name)
 }
 
 func LoadPluginType(name string) Plugin {
 	return pluginTypes[name]
 }
 
+// IsBlockingPlugin returns whether the plugin is marked as blocking
+func IsBlockingPlugin(name string) bool {
+	b, ok := LoadPluginType(name).(Blocker)
+	return ok && b.Blocking()
+}
+
 // We separate the plugin type storage and plugin storage, to avoid plugin type overrides the plugin by accident.
 
 func RegisterPlugin(name string, plugin Plugin) {
@@ -91,13 +186,11 @@ func RegisterPlugin(name string, plugin Plugin) {
 	logger.Info("register plugin", "name", name)
 
 	order := plugin.Order()
-	if goPlugin, ok := plugin.(GoPlugin); ok {
+	goPlugin, isGoPlugin := plugin.(GoPlugin)
+	if isGoPlugin {
 		if order.Position == OrderPositionOuter || order.Position == OrderPositionInner {
 			panic(errInvalidGoPluginOrder)
 		}
-		RegisterHTTPFilterFactoryAndParser(name,
-			goPlugin.Factory(),
-			NewPluginConfigParser(goPlugin))
 	} else if _, ok := plugin.(NativePlugin); ok {
 		switch order.Position {
 		case OrderPositionOuter, OrderPositionInner, OrderPositionListener, OrderPositionNetwork:
@@ -114,12 +207,24 @@ func RegisterPlugin(name string, plugin Plugin) {
 		}
 	}
 
+	if err := ValidatePluginName(name); err != nil {
+		panic(err.Error())
+	}
+
+	if isGoPlugin {
+		RegisterHTTPFilterFactoryAndParser(name,
+			goPlugin.Factory(),
+			NewPluginConfigParser(goPlugin))
+	}
+
 	// override plugin is allowed so that we can patch plugin with bugfix if upgrading
 	// the whole htnn is not available
+	checkPluginCollision(pluginRegistrants, "plugin", name, registrant(1))
 	plugins[name] = plugin
 
 	// We don't force developer to divide their plugin into two parts for better DX.
-	RegisterPluginType(name, plugin)
+	// The plugin type is usually registered in another package, so don't treat it as collision.
+	registerPluginType(name, plugin)
 }
 
 func LoadPlugin(name string) Plugin {
@@ -134,10 +239,32 @@ func IteratePlugin(f func(key string, value Plugin) bool) {
 	}
 }
 
+func IteratePluginType(f func(key string, value Plugin) bool) {
+	for k, v := range pluginTypes {
+		if !f(k, v) {
+			return
+		}
+	}
+}
+
 // This method should be called at startup. There will be race if it's called during runtime.
 func DisablePlugin(name string) {
+	DisablePluginWithReason(name, "disabled by configuration")
+}
+
+// DisablePluginWithReason is similar to DisablePlugin, but it records the reason why the plugin is disabled.
+// The reason will be reported when a policy refers to the disabled plugin.
+// This method should be called at startup. There will be race if it's called during runtime.
+func DisablePluginWithReason(name string, reason string) {
 	delete(plugins, name)
 	delete(pluginTypes, name)
+	disabledPlugins[name] = reason
+}
+
+// LoadDisabledPluginReason returns the reason why the plugin is disabled, and whether the plugin is disabled.
+func LoadDisabledPluginReason(name string) (string, bool) {
+	reason, ok := disabledPlugins[name]
+	return reason, ok
 }
 
 type PluginConfigParser struct {
@@ -197,6 +324,10 @@ func (p *PluginMethodDefaultImpl) Merge(parent interface{}, child interface{}) i
 	return child
 }
 
+func (p *PluginMethodDefaultImpl) Maturity() PluginMaturity {
+	return MaturityStable
+}
+
 func ComparePluginOrder(a, b string) bool {
 	return ComparePluginOrderInt(a, b) < 0
 }
@@ -262,3 +262,79 @@ func TestRegisterPluginWithType(t *testing.T) {
 	assert.NotNil(t, LoadPlugin("mock"))
 	assert.NotNil(t, LoadPluginType("mock"))
 }
+
+func TestDisablePlugin(t *testing.T) {
+	RegisterPlugin("disabled", &MockPlugin{})
+	_, ok := LoadDisabledPluginReason("disabled")
+	assert.False(t, ok)
+	assert.Equal(t, MaturityStable, LoadPluginType("disabled").Maturity())
+
+	DisablePluginWithReason("disabled", "experimental plugins are disabled")
+	assert.Nil(t, LoadPlugin("disabled"))
+	assert.Nil(t, LoadPluginType("disabled"))
+	reason, ok := LoadDisabledPluginReason("disabled")
+	assert.True(t, ok)
+	assert.Equal(t, "experimental plugins are disabled", reason)
+
+	RegisterPlugin("disabled", &MockPlugin{})
+	_, ok = LoadDisabledPluginReason("disabled")
+	assert.False(t, ok)
+}
+
+func TestValidatePluginName(t *testing.T) {
+	for _, name := range []string{"limitReq", "vendor.limitReq", "my-vendor.limit_req"} {
+		assert.NoError(t, ValidatePluginName(name), name)
+	}
+	for _, name := range []string{"", "vendor.", ".limitReq", "a.b.c", "limit req", "vendor/limitReq"} {
+		assert.Error(t, ValidatePluginName(name), name)
+	}
+
+	assert.Panics(t, func() {
+		RegisterPlugin("a.b.c", &MockPlugin{})
+	})
+	assert.Nil(t, LoadHTTPFilterFactoryAndParser("a.b.c"))
+	assert.Panics(t, func() {
+		RegisterPluginType("a.b.c", &MockPlugin{})
+	})
+}
+
+func TestRegisterNamespacedPlugin(t *testing.T) {
+	RegisterPlugin("vendor.mock", &MockPlugin{})
+	assert.NotNil(t, LoadPlugin("vendor.mock"))
+	assert.NotNil(t, LoadPluginType("vendor.mock"))
+	assert.NotNil(t, LoadHTTPFilterFactoryAndParser("vendor.mock"))
+}
+
+func TestPluginCollision(t *testing.T) {
+	RegisterPlugin("collision", &MockPlugin{})
+	// register again in the same package is not a collision
+	RegisterPlugin("collision", &MockPlugin{})
+	for _, c := range LoadPluginCollisions() {
+		assert.NotEqual(t, "collision", c.Name)
+	}
+
+	pluginRegistrants["collision"] = "example.com/builtin"
+	RegisterPlugin("collision", &MockPlugin{})
+
+	pluginTypeRegistrants["collisionType"] = "example.com/builtin"
+	RegisterPluginType("collisionType", &MockPlugin{})
+
+	var found []PluginCollision
+	for _, c := range LoadPluginCollisions() {
+		if c.Name == "collision" || c.Name == "collisionType" {
+			found = append(found, c)
+		}
+	}
+	assert.Equal(t, []PluginCollision{
+		{
+			Name:     "collision",
+			Previous: "example.com/builtin",
+			Current:  "mosn.io/htnn/api/pkg/plugins",
+		},
+		{
+			Name:     "collisionType",
+			Previous: "example.com/builtin",
+			Current:  "mosn.io/htnn/api/pkg/plugins",
+		},
+	}, found)
+}
@@ -0,0 +1,127 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// RedactedValue is used to replace the sensitive field when the configuration is logged or dumped
+	RedactedValue = "******"
+	// SecretRefPrefix is the prefix of the sensitive field which refers to a Kubernetes Secret,
+	// in the format of `secret://$name/$key`. The Secret should be in the same namespace as the policy.
+	SecretRefPrefix = "secret://"
+	// SealedValuePrefix is the prefix of the sensitive field which is encrypted with the key
+	// configured in the control plane, in the format of `sealed:$base64_encoded_ciphertext`.
+	SealedValuePrefix = "sealed:"
+)
+
+// SensitiveFielder is implemented by the configuration which contains sensitive fields, like password.
+// The sensitive fields can be provided as Secret reference or sealed value, and they are resolved
+// by the control plane before delivering to the data plane. They are also redacted from the log.
+type SensitiveFielder interface {
+	// SensitiveFields returns the JSON paths of the sensitive fields, like `password` or `redis.password`.
+	// Only string fields are supported.
+	SensitiveFields() []string
+}
+
+// LoadSensitiveFields returns the sensitive fields of the plugin's configuration
+func LoadSensitiveFields(name string) []string {
+	p := LoadPluginType(name)
+	if p == nil {
+		return nil
+	}
+	if sf, ok := p.Config().(SensitiveFielder); ok {
+		return sf.SensitiveFields()
+	}
+	return nil
+}
+
+// LoadConsumerSensitiveFields returns the sensitive fields of the plugin's consumer configuration
+func LoadConsumerSensitiveFields(name string) []string {
+	p, ok := LoadPluginType(name).(ConsumerPlugin)
+	if !ok {
+		return nil
+	}
+	if sf, ok := p.ConsumerConfig().(SensitiveFielder); ok {
+		return sf.SensitiveFields()
+	}
+	return nil
+}
+
+// TransformSensitiveFields calls fn with each sensitive field in the configuration which is unmarshalled from JSON,
+// and replaces the field with the result. When a field in the path is an array, all the elements will be visited.
+func TransformSensitiveFields(config interface{}, paths []string, fn func(value string) (string, error)) error {
+	for _, path := range paths {
+		err := transformSensitiveField(config, strings.Split(path, "."), fn)
+		if err != nil {
+			return fmt.Errorf("failed to handle sensitive field %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func transformSensitiveField(node interface{}, keys []string, fn func(value string) (string, error)) error {
+	switch v := node.(type) {
+	case []interface{}:
+		for _, elem := range v {
+			if err := transformSensitiveField(elem, keys, fn); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		child, ok := v[keys[0]]
+		if !ok {
+			return nil
+		}
+		if len(keys) > 1 {
+			return transformSensitiveField(child, keys[1:], fn)
+		}
+
+		switch value := child.(type) {
+		case string:
+			res, err := fn(value)
+			if err != nil {
+				return err
+			}
+			v[keys[0]] = res
+		case []interface{}:
+			for i, elem := range value {
+				s, ok := elem.(string)
+				if !ok {
+					continue
+				}
+				res, err := fn(s)
+				if err != nil {
+					return err
+				}
+				value[i] = res
+			}
+		}
+	}
+	return nil
+}
+
+// RedactSensitiveFields replaces the sensitive fields in the configuration with RedactedValue
+func RedactSensitiveFields(config interface{}, paths []string) {
+	_ = TransformSensitiveFields(config, paths, func(value string) (string, error) {
+		if value == "" {
+			return value, nil
+		}
+		return RedactedValue, nil
+	})
+}
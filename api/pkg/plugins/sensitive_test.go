@@ -0,0 +1,86 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugins
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+type sensitiveConfig struct {
+	MockPluginConfig
+}
+
+func (c *sensitiveConfig) SensitiveFields() []string {
+	return []string{"password", "backends.token"}
+}
+
+type sensitivePlugin struct {
+	MockConsumerPlugin
+}
+
+func (p *sensitivePlugin) Config() api.PluginConfig {
+	return &sensitiveConfig{}
+}
+
+func (p *sensitivePlugin) ConsumerConfig() api.PluginConsumerConfig {
+	return nil
+}
+
+func TestLoadSensitiveFields(t *testing.T) {
+	RegisterPluginType("sensitive", &sensitivePlugin{})
+	assert.Equal(t, []string{"password", "backends.token"}, LoadSensitiveFields("sensitive"))
+	assert.Nil(t, LoadConsumerSensitiveFields("sensitive"))
+
+	RegisterPluginType("insensitive", &MockPlugin{})
+	assert.Nil(t, LoadSensitiveFields("insensitive"))
+	assert.Nil(t, LoadSensitiveFields("nonexistent"))
+}
+
+func TestRedactSensitiveFields(t *testing.T) {
+	var conf interface{}
+	err := json.Unmarshal([]byte(`{
+		"password": "pass",
+		"user": "admin",
+		"backends": [{"token": "t1"}, {"token": ""}, {"addr": "127.0.0.1"}],
+		"tokens": ["a", "b"]
+	}`), &conf)
+	require.NoError(t, err)
+
+	RedactSensitiveFields(conf, []string{"password", "backends.token", "tokens", "missing.field"})
+	b, _ := json.Marshal(conf)
+	assert.JSONEq(t, `{
+		"password": "******",
+		"user": "admin",
+		"backends": [{"token": "******"}, {"token": ""}, {"addr": "127.0.0.1"}],
+		"tokens": ["******", "******"]
+	}`, string(b))
+}
+
+func TestTransformSensitiveFields(t *testing.T) {
+	conf := map[string]interface{}{
+		"password": "sealed:xxx",
+	}
+	err := TransformSensitiveFields(conf, []string{"password"}, func(value string) (string, error) {
+		return "", errors.New("bad value")
+	})
+	assert.ErrorContains(t, err, "failed to handle sensitive field password: bad value")
+}
@@ -15,6 +15,8 @@
 package plugins
 
 import (
+	"time"
+
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 )
 
@@ -51,6 +53,26 @@ func (p PluginType) String() string {
 	}
 }
 
+// PluginMaturity describes how mature a plugin is. Operators can disable all experimental plugins
+// in the control plane.
+type PluginMaturity int
+
+const (
+	MaturityStable       PluginMaturity = iota // Stable is the default
+	MaturityExperimental                       // Plugins which are still under development, and may change in incompatible ways
+)
+
+func (p PluginMaturity) String() string {
+	switch p {
+	case MaturityStable:
+		return "Stable"
+	case MaturityExperimental:
+		return "Experimental"
+	default:
+		return "Unknown"
+	}
+}
+
 // PluginOrder is used by the control plane to specify the order of the plugins, especially during merging.
 // There is always a requirement to specify the order by users.
 // For now, we just provide a default order in plugins. Therefore, users don't need to manually configure the order.
@@ -142,12 +164,38 @@ type Plugin interface {
 	Type() PluginType
 	Order() PluginOrder
 	Merge(parent interface{}, child interface{}) interface{}
+	Maturity() PluginMaturity
 }
 
 type Initer interface {
 	Init(cb api.ConfigCallbackHandler) error
 }
 
+// LazyIniter can be implemented by the configuration which implements Initer. If LazyInit returns
+// true, the Init will be run on the first request which executes the plugin, instead of the first
+// request of the route. Concurrent requests will wait for the same Init. A failed lazy Init only
+// fails the requests which execute the plugin, and it will be retried by the later requests.
+// It's useful when the Init depends on an external service which may be unavailable, for example,
+// an OIDC issuer. So that the other plugins in the same route won't be blocked.
+type LazyIniter interface {
+	LazyInit() bool
+}
+
+// InitTimeouter can be implemented by the configuration which implements Initer, to specify
+// how long we will wait for the Init. If the Init doesn't finish in time, the configuration
+// is treated as failed. The default timeout is 10 seconds.
+type InitTimeouter interface {
+	InitTimeout() time.Duration
+}
+
+// Blocker can be implemented by the plugin which does blocking network I/O in its filter, like
+// calling an external service. If Blocking returns true, the requests which run the plugin will be
+// executed by the worker pool of the filtermanager when the pool is enabled, so that a traffic spike
+// won't create unbounded goroutines.
+type Blocker interface {
+	Blocking() bool
+}
+
 type NativePlugin interface {
 	Plugin
 
@@ -60,6 +60,8 @@ type Option struct {
 	LogLevel  string
 	Envs      map[string]string
 	Bootstrap *bootstrap
+	// Ports are the extra ports published to the host, like the port of the metrics server
+	Ports []int
 
 	NoErrorLogCheck    bool
 	ExpectLogPattern   []string
@@ -171,6 +173,10 @@ func StartDataPlane(t *testing.T, opt *Option) (*DataPlane, error) {
 	for k, v := range opt.Envs {
 		envs = append(envs, "-e", k+"="+v)
 	}
+	ports := []string{}
+	for _, port := range opt.Ports {
+		ports = append(ports, "-p", fmt.Sprintf("%d:%d", port, port))
+	}
 
 	pwd, _ := os.Getwd()
 	soPath := filepath.Join(pwd, "libgolang.so")
@@ -199,7 +205,7 @@ func StartDataPlane(t *testing.T, opt *Option) (*DataPlane, error) {
 		" -v /tmp:/tmp" +
 		" -e GOCOVERDIR=" + coverDir +
 		" " + strings.Join(envs, " ") +
-		" -p 10000:10000 -p 9998:9998 " + hostAddr + " " +
+		" -p 10000:10000 -p 9998:9998 " + strings.Join(ports, " ") + " " + hostAddr + " " +
 		image
 
 	content, _ := os.ReadFile(cfgFile.Name())
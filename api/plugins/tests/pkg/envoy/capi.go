@@ -354,6 +354,26 @@ func (i *DynamicMetadata) Set(filterName string, key string, value interface{})
 	dm[key] = value
 }
 
+func (i *DynamicMetadata) GetString(filterName string, key string) (string, bool) {
+	v, ok := i.store[filterName][key].(string)
+	return v, ok
+}
+
+func (i *DynamicMetadata) GetNumber(filterName string, key string) (float64, bool) {
+	v, ok := i.store[filterName][key].(float64)
+	return v, ok
+}
+
+func (i *DynamicMetadata) GetBool(filterName string, key string) (bool, bool) {
+	v, ok := i.store[filterName][key].(bool)
+	return v, ok
+}
+
+func (i *DynamicMetadata) GetStruct(filterName string, key string) (map[string]interface{}, bool) {
+	v, ok := i.store[filterName][key].(map[string]interface{})
+	return v, ok
+}
+
 type FilterState struct {
 	store map[string]string
 }
@@ -465,12 +485,25 @@ func (i *StreamInfo) DownstreamRemoteParsedAddress() *api.IPAddress {
 	}
 }
 
+func (i *StreamInfo) DownstreamLocalParsedAddress() *api.IPAddress {
+	return &api.IPAddress{
+		Address: "0.0.0.0:10000",
+		IP:      "0.0.0.0",
+		Port:    10000,
+	}
+}
+
+func (i *StreamInfo) DownstreamTLSInfo() *api.TLSInfo {
+	return nil
+}
+
 var _ api.StreamInfo = (*StreamInfo)(nil)
 
 type LocalResponse struct {
-	Code    int
-	Body    string
-	Headers map[string][]string
+	Code       int
+	Body       string
+	Headers    map[string][]string
+	GrpcStatus int64
 }
 
 type filterCallbackHandler struct {
@@ -482,6 +515,8 @@ type filterCallbackHandler struct {
 	consumer    api.Consumer
 	pluginState api.PluginState
 	ch          chan struct{}
+
+	mirroredRequests []MirroredRequest
 }
 
 func NewFilterCallbackHandler() *filterCallbackHandler {
@@ -517,7 +552,7 @@ func (i *filterCallbackHandler) WaitContinued() {
 func (i *filterCallbackHandler) SendLocalReply(responseCode int, bodyText string, headers map[string][]string, grpcStatus int64, details string) {
 	i.lock.Lock()
 	defer i.lock.Unlock()
-	i.resp = LocalResponse{Code: responseCode, Body: bodyText, Headers: headers}
+	i.resp = LocalResponse{Code: responseCode, Body: bodyText, Headers: headers, GrpcStatus: grpcStatus}
 
 	i.Continue(capi.LocalReply)
 }
@@ -565,6 +600,36 @@ func (i *filterCallbackHandler) PluginState() api.PluginState {
 	return i.pluginState
 }
 
+type MirroredRequest struct {
+	Address string
+	Headers http.Header
+	Body    []byte
+}
+
+func (i *filterCallbackHandler) MirrorRequest(address string, headers api.RequestHeaderMap, body api.BufferInstance) {
+	req := MirroredRequest{
+		Address: address,
+		Headers: http.Header{},
+	}
+	headers.Range(func(k, v string) bool {
+		req.Headers.Add(k, v)
+		return true
+	})
+	if body != nil {
+		req.Body = append([]byte{}, body.Bytes()...)
+	}
+
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.mirroredRequests = append(i.mirroredRequests, req)
+}
+
+func (i *filterCallbackHandler) MirroredRequests() []MirroredRequest {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	return i.mirroredRequests
+}
+
 func (i *filterCallbackHandler) WithLogArg(key string, value any) api.StreamFilterCallbacks {
 	return i
 }
@@ -615,8 +680,17 @@ type capiFilterCallbackHandler struct {
 	*filterCallbackHandler
 }
 
+// capiStreamInfo adapts the api.StreamInfo to the capi.StreamInfo
+type capiStreamInfo struct {
+	api.StreamInfo
+}
+
+func (i *capiStreamInfo) DynamicMetadata() capi.DynamicMetadata {
+	return i.StreamInfo.DynamicMetadata()
+}
+
 func (cb *capiFilterCallbackHandler) StreamInfo() capi.StreamInfo {
-	return cb.filterCallbackHandler.StreamInfo()
+	return &capiStreamInfo{cb.filterCallbackHandler.StreamInfo()}
 }
 
 var _ capi.FilterCallbackHandler = (*capiFilterCallbackHandler)(nil)
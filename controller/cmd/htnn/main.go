@@ -0,0 +1,179 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/render"
+	_ "mosn.io/htnn/controller/plugins"    // register plugins
+	_ "mosn.io/htnn/controller/registries" // register registries
+)
+
+const usage = `Usage: htnn <command> [flags]
+
+Commands:
+  render    Render the resources generated by the HTNN controller from the given resources,
+            without connecting to a cluster.
+`
+
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSlice) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "render":
+		os.Exit(runRender(os.Args[2:]))
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+}
+
+func runRender(args []string) int {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	var files stringSlice
+	fs.Var(&files, "f", "File or directory which contains the resources, `-` means stdin. Can be specified multiple times.")
+	namespace := fs.String("n", "default", "Namespace of the resources which don't specify one.")
+	strict := fs.Bool("strict", false, "Exit with non-zero code if any resource is rejected.")
+	format := fs.String("o", "envoyfilter", "Output format. `envoyfilter` outputs the EnvoyFilters for Istio, "+
+		"and `envoy` outputs the fragment of the configuration for the standalone Envoy.")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: htnn render -f FILE [-f FILE...] [flags]\n\n")
+		fs.PrintDefaults()
+	}
+	_ = fs.Parse(args)
+
+	if len(files) == 0 || (*format != "envoyfilter" && *format != "envoy") {
+		fs.Usage()
+		return 2
+	}
+
+	// The features are configured via the environment variables, like the controller
+	config.Init()
+
+	var objs []*unstructured.Unstructured
+	for _, f := range files {
+		res, err := readResources(f, *namespace)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to read %s: %v\n", f, err)
+			return 1
+		}
+		objs = append(objs, res...)
+	}
+
+	res, err := render.Render(context.Background(), objs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to render: %v\n", err)
+		return 1
+	}
+
+	if *format == "envoy" {
+		conf, skipped := render.ToEnvoyConfig(res.EnvoyFilters)
+		d, err := yaml.Marshal(conf)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to marshal Envoy configuration: %v\n", err)
+			return 1
+		}
+		fmt.Print(string(d))
+		res.Ignored = append(res.Ignored, skipped...)
+	} else {
+		for _, ef := range res.EnvoyFilters {
+			d, err := yaml.Marshal(ef)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to marshal EnvoyFilter %s/%s: %v\n", ef.Namespace, ef.Name, err)
+				return 1
+			}
+			fmt.Printf("---\n%s", d)
+		}
+	}
+
+	for _, msg := range res.Ignored {
+		fmt.Fprintf(os.Stderr, "ignored %s\n", msg)
+	}
+	for _, msg := range res.Rejected {
+		fmt.Fprintf(os.Stderr, "rejected %s\n", msg)
+	}
+	if *strict && len(res.Rejected) > 0 {
+		return 1
+	}
+	return 0
+}
+
+func readResources(path string, namespace string) ([]*unstructured.Unstructured, error) {
+	if path == "-" {
+		return render.Decode(os.Stdin, namespace)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return readFile(path, namespace)
+	}
+
+	var objs []*unstructured.Unstructured
+	err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(p)
+		if d.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			return nil
+		}
+		res, err := readFile(p, namespace)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		objs = append(objs, res...)
+		return nil
+	})
+	return objs, err
+}
+
+func readFile(path string, namespace string) ([]*unstructured.Unstructured, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return render.Decode(f, namespace)
+}
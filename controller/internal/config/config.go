@@ -15,13 +15,16 @@
 package config
 
 import (
+	"hash/fnv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 
 	"mosn.io/htnn/api/pkg/plugins"
 	"mosn.io/htnn/controller/internal/log"
+	"mosn.io/htnn/controller/pkg/constant"
 )
 
 func updateStringIfSet(vp *viper.Viper, key string, item *string) {
@@ -38,6 +41,20 @@ func updateBoolIfSet(vp *viper.Viper, key string, item *bool) {
 	}
 }
 
+func updateIntIfSet(vp *viper.Viper, key string, item *int) {
+	if vp.IsSet(key) {
+		*item = vp.GetInt(key)
+		return
+	}
+}
+
+func updateDurationIfSet(vp *viper.Viper, key string, item *time.Duration) {
+	if vp.IsSet(key) {
+		*item = vp.GetDuration(key)
+		return
+	}
+}
+
 var (
 	configLock sync.RWMutex
 )
@@ -62,6 +79,28 @@ func RootNamespace() string {
 	return rootNamespace
 }
 
+var istioRevision = ""
+
+// The Istio revision served by this controller. The generated EnvoyFilters are labeled with it, and
+// only the resources of this revision are reconciled, so that one controller can run per revision
+// during the canary upgrade of Istio.
+// This field is automatically configured when HTNN controller is run in the istiod.
+func IstioRevision() string {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return istioRevision
+}
+
+// InRevision returns true if the resource with the labels belongs to the revision served by this
+// controller. Like Istio, the resource without the revision label belongs to all the revisions.
+func InRevision(labels map[string]string) bool {
+	rev, ok := labels[constant.LabelIstioRevision]
+	if !ok {
+		return true
+	}
+	return rev == IstioRevision()
+}
+
 var enableGatewayAPI = true
 
 // If this is set to true, support for Kubernetes gateway-api will be enabled.
@@ -95,6 +134,16 @@ func EnableNativePlugin() bool {
 	return enableNativePlugin
 }
 
+var enableExperimentalPlugin = true
+
+// Enable experimental plugins. Operators who only want to run stable plugins can disable it.
+// Policies which refer to the disabled plugins will be rejected by the webhook.
+func EnableExperimentalPlugin() bool {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return enableExperimentalPlugin
+}
+
 // LDS Plugin Via ECDS is disabled by default, because
 // 1. Per-LDS ECDS may be expensive in some cases.
 // 2. We can't disable a LDS plugin via ECDS. So every route under this LDS will execute it.
@@ -123,6 +172,177 @@ func UseWildcardIPv6InLDSName() bool {
 	return useWildcardIPv6InLDSName
 }
 
+var sealedValueKey = ""
+
+// The base64 encoded AES key used to decrypt the sealed value in the plugin configuration.
+// The key should be 16, 24 or 32 bytes long after decoding.
+func SealedValueKey() string {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return sealedValueKey
+}
+
+var keyIssuerAddr = ""
+
+// The address to serve the endpoint which issues the keyAuth keys for the consumers.
+// The endpoint is disabled if it's empty.
+func KeyIssuerAddr() string {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return keyIssuerAddr
+}
+
+var keyIssuerCertFile = ""
+
+// The certificate file used by the key issuer endpoint, which is served over TLS.
+func KeyIssuerCertFile() string {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return keyIssuerCertFile
+}
+
+var keyIssuerKeyFile = ""
+
+// The private key file of the key issuer endpoint's certificate.
+func KeyIssuerKeyFile() string {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return keyIssuerKeyFile
+}
+
+var rolloutPrometheusAddr = ""
+
+// The address of the Prometheus server which runs the analysis of the FilterPolicy rollout,
+// like `http://prometheus.istio-system:9090`.
+func RolloutPrometheusAddr() string {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return rolloutPrometheusAddr
+}
+
+var configDumpAddr = ""
+
+// The address to serve the endpoint which dumps the generated configuration and diffs its revisions.
+// The endpoint is disabled if it's empty.
+func ConfigDumpAddr() string {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return configDumpAddr
+}
+
+var configDumpToken = ""
+
+// The bearer token required by the config dump endpoint.
+func ConfigDumpToken() string {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return configDumpToken
+}
+
+var secretReconcileDebounce = time.Second
+
+// The window to wait before reconciling the resources which refer to the changed Secret, so that
+// the Secrets changed together only trigger one reconciliation.
+func SecretReconcileDebounce() time.Duration {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return secretReconcileDebounce
+}
+
+var shardNamespaces []string
+var shardCount = 1
+var shardIndex = 0
+
+// ShardEnabled returns true when the controller only reconciles the resources in part of the namespaces,
+// so that the reconciliation can be split across multiple controllers.
+func ShardEnabled() bool {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return len(shardNamespaces) > 0 || shardCount > 1
+}
+
+// InShard returns true if the resources in the namespace should be reconciled by this controller.
+// If the namespaces of the shard are specified, only the listed namespaces are in the shard. Otherwise,
+// the namespaces are distributed into the shards by their hash.
+func InShard(namespace string) bool {
+	configLock.RLock()
+	defer configLock.RUnlock()
+
+	if len(shardNamespaces) > 0 {
+		for _, ns := range shardNamespaces {
+			if ns == namespace {
+				return true
+			}
+		}
+		return false
+	}
+	if shardCount <= 1 {
+		return true
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(namespace))
+	return int(h.Sum32()%uint32(shardCount)) == shardIndex
+}
+
+var maxPoliciesPerNamespace = 0
+
+// MaxPoliciesPerNamespace returns the max number of FilterPolicies and HTTPFilterPolicies in a namespace.
+// Zero means unlimited.
+func MaxPoliciesPerNamespace() int {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return maxPoliciesPerNamespace
+}
+
+var maxConsumersPerNamespace = 0
+
+// MaxConsumersPerNamespace returns the max number of Consumers in a namespace. Zero means unlimited.
+func MaxConsumersPerNamespace() int {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return maxConsumersPerNamespace
+}
+
+var maxEnvoyFilterSize = 0
+
+// MaxEnvoyFilterSize returns the max size in bytes of a generated EnvoyFilter. Zero means unlimited.
+func MaxEnvoyFilterSize() int {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return maxEnvoyFilterSize
+}
+
+var watchNamespaceSelector = ""
+
+// WatchNamespaceSelector returns the label selector of the namespaces whose resources are reconciled.
+// All the namespaces are watched if it's empty.
+func WatchNamespaceSelector() string {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return watchNamespaceSelector
+}
+
+var informerResyncPeriod time.Duration
+
+// InformerResyncPeriod returns the resync period of the informers started by the controller itself.
+// Zero disables the resync.
+func InformerResyncPeriod() time.Duration {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return informerResyncPeriod
+}
+
+var stripUnusedFields = true
+
+// StripUnusedFields returns true if the fields not used by the controller, like the managed fields,
+// should be removed from the cached objects to reduce the memory usage.
+func StripUnusedFields() bool {
+	configLock.RLock()
+	defer configLock.RUnlock()
+	return stripUnusedFields
+}
+
 type envStringReplacer struct {
 }
 
@@ -140,21 +360,59 @@ func Init() {
 	// a config item `envoy.go_so_path` can be set with env `HTNN_ENVOY_GO_SO_PATH`
 
 	updateStringIfSet(vp, "envoy.go_so_path", &goSoPath)
+	updateStringIfSet(vp, "sealed_value_key", &sealedValueKey)
+	updateStringIfSet(vp, "key_issuer.addr", &keyIssuerAddr)
+	updateStringIfSet(vp, "key_issuer.cert_file", &keyIssuerCertFile)
+	updateStringIfSet(vp, "key_issuer.key_file", &keyIssuerKeyFile)
+	updateStringIfSet(vp, "rollout.prometheus_addr", &rolloutPrometheusAddr)
+	updateStringIfSet(vp, "config_dump.addr", &configDumpAddr)
+	updateStringIfSet(vp, "config_dump.token", &configDumpToken)
+	updateDurationIfSet(vp, "secret_reconcile_debounce", &secretReconcileDebounce)
+
+	updateStringIfSet(vp, "watch_namespace_selector", &watchNamespaceSelector)
+	updateDurationIfSet(vp, "informer_resync_period", &informerResyncPeriod)
+	updateBoolIfSet(vp, "strip_unused_fields", &stripUnusedFields)
+
+	var namespaces string
+	updateStringIfSet(vp, "shard.namespaces", &namespaces)
+	shardNamespaces = nil
+	for _, ns := range strings.Split(namespaces, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns != "" {
+			shardNamespaces = append(shardNamespaces, ns)
+		}
+	}
+	updateIntIfSet(vp, "shard.count", &shardCount)
+	updateIntIfSet(vp, "shard.index", &shardIndex)
+
+	updateIntIfSet(vp, "quota.max_policies_per_namespace", &maxPoliciesPerNamespace)
+	updateIntIfSet(vp, "quota.max_consumers_per_namespace", &maxConsumersPerNamespace)
+	updateIntIfSet(vp, "quota.max_envoy_filter_size", &maxEnvoyFilterSize)
 
 	updateBoolIfSet(vp, "enable_embedded_mode", &enableEmbeddedMode)
 	updateBoolIfSet(vp, "enable_native_plugin", &enableNativePlugin)
+	updateBoolIfSet(vp, "enable_experimental_plugin", &enableExperimentalPlugin)
 	updateBoolIfSet(vp, "enable_lds_plugin_via_ecds", &enableLDSPluginViaECDS)
 	updateBoolIfSet(vp, "use_wildcard_ipv6_in_lds_name", &useWildcardIPv6InLDSName)
 
 	// The configuration below is set via the Istio directly, not via the environment variables
 	// provided when starting the Istio.
 	updateStringIfSet(vp, "istio.root_namespace", &rootNamespace)
+	// reset, so that the revision can be removed
+	istioRevision = ""
+	updateStringIfSet(vp, "istio.revision", &istioRevision)
 	updateBoolIfSet(vp, "enable_gateway_api", &enableGatewayAPI)
 
 	postInit()
 }
 
 func postInit() {
+	if shardCount < 1 || shardIndex < 0 || shardIndex >= shardCount {
+		log.Errorf("invalid shard index %d with shard count %d, disable sharding", shardIndex, shardCount)
+		shardCount = 1
+		shardIndex = 0
+	}
+
 	if !enableNativePlugin {
 		log.Infof("native plugin disabled by configured")
 		plugins.IteratePlugin(func(key string, value plugins.Plugin) bool {
@@ -163,9 +421,21 @@ func postInit() {
 				return true
 			}
 
-			plugins.DisablePlugin(key)
+			plugins.DisablePluginWithReason(key, "native plugin is disabled by configuration")
 			return true
 		})
 
 	}
+
+	if !enableExperimentalPlugin {
+		log.Infof("experimental plugin disabled by configured")
+		plugins.IteratePluginType(func(key string, value plugins.Plugin) bool {
+			if value.Maturity() != plugins.MaturityExperimental {
+				return true
+			}
+
+			plugins.DisablePluginWithReason(key, "experimental plugin is disabled by configuration")
+			return true
+		})
+	}
 }
@@ -16,7 +16,9 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -25,10 +27,25 @@ func setEnvForTest() {
 	os.Setenv("HTNN_ENABLE_GATEWAY_API", "false")
 	os.Setenv("HTNN_ENABLE_EMBEDDED_MODE", "false")
 	os.Setenv("HTNN_ENABLE_NATIVE_PLUGIN", "false")
+	os.Setenv("HTNN_ENABLE_EXPERIMENTAL_PLUGIN", "false")
 	os.Setenv("HTNN_ENVOY_GO_SO_PATH", "/usr/local/golang.so")
 	os.Setenv("HTNN_ISTIO_ROOT_NAMESPACE", "htnn")
+	os.Setenv("HTNN_ISTIO_REVISION", "canary")
 	os.Setenv("HTNN_ENABLE_LDS_PLUGIN_VIA_ECDS", "true")
 	os.Setenv("HTNN_USE_WILDCARD_IPV6_IN_LDS_NAME", "true")
+	os.Setenv("HTNN_SEALED_VALUE_KEY", "a2V5")
+	os.Setenv("HTNN_SHARD_NAMESPACES", "ns1, ns2")
+	os.Setenv("HTNN_SECRET_RECONCILE_DEBOUNCE", "5s")
+	os.Setenv("HTNN_QUOTA_MAX_POLICIES_PER_NAMESPACE", "100")
+	os.Setenv("HTNN_QUOTA_MAX_CONSUMERS_PER_NAMESPACE", "1000")
+	os.Setenv("HTNN_QUOTA_MAX_ENVOY_FILTER_SIZE", "1048576")
+	os.Setenv("HTNN_WATCH_NAMESPACE_SELECTOR", "htnn.mosn.io/watch=true")
+	os.Setenv("HTNN_INFORMER_RESYNC_PERIOD", "10m")
+	os.Setenv("HTNN_STRIP_UNUSED_FIELDS", "false")
+	os.Setenv("HTNN_KEY_ISSUER_ADDR", ":8090")
+	os.Setenv("HTNN_KEY_ISSUER_CERT_FILE", "/etc/htnn/tls.crt")
+	os.Setenv("HTNN_KEY_ISSUER_KEY_FILE", "/etc/htnn/tls.key")
+	os.Setenv("HTNN_ROLLOUT_PROMETHEUS_ADDR", "http://prometheus:9090")
 }
 
 func TestInit(t *testing.T) {
@@ -38,10 +55,28 @@ func TestInit(t *testing.T) {
 	assert.Equal(t, true, EnableGatewayAPI())
 	assert.Equal(t, true, EnableEmbeddedMode())
 	assert.Equal(t, true, EnableNativePlugin())
+	assert.Equal(t, true, EnableExperimentalPlugin())
 	assert.Equal(t, "/etc/libgolang.so", GoSoPath())
 	assert.Equal(t, "istio-system", RootNamespace())
+	assert.Equal(t, "", IstioRevision())
+	assert.Equal(t, true, InRevision(nil))
+	assert.Equal(t, false, InRevision(map[string]string{"istio.io/rev": "canary"}))
 	assert.Equal(t, false, EnableLDSPluginViaECDS())
 	assert.Equal(t, false, UseWildcardIPv6InLDSName())
+	assert.Equal(t, "", SealedValueKey())
+	assert.Equal(t, time.Second, SecretReconcileDebounce())
+	assert.Equal(t, false, ShardEnabled())
+	assert.Equal(t, true, InShard("ns3"))
+	assert.Equal(t, 0, MaxPoliciesPerNamespace())
+	assert.Equal(t, 0, MaxConsumersPerNamespace())
+	assert.Equal(t, 0, MaxEnvoyFilterSize())
+	assert.Equal(t, "", WatchNamespaceSelector())
+	assert.Equal(t, time.Duration(0), InformerResyncPeriod())
+	assert.Equal(t, true, StripUnusedFields())
+	assert.Equal(t, "", KeyIssuerAddr())
+	assert.Equal(t, "", KeyIssuerCertFile())
+	assert.Equal(t, "", KeyIssuerKeyFile())
+	assert.Equal(t, "", RolloutPrometheusAddr())
 
 	setEnvForTest()
 	Init()
@@ -49,8 +84,58 @@ func TestInit(t *testing.T) {
 	assert.Equal(t, false, EnableGatewayAPI())
 	assert.Equal(t, false, EnableEmbeddedMode())
 	assert.Equal(t, false, EnableNativePlugin())
+	assert.Equal(t, false, EnableExperimentalPlugin())
 	assert.Equal(t, "/usr/local/golang.so", GoSoPath())
 	assert.Equal(t, "htnn", RootNamespace())
+	assert.Equal(t, "canary", IstioRevision())
+	assert.Equal(t, true, InRevision(nil))
+	assert.Equal(t, true, InRevision(map[string]string{"istio.io/rev": "canary"}))
+	assert.Equal(t, false, InRevision(map[string]string{"istio.io/rev": "stable"}))
 	assert.Equal(t, true, EnableLDSPluginViaECDS())
 	assert.Equal(t, true, UseWildcardIPv6InLDSName())
+	assert.Equal(t, "a2V5", SealedValueKey())
+	assert.Equal(t, 5*time.Second, SecretReconcileDebounce())
+	assert.Equal(t, true, ShardEnabled())
+	assert.Equal(t, true, InShard("ns2"))
+	assert.Equal(t, false, InShard("ns3"))
+	assert.Equal(t, 100, MaxPoliciesPerNamespace())
+	assert.Equal(t, 1000, MaxConsumersPerNamespace())
+	assert.Equal(t, 1048576, MaxEnvoyFilterSize())
+	assert.Equal(t, "htnn.mosn.io/watch=true", WatchNamespaceSelector())
+	assert.Equal(t, 10*time.Minute, InformerResyncPeriod())
+	assert.Equal(t, false, StripUnusedFields())
+	assert.Equal(t, ":8090", KeyIssuerAddr())
+	assert.Equal(t, "/etc/htnn/tls.crt", KeyIssuerCertFile())
+	assert.Equal(t, "/etc/htnn/tls.key", KeyIssuerKeyFile())
+	assert.Equal(t, "http://prometheus:9090", RolloutPrometheusAddr())
+}
+
+func TestInShardByHash(t *testing.T) {
+	t.Setenv("HTNN_SHARD_NAMESPACES", "")
+	t.Setenv("HTNN_SHARD_COUNT", "3")
+
+	owners := map[string]int{}
+	namespaces := []string{"default", "istio-system", "ns1", "ns2", "ns3", "ns4"}
+	for i := 0; i < 3; i++ {
+		t.Setenv("HTNN_SHARD_INDEX", strconv.Itoa(i))
+		Init()
+		assert.Equal(t, true, ShardEnabled())
+		for _, ns := range namespaces {
+			if InShard(ns) {
+				owners[ns]++
+			}
+		}
+	}
+	// each namespace belongs to exactly one shard
+	for _, ns := range namespaces {
+		assert.Equal(t, 1, owners[ns], ns)
+	}
+
+	t.Setenv("HTNN_SHARD_INDEX", "3")
+	Init()
+	assert.Equal(t, false, ShardEnabled())
+
+	t.Setenv("HTNN_SHARD_COUNT", "1")
+	t.Setenv("HTNN_SHARD_INDEX", "0")
+	Init()
 }
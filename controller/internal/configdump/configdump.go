@@ -0,0 +1,448 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package configdump keeps the recent revisions of the EnvoyFilters generated from the FilterPolicies,
+// so that the operators can find out what is changed in the data plane after a policy is edited.
+package configdump
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pmezard/go-difflib/difflib"
+	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/controller/internal/log"
+	"mosn.io/htnn/controller/pkg/component"
+)
+
+const (
+	// MaxRevisions is the number of the revisions kept in memory
+	MaxRevisions = 16
+)
+
+var (
+	ErrRevisionNotFound = errors.New("revision not found")
+)
+
+func compareKeys(a, b component.EnvoyFilterKey) int {
+	if a.Namespace != b.Namespace {
+		return strings.Compare(a.Namespace, b.Namespace)
+	}
+	return strings.Compare(a.Name, b.Name)
+}
+
+type revision struct {
+	id        int64
+	timestamp time.Time
+	// the EnvoyFilters are stored in the serialized form, as the generated objects may be modified
+	// after they are written
+	envoyFilters map[component.EnvoyFilterKey][]byte
+	targets      map[string][]component.EnvoyFilterKey
+}
+
+// envoyFiltersOf returns the EnvoyFilters generated for the target, including the ones for the canary
+// versions in rollout
+func (rev *revision) envoyFiltersOf(target string) ([]component.EnvoyFilterKey, bool) {
+	keys, ok := rev.targets[target]
+	if !ok {
+		return nil, false
+	}
+
+	res := []component.EnvoyFilterKey{}
+	for key := range rev.envoyFilters {
+		for _, k := range keys {
+			if key.Namespace == k.Namespace &&
+				(key.Name == k.Name || strings.HasPrefix(key.Name, k.Name+"-canary-")) {
+				res = append(res, key)
+				break
+			}
+		}
+	}
+	slices.SortFunc(res, compareKeys)
+	return res, true
+}
+
+// redactPlugins masks the sensitive fields in the plugin configurations, as the Secret references and
+// the sealed values are already resolved in the generated EnvoyFilters.
+func redactPlugins(node interface{}) {
+	switch v := node.(type) {
+	case []interface{}:
+		for _, elem := range v {
+			redactPlugins(elem)
+		}
+	case map[string]interface{}:
+		if name, ok := v["name"].(string); ok {
+			if conf, ok := v["config"]; ok {
+				plugins.RedactSensitiveFields(conf, plugins.LoadSensitiveFields(name))
+			}
+		}
+		for _, child := range v {
+			redactPlugins(child)
+		}
+	}
+}
+
+func marshalEnvoyFilter(ef *istiov1a3.EnvoyFilter) ([]byte, error) {
+	b, err := json.Marshal(ef)
+	if err != nil {
+		return nil, err
+	}
+	var res interface{}
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+	redactPlugins(res)
+	return json.MarshalIndent(res, "", "  ")
+}
+
+// Store records the EnvoyFilters generated in each reconciliation as a revision. Only the last
+// MaxRevisions revisions are kept.
+type Store struct {
+	lock      sync.RWMutex
+	lastID    int64
+	revisions []*revision
+}
+
+// Record adds a new revision if the EnvoyFilters or the targets are changed
+func (s *Store) Record(efs map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter, targets map[string][]component.EnvoyFilterKey) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var last *revision
+	if len(s.revisions) > 0 {
+		last = s.revisions[len(s.revisions)-1]
+	}
+
+	changed := last == nil || len(last.envoyFilters) != len(efs) || len(last.targets) != len(targets)
+	serialized := make(map[component.EnvoyFilterKey][]byte, len(efs))
+	for key, ef := range efs {
+		b, err := marshalEnvoyFilter(ef)
+		if err != nil {
+			log.Errorf("failed to marshal EnvoyFilter %s/%s: %v", key.Namespace, key.Name, err)
+			continue
+		}
+		if last != nil {
+			if prev, ok := last.envoyFilters[key]; ok && bytes.Equal(prev, b) {
+				// share the unchanged EnvoyFilters between the revisions
+				serialized[key] = prev
+				continue
+			}
+		}
+		serialized[key] = b
+		changed = true
+	}
+	if !changed {
+		for target, keys := range targets {
+			if !slices.Equal(last.targets[target], keys) {
+				changed = true
+				break
+			}
+		}
+	}
+	if !changed {
+		return
+	}
+
+	s.lastID++
+	s.revisions = append(s.revisions, &revision{
+		id:           s.lastID,
+		timestamp:    time.Now(),
+		envoyFilters: serialized,
+		targets:      targets,
+	})
+	if len(s.revisions) > MaxRevisions {
+		s.revisions = s.revisions[len(s.revisions)-MaxRevisions:]
+	}
+}
+
+// get returns the revision with the given id. Zero means the latest one.
+func (s *Store) get(id int64) (*revision, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if len(s.revisions) == 0 {
+		return nil, ErrRevisionNotFound
+	}
+	if id == 0 {
+		return s.revisions[len(s.revisions)-1], nil
+	}
+	for _, rev := range s.revisions {
+		if rev.id == id {
+			return rev, nil
+		}
+	}
+	return nil, ErrRevisionNotFound
+}
+
+// RevisionInfo describes a revision
+type RevisionInfo struct {
+	Revision  int64     `json:"revision"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Revisions lists the kept revisions, from the oldest to the latest
+func (s *Store) Revisions() []RevisionInfo {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	res := make([]RevisionInfo, 0, len(s.revisions))
+	for _, rev := range s.revisions {
+		res = append(res, RevisionInfo{Revision: rev.id, Timestamp: rev.timestamp})
+	}
+	return res
+}
+
+// Dump is the configuration generated for a target in a revision
+type Dump struct {
+	RevisionInfo
+	Target       string            `json:"target"`
+	EnvoyFilters []json.RawMessage `json:"envoyFilters"`
+}
+
+// Dump returns the EnvoyFilters generated for the target, which is a gateway or a route named as
+// `$namespace/$name`, in the given revision. Zero means the latest revision.
+func (s *Store) Dump(target string, id int64) (*Dump, error) {
+	rev, err := s.get(id)
+	if err != nil {
+		return nil, err
+	}
+	keys, ok := rev.envoyFiltersOf(target)
+	if !ok {
+		return nil, fmt.Errorf("target %s not found in revision %d", target, rev.id)
+	}
+
+	dump := &Dump{
+		RevisionInfo: RevisionInfo{Revision: rev.id, Timestamp: rev.timestamp},
+		Target:       target,
+		EnvoyFilters: make([]json.RawMessage, 0, len(keys)),
+	}
+	for _, key := range keys {
+		dump.EnvoyFilters = append(dump.EnvoyFilters, rev.envoyFilters[key])
+	}
+	return dump, nil
+}
+
+// Diff returns the unified diff of the EnvoyFilters generated for the target between two revisions.
+// Zero `to` means the latest revision, and zero `from` means the revision before `to`.
+// The target missing in one of the revisions is treated as having no EnvoyFilters.
+func (s *Store) Diff(target string, from int64, to int64) (string, error) {
+	toRev, err := s.get(to)
+	if err != nil {
+		return "", err
+	}
+	if from == 0 {
+		from = toRev.id - 1
+		if from == 0 {
+			// there is no revision before the first one
+			return "", ErrRevisionNotFound
+		}
+	}
+	fromRev, err := s.get(from)
+	if err != nil {
+		return "", err
+	}
+
+	fromKeys, foundInFrom := fromRev.envoyFiltersOf(target)
+	toKeys, foundInTo := toRev.envoyFiltersOf(target)
+	if !foundInFrom && !foundInTo {
+		return "", fmt.Errorf("target %s not found in revision %d and %d", target, fromRev.id, toRev.id)
+	}
+
+	keys := append(slices.Clone(fromKeys), toKeys...)
+	slices.SortFunc(keys, compareKeys)
+	keys = slices.Compact(keys)
+
+	var buf strings.Builder
+	for _, key := range keys {
+		var a, b []string
+		if slices.Contains(fromKeys, key) {
+			a = difflib.SplitLines(string(fromRev.envoyFilters[key]))
+		}
+		if slices.Contains(toKeys, key) {
+			b = difflib.SplitLines(string(toRev.envoyFilters[key]))
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        a,
+			B:        b,
+			FromFile: fmt.Sprintf("%s/%s@%d", key.Namespace, key.Name, fromRev.id),
+			ToFile:   fmt.Sprintf("%s/%s@%d", key.Namespace, key.Name, toRev.id),
+			Context:  3,
+		})
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(diff)
+	}
+	return buf.String(), nil
+}
+
+var store = &Store{}
+
+var (
+	enabledLock sync.RWMutex
+	enabled     bool
+)
+
+// Record adds a new revision to the global store, if the config dump endpoint is enabled
+func Record(efs map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter, targets map[string][]component.EnvoyFilterKey) {
+	enabledLock.RLock()
+	on := enabled
+	enabledLock.RUnlock()
+	if !on {
+		return
+	}
+	store.Record(efs, targets)
+}
+
+// Handler serves the endpoints below. The request should carry the token in the
+// `Authorization: Bearer $token` header.
+//
+// * `GET /revisions`: lists the kept revisions.
+// * `GET /targets/$namespace/$name?revision=$id`: dumps the EnvoyFilters generated for the gateway or
+// the route in the given revision. The latest revision is used if it's not specified.
+// * `GET /targets/$namespace/$name/diff?from=$id&to=$id`: shows what is changed in the EnvoyFilters
+// generated for the gateway or the route between two revisions.
+type Handler struct {
+	Store *Store
+	Token string
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"msg": msg})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("content-type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (h *Handler) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(h.Token)) == 1
+}
+
+func parseRevision(r *http.Request, name string) (int64, error) {
+	s := r.URL.Query().Get(name)
+	if s == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid %s: %s", name, s)
+	}
+	return id, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	segs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	isRevisions := len(segs) == 1 && segs[0] == "revisions"
+	isDump := len(segs) == 3 && segs[0] == "targets" && segs[1] != "" && segs[2] != ""
+	isDiff := len(segs) == 4 && segs[0] == "targets" && segs[1] != "" && segs[2] != "" && segs[3] == "diff"
+	if !isRevisions && !isDump && !isDiff {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if isRevisions {
+		writeJSON(w, h.Store.Revisions())
+		return
+	}
+
+	target := segs[1] + "/" + segs[2]
+	if isDump {
+		id, err := parseRevision(r, "revision")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		dump, err := h.Store.Dump(target, id)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeJSON(w, dump)
+		return
+	}
+
+	from, err := parseRevision(r, "from")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	to, err := parseRevision(r, "to")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	diff, err := h.Store.Diff(target, from, to)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	w.Header().Set("content-type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(diff))
+}
+
+// Start serves the Handler on the given address until the stop channel is closed
+func Start(addr string, token string, stop <-chan struct{}) error {
+	if token == "" {
+		return errors.New("token is required to serve the config dump")
+	}
+
+	srv := &http.Server{
+		Addr: addr,
+		Handler: &Handler{
+			Store: store,
+			Token: token,
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	enabledLock.Lock()
+	enabled = true
+	enabledLock.Unlock()
+	go func() {
+		<-stop
+		srv.Close()
+	}()
+	go func() {
+		log.Infof("config dump listens on %s", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("config dump exited: %v", err)
+		}
+	}()
+	return nil
+}
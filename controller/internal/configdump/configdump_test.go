@@ -0,0 +1,291 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configdump
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istioapi "istio.io/api/networking/v1alpha3"
+	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/controller/internal/istio"
+	"mosn.io/htnn/controller/pkg/component"
+)
+
+var (
+	hostKey   = component.EnvoyFilterKey{Namespace: "default", Name: "htnn-h-httpbin.example.com"}
+	canaryKey = component.EnvoyFilterKey{Namespace: "default", Name: "htnn-h-httpbin.example.com-canary-0"}
+	ldsKey    = component.EnvoyFilterKey{Namespace: "default", Name: "htnn-lds-0.0.0.0-80"}
+
+	targets = map[string][]component.EnvoyFilterKey{
+		"default/gateway": {hostKey, ldsKey},
+		"default/vs":      {hostKey},
+	}
+)
+
+func newEnvoyFilter(key component.EnvoyFilterKey, priority int32) *istiov1a3.EnvoyFilter {
+	return &istiov1a3.EnvoyFilter{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec:       istioapi.EnvoyFilter{Priority: priority},
+	}
+}
+
+func TestStore(t *testing.T) {
+	s := &Store{}
+	_, err := s.Dump("default/vs", 0)
+	assert.ErrorIs(t, err, ErrRevisionNotFound)
+
+	s.Record(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		hostKey: newEnvoyFilter(hostKey, 1),
+		ldsKey:  newEnvoyFilter(ldsKey, 1),
+	}, targets)
+	// nothing changed
+	s.Record(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		hostKey: newEnvoyFilter(hostKey, 1),
+		ldsKey:  newEnvoyFilter(ldsKey, 1),
+	}, targets)
+	require.Len(t, s.Revisions(), 1)
+
+	dump, err := s.Dump("default/vs", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), dump.Revision)
+	require.Len(t, dump.EnvoyFilters, 1)
+	assert.Contains(t, string(dump.EnvoyFilters[0]), `"name": "htnn-h-httpbin.example.com"`)
+	dump, err = s.Dump("default/gateway", 1)
+	require.NoError(t, err)
+	assert.Len(t, dump.EnvoyFilters, 2)
+	_, err = s.Dump("default/unknown", 0)
+	assert.EqualError(t, err, "target default/unknown not found in revision 1")
+	_, err = s.Diff("default/vs", 0, 0)
+	assert.ErrorIs(t, err, ErrRevisionNotFound)
+
+	// the canary version is added
+	s.Record(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		hostKey:   newEnvoyFilter(hostKey, 1),
+		canaryKey: newEnvoyFilter(canaryKey, 2),
+		ldsKey:    newEnvoyFilter(ldsKey, 1),
+	}, targets)
+	dump, err = s.Dump("default/vs", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), dump.Revision)
+	assert.Len(t, dump.EnvoyFilters, 2)
+
+	diff, err := s.Diff("default/vs", 0, 0)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "+++ default/htnn-h-httpbin.example.com-canary-0@2")
+	assert.Contains(t, diff, `+    "priority": 2`)
+	assert.NotContains(t, diff, "htnn-lds-0.0.0.0-80")
+
+	// the route is removed
+	s.Record(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		ldsKey: newEnvoyFilter(ldsKey, 1),
+	}, map[string][]component.EnvoyFilterKey{
+		"default/gateway": {ldsKey},
+	})
+	diff, err = s.Diff("default/vs", 1, 3)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- default/htnn-h-httpbin.example.com@1")
+	assert.Contains(t, diff, `-    "priority": 1`)
+	diff, err = s.Diff("default/gateway", 2, 2)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+	_, err = s.Diff("default/unknown", 1, 3)
+	assert.EqualError(t, err, "target default/unknown not found in revision 1 and 3")
+
+	// only the recent revisions are kept
+	for i := 0; i < MaxRevisions; i++ {
+		s.Record(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+			ldsKey: newEnvoyFilter(ldsKey, int32(i+2)),
+		}, targets)
+	}
+	revs := s.Revisions()
+	require.Len(t, revs, MaxRevisions)
+	assert.Equal(t, int64(4), revs[0].Revision)
+	assert.Equal(t, int64(MaxRevisions+3), revs[MaxRevisions-1].Revision)
+	_, err = s.Dump("default/gateway", 3)
+	assert.ErrorIs(t, err, ErrRevisionNotFound)
+}
+
+type sensitiveConfig struct {
+	plugins.MockPluginConfig
+}
+
+func (c *sensitiveConfig) SensitiveFields() []string {
+	return []string{"password"}
+}
+
+type sensitivePlugin struct {
+	plugins.MockPlugin
+}
+
+func (p *sensitivePlugin) Config() api.PluginConfig {
+	return &sensitiveConfig{}
+}
+
+func TestStoreRedactSensitiveFields(t *testing.T) {
+	plugins.RegisterPluginType("sensitive", &sensitivePlugin{})
+
+	ef := newEnvoyFilter(hostKey, 1)
+	ef.Spec.ConfigPatches = []*istioapi.EnvoyFilter_EnvoyConfigObjectPatch{
+		{
+			ApplyTo: istioapi.EnvoyFilter_HTTP_ROUTE,
+			Patch: &istioapi.EnvoyFilter_Patch{
+				Operation: istioapi.EnvoyFilter_Patch_MERGE,
+				Value: istio.MustNewStruct(map[string]interface{}{
+					"plugins": []interface{}{
+						map[string]interface{}{
+							"name": "sensitive",
+							"config": map[string]interface{}{
+								"user":     "admin",
+								"password": "resolved password",
+							},
+						},
+					},
+				}),
+			},
+		},
+	}
+	s := &Store{}
+	s.Record(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		hostKey: ef,
+	}, targets)
+
+	dump, err := s.Dump("default/vs", 0)
+	require.NoError(t, err)
+	require.Len(t, dump.EnvoyFilters, 1)
+	out := string(dump.EnvoyFilters[0])
+	assert.NotContains(t, out, "resolved password")
+	assert.Contains(t, out, `"password": "`+plugins.RedactedValue+`"`)
+	assert.Contains(t, out, `"user": "admin"`)
+}
+
+func TestHandler(t *testing.T) {
+	s := &Store{}
+	s.Record(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		hostKey: newEnvoyFilter(hostKey, 1),
+	}, targets)
+	s.Record(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		hostKey: newEnvoyFilter(hostKey, 2),
+	}, targets)
+	h := &Handler{
+		Store: s,
+		Token: "token",
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		code   int
+		body   string
+	}{
+		{
+			name:   "revisions",
+			method: http.MethodGet,
+			path:   "/revisions",
+			token:  "token",
+			code:   http.StatusOK,
+			body:   `"revision":2`,
+		},
+		{
+			name:   "dump",
+			method: http.MethodGet,
+			path:   "/targets/default/vs?revision=1",
+			token:  "token",
+			code:   http.StatusOK,
+			body:   `"target":"default/vs"`,
+		},
+		{
+			name:   "diff",
+			method: http.MethodGet,
+			path:   "/targets/default/vs/diff",
+			token:  "token",
+			code:   http.StatusOK,
+			body:   `+    "priority": 2`,
+		},
+		{
+			name:   "unauthorized",
+			method: http.MethodGet,
+			path:   "/revisions",
+			token:  "other",
+			code:   http.StatusUnauthorized,
+		},
+		{
+			name:   "target not found",
+			method: http.MethodGet,
+			path:   "/targets/default/unknown",
+			token:  "token",
+			code:   http.StatusNotFound,
+		},
+		{
+			name:   "revision not found",
+			method: http.MethodGet,
+			path:   "/targets/default/vs/diff?from=1&to=3",
+			token:  "token",
+			code:   http.StatusNotFound,
+		},
+		{
+			name:   "invalid revision",
+			method: http.MethodGet,
+			path:   "/targets/default/vs?revision=-1",
+			token:  "token",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "unknown path",
+			method: http.MethodGet,
+			path:   "/targets/default",
+			token:  "token",
+			code:   http.StatusNotFound,
+		},
+		{
+			name:   "bad method",
+			method: http.MethodPost,
+			path:   "/revisions",
+			token:  "token",
+			code:   http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			assert.Equal(t, tt.code, rec.Code)
+			if tt.body != "" {
+				assert.Contains(t, rec.Body.String(), tt.body)
+			}
+			if tt.code != http.StatusOK {
+				var res map[string]string
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				assert.NotEmpty(t, res["msg"])
+			} else if strings.HasSuffix(req.URL.Path, "/diff") {
+				assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/events"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+// policyAttachments records the targets which the policies are attached to during the reconciliation.
+// After the reconciliation succeeds, the difference from the last one is emitted as the events
+// on the targets, so that one can find out which policies are attached via `kubectl describe`.
+type policyAttachments struct {
+	current map[string]client.Object
+	next    map[string]client.Object
+}
+
+func policyDisplayName(policy *mosniov1.FilterPolicy) string {
+	kind := "FilterPolicy"
+	if policy.FromHTTPFilterPolicy() {
+		kind = "HTTPFilterPolicy"
+	}
+	return fmt.Sprintf("%s %s/%s", kind, policy.Namespace, policy.Name)
+}
+
+func sameTarget(a, b client.Object) bool {
+	return fmt.Sprintf("%T", a) == fmt.Sprintf("%T", b) &&
+		a.GetNamespace() == b.GetNamespace() && a.GetName() == b.GetName() && a.GetUID() == b.GetUID()
+}
+
+func (a *policyAttachments) record(policy *mosniov1.FilterPolicy, target client.Object) {
+	// The embedded policy is a part of the target, and the policy in the other shard is
+	// reported by the controller which owns it.
+	if policy.UID == "" || !config.InShard(policy.Namespace) {
+		return
+	}
+	if a.next == nil {
+		a.next = make(map[string]client.Object)
+	}
+	a.next[policyDisplayName(policy)] = target
+}
+
+// discard drops the targets recorded in the failed reconciliation
+func (a *policyAttachments) discard() {
+	a.next = nil
+}
+
+func (a *policyAttachments) commit() {
+	for name, target := range a.current {
+		if newTarget, ok := a.next[name]; !ok || !sameTarget(target, newTarget) {
+			events.Normal(target, events.ReasonDetached, name+" is detached")
+		}
+	}
+	for name, target := range a.next {
+		if oldTarget, ok := a.current[name]; !ok || !sameTarget(oldTarget, target) {
+			events.Normal(target, events.ReasonAttached, name+" is attached")
+		}
+	}
+	a.current = a.next
+	a.next = nil
+}
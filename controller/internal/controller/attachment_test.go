@@ -0,0 +1,75 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"mosn.io/htnn/controller/internal/events"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+func TestPolicyAttachments(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	events.SetRecorder(recorder)
+	defer events.SetRecorder(nil)
+
+	policy := &mosniov1.FilterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy", UID: "1"},
+	}
+	vs := &istiov1a3.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "vs", UID: "2"},
+	}
+	vs2 := &istiov1a3.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "vs2", UID: "3"},
+	}
+	embedded := &mosniov1.FilterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "embedded-virtualservice-vs"},
+	}
+
+	var a policyAttachments
+	a.record(policy, vs)
+	a.record(embedded, vs)
+	a.commit()
+	assert.Equal(t, "Normal Attached FilterPolicy ns/policy is attached", <-recorder.Events)
+	assert.Empty(t, recorder.Events)
+
+	// nothing changed
+	a.record(policy, vs)
+	a.commit()
+	assert.Empty(t, recorder.Events)
+
+	// the failed reconciliation is ignored
+	a.record(policy, vs2)
+	a.discard()
+	a.record(policy, vs)
+	a.commit()
+	assert.Empty(t, recorder.Events)
+
+	// change the target
+	a.record(policy, vs2)
+	a.commit()
+	assert.Equal(t, "Normal Detached FilterPolicy ns/policy is detached", <-recorder.Events)
+	assert.Equal(t, "Normal Attached FilterPolicy ns/policy is attached", <-recorder.Events)
+
+	a.commit()
+	assert.Equal(t, "Normal Detached FilterPolicy ns/policy is detached", <-recorder.Events)
+	assert.Empty(t, recorder.Events)
+}
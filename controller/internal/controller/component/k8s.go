@@ -16,15 +16,28 @@ package component
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"google.golang.org/protobuf/proto"
 	istioapi "istio.io/api/networking/v1alpha3"
 	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/informer"
 	"mosn.io/htnn/controller/internal/log"
 	"mosn.io/htnn/controller/pkg/component"
 	"mosn.io/htnn/controller/pkg/constant"
@@ -46,6 +59,35 @@ func NewK8sOutput(c client.Client) component.Output {
 	return o
 }
 
+// envoyFilterSelector selects the EnvoyFilters generated by the creator for the Istio revision served
+// by this controller, so that the controllers of different revisions won't delete each other's output.
+func envoyFilterSelector(creator string) (client.ListOption, error) {
+	op := selection.DoesNotExist
+	var values []string
+	if rev := config.IstioRevision(); rev != "" {
+		op = selection.Equals
+		values = []string{rev}
+	}
+	req, err := labels.NewRequirement(constant.LabelIstioRevision, op, values)
+	if err != nil {
+		return nil, err
+	}
+	selector := labels.SelectorFromSet(labels.Set{constant.LabelCreatedBy: creator}).Add(*req)
+	return client.MatchingLabelsSelector{Selector: selector}, nil
+}
+
+// withRevision returns a copy of the generated EnvoyFilter for the Istio revision. The revision is
+// added to the name, as the EnvoyFilters of different revisions live in the same namespace.
+func withRevision(ef *istiov1a3.EnvoyFilter) *istiov1a3.EnvoyFilter {
+	rev := config.IstioRevision()
+	if rev == "" {
+		return ef
+	}
+	ef = ef.DeepCopy()
+	ef.Name = ef.Name + "-" + rev
+	return ef
+}
+
 func (o *k8sOutput) FromFilterPolicy(ctx context.Context, generatedEnvoyFilters map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter) error {
 	return o.diffGeneratedEnvoyFilters(ctx, "FilterPolicy", generatedEnvoyFilters)
 }
@@ -58,16 +100,93 @@ func (o *k8sOutput) FromDynamicConfig(ctx context.Context, efs map[component.Env
 	return o.diffGeneratedEnvoyFilters(ctx, "DynamicConfig", efs)
 }
 
+// hashEnvoyFilter returns the hash of the fields written by the output. The hash annotation itself
+// is excluded, so the hash of a stored EnvoyFilter can be recomputed.
+func hashEnvoyFilter(ef *istiov1a3.EnvoyFilter) (string, error) {
+	// Deterministic marshaling is required to get the same bytes from the maps in the Struct
+	spec, err := proto.MarshalOptions{Deterministic: true}.Marshal(&ef.Spec)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write(spec)
+	for _, m := range []map[string]string{ef.Labels, ef.Annotations} {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			if k != constant.AnnotationHash {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		// use the separators which are not allowed in the label key to avoid ambiguity
+		h.Write([]byte{0})
+		for _, k := range keys {
+			h.Write([]byte(k))
+			h.Write([]byte{'='})
+			h.Write([]byte(m[k]))
+			h.Write([]byte{'\n'})
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16]), nil
+}
+
+// setHash returns a copy of the generated EnvoyFilter with the hash annotation
+func setHash(ef *istiov1a3.EnvoyFilter) (*istiov1a3.EnvoyFilter, error) {
+	hash, err := hashEnvoyFilter(ef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash EnvoyFilter: %w, namespacedName: %v",
+			err, types.NamespacedName{Name: ef.Name, Namespace: ef.Namespace})
+	}
+	ef = ef.DeepCopy()
+	if ef.Annotations == nil {
+		ef.Annotations = map[string]string{}
+	}
+	ef.Annotations[constant.AnnotationHash] = hash
+	return ef, nil
+}
+
+// unchanged checks if the stored EnvoyFilter has the same content as the generated one, so we
+// don't need to write it again and trigger a push. The hash of the stored one is recomputed instead of
+// reading from the annotation, so that the modification made by others can still be reverted.
+func unchanged(stored *istiov1a3.EnvoyFilter, generated *istiov1a3.EnvoyFilter) bool {
+	hash, err := hashEnvoyFilter(stored)
+	if err != nil {
+		return false
+	}
+	return hash == generated.Annotations[constant.AnnotationHash]
+}
+
+// sourceNamespace returns the namespace of the resources which generate the EnvoyFilter. The EnvoyFilters
+// without the label, like the ones generated before sharding is enabled, are considered from their own namespace.
+func sourceNamespace(ef *istiov1a3.EnvoyFilter) string {
+	if ns, ok := ef.Labels[constant.LabelSourceNamespace]; ok {
+		return ns
+	}
+	return ef.Namespace
+}
+
 func (o *k8sOutput) diffGeneratedEnvoyFilters(ctx context.Context, creator string, generatedEnvoyFilters map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter) error {
 	logger := o.logger
 
+	selector, err := envoyFilterSelector(creator)
+	if err != nil {
+		return err
+	}
 	var envoyfilters istiov1a3.EnvoyFilterList
-	if err := o.List(ctx, &envoyfilters,
-		client.MatchingLabels{constant.LabelCreatedBy: creator},
-	); err != nil {
+	if err := o.List(ctx, &envoyfilters, selector); err != nil {
 		return fmt.Errorf("failed to list EnvoyFilter: %w", err)
 	}
 
+	if config.IstioRevision() != "" {
+		efs := make(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter, len(generatedEnvoyFilters))
+		for _, ef := range generatedEnvoyFilters {
+			ef = withRevision(ef)
+			efs[component.EnvoyFilterKey{Namespace: ef.Namespace, Name: ef.Name}] = ef
+		}
+		generatedEnvoyFilters = efs
+	}
+
 	preEnvoyFilterMap := make(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter, len(envoyfilters.Items))
 	for _, e := range envoyfilters.Items {
 		key := component.EnvoyFilterKey{
@@ -75,6 +194,10 @@ func (o *k8sOutput) diffGeneratedEnvoyFilters(ctx context.Context, creator strin
 			Name:      e.Name,
 		}
 		if _, ok := generatedEnvoyFilters[key]; !ok {
+			if !config.InShard(sourceNamespace(e)) {
+				// generated by the controller which owns the namespace
+				continue
+			}
 			logger.Info("delete EnvoyFilter", "name", e.Name, "namespace", e.Namespace)
 			if err := o.Delete(ctx, e); err != nil {
 				return fmt.Errorf("failed to delete EnvoyFilter: %w, namespacedName: %v",
@@ -86,6 +209,11 @@ func (o *k8sOutput) diffGeneratedEnvoyFilters(ctx context.Context, creator strin
 	}
 
 	for key, ef := range generatedEnvoyFilters {
+		ef, err := setHash(ef)
+		if err != nil {
+			return err
+		}
+
 		envoyfilter, ok := preEnvoyFilterMap[key]
 		if !ok {
 			logger.Info("create EnvoyFilter", "name", ef.Name, "namespace", ef.Namespace)
@@ -96,7 +224,7 @@ func (o *k8sOutput) diffGeneratedEnvoyFilters(ctx context.Context, creator strin
 			}
 
 		} else {
-			if proto.Equal(&envoyfilter.Spec, &ef.Spec) {
+			if unchanged(envoyfilter, ef) {
 				continue
 			}
 
@@ -116,9 +244,14 @@ func (o *k8sOutput) diffGeneratedEnvoyFilters(ctx context.Context, creator strin
 func (o *k8sOutput) diffGeneratedEnvoyFilter(ctx context.Context, creator string, ef *istiov1a3.EnvoyFilter) error {
 	logger := o.logger
 
+	ef = withRevision(ef)
 	nsName := types.NamespacedName{Name: ef.Name, Namespace: ef.Namespace}
+	selector, err := envoyFilterSelector(creator)
+	if err != nil {
+		return err
+	}
 	var envoyfilters istiov1a3.EnvoyFilterList
-	if err := o.List(ctx, &envoyfilters, client.MatchingLabels{constant.LabelCreatedBy: creator}); err != nil {
+	if err := o.List(ctx, &envoyfilters, selector); err != nil {
 		return fmt.Errorf("failed to list EnvoyFilter: %w", err)
 	}
 
@@ -136,16 +269,20 @@ func (o *k8sOutput) diffGeneratedEnvoyFilter(ctx context.Context, creator string
 		}
 	}
 
+	ef, err = setHash(ef)
+	if err != nil {
+		return err
+	}
+
 	if envoyfilter == nil {
 		logger.Info("create EnvoyFilter", "name", ef.Name, "namespace", ef.Namespace)
 
-		if err := o.Create(ctx, ef.DeepCopy()); err != nil {
+		if err := o.Create(ctx, ef); err != nil {
 			return fmt.Errorf("failed to create EnvoyFilter: %w, namespacedName: %v", err, nsName)
 		}
-	} else {
+	} else if !unchanged(envoyfilter, ef) {
 		logger.Info("update EnvoyFilter", "name", ef.Name, "namespace", ef.Namespace)
 
-		ef = ef.DeepCopy()
 		ef.SetResourceVersion(envoyfilter.ResourceVersion)
 		if err := o.Update(ctx, ef); err != nil {
 			return fmt.Errorf("failed to update EnvoyFilter: %w, namespacedName: %v", err, nsName)
@@ -163,12 +300,75 @@ type resourceManager struct {
 	client.Client
 }
 
+// Get hides the resources in the namespaces which are not watched, as if they don't exist
 func (r *resourceManager) Get(ctx context.Context, key client.ObjectKey, out client.Object) error {
+	if !informer.NamespaceWatched(key.Namespace) {
+		return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+	}
 	return r.Client.Get(ctx, key, out)
 }
 
+// isNamespaced returns true if the items of the list are namespaced resources
+func (r *resourceManager) isNamespaced(list client.ObjectList) (bool, error) {
+	gvk, err := apiutil.GVKForObject(list, r.Scheme())
+	if err != nil {
+		return false, err
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	return apiutil.IsGVKNamespaced(gvk, r.RESTMapper())
+}
+
+// listWatched lists the resources in the watched namespaces. Each namespace is listed separately, so
+// that only the resources in them are read from the cache via the namespace index.
+func (r *resourceManager) listWatched(ctx context.Context, list client.ObjectList) error {
+	namespaces := informer.WatchedNamespaces()
+	if namespaces == nil {
+		return r.Client.List(ctx, list)
+	}
+	namespaced, err := r.isNamespaced(list)
+	if err != nil {
+		return err
+	}
+	if !namespaced {
+		return r.Client.List(ctx, list)
+	}
+
+	var items []runtime.Object
+	for _, ns := range namespaces {
+		l := list.DeepCopyObject().(client.ObjectList)
+		if err := r.Client.List(ctx, l, client.InNamespace(ns)); err != nil {
+			return err
+		}
+		objs, err := meta.ExtractList(l)
+		if err != nil {
+			return err
+		}
+		items = append(items, objs...)
+	}
+	return meta.SetList(list, items)
+}
+
+// List lists the resources of the Istio revision served by this controller, like what Istio does
 func (r *resourceManager) List(ctx context.Context, list client.ObjectList) error {
-	return r.Client.List(ctx, list)
+	if err := r.listWatched(ctx, list); err != nil {
+		return err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	filtered := items[:0]
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok || config.InRevision(obj.GetLabels()) {
+			filtered = append(filtered, item)
+		}
+	}
+	if len(filtered) == len(items) {
+		return nil
+	}
+	return meta.SetList(list, filtered)
 }
 
 func (r *resourceManager) UpdateStatus(ctx context.Context, obj client.Object, status any) error {
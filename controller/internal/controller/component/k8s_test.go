@@ -0,0 +1,330 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package component
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istioapi "istio.io/api/networking/v1alpha3"
+	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	istioscheme "istio.io/client-go/pkg/clientset/versioned/scheme"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/informer"
+	"mosn.io/htnn/controller/pkg/component"
+	"mosn.io/htnn/controller/pkg/constant"
+)
+
+func newEnvoyFilter(name string, priority int32) *istiov1a3.EnvoyFilter {
+	return &istiov1a3.EnvoyFilter{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels: map[string]string{
+				constant.LabelCreatedBy: "FilterPolicy",
+			},
+		},
+		Spec: istioapi.EnvoyFilter{
+			Priority: priority,
+		},
+	}
+}
+
+func TestDiffGeneratedEnvoyFiltersByHash(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, istioscheme.AddToScheme(scheme))
+	cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+	output := NewK8sOutput(cli)
+	ctx := context.Background()
+
+	generate := func(efs ...*istiov1a3.EnvoyFilter) map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter {
+		res := map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{}
+		for _, ef := range efs {
+			res[component.EnvoyFilterKey{Namespace: ef.Namespace, Name: ef.Name}] = ef
+		}
+		return res
+	}
+	get := func(name string) *istiov1a3.EnvoyFilter {
+		var ef istiov1a3.EnvoyFilter
+		require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &ef))
+		return &ef
+	}
+
+	require.NoError(t, output.FromFilterPolicy(ctx, generate(newEnvoyFilter("a", 1), newEnvoyFilter("b", 1))))
+	a := get("a")
+	b := get("b")
+	assert.NotEmpty(t, a.Annotations[constant.AnnotationHash])
+	assert.Equal(t, a.Annotations[constant.AnnotationHash], b.Annotations[constant.AnnotationHash])
+
+	// only the changed one is written
+	require.NoError(t, output.FromFilterPolicy(ctx, generate(newEnvoyFilter("a", 1), newEnvoyFilter("b", 2))))
+	assert.Equal(t, a.ResourceVersion, get("a").ResourceVersion)
+	updated := get("b")
+	assert.NotEqual(t, b.ResourceVersion, updated.ResourceVersion)
+	assert.NotEqual(t, b.Annotations[constant.AnnotationHash], updated.Annotations[constant.AnnotationHash])
+
+	// the change of metadata also counts
+	ef := newEnvoyFilter("a", 1)
+	ef.Annotations = map[string]string{"htnn.mosn.io/info": `{"filterpolicies":["default/policy"]}`}
+	require.NoError(t, output.FromFilterPolicy(ctx, generate(ef, newEnvoyFilter("b", 2))))
+	assert.NotEqual(t, a.ResourceVersion, get("a").ResourceVersion)
+
+	// the modification made by others is reverted even if the hash annotation is kept
+	a = get("a")
+	a.Spec.Priority = 10
+	require.NoError(t, cli.Update(ctx, a))
+	require.NoError(t, output.FromFilterPolicy(ctx, generate(ef, newEnvoyFilter("b", 2))))
+	assert.Equal(t, int32(1), get("a").Spec.Priority)
+
+	// the EnvoyFilter created without the hash annotation is not rewritten if unchanged
+	b = get("b")
+	delete(b.Annotations, constant.AnnotationHash)
+	require.NoError(t, cli.Update(ctx, b))
+	b = get("b")
+	require.NoError(t, output.FromFilterPolicy(ctx, generate(ef, newEnvoyFilter("b", 2))))
+	assert.Equal(t, b.ResourceVersion, get("b").ResourceVersion)
+}
+
+func TestDiffGeneratedEnvoyFilterByHash(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, istioscheme.AddToScheme(scheme))
+	cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+	output := NewK8sOutput(cli)
+	ctx := context.Background()
+
+	ef := newEnvoyFilter("consumers", 1)
+	ef.Labels[constant.LabelCreatedBy] = "Consumer"
+	require.NoError(t, output.FromConsumer(ctx, ef))
+	var created istiov1a3.EnvoyFilter
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "consumers"}, &created))
+	// the generated one is not modified
+	assert.Nil(t, ef.Annotations)
+
+	require.NoError(t, output.FromConsumer(ctx, ef))
+	var curr istiov1a3.EnvoyFilter
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "consumers"}, &curr))
+	assert.Equal(t, created.ResourceVersion, curr.ResourceVersion)
+
+	ef.Spec.Priority = 2
+	require.NoError(t, output.FromConsumer(ctx, ef))
+	require.NoError(t, cli.Get(ctx, client.ObjectKey{Namespace: "default", Name: "consumers"}, &curr))
+	assert.NotEqual(t, created.ResourceVersion, curr.ResourceVersion)
+}
+
+func TestDiffGeneratedEnvoyFiltersWithShard(t *testing.T) {
+	// registered before Setenv, so it runs after the environment variable is restored
+	t.Cleanup(config.Init)
+	t.Setenv("HTNN_SHARD_NAMESPACES", "default")
+	config.Init()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, istioscheme.AddToScheme(scheme))
+	other := newEnvoyFilter("b", 1)
+	other.Namespace = "other"
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newEnvoyFilter("a", 1), other).Build()
+	output := NewK8sOutput(cli)
+	ctx := context.Background()
+
+	require.NoError(t, output.FromFilterPolicy(ctx, map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{}))
+	var efs istiov1a3.EnvoyFilterList
+	require.NoError(t, cli.List(ctx, &efs))
+	// the EnvoyFilter out of the shard is kept
+	require.Len(t, efs.Items, 1)
+	assert.Equal(t, "other", efs.Items[0].Namespace)
+}
+
+func TestDiffGeneratedEnvoyFiltersWithTwoShards(t *testing.T) {
+	// registered before Setenv, so it runs after the environment variable is restored
+	t.Cleanup(config.Init)
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, istioscheme.AddToScheme(scheme))
+	cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+	output := NewK8sOutput(cli)
+	ctx := context.Background()
+
+	// both shards generate EnvoyFilters in the namespace of the gateway
+	generated := func(name string, sourceNs string) map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter {
+		ef := newEnvoyFilter(name, 1)
+		ef.Namespace = "gateway"
+		ef.Labels[constant.LabelSourceNamespace] = sourceNs
+		return map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+			{Namespace: ef.Namespace, Name: ef.Name}: ef,
+		}
+	}
+	reconcile := func(shard string, efs map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter) {
+		t.Setenv("HTNN_SHARD_NAMESPACES", shard)
+		config.Init()
+		require.NoError(t, output.FromFilterPolicy(ctx, efs))
+	}
+	list := func() map[string]string {
+		var efs istiov1a3.EnvoyFilterList
+		require.NoError(t, cli.List(ctx, &efs))
+		res := map[string]string{}
+		for _, ef := range efs.Items {
+			res[ef.Namespace+"/"+ef.Name] = ef.Labels[constant.LabelSourceNamespace]
+		}
+		return res
+	}
+
+	// the gateway namespace is in the first shard
+	reconcile("a,gateway", generated("htnn-h-example.com-a", "a"))
+	reconcile("b", generated("htnn-h-example.com-b", "b"))
+	reconcile("a,gateway", generated("htnn-h-example.com-a", "a"))
+	assert.Equal(t, map[string]string{
+		"gateway/htnn-h-example.com-a": "a",
+		"gateway/htnn-h-example.com-b": "b",
+	}, list())
+
+	// the EnvoyFilter is deleted by its own shard
+	reconcile("b", nil)
+	assert.Equal(t, map[string]string{
+		"gateway/htnn-h-example.com-a": "a",
+	}, list())
+}
+
+func TestDiffGeneratedEnvoyFiltersWithRevision(t *testing.T) {
+	// registered before Setenv, so it runs after the environment variable is restored
+	t.Cleanup(config.Init)
+	t.Setenv("HTNN_ISTIO_REVISION", "canary")
+	config.Init()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, istioscheme.AddToScheme(scheme))
+	// generated by the controller without revision
+	stable := newEnvoyFilter("a", 1)
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(stable).Build()
+	output := NewK8sOutput(cli)
+	ctx := context.Background()
+
+	ef := newEnvoyFilter("a", 1)
+	ef.Labels[constant.LabelIstioRevision] = "canary"
+	require.NoError(t, output.FromFilterPolicy(ctx, map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		{Namespace: "default", Name: "a"}: ef,
+	}))
+	var efs istiov1a3.EnvoyFilterList
+	require.NoError(t, cli.List(ctx, &efs))
+	require.Len(t, efs.Items, 2)
+	names := []string{}
+	for _, e := range efs.Items {
+		names = append(names, e.Name)
+	}
+	assert.ElementsMatch(t, []string{"a", "a-canary"}, names)
+	// the generated one is not modified
+	assert.Equal(t, "a", ef.Name)
+
+	// only the EnvoyFilters of the revision are deleted
+	require.NoError(t, output.FromFilterPolicy(ctx, map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{}))
+	require.NoError(t, cli.List(ctx, &efs))
+	require.Len(t, efs.Items, 1)
+	assert.Equal(t, "a", efs.Items[0].Name)
+
+	t.Setenv("HTNN_ISTIO_REVISION", "")
+	config.Init()
+	require.NoError(t, output.FromFilterPolicy(ctx, map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{}))
+	require.NoError(t, cli.List(ctx, &efs))
+	assert.Len(t, efs.Items, 0)
+}
+
+func TestListInRevision(t *testing.T) {
+	t.Cleanup(config.Init)
+	t.Setenv("HTNN_ISTIO_REVISION", "canary")
+	config.Init()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, istioscheme.AddToScheme(scheme))
+	newVirtualService := func(name string, rev string) *istiov1a3.VirtualService {
+		vs := &istiov1a3.VirtualService{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		}
+		if rev != "" {
+			vs.Labels = map[string]string{constant.LabelIstioRevision: rev}
+		}
+		return vs
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newVirtualService("global", ""),
+		newVirtualService("canary", "canary"),
+		newVirtualService("stable", "stable"),
+	).Build()
+	rm := NewK8sResourceManager(cli)
+
+	var vsList istiov1a3.VirtualServiceList
+	require.NoError(t, rm.List(context.Background(), &vsList))
+	names := []string{}
+	for _, vs := range vsList.Items {
+		names = append(names, vs.Name)
+	}
+	assert.ElementsMatch(t, []string{"global", "canary"}, names)
+}
+
+func TestReadWatchedNamespaces(t *testing.T) {
+	t.Cleanup(func() {
+		informer.SetWatchedNamespaces(nil)
+		config.Init()
+	})
+	t.Setenv("HTNN_WATCH_NAMESPACE_SELECTOR", "htnn.mosn.io/watch=true")
+	config.Init()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, istioscheme.AddToScheme(scheme))
+	vsGVK := istiov1a3.SchemeGroupVersion.WithKind("VirtualService")
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{istiov1a3.SchemeGroupVersion})
+	mapper.Add(vsGVK, meta.RESTScopeNamespace)
+	newVirtualService := func(ns string) *istiov1a3.VirtualService {
+		return &istiov1a3.VirtualService{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "vs"},
+		}
+	}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(mapper).WithObjects(
+		newVirtualService("watched"),
+		newVirtualService("unwatched"),
+		newVirtualService("istio-system"),
+	).Build()
+	rm := NewK8sResourceManager(cli)
+	ctx := context.Background()
+
+	listNamespaces := func() []string {
+		var vsList istiov1a3.VirtualServiceList
+		require.NoError(t, rm.List(ctx, &vsList))
+		namespaces := []string{}
+		for _, vs := range vsList.Items {
+			namespaces = append(namespaces, vs.Namespace)
+		}
+		return namespaces
+	}
+
+	// not synced yet
+	assert.ElementsMatch(t, []string{"watched", "unwatched", "istio-system"}, listNamespaces())
+
+	informer.SetWatchedNamespaces([]string{"watched"})
+	assert.ElementsMatch(t, []string{"watched", "istio-system"}, listNamespaces())
+
+	var vs istiov1a3.VirtualService
+	err := rm.Get(ctx, client.ObjectKey{Namespace: "unwatched", Name: "vs"}, &vs)
+	assert.True(t, apierrors.IsNotFound(err))
+	err = rm.Get(ctx, client.ObjectKey{Namespace: "watched", Name: "vs"}, &vs)
+	assert.NoError(t, err)
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -29,9 +30,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"mosn.io/htnn/controller/internal/events"
 	"mosn.io/htnn/controller/internal/istio"
 	"mosn.io/htnn/controller/internal/log"
 	"mosn.io/htnn/controller/internal/metrics"
+	"mosn.io/htnn/controller/internal/quota"
 	"mosn.io/htnn/controller/pkg/component"
 	mosniov1 "mosn.io/htnn/types/apis/v1"
 )
@@ -40,6 +43,8 @@ import (
 type ConsumerReconciler struct {
 	component.ResourceManager
 	Output component.Output
+
+	secretRefs secretReferences
 }
 
 //+kubebuilder:rbac:groups=htnn.mosn.io,resources=consumers,verbs=get;list;watch;create;update;patch;delete
@@ -65,6 +70,7 @@ func (r *ConsumerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	r.secretRefs.commit()
 
 	err = r.generateCustomResource(ctx, state)
 	if err != nil {
@@ -85,6 +91,14 @@ func (r *ConsumerReconciler) consumersToState(ctx context.Context,
 	if err := r.List(ctx, consumers); err != nil {
 		return nil, fmt.Errorf("failed to list Consumer: %w", err)
 	}
+	quota.SetConsumers(consumers)
+
+	// The status of PluginConfigPolicy is written by the FilterPolicy reconciler
+	var pluginConfigPolicies mosniov1.PluginConfigPolicyList
+	allowances, err := listPluginConfigPolicies(ctx, r, &pluginConfigPolicies)
+	if err != nil {
+		return nil, err
+	}
 
 	namespaceToConsumers := make(map[string]map[string]*mosniov1.Consumer)
 	for i := range consumers.Items {
@@ -103,6 +117,18 @@ func (r *ConsumerReconciler) consumersToState(ctx context.Context,
 			continue
 		}
 
+		if err := mosniov1.ValidateConsumerAllowance(consumer, allowances); err != nil {
+			log.Errorf("forbidden Consumer, err: %v, name: %s, namespace: %s", err, consumer.Name, consumer.Namespace)
+			consumer.SetAccepted(mosniov1.ReasonForbidden, err.Error())
+			continue
+		}
+
+		if err := resolveConsumerSensitiveFields(ctx, r, &r.secretRefs, consumer); err != nil {
+			log.Errorf("failed to resolve Consumer, err: %v, name: %s, namespace: %s", err, consumer.Name, consumer.Namespace)
+			consumer.SetAccepted(mosniov1.ReasonUnresolved, err.Error())
+			continue
+		}
+
 		namespace := consumer.Namespace
 		if namespaceToConsumers[namespace] == nil {
 			namespaceToConsumers[namespace] = make(map[string]*mosniov1.Consumer)
@@ -147,7 +173,11 @@ func (r *ConsumerReconciler) generateCustomResource(ctx context.Context, state *
 
 	ef := istio.GenerateConsumers(consumerData)
 
-	return r.Output.FromConsumer(ctx, ef)
+	if err := r.Output.FromConsumer(ctx, ef); err != nil {
+		return err
+	}
+	metrics.GeneratedEnvoyFilters.With(metrics.LabelCreatedBy, "Consumer").Record(1)
+	return nil
 }
 
 func (r *ConsumerReconciler) updateConsumers(ctx context.Context, consumers *mosniov1.ConsumerList) error {
@@ -156,6 +186,7 @@ func (r *ConsumerReconciler) updateConsumers(ctx context.Context, consumers *mos
 		if !consumer.Status.IsChanged() {
 			continue
 		}
+		events.FromStatus(consumer, consumer.Status.Conditions)
 		consumer.Status.Reset()
 		if err := r.UpdateStatus(ctx, consumer, &consumer.Status); err != nil {
 			return fmt.Errorf("failed to update Consumer status: %w, namespacedName: %v",
@@ -166,6 +197,11 @@ func (r *ConsumerReconciler) updateConsumers(ctx context.Context, consumers *mos
 	return nil
 }
 
+// RefersSecret returns true if the Secret is referred by the Consumers in the last reconciliation
+func (r *ConsumerReconciler) RefersSecret(_ context.Context, key types.NamespacedName) bool {
+	return r.secretRefs.has(key)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ConsumerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	controller := ctrl.NewControllerManagedBy(mgr).
@@ -178,6 +214,26 @@ func (r *ConsumerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			builder.WithPredicates(
 				predicate.GenerationChangedPredicate{},
 			),
+		).
+		Watches(
+			&mosniov1.PluginConfigPolicy{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []reconcile.Request {
+				return triggerReconciliation()
+			}),
+			builder.WithPredicates(
+				predicate.GenerationChangedPredicate{},
+			),
+		).
+		Watches(
+			&corev1.Secret{},
+			&secretHandler{
+				requests: func(ctx context.Context, secret *corev1.Secret) []reconcile.Request {
+					if r.RefersSecret(ctx, client.ObjectKeyFromObject(secret)) {
+						return triggerReconciliation()
+					}
+					return nil
+				},
+			},
 		)
 	return controller.Complete(r)
 }
@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"mosn.io/htnn/controller/internal/events"
 	"mosn.io/htnn/controller/internal/istio"
 	"mosn.io/htnn/controller/internal/log"
 	"mosn.io/htnn/controller/internal/metrics"
@@ -123,7 +124,11 @@ func (r *DynamicConfigReconciler) dynamicconfigsToState(ctx context.Context,
 
 func (r *DynamicConfigReconciler) generateCustomResource(ctx context.Context, state *dynamicConfigReconcileState) error {
 	efs := istio.GenerateDynamicConfigs(state.namespaceToDynamicConfigs)
-	return r.Output.FromDynamicConfig(ctx, efs)
+	if err := r.Output.FromDynamicConfig(ctx, efs); err != nil {
+		return err
+	}
+	metrics.GeneratedEnvoyFilters.With(metrics.LabelCreatedBy, "DynamicConfig").Record(float64(len(efs)))
+	return nil
 }
 
 func (r *DynamicConfigReconciler) updateDynamicConfigs(ctx context.Context, dynamicConfigs *mosniov1.DynamicConfigList) error {
@@ -132,6 +137,7 @@ func (r *DynamicConfigReconciler) updateDynamicConfigs(ctx context.Context, dyna
 		if !dynamicConfig.Status.IsChanged() {
 			continue
 		}
+		events.FromStatus(dynamicConfig, dynamicConfig.Status.Conditions)
 		dynamicConfig.Status.Reset()
 		if err := r.UpdateStatus(ctx, dynamicConfig, &dynamicConfig.Status); err != nil {
 			return fmt.Errorf("failed to update DynamicConfig status: %w, namespacedName: %v",
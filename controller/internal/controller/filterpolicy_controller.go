@@ -26,7 +26,9 @@ import (
 	"time"
 
 	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -39,8 +41,11 @@ import (
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/configdump"
+	"mosn.io/htnn/controller/internal/events"
 	"mosn.io/htnn/controller/internal/log"
 	"mosn.io/htnn/controller/internal/metrics"
+	"mosn.io/htnn/controller/internal/quota"
 	"mosn.io/htnn/controller/internal/translation"
 	"mosn.io/htnn/controller/pkg/component"
 	"mosn.io/htnn/controller/pkg/constant"
@@ -58,10 +63,18 @@ type FilterPolicyReconciler struct {
 
 	indexers map[string]*customResourceIndexer
 
-	virtualServiceIndexer *customResourceIndexer
-	httpRouteIndexer      *customResourceIndexer
-	istioGatewayIndexer   *customResourceIndexer
-	k8sGatewayIndexer     *customResourceIndexer
+	virtualServiceIndexer  *customResourceIndexer
+	httpRouteIndexer       *customResourceIndexer
+	istioGatewayIndexer    *customResourceIndexer
+	k8sGatewayIndexer      *customResourceIndexer
+	serviceEntryIndexer    *customResourceIndexer
+	destinationRuleIndexer *customResourceIndexer
+
+	secretRefs  secretReferences
+	attachments policyAttachments
+
+	// the EnvoyFilters written in the last reconciliation
+	lastEnvoyFilters map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter
 }
 
 func NewFilterPolicyReconciler(output component.Output, manager component.ResourceManager) *FilterPolicyReconciler {
@@ -84,8 +97,22 @@ func NewFilterPolicyReconciler(output component.Output, manager component.Resour
 		CustomResource: &istiov1a3.Gateway{},
 	}
 	r.istioGatewayIndexer = istioGatewayIndexer
+	serviceEntryIndexer := &customResourceIndexer{
+		Group:          "networking.istio.io",
+		Kind:           "ServiceEntry",
+		CustomResource: &istiov1a3.ServiceEntry{},
+	}
+	r.serviceEntryIndexer = serviceEntryIndexer
+	destinationRuleIndexer := &customResourceIndexer{
+		Group:          "networking.istio.io",
+		Kind:           "DestinationRule",
+		CustomResource: &istiov1a3.DestinationRule{},
+	}
+	r.destinationRuleIndexer = destinationRuleIndexer
 	r.addIndexer(virtualServiceIndexer)
 	r.addIndexer(istioGatewayIndexer)
+	r.addIndexer(serviceEntryIndexer)
+	r.addIndexer(destinationRuleIndexer)
 
 	if config.EnableGatewayAPI() {
 		httpRouteIndexer := &customResourceIndexer{
@@ -145,11 +172,19 @@ func (r *FilterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	log.Info("Reconcile FilterPolicy")
 
 	var policies mosniov1.FilterPolicyList
-	initState, err := r.policyToTranslationState(ctx, &policies)
+	var pluginConfigPolicies mosniov1.PluginConfigPolicyList
+	allowances, err := r.listPolicies(ctx, &policies, &pluginConfigPolicies)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	quota.SetPolicies(&policies)
+	initState, err := r.policyToTranslationState(ctx, &policies, allowances)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 	if initState == nil {
+		r.secretRefs.commit()
+		r.attachments.commit()
 		return ctrl.Result{}, nil
 	}
 
@@ -159,17 +194,52 @@ func (r *FilterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	metrics.FPTranslateDurationDistribution.Record(processDurationInSecs)
 	if err != nil {
 		log.Errorf("failed to process state: %v", err)
+		metrics.FPTranslateErrors.Increment()
+		r.secretRefs.commit()
+		r.attachments.discard()
 		// there is no retryable err during processing
 		return ctrl.Result{}, nil
 	}
 
-	generatedEnvoyFilters := finalState.EnvoyFilters
+	// the Secrets referred by the stable version of the policies in rollout are also recorded
+	generatedEnvoyFilters, err := r.translateRollouts(ctx, &policies, allowances, finalState.EnvoyFilters)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.secretRefs.commit()
+	generatedEnvoyFilters = r.limitEnvoyFilterSize(&policies, generatedEnvoyFilters)
+
 	err = r.output.FromFilterPolicy(ctx, generatedEnvoyFilters)
 	if err != nil {
+		r.attachments.discard()
 		return ctrl.Result{}, err
 	}
+	r.attachments.commit()
+	r.lastEnvoyFilters = generatedEnvoyFilters
+	configdump.Record(generatedEnvoyFilters, finalState.Targets)
+	metrics.GeneratedEnvoyFilters.With(metrics.LabelCreatedBy, "FilterPolicy").Record(float64(len(generatedEnvoyFilters)))
+
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if !config.InShard(policy.Namespace) {
+			// the status is maintained by the controller which owns the namespace
+			continue
+		}
+		if policy.IsAccepted() {
+			policy.SetOverridden(finalState.OverriddenPlugins[getK8sKey(policy.Namespace, policy.Name)])
+			if !policy.FromHTTPFilterPolicy() {
+				policy.SetAppliedGeneration()
+			}
+		} else {
+			policy.RemoveOverridden()
+		}
+	}
 
 	err = r.updatePolicies(ctx, &policies)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	err = updatePluginConfigPolicies(ctx, r, &pluginConfigPolicies)
 	return ctrl.Result{}, err
 }
 
@@ -220,8 +290,33 @@ func (r *FilterPolicyReconciler) resolveWithVirtualService(ctx context.Context,
 	virtualService *istiov1a3.VirtualService, policy *mosniov1.FilterPolicy, initState *translation.InitState,
 	gwIdx map[string][]*mosniov1.FilterPolicy) error {
 
-	var err error
-	accepted := false
+	gws, err := r.resolveGatewaysOfVirtualService(ctx, virtualService, policy, initState, gwIdx)
+	if err != nil {
+		return err
+	}
+
+	if len(gws) > 0 {
+		initState.AddPolicyForVirtualService(policy, virtualService, gws)
+		policy.SetAccepted(gwapiv1a2.PolicyReasonAccepted)
+		r.attachments.record(policy, virtualService)
+		// For reducing the write to K8S API server and reconciliation,
+		// we don't add `gateway.networking.k8s.io/PolicyAffected` to the affected resource.
+		// If people want to check whether the VirtualService/HTTPRoute is affected, they can
+		// check whether there is an EnvoyFilter named `htnn-h-$host` (the `$host` is one of the resources' hosts).
+		// For wildcard host, the `*.` is converted to `-`. For example, `*.example.com` results in
+		// EnvoyFilter name `htnn-h--example.com`, and `www.example.com` results in `htnn-h-www.example.com`.
+	} else {
+		policy.SetAccepted(gwapiv1a2.PolicyReasonTargetNotFound, "all gateways are not found or unsupported")
+	}
+
+	return nil
+}
+
+// resolveGatewaysOfVirtualService returns the valid gateways of the VirtualService
+func (r *FilterPolicyReconciler) resolveGatewaysOfVirtualService(ctx context.Context,
+	virtualService *istiov1a3.VirtualService, policy *mosniov1.FilterPolicy, initState *translation.InitState,
+	gwIdx map[string][]*mosniov1.FilterPolicy) ([]*istiov1a3.Gateway, error) {
+
 	gws := initState.GetGatewaysWithVirtualService(virtualService)
 	if len(gws) > 0 {
 		for _, gateway := range gws {
@@ -229,8 +324,6 @@ func (r *FilterPolicyReconciler) resolveWithVirtualService(ctx context.Context,
 			gwIdx[key] = append(gwIdx[key], policy)
 		}
 
-		accepted = true
-
 	} else {
 		gws = make([]*istiov1a3.Gateway, 0, len(virtualService.Spec.Gateways))
 		for _, gw := range virtualService.Spec.Gateways {
@@ -249,10 +342,10 @@ func (r *FilterPolicyReconciler) resolveWithVirtualService(ctx context.Context,
 			gwIdx[key] = append(gwIdx[key], policy)
 
 			var gateway istiov1a3.Gateway
-			err = r.Get(ctx, types.NamespacedName{Name: gw, Namespace: virtualService.Namespace}, &gateway)
+			err := r.Get(ctx, types.NamespacedName{Name: gw, Namespace: virtualService.Namespace}, &gateway)
 			if err != nil {
 				if !apierrors.IsNotFound(err) {
-					return err
+					return nil, err
 				}
 				log.Infof("gateway not found, name: %s, namespace: %s, gateway: %s", virtualService.Name, virtualService.Namespace, gw)
 				continue
@@ -266,22 +359,78 @@ func (r *FilterPolicyReconciler) resolveWithVirtualService(ctx context.Context,
 			}
 
 			gws = append(gws, &gateway)
+		}
+	}
 
-			accepted = true
+	return gws, nil
+}
+
+// resolveUpstream resolves the policy targeting a ServiceEntry or a DestinationRule. The policy is
+// applied to all the VirtualService routes going to the hosts of the target.
+func (r *FilterPolicyReconciler) resolveUpstream(ctx context.Context,
+	policy *mosniov1.FilterPolicy, initState *translation.InitState, gwIdx map[string][]*mosniov1.FilterPolicy) error {
+
+	ref := policy.Spec.TargetRef
+	nsName := types.NamespacedName{Name: string(ref.Name), Namespace: policy.Namespace}
+	var hosts []string
+	var target client.Object
+	var err error
+	if ref.Kind == "ServiceEntry" {
+		var serviceEntry istiov1a3.ServiceEntry
+		err = r.Get(ctx, nsName, &serviceEntry)
+		hosts = serviceEntry.Spec.Hosts
+		target = &serviceEntry
+	} else {
+		var destinationRule istiov1a3.DestinationRule
+		err = r.Get(ctx, nsName, &destinationRule)
+		hosts = []string{destinationRule.Spec.Host}
+		target = &destinationRule
+	}
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get %s: %w, NamespacedName: %v", ref.Kind, err, nsName)
 		}
+
+		policy.SetAccepted(gwapiv1a2.PolicyReasonTargetNotFound)
+		return nil
+	}
+
+	var virtualServices istiov1a3.VirtualServiceList
+	if err := r.List(ctx, &virtualServices); err != nil {
+		return fmt.Errorf("failed to list VirtualService: %w", err)
+	}
+
+	accepted := false
+	for _, vs := range virtualServices.Items {
+		if mosniov1.ValidateVirtualService(vs) != nil {
+			continue
+		}
+
+		routeNames := translation.RoutesToUpstream(vs, policy.Namespace, hosts)
+		if len(routeNames) == 0 {
+			continue
+		}
+
+		gws, err := r.resolveGatewaysOfVirtualService(ctx, vs, policy, initState, gwIdx)
+		if err != nil {
+			return err
+		}
+		if len(gws) == 0 {
+			continue
+		}
+
+		// The VirtualServices in other shards are still checked, so all the controllers agree on the status
+		if config.InShard(vs.Namespace) {
+			initState.AddUpstreamPolicyForVirtualService(policy, vs, gws, routeNames)
+		}
+		accepted = true
 	}
 
 	if accepted {
-		initState.AddPolicyForVirtualService(policy, virtualService, gws)
 		policy.SetAccepted(gwapiv1a2.PolicyReasonAccepted)
-		// For reducing the write to K8S API server and reconciliation,
-		// we don't add `gateway.networking.k8s.io/PolicyAffected` to the affected resource.
-		// If people want to check whether the VirtualService/HTTPRoute is affected, they can
-		// check whether there is an EnvoyFilter named `htnn-h-$host` (the `$host` is one of the resources' hosts).
-		// For wildcard host, the `*.` is converted to `-`. For example, `*.example.com` results in
-		// EnvoyFilter name `htnn-h--example.com`, and `www.example.com` results in `htnn-h-www.example.com`.
+		r.attachments.record(policy, target)
 	} else {
-		policy.SetAccepted(gwapiv1a2.PolicyReasonTargetNotFound, "all gateways are not found or unsupported")
+		policy.SetAccepted(gwapiv1a2.PolicyReasonTargetNotFound, "no route goes to the upstream")
 	}
 
 	return nil
@@ -303,6 +452,15 @@ func (r *FilterPolicyReconciler) resolveHTTPRoute(ctx context.Context,
 		return nil
 	}
 
+	if ref.SectionName != nil {
+		name := string(*ref.SectionName)
+		idx, err := mosniov1.HTTPRouteRuleIndex(name)
+		if err != nil || idx >= len(route.Spec.Rules) {
+			policy.SetAccepted(gwapiv1a2.PolicyReasonTargetNotFound, fmt.Sprintf("sectionName %s not found", name))
+			return nil
+		}
+	}
+
 	accepted := false
 	gws := initState.GetGatewaysWithHTTPRoute(&route)
 	if len(gws) > 0 {
@@ -380,6 +538,7 @@ func (r *FilterPolicyReconciler) resolveHTTPRoute(ctx context.Context,
 	if accepted {
 		initState.AddPolicyForHTTPRoute(policy, &route, gws)
 		policy.SetAccepted(gwapiv1a2.PolicyReasonAccepted)
+		r.attachments.record(policy, &route)
 	} else {
 		policy.SetAccepted(gwapiv1a2.PolicyReasonTargetNotFound, "all gateways are not found or unsupported")
 	}
@@ -434,6 +593,7 @@ func (r *FilterPolicyReconciler) resolveWithIstioGateway(_ context.Context,
 
 	initState.AddPolicyForIstioGateway(policy, gateway)
 	policy.SetAccepted(gwapiv1a2.PolicyReasonAccepted)
+	r.attachments.record(policy, gateway)
 	return nil
 }
 
@@ -471,6 +631,7 @@ func (r *FilterPolicyReconciler) resolveK8sGateway(ctx context.Context,
 
 	initState.AddPolicyForK8sGateway(policy, &gateway)
 	policy.SetAccepted(gwapiv1a2.PolicyReasonAccepted)
+	r.attachments.record(policy, &gateway)
 	return nil
 }
 
@@ -506,8 +667,19 @@ func getFilterPolicyFromAnnotation(obj client.Object) *mosniov1.FilterPolicy {
 	return nil
 }
 
-func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
-	policies *mosniov1.FilterPolicyList) (*translation.InitState, error) {
+// policyInShard returns true if the policy should be resolved by this controller. Except the policy targeting
+// an upstream, which affects the VirtualServices in all namespaces, the policy only affects the resources in
+// its namespace.
+func policyInShard(policy *mosniov1.FilterPolicy) bool {
+	if config.InShard(policy.Namespace) {
+		return true
+	}
+	ref := policy.Spec.TargetRef
+	return ref != nil && ref.Group == "networking.istio.io" && (ref.Kind == "ServiceEntry" || ref.Kind == "DestinationRule")
+}
+
+func (r *FilterPolicyReconciler) listPolicies(ctx context.Context,
+	policies *mosniov1.FilterPolicyList, pluginConfigPolicies *mosniov1.PluginConfigPolicyList) ([]*mosniov1.PluginConfigPolicy, error) {
 
 	// For current implementation, let's rebuild the state each time to avoid complexity.
 	// The controller will use local cache when doing read operation.
@@ -524,16 +696,24 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 		policies.Items = append(policies.Items, mosniov1.ConvertHTTPFilterPolicyToFilterPolicy(&p))
 	}
 
+	return listPluginConfigPolicies(ctx, r, pluginConfigPolicies)
+}
+
+func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
+	policies *mosniov1.FilterPolicyList, allowances []*mosniov1.PluginConfigPolicy) (*translation.InitState, error) {
+
 	initState := translation.NewInitState()
 	vsIdx := map[string][]*mosniov1.FilterPolicy{}
 	hrIdx := map[string][]*mosniov1.FilterPolicy{}
 	istioGwIdx := map[string][]*mosniov1.FilterPolicy{}
 	k8sGwIdx := map[string][]*mosniov1.FilterPolicy{}
+	seIdx := map[string][]*mosniov1.FilterPolicy{}
+	drIdx := map[string][]*mosniov1.FilterPolicy{}
 
 	for i := range policies.Items {
 		policy := &policies.Items[i]
 		ref := policy.Spec.TargetRef
-		if ref == nil {
+		if ref == nil || !policyInShard(policy) {
 			continue
 		}
 
@@ -544,10 +724,19 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 			vsIdx[key] = append(vsIdx[key], policy)
 		} else if ref.Group == "gateway.networking.k8s.io" && ref.Kind == "HTTPRoute" {
 			hrIdx[key] = append(hrIdx[key], policy)
+		} else if ref.Group == "networking.istio.io" && ref.Kind == "ServiceEntry" {
+			seIdx[key] = append(seIdx[key], policy)
+		} else if ref.Group == "networking.istio.io" && ref.Kind == "DestinationRule" {
+			drIdx[key] = append(drIdx[key], policy)
 		}
 	}
 
 	r.virtualServiceIndexer.UpdateIndex(vsIdx)
+	r.serviceEntryIndexer.UpdateIndex(seIdx)
+	r.destinationRuleIndexer.UpdateIndex(drIdx)
+	// We can't know which VirtualServices will go to the upstream until they are changed,
+	// so any change of VirtualService should trigger reconciliation when there is a policy targeting an upstream.
+	r.virtualServiceIndexer.SetMatchAll(len(seIdx) > 0 || len(drIdx) > 0)
 	if config.EnableGatewayAPI() {
 		r.httpRouteIndexer.UpdateIndex(hrIdx)
 	}
@@ -556,6 +745,10 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 
 	for i := range policies.Items {
 		policy := &policies.Items[i]
+		if !policyInShard(policy) {
+			continue
+		}
+
 		ref := policy.Spec.TargetRef
 		if ref == nil {
 			policy.SetAccepted(gwapiv1a2.PolicyReasonInvalid, "targetRef is required when using FilterPolicy outside embedded mode")
@@ -566,6 +759,10 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 
 		// defensive code in case the webhook doesn't work
 		if policy.IsSpecChanged() {
+			if !policy.FromHTTPFilterPolicy() {
+				policy.SetPlugins(mosniov1.ValidateFilterPolicyPlugins(policy))
+			}
+
 			err := mosniov1.ValidateFilterPolicy(policy)
 			if err != nil {
 				log.Errorf("invalid FilterPolicy, err: %v, name: %s, namespace: %s", err, policy.Name, policy.Namespace)
@@ -587,6 +784,19 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 			continue
 		}
 
+		// The allowance is checked in each reconciliation as the PluginConfigPolicy may be changed
+		if err := mosniov1.ValidateFilterPolicyAllowance(policy, allowances); err != nil {
+			log.Errorf("forbidden FilterPolicy, err: %v, name: %s, namespace: %s", err, policy.Name, policy.Namespace)
+			policy.SetAccepted(gwapiv1a2.PolicyConditionReason(mosniov1.ReasonForbidden), err.Error())
+			continue
+		}
+
+		if err := resolveFilterPolicySensitiveFields(ctx, r, &r.secretRefs, policy); err != nil {
+			log.Errorf("failed to resolve FilterPolicy, err: %v, name: %s, namespace: %s", err, policy.Name, policy.Namespace)
+			policy.SetAccepted(gwapiv1a2.PolicyConditionReason(mosniov1.ReasonUnresolved), err.Error())
+			continue
+		}
+
 		var err error
 		if ref.Group == "networking.istio.io" {
 			if ref.Kind == "VirtualService" {
@@ -595,6 +805,8 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 				key := getK8sKey(nsName.Namespace, nsName.Name)
 				istioGwIdx[key] = append(istioGwIdx[key], policy)
 				err = r.resolveIstioGateway(ctx, policy, initState)
+			} else if ref.Kind == "ServiceEntry" || ref.Kind == "DestinationRule" {
+				err = r.resolveUpstream(ctx, policy, initState, istioGwIdx)
 			}
 		} else if ref.Group == "gateway.networking.k8s.io" {
 			if ref.Kind == "HTTPRoute" {
@@ -618,6 +830,9 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 		}
 
 		for _, vs := range virtualServices.Items {
+			if !config.InShard(vs.Namespace) {
+				continue
+			}
 			policy := getFilterPolicyFromAnnotation(vs)
 			if policy == nil {
 				continue
@@ -626,6 +841,14 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 			policy.Namespace = vs.Namespace
 			// Name convention is "embedded-$kind-$name"
 			policy.Name = "embedded-virtualservice-" + vs.Name
+			if err := mosniov1.ValidateFilterPolicyAllowance(policy, allowances); err != nil {
+				log.Errorf("forbidden embedded FilterPolicy, err: %v, name: %s, namespace: %s", err, policy.Name, policy.Namespace)
+				continue
+			}
+			if err := resolveFilterPolicySensitiveFields(ctx, r, &r.secretRefs, policy); err != nil {
+				log.Errorf("failed to resolve embedded FilterPolicy, err: %v, name: %s, namespace: %s", err, policy.Name, policy.Namespace)
+				continue
+			}
 			err := r.resolveWithVirtualService(ctx, vs, policy, initState, istioGwIdx)
 			if err != nil {
 				return nil, err
@@ -646,6 +869,9 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 
 		if config.EnableEmbeddedMode() {
 			for _, gw := range gateways.Items {
+				if !config.InShard(gw.Namespace) {
+					continue
+				}
 				policy := getFilterPolicyFromAnnotation(gw)
 				if policy == nil {
 					continue
@@ -655,6 +881,14 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 				policy.Namespace = gw.Namespace
 				// Name convention is "embedded-$kind-$name"
 				policy.Name = "embedded-gateway-" + gw.Name
+				if err := mosniov1.ValidateFilterPolicyAllowance(policy, allowances); err != nil {
+					log.Errorf("forbidden embedded FilterPolicy, err: %v, name: %s, namespace: %s", err, policy.Name, policy.Namespace)
+					continue
+				}
+				if err := resolveFilterPolicySensitiveFields(ctx, r, &r.secretRefs, policy); err != nil {
+					log.Errorf("failed to resolve embedded FilterPolicy, err: %v, name: %s, namespace: %s", err, policy.Name, policy.Namespace)
+					continue
+				}
 				err := r.resolveWithIstioGateway(ctx, gw, policy, initState)
 				if err != nil {
 					return nil, err
@@ -668,7 +902,9 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 		}
 
 		for _, gw := range gateways.Items {
-			initState.AddIstioGateway(gw)
+			if config.InShard(gw.Namespace) {
+				initState.AddIstioGateway(gw)
+			}
 		}
 
 		if config.EnableGatewayAPI() {
@@ -678,7 +914,9 @@ func (r *FilterPolicyReconciler) policyToTranslationState(ctx context.Context,
 			}
 
 			for i := range k8sGateways.Items {
-				initState.AddK8sGateway(&k8sGateways.Items[i])
+				if config.InShard(k8sGateways.Items[i].Namespace) {
+					initState.AddK8sGateway(&k8sGateways.Items[i])
+				}
 			}
 
 		}
@@ -700,13 +938,14 @@ func (r *FilterPolicyReconciler) updatePolicies(ctx context.Context,
 		policy := &policies.Items[i]
 		// track changed status will be a little faster than iterating policies
 		// but make code much complex
-		if !policy.Status.IsChanged() {
+		if !policy.Status.IsChanged() || !config.InShard(policy.Namespace) {
 			continue
 		}
 
 		if policy.FromHTTPFilterPolicy() {
 			// The policy is generated by HTTPFilterPolicy
 			p := mosniov1.ConvertFilterPolicyToHTTPFilterPolicy(policy)
+			events.FromStatus(&p, p.Status.Conditions)
 			if err := r.UpdateStatus(ctx, p.DeepCopy(), &p.Status); err != nil {
 				return fmt.Errorf("failed to update HTTPFilterPolicy status: %w, namespacedName: %v",
 					err, types.NamespacedName{Name: p.Name, Namespace: p.Namespace})
@@ -714,6 +953,7 @@ func (r *FilterPolicyReconciler) updatePolicies(ctx context.Context,
 			continue
 		}
 
+		recordFilterPolicyEvents(policy, &policy.Status)
 		policy.Status.Reset()
 		// DeepCopy is used to avoid data race in FindAffectedObjects
 		if err := r.UpdateStatus(ctx, policy.DeepCopy(), &policy.Status); err != nil {
@@ -724,11 +964,22 @@ func (r *FilterPolicyReconciler) updatePolicies(ctx context.Context,
 	return nil
 }
 
+// recordFilterPolicyEvents emits the warning events when the policy or its plugins are not accepted
+func recordFilterPolicyEvents(obj runtime.Object, status *mosniov1.FilterPolicyStatus) {
+	events.FromStatus(obj, status.Conditions)
+	for _, ps := range status.Plugins {
+		if ps.Reason == mosniov1.ReasonInvalid {
+			events.Warning(obj, string(ps.Reason), ps.Message)
+		}
+	}
+}
+
 // customResourceIndexer indexes the additional customer resource
 // according to the reconciled customer resource
 type customResourceIndexer struct {
-	lock  sync.RWMutex
-	index map[string][]*mosniov1.FilterPolicy
+	lock     sync.RWMutex
+	index    map[string][]*mosniov1.FilterPolicy
+	matchAll bool
 
 	Group          string
 	Kind           string
@@ -741,6 +992,13 @@ func (v *customResourceIndexer) UpdateIndex(idx map[string][]*mosniov1.FilterPol
 	v.lock.Unlock()
 }
 
+// SetMatchAll makes any change of the custom resource trigger reconciliation, regardless of the index
+func (v *customResourceIndexer) SetMatchAll(matchAll bool) {
+	v.lock.Lock()
+	v.matchAll = matchAll
+	v.lock.Unlock()
+}
+
 func (v *customResourceIndexer) FindAffectedObjects(ctx context.Context, obj component.ResourceMeta) []reconcile.Request {
 	if config.EnableEmbeddedMode() {
 		ann := obj.GetAnnotations()
@@ -767,8 +1025,14 @@ func (v *customResourceIndexer) FindAffectedObjects(ctx context.Context, obj com
 	}
 
 	v.lock.RLock()
+	matchAll := v.matchAll
 	policies, ok := v.index[getK8sKey(obj.GetNamespace(), obj.GetName())]
 	v.lock.RUnlock()
+	if matchAll {
+		log.Infof("Resource changed, trigger reconciliation, group: %s, kind: %s, namespace: %s, name: %s",
+			obj.GetGroup(), obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		return triggerReconciliation()
+	}
 	if !ok {
 		return nil
 	}
@@ -813,6 +1077,11 @@ func wrapClientObjectToResourceMeta(obj client.Object, group, kind string) compo
 	}
 }
 
+// RefersSecret returns true if the Secret is referred by the FilterPolicies in the last reconciliation
+func (r *FilterPolicyReconciler) RefersSecret(_ context.Context, key types.NamespacedName) bool {
+	return r.secretRefs.has(key)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *FilterPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	controller := ctrl.NewControllerManagedBy(mgr).
@@ -822,9 +1091,32 @@ func (r *FilterPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []reconcile.Request {
 				return triggerReconciliation()
 			}),
+			builder.WithPredicates(
+				predicate.Or(
+					predicate.GenerationChangedPredicate{},
+					rolloutStatusChangedPredicate,
+				),
+			),
+		).
+		Watches(
+			&mosniov1.PluginConfigPolicy{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []reconcile.Request {
+				return triggerReconciliation()
+			}),
 			builder.WithPredicates(
 				predicate.GenerationChangedPredicate{},
 			),
+		).
+		Watches(
+			&corev1.Secret{},
+			&secretHandler{
+				requests: func(ctx context.Context, secret *corev1.Secret) []reconcile.Request {
+					if r.RefersSecret(ctx, client.ObjectKeyFromObject(secret)) {
+						return triggerReconciliation()
+					}
+					return nil
+				},
+			},
 		)
 		// We don't reconcile when the generated EnvoyFilter is modified.
 		// So that user can manually correct the EnvoyFilter, until something else is changed.
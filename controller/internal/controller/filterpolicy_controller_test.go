@@ -58,4 +58,9 @@ func TestNeedReconcile(t *testing.T) {
 		"ns/name": {&policy},
 	}
 	assert.True(t, r.NeedReconcile(ctx, res))
+
+	r.httpRouteIndexer.index = nil
+	assert.False(t, r.NeedReconcile(ctx, res))
+	r.httpRouteIndexer.SetMatchAll(true)
+	assert.True(t, r.NeedReconcile(ctx, res))
 }
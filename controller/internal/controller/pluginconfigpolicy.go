@@ -0,0 +1,79 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"mosn.io/htnn/controller/internal/events"
+	"mosn.io/htnn/controller/internal/log"
+	"mosn.io/htnn/controller/pkg/component"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+//+kubebuilder:rbac:groups=htnn.mosn.io,resources=pluginconfigpolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=htnn.mosn.io,resources=pluginconfigpolicies/status,verbs=get;update;patch
+
+// listPluginConfigPolicies lists the PluginConfigPolicies and validates them. The status is only
+// updated in memory, so the caller can decide whether to write it back.
+func listPluginConfigPolicies(ctx context.Context, rm component.ResourceManager,
+	list *mosniov1.PluginConfigPolicyList) ([]*mosniov1.PluginConfigPolicy, error) {
+
+	if err := rm.List(ctx, list); err != nil {
+		return nil, fmt.Errorf("failed to list PluginConfigPolicy: %w", err)
+	}
+
+	policies := make([]*mosniov1.PluginConfigPolicy, 0, len(list.Items))
+	for i := range list.Items {
+		policy := &list.Items[i]
+
+		// defensive code in case the webhook doesn't work
+		if policy.IsSpecChanged() {
+			err := mosniov1.ValidatePluginConfigPolicy(policy)
+			if err != nil {
+				log.Errorf("invalid PluginConfigPolicy, err: %v, name: %s", err, policy.Name)
+				policy.SetAccepted(mosniov1.ReasonInvalid, err.Error())
+				continue
+			}
+			policy.SetAccepted(mosniov1.ReasonAccepted)
+		}
+		if !policy.IsValid() {
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+func updatePluginConfigPolicies(ctx context.Context, rm component.ResourceManager,
+	list *mosniov1.PluginConfigPolicyList) error {
+
+	for i := range list.Items {
+		policy := &list.Items[i]
+		if !policy.Status.IsChanged() {
+			continue
+		}
+		events.FromStatus(policy, policy.Status.Conditions)
+		policy.Status.Reset()
+		if err := rm.UpdateStatus(ctx, policy, &policy.Status); err != nil {
+			return fmt.Errorf("failed to update PluginConfigPolicy status: %w, namespacedName: %v",
+				err, types.NamespacedName{Name: policy.Name})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/events"
+	"mosn.io/htnn/controller/internal/log"
+	"mosn.io/htnn/controller/internal/translation"
+	"mosn.io/htnn/controller/pkg/component"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+// limitEnvoyFilterSize replaces the generated EnvoyFilters which exceed the size quota with the
+// ones written last time, so that the policies in one namespace can't make the EnvoyFilter too large
+// to be stored. The EnvoyFilter is dropped if it's never written before.
+func (r *FilterPolicyReconciler) limitEnvoyFilterSize(policies *mosniov1.FilterPolicyList,
+	efs map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter) map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter {
+
+	limit := config.MaxEnvoyFilterSize()
+	if limit <= 0 {
+		return efs
+	}
+
+	for key, ef := range efs {
+		b, err := json.Marshal(ef)
+		if err != nil || len(b) <= limit {
+			continue
+		}
+
+		msg := fmt.Sprintf("the generated EnvoyFilter %s/%s has %d bytes, which exceeds the quota %d bytes",
+			key.Namespace, key.Name, len(b), limit)
+		if prev, ok := r.lastEnvoyFilters[key]; ok {
+			efs[key] = prev
+			msg += ", the previous version is kept"
+		} else {
+			delete(efs, key)
+		}
+		log.Error(msg)
+
+		var info translation.Info
+		if err := json.Unmarshal([]byte(ef.Annotations[translation.AnnotationInfo]), &info); err != nil {
+			continue
+		}
+		for i := range policies.Items {
+			policy := &policies.Items[i]
+			nsName := policy.Namespace + "/" + policy.Name
+			for _, name := range info.FilterPolicies {
+				if name != nsName {
+					continue
+				}
+				if policy.FromHTTPFilterPolicy() {
+					p := mosniov1.ConvertFilterPolicyToHTTPFilterPolicy(policy)
+					events.Warning(&p, events.ReasonQuotaExceeded, msg)
+				} else {
+					events.Warning(policy, events.ReasonQuotaExceeded, msg)
+				}
+				break
+			}
+		}
+	}
+	return efs
+}
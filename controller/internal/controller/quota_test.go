@@ -0,0 +1,100 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/events"
+	"mosn.io/htnn/controller/internal/translation"
+	"mosn.io/htnn/controller/pkg/component"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+func TestLimitEnvoyFilterSize(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	events.SetRecorder(recorder)
+	defer events.SetRecorder(nil)
+
+	t.Setenv("HTNN_QUOTA_MAX_ENVOY_FILTER_SIZE", "1024")
+	config.Init()
+	t.Cleanup(func() {
+		// Init doesn't reset the value when the env is removed
+		os.Setenv("HTNN_QUOTA_MAX_ENVOY_FILTER_SIZE", "0")
+		config.Init()
+	})
+
+	policies := &mosniov1.FilterPolicyList{
+		Items: []mosniov1.FilterPolicy{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy"}},
+			mosniov1.ConvertHTTPFilterPolicyToFilterPolicy(&mosniov1.HTTPFilterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "http"},
+			}),
+		},
+	}
+	newEnvoyFilter := func(name string, size int) *istiov1a3.EnvoyFilter {
+		info := translation.Info{FilterPolicies: []string{"ns/policy", "ns/http"}}
+		return &istiov1a3.EnvoyFilter{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      name,
+				Annotations: map[string]string{
+					translation.AnnotationInfo: info.String(),
+					"padding":                  strings.Repeat("x", size),
+				},
+			},
+		}
+	}
+	small := component.EnvoyFilterKey{Namespace: "ns", Name: "small"}
+	large := component.EnvoyFilterKey{Namespace: "ns", Name: "large"}
+
+	r := &FilterPolicyReconciler{}
+	efs := map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		small: newEnvoyFilter("small", 0),
+		large: newEnvoyFilter("large", 2048),
+	}
+	efs = r.limitEnvoyFilterSize(policies, efs)
+	require.Len(t, efs, 1)
+	assert.NotNil(t, efs[small])
+	assert.Len(t, recorder.Events, 2)
+	ev := <-recorder.Events
+	assert.True(t, strings.HasPrefix(ev, "Warning QuotaExceeded the generated EnvoyFilter ns/large has "), ev)
+	assert.True(t, strings.HasSuffix(ev, "which exceeds the quota 1024 bytes"), ev)
+	<-recorder.Events
+
+	// keep the previous version
+	prev := newEnvoyFilter("large", 0)
+	r.lastEnvoyFilters = map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		large: prev,
+	}
+	efs = map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		large: newEnvoyFilter("large", 2048),
+	}
+	efs = r.limitEnvoyFilterSize(policies, efs)
+	assert.Same(t, prev, efs[large])
+	assert.Len(t, recorder.Events, 2)
+	ev = <-recorder.Events
+	assert.True(t, strings.HasSuffix(ev, ", the previous version is kept"), ev)
+	<-recorder.Events
+}
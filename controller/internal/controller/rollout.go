@@ -0,0 +1,462 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	istioapi "istio.io/api/networking/v1alpha3"
+	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/log"
+	"mosn.io/htnn/controller/pkg/component"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+const (
+	defaultRolloutInterval = time.Minute
+	defaultRolloutDuration = 10 * time.Minute
+
+	prometheusQueryTimeout = 10 * time.Second
+)
+
+// prometheusClient is used to query the Prometheus server. The timeout prevents the reconciliation from
+// being blocked by a slow server.
+var prometheusClient = &http.Client{
+	Timeout: prometheusQueryTimeout,
+}
+
+// stableSpec returns the spec promoted last time. The targetRef is not rolled out.
+func stableSpec(policy *mosniov1.FilterPolicy) (*mosniov1.FilterPolicySpec, error) {
+	spec := &mosniov1.FilterPolicySpec{}
+	st := policy.Status.Rollout
+	if st.Stable != nil && len(st.Stable.Raw) > 0 {
+		if err := json.Unmarshal(st.Stable.Raw, spec); err != nil {
+			return nil, err
+		}
+	}
+	spec.TargetRef = policy.Spec.TargetRef
+	spec.Rollout = policy.Spec.Rollout
+	return spec, nil
+}
+
+func toStable(spec *mosniov1.FilterPolicySpec) (*runtime.RawExtension, error) {
+	stable := spec.DeepCopy()
+	stable.TargetRef = nil
+	stable.Rollout = nil
+	b, err := json.Marshal(stable)
+	if err != nil {
+		return nil, err
+	}
+	return &runtime.RawExtension{Raw: b}, nil
+}
+
+// isRollingOut returns true if the current generation of the policy is not applied to all the gateway pods
+func isRollingOut(policy *mosniov1.FilterPolicy) bool {
+	st := policy.Status.Rollout
+	if policy.Spec.Rollout == nil || st == nil {
+		return false
+	}
+	return st.Generation != policy.Generation || st.Phase != mosniov1.RolloutPhasePromoted
+}
+
+// isCanary returns true if the current generation of the policy is applied to the canary pods
+func isCanary(policy *mosniov1.FilterPolicy) bool {
+	st := policy.Status.Rollout
+	return st.Generation == policy.Generation && st.Phase == mosniov1.RolloutPhaseProgressing && policy.IsAccepted()
+}
+
+func selectorKey(selector map[string]string) string {
+	labels := make([]string, 0, len(selector))
+	for k, v := range selector {
+		labels = append(labels, k+"="+v)
+	}
+	slices.Sort(labels)
+	return strings.Join(labels, ",")
+}
+
+func (r *FilterPolicyReconciler) translate(ctx context.Context, policies *mosniov1.FilterPolicyList,
+	allowances []*mosniov1.PluginConfigPolicy) (map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter, error) {
+
+	initState, err := r.policyToTranslationState(ctx, policies, allowances)
+	if err != nil {
+		return nil, err
+	}
+	finalState, err := initState.Process(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return finalState.EnvoyFilters, nil
+}
+
+// translateRollouts translates the policies in rollout. The stable version of the policies is applied
+// to all the gateway pods, and the new version is applied to the canary pods via the EnvoyFilters with
+// workload selector, which are applied after the stable ones.
+func (r *FilterPolicyReconciler) translateRollouts(ctx context.Context, policies *mosniov1.FilterPolicyList,
+	allowances []*mosniov1.PluginConfigPolicy, efs map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter,
+) (map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter, error) {
+
+	stableSpecs := make(map[int]*mosniov1.FilterPolicySpec)
+	canaries := make(map[string][]int)
+	selectors := make(map[string]map[string]string)
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if !policyInShard(policy) || !isRollingOut(policy) {
+			continue
+		}
+
+		spec, err := stableSpec(policy)
+		if err != nil {
+			log.Errorf("failed to unmarshal the stable spec, err: %v, name: %s, namespace: %s", err, policy.Name, policy.Namespace)
+			continue
+		}
+		stableSpecs[i] = spec
+		if isCanary(policy) {
+			key := selectorKey(policy.Spec.Rollout.Selector)
+			canaries[key] = append(canaries[key], i)
+			selectors[key] = policy.Spec.Rollout.Selector
+		}
+	}
+	if len(stableSpecs) == 0 {
+		return efs, nil
+	}
+
+	// The status in the copies is dropped. The stable version is validated again as its status is reset.
+	toStableVersion := func(policies *mosniov1.FilterPolicyList, canary []int) {
+		for i, spec := range stableSpecs {
+			if slices.Contains(canary, i) {
+				continue
+			}
+			policy := &policies.Items[i]
+			policy.Spec = *spec.DeepCopy()
+			policy.Status = mosniov1.FilterPolicyStatus{}
+		}
+	}
+
+	stable := policies.DeepCopy()
+	toStableVersion(stable, nil)
+	base, err := r.translate(ctx, stable, allowances)
+	if err != nil {
+		log.Errorf("failed to translate the stable version of the policies in rollout: %v", err)
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(canaries))
+	for key := range canaries {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for i, key := range keys {
+		canary := policies.DeepCopy()
+		toStableVersion(canary, canaries[key])
+		canaryEFs, err := r.translate(ctx, canary, allowances)
+		if err != nil {
+			log.Errorf("failed to translate the canary version of the policies in rollout: %v", err)
+			return nil, err
+		}
+
+		addCanaryEnvoyFilters(base, canaryEFs, i, selectors[key])
+	}
+	return base, nil
+}
+
+// addCanaryEnvoyFilters adds the EnvoyFilters which override the route configuration of the canary pods.
+// The listener level patches, like the insertion of the Go filter, are dropped, as they are already
+// applied to all the pods by the stable EnvoyFilters. Applying them again inserts the filters twice.
+func addCanaryEnvoyFilters(base map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter,
+	canaryEFs map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter, idx int, selector map[string]string) {
+
+	for _, ef := range canaryEFs {
+		if stableEF, ok := base[component.EnvoyFilterKey{Namespace: ef.Namespace, Name: ef.Name}]; ok &&
+			proto.Equal(&stableEF.Spec, &ef.Spec) {
+			continue
+		}
+
+		patches := make([]*istioapi.EnvoyFilter_EnvoyConfigObjectPatch, 0, len(ef.Spec.ConfigPatches))
+		for _, patch := range ef.Spec.ConfigPatches {
+			if patch.ApplyTo == istioapi.EnvoyFilter_HTTP_ROUTE {
+				patches = append(patches, patch)
+			}
+		}
+		if len(patches) == 0 {
+			log.Infof("skip the canary version of EnvoyFilter %s/%s as only the route level configuration is rolled out",
+				ef.Namespace, ef.Name)
+			continue
+		}
+
+		// The EnvoyFilter with workload selector only applies to the pods in the same namespace,
+		// which matches how we generate the EnvoyFilters.
+		ef.SetName(fmt.Sprintf("%s-canary-%d", ef.Name, idx))
+		ef.Spec.ConfigPatches = patches
+		ef.Spec.WorkloadSelector = &istioapi.WorkloadSelector{
+			Labels: selector,
+		}
+		ef.Spec.Priority++
+		base[component.EnvoyFilterKey{Namespace: ef.Namespace, Name: ef.Name}] = ef
+	}
+}
+
+// rolloutStatusChangedPredicate triggers the translation when the rollout is started, promoted or rolled back
+var rolloutStatusChangedPredicate = predicate.Funcs{
+	CreateFunc: func(_ event.CreateEvent) bool {
+		return false
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPolicy, ok := e.ObjectOld.(*mosniov1.FilterPolicy)
+		if !ok {
+			return false
+		}
+		newPolicy, ok := e.ObjectNew.(*mosniov1.FilterPolicy)
+		if !ok {
+			return false
+		}
+		oldSt, newSt := oldPolicy.Status.Rollout, newPolicy.Status.Rollout
+		if oldSt == nil || newSt == nil {
+			return oldSt != newSt
+		}
+		return oldSt.Phase != newSt.Phase || oldSt.Generation != newSt.Generation
+	},
+	DeleteFunc: func(_ event.DeleteEvent) bool {
+		return false
+	},
+	GenericFunc: func(_ event.GenericEvent) bool {
+		return false
+	},
+}
+
+// FilterPolicyRolloutReconciler drives the rollout of the FilterPolicies. It starts the rollout when
+// the policy is changed, and promotes or rolls back the new configuration according to the analysis.
+// The result is recorded in the status, which is read by the FilterPolicyReconciler.
+type FilterPolicyRolloutReconciler struct {
+	component.ResourceManager
+
+	query func(ctx context.Context, address string, query string) (int, error)
+}
+
+func NewFilterPolicyRolloutReconciler(manager component.ResourceManager) *FilterPolicyRolloutReconciler {
+	return &FilterPolicyRolloutReconciler{
+		ResourceManager: manager,
+		query:           queryPrometheus,
+	}
+}
+
+// Reconcile advances the rollouts of all the FilterPolicies, and requeues until the next analysis.
+func (r *FilterPolicyRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log.Info("Reconcile FilterPolicy rollout")
+
+	var policies mosniov1.FilterPolicyList
+	if err := r.List(ctx, &policies); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list FilterPolicy: %w", err)
+	}
+
+	now := time.Now()
+	var requeueAfter time.Duration
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if !config.InShard(policy.Namespace) {
+			continue
+		}
+
+		changed, next := r.advance(ctx, policy, now)
+		if changed {
+			if err := r.UpdateStatus(ctx, policy.DeepCopy(), &policy.Status); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to update FilterPolicy status: %w, namespacedName: %v",
+					err, types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace})
+			}
+		}
+		if next > 0 && (requeueAfter == 0 || next < requeueAfter) {
+			requeueAfter = next
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// advance moves the rollout of the policy forward. It returns whether the status is changed, and
+// how long to wait before the next analysis.
+func (r *FilterPolicyRolloutReconciler) advance(ctx context.Context, policy *mosniov1.FilterPolicy,
+	now time.Time) (bool, time.Duration) {
+
+	rollout := policy.Spec.Rollout
+	st := policy.Status.Rollout
+	if rollout == nil {
+		if st == nil {
+			return false, 0
+		}
+		// so the rollout starts from scratch when it's configured again
+		policy.Status.Rollout = nil
+		return true, 0
+	}
+
+	if st == nil {
+		// The current configuration is the baseline of the following rollouts
+		stable, err := toStable(&policy.Spec)
+		if err != nil {
+			log.Errorf("failed to marshal FilterPolicy, err: %v, name: %s, namespace: %s", err, policy.Name, policy.Namespace)
+			return false, 0
+		}
+		policy.Status.Rollout = &mosniov1.FilterPolicyRolloutStatus{
+			Phase:      mosniov1.RolloutPhasePromoted,
+			Generation: policy.Generation,
+			StartTime:  metav1.NewTime(now),
+			Stable:     stable,
+		}
+		return true, 0
+	}
+
+	interval := defaultRolloutInterval
+	if rollout.Analysis.Interval != nil {
+		interval = rollout.Analysis.Interval.Duration
+	}
+	duration := defaultRolloutDuration
+	if rollout.Analysis.Duration != nil {
+		duration = rollout.Analysis.Duration.Duration
+	}
+
+	if st.Generation != policy.Generation {
+		if err := mosniov1.ValidateFilterPolicy(policy); err != nil {
+			// the invalid configuration is never rolled out
+			return false, 0
+		}
+		st.Phase = mosniov1.RolloutPhaseProgressing
+		st.Generation = policy.Generation
+		st.StartTime = metav1.NewTime(now)
+		st.Message = ""
+		return true, interval
+	}
+
+	if st.Phase != mosniov1.RolloutPhaseProgressing {
+		return false, 0
+	}
+
+	elapsed := now.Sub(st.StartTime.Time)
+	n, err := r.query(ctx, config.RolloutPrometheusAddr(), rollout.Analysis.Query)
+	if err != nil {
+		log.Errorf("failed to run the analysis, err: %v, name: %s, namespace: %s", err, policy.Name, policy.Namespace)
+		if elapsed >= duration {
+			// the new configuration can't be proved healthy before the deadline
+			st.Phase = mosniov1.RolloutPhaseRolledBack
+			st.Message = fmt.Sprintf("failed to run the analysis before the deadline: %v", err)
+			return true, 0
+		}
+
+		next := min(interval, duration-elapsed)
+		msg := fmt.Sprintf("failed to run the analysis: %v", err)
+		if st.Message == msg {
+			return false, next
+		}
+		st.Message = msg
+		return true, next
+	}
+	if n > 0 {
+		st.Phase = mosniov1.RolloutPhaseRolledBack
+		st.Message = fmt.Sprintf("the analysis returns %d series", n)
+		return true, 0
+	}
+
+	if elapsed < duration {
+		// the message of the failed query is kept until the analysis passes
+		return false, min(interval, duration-elapsed)
+	}
+
+	stable, err := toStable(&policy.Spec)
+	if err != nil {
+		log.Errorf("failed to marshal FilterPolicy, err: %v, name: %s, namespace: %s", err, policy.Name, policy.Namespace)
+		return false, interval
+	}
+	st.Phase = mosniov1.RolloutPhasePromoted
+	st.Message = ""
+	st.Stable = stable
+	return true, 0
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FilterPolicyRolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("filterpolicyrollout").
+		Watches(
+			&mosniov1.FilterPolicy{},
+			handler.EnqueueRequestsFromMapFunc(func(_ context.Context, _ client.Object) []reconcile.Request {
+				return triggerReconciliation()
+			}),
+			builder.WithPredicates(
+				predicate.GenerationChangedPredicate{},
+			),
+		).
+		Complete(r)
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string            `json:"resultType"`
+		Result     []json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// queryPrometheus runs the instant query and returns the number of the series in the result
+func queryPrometheus(ctx context.Context, address string, query string) (int, error) {
+	if address == "" {
+		return 0, errors.New("the address of the Prometheus server is not configured")
+	}
+
+	u := strings.TrimSuffix(address, "/") + "/api/v1/query?" + url.Values{"query": {query}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := prometheusClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	var res prometheusResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return 0, fmt.Errorf("unexpected response with status code %d: %s", resp.StatusCode, body)
+	}
+	if res.Status != "success" {
+		return 0, errors.New(res.Error)
+	}
+	if res.Data.ResultType != "vector" && res.Data.ResultType != "matrix" {
+		return 0, fmt.Errorf("the query should return a vector, got %s", res.Data.ResultType)
+	}
+	return len(res.Data.Result), nil
+}
@@ -0,0 +1,304 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istioapi "istio.io/api/networking/v1alpha3"
+	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"mosn.io/htnn/controller/internal/istio"
+	"mosn.io/htnn/controller/internal/model"
+	"mosn.io/htnn/controller/pkg/component"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+func TestAdvanceRollout(t *testing.T) {
+	var series int
+	var queryErr error
+	r := &FilterPolicyRolloutReconciler{
+		query: func(_ context.Context, _ string, _ string) (int, error) {
+			return series, queryErr
+		},
+	}
+
+	policy := &mosniov1.FilterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy", Generation: 1},
+		Spec: mosniov1.FilterPolicySpec{
+			TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+				PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+					Group: "networking.istio.io",
+					Kind:  "VirtualService",
+					Name:  "vs",
+				},
+			},
+			Filters: map[string]mosniov1.Plugin{
+				"demo": {Config: runtime.RawExtension{Raw: []byte(`{"hostName":"v1"}`)}},
+			},
+			Rollout: &mosniov1.FilterPolicyRollout{
+				Selector: map[string]string{"canary": "true"},
+				Analysis: mosniov1.RolloutAnalysis{
+					Query: "up == 0",
+				},
+			},
+		},
+	}
+	ctx := context.Background()
+	now := time.Now()
+
+	// the first generation is the baseline
+	changed, next := r.advance(ctx, policy, now)
+	assert.True(t, changed)
+	assert.Equal(t, time.Duration(0), next)
+	st := policy.Status.Rollout
+	require.NotNil(t, st)
+	assert.Equal(t, mosniov1.RolloutPhasePromoted, st.Phase)
+	assert.False(t, isRollingOut(policy))
+	changed, _ = r.advance(ctx, policy, now)
+	assert.False(t, changed)
+
+	// start the rollout
+	policy.Generation = 2
+	policy.Spec.Filters["demo"] = mosniov1.Plugin{Config: runtime.RawExtension{Raw: []byte(`{"hostName":"v2"}`)}}
+	changed, next = r.advance(ctx, policy, now)
+	assert.True(t, changed)
+	assert.Equal(t, defaultRolloutInterval, next)
+	assert.Equal(t, mosniov1.RolloutPhaseProgressing, st.Phase)
+	assert.True(t, isRollingOut(policy))
+	spec, err := stableSpec(policy)
+	require.NoError(t, err)
+	assert.Equal(t, `{"hostName":"v1"}`, string(spec.Filters["demo"].Config.Raw))
+	assert.Equal(t, policy.Spec.TargetRef, spec.TargetRef)
+
+	// the analysis fails
+	queryErr = errors.New("timeout")
+	changed, next = r.advance(ctx, policy, now.Add(time.Minute))
+	assert.True(t, changed)
+	assert.Equal(t, defaultRolloutInterval, next)
+	assert.Equal(t, "failed to run the analysis: timeout", st.Message)
+	changed, _ = r.advance(ctx, policy, now.Add(2*time.Minute))
+	assert.False(t, changed)
+	queryErr = nil
+
+	// the analysis passes, wait until the duration is reached
+	changed, next = r.advance(ctx, policy, now.Add(9*time.Minute+30*time.Second))
+	assert.False(t, changed)
+	assert.Equal(t, 30*time.Second, next)
+	assert.Equal(t, mosniov1.RolloutPhaseProgressing, st.Phase)
+
+	changed, _ = r.advance(ctx, policy, now.Add(10*time.Minute))
+	assert.True(t, changed)
+	assert.Equal(t, mosniov1.RolloutPhasePromoted, st.Phase)
+	assert.Equal(t, "", st.Message)
+	spec, err = stableSpec(policy)
+	require.NoError(t, err)
+	assert.Equal(t, `{"hostName":"v2"}`, string(spec.Filters["demo"].Config.Raw))
+
+	// roll back
+	policy.Generation = 3
+	policy.Spec.Filters["demo"] = mosniov1.Plugin{Config: runtime.RawExtension{Raw: []byte(`{"hostName":"v3"}`)}}
+	r.advance(ctx, policy, now)
+	series = 2
+	changed, next = r.advance(ctx, policy, now.Add(time.Minute))
+	assert.True(t, changed)
+	assert.Equal(t, time.Duration(0), next)
+	assert.Equal(t, mosniov1.RolloutPhaseRolledBack, st.Phase)
+	assert.Equal(t, "the analysis returns 2 series", st.Message)
+	assert.True(t, isRollingOut(policy))
+	spec, err = stableSpec(policy)
+	require.NoError(t, err)
+	assert.Equal(t, `{"hostName":"v2"}`, string(spec.Filters["demo"].Config.Raw))
+
+	// the analysis keeps failing until the deadline
+	policy.Generation = 4
+	policy.Spec.Filters["demo"] = mosniov1.Plugin{Config: runtime.RawExtension{Raw: []byte(`{"hostName":"v4"}`)}}
+	r.advance(ctx, policy, now)
+	series = 0
+	queryErr = errors.New("timeout")
+	changed, next = r.advance(ctx, policy, now.Add(9*time.Minute+30*time.Second))
+	assert.True(t, changed)
+	assert.Equal(t, 30*time.Second, next)
+	assert.Equal(t, mosniov1.RolloutPhaseProgressing, st.Phase)
+	changed, next = r.advance(ctx, policy, now.Add(10*time.Minute))
+	assert.True(t, changed)
+	assert.Equal(t, time.Duration(0), next)
+	assert.Equal(t, mosniov1.RolloutPhaseRolledBack, st.Phase)
+	assert.Equal(t, "failed to run the analysis before the deadline: timeout", st.Message)
+	queryErr = nil
+
+	// the invalid configuration is not rolled out
+	policy.Generation = 5
+	policy.Spec.Rollout.Analysis.Query = ""
+	changed, _ = r.advance(ctx, policy, now)
+	assert.False(t, changed)
+	assert.Equal(t, int64(4), st.Generation)
+
+	// remove the rollout
+	policy.Spec.Rollout = nil
+	changed, _ = r.advance(ctx, policy, now)
+	assert.True(t, changed)
+	assert.Nil(t, policy.Status.Rollout)
+}
+
+func TestAddCanaryEnvoyFilters(t *testing.T) {
+	generate := func(version string) map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter {
+		golangConfig := map[string]interface{}{
+			"demo": map[string]interface{}{"hostName": version},
+		}
+		lds := istio.GenerateLDSFilter("default-0.0.0.0_80", "0.0.0.0_80", true, map[string]interface{}{
+			model.CategoryECDSGolang: golangConfig,
+		})
+		lds.SetNamespace("default")
+		lds.SetName("htnn-lds-0.0.0.0-80")
+		route := istio.GenerateRouteFilter(&model.VirtualHost{Name: "default.local:80"}, "route", map[string]interface{}{
+			"htnn.filters.http.golang": golangConfig,
+		})
+		route.SetNamespace("default")
+		route.SetName("htnn-h-default.local")
+		return map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+			{Namespace: "default", Name: lds.Name}:   lds,
+			{Namespace: "default", Name: route.Name}: route,
+		}
+	}
+
+	selector := map[string]string{"canary": "true"}
+	efs := generate("v1")
+	addCanaryEnvoyFilters(efs, generate("v2"), 0, selector)
+	require.Len(t, efs, 3)
+
+	canary := efs[component.EnvoyFilterKey{Namespace: "default", Name: "htnn-h-default.local-canary-0"}]
+	require.NotNil(t, canary)
+	assert.Equal(t, selector, canary.Spec.WorkloadSelector.Labels)
+	assert.Equal(t, int32(1), canary.Spec.Priority)
+	require.Len(t, canary.Spec.ConfigPatches, 1)
+	assert.Equal(t, istioapi.EnvoyFilter_HTTP_ROUTE, canary.Spec.ConfigPatches[0].ApplyTo)
+	assert.Contains(t, canary.Spec.ConfigPatches[0].Patch.Value.String(), "v2")
+
+	// the Go filter is only inserted once on the canary pods
+	insertions := 0
+	for _, ef := range efs {
+		if ef.Spec.WorkloadSelector != nil && ef.Spec.WorkloadSelector.Labels["canary"] != "true" {
+			continue
+		}
+		for _, patch := range ef.Spec.ConfigPatches {
+			if patch.ApplyTo == istioapi.EnvoyFilter_HTTP_FILTER {
+				insertions++
+			}
+		}
+	}
+	assert.Equal(t, 1, insertions)
+}
+
+func TestRolloutStatusChangedPredicate(t *testing.T) {
+	newPolicy := func(st *mosniov1.FilterPolicyRolloutStatus) *mosniov1.FilterPolicy {
+		return &mosniov1.FilterPolicy{
+			Status: mosniov1.FilterPolicyStatus{Rollout: st},
+		}
+	}
+	update := func(oldSt, newSt *mosniov1.FilterPolicyRolloutStatus) bool {
+		return rolloutStatusChangedPredicate.Update(event.UpdateEvent{
+			ObjectOld: newPolicy(oldSt),
+			ObjectNew: newPolicy(newSt),
+		})
+	}
+
+	assert.False(t, update(nil, nil))
+	assert.True(t, update(nil, &mosniov1.FilterPolicyRolloutStatus{}))
+	assert.True(t, update(&mosniov1.FilterPolicyRolloutStatus{}, nil))
+	assert.True(t, update(
+		&mosniov1.FilterPolicyRolloutStatus{Phase: mosniov1.RolloutPhaseProgressing, Generation: 1},
+		&mosniov1.FilterPolicyRolloutStatus{Phase: mosniov1.RolloutPhasePromoted, Generation: 1},
+	))
+	// only the message is changed
+	assert.False(t, update(
+		&mosniov1.FilterPolicyRolloutStatus{Phase: mosniov1.RolloutPhaseProgressing, Generation: 1},
+		&mosniov1.FilterPolicyRolloutStatus{Phase: mosniov1.RolloutPhaseProgressing, Generation: 1, Message: "failed"},
+	))
+}
+
+func TestQueryPrometheus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		series int
+		err    string
+	}{
+		{
+			name:   "empty",
+			status: 200,
+			body:   `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+		},
+		{
+			name:   "series",
+			status: 200,
+			body:   `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1,"1"]}]}}`,
+			series: 1,
+		},
+		{
+			name:   "scalar",
+			status: 200,
+			body:   `{"status":"success","data":{"resultType":"scalar","result":[1,"1"]}}`,
+			err:    "the query should return a vector, got scalar",
+		},
+		{
+			name:   "bad query",
+			status: 400,
+			body:   `{"status":"error","errorType":"bad_data","error":"parse error"}`,
+			err:    "parse error",
+		},
+		{
+			name:   "not json",
+			status: 502,
+			body:   `bad gateway`,
+			err:    "unexpected response with status code 502: bad gateway",
+		},
+	}
+
+	_, err := queryPrometheus(context.Background(), "", "up == 0")
+	assert.EqualError(t, err, "the address of the Prometheus server is not configured")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/query", r.URL.Path)
+				assert.Equal(t, "up == 0", r.URL.Query().Get("query"))
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer ts.Close()
+
+			n, err := queryPrometheus(context.Background(), ts.URL+"/", "up == 0")
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.series, n)
+		})
+	}
+}
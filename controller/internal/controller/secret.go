@@ -0,0 +1,121 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/controller/internal/config"
+)
+
+// secretReferences records the Secrets referred by the sensitive fields during the reconciliation.
+// The references found in the current reconciliation take effect after it succeeds, so a failed
+// reconciliation won't drop the references.
+type secretReferences struct {
+	lock    sync.RWMutex
+	current map[types.NamespacedName]struct{}
+	next    map[types.NamespacedName]struct{}
+}
+
+func (s *secretReferences) record(key types.NamespacedName) {
+	if s == nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.next == nil {
+		s.next = make(map[types.NamespacedName]struct{})
+	}
+	s.next[key] = struct{}{}
+}
+
+func (s *secretReferences) commit() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.current = s.next
+	s.next = nil
+}
+
+func (s *secretReferences) has(key types.NamespacedName) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	_, ok := s.current[key]
+	return ok
+}
+
+// refersSecret returns true if the configuration refers to the Secret
+func refersSecret(raw []byte, name string) bool {
+	return bytes.Contains(raw, []byte(plugins.SecretRefPrefix+name+"/"))
+}
+
+// secretHandler enqueues the resources which refer to the changed Secret, so that the rotated
+// credentials take effect without touching the resources. The requests are delayed by the debounce
+// window, so the Secrets changed together only trigger one reconciliation.
+type secretHandler struct {
+	requests func(ctx context.Context, secret *corev1.Secret) []reconcile.Request
+}
+
+var _ handler.EventHandler = &secretHandler{}
+
+func (h *secretHandler) enqueue(ctx context.Context, obj client.Object, q workqueue.RateLimitingInterface) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		return
+	}
+	debounce := config.SecretReconcileDebounce()
+	for _, req := range h.requests(ctx, secret) {
+		q.AddAfter(req, debounce)
+	}
+}
+
+func (h *secretHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(ctx, evt.Object, q)
+}
+
+func (h *secretHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
+	oldSecret, ok := evt.ObjectOld.(*corev1.Secret)
+	if !ok {
+		return
+	}
+	newSecret, ok := evt.ObjectNew.(*corev1.Secret)
+	if !ok {
+		return
+	}
+	if reflect.DeepEqual(oldSecret.Data, newSecret.Data) {
+		// only the data is referred
+		return
+	}
+	h.enqueue(ctx, newSecret, q)
+}
+
+func (h *secretHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
+	h.enqueue(ctx, evt.Object, q)
+}
+
+func (h *secretHandler) Generic(_ context.Context, _ event.GenericEvent, _ workqueue.RateLimitingInterface) {
+}
@@ -0,0 +1,137 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/controller/component"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+func TestSecretReferences(t *testing.T) {
+	var refs secretReferences
+	key := types.NamespacedName{Namespace: "ns", Name: "redis"}
+
+	refs.record(key)
+	// not committed yet
+	assert.False(t, refs.has(key))
+	refs.commit()
+	assert.True(t, refs.has(key))
+
+	// the references are replaced by the ones found in the next reconciliation
+	refs.record(types.NamespacedName{Namespace: "ns", Name: "mysql"})
+	assert.True(t, refs.has(key))
+	refs.commit()
+	assert.False(t, refs.has(key))
+
+	var nilRefs *secretReferences
+	assert.NotPanics(t, func() {
+		nilRefs.record(key)
+	})
+}
+
+func TestRefersSecret(t *testing.T) {
+	raw := []byte(`{"password":"secret://redis/password"}`)
+	assert.True(t, refersSecret(raw, "redis"))
+	assert.False(t, refersSecret(raw, "red"))
+	assert.False(t, refersSecret(raw, "password"))
+}
+
+func TestSecretHandler(t *testing.T) {
+	// registered before Setenv, so it runs after the environment variable is restored
+	t.Cleanup(config.Init)
+	t.Setenv("HTNN_SECRET_RECONCILE_DEBOUNCE", "100ms")
+	config.Init()
+
+	h := &secretHandler{
+		requests: func(_ context.Context, secret *corev1.Secret) []reconcile.Request {
+			if secret.Name == "redis" {
+				return triggerReconciliation()
+			}
+			return nil
+		},
+	}
+	newSecret := func(name string, value string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: name},
+			Data:       map[string][]byte{"password": []byte(value)},
+		}
+	}
+	ctx := context.Background()
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+
+	h.Create(ctx, event.CreateEvent{Object: newSecret("mysql", "a")}, q)
+	h.Update(ctx, event.UpdateEvent{ObjectOld: newSecret("redis", "a"), ObjectNew: newSecret("redis", "a")}, q)
+	h.Generic(ctx, event.GenericEvent{Object: newSecret("redis", "a")}, q)
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(t, 0, q.Len())
+
+	// the changes within the debounce window are merged
+	h.Update(ctx, event.UpdateEvent{ObjectOld: newSecret("redis", "a"), ObjectNew: newSecret("redis", "b")}, q)
+	h.Delete(ctx, event.DeleteEvent{Object: newSecret("redis", "b")}, q)
+	assert.Equal(t, 0, q.Len())
+	assert.Eventually(t, func() bool {
+		return q.Len() == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReconcilersReferringSecret(t *testing.T) {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "ns", Name: "redis"}
+
+	policyReconciler := &FilterPolicyReconciler{}
+	assert.False(t, policyReconciler.RefersSecret(ctx, key))
+	policyReconciler.secretRefs.record(key)
+	policyReconciler.secretRefs.commit()
+	assert.True(t, policyReconciler.RefersSecret(ctx, key))
+
+	consumerReconciler := &ConsumerReconciler{}
+	assert.False(t, consumerReconciler.RefersSecret(ctx, key))
+	consumerReconciler.secretRefs.record(key)
+	consumerReconciler.secretRefs.commit()
+	assert.True(t, consumerReconciler.RefersSecret(ctx, key))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, mosniov1.AddToScheme(scheme))
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&mosniov1.ServiceRegistry{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "nacos"},
+		Spec: mosniov1.ServiceRegistrySpec{
+			Type: "nacos",
+			Config: runtime.RawExtension{
+				Raw: []byte(`{"password":"secret://redis/password"}`),
+			},
+		},
+	}).Build()
+	registryReconciler := NewServiceRegistryReconciler(component.NewK8sResourceManager(cli))
+	assert.True(t, registryReconciler.RefersSecret(ctx, key))
+	assert.False(t, registryReconciler.RefersSecret(ctx, types.NamespacedName{Namespace: "other", Name: "redis"}))
+	assert.False(t, registryReconciler.RefersSecret(ctx, types.NamespacedName{Namespace: "ns", Name: "mysql"}))
+}
@@ -0,0 +1,182 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/pkg/component"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+	registrytype "mosn.io/htnn/types/pkg/registry"
+	"mosn.io/htnn/types/pkg/sealed"
+)
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// sensitiveFieldResolver resolves the Secret references and the sealed values in the sensitive fields
+// of the plugin configuration, so that the data plane can use them directly.
+type sensitiveFieldResolver struct {
+	component.ResourceManager
+	namespace string
+	refs      *secretReferences
+}
+
+func (r *sensitiveFieldResolver) resolveValue(ctx context.Context, value string) (string, error) {
+	if strings.HasPrefix(value, plugins.SecretRefPrefix) {
+		ref := strings.TrimPrefix(value, plugins.SecretRefPrefix)
+		name, key, found := strings.Cut(ref, "/")
+		if !found || name == "" || key == "" {
+			return "", fmt.Errorf("invalid secret reference %q: should be in the format of `%s$name/$key`",
+				value, plugins.SecretRefPrefix)
+		}
+
+		var secret corev1.Secret
+		nsName := types.NamespacedName{Name: name, Namespace: r.namespace}
+		// record the reference even if the Secret is missing, so that creating it triggers the reconciliation
+		r.refs.record(nsName)
+		err := r.Get(ctx, nsName, &secret)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", fmt.Errorf("secret %s not found", nsName)
+			}
+			return "", fmt.Errorf("failed to get secret %s: %w", nsName, err)
+		}
+		data, ok := secret.Data[key]
+		if !ok {
+			return "", fmt.Errorf("key %s not found in secret %s", key, nsName)
+		}
+		return string(data), nil
+	}
+
+	if sealed.IsSealed(value) {
+		k := config.SealedValueKey()
+		if k == "" {
+			return "", errors.New("sealed value key is not configured")
+		}
+		key, err := sealed.ParseKey(k)
+		if err != nil {
+			return "", err
+		}
+		return sealed.Unseal(key, value)
+	}
+
+	return value, nil
+}
+
+func (r *sensitiveFieldResolver) resolve(ctx context.Context, fields []string, raw *runtime.RawExtension) error {
+	if len(fields) == 0 || len(raw.Raw) == 0 {
+		return nil
+	}
+
+	var conf interface{}
+	if err := json.Unmarshal(raw.Raw, &conf); err != nil {
+		return err
+	}
+
+	changed := false
+	err := plugins.TransformSensitiveFields(conf, fields, func(value string) (string, error) {
+		res, err := r.resolveValue(ctx, value)
+		if res != value {
+			changed = true
+		}
+		return res, err
+	})
+	if err != nil || !changed {
+		return err
+	}
+
+	b, err := json.Marshal(conf)
+	if err != nil {
+		return err
+	}
+	raw.Raw = b
+	return nil
+}
+
+func (r *sensitiveFieldResolver) resolveFilters(ctx context.Context, filters map[string]mosniov1.Plugin) error {
+	for name, filter := range filters {
+		err := r.resolve(ctx, plugins.LoadSensitiveFields(name), &filter.Config)
+		if err != nil {
+			return fmt.Errorf("failed to resolve sensitive fields of filter %s: %w", name, err)
+		}
+		filters[name] = filter
+	}
+	return nil
+}
+
+// resolveFilterPolicySensitiveFields replaces the sensitive fields in the FilterPolicy with the resolved value.
+func resolveFilterPolicySensitiveFields(ctx context.Context, rm component.ResourceManager, refs *secretReferences,
+	policy *mosniov1.FilterPolicy) error {
+
+	r := &sensitiveFieldResolver{
+		ResourceManager: rm,
+		namespace:       policy.Namespace,
+		refs:            refs,
+	}
+	if err := r.resolveFilters(ctx, policy.Spec.Filters); err != nil {
+		return err
+	}
+	for _, sub := range policy.Spec.SubPolicies {
+		if err := r.resolveFilters(ctx, sub.Filters); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveConsumerSensitiveFields replaces the sensitive fields in the Consumer with the resolved value.
+func resolveConsumerSensitiveFields(ctx context.Context, rm component.ResourceManager, refs *secretReferences,
+	consumer *mosniov1.Consumer) error {
+
+	r := &sensitiveFieldResolver{
+		ResourceManager: rm,
+		namespace:       consumer.Namespace,
+		refs:            refs,
+	}
+	for name, filter := range consumer.Spec.Auth {
+		err := r.resolve(ctx, plugins.LoadConsumerSensitiveFields(name), &filter.Config)
+		if err != nil {
+			return fmt.Errorf("failed to resolve sensitive fields of authn filter %s: %w", name, err)
+		}
+		consumer.Spec.Auth[name] = filter
+	}
+	return r.resolveFilters(ctx, consumer.Spec.Filters)
+}
+
+// resolveServiceRegistrySensitiveFields replaces the sensitive fields in the ServiceRegistry with the resolved value.
+func resolveServiceRegistrySensitiveFields(ctx context.Context, rm component.ResourceManager,
+	serviceRegistry *mosniov1.ServiceRegistry) error {
+
+	r := &sensitiveFieldResolver{
+		ResourceManager: rm,
+		namespace:       serviceRegistry.Namespace,
+	}
+	err := r.resolve(ctx, registrytype.LoadSensitiveFields(serviceRegistry.Spec.Type), &serviceRegistry.Spec.Config)
+	if err != nil {
+		return fmt.Errorf("failed to resolve sensitive fields of registry %s: %w", serviceRegistry.Spec.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/controller/internal/config"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+	"mosn.io/htnn/types/pkg/sealed"
+)
+
+type secretResourceManager struct {
+	secrets map[client.ObjectKey]*corev1.Secret
+}
+
+func (m *secretResourceManager) Get(ctx context.Context, key client.ObjectKey, out client.Object) error {
+	secret, ok := m.secrets[key]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+	}
+	secret.DeepCopyInto(out.(*corev1.Secret))
+	return nil
+}
+
+func (m *secretResourceManager) List(ctx context.Context, list client.ObjectList) error {
+	return nil
+}
+
+func (m *secretResourceManager) UpdateStatus(ctx context.Context, obj client.Object, statusPtr any) error {
+	return nil
+}
+
+type sensitiveConfig struct {
+	plugins.MockPluginConfig
+}
+
+func (c *sensitiveConfig) SensitiveFields() []string {
+	return []string{"password"}
+}
+
+type sensitivePlugin struct {
+	plugins.MockPlugin
+}
+
+func (p *sensitivePlugin) Config() api.PluginConfig {
+	return &sensitiveConfig{}
+}
+
+func TestResolveFilterPolicySensitiveFields(t *testing.T) {
+	plugins.RegisterPluginType("sensitive", &sensitivePlugin{})
+
+	key := "MDEyMzQ1Njc4OWFiY2RlZg=="
+	os.Setenv("HTNN_SEALED_VALUE_KEY", key)
+	defer os.Unsetenv("HTNN_SEALED_VALUE_KEY")
+	config.Init()
+	k, _ := sealed.ParseKey(key)
+	sealedValue, err := sealed.Seal(k, "sealed password")
+	require.NoError(t, err)
+
+	rm := &secretResourceManager{
+		secrets: map[client.ObjectKey]*corev1.Secret{
+			{Namespace: "ns", Name: "redis"}: {
+				Data: map[string][]byte{
+					"password": []byte("secret password"),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		res      string
+		err      string
+	}{
+		{
+			name:     "plain",
+			password: "plain password",
+			res:      `{"password":"plain password"}`,
+		},
+		{
+			name:     "secret",
+			password: "secret://redis/password",
+			res:      `{"password":"secret password"}`,
+		},
+		{
+			name:     "sealed",
+			password: sealedValue,
+			res:      `{"password":"sealed password"}`,
+		},
+		{
+			name:     "secret not found",
+			password: "secret://mysql/password",
+			err:      "secret ns/mysql not found",
+		},
+		{
+			name:     "key not found",
+			password: "secret://redis/pass",
+			err:      "key pass not found in secret ns/redis",
+		},
+		{
+			name:     "invalid reference",
+			password: "secret://redis",
+			err:      "invalid secret reference",
+		},
+		{
+			name:     "bad sealed value",
+			password: "sealed:YWJj",
+			err:      "invalid sealed value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &mosniov1.FilterPolicy{}
+			policy.Namespace = "ns"
+			policy.Spec.Filters = map[string]mosniov1.Plugin{
+				"sensitive": {
+					Config: runtime.RawExtension{
+						Raw: []byte(`{"password":"` + tt.password + `"}`),
+					},
+				},
+				"others": {
+					Config: runtime.RawExtension{
+						Raw: []byte(`{"password":"secret://redis/password"}`),
+					},
+				},
+			}
+			err := resolveFilterPolicySensitiveFields(context.Background(), rm, nil, policy)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.res, string(policy.Spec.Filters["sensitive"].Config.Raw))
+			// only the sensitive fields are resolved
+			assert.JSONEq(t, `{"password":"secret://redis/password"}`, string(policy.Spec.Filters["others"].Config.Raw))
+		})
+	}
+
+	// the referred Secret is recorded even if it's missing
+	var refs secretReferences
+	policy := &mosniov1.FilterPolicy{}
+	policy.Namespace = "ns"
+	policy.Spec.Filters = map[string]mosniov1.Plugin{
+		"sensitive": {
+			Config: runtime.RawExtension{
+				Raw: []byte(`{"password":"secret://mysql/password"}`),
+			},
+		},
+	}
+	err = resolveFilterPolicySensitiveFields(context.Background(), rm, &refs, policy)
+	require.Error(t, err)
+	refs.commit()
+	assert.True(t, refs.has(client.ObjectKey{Namespace: "ns", Name: "mysql"}))
+}
@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -30,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"mosn.io/htnn/controller/internal/events"
 	"mosn.io/htnn/controller/internal/log"
 	"mosn.io/htnn/controller/internal/metrics"
 	"mosn.io/htnn/controller/internal/registry"
@@ -114,8 +117,11 @@ func (r *ServiceRegistryReconciler) reconcileServiceRegistry(ctx context.Context
 		return nil
 	}
 
-	err = registry.UpdateRegistry(&serviceRegistry, prevServiceRegistry)
+	err = resolveServiceRegistrySensitiveFields(ctx, r, &serviceRegistry)
 	if err != nil {
+		log.Errorf("failed to resolve ServiceRegistry %v: %v", nsName, err)
+		serviceRegistry.SetAccepted(mosniov1.ReasonUnresolved, err.Error())
+	} else if err = registry.UpdateRegistry(&serviceRegistry, prevServiceRegistry); err != nil {
 		log.Errorf("failed to update ServiceRegistry %v: %v", nsName, err)
 		serviceRegistry.SetAccepted(mosniov1.ReasonInvalid, err.Error())
 		// don't retry if the err is caused by registry.
@@ -133,6 +139,7 @@ func (r *ServiceRegistryReconciler) reconcileServiceRegistry(ctx context.Context
 	if !serviceRegistry.Status.IsChanged() {
 		return nil
 	}
+	events.FromStatus(&serviceRegistry, serviceRegistry.Status.Conditions)
 	serviceRegistry.Status.Reset()
 
 	if err := r.UpdateStatus(ctx, &serviceRegistry, &serviceRegistry.Status); err != nil {
@@ -160,5 +167,38 @@ func (r *ServiceRegistryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			builder.WithPredicates(
 				predicate.GenerationChangedPredicate{},
 			),
+		).
+		Watches(
+			&corev1.Secret{},
+			&secretHandler{
+				requests: r.serviceRegistriesReferringSecret,
+			},
 		).Complete(r)
 }
+
+// RefersSecret returns true if the Secret is referred by any ServiceRegistry
+func (r *ServiceRegistryReconciler) RefersSecret(ctx context.Context, key types.NamespacedName) bool {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+	return len(r.serviceRegistriesReferringSecret(ctx, secret)) > 0
+}
+
+// serviceRegistriesReferringSecret returns the ServiceRegistries which refer to the Secret in the configuration
+func (r *ServiceRegistryReconciler) serviceRegistriesReferringSecret(ctx context.Context, secret *corev1.Secret) []reconcile.Request {
+	var serviceRegistries mosniov1.ServiceRegistryList
+	if err := r.List(ctx, &serviceRegistries); err != nil {
+		log.Errorf("failed to list ServiceRegistry: %v", err)
+		return nil
+	}
+
+	var reqs []reconcile.Request
+	for _, serviceRegistry := range serviceRegistries.Items {
+		if serviceRegistry.Namespace != secret.Namespace || !refersSecret(serviceRegistry.Spec.Config.Raw, secret.Name) {
+			continue
+		}
+		reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: serviceRegistry.Namespace,
+			Name:      serviceRegistry.Name,
+		}})
+	}
+	return reqs
+}
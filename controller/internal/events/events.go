@@ -0,0 +1,80 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"mosn.io/htnn/controller/pkg/component"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+const (
+	// ReasonAttached is used when a policy is attached to the target
+	ReasonAttached = "Attached"
+	// ReasonDetached is used when a policy is detached from the target
+	ReasonDetached = "Detached"
+	// ReasonQuotaExceeded is used when the resource generated from the object exceeds the quota
+	ReasonQuotaExceeded = "QuotaExceeded"
+)
+
+//+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+var (
+	lock     sync.RWMutex
+	recorder component.EventRecorder
+)
+
+// SetRecorder sets the recorder used to emit the events. Setting it to nil disables the events.
+func SetRecorder(r component.EventRecorder) {
+	lock.Lock()
+	recorder = r
+	lock.Unlock()
+}
+
+func emit(obj runtime.Object, eventtype, reason, message string) {
+	lock.RLock()
+	r := recorder
+	lock.RUnlock()
+	if r == nil {
+		return
+	}
+	r.Event(obj, eventtype, reason, message)
+}
+
+// Normal emits an event which is informational on the object.
+func Normal(obj runtime.Object, reason, message string) {
+	emit(obj, corev1.EventTypeNormal, reason, message)
+}
+
+// Warning emits an event which shows something goes wrong on the object.
+func Warning(obj runtime.Object, reason, message string) {
+	emit(obj, corev1.EventTypeWarning, reason, message)
+}
+
+// FromStatus emits a warning event if the Accepted condition shows the object is not accepted.
+// The event uses the reason and the message of the condition.
+func FromStatus(obj runtime.Object, conds []metav1.Condition) {
+	for _, cond := range conds {
+		if cond.Type == string(mosniov1.ConditionAccepted) && cond.Status == metav1.ConditionFalse {
+			Warning(obj, cond.Reason, cond.Message)
+			return
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+func TestFromStatus(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	SetRecorder(recorder)
+	defer SetRecorder(nil)
+
+	obj := &mosniov1.Consumer{}
+	FromStatus(obj, []metav1.Condition{
+		{
+			Type:   string(mosniov1.ConditionAccepted),
+			Status: metav1.ConditionTrue,
+			Reason: string(mosniov1.ReasonAccepted),
+		},
+	})
+	FromStatus(obj, []metav1.Condition{
+		{
+			Type:    string(mosniov1.ConditionAccepted),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(mosniov1.ReasonInvalid),
+			Message: "spec.auth: unknown http filter: auth",
+		},
+	})
+	Normal(obj, ReasonAttached, "FilterPolicy ns/policy is attached")
+
+	assert.Equal(t, "Warning Invalid spec.auth: unknown http filter: auth", <-recorder.Events)
+	assert.Equal(t, "Normal Attached FilterPolicy ns/policy is attached", <-recorder.Events)
+	assert.Empty(t, recorder.Events)
+
+	SetRecorder(nil)
+	// no recorder, no panic
+	Warning(obj, "Invalid", "msg")
+}
@@ -0,0 +1,62 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	istioscheme "istio.io/client-go/pkg/clientset/versioned/scheme"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+
+	"mosn.io/htnn/controller/internal/gatewayapi"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+// Component is the source of the events emitted by HTNN
+const Component = "htnn-controller"
+
+// Start sends the events to the K8S API server until the stop channel is closed
+func Start(restConfig *rest.Config, stop <-chan struct{}) error {
+	scheme := runtime.NewScheme()
+	fs := []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		mosniov1.AddToScheme,
+		istioscheme.AddToScheme,
+		gatewayapi.AddToScheme,
+	}
+	for _, f := range fs {
+		if err := f(scheme); err != nil {
+			return err
+		}
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientset.CoreV1().Events("")})
+	SetRecorder(broadcaster.NewRecorder(scheme, corev1.EventSource{Component: Component}))
+	go func() {
+		<-stop
+		SetRecorder(nil)
+		broadcaster.Shutdown()
+	}()
+	return nil
+}
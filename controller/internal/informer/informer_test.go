@@ -0,0 +1,169 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package informer
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"mosn.io/htnn/controller/internal/config"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+func TestStripUnusedFields(t *testing.T) {
+	newConsumer := func() *mosniov1.Consumer {
+		return &mosniov1.Consumer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "consumer",
+				Annotations: map[string]string{
+					corev1.LastAppliedConfigAnnotation: `{"spec":{}}`,
+					"htnn.mosn.io/info":                "{}",
+				},
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+			},
+		}
+	}
+
+	obj, err := StripUnusedFields(newConsumer())
+	require.NoError(t, err)
+	consumer := obj.(*mosniov1.Consumer)
+	assert.Nil(t, consumer.ManagedFields)
+	assert.Equal(t, map[string]string{"htnn.mosn.io/info": "{}"}, consumer.Annotations)
+
+	t.Cleanup(func() {
+		// Init doesn't reset the value when the env is removed
+		os.Setenv("HTNN_STRIP_UNUSED_FIELDS", "true")
+		config.Init()
+	})
+	t.Setenv("HTNN_STRIP_UNUSED_FIELDS", "false")
+	config.Init()
+	obj, err = StripUnusedFields(newConsumer())
+	require.NoError(t, err)
+	consumer = obj.(*mosniov1.Consumer)
+	assert.NotNil(t, consumer.ManagedFields)
+	assert.Len(t, consumer.Annotations, 2)
+
+	// the objects which are not k8s resources are kept as is
+	tombstone := cache.DeletedFinalStateUnknown{Key: "ns/name"}
+	obj, err = StripUnusedFields(tombstone)
+	require.NoError(t, err)
+	assert.Equal(t, tombstone, obj)
+	obj, err = StripUnusedFields("string")
+	require.NoError(t, err)
+	assert.Equal(t, "string", obj)
+}
+
+func TestWatchedNamespaces(t *testing.T) {
+	// all the namespaces are watched without the selector
+	config.Init()
+	assert.Nil(t, WatchedNamespaces())
+	assert.True(t, NamespaceWatched("unwatched"))
+
+	t.Setenv("HTNN_WATCH_NAMESPACE_SELECTOR", "htnn.mosn.io/watch=true")
+	config.Init()
+	t.Cleanup(func() {
+		SetWatchedNamespaces(nil)
+	})
+	// not synced yet
+	assert.Nil(t, WatchedNamespaces())
+	assert.True(t, NamespaceWatched("unwatched"))
+
+	assert.True(t, SetWatchedNamespaces([]string{"watched"}))
+	assert.False(t, SetWatchedNamespaces([]string{"watched"}))
+	assert.Equal(t, []string{"istio-system", "watched"}, WatchedNamespaces())
+	assert.True(t, NamespaceWatched("watched"))
+	assert.True(t, NamespaceWatched("istio-system"))
+	assert.False(t, NamespaceWatched("unwatched"))
+
+	assert.True(t, SetWatchedNamespaces([]string{"unwatched", "istio-system"}))
+	assert.Equal(t, []string{"istio-system", "unwatched"}, WatchedNamespaces())
+	assert.False(t, NamespaceWatched("watched"))
+
+	// no namespace is selected
+	assert.True(t, SetWatchedNamespaces([]string{}))
+	assert.Equal(t, []string{"istio-system"}, WatchedNamespaces())
+	assert.False(t, NamespaceWatched("unwatched"))
+
+	assert.True(t, SetWatchedNamespaces(nil))
+	assert.Nil(t, WatchedNamespaces())
+	assert.True(t, NamespaceWatched("unwatched"))
+}
+
+func TestSecretWatcher(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "creds"},
+		Data:       map[string][]byte{"password": []byte("pass")},
+	}
+	var out corev1.Secret
+	assert.Error(t, GetSecret(types.NamespacedName{Namespace: "default", Name: "creds"}, &out))
+
+	t.Cleanup(config.Init)
+	t.Setenv("HTNN_SECRET_RECONCILE_DEBOUNCE", "10ms")
+	config.Init()
+
+	client := k8sfake.NewSimpleClientset(secret)
+	stop := make(chan struct{})
+	t.Cleanup(func() {
+		close(stop)
+	})
+	changed := make(chan types.NamespacedName, 10)
+	require.NoError(t, StartSecretWatcher(client, stop, func(key types.NamespacedName) {
+		changed <- key
+	}))
+
+	key := types.NamespacedName{Namespace: "default", Name: "creds"}
+	require.NoError(t, GetSecret(key, &out))
+	assert.Equal(t, "pass", string(out.Data["password"]))
+	err := GetSecret(types.NamespacedName{Namespace: "default", Name: "missing"}, &out)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	ctx := context.Background()
+	// the change of the metadata is ignored
+	secret.Labels = map[string]string{"app": "demo"}
+	_, err = client.CoreV1().Secrets("default").Update(ctx, secret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	secret.Data["password"] = []byte("rotated")
+	_, err = client.CoreV1().Secrets("default").Update(ctx, secret, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	select {
+	case k := <-changed:
+		assert.Equal(t, key, k)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the change of the secret is not notified")
+	}
+	require.Eventually(t, func() bool {
+		return GetSecret(key, &out) == nil && string(out.Data["password"]) == "rotated"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Empty(t, changed)
+
+	require.NoError(t, client.CoreV1().Secrets("default").Delete(ctx, "creds", metav1.DeleteOptions{}))
+	select {
+	case k := <-changed:
+		assert.Equal(t, key, k)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the deletion of the secret is not notified")
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package informer
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/log"
+)
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+var (
+	nsLock sync.RWMutex
+	// nil means the namespaces are not synced yet
+	watchedNamespaces map[string]struct{}
+)
+
+// NamespaceWatched returns true if the resources in the namespace should be reconciled. When a
+// namespace selector is configured, only the namespaces matched by it and the root namespace are watched.
+// All the namespaces are watched before the selected namespaces are synced, so that the generated
+// configuration won't be removed during startup.
+func NamespaceWatched(namespace string) bool {
+	if namespace == "" || namespace == config.RootNamespace() || config.WatchNamespaceSelector() == "" {
+		return true
+	}
+
+	nsLock.RLock()
+	defer nsLock.RUnlock()
+	if watchedNamespaces == nil {
+		return true
+	}
+	_, ok := watchedNamespaces[namespace]
+	return ok
+}
+
+// WatchedNamespaces returns the namespaces whose resources should be reconciled, including the root
+// namespace. It returns nil if all the namespaces are watched.
+func WatchedNamespaces() []string {
+	if config.WatchNamespaceSelector() == "" {
+		return nil
+	}
+
+	nsLock.RLock()
+	defer nsLock.RUnlock()
+	if watchedNamespaces == nil {
+		return nil
+	}
+	root := config.RootNamespace()
+	namespaces := make([]string, 0, len(watchedNamespaces)+1)
+	namespaces = append(namespaces, root)
+	for ns := range watchedNamespaces {
+		if ns != root {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	slices.Sort(namespaces)
+	return namespaces
+}
+
+// SetWatchedNamespaces updates the watched namespaces and returns true if they are changed. It's
+// called by the namespace watcher once the selected namespaces are synced. A nil slice resets them to
+// the unsynced state.
+func SetWatchedNamespaces(namespaces []string) bool {
+	nsLock.Lock()
+	defer nsLock.Unlock()
+
+	if namespaces == nil {
+		changed := watchedNamespaces != nil
+		watchedNamespaces = nil
+		return changed
+	}
+	changed := watchedNamespaces == nil || len(watchedNamespaces) != len(namespaces)
+	set := make(map[string]struct{}, len(namespaces))
+	for _, ns := range namespaces {
+		set[ns] = struct{}{}
+		if _, ok := watchedNamespaces[ns]; !ok {
+			changed = true
+		}
+	}
+	watchedNamespaces = set
+	return changed
+}
+
+// StartNamespaceWatcher watches the namespaces matched by the configured selector until the stop
+// channel is closed. The onChange is called when the watched namespaces are changed, including the
+// first time they are synced. Only the metadata of the selected namespaces is cached.
+func StartNamespaceWatcher(restConfig *rest.Config, stop <-chan struct{}, onChange func()) error {
+	selector := config.WatchNamespaceSelector()
+	if selector == "" {
+		return nil
+	}
+	if _, err := labels.Parse(selector); err != nil {
+		return fmt.Errorf("invalid namespace selector %q: %w", selector, err)
+	}
+
+	c, err := metadata.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	factory := metadatainformer.NewFilteredSharedInformerFactory(c, config.InformerResyncPeriod(), metav1.NamespaceAll,
+		func(opts *metav1.ListOptions) {
+			opts.LabelSelector = selector
+		})
+	informer := factory.ForResource(corev1.SchemeGroupVersion.WithResource("namespaces")).Informer()
+	if err := informer.SetTransform(StripUnusedFields); err != nil {
+		return err
+	}
+
+	var synced bool
+	var syncedLock sync.Mutex
+	update := func() {
+		syncedLock.Lock()
+		changed := synced && SetWatchedNamespaces(informer.GetStore().ListKeys())
+		syncedLock.Unlock()
+		if changed {
+			log.Infof("watched namespaces are changed, selector: %s", selector)
+			onChange()
+		}
+	}
+	_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(_ interface{}) {
+			update()
+		},
+		UpdateFunc: func(_, _ interface{}) {
+			update()
+		},
+		DeleteFunc: func(_ interface{}) {
+			update()
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	factory.Start(stop)
+	go func() {
+		if !cache.WaitForCacheSync(stop, informer.HasSynced) {
+			return
+		}
+		syncedLock.Lock()
+		synced = true
+		syncedLock.Unlock()
+		update()
+	}()
+	return nil
+}
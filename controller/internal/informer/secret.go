@@ -0,0 +1,170 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package informer
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/log"
+)
+
+var (
+	secretLock     sync.Mutex
+	secretInformer cache.SharedIndexInformer
+	// unsyncedSecrets are the Secrets read before the Secrets are synced. They are treated as changed
+	// once synced, so that the resources referring to them are reconciled again.
+	unsyncedSecrets map[types.NamespacedName]struct{}
+)
+
+// secretFieldSelector excludes the Secrets which are never referred by HTNN but can be large or
+// numerous, like what Istio does for its credential controller.
+var secretFieldSelector = fields.AndSelectors(
+	fields.OneTermNotEqualSelector("type", "helm.sh/release.v1"),
+	fields.OneTermNotEqualSelector("type", string(corev1.SecretTypeServiceAccountToken)),
+).String()
+
+// GetSecret reads the Secret from the cache of the Secret watcher. It's used when the resources are
+// not read via controller-runtime, for example, in istiod.
+func GetSecret(key types.NamespacedName, out *corev1.Secret) error {
+	secretLock.Lock()
+	informer := secretInformer
+	if informer == nil {
+		secretLock.Unlock()
+		return errors.New("the secret watcher is not started")
+	}
+	if !informer.HasSynced() {
+		unsyncedSecrets[key] = struct{}{}
+		secretLock.Unlock()
+		return errors.New("the secrets are not synced yet")
+	}
+	secretLock.Unlock()
+
+	obj, exists, err := informer.GetStore().GetByKey(key.String())
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return apierrors.NewNotFound(corev1.Resource("secrets"), key.Name)
+	}
+	obj.(*corev1.Secret).DeepCopyInto(out)
+	return nil
+}
+
+// StartSecretWatcher caches the Secrets so that they can be read via GetSecret. The onChange is called
+// with the Secret whose data is changed, after the debounce window, so the Secrets changed together
+// only trigger one call for each of them. It returns after the Secrets are synced, so that they are
+// available in the first reconciliation. The onChange can be nil if the changes are not concerned.
+func StartSecretWatcher(client kubernetes.Interface, stop <-chan struct{}, onChange func(key types.NamespacedName)) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(client, config.InformerResyncPeriod(),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = secretFieldSelector
+		}))
+	informer := factory.Core().V1().Secrets().Informer()
+	if err := informer.SetTransform(StripUnusedFields); err != nil {
+		return err
+	}
+
+	queue := workqueue.NewDelayingQueue()
+	enqueue := func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			return
+		}
+		queue.AddAfter(types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name},
+			config.SecretReconcileDebounce())
+	}
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj interface{}, isInInitialList bool) {
+			if isInInitialList {
+				// nothing refers to the Secrets before they are synced, except the unsynced ones
+				return
+			}
+			enqueue(obj)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldSecret, ok := oldObj.(*corev1.Secret)
+			if !ok {
+				return
+			}
+			newSecret, ok := newObj.(*corev1.Secret)
+			if !ok {
+				return
+			}
+			if reflect.DeepEqual(oldSecret.Data, newSecret.Data) {
+				// only the data is referred
+				return
+			}
+			enqueue(newSecret)
+		},
+		DeleteFunc: enqueue,
+	})
+	if err != nil {
+		return err
+	}
+
+	secretLock.Lock()
+	secretInformer = informer
+	unsyncedSecrets = make(map[types.NamespacedName]struct{})
+	secretLock.Unlock()
+
+	go func() {
+		<-stop
+		queue.ShutDown()
+	}()
+	go func() {
+		for {
+			item, shutdown := queue.Get()
+			if shutdown {
+				return
+			}
+			key := item.(types.NamespacedName)
+			log.Infof("secret %s is changed", key)
+			if onChange != nil {
+				onChange(key)
+			}
+			queue.Done(item)
+		}
+	}()
+
+	factory.Start(stop)
+	if !cache.WaitForCacheSync(stop, informer.HasSynced) {
+		return fmt.Errorf("failed to sync secrets")
+	}
+
+	secretLock.Lock()
+	for key := range unsyncedSecrets {
+		queue.Add(key)
+	}
+	unsyncedSecrets = nil
+	secretLock.Unlock()
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package informer tunes the informers which cache the resources for the controller, so that the
+// controller uses less memory in the clusters with lots of unrelated resources.
+package informer
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+
+	"mosn.io/htnn/controller/internal/config"
+)
+
+// StripUnusedFields is a transform function of the informer. It removes the managed fields and the
+// last-applied-configuration annotation, which is a full copy of the object, from the cached object,
+// unless it's disabled via the config.
+func StripUnusedFields(obj interface{}) (interface{}, error) {
+	if _, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		return obj, nil
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		// not a k8s object, keep it as is
+		return obj, nil
+	}
+
+	if !config.StripUnusedFields() {
+		return obj, nil
+	}
+	accessor.SetManagedFields(nil)
+	annotations := accessor.GetAnnotations()
+	if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		// the object is decoded from the response, so it's safe to modify it in place
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		accessor.SetAnnotations(annotations)
+	}
+	return obj, nil
+}
@@ -48,6 +48,17 @@ const (
 	DynamicConfigEnvoyFilterName = "htnn-dynamic-config"
 )
 
+// GeneratedLabels returns the labels of the EnvoyFilter generated from the creator's resources
+func GeneratedLabels(creator string) map[string]string {
+	labels := map[string]string{
+		constant.LabelCreatedBy: creator,
+	}
+	if rev := ctrlcfg.IstioRevision(); rev != "" {
+		labels[constant.LabelIstioRevision] = rev
+	}
+	return labels
+}
+
 type configWrapper struct {
 	name   string
 	pre    bool
@@ -163,9 +174,7 @@ func DefaultEnvoyFilters() map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter {
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: ctrlcfg.RootNamespace(),
 			Name:      DefaultHTTPFilter,
-			Labels: map[string]string{
-				constant.LabelCreatedBy: "FilterPolicy",
-			},
+			Labels:    GeneratedLabels("FilterPolicy"),
 		},
 		Spec: istioapi.EnvoyFilter{
 			ConfigPatches: patches,
@@ -405,9 +414,7 @@ func GenerateConsumers(consumers map[string]interface{}) *istiov1a3.EnvoyFilter
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: ctrlcfg.RootNamespace(),
 			Name:      ECDSConsumerName,
-			Labels: map[string]string{
-				constant.LabelCreatedBy: "Consumer",
-			},
+			Labels:    GeneratedLabels("Consumer"),
 		},
 		Spec: istioapi.EnvoyFilter{
 			ConfigPatches: []*istioapi.EnvoyFilter_EnvoyConfigObjectPatch{
@@ -476,9 +483,7 @@ func GenerateDynamicConfigs(namespacedDynamicConfigs map[string]map[string]*mosn
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: ns,
 				Name:      DynamicConfigEnvoyFilterName,
-				Labels: map[string]string{
-					constant.LabelCreatedBy: "DynamicConfig",
-				},
+				Labels:    GeneratedLabels("DynamicConfig"),
 			},
 			Spec: istioapi.EnvoyFilter{
 				ConfigPatches: []*istioapi.EnvoyFilter_EnvoyConfigObjectPatch{},
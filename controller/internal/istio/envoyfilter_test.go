@@ -147,6 +147,17 @@ func TestDefaultFilters(t *testing.T) {
 	require.Equal(t, want, actual)
 }
 
+func TestGeneratedLabels(t *testing.T) {
+	require.Equal(t, map[string]string{"htnn.mosn.io/created-by": "Consumer"}, GeneratedLabels("Consumer"))
+
+	patch := gomonkey.ApplyFuncReturn(ctrlcfg.IstioRevision, "canary")
+	defer patch.Reset()
+	require.Equal(t, map[string]string{
+		"htnn.mosn.io/created-by": "Consumer",
+		"istio.io/rev":            "canary",
+	}, GeneratedLabels("Consumer"))
+}
+
 func TestGenerateConsumers(t *testing.T) {
 	patch := gomonkey.ApplyFuncReturn(ctrlcfg.GoSoPath, "/path/to/goso")
 	defer patch.Reset()
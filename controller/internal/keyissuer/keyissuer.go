@@ -0,0 +1,389 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package keyissuer mints keyAuth credentials for the consumers. The credentials are stored in
+// Secrets, and the consumers refer to them via the Secret reference, so the developer portals can
+// issue keys through HTNN instead of editing the Consumer by hand. The issued keys are verified by
+// the keyAuth plugin like the other keys.
+package keyissuer
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/controller/internal/log"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+const (
+	// AuthPlugin is the plugin which consumes the issued keys
+	AuthPlugin = "keyAuth"
+	// LabelConsumer marks the Secrets created for the consumer, so that the stale ones can be
+	// found and removed after a new key is issued
+	LabelConsumer = "htnn.mosn.io/key-issued-for"
+	// SecretKey is the key of the credential in the Secret
+	SecretKey = "key"
+
+	keyPrefix = "htnn_"
+	keyBytes  = 32
+)
+
+var (
+	ErrConsumerNotFound = errors.New("consumer not found")
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrForbidden        = errors.New("forbidden")
+)
+
+// Credential is the key issued for a consumer
+type Credential struct {
+	Consumer string `json:"consumer"`
+	Key      string `json:"key"`
+	Secret   string `json:"secret"`
+}
+
+type Issuer struct {
+	client client.Client
+}
+
+func NewIssuer(c client.Client) *Issuer {
+	return &Issuer{client: c}
+}
+
+func randomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encode(b), nil
+}
+
+// Issue mints a new key for the consumer. The key replaces the previous one in the consumer's
+// keyAuth configuration, and the Secrets which store the previous keys are removed.
+//
+// Each key is stored in a new Secret instead of updating the existing one, so that the key takes
+// effect with the Consumer's update, without waiting for the debounce of the Secret changes. The
+// Secret may be unknown to the controller when the Consumer is reconciled, as it's read from the
+// Secret watcher in istiod. In this case, the consumer is reconciled again once the Secret is watched,
+// as the reference is recorded even if the Secret is missing.
+func (i *Issuer) Issue(ctx context.Context, namespace string, name string) (*Credential, error) {
+	var consumer mosniov1.Consumer
+	nsName := types.NamespacedName{Namespace: namespace, Name: name}
+	if err := i.client.Get(ctx, nsName, &consumer); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, ErrConsumerNotFound
+		}
+		return nil, fmt.Errorf("failed to get consumer %s: %w", nsName, err)
+	}
+
+	key, err := randomString(keyBytes, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	key = keyPrefix + key
+	suffix, err := randomString(4, hex.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-key-%s", name, suffix),
+			Namespace: namespace,
+			Labels: map[string]string{
+				LabelConsumer: name,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			SecretKey: []byte(key),
+		},
+	}
+	// remove the Secret together with the consumer
+	if err := controllerutil.SetOwnerReference(&consumer, secret, i.client.Scheme()); err != nil {
+		return nil, err
+	}
+	if err := i.client.Create(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to create secret: %w", err)
+	}
+
+	ref := fmt.Sprintf("%s%s/%s", plugins.SecretRefPrefix, secret.Name, SecretKey)
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var consumer mosniov1.Consumer
+		if err := i.client.Get(ctx, nsName, &consumer); err != nil {
+			return err
+		}
+		return i.setKey(ctx, &consumer, ref)
+	})
+	if err != nil {
+		// the Secret is not referred, clean it up
+		_ = i.client.Delete(ctx, secret)
+		return nil, fmt.Errorf("failed to update consumer %s: %w", nsName, err)
+	}
+
+	i.removeStaleSecrets(ctx, namespace, name, secret.Name)
+	return &Credential{
+		Consumer: name,
+		Key:      key,
+		Secret:   secret.Name,
+	}, nil
+}
+
+func (i *Issuer) setKey(ctx context.Context, consumer *mosniov1.Consumer, ref string) error {
+	conf := map[string]interface{}{}
+	if p, ok := consumer.Spec.Auth[AuthPlugin]; ok && len(p.Config.Raw) > 0 {
+		if err := json.Unmarshal(p.Config.Raw, &conf); err != nil {
+			return err
+		}
+	}
+	conf["key"] = ref
+	b, err := json.Marshal(conf)
+	if err != nil {
+		return err
+	}
+
+	if consumer.Spec.Auth == nil {
+		consumer.Spec.Auth = map[string]mosniov1.ConsumerPlugin{}
+	}
+	p := consumer.Spec.Auth[AuthPlugin]
+	p.Config.Raw = b
+	consumer.Spec.Auth[AuthPlugin] = p
+	return i.client.Update(ctx, consumer)
+}
+
+func (i *Issuer) removeStaleSecrets(ctx context.Context, namespace string, name string, current string) {
+	var secrets corev1.SecretList
+	err := i.client.List(ctx, &secrets, client.InNamespace(namespace), client.MatchingLabels{LabelConsumer: name})
+	if err != nil {
+		log.Errorf("failed to list secrets of consumer %s/%s: %v", namespace, name, err)
+		return
+	}
+	for idx := range secrets.Items {
+		secret := &secrets.Items[idx]
+		if secret.Name == current {
+			continue
+		}
+		if err := i.client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			log.Errorf("failed to delete stale secret %s/%s: %v", namespace, secret.Name, err)
+		}
+	}
+}
+
+// Authorizer checks if the bearer token is allowed to issue keys for the consumer
+type Authorizer interface {
+	// Authorize returns ErrUnauthenticated if the token is invalid, and ErrForbidden if the token
+	// is not allowed to issue keys for the consumer.
+	Authorize(ctx context.Context, token string, namespace string, name string) error
+}
+
+// KubeAuthorizer authenticates the token via the TokenReview, and checks the permission via the
+// SubjectAccessReview. The caller should be allowed to `create` the `consumers/keys` of the
+// consumer, so the key issuance can be granted per namespace with the RBAC, like:
+//
+//	apiVersion: rbac.authorization.k8s.io/v1
+//	kind: Role
+//	metadata:
+//	  name: key-issuer
+//	  namespace: default
+//	rules:
+//	- apiGroups: ["htnn.mosn.io"]
+//	  resources: ["consumers/keys"]
+//	  verbs: ["create"]
+type KubeAuthorizer struct {
+	client client.Client
+}
+
+func NewKubeAuthorizer(c client.Client) *KubeAuthorizer {
+	return &KubeAuthorizer{client: c}
+}
+
+func (a *KubeAuthorizer) Authorize(ctx context.Context, token string, namespace string, name string) error {
+	review := &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{
+			Token: token,
+		},
+	}
+	if err := a.client.Create(ctx, review); err != nil {
+		return fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return ErrUnauthenticated
+	}
+
+	user := review.Status.User
+	extra := make(map[string]authzv1.ExtraValue, len(user.Extra))
+	for k, v := range user.Extra {
+		extra[k] = authzv1.ExtraValue(v)
+	}
+	sar := &authzv1.SubjectAccessReview{
+		Spec: authzv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Namespace:   namespace,
+				Verb:        "create",
+				Group:       mosniov1.GroupVersion.Group,
+				Resource:    "consumers",
+				Subresource: "keys",
+				Name:        name,
+			},
+		},
+	}
+	if err := a.client.Create(ctx, sar); err != nil {
+		return fmt.Errorf("failed to review access: %w", err)
+	}
+	if !sar.Status.Allowed {
+		log.Infof("user %s is not allowed to issue keys for consumer %s/%s: %s",
+			user.Username, namespace, name, sar.Status.Reason)
+		return ErrForbidden
+	}
+	return nil
+}
+
+// Handler serves `POST /consumers/$namespace/$name/keys`, which issues a new key for the consumer.
+// The request should carry the token in the `Authorization: Bearer $token` header.
+type Handler struct {
+	Issuer     *Issuer
+	Authorizer Authorizer
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"msg": msg})
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("authorization"), "Bearer ")
+	if !ok || token == "" {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	segs := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(segs) != 4 || segs[0] != "consumers" || segs[1] == "" || segs[2] == "" || segs[3] != "keys" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	ns, name := segs[1], segs[2]
+	if err := h.Authorizer.Authorize(r.Context(), token, ns, name); err != nil {
+		switch {
+		case errors.Is(err, ErrUnauthenticated):
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+		case errors.Is(err, ErrForbidden):
+			writeError(w, http.StatusForbidden, "forbidden")
+		default:
+			log.Errorf("failed to authorize the request to issue key for consumer %s/%s: %v", ns, name, err)
+			writeError(w, http.StatusInternalServerError, "failed to authorize")
+		}
+		return
+	}
+
+	cred, err := h.Issuer.Issue(r.Context(), ns, name)
+	if err != nil {
+		if errors.Is(err, ErrConsumerNotFound) {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("consumer %s/%s not found", ns, name))
+			return
+		}
+		log.Errorf("failed to issue key for consumer %s/%s: %v", ns, name, err)
+		writeError(w, http.StatusInternalServerError, "failed to issue key")
+		return
+	}
+
+	log.Infof("issued key for consumer %s/%s, secret: %s", ns, name, cred.Secret)
+	w.Header().Set("content-type", "application/json")
+	w.Header().Set("cache-control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(cred)
+}
+
+// Start serves the Handler over TLS on the given address until the stop channel is closed
+func Start(restConfig *rest.Config, addr string, certFile string, keyFile string, stop <-chan struct{}) error {
+	if certFile == "" || keyFile == "" {
+		return errors.New("certificate and key are required to serve the key issuer")
+	}
+	// fail fast if the certificate is invalid
+	if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		return fmt.Errorf("failed to load the certificate of the key issuer: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return err
+	}
+	if err := mosniov1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr: addr,
+		Handler: &Handler{
+			Issuer:     NewIssuer(c),
+			Authorizer: NewKubeAuthorizer(c),
+		},
+		TLSConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		},
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-stop
+		srv.Close()
+	}()
+	go func() {
+		log.Infof("key issuer listens on %s", addr)
+		if err := srv.ListenAndServeTLS(certFile, keyFile); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("key issuer exited: %v", err)
+		}
+	}()
+	return nil
+}
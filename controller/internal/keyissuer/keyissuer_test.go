@@ -0,0 +1,271 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keyissuer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+func newClient(t *testing.T, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, mosniov1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newConsumer(auth string) *mosniov1.Consumer {
+	c := &mosniov1.Consumer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "alice",
+			Namespace: "default",
+		},
+		Spec: mosniov1.ConsumerSpec{
+			Auth: map[string]mosniov1.ConsumerPlugin{},
+		},
+	}
+	if auth != "" {
+		c.Spec.Auth[AuthPlugin] = mosniov1.ConsumerPlugin{Config: runtime.RawExtension{Raw: []byte(auth)}}
+	}
+	return c
+}
+
+func TestIssue(t *testing.T) {
+	ctx := context.Background()
+	c := newClient(t, newConsumer(`{"key":"plain"}`))
+	issuer := NewIssuer(c)
+
+	_, err := issuer.Issue(ctx, "default", "bob")
+	assert.ErrorIs(t, err, ErrConsumerNotFound)
+
+	cred, err := issuer.Issue(ctx, "default", "alice")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(cred.Key, keyPrefix))
+	assert.Equal(t, "alice", cred.Consumer)
+
+	var secret corev1.Secret
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: cred.Secret}, &secret))
+	assert.Equal(t, cred.Key, string(secret.Data[SecretKey]))
+	assert.Equal(t, "alice", secret.Labels[LabelConsumer])
+	require.Len(t, secret.OwnerReferences, 1)
+	assert.Equal(t, "alice", secret.OwnerReferences[0].Name)
+
+	var consumer mosniov1.Consumer
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "alice"}, &consumer))
+	assert.JSONEq(t, `{"key":"secret://`+cred.Secret+`/key"}`, string(consumer.Spec.Auth[AuthPlugin].Config.Raw))
+
+	// issue again to rotate the key
+	newCred, err := issuer.Issue(ctx, "default", "alice")
+	require.NoError(t, err)
+	assert.NotEqual(t, cred.Key, newCred.Key)
+	assert.NotEqual(t, cred.Secret, newCred.Secret)
+
+	var secrets corev1.SecretList
+	require.NoError(t, c.List(ctx, &secrets, client.InNamespace("default")))
+	require.Len(t, secrets.Items, 1)
+	assert.Equal(t, newCred.Secret, secrets.Items[0].Name)
+}
+
+func TestIssueWithoutKeyAuth(t *testing.T) {
+	ctx := context.Background()
+	consumer := newConsumer("")
+	consumer.Spec.Auth["hmacAuth"] = mosniov1.ConsumerPlugin{Config: runtime.RawExtension{Raw: []byte(`{"secretKey":"x"}`)}}
+	c := newClient(t, consumer)
+
+	cred, err := NewIssuer(c).Issue(ctx, "default", "alice")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "alice"}, consumer))
+	assert.JSONEq(t, `{"key":"secret://`+cred.Secret+`/key"}`, string(consumer.Spec.Auth[AuthPlugin].Config.Raw))
+	assert.JSONEq(t, `{"secretKey":"x"}`, string(consumer.Spec.Auth["hmacAuth"].Config.Raw))
+}
+
+type fakeAuthorizer struct{}
+
+func (a *fakeAuthorizer) Authorize(ctx context.Context, token string, namespace string, name string) error {
+	switch token {
+	case "token":
+		return nil
+	case "other":
+		return ErrForbidden
+	case "broken":
+		return errors.New("ouch")
+	}
+	return ErrUnauthenticated
+}
+
+func TestHandler(t *testing.T) {
+	h := &Handler{
+		Issuer:     NewIssuer(newClient(t, newConsumer(`{"key":"plain"}`))),
+		Authorizer: &fakeAuthorizer{},
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		code   int
+	}{
+		{
+			name:   "issue",
+			method: http.MethodPost,
+			path:   "/consumers/default/alice/keys",
+			token:  "token",
+			code:   http.StatusCreated,
+		},
+		{
+			name:   "no token",
+			method: http.MethodPost,
+			path:   "/consumers/default/alice/keys",
+			code:   http.StatusUnauthorized,
+		},
+		{
+			name:   "unauthenticated",
+			method: http.MethodPost,
+			path:   "/consumers/default/alice/keys",
+			token:  "invalid",
+			code:   http.StatusUnauthorized,
+		},
+		{
+			name:   "forbidden",
+			method: http.MethodPost,
+			path:   "/consumers/default/alice/keys",
+			token:  "other",
+			code:   http.StatusForbidden,
+		},
+		{
+			name:   "failed to authorize",
+			method: http.MethodPost,
+			path:   "/consumers/default/alice/keys",
+			token:  "broken",
+			code:   http.StatusInternalServerError,
+		},
+		{
+			name:   "consumer not found",
+			method: http.MethodPost,
+			path:   "/consumers/default/bob/keys",
+			token:  "token",
+			code:   http.StatusNotFound,
+		},
+		{
+			name:   "unknown path",
+			method: http.MethodPost,
+			path:   "/consumers/default/alice",
+			token:  "token",
+			code:   http.StatusNotFound,
+		},
+		{
+			name:   "bad method",
+			method: http.MethodGet,
+			path:   "/consumers/default/alice/keys",
+			token:  "token",
+			code:   http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			assert.Equal(t, tt.code, rec.Code)
+
+			if tt.code == http.StatusCreated {
+				var cred Credential
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &cred))
+				assert.NotEmpty(t, cred.Key)
+				assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
+
+func TestKubeAuthorizer(t *testing.T) {
+	var sars []*authzv1.SubjectAccessReview
+	c := interceptor.NewClient(newClient(t).(client.WithWatch), interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			switch o := obj.(type) {
+			case *authnv1.TokenReview:
+				if o.Spec.Token == "token" {
+					o.Status.Authenticated = true
+					o.Status.User = authnv1.UserInfo{
+						Username: "system:serviceaccount:default:portal",
+						Groups:   []string{"system:serviceaccounts"},
+						Extra: map[string]authnv1.ExtraValue{
+							"authentication.kubernetes.io/pod-name": {"portal-0"},
+						},
+					}
+				}
+			case *authzv1.SubjectAccessReview:
+				sars = append(sars, o)
+				o.Status.Allowed = o.Spec.ResourceAttributes.Namespace == "default"
+			default:
+				return errors.New("unexpected object")
+			}
+			return nil
+		},
+	})
+	a := NewKubeAuthorizer(c)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, a.Authorize(ctx, "invalid", "default", "alice"), ErrUnauthenticated)
+	assert.Empty(t, sars)
+
+	require.NoError(t, a.Authorize(ctx, "token", "default", "alice"))
+	require.Len(t, sars, 1)
+	spec := sars[0].Spec
+	assert.Equal(t, "system:serviceaccount:default:portal", spec.User)
+	assert.Equal(t, []string{"system:serviceaccounts"}, spec.Groups)
+	assert.Equal(t, authzv1.ExtraValue{"portal-0"}, spec.Extra["authentication.kubernetes.io/pod-name"])
+	assert.Equal(t, &authzv1.ResourceAttributes{
+		Namespace:   "default",
+		Verb:        "create",
+		Group:       "htnn.mosn.io",
+		Resource:    "consumers",
+		Subresource: "keys",
+		Name:        "alice",
+	}, spec.ResourceAttributes)
+
+	assert.ErrorIs(t, a.Authorize(ctx, "token", "other", "alice"), ErrForbidden)
+}
+
+func TestStartWithoutTLS(t *testing.T) {
+	err := Start(nil, ":0", "", "", make(chan struct{}))
+	assert.ErrorContains(t, err, "certificate and key are required")
+}
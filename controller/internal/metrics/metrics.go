@@ -27,6 +27,11 @@ const (
 	DC                      = "htnn_dynamic_config"
 	TranslateDurationSuffix = "translate_duration_seconds"
 	ReconcileDurationSuffix = "reconcile_duration_seconds"
+	TranslateErrorsSuffix   = "translate_errors_total"
+
+	LabelCreatedBy = "created_by"
+	LabelRegistry  = "registry"
+	LabelEvent     = "event"
 )
 
 type voidMetric struct {
@@ -34,12 +39,22 @@ type voidMetric struct {
 
 func (m *voidMetric) Record(value float64) {}
 
+func (m *voidMetric) Increment() {}
+
+func (m *voidMetric) With(label, value string) component.Metric { return m }
+
 var (
 	FPTranslateDurationDistribution              component.Distribution = &voidMetric{}
 	FPReconcileDurationDistribution              component.Distribution = &voidMetric{}
 	ConsumerReconcileDurationDistribution        component.Distribution = &voidMetric{}
 	ServiceRegistryReconcileDurationDistribution component.Distribution = &voidMetric{}
 	DynamicConfigReconcileDurationDistribution   component.Distribution = &voidMetric{}
+
+	FPTranslateErrors             component.Metric = &voidMetric{}
+	GeneratedEnvoyFilters         component.Metric = &voidMetric{}
+	GeneratedServiceEntries       component.Metric = &voidMetric{}
+	ServiceRegistryServiceUpdates component.Metric = &voidMetric{}
+	ServiceRegistryServices       component.Metric = &voidMetric{}
 )
 
 func InitMetrics(provider component.MetricProvider) {
@@ -69,4 +84,19 @@ func InitMetrics(provider component.MetricProvider) {
 		// minimal: 100 microseconds
 		[]float64{1e-4, 1e-3, 0.01, 0.1, 1, 10},
 	)
+	FPTranslateErrors = provider.NewSum(fmt.Sprintf("%s_%s", FP, TranslateErrorsSuffix),
+		"Number of failures when HTNN translates FilterPolicy.",
+	)
+	GeneratedEnvoyFilters = provider.NewGauge("htnn_generated_envoyfilters",
+		"Number of EnvoyFilters generated by HTNN, labeled with the kind of resource they are generated from.",
+	)
+	GeneratedServiceEntries = provider.NewGauge("htnn_generated_serviceentries",
+		"Number of ServiceEntries generated by HTNN from the service registries.",
+	)
+	ServiceRegistryServiceUpdates = provider.NewSum(fmt.Sprintf("%s_service_updates_total", SR),
+		"Number of service changes received from the service registries, labeled with the registry type and the event.",
+	)
+	ServiceRegistryServices = provider.NewGauge(fmt.Sprintf("%s_services", SR),
+		"Number of services synced from the service registries, labeled with the registry type.",
+	)
 }
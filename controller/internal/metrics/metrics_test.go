@@ -24,6 +24,8 @@ import (
 
 type metricProvider struct {
 	distributions int
+	sums          int
+	gauges        int
 }
 
 func (m *metricProvider) NewDistribution(name string, description string, buckets []float64) component.Distribution {
@@ -31,8 +33,20 @@ func (m *metricProvider) NewDistribution(name string, description string, bucket
 	return nil
 }
 
+func (m *metricProvider) NewSum(name string, description string) component.Metric {
+	m.sums++
+	return nil
+}
+
+func (m *metricProvider) NewGauge(name string, description string) component.Metric {
+	m.gauges++
+	return nil
+}
+
 func TestInitMetrics(t *testing.T) {
 	p := &metricProvider{}
 	InitMetrics(p)
 	assert.Equal(t, 5, p.distributions)
+	assert.Equal(t, 2, p.sums)
+	assert.Equal(t, 3, p.gauges)
 }
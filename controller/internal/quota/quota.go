@@ -0,0 +1,104 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"fmt"
+	"sync"
+
+	"mosn.io/htnn/controller/internal/config"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+// usage records the resources in each namespace. It's updated in each reconciliation, so the quota
+// is checked against the resources known by the last reconciliation. The resources created in
+// between may exceed the quota a little, which is fine as the quota is used to protect the control plane.
+type usage struct {
+	lock  sync.RWMutex
+	names map[string]map[string]struct{}
+}
+
+func (u *usage) set(names map[string]map[string]struct{}) {
+	u.lock.Lock()
+	u.names = names
+	u.lock.Unlock()
+}
+
+func (u *usage) check(desc string, namespace string, name string, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	u.lock.RLock()
+	defer u.lock.RUnlock()
+	names := u.names[namespace]
+	if _, ok := names[name]; ok {
+		// updating the existing resource is always allowed
+		return nil
+	}
+	if len(names) >= limit {
+		return fmt.Errorf("the number of %s in namespace %s reaches the quota %d", desc, namespace, limit)
+	}
+	return nil
+}
+
+func add(names map[string]map[string]struct{}, namespace string, name string) {
+	if names[namespace] == nil {
+		names[namespace] = make(map[string]struct{})
+	}
+	names[namespace][name] = struct{}{}
+}
+
+var (
+	policies  = &usage{}
+	consumers = &usage{}
+)
+
+// SetPolicies records the FilterPolicies and the HTTPFilterPolicies in the cluster
+func SetPolicies(list *mosniov1.FilterPolicyList) {
+	names := make(map[string]map[string]struct{})
+	for i := range list.Items {
+		policy := &list.Items[i]
+		kind := "FilterPolicy"
+		if policy.FromHTTPFilterPolicy() {
+			kind = "HTTPFilterPolicy"
+		}
+		add(names, policy.Namespace, kind+"/"+policy.Name)
+	}
+	policies.set(names)
+}
+
+// SetConsumers records the Consumers in the cluster
+func SetConsumers(list *mosniov1.ConsumerList) {
+	names := make(map[string]map[string]struct{})
+	for i := range list.Items {
+		consumer := &list.Items[i]
+		add(names, consumer.Namespace, consumer.Name)
+	}
+	consumers.set(names)
+}
+
+// Check returns an error if creating the resource of the given kind exceeds the quota of the namespace.
+// The FilterPolicies and the HTTPFilterPolicies share the same quota.
+func Check(kind string, namespace string, name string) error {
+	switch kind {
+	case "FilterPolicy", "HTTPFilterPolicy":
+		return policies.check("FilterPolicies and HTTPFilterPolicies", namespace, kind+"/"+name,
+			config.MaxPoliciesPerNamespace())
+	case "Consumer":
+		return consumers.check("Consumers", namespace, name, config.MaxConsumersPerNamespace())
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"mosn.io/htnn/controller/internal/config"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+func TestCheck(t *testing.T) {
+	// zero means unlimited
+	config.Init()
+	assert.NoError(t, Check("FilterPolicy", "ns", "policy"))
+	assert.NoError(t, Check("Consumer", "ns", "consumer"))
+
+	t.Cleanup(config.Init)
+	t.Setenv("HTNN_QUOTA_MAX_POLICIES_PER_NAMESPACE", "2")
+	t.Setenv("HTNN_QUOTA_MAX_CONSUMERS_PER_NAMESPACE", "1")
+	config.Init()
+
+	httpFilterPolicy := mosniov1.ConvertHTTPFilterPolicyToFilterPolicy(&mosniov1.HTTPFilterPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "http"},
+	})
+	SetPolicies(&mosniov1.FilterPolicyList{
+		Items: []mosniov1.FilterPolicy{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "policy"}},
+			httpFilterPolicy,
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "policy"}},
+		},
+	})
+	SetConsumers(&mosniov1.ConsumerList{
+		Items: []mosniov1.Consumer{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "consumer"}},
+		},
+	})
+
+	// updating the existing resources
+	assert.NoError(t, Check("FilterPolicy", "ns", "policy"))
+	assert.NoError(t, Check("HTTPFilterPolicy", "ns", "http"))
+	assert.NoError(t, Check("Consumer", "ns", "consumer"))
+
+	// FilterPolicy and HTTPFilterPolicy share the quota
+	assert.EqualError(t, Check("FilterPolicy", "ns", "http"),
+		"the number of FilterPolicies and HTTPFilterPolicies in namespace ns reaches the quota 2")
+	assert.EqualError(t, Check("HTTPFilterPolicy", "ns", "policy"),
+		"the number of FilterPolicies and HTTPFilterPolicies in namespace ns reaches the quota 2")
+	assert.EqualError(t, Check("Consumer", "ns", "another"),
+		"the number of Consumers in namespace ns reaches the quota 1")
+
+	// other namespaces are not affected
+	assert.NoError(t, Check("FilterPolicy", "other", "another"))
+	assert.NoError(t, Check("Consumer", "other", "another"))
+	// other kinds are not limited
+	assert.NoError(t, Check("ServiceRegistry", "ns", "another"))
+}
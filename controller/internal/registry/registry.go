@@ -15,6 +15,8 @@
 package registry
 
 import (
+	"bytes"
+
 	"k8s.io/apimachinery/pkg/types"
 
 	"mosn.io/htnn/controller/internal/log"
@@ -38,8 +40,10 @@ func InitRegistryManager(opt *RegistryManagerOption) {
 }
 
 func UpdateRegistry(registry *mosniov1.ServiceRegistry, prevServiceRegistry *mosniov1.ServiceRegistry) error {
-	if prevServiceRegistry != nil && prevServiceRegistry.Generation == registry.Generation {
-		// no change
+	if prevServiceRegistry != nil && prevServiceRegistry.Generation == registry.Generation &&
+		bytes.Equal(prevServiceRegistry.Spec.Config.Raw, registry.Spec.Config.Raw) {
+		// no change. Note that the resolved configuration can change without a new generation, when
+		// the referred Secret is changed.
 		return nil
 	}
 
@@ -22,6 +22,7 @@ import (
 	istioapi "istio.io/api/networking/v1alpha3"
 
 	"mosn.io/htnn/controller/internal/log"
+	"mosn.io/htnn/controller/internal/metrics"
 	"mosn.io/htnn/controller/pkg/component"
 	pkgRegistry "mosn.io/htnn/controller/pkg/registry"
 )
@@ -31,15 +32,27 @@ type serviceEntryStore struct {
 
 	lock    sync.RWMutex
 	entries map[string]*istioapi.ServiceEntry
+	// the type of registry where the service comes from
+	sources map[string]string
+	// the number of services from each type of registry
+	sourceCounts map[string]int
 }
 
 func newServiceEntryStore(output component.Output) *serviceEntryStore {
 	return &serviceEntryStore{
-		output:  output,
-		entries: make(map[string]*istioapi.ServiceEntry),
+		output:       output,
+		entries:      make(map[string]*istioapi.ServiceEntry),
+		sources:      make(map[string]string),
+		sourceCounts: make(map[string]int),
 	}
 }
 
+func (store *serviceEntryStore) recordMetrics(source string, event string) {
+	metrics.ServiceRegistryServiceUpdates.With(metrics.LabelRegistry, source).With(metrics.LabelEvent, event).Increment()
+	metrics.ServiceRegistryServices.With(metrics.LabelRegistry, source).Record(float64(store.sourceCounts[source]))
+	metrics.GeneratedServiceEntries.Record(float64(len(store.entries)))
+}
+
 // Implement ServiceEntryStore interface
 
 func (store *serviceEntryStore) Update(service string, se *pkgRegistry.ServiceEntryWrapper) {
@@ -59,8 +72,17 @@ func (store *serviceEntryStore) Update(service string, se *pkgRegistry.ServiceEn
 		}
 	}
 	store.entries[service] = &se.ServiceEntry
+	if prevSource, ok := store.sources[service]; !ok || prevSource != se.Source {
+		if ok {
+			store.sourceCounts[prevSource]--
+			metrics.ServiceRegistryServices.With(metrics.LabelRegistry, prevSource).Record(float64(store.sourceCounts[prevSource]))
+		}
+		store.sources[service] = se.Source
+		store.sourceCounts[se.Source]++
+	}
 
 	store.output.FromServiceRegistry(ctx, store.entries)
+	store.recordMetrics(se.Source, "update")
 }
 
 func (store *serviceEntryStore) Delete(service string) {
@@ -73,5 +95,10 @@ func (store *serviceEntryStore) Delete(service string) {
 
 	log.Infof("service entry store deletes service: %s", service)
 	delete(store.entries, service)
+	source := store.sources[service]
+	delete(store.sources, service)
+	store.sourceCounts[source]--
+
 	store.output.FromServiceRegistry(context.Background(), store.entries)
+	store.recordMetrics(source, "delete")
 }
@@ -52,3 +52,34 @@ func TestStoreRepeatedUpdate(t *testing.T) {
 
 	require.Equal(t, 1, counter)
 }
+
+func TestStoreCountServicesBySource(t *testing.T) {
+	client := pkg.FakeK8sClient(t)
+	out := component.NewK8sOutput(client)
+	patches := gomonkey.ApplyMethodFunc(out, "FromServiceRegistry", func(ctx interface{}, serviceEntries map[string]*istioapi.ServiceEntry) {
+	})
+	defer patches.Reset()
+
+	store := newServiceEntryStore(out)
+	newEntry := func(host string, source string) *pkgRegistry.ServiceEntryWrapper {
+		return &pkgRegistry.ServiceEntryWrapper{
+			ServiceEntry: istioapi.ServiceEntry{
+				Hosts: []string{host},
+			},
+			Source: source,
+		}
+	}
+	store.Update("a", newEntry("a.nacos", "nacos"))
+	store.Update("b", newEntry("b.nacos", "nacos"))
+	store.Update("c", newEntry("c.consul", "consul"))
+	require.Equal(t, map[string]int{"nacos": 2, "consul": 1}, store.sourceCounts)
+
+	// the service moves to another registry
+	store.Update("b", newEntry("b.consul", "consul"))
+	require.Equal(t, map[string]int{"nacos": 1, "consul": 2}, store.sourceCounts)
+
+	store.Delete("a")
+	store.Delete("unknown")
+	require.Equal(t, map[string]int{"nacos": 0, "consul": 2}, store.sourceCounts)
+	require.Equal(t, map[string]string{"b": "consul", "c": "consul"}, store.sources)
+}
@@ -0,0 +1,194 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"fmt"
+	"sort"
+
+	istioapi "istio.io/api/networking/v1alpha3"
+	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+
+	"mosn.io/htnn/controller/pkg/constant"
+)
+
+const routerFilter = "envoy.filters.http.router"
+
+// EnvoyConfig is the configuration for the standalone Envoy, converted from the generated EnvoyFilters.
+// It's only a fragment without the listeners, routes and clusters. The fields are named after Envoy's API,
+// so they can be merged into the Envoy configuration directly.
+type EnvoyConfig struct {
+	// HTTPFilters should be put before the router filter of the http_connection_manager, in order
+	HTTPFilters []map[string]interface{} `json:"http_filters,omitempty"`
+	// VirtualHosts contain the per-route configuration, which is matched by the name of the virtual
+	// host and the route
+	VirtualHosts []*EnvoyVirtualHost `json:"virtual_hosts,omitempty"`
+	// ExtensionConfigs are served via ECDS, for example, with a file-based config source
+	ExtensionConfigs []map[string]interface{} `json:"extension_configs,omitempty"`
+}
+
+type EnvoyVirtualHost struct {
+	Name   string        `json:"name"`
+	Routes []*EnvoyRoute `json:"routes"`
+}
+
+type EnvoyRoute struct {
+	Name                 string                 `json:"name"`
+	TypedPerFilterConfig map[string]interface{} `json:"typed_per_filter_config"`
+}
+
+type envoyConfigBuilder struct {
+	// the router filter is added as the anchor of the insertion, and removed after all patches are applied
+	httpFilters      []map[string]interface{}
+	virtualHosts     []*EnvoyVirtualHost
+	extensionConfigs []map[string]interface{}
+}
+
+func (b *envoyConfigBuilder) httpFilterIndex(name string) int {
+	for i, filter := range b.httpFilters {
+		if filter["name"] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (b *envoyConfigBuilder) insertHTTPFilter(idx int, filter map[string]interface{}) {
+	b.httpFilters = append(b.httpFilters, nil)
+	copy(b.httpFilters[idx+1:], b.httpFilters[idx:])
+	b.httpFilters[idx] = filter
+}
+
+func (b *envoyConfigBuilder) addHTTPFilter(cp *istioapi.EnvoyFilter_EnvoyConfigObjectPatch) error {
+	listener := cp.GetMatch().GetListener()
+	if listener.GetName() != "" {
+		return fmt.Errorf("the HTTP filter of the listener %s can't be converted", listener.GetName())
+	}
+
+	filter := cp.Patch.Value.AsMap()
+	anchor := listener.GetFilterChain().GetFilter().GetSubFilter().GetName()
+	switch cp.Patch.Operation {
+	case istioapi.EnvoyFilter_Patch_INSERT_FIRST:
+		b.insertHTTPFilter(0, filter)
+	case istioapi.EnvoyFilter_Patch_INSERT_BEFORE, istioapi.EnvoyFilter_Patch_INSERT_AFTER:
+		idx := b.httpFilterIndex(anchor)
+		if idx == -1 {
+			return fmt.Errorf("the HTTP filter %s to insert %v is not found", anchor, filter["name"])
+		}
+		if cp.Patch.Operation == istioapi.EnvoyFilter_Patch_INSERT_AFTER {
+			idx++
+		}
+		b.insertHTTPFilter(idx, filter)
+	default:
+		return fmt.Errorf("the operation %s of the HTTP filter can't be converted", cp.Patch.Operation)
+	}
+	return nil
+}
+
+func (b *envoyConfigBuilder) mergeRoute(cp *istioapi.EnvoyFilter_EnvoyConfigObjectPatch) error {
+	vhostMatch := cp.GetMatch().GetRouteConfiguration().GetVhost()
+	if cp.Patch.Operation != istioapi.EnvoyFilter_Patch_MERGE || vhostMatch.GetRoute().GetName() == "" {
+		return fmt.Errorf("only merging the route matched by name can be converted")
+	}
+
+	var vhost *EnvoyVirtualHost
+	for _, vh := range b.virtualHosts {
+		if vh.Name == vhostMatch.Name {
+			vhost = vh
+			break
+		}
+	}
+	if vhost == nil {
+		vhost = &EnvoyVirtualHost{Name: vhostMatch.Name}
+		b.virtualHosts = append(b.virtualHosts, vhost)
+	}
+
+	var route *EnvoyRoute
+	for _, r := range vhost.Routes {
+		if r.Name == vhostMatch.Route.Name {
+			route = r
+			break
+		}
+	}
+	if route == nil {
+		route = &EnvoyRoute{
+			Name:                 vhostMatch.Route.Name,
+			TypedPerFilterConfig: map[string]interface{}{},
+		}
+		vhost.Routes = append(vhost.Routes, route)
+	}
+
+	value := cp.Patch.Value.AsMap()
+	config, _ := value["typed_per_filter_config"].(map[string]interface{})
+	for name, c := range config {
+		route.TypedPerFilterConfig[name] = c
+	}
+	return nil
+}
+
+func (b *envoyConfigBuilder) add(cp *istioapi.EnvoyFilter_EnvoyConfigObjectPatch) error {
+	if cp.Patch == nil || cp.Patch.Value == nil {
+		return nil
+	}
+
+	switch cp.ApplyTo {
+	case istioapi.EnvoyFilter_HTTP_FILTER:
+		return b.addHTTPFilter(cp)
+	case istioapi.EnvoyFilter_HTTP_ROUTE:
+		return b.mergeRoute(cp)
+	case istioapi.EnvoyFilter_EXTENSION_CONFIG:
+		b.extensionConfigs = append(b.extensionConfigs, cp.Patch.Value.AsMap())
+		return nil
+	default:
+		return fmt.Errorf("the patch applied to %s can't be converted", cp.ApplyTo)
+	}
+}
+
+// ToEnvoyConfig converts the generated EnvoyFilters to the configuration for the standalone Envoy.
+// As the standalone Envoy doesn't have the listeners generated by Istio, the patches to a specific
+// listener are skipped and reported in the returned messages.
+func ToEnvoyConfig(efs []*istiov1a3.EnvoyFilter) (*EnvoyConfig, []string) {
+	// Istio applies the EnvoyFilters by priority and creation time. The EnvoyFilters generated from
+	// FilterPolicy are created once the controller starts, so they are applied first.
+	sorted := make([]*istiov1a3.EnvoyFilter, len(efs))
+	copy(sorted, efs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Spec.Priority != b.Spec.Priority {
+			return a.Spec.Priority < b.Spec.Priority
+		}
+		return a.Labels[constant.LabelCreatedBy] == "FilterPolicy" && b.Labels[constant.LabelCreatedBy] != "FilterPolicy"
+	})
+
+	b := &envoyConfigBuilder{
+		httpFilters: []map[string]interface{}{{"name": routerFilter}},
+	}
+	var skipped []string
+	for _, ef := range sorted {
+		for _, cp := range ef.Spec.ConfigPatches {
+			if err := b.add(cp); err != nil {
+				skipped = append(skipped, fmt.Sprintf("EnvoyFilter %s/%s: %s", ef.Namespace, ef.Name, err))
+			}
+		}
+	}
+
+	idx := b.httpFilterIndex(routerFilter)
+	httpFilters := append(b.httpFilters[:idx:idx], b.httpFilters[idx+1:]...)
+	return &EnvoyConfig{
+		HTTPFilters:      httpFilters,
+		VirtualHosts:     b.virtualHosts,
+		ExtensionConfigs: b.extensionConfigs,
+	}, skipped
+}
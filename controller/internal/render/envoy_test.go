@@ -0,0 +1,90 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	istioapi "istio.io/api/networking/v1alpha3"
+	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"mosn.io/htnn/controller/internal/istio"
+)
+
+func TestToEnvoyConfig(t *testing.T) {
+	f, err := os.Open("testdata/resources.yaml")
+	require.NoError(t, err)
+	defer f.Close()
+	objs, err := Decode(f, "default")
+	require.NoError(t, err)
+	res, err := Render(context.Background(), objs)
+	require.NoError(t, err)
+
+	listenerPatch := &istiov1a3.EnvoyFilter{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "htnn-lds",
+		},
+		Spec: istioapi.EnvoyFilter{
+			ConfigPatches: []*istioapi.EnvoyFilter_EnvoyConfigObjectPatch{
+				{
+					ApplyTo: istioapi.EnvoyFilter_LISTENER,
+					Patch: &istioapi.EnvoyFilter_Patch{
+						Operation: istioapi.EnvoyFilter_Patch_MERGE,
+						Value:     istio.MustNewStruct(map[string]interface{}{}),
+					},
+				},
+			},
+		},
+	}
+	conf, skipped := ToEnvoyConfig(append(res.EnvoyFilters, listenerPatch))
+	assert.Equal(t, []string{"EnvoyFilter default/htnn-lds: the patch applied to LISTENER can't be converted"}, skipped)
+
+	var names []string
+	for _, filter := range conf.HTTPFilters {
+		names = append(names, filter["name"].(string))
+	}
+	golang := -1
+	for i, name := range names {
+		switch name {
+		case "htnn.filters.http.golang":
+			golang = i
+		case "htnn.filters.http.outerLua":
+			// the outer native plugins run before the Go plugins
+			assert.Equal(t, -1, golang)
+		case "htnn.filters.http.innerLua":
+			assert.NotEqual(t, -1, golang)
+		}
+	}
+	assert.NotEqual(t, -1, golang)
+	assert.NotContains(t, names, routerFilter)
+	// the HTTP filter of the consumer is inserted after the default ones
+	assert.Equal(t, "htnn-consumer", names[len(names)-1])
+
+	require.Len(t, conf.ExtensionConfigs, 1)
+	assert.Equal(t, "htnn-consumer", conf.ExtensionConfigs[0]["name"])
+
+	require.Len(t, conf.VirtualHosts, 1)
+	vhost := conf.VirtualHosts[0]
+	assert.Equal(t, "default.local:80", vhost.Name)
+	require.Len(t, vhost.Routes, 1)
+	assert.Equal(t, "route", vhost.Routes[0].Name)
+	assert.Contains(t, vhost.Routes[0].TypedPerFilterConfig, "htnn.filters.http.golang")
+}
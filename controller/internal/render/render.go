@@ -0,0 +1,236 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package render runs the reconcilers against the resources read from files, like what the controller
+// does in a cluster, so that the generated resources can be reviewed without a cluster.
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	istioapi "istio.io/api/networking/v1alpha3"
+	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	istioscheme "istio.io/client-go/pkg/clientset/versioned/scheme"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"mosn.io/htnn/controller/internal/controller"
+	"mosn.io/htnn/controller/internal/gatewayapi"
+	"mosn.io/htnn/controller/pkg/component"
+	mosniov1 "mosn.io/htnn/types/apis/v1"
+)
+
+func newScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+	fs := []func(s *runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		istioscheme.AddToScheme,
+		gatewayapi.AddToScheme,
+		mosniov1.AddToScheme,
+	}
+	for _, f := range fs {
+		if err := f(scheme); err != nil {
+			return nil, err
+		}
+	}
+	return scheme, nil
+}
+
+// Decode reads the resources from the YAML or JSON documents. The namespace is used when the
+// resource doesn't specify one.
+func Decode(r io.Reader, namespace string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var raw json.RawMessage
+		err := decoder.Decode(&raw)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		raw = bytes.TrimSpace(raw)
+		if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+			// empty document
+			continue
+		}
+
+		u := &unstructured.Unstructured{}
+		// UnmarshalJSON keeps the integers as int64, which is required by the accessors like GetGeneration
+		if err := u.UnmarshalJSON(raw); err != nil {
+			return nil, err
+		}
+
+		if u.GetNamespace() == "" {
+			u.SetNamespace(namespace)
+		}
+		if u.GetGeneration() == 0 {
+			u.SetGeneration(1)
+		}
+		// The status dumped from the cluster is stale, so we start from scratch
+		delete(u.Object, "status")
+		objs = append(objs, u)
+	}
+	return objs, nil
+}
+
+// Result is the result of rendering
+type Result struct {
+	// EnvoyFilters are the generated EnvoyFilters, sorted by namespace and name
+	EnvoyFilters []*istiov1a3.EnvoyFilter
+	// Rejected describes the resources which are not accepted
+	Rejected []string
+	// Ignored describes the resources which are skipped by the rendering
+	Ignored []string
+}
+
+// Render generates the resources like the controller does in a cluster.
+func Render(ctx context.Context, objs []*unstructured.Unstructured) (*Result, error) {
+	scheme, err := newScheme()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{}
+	rm := newResourceManager(scheme)
+	hasConsumer := false
+	hasDynamicConfig := false
+	for _, obj := range objs {
+		gk := obj.GroupVersionKind().GroupKind()
+		if gk.Group == mosniov1.GroupVersion.Group {
+			switch gk.Kind {
+			case "Consumer":
+				hasConsumer = true
+			case "DynamicConfig":
+				hasDynamicConfig = true
+			case "PluginConfigPolicy":
+				// PluginConfigPolicy is cluster-scoped
+				obj.SetNamespace("")
+			case "ServiceRegistry":
+				// Talking to the registry is not what we want in the rendering
+				res.Ignored = append(res.Ignored, fmt.Sprintf("ServiceRegistry %s/%s: the service entries from the registry can't be rendered",
+					obj.GetNamespace(), obj.GetName()))
+				continue
+			}
+		}
+		rm.add(obj)
+	}
+
+	output := newOutput()
+	reconcilers := []interface {
+		Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error)
+	}{
+		controller.NewFilterPolicyReconciler(output, rm),
+	}
+	// Like the controller, don't generate the EnvoyFilter if there is no such resource
+	if hasConsumer {
+		reconcilers = append(reconcilers, &controller.ConsumerReconciler{
+			ResourceManager: rm,
+			Output:          output,
+		})
+	}
+	if hasDynamicConfig {
+		reconcilers = append(reconcilers, &controller.DynamicConfigReconciler{
+			ResourceManager: rm,
+			Output:          output,
+		})
+	}
+	for _, r := range reconcilers {
+		if _, err := r.Reconcile(ctx, ctrl.Request{}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, ef := range output.envoyFilters {
+		ef.SetGroupVersionKind(istiov1a3.SchemeGroupVersion.WithKind("EnvoyFilter"))
+		res.EnvoyFilters = append(res.EnvoyFilters, ef)
+	}
+	sort.Slice(res.EnvoyFilters, func(i, j int) bool {
+		a, b := res.EnvoyFilters[i], res.EnvoyFilters[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	res.Rejected = rm.rejected()
+	return res, nil
+}
+
+type output struct {
+	envoyFilters map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter
+}
+
+func newOutput() *output {
+	return &output{
+		envoyFilters: make(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter),
+	}
+}
+
+func (o *output) add(efs map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter) {
+	for key, ef := range efs {
+		o.envoyFilters[key] = ef.DeepCopy()
+	}
+}
+
+func (o *output) FromFilterPolicy(_ context.Context, efs map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter) error {
+	o.add(efs)
+	return nil
+}
+
+func (o *output) FromConsumer(_ context.Context, ef *istiov1a3.EnvoyFilter) error {
+	o.add(map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter{
+		{Namespace: ef.Namespace, Name: ef.Name}: ef,
+	})
+	return nil
+}
+
+func (o *output) FromServiceRegistry(_ context.Context, _ map[string]*istioapi.ServiceEntry) {}
+
+func (o *output) FromDynamicConfig(_ context.Context, efs map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter) error {
+	o.add(efs)
+	return nil
+}
+
+// acceptedCondition returns the Accepted condition of the HTNN resources
+func acceptedCondition(obj runtime.Object) *metav1.Condition {
+	var conds []metav1.Condition
+	switch o := obj.(type) {
+	case *mosniov1.FilterPolicy:
+		conds = o.Status.Conditions
+	case *mosniov1.HTTPFilterPolicy:
+		conds = o.Status.Conditions
+	case *mosniov1.Consumer:
+		conds = o.Status.Conditions
+	case *mosniov1.DynamicConfig:
+		conds = o.Status.Conditions
+	case *mosniov1.PluginConfigPolicy:
+		conds = o.Status.Conditions
+	default:
+		return nil
+	}
+	return apimeta.FindStatusCondition(conds, string(mosniov1.ConditionAccepted))
+}
@@ -0,0 +1,169 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/controller/internal/config"
+	_ "mosn.io/htnn/controller/plugins"    // register plugins
+	_ "mosn.io/htnn/controller/registries" // register registries
+)
+
+func TestDecode(t *testing.T) {
+	objs, err := Decode(strings.NewReader(`
+---
+apiVersion: htnn.mosn.io/v1
+kind: Consumer
+metadata:
+  name: alice
+  namespace: ns
+status:
+  conditions: []
+---
+{"apiVersion": "htnn.mosn.io/v1", "kind": "Consumer", "metadata": {"name": "bob", "generation": 2}}
+`), "default")
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+	assert.Equal(t, "ns", objs[0].GetNamespace())
+	assert.Equal(t, int64(1), objs[0].GetGeneration())
+	assert.NotContains(t, objs[0].Object, "status")
+	assert.Equal(t, "default", objs[1].GetNamespace())
+	assert.Equal(t, int64(2), objs[1].GetGeneration())
+
+	_, err = Decode(strings.NewReader(`metadata: {name: alice}`), "default")
+	assert.ErrorContains(t, err, "Object 'Kind' is missing")
+}
+
+func TestRender(t *testing.T) {
+	f, err := os.Open("testdata/resources.yaml")
+	require.NoError(t, err)
+	defer f.Close()
+	objs, err := Decode(f, "default")
+	require.NoError(t, err)
+
+	res, err := Render(context.Background(), objs)
+	require.NoError(t, err)
+
+	var names []string
+	for _, ef := range res.EnvoyFilters {
+		assert.Equal(t, "EnvoyFilter", ef.Kind)
+		names = append(names, ef.Namespace+"/"+ef.Name)
+	}
+	assert.Equal(t, []string{
+		"default/htnn-h-default.local",
+		"istio-system/htnn-consumer",
+		"istio-system/htnn-http-filter",
+	}, names)
+	assert.Contains(t, res.EnvoyFilters[0].Annotations["htnn.mosn.io/info"], "default/policy")
+
+	assert.Equal(t, []string{
+		"FilterPolicy default/invalid: Invalid: spec.filters.limitReq.config.average: value must be greater than 0",
+		"FilterPolicy default/not-found: TargetNotFound: The policy targets non-existent resource",
+	}, res.Rejected)
+	assert.Equal(t, []string{
+		"ServiceRegistry default/nacos: the service entries from the registry can't be rendered",
+	}, res.Ignored)
+}
+
+func TestRenderWithShard(t *testing.T) {
+	// registered before Setenv, so it runs after the environment variable is restored
+	t.Cleanup(config.Init)
+	t.Setenv("HTNN_SHARD_NAMESPACES", "other")
+	config.Init()
+
+	f, err := os.Open("testdata/resources.yaml")
+	require.NoError(t, err)
+	defer f.Close()
+	objs, err := Decode(f, "default")
+	require.NoError(t, err)
+
+	res, err := Render(context.Background(), objs)
+	require.NoError(t, err)
+
+	var names []string
+	for _, ef := range res.EnvoyFilters {
+		names = append(names, ef.Namespace+"/"+ef.Name)
+	}
+	// the policies in the namespace out of the shard are skipped
+	assert.Equal(t, []string{
+		"istio-system/htnn-consumer",
+		"istio-system/htnn-http-filter",
+	}, names)
+	assert.Empty(t, res.Rejected)
+
+	t.Setenv("HTNN_SHARD_NAMESPACES", "default")
+	config.Init()
+	res, err = Render(context.Background(), objs)
+	require.NoError(t, err)
+	// the EnvoyFilter of the routes is split by the namespace of the routes
+	ef := res.EnvoyFilters[0]
+	assert.Equal(t, "default/htnn-h-default.local-default", ef.Namespace+"/"+ef.Name)
+	assert.Equal(t, "default", ef.Labels["htnn.mosn.io/source-namespace"])
+}
+
+func TestRenderWithPluginConfigPolicy(t *testing.T) {
+	f, err := os.Open("testdata/resources.yaml")
+	require.NoError(t, err)
+	defer f.Close()
+	objs, err := Decode(f, "default")
+	require.NoError(t, err)
+	extra, err := Decode(strings.NewReader(`
+apiVersion: htnn.mosn.io/v1
+kind: PluginConfigPolicy
+metadata:
+  name: team
+spec:
+  namespaces:
+  - default
+  plugins:
+  - name: demo
+---
+apiVersion: htnn.mosn.io/v1
+kind: PluginConfigPolicy
+metadata:
+  name: invalid
+spec:
+  plugins:
+  - name: unknown
+`), "default")
+	require.NoError(t, err)
+	objs = append(objs, extra...)
+
+	res, err := Render(context.Background(), objs)
+	require.NoError(t, err)
+
+	var names []string
+	for _, ef := range res.EnvoyFilters {
+		names = append(names, ef.Namespace+"/"+ef.Name)
+	}
+	assert.Equal(t, []string{
+		"istio-system/htnn-consumer",
+		"istio-system/htnn-http-filter",
+	}, names)
+	assert.Equal(t, []string{
+		"Consumer default/alice: Forbidden: spec.auth.keyAuth: plugin keyAuth is not allowed by PluginConfigPolicy team",
+		"FilterPolicy default/invalid: Invalid: spec.filters.limitReq.config.average: value must be greater than 0",
+		"FilterPolicy default/not-found: TargetNotFound: The policy targets non-existent resource",
+		"FilterPolicy default/policy: Forbidden: spec.filters.limitReq: plugin limitReq is not allowed by PluginConfigPolicy team",
+		"PluginConfigPolicy invalid: Invalid: spec.plugins[0].name: unknown http filter: unknown",
+	}, res.Rejected)
+}
@@ -0,0 +1,151 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// resourceManager serves the resources in memory. The resources are stored by group & kind, so the
+// reconciler can read them in a version different from the one in the files, like the HTTPRoute
+// in gateway.networking.k8s.io/v1 is read as v1beta1.
+type resourceManager struct {
+	scheme    *runtime.Scheme
+	resources map[schema.GroupKind]map[types.NamespacedName]*unstructured.Unstructured
+	// the objects whose status is updated, indexed by "$kind $namespace/$name", or "$kind $name"
+	// for the cluster-scoped ones
+	updated map[string]client.Object
+}
+
+func newResourceManager(scheme *runtime.Scheme) *resourceManager {
+	return &resourceManager{
+		scheme:    scheme,
+		resources: make(map[schema.GroupKind]map[types.NamespacedName]*unstructured.Unstructured),
+		updated:   make(map[string]client.Object),
+	}
+}
+
+func (r *resourceManager) add(obj *unstructured.Unstructured) {
+	gk := obj.GroupVersionKind().GroupKind()
+	if r.resources[gk] == nil {
+		r.resources[gk] = make(map[types.NamespacedName]*unstructured.Unstructured)
+	}
+	r.resources[gk][types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}] = obj
+}
+
+func (r *resourceManager) groupVersionKind(obj runtime.Object) (schema.GroupVersionKind, error) {
+	gvks, _, err := r.scheme.ObjectKinds(obj)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	return gvks[0], nil
+}
+
+func convert(u *unstructured.Unstructured, gvk schema.GroupVersionKind, out runtime.Object) error {
+	// Use JSON instead of the unstructured converter, as the Istio resources are defined with protobuf
+	data, err := json.Marshal(u.Object)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode %s %s/%s: %w", gvk.Kind, u.GetNamespace(), u.GetName(), err)
+	}
+	out.GetObjectKind().SetGroupVersionKind(gvk)
+	return nil
+}
+
+func (r *resourceManager) Get(_ context.Context, key client.ObjectKey, out client.Object) error {
+	gvk, err := r.groupVersionKind(out)
+	if err != nil {
+		return err
+	}
+
+	u, ok := r.resources[gvk.GroupKind()][key]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, key.Name)
+	}
+	return convert(u, gvk, out)
+}
+
+func (r *resourceManager) List(_ context.Context, list client.ObjectList) error {
+	gvk, err := r.groupVersionKind(list)
+	if err != nil {
+		return err
+	}
+
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	resources := r.resources[gvk.GroupKind()]
+	keys := make([]types.NamespacedName, 0, len(resources))
+	for key := range resources {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	items := make([]runtime.Object, 0, len(keys))
+	for _, key := range keys {
+		obj, err := r.scheme.New(gvk)
+		if err != nil {
+			return err
+		}
+		if err := convert(resources[key], gvk, obj); err != nil {
+			return err
+		}
+		items = append(items, obj)
+	}
+	return apimeta.SetList(list, items)
+}
+
+func (r *resourceManager) UpdateStatus(_ context.Context, obj client.Object, _ any) error {
+	gvk, err := r.groupVersionKind(obj)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%s %s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName())
+	if obj.GetNamespace() == "" {
+		// cluster-scoped resource
+		key = fmt.Sprintf("%s %s", gvk.Kind, obj.GetName())
+	}
+	r.updated[key] = obj
+	return nil
+}
+
+// rejected returns the resources which are not accepted, with the reason
+func (r *resourceManager) rejected() []string {
+	var res []string
+	for key, obj := range r.updated {
+		cond := acceptedCondition(obj)
+		if cond == nil || cond.Status == metav1.ConditionTrue {
+			continue
+		}
+		res = append(res, fmt.Sprintf("%s: %s: %s", key, cond.Reason, cond.Message))
+	}
+	sort.Strings(res)
+	return res
+}
@@ -18,12 +18,15 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
 	"golang.org/x/net/idna"
 	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"k8s.io/apimachinery/pkg/types"
 
+	"mosn.io/htnn/controller/internal/config"
 	"mosn.io/htnn/controller/internal/istio"
 	"mosn.io/htnn/controller/internal/model"
 	"mosn.io/htnn/controller/pkg/component"
@@ -75,20 +78,49 @@ func envoyFilterNameFromVirtualHost(vhost *model.VirtualHost) string {
 	return fmt.Sprintf("%s-%s.%s", prefix, vhost.NsName.Namespace, vhost.NsName.Name)
 }
 
+// virtualHostSourceNamespace returns the namespace of the route which generates the VirtualHost
+func virtualHostSourceNamespace(vhost *model.VirtualHost) string {
+	if vhost.NsName != nil {
+		return vhost.NsName.Namespace
+	}
+	if vhost.GatewaySection != nil {
+		return vhost.GatewaySection.NsName.Namespace
+	}
+	return ""
+}
+
 func envoyFilterNameFromLds(ldsName string) string {
 	ldsName = strings.ReplaceAll(ldsName, "_", "-")
 	ldsName = strings.ReplaceAll(ldsName, ":", "-")
 	return fmt.Sprintf("htnn-lds-%s", ldsName)
 }
 
+// shardedEnvoyFilterName appends the namespace of the source resources to the EnvoyFilter's name when
+// sharding is enabled. The resources of the same EnvoyFilter may come from different shards. Split them
+// so that the controllers won't overwrite each other's EnvoyFilter.
+func shardedEnvoyFilterName(name string, sourceNs string) string {
+	if !config.ShardEnabled() || sourceNs == "" {
+		return name
+	}
+	return fmt.Sprintf("%s-%s", name, sourceNs)
+}
+
 // finalState is the end of the translation. We convert the state to EnvoyFilter and write it to k8s.
 type FinalState struct {
 	EnvoyFilters map[component.EnvoyFilterKey]*istiov1a3.EnvoyFilter
+	// OverriddenPlugins maps the policy to its plugins which don't take effect anywhere, and the plugins
+	// are mapped to the policies whose configuration is used instead. The policies are named as `$namespace/$name`.
+	OverriddenPlugins map[string]map[string][]string
+	// Targets maps the gateways and the routes, named as `$namespace/$name`, to the EnvoyFilters generated
+	// for them. The EnvoyFilters of a gateway include the ones generated for the routes attached to it.
+	Targets map[string][]component.EnvoyFilterKey
 }
 
 type envoyFilterWrapper struct {
 	*istiov1a3.EnvoyFilter
 	info *Info
+	// sourceNamespace is the namespace of the resources which generate the EnvoyFilter
+	sourceNamespace string
 }
 
 func toFinalState(_ *Ctx, state *mergedState) (*FinalState, error) {
@@ -97,6 +129,14 @@ func toFinalState(_ *Ctx, state *mergedState) (*FinalState, error) {
 		ef.Spec.Priority = DefaultEnvoyFilterPriority
 	}
 	efList := []*envoyFilterWrapper{}
+	targets := map[string]map[component.EnvoyFilterKey]struct{}{}
+	addTarget := func(nsName *types.NamespacedName, ns string, name string) {
+		target := nsName.String()
+		if targets[target] == nil {
+			targets[target] = map[component.EnvoyFilterKey]struct{}{}
+		}
+		targets[target][component.EnvoyFilterKey{Namespace: ns, Name: name}] = struct{}{}
+	}
 
 	for proxy, cfg := range state.Proxies {
 		hostRules := cfg.Hosts
@@ -110,13 +150,21 @@ func toFinalState(_ *Ctx, state *mergedState) (*FinalState, error) {
 				// that the namespace of workload matches the namespace of gateway.
 				ns := proxy.Namespace
 				ef.SetNamespace(ns)
-				name := envoyFilterNameFromVirtualHost(host.VirtualHost)
+				sourceNs := virtualHostSourceNamespace(host.VirtualHost)
+				name := shardedEnvoyFilterName(envoyFilterNameFromVirtualHost(host.VirtualHost), sourceNs)
 				ef.SetName(name)
 
 				efList = append(efList, &envoyFilterWrapper{
-					EnvoyFilter: ef,
-					info:        route.Info,
+					EnvoyFilter:     ef,
+					info:            route.Info,
+					sourceNamespace: sourceNs,
 				})
+				if host.VirtualHost.NsName != nil {
+					addTarget(host.VirtualHost.NsName, ns, name)
+				}
+				if host.VirtualHost.GatewaySection != nil {
+					addTarget(&host.VirtualHost.GatewaySection.NsName, ns, name)
+				}
 			}
 		}
 
@@ -133,15 +181,23 @@ func toFinalState(_ *Ctx, state *mergedState) (*FinalState, error) {
 
 			ef := istio.GenerateLDSFilter(key, name, gateway.Gateway.HasHCM, config)
 			ef.SetNamespace(ns)
+			var sourceNs string
+			if gateway.Gateway.GatewaySection != nil {
+				sourceNs = gateway.Gateway.GatewaySection.NsName.Namespace
+			}
 			// Put all LDS level filters of the same LDS into the same EnvoyFilter.
-			efName := envoyFilterNameFromLds(name)
+			efName := shardedEnvoyFilterName(envoyFilterNameFromLds(name), sourceNs)
 			// Each LDS has it own EnvoyFilter, so it's easy to figure out how many filters are inserted into one LDS and their order.
 			ef.SetName(efName)
 
 			efList = append(efList, &envoyFilterWrapper{
-				EnvoyFilter: ef,
-				info:        info,
+				EnvoyFilter:     ef,
+				info:            info,
+				sourceNamespace: sourceNs,
 			})
+			if gateway.Gateway.GatewaySection != nil {
+				addTarget(&gateway.Gateway.GatewaySection.NsName, ns, efName)
+			}
 		}
 	}
 
@@ -176,7 +232,12 @@ func toFinalState(_ *Ctx, state *mergedState) (*FinalState, error) {
 		if ef.Labels == nil {
 			ef.Labels = map[string]string{}
 		}
-		ef.Labels[constant.LabelCreatedBy] = "FilterPolicy"
+		for k, v := range istio.GeneratedLabels("FilterPolicy") {
+			ef.Labels[k] = v
+		}
+		if config.ShardEnabled() && ef.sourceNamespace != "" {
+			ef.Labels[constant.LabelSourceNamespace] = ef.sourceNamespace
+		}
 
 		if strings.HasPrefix(ef.Name, "htnn-h-") {
 			// Sort here to avoid EnvoyFilter change caused by the order of ConfigPatch.
@@ -197,6 +258,49 @@ func toFinalState(_ *Ctx, state *mergedState) (*FinalState, error) {
 	}
 
 	return &FinalState{
-		EnvoyFilters: efs,
+		EnvoyFilters:      efs,
+		OverriddenPlugins: toOverriddenPlugins(state.PluginUsages),
+		Targets:           toTargets(targets),
 	}, nil
 }
+
+func toTargets(targets map[string]map[component.EnvoyFilterKey]struct{}) map[string][]component.EnvoyFilterKey {
+	res := make(map[string][]component.EnvoyFilterKey, len(targets))
+	for target, keys := range targets {
+		list := make([]component.EnvoyFilterKey, 0, len(keys))
+		for key := range keys {
+			list = append(list, key)
+		}
+		slices.SortFunc(list, func(a, b component.EnvoyFilterKey) int {
+			if a.Namespace != b.Namespace {
+				return strings.Compare(a.Namespace, b.Namespace)
+			}
+			return strings.Compare(a.Name, b.Name)
+		})
+		res[target] = list
+	}
+	return res
+}
+
+func toOverriddenPlugins(usages map[string]map[string]*pluginUsage) map[string]map[string][]string {
+	overridden := make(map[string]map[string][]string)
+	for policy, pluginUsages := range usages {
+		for name, usage := range pluginUsages {
+			if usage.effective {
+				continue
+			}
+
+			winners := make([]string, 0, len(usage.overriddenBy))
+			for winner := range usage.overriddenBy {
+				winners = append(winners, winner)
+			}
+			slices.Sort(winners)
+
+			if overridden[policy] == nil {
+				overridden[policy] = make(map[string][]string)
+			}
+			overridden[policy][name] = winners
+		}
+	}
+	return overridden
+}
@@ -18,6 +18,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/model"
+	"mosn.io/htnn/controller/pkg/component"
+	"mosn.io/htnn/controller/pkg/constant"
 )
 
 func TestEnvoyFilterNameFromLds(t *testing.T) {
@@ -32,3 +39,51 @@ func TestEnvoyFilterNameFromLds(t *testing.T) {
 		assert.True(t, validEnvoyFilterName.MatchString(out))
 	}
 }
+
+func TestToFinalStateWithShard(t *testing.T) {
+	// registered before Setenv, so it runs after the environment variable is restored
+	t.Cleanup(config.Init)
+	t.Setenv("HTNN_SHARD_NAMESPACES", "default,other")
+	config.Init()
+
+	gw := &model.GatewaySection{NsName: types.NamespacedName{Namespace: "default", Name: "gw"}}
+	state := &mergedState{
+		Proxies: map[Proxy]*mergedProxyConfig{
+			{Namespace: "default"}: {
+				Hosts: map[string]*mergedHostPolicy{
+					"example.com:80": {
+						VirtualHost: &model.VirtualHost{
+							GatewaySection: gw,
+							NsName:         &types.NamespacedName{Namespace: "other", Name: "route"},
+							Name:           "example.com:80",
+						},
+						Routes: map[string]*mergedPolicy{
+							"route": {Config: map[string]interface{}{}},
+						},
+					},
+				},
+				Gateways: map[string]*mergedGatewayPolicy{
+					"0.0.0.0_80": {
+						Gateway: &model.Gateway{GatewaySection: gw, HasHCM: true},
+					},
+				},
+			},
+		},
+	}
+	fs, err := toFinalState(nil, state)
+	require.NoError(t, err)
+
+	// every EnvoyFilter generated from the FilterPolicies is named after its source namespace
+	for key, sourceNs := range map[component.EnvoyFilterKey]string{
+		{Namespace: "default", Name: "htnn-h-example.com-other"}:    "other",
+		{Namespace: "default", Name: "htnn-lds-0.0.0.0-80-default"}: "default",
+	} {
+		ef, ok := fs.EnvoyFilters[key]
+		require.True(t, ok, key)
+		assert.Equal(t, sourceNs, ef.Labels[constant.LabelSourceNamespace])
+	}
+	assert.Equal(t, []component.EnvoyFilterKey{
+		{Namespace: "default", Name: "htnn-h-example.com-other"},
+		{Namespace: "default", Name: "htnn-lds-0.0.0.0-80-default"},
+	}, fs.Targets["default/gw"])
+}
@@ -17,7 +17,9 @@ package translation
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 
 	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	"k8s.io/apimachinery/pkg/types"
@@ -91,7 +93,7 @@ func (s *InitState) GetGatewaysWithVirtualService(vs *istiov1a3.VirtualService)
 	return vsp.Gateways
 }
 
-func (s *InitState) AddPolicyForVirtualService(policy *mosniov1.FilterPolicy, vs *istiov1a3.VirtualService, gws []*istiov1a3.Gateway) {
+func (s *InitState) getOrCreateVirtualServicePolicies(vs *istiov1a3.VirtualService, gws []*istiov1a3.Gateway) *VirtualServicePolicies {
 	nn := types.NamespacedName{
 		Namespace: vs.Namespace,
 		Name:      vs.Name,
@@ -106,6 +108,11 @@ func (s *InitState) AddPolicyForVirtualService(policy *mosniov1.FilterPolicy, vs
 		}
 		s.VirtualServicePolicies[nn] = vsp
 	}
+	return vsp
+}
+
+func (s *InitState) AddPolicyForVirtualService(policy *mosniov1.FilterPolicy, vs *istiov1a3.VirtualService, gws []*istiov1a3.Gateway) {
+	vsp := s.getOrCreateVirtualServicePolicies(vs, gws)
 
 	targetRef := policy.Spec.TargetRef
 	if targetRef == nil || targetRef.SectionName == nil {
@@ -124,7 +131,9 @@ func (s *InitState) AddPolicyForVirtualService(policy *mosniov1.FilterPolicy, vs
 				p := &mosniov1.FilterPolicy{}
 				*p = *policy
 				p.Spec = mosniov1.FilterPolicySpec{
-					Filters: subPolicy.Filters,
+					Filters:       subPolicy.Filters,
+					MergeStrategy: policy.Spec.MergeStrategy,
+					Priority:      policy.Spec.Priority,
 				}
 				subPolicies[string(subPolicy.SectionName)] = p
 			}
@@ -149,6 +158,62 @@ func (s *InitState) AddPolicyForVirtualService(policy *mosniov1.FilterPolicy, vs
 	}
 }
 
+// AddUpstreamPolicyForVirtualService adds the policy targeting an upstream to the given routes of the VirtualService,
+// which are the routes going to the upstream.
+func (s *InitState) AddUpstreamPolicyForVirtualService(policy *mosniov1.FilterPolicy, vs *istiov1a3.VirtualService,
+	gws []*istiov1a3.Gateway, routeNames []string) {
+
+	vsp := s.getOrCreateVirtualServicePolicies(vs, gws)
+	for _, routeName := range routeNames {
+		vsp.RoutePolicies[routeName] = append(vsp.RoutePolicies[routeName], &FilterPolicyWrapper{
+			FilterPolicy: policy,
+			scope:        PolicyScopeUpstream,
+		})
+	}
+}
+
+// RoutesToUpstream returns the names of the routes in the VirtualService which go to one of the upstream hosts.
+// The short names in the hosts are resolved against the given namespace, while the short names in the
+// route destinations are resolved against the VirtualService's namespace, like what istio does.
+func RoutesToUpstream(vs *istiov1a3.VirtualService, namespace string, hosts []string) []string {
+	upstreamHosts := make([]string, len(hosts))
+	for i, host := range hosts {
+		upstreamHosts[i] = fullyQualifiedHost(host, namespace)
+	}
+
+	var routeNames []string
+	for _, httpRoute := range vs.Spec.Http {
+		for _, dest := range httpRoute.Route {
+			if dest.Destination == nil {
+				continue
+			}
+
+			host := fullyQualifiedHost(dest.Destination.Host, vs.Namespace)
+			if slices.ContainsFunc(upstreamHosts, func(upstreamHost string) bool {
+				return matchHost(upstreamHost, host)
+			}) {
+				routeNames = append(routeNames, httpRoute.Name)
+				break
+			}
+		}
+	}
+	return routeNames
+}
+
+func fullyQualifiedHost(host string, namespace string) string {
+	if strings.Contains(host, ".") {
+		return host
+	}
+	return fmt.Sprintf("%s.%s.svc.cluster.local", host, namespace)
+}
+
+func matchHost(pattern string, host string) bool {
+	if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+		return strings.HasSuffix(host, suffix)
+	}
+	return pattern == host
+}
+
 func (s *InitState) GetGatewaysWithHTTPRoute(route *gwapiv1b1.HTTPRoute) []*gwapiv1b1.Gateway {
 	nn := types.NamespacedName{
 		Namespace: route.Namespace,
@@ -179,15 +244,51 @@ func (s *InitState) AddPolicyForHTTPRoute(policy *mosniov1.FilterPolicy, route *
 		s.HTTPRoutePolicies[nn] = hp
 	}
 
-	for i := range route.Spec.Rules {
-		name := fmt.Sprintf("%s.%s.%d", route.Namespace, route.Name, i)
+	targetRef := policy.Spec.TargetRef
+	if targetRef == nil || targetRef.SectionName == nil {
+		subPolicies := make(map[int]*mosniov1.FilterPolicy, len(policy.Spec.SubPolicies))
+		for _, subPolicy := range policy.Spec.SubPolicies {
+			// the section name is validated in the validation of FilterPolicy
+			idx, _ := mosniov1.HTTPRouteRuleIndex(string(subPolicy.SectionName))
+			p := &mosniov1.FilterPolicy{}
+			*p = *policy
+			p.Spec = mosniov1.FilterPolicySpec{
+				Filters:       subPolicy.Filters,
+				MergeStrategy: policy.Spec.MergeStrategy,
+				Priority:      policy.Spec.Priority,
+			}
+			subPolicies[idx] = p
+		}
+
+		for i := range route.Spec.Rules {
+			name := httpRouteRuleName(route, i)
+			hp.RoutePolicies[name] = append(hp.RoutePolicies[name], &FilterPolicyWrapper{
+				FilterPolicy: policy,
+				scope:        PolicyScopeRoute,
+			})
+			if subPolicy, ok := subPolicies[i]; ok {
+				hp.RoutePolicies[name] = append(hp.RoutePolicies[name], &FilterPolicyWrapper{
+					FilterPolicy: subPolicy,
+					scope:        PolicyScopeRule,
+				})
+			}
+		}
+
+	} else {
+		idx, _ := mosniov1.HTTPRouteRuleIndex(string(*targetRef.SectionName))
+		name := httpRouteRuleName(route, idx)
 		hp.RoutePolicies[name] = append(hp.RoutePolicies[name], &FilterPolicyWrapper{
 			FilterPolicy: policy,
-			scope:        PolicyScopeRoute,
+			scope:        PolicyScopeRule,
 		})
 	}
 }
 
+// httpRouteRuleName returns the name of the route generated by istio from the rule of HTTPRoute
+func httpRouteRuleName(route *gwapiv1b1.HTTPRoute, idx int) string {
+	return fmt.Sprintf("%s.%s.%d", route.Namespace, route.Name, idx)
+}
+
 func (s *InitState) AddIstioGateway(gw *istiov1a3.Gateway) {
 	s.AddPolicyForIstioGateway(nil, gw)
 }
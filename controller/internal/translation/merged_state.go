@@ -37,6 +37,16 @@ import (
 // 3. transform a plugin to different plugins if needed
 type mergedState struct {
 	Proxies map[Proxy]*mergedProxyConfig
+
+	// PluginUsages records how the plugins are used, indexed by the policy and then the plugin name
+	PluginUsages map[string]map[string]*pluginUsage
+}
+
+// pluginUsage records whether the plugin from a policy takes effect in the merged policies.
+// If not, it records the policies whose configuration is used instead.
+type pluginUsage struct {
+	effective    bool
+	overriddenBy map[string]struct{}
 }
 
 type mergedProxyConfig struct {
@@ -67,14 +77,18 @@ func toNsName(policy *FilterPolicyWrapper) string {
 // Highest priority policy will be first.
 // According to the https://gateway-api.sigs.k8s.io/geps/gep-713/,
 // 1. A Policy targeting a more specific scope wins over a policy targeting a lesser specific scope.
-// 2. If multiple polices configure the same plugin, the oldest one (based on creation timestamp) wins.
-// 3. If there are multiple oldest polices, the one appearing first in alphabetical order by {namespace}/{name} wins.
+// 2. If multiple polices configure the same plugin, the one with higher priority wins.
+// 3. If the priorities are equal, the oldest one (based on creation timestamp) wins.
+// 4. If there are multiple oldest polices, the one appearing first in alphabetical order by {namespace}/{name} wins.
 func sortFilterPolicy(policies []*FilterPolicyWrapper) {
 	// use Slice instead of SliceStable because each policy has unique namespace/name
 	sort.Slice(policies, func(i, j int) bool {
 		if policies[i].scope != policies[j].scope {
 			return policies[i].scope < policies[j].scope
 		}
+		if policies[i].Spec.Priority != policies[j].Spec.Priority {
+			return policies[i].Spec.Priority > policies[j].Spec.Priority
+		}
 		if policies[i].CreationTimestamp != policies[j].CreationTimestamp {
 			return policies[i].CreationTimestamp.Before(&policies[j].CreationTimestamp)
 		}
@@ -89,6 +103,35 @@ const (
 	PolicyKindLDS
 )
 
+func goPluginToMap(plugin *fmModel.FilterConfig) map[string]interface{} {
+	m := map[string]interface{}{
+		"name":   plugin.Name,
+		"config": plugin.Config,
+	}
+	if plugin.Sampling != nil {
+		m["sampling"] = map[string]interface{}{
+			"percentage": plugin.Sampling.Percentage,
+		}
+	}
+	if plugin.MergeStrategy != "" {
+		m["mergeStrategy"] = plugin.MergeStrategy
+	}
+	if plugin.HeaderOps != nil {
+		// convert to the map so that it can be put into the protobuf struct
+		var ops map[string]interface{}
+		b, _ := json.Marshal(plugin.HeaderOps)
+		_ = json.Unmarshal(b, &ops)
+		m["headerOps"] = ops
+	}
+	if plugin.Match != nil {
+		var match map[string]interface{}
+		b, _ := json.Marshal(plugin.Match)
+		_ = json.Unmarshal(b, &match)
+		m["match"] = match
+	}
+	return m
+}
+
 func translateFilterManagerConfigToPolicyInRDS(fmc *filtermanager.FilterManagerConfig,
 	nsName *types.NamespacedName, virtualHost *model.VirtualHost) map[string]interface{} {
 
@@ -96,7 +139,8 @@ func translateFilterManagerConfigToPolicyInRDS(fmc *filtermanager.FilterManagerC
 
 	nativeFilters := []*fmModel.FilterConfig{}
 	goFilterManager := &filtermanager.FilterManagerConfig{
-		Plugins: []*fmModel.FilterConfig{},
+		Plugins:         []*fmModel.FilterConfig{},
+		DisabledPlugins: fmc.DisabledPlugins,
 	}
 
 	consumerNeeded := false
@@ -157,19 +201,24 @@ func translateFilterManagerConfigToPolicyInRDS(fmc *filtermanager.FilterManagerC
 		goFilterManager.Namespace = nsName.Namespace
 	}
 
-	if len(goFilterManager.Plugins) > 0 {
+	// the route which only disables the plugins from the Gateway still needs the configuration
+	if len(goFilterManager.Plugins) > 0 || len(goFilterManager.DisabledPlugins) > 0 {
 		v := map[string]interface{}{}
 		if goFilterManager.Namespace != "" {
 			v["namespace"] = goFilterManager.Namespace
 		}
 		plugins := make([]interface{}, len(goFilterManager.Plugins))
 		for i, plugin := range goFilterManager.Plugins {
-			plugins[i] = map[string]interface{}{
-				"name":   plugin.Name,
-				"config": plugin.Config,
-			}
+			plugins[i] = goPluginToMap(plugin)
 		}
 		v["plugins"] = plugins
+		if len(goFilterManager.DisabledPlugins) > 0 {
+			disabled := make([]interface{}, len(goFilterManager.DisabledPlugins))
+			for i, name := range goFilterManager.DisabledPlugins {
+				disabled[i] = name
+			}
+			v["disabledPlugins"] = disabled
+		}
 
 		golangFilterName := "htnn.filters.http.golang"
 		if ctrlcfg.EnableLDSPluginViaECDS() {
@@ -281,10 +330,7 @@ func translateFilterManagerConfigToPolicyInLDS(fmc *filtermanager.FilterManagerC
 		}
 		plugins := make([]interface{}, len(goFilterManager.Plugins))
 		for i, plugin := range goFilterManager.Plugins {
-			plugins[i] = map[string]interface{}{
-				"name":   plugin.Name,
-				"config": plugin.Config,
-			}
+			plugins[i] = goPluginToMap(plugin)
 		}
 		cfg["plugins"] = plugins
 		config[model.CategoryECDSGolang] = cfg
@@ -297,7 +343,7 @@ func translateFilterManagerConfigToPolicyInLDS(fmc *filtermanager.FilterManagerC
 }
 
 func toMergedPolicy(nsName *types.NamespacedName, policies []*FilterPolicyWrapper,
-	policyKind PolicyKind, virtualHost *model.VirtualHost) *mergedPolicy {
+	policyKind PolicyKind, virtualHost *model.VirtualHost, usages map[string]map[string]*pluginUsage) *mergedPolicy {
 
 	sortFilterPolicy(policies)
 
@@ -307,21 +353,44 @@ func toMergedPolicy(nsName *types.NamespacedName, policies []*FilterPolicyWrappe
 		},
 	}
 
-	// use map to deduplicate policies, especially for the sub-policies
-	usedFP := make(map[string]struct{}, len(policies))
+	// group the same filter from different policies, the one from the higher priority policy comes first
+	filters := make(map[string][]*policyFilter)
 	for _, policy := range policies {
-		used := false
 		for name, filter := range policy.Spec.Filters {
-			if _, ok := p.Spec.Filters[name]; !ok {
-				p.Spec.Filters[name] = filter
-				used = true
-			}
+			filters[name] = append(filters[name], &policyFilter{
+				Plugin: filter,
+				policy: policy,
+			})
 		}
+	}
 
-		if used {
+	// use map to deduplicate policies, especially for the sub-policies
+	usedFP := make(map[string]struct{}, len(policies))
+
+	// the filters from the Gateway are disabled if any of the policies disables them
+	var disabledFilters []string
+	for _, policy := range policies {
+		if len(policy.Spec.DisabledFilters) > 0 {
+			disabledFilters = append(disabledFilters, policy.Spec.DisabledFilters...)
 			usedFP[toNsName(policy)] = struct{}{}
 		}
 	}
+	slices.Sort(disabledFilters)
+	p.Spec.DisabledFilters = slices.Compact(disabledFilters)
+	mergeStrategies := make(map[string]string)
+	for name, fs := range filters {
+		// the policies whose filter is merged, others are overridden
+		merged := make(map[string]struct{}, len(fs))
+		p.Spec.Filters[name] = mergeFilters(name, fs, merged)
+		recordPluginUsages(name, fs, merged, usages)
+		for policy := range merged {
+			usedFP[policy] = struct{}{}
+		}
+		if s := fs[0].policy.Spec.MergeStrategy; s != "" {
+			// pass the strategy to the data plane, so that it can be merged with the plugin from the Gateway
+			mergeStrategies[name] = s
+		}
+	}
 
 	info := &Info{
 		FilterPolicies: make([]string, 0, len(usedFP)),
@@ -331,7 +400,7 @@ func toMergedPolicy(nsName *types.NamespacedName, policies []*FilterPolicyWrappe
 	}
 	slices.Sort(info.FilterPolicies) // order is required for later procession
 
-	fmc := translateFilterPolicyToFilterManagerConfig(p)
+	fmc := translateFilterPolicyToFilterManagerConfig(p, mergeStrategies)
 	var config map[string]interface{}
 	if policyKind == PolicyKindRDS {
 		config = translateFilterManagerConfigToPolicyInRDS(fmc, nsName, virtualHost)
@@ -346,15 +415,96 @@ func toMergedPolicy(nsName *types.NamespacedName, policies []*FilterPolicyWrappe
 	}
 }
 
-func translateFilterPolicyToFilterManagerConfig(policy *mosniov1.FilterPolicy) *filtermanager.FilterManagerConfig {
+type policyFilter struct {
+	mosniov1.Plugin
+
+	policy *FilterPolicyWrapper
+}
+
+// recordPluginUsages records whether the filter from each policy is merged. The filters not in
+// the merged policies are overridden by the merged ones.
+func recordPluginUsages(name string, fs []*policyFilter, merged map[string]struct{},
+	usages map[string]map[string]*pluginUsage) {
+
+	for _, f := range fs {
+		policy := toNsName(f.policy)
+		if usages[policy] == nil {
+			usages[policy] = make(map[string]*pluginUsage)
+		}
+		usage := usages[policy][name]
+		if usage == nil {
+			usage = &pluginUsage{
+				overriddenBy: make(map[string]struct{}),
+			}
+			usages[policy][name] = usage
+		}
+
+		if _, ok := merged[policy]; ok {
+			usage.effective = true
+			continue
+		}
+		for winner := range merged {
+			usage.overriddenBy[winner] = struct{}{}
+		}
+	}
+}
+
+// mergeFilters merges the same filter from the policies according to the merge strategy.
+// The filters should be sorted by the priority of the policies, from high to low.
+func mergeFilters(name string, fs []*policyFilter, usedFP map[string]struct{}) mosniov1.Plugin {
+	child := fs[0]
+	usedFP[toNsName(child.policy)] = struct{}{}
+
+	strategy := plugins.MergeStrategy(child.policy.Spec.MergeStrategy)
+	if strategy == "" {
+		strategy = plugins.LoadMergeStrategy(name)
+	}
+	if strategy == plugins.MergeStrategyReplace || len(fs) == 1 {
+		return child.Plugin
+	}
+
+	parent := mergeFilters(name, fs[1:], usedFP)
+	var parentCfg, childCfg interface{}
+	// we validated the filter at the beginning, so theorily err should not happen
+	if err := json.Unmarshal(parent.Config.Raw, &parentCfg); err != nil {
+		return child.Plugin
+	}
+	if err := json.Unmarshal(child.Config.Raw, &childCfg); err != nil {
+		return child.Plugin
+	}
+	b, err := json.Marshal(plugins.MergeConfig(strategy, parentCfg, childCfg))
+	if err != nil {
+		return child.Plugin
+	}
+
+	merged := *child.Plugin.DeepCopy()
+	merged.Config.Raw = b
+	return merged
+}
+
+func translateFilterPolicyToFilterManagerConfig(policy *mosniov1.FilterPolicy, mergeStrategies map[string]string) *filtermanager.FilterManagerConfig {
 	fmc := &filtermanager.FilterManagerConfig{
-		Plugins: []*fmModel.FilterConfig{},
+		Plugins:         []*fmModel.FilterConfig{},
+		DisabledPlugins: policy.Spec.DisabledFilters,
 	}
 	for name, filter := range policy.Spec.Filters {
-		fmc.Plugins = append(fmc.Plugins, &fmModel.FilterConfig{
-			Name:   name,
-			Config: filter.Config.Raw,
-		})
+		fc := &fmModel.FilterConfig{
+			Name:          name,
+			Config:        filter.Config.Raw,
+			MergeStrategy: mergeStrategies[name],
+		}
+		if filter.Sampling != nil {
+			fc.Sampling = &fmModel.Sampling{
+				Percentage: filter.Sampling.Percentage,
+			}
+		}
+		if filter.HeaderOps != nil {
+			fc.HeaderOps = filter.HeaderOps.ToConfig()
+		}
+		if filter.Match != nil {
+			fc.Match = filter.Match.ToConfig()
+		}
+		fmc.Plugins = append(fmc.Plugins, fc)
 	}
 
 	sortPlugins(fmc.Plugins)
@@ -369,7 +519,8 @@ func sortPlugins(ps []*fmModel.FilterConfig) {
 
 func toMergedState(ctx *Ctx, state *dataPlaneState) (*FinalState, error) {
 	s := &mergedState{
-		Proxies: make(map[Proxy]*mergedProxyConfig),
+		Proxies:      make(map[Proxy]*mergedProxyConfig),
+		PluginUsages: make(map[string]map[string]*pluginUsage),
 	}
 
 	for proxy, cfg := range state.Proxies {
@@ -381,7 +532,7 @@ func toMergedState(ctx *Ctx, state *dataPlaneState) (*FinalState, error) {
 			}
 
 			for routeName, route := range host.Routes {
-				mergedPolicy := toMergedPolicy(route.NsName, route.Policies, PolicyKindRDS, mh.VirtualHost)
+				mergedPolicy := toMergedPolicy(route.NsName, route.Policies, PolicyKindRDS, mh.VirtualHost, s.PluginUsages)
 				mh.Routes[routeName] = mergedPolicy
 			}
 
@@ -394,7 +545,7 @@ func toMergedState(ctx *Ctx, state *dataPlaneState) (*FinalState, error) {
 				Gateway: gateway.Gateway,
 			}
 			if len(gateway.Policies) > 0 {
-				mg.Policy = toMergedPolicy(&gateway.Gateway.GatewaySection.NsName, gateway.Policies, PolicyKindLDS, nil)
+				mg.Policy = toMergedPolicy(&gateway.Gateway.GatewaySection.NsName, gateway.Policies, PolicyKindLDS, nil, s.PluginUsages)
 			}
 
 			mergedGateways[name] = mg
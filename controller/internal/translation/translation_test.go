@@ -83,11 +83,17 @@ type testInput struct {
 
 	VirtualService map[string][]*istiov1a3.VirtualService `json:"virtualService"`
 	IstioGateway   []*istiov1a3.Gateway                   `json:"istioGateway"`
+	ServiceEntry   []*istiov1a3.ServiceEntry              `json:"serviceEntry"`
 
 	HTTPRoute map[string][]*gwapiv1b1.HTTPRoute `json:"httpRoute"`
 	Gateway   []*gwapiv1b1.Gateway              `json:"gateway"`
 
 	Features *Features `json:"features"`
+
+	// the expected overridden plugins, only checked when it's set
+	OverriddenPlugins map[string]map[string][]string `json:"overriddenPlugins"`
+	// the expected EnvoyFilters of each target, named as `$namespace/$name`, only checked when it's set
+	Targets map[string][]string `json:"targets"`
 }
 
 func TestTranslate(t *testing.T) {
@@ -215,6 +221,27 @@ func TestTranslate(t *testing.T) {
 				}
 			}
 
+			for _, se := range input.ServiceEntry {
+				if se.Namespace == "" {
+					se.SetNamespace("default")
+				}
+				fps := fpsMap[se.Name]
+				if fps != nil {
+					delete(fpsMap, se.Name)
+				}
+				for _, fp := range fps {
+					if fp.Namespace == "" {
+						fp.SetNamespace("default")
+					}
+					for _, wrapper := range vsToGws {
+						routes := RoutesToUpstream(wrapper.vs, fp.Namespace, se.Spec.Hosts)
+						if len(routes) > 0 {
+							s.AddUpstreamPolicyForVirtualService(fp, wrapper.vs, wrapper.gws, routes)
+						}
+					}
+				}
+			}
+
 			// For gateway-only cases
 			for _, gw := range input.IstioGateway {
 				name := gw.Name
@@ -234,6 +261,18 @@ func TestTranslate(t *testing.T) {
 
 			fs, err := s.Process(context.Background())
 			require.NoError(t, err)
+			if input.OverriddenPlugins != nil {
+				require.Equal(t, input.OverriddenPlugins, fs.OverriddenPlugins)
+			}
+			if input.Targets != nil {
+				targets := map[string][]string{}
+				for target, keys := range fs.Targets {
+					for _, key := range keys {
+						targets[target] = append(targets[target], key.Namespace+"/"+key.Name)
+					}
+				}
+				require.Equal(t, input.Targets, targets)
+			}
 
 			defaultEnvoyFilters := istio.DefaultEnvoyFilters()
 			for key := range defaultEnvoyFilters {
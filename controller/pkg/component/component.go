@@ -20,6 +20,7 @@ import (
 
 	istioapi "istio.io/api/networking/v1alpha3"
 	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -52,6 +53,12 @@ type ResourceMeta interface {
 	GetAnnotations() map[string]string
 }
 
+type EventRecorder interface {
+	// Event records an event of the given type (Normal or Warning) on the object.
+	// It has the same signature as the method in client-go's record.EventRecorder.
+	Event(object runtime.Object, eventtype, reason, message string)
+}
+
 type CtrlLogger interface {
 	Error(msg any)
 	Errorf(format string, args ...any)
@@ -64,8 +71,21 @@ type Distribution interface {
 	Record(value float64)
 }
 
+type Metric interface {
+	// Increment adds 1 to the metric.
+	Increment()
+	// Record adds the value to the Sum, or sets the value of the Gauge.
+	Record(value float64)
+	// With returns the Metric with the given label.
+	With(label, value string) Metric
+}
+
 type MetricProvider interface {
 	// NewDistribution creates a new Metric type called Distribution. This means that the
 	// data collected by the Metric will be collected and exported as a histogram, with the specified bounds.
 	NewDistribution(name, description string, bounds []float64) Distribution
+	// NewSum creates a new Metric type called Sum, which is exported as a counter.
+	NewSum(name, description string) Metric
+	// NewGauge creates a new Metric type called Gauge, which records the latest value.
+	NewGauge(name, description string) Metric
 }
@@ -16,7 +16,14 @@ package constant
 
 const (
 	LabelCreatedBy = "htnn.mosn.io/created-by"
+	// LabelIstioRevision is the label used by Istio to decide which revision handles the resource
+	LabelIstioRevision = "istio.io/rev"
+	// LabelSourceNamespace is the namespace of the resources which generate the EnvoyFilter. It's set when
+	// sharding is enabled, so that each controller only manages the EnvoyFilters from its shard
+	LabelSourceNamespace = "htnn.mosn.io/source-namespace"
 
 	AnnotationFilterPolicy     = "htnn.mosn.io/filterpolicy"
 	AnnotationHTTPFilterPolicy = "htnn.mosn.io/httpfilterpolicy"
+	// AnnotationHash is the hash of the generated resource's content, used to skip unchanged writes
+	AnnotationHash = "htnn.mosn.io/hash"
 )
@@ -20,12 +20,21 @@ import (
 	"fmt"
 	"os"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"mosn.io/htnn/controller/internal/config"
+	"mosn.io/htnn/controller/internal/configdump"
 	"mosn.io/htnn/controller/internal/controller"
+	"mosn.io/htnn/controller/internal/events"
+	"mosn.io/htnn/controller/internal/informer"
+	"mosn.io/htnn/controller/internal/keyissuer"
 	"mosn.io/htnn/controller/internal/log"
 	"mosn.io/htnn/controller/internal/metrics"
+	"mosn.io/htnn/controller/internal/quota"
 	"mosn.io/htnn/controller/internal/registry"
 	"mosn.io/htnn/controller/pkg/component"
 )
@@ -34,8 +43,16 @@ type Reconciler interface {
 	Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error)
 }
 
+// SecretReferrer is implemented by the reconcilers of the resources which can refer to Secrets
+type SecretReferrer interface {
+	// RefersSecret returns true if the Secret is referred by the resources, so that they should be
+	// reconciled when the Secret is changed.
+	RefersSecret(ctx context.Context, key types.NamespacedName) bool
+}
+
 type FilterPolicyReconciler interface {
 	Reconciler
+	SecretReferrer
 
 	NeedReconcile(ctx context.Context, meta component.ResourceMeta) bool
 }
@@ -49,6 +66,7 @@ func NewFilterPolicyReconciler(output component.Output, manager component.Resour
 
 type ConsumerReconciler interface {
 	Reconciler
+	SecretReferrer
 }
 
 func NewConsumerReconciler(output component.Output, manager component.ResourceManager) ConsumerReconciler {
@@ -60,6 +78,7 @@ func NewConsumerReconciler(output component.Output, manager component.ResourceMa
 
 type ServiceRegistryReconciler interface {
 	Reconciler
+	SecretReferrer
 }
 
 func NewServiceRegistryReconciler(output component.Output, manager component.ResourceManager) ServiceRegistryReconciler {
@@ -82,6 +101,14 @@ func NewDynamicConfigReconciler(output component.Output, manager component.Resou
 	}
 }
 
+type FilterPolicyRolloutReconciler interface {
+	Reconciler
+}
+
+func NewFilterPolicyRolloutReconciler(manager component.ResourceManager) FilterPolicyRolloutReconciler {
+	return controller.NewFilterPolicyRolloutReconciler(manager)
+}
+
 func SetLogger(logger component.CtrlLogger) {
 	log.SetLogger(logger)
 }
@@ -96,9 +123,78 @@ func InitConfig(enableGatewayAPI bool, rootNamespace string) {
 
 	os.Setenv("HTNN_ENABLE_GATEWAY_API", fmt.Sprintf("%t", enableGatewayAPI))
 	os.Setenv("HTNN_ISTIO_ROOT_NAMESPACE", rootNamespace)
+	// The revision of the istiod is set via the env REVISION
+	if rev := os.Getenv("REVISION"); rev != "" {
+		os.Setenv("HTNN_ISTIO_REVISION", rev)
+	}
 	config.Init()
 }
 
 func InitMetrics(provider component.MetricProvider) {
 	metrics.InitMetrics(provider)
 }
+
+// StartKeyIssuer starts the endpoint which issues keys for the consumers, if it's configured
+func StartKeyIssuer(restConfig *rest.Config, stop <-chan struct{}) error {
+	addr := config.KeyIssuerAddr()
+	if addr == "" {
+		return nil
+	}
+	return keyissuer.Start(restConfig, addr, config.KeyIssuerCertFile(), config.KeyIssuerKeyFile(), stop)
+}
+
+// StartConfigDump starts the endpoint which dumps the generated configuration, if it's configured
+func StartConfigDump(stop <-chan struct{}) error {
+	addr := config.ConfigDumpAddr()
+	if addr == "" {
+		return nil
+	}
+	return configdump.Start(addr, config.ConfigDumpToken(), stop)
+}
+
+// StartEventRecorder emits the events on the HTNN resources and their targets until the stop channel is closed.
+// As the events are user-visible, only one of the controllers should emit them.
+func StartEventRecorder(restConfig *rest.Config, stop <-chan struct{}) error {
+	return events.Start(restConfig, stop)
+}
+
+// ValidateQuota is used by the webhook to reject the resource of the given kind, if creating it
+// exceeds the quota of its namespace. The usage is recorded in the last reconciliation.
+func ValidateQuota(kind string, namespace string, name string) error {
+	return quota.Check(kind, namespace, name)
+}
+
+// StartNamespaceWatcher watches the namespaces selected by the config, so that only the resources in them
+// are reconciled. The onChange is called when the watched namespaces are changed to trigger the reconciliation.
+func StartNamespaceWatcher(restConfig *rest.Config, stop <-chan struct{}, onChange func()) error {
+	return informer.StartNamespaceWatcher(restConfig, stop, onChange)
+}
+
+// StripUnusedFields is the transform function used by the informers of the HTNN resources, which removes
+// the fields not used by the controller from the cache.
+func StripUnusedFields(obj any) (any, error) {
+	return informer.StripUnusedFields(obj)
+}
+
+// NamespaceWatched returns true if the resources in the namespace should be reconciled
+func NamespaceWatched(namespace string) bool {
+	return informer.NamespaceWatched(namespace)
+}
+
+// WatchedNamespaces returns the namespaces whose resources should be reconciled, or nil if all the namespaces
+// are watched. The resource manager should only read the resources in them.
+func WatchedNamespaces() []string {
+	return informer.WatchedNamespaces()
+}
+
+// StartSecretWatcher caches the Secrets referred by the sensitive fields, as they are not in the config store
+// of Istio. The onChange is called with the changed Secret to trigger the reconciliation of the resources
+// referring to it. It returns after the Secrets are synced.
+func StartSecretWatcher(client kubernetes.Interface, stop <-chan struct{}, onChange func(key types.NamespacedName)) error {
+	return informer.StartSecretWatcher(client, stop, onChange)
+}
+
+// GetSecret reads the Secret cached by the Secret watcher. The resource manager should use it to read Secrets.
+func GetSecret(key types.NamespacedName, out *corev1.Secret) error {
+	return informer.GetSecret(key, out)
+}
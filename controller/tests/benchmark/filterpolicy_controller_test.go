@@ -173,7 +173,7 @@ var _ = Describe("FilterPolicy controller", func() {
 					return false
 				}
 				for _, policy := range policies.Items {
-					if len(policy.Status.Conditions) != 1 {
+					if len(policy.Status.Conditions) == 0 {
 						return false
 					}
 					if policy.Status.Conditions[0].Reason != string(gwapiv1a2.PolicyReasonAccepted) {
@@ -827,6 +827,84 @@ var _ = Describe("FilterPolicy controller, for route", func() {
 			Expect(names).To(ConsistOf([]string{"htnn-http-filter", "htnn-h-default.local"}))
 		})
 
+		It("deal with rollout", func() {
+			ctx := context.Background()
+			input := []map[string]interface{}{}
+			mustReadFilterPolicy("virtualservice_rollout", &input)
+
+			for _, in := range input {
+				obj := pkg.MapToObj(in)
+				Expect(k8sClient.Create(ctx, obj)).Should(Succeed())
+			}
+
+			var policies mosniov1.FilterPolicyList
+			var policy *mosniov1.FilterPolicy
+			Eventually(func() bool {
+				if err := k8sClient.List(ctx, &policies); err != nil || len(policies.Items) == 0 {
+					return false
+				}
+				policy = &policies.Items[0]
+				st := policy.Status.Rollout
+				return st != nil && st.Phase == mosniov1.RolloutPhasePromoted
+			}, timeout, interval).Should(BeTrue())
+
+			base := client.MergeFrom(policy.DeepCopy())
+			policy.Spec.Filters["demo"] = mosniov1.Plugin{
+				Config: runtime.RawExtension{
+					Raw: []byte(`{"hostName":"kate"}`),
+				},
+			}
+			Expect(k8sClient.Patch(ctx, policy, base)).Should(Succeed())
+
+			var envoyfilters istiov1a3.EnvoyFilterList
+			Eventually(func() bool {
+				if err := k8sClient.List(ctx, &envoyfilters); err != nil {
+					return false
+				}
+				return len(envoyfilters.Items) == 3
+			}, timeout, interval).Should(BeTrue())
+
+			names := []string{}
+			for _, ef := range envoyfilters.Items {
+				names = append(names, ef.Name)
+				if ef.Namespace != "default" {
+					continue
+				}
+				Expect(len(ef.Spec.ConfigPatches)).To(Equal(1))
+				b, _ := ef.Spec.ConfigPatches[0].Patch.Value.MarshalJSON()
+				if ef.Name == "htnn-h-default.local" {
+					Expect(string(b)).To(ContainSubstring(`"hostName":"goldfish"`))
+					Expect(ef.Spec.WorkloadSelector).To(BeNil())
+				} else {
+					Expect(string(b)).To(ContainSubstring(`"hostName":"kate"`))
+					Expect(ef.Spec.WorkloadSelector.Labels).To(Equal(map[string]string{"canary": "true"}))
+				}
+			}
+			Expect(names).To(ConsistOf([]string{"htnn-http-filter", "htnn-h-default.local", "htnn-h-default.local-canary-0"}))
+
+			Expect(k8sClient.List(ctx, &policies)).Should(Succeed())
+			st := policies.Items[0].Status.Rollout
+			Expect(st.Phase).To(Equal(mosniov1.RolloutPhaseProgressing))
+
+			// stop the rollout, so the new configuration is applied to all the pods
+			policy = &policies.Items[0]
+			base = client.MergeFrom(policy.DeepCopy())
+			policy.Spec.Rollout = nil
+			Expect(k8sClient.Patch(ctx, policy, base)).Should(Succeed())
+			Eventually(func() bool {
+				if err := k8sClient.List(ctx, &envoyfilters); err != nil {
+					return false
+				}
+				return len(envoyfilters.Items) == 2
+			}, timeout, interval).Should(BeTrue())
+			for _, ef := range envoyfilters.Items {
+				if ef.Name == "htnn-h-default.local" {
+					b, _ := ef.Spec.ConfigPatches[0].Patch.Value.MarshalJSON()
+					Expect(string(b)).To(ContainSubstring(`"hostName":"kate"`))
+				}
+			}
+		})
+
 	})
 
 	Context("When reconciling FilterPolicy with HTTPRoute", func() {
@@ -42,6 +42,7 @@ import (
 	"mosn.io/htnn/controller/internal/config"
 	"mosn.io/htnn/controller/internal/controller"
 	"mosn.io/htnn/controller/internal/controller/component"
+	"mosn.io/htnn/controller/internal/events"
 	"mosn.io/htnn/controller/internal/gatewayapi"
 	"mosn.io/htnn/controller/internal/log"
 	"mosn.io/htnn/controller/internal/registry"
@@ -131,6 +132,8 @@ var _ = BeforeSuite(func() {
 	})
 	Expect(err).ToNot(HaveOccurred())
 
+	events.SetRecorder(k8sManager.GetEventRecorderFor(events.Component))
+
 	output := component.NewK8sOutput(k8sManager.GetClient())
 	rm := component.NewK8sResourceManager(k8sManager.GetClient())
 	err = controller.NewFilterPolicyReconciler(
@@ -159,6 +162,11 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = controller.NewFilterPolicyRolloutReconciler(
+		rm,
+	).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	go func() {
 		defer GinkgoRecover()
 		err = k8sManager.Start(ctx)
@@ -38,9 +38,29 @@ func init() {
 			require.Equal(t, 200, rsp.StatusCode)
 			require.Equal(t, origin, rsp.Header.Get("Access-Control-Allow-Origin"))
 			require.Equal(t, meth, rsp.Header.Get("Access-Control-Allow-Methods"))
+			require.Equal(t, "x-custom", rsp.Header.Get("Access-Control-Allow-Headers"))
+			require.Equal(t, "600", rsp.Header.Get("Access-Control-Max-Age"))
+			require.Equal(t, "true", rsp.Header.Get("Access-Control-Allow-Credentials"))
 			rsp, _ = suite.Post("/echo", hdr, strings.NewReader(""))
 			require.Equal(t, 200, rsp.StatusCode)
 			require.Equal(t, origin, rsp.Header.Get("Access-Control-Allow-Origin"))
+			require.Equal(t, "x-expose", rsp.Header.Get("Access-Control-Expose-Headers"))
+			require.Equal(t, "true", rsp.Header.Get("Access-Control-Allow-Credentials"))
+
+			// exact match
+			hdr.Set("Origin", "http://default.local")
+			hdr.Set("Access-Control-Request-Private-Network", "true")
+			rsp, err = suite.Options("/echo", hdr)
+			require.NoError(t, err)
+			require.Equal(t, 200, rsp.StatusCode)
+			require.Equal(t, "http://default.local", rsp.Header.Get("Access-Control-Allow-Origin"))
+			require.Equal(t, "true", rsp.Header.Get("Access-Control-Allow-Private-Network"))
+
+			// mismatched
+			hdr.Set("Origin", "http://default.localx")
+			rsp, err = suite.Options("/echo", hdr)
+			require.NoError(t, err)
+			require.Equal(t, "", rsp.Header.Get("Access-Control-Allow-Origin"))
 		},
 	})
 }
@@ -15,7 +15,9 @@
 package tests
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -28,7 +30,16 @@ func init() {
 		Run: func(t *testing.T, suite *suite.Suite) {
 			resp, err := suite.Head("/echo", nil)
 			require.NoError(t, err)
+			require.Equal(t, 200, resp.StatusCode)
+
+			// only the requests with the matched headers are injected
+			hdr := http.Header{}
+			hdr.Set("x-fault", "true")
+			start := time.Now()
+			resp, err = suite.Head("/echo", hdr)
+			require.NoError(t, err)
 			require.Equal(t, 401, resp.StatusCode)
+			require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
 		},
 	})
 }
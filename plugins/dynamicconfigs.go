@@ -16,4 +16,5 @@ package plugins
 
 import (
 	_ "mosn.io/htnn/plugins/dynamicconfigs/demo"
+	_ "mosn.io/htnn/plugins/dynamicconfigs/sentinel"
 )
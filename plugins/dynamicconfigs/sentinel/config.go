@@ -0,0 +1,80 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinel
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/alibaba/sentinel-golang/core/system"
+
+	"mosn.io/htnn/api/pkg/dynamicconfig"
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/dynamicconfigs/sentinel"
+)
+
+var (
+	systemBlockResponse atomic.Pointer[sentinel.BlockResponse]
+)
+
+func init() {
+	dynamicconfig.RegisterDynamicConfigHandler("sentinel", &handler{})
+}
+
+type handler struct {
+	sentinel.Provider
+}
+
+// OnUpdate loads the system rules, which protect all the routes using the sentinel plugin
+// according to the load, CPU usage, average RT, concurrency or QPS of the whole Envoy.
+func (h *handler) OnUpdate(config any) error {
+	c := config.(*sentinel.Config)
+	api.LogInfof("sentinel dynamic config: %v", c)
+
+	if err := Init(); err != nil {
+		return err
+	}
+
+	rules := make([]*system.Rule, 0, len(c.Rules))
+	for _, r := range c.Rules {
+		strategy := system.NoAdaptive
+		if r.Strategy == sentinel.SystemRule_BBR {
+			strategy = system.BBR
+		}
+		rule := &system.Rule{
+			ID:           r.Id,
+			MetricType:   system.MetricType(r.MetricType),
+			TriggerCount: r.TriggerCount,
+			Strategy:     strategy,
+		}
+		// Sentinel ignores the invalid rules silently, so we check them first
+		if err := system.IsValidSystemRule(rule); err != nil {
+			return fmt.Errorf("invalid system rule %s: %w", rule, err)
+		}
+		rules = append(rules, rule)
+	}
+	if _, err := system.LoadRules(rules); err != nil {
+		return err
+	}
+
+	systemBlockResponse.Store(c.BlockResponse)
+	return nil
+}
+
+// GetSystemBlockResponse returns the response configured for the requests blocked by the system
+// rules. It returns nil if the response is not configured.
+func GetSystemBlockResponse() *sentinel.BlockResponse {
+	return systemBlockResponse.Load()
+}
@@ -0,0 +1,66 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinel
+
+import (
+	"testing"
+
+	"github.com/alibaba/sentinel-golang/core/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	_ "mosn.io/htnn/api/plugins/tests/pkg/envoy" // for log implementation
+	"mosn.io/htnn/types/dynamicconfigs/sentinel"
+)
+
+func TestOnUpdate(t *testing.T) {
+	defer system.ClearRules()
+
+	h := &handler{}
+	c := &sentinel.Config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"rules": [
+			{"metricType": "CPU_USAGE", "triggerCount": 0.8, "strategy": "BBR"},
+			{"metricType": "AVG_RT", "triggerCount": 100}
+		],
+		"blockResponse": {"statusCode": 503}
+	}`), c))
+	require.NoError(t, c.Validate())
+	require.NoError(t, h.OnUpdate(c))
+
+	rules := system.GetRules()
+	require.Len(t, rules, 2)
+	for _, r := range rules {
+		if r.MetricType == system.CpuUsage {
+			assert.Equal(t, system.BBR, r.Strategy)
+		} else {
+			assert.Equal(t, system.AvgRT, r.MetricType)
+			assert.Equal(t, system.NoAdaptive, r.Strategy)
+		}
+	}
+	assert.Equal(t, uint32(503), GetSystemBlockResponse().GetStatusCode())
+
+	c = &sentinel.Config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"rules": [
+			{"metricType": "CPU_USAGE", "triggerCount": 80}
+		]
+	}`), c))
+	require.NoError(t, c.Validate())
+	assert.ErrorContains(t, h.OnUpdate(c), "invalid CPU usage")
+	// the previous rules are kept
+	assert.Len(t, system.GetRules(), 2)
+}
@@ -0,0 +1,88 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinel
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	sentinelapi "github.com/alibaba/sentinel-golang/api"
+	sentinelconf "github.com/alibaba/sentinel-golang/core/config"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+var (
+	initOnce sync.Once
+	initErr  error
+)
+
+// Init initializes Sentinel for the whole process. The rules of Sentinel are global, so both the
+// sentinel plugin and the sentinel DynamicConfig call it before loading their rules.
+func Init() error {
+	initOnce.Do(func() {
+		conf := sentinelconf.NewDefaultConfig()
+		// write the logs via Envoy and don't flush the metrics to the files
+		conf.Sentinel.Log.Logger = &logger{}
+		conf.Sentinel.Log.Metric.FlushIntervalSec = 0
+		initErr = sentinelapi.InitWithConfig(conf)
+	})
+	return initErr
+}
+
+type logger struct{}
+
+func format(msg string, keysAndValues []interface{}) string {
+	var b strings.Builder
+	b.WriteString("sentinel: ")
+	b.WriteString(msg)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fmt.Fprintf(&b, ", %v: %+v", keysAndValues[i], keysAndValues[i+1])
+	}
+	return b.String()
+}
+
+func (l *logger) Debug(msg string, keysAndValues ...interface{}) {
+	api.LogDebug(format(msg, keysAndValues))
+}
+
+func (l *logger) DebugEnabled() bool {
+	return api.GetLogLevel() <= api.LogLevelDebug
+}
+
+func (l *logger) Info(msg string, keysAndValues ...interface{}) {
+	api.LogInfo(format(msg, keysAndValues))
+}
+
+func (l *logger) InfoEnabled() bool {
+	return api.GetLogLevel() <= api.LogLevelInfo
+}
+
+func (l *logger) Warn(msg string, keysAndValues ...interface{}) {
+	api.LogWarn(format(msg, keysAndValues))
+}
+
+func (l *logger) WarnEnabled() bool {
+	return api.GetLogLevel() <= api.LogLevelWarn
+}
+
+func (l *logger) Error(err error, msg string, keysAndValues ...interface{}) {
+	api.LogErrorf("%s, error: %v", format(msg, keysAndValues), err)
+}
+
+func (l *logger) ErrorEnabled() bool {
+	return api.GetLogLevel() <= api.LogLevelError
+}
@@ -0,0 +1,91 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package geoip looks up the location of the IPs with the MaxMind GeoIP2 / GeoLite2 database.
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// Database is a loaded GeoIP database. It can be used concurrently.
+type Database struct {
+	reader  *maxminddb.Reader
+	modTime time.Time
+	size    int64
+}
+
+// Country returns the ISO 3166-1 alpha-2 code of the country, or empty if not found
+func (db *Database) Country(ip netip.Addr) (string, error) {
+	var record struct {
+		Country struct {
+			ISOCode string `maxminddb:"iso_code"`
+		} `maxminddb:"country"`
+	}
+	err := db.reader.Lookup(net.IP(ip.AsSlice()), &record)
+	if err != nil {
+		return "", err
+	}
+	return record.Country.ISOCode, nil
+}
+
+var (
+	// The database is shared by the configurations which use the same file, and is reloaded when
+	// the file is changed.
+	databases     = map[string]*Database{}
+	databasesLock sync.Mutex
+)
+
+// Load loads the database from the given path. The loaded database is reused until the file is
+// changed.
+func Load(path string) (*Database, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load GeoIP database: %w", err)
+	}
+
+	databasesLock.Lock()
+	defer databasesLock.Unlock()
+
+	db, ok := databases[path]
+	if ok && db.modTime.Equal(fi.ModTime()) && db.size == fi.Size() {
+		return db, nil
+	}
+
+	// Read the whole file instead of using mmap, so that the memory of the replaced database
+	// can be reclaimed once the configurations using it are gone.
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load GeoIP database: %w", err)
+	}
+	reader, err := maxminddb.FromBytes(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load GeoIP database %s: %w", path, err)
+	}
+
+	db = &Database{
+		reader:  reader,
+		modTime: fi.ModTime(),
+		size:    fi.Size(),
+	}
+	databases[path] = db
+	return db, nil
+}
@@ -0,0 +1,116 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kafka produces records to Kafka via the Kafka REST Proxy v2 API, as the data plane
+// doesn't embed a Kafka client.
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	contentType = "application/vnd.kafka.json.v2+json"
+)
+
+// Record is a record produced to Kafka. The key and the value are marshaled as JSON, so a
+// json.RawMessage can be used to embed a JSON document. The key is omitted if it's nil.
+type Record struct {
+	Key   any `json:"key,omitempty"`
+	Value any `json:"value"`
+}
+
+type produceRequest struct {
+	Records []Record `json:"records"`
+}
+
+type produceResponse struct {
+	Offsets []struct {
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+}
+
+type RESTProducer struct {
+	client *http.Client
+	url    string
+}
+
+// NewRESTProducer returns a producer which produces to the given topic via the REST Proxy,
+// like `http://kafka-rest.default:8082`.
+func NewRESTProducer(client *http.Client, proxyURL string, topic string) *RESTProducer {
+	return &RESTProducer{
+		client: client,
+		url:    strings.TrimSuffix(proxyURL, "/") + "/topics/" + url.PathEscape(topic),
+	}
+}
+
+// Produce sends the records in one request. An error is returned if the request fails or any of
+// the records is not produced.
+func (p *RESTProducer) Produce(records []Record) error {
+	body, err := json.Marshal(produceRequest{Records: records})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("content-type", contentType)
+	req.Header.Set("accept", "application/vnd.kafka.v2+json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, data)
+	}
+
+	var res produceResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		// the records are accepted even if the response can't be parsed
+		return nil
+	}
+	failed := 0
+	var firstErr string
+	for _, offset := range res.Offsets {
+		if offset.ErrorCode != nil || offset.Error != nil {
+			if failed == 0 && offset.Error != nil {
+				firstErr = *offset.Error
+			}
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to produce %d of %d records: %s", failed, len(records), firstErr)
+	}
+	return nil
+}
+
+// Close closes the idle connections to the REST Proxy.
+func (p *RESTProducer) Close() {
+	p.client.CloseIdleConnections()
+}
@@ -0,0 +1,58 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRESTProducer(t *testing.T) {
+	var resp string
+	var status int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/topics/events", r.URL.Path)
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("content-type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"records":[{"key":"a","value":{"id":1}},{"value":"raw"}]}`, string(body))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(resp))
+	}))
+	defer srv.Close()
+
+	p := NewRESTProducer(srv.Client(), srv.URL+"/", "events")
+	defer p.Close()
+	records := []Record{
+		{Key: "a", Value: json.RawMessage(`{"id":1}`)},
+		{Value: "raw"},
+	}
+
+	status = 200
+	resp = `{"offsets":[{"partition":0,"offset":1,"error_code":null,"error":null},{"partition":0,"offset":2,"error_code":null,"error":null}]}`
+	require.NoError(t, p.Produce(records))
+
+	resp = `{"offsets":[{"partition":0,"offset":1,"error_code":null,"error":null},{"error_code":50003,"error":"timeout"}]}`
+	assert.EqualError(t, p.Produce(records), "failed to produce 1 of 2 records: timeout")
+
+	status = 404
+	resp = `{"error_code":40401,"message":"Topic not found."}`
+	assert.ErrorContains(t, p.Produce(records), "unexpected status code 404")
+}
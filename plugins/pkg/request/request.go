@@ -15,6 +15,8 @@
 package request
 
 import (
+	"strings"
+
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 )
 
@@ -35,3 +37,21 @@ func GetHeaders(header api.RequestHeaderMap) map[string][]string {
 	})
 	return hdr
 }
+
+// ClientIP returns the IP of the client. When there are trusted proxies in front of the gateway,
+// the IP is taken from the X-Forwarded-For header, skipping the addresses appended by them.
+func ClientIP(headers api.RequestHeaderMap, callbacks api.FilterCallbackHandler, xffNumTrustedHops int) string {
+	n := xffNumTrustedHops
+	if n > 0 {
+		var addrs []string
+		for _, value := range headers.Values("x-forwarded-for") {
+			for _, addr := range strings.Split(value, ",") {
+				addrs = append(addrs, strings.TrimSpace(addr))
+			}
+		}
+		if len(addrs) >= n {
+			return addrs[len(addrs)-n]
+		}
+	}
+	return callbacks.StreamInfo().DownstreamRemoteParsedAddress().IP
+}
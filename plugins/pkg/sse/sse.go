@@ -0,0 +1,213 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sse handles the server-sent events (text/event-stream) in the response. As an event
+// stream may never end, the plugins should not buffer the whole response. Instead, they can
+// process the events one by one when the data arrives.
+package sse
+
+import (
+	"bytes"
+	"errors"
+	"mime"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const ContentType = "text/event-stream"
+
+// IsEventStream checks if the response is an event stream according to the headers
+func IsEventStream(headers api.ResponseHeaderMap) bool {
+	ct, ok := headers.Get("content-type")
+	if !ok {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	return err == nil && mediaType == ContentType
+}
+
+// Event is a server-sent event. Only the non-empty fields are written.
+type Event struct {
+	// The comments, like the `: ping` sent as the heartbeat. They are kept so that the
+	// connection is still alive after the event is transformed.
+	Comments []string
+	ID       string
+	// The event type. An empty type means `message`.
+	Event string
+	// The data fields, joined with '\n'
+	Data  string
+	Retry string
+}
+
+// Type returns the event type with the default value
+func (e *Event) Type() string {
+	if e.Event == "" {
+		return "message"
+	}
+	return e.Event
+}
+
+// Bytes encodes the event, including the blank line in the end
+func (e *Event) Bytes() []byte {
+	var buf bytes.Buffer
+	writeField := func(name, value string) {
+		buf.WriteString(name)
+		if value != "" {
+			buf.WriteString(": ")
+			buf.WriteString(value)
+		}
+		buf.WriteByte('\n')
+	}
+	for _, c := range e.Comments {
+		buf.WriteByte(':')
+		buf.WriteString(c)
+		buf.WriteByte('\n')
+	}
+	if e.ID != "" {
+		writeField("id", e.ID)
+	}
+	if e.Event != "" {
+		writeField("event", e.Event)
+	}
+	if e.Retry != "" {
+		writeField("retry", e.Retry)
+	}
+	if e.Data != "" {
+		for _, line := range strings.Split(e.Data, "\n") {
+			writeField("data", line)
+		}
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+// ErrEventTooLarge is returned when an incomplete event exceeds the limit
+var ErrEventTooLarge = errors.New("event too large")
+
+// Parser parses the event stream which arrives in chunks. The chunk may end in the middle of
+// an event, which is kept until the rest arrives.
+type Parser struct {
+	// The maximum size of an event. No limit if it's zero.
+	MaxEventSize int
+
+	pending []byte
+}
+
+// Pending returns the data of the incomplete event
+func (p *Parser) Pending() []byte {
+	return p.pending
+}
+
+// Feed parses the complete events in the data
+func (p *Parser) Feed(data []byte) ([]*Event, error) {
+	p.pending = append(p.pending, data...)
+
+	var events []*Event
+	ev := &Event{}
+	var dataLines []string
+	start := 0
+	pos := 0
+	for pos < len(p.pending) {
+		i := bytes.IndexAny(p.pending[pos:], "\r\n")
+		if i < 0 {
+			break
+		}
+		end := pos + i
+		next := end + 1
+		if p.pending[end] == '\r' {
+			if next == len(p.pending) {
+				// wait for the possible '\n' in the next chunk
+				break
+			}
+			if p.pending[next] == '\n' {
+				next++
+			}
+		}
+		line := string(p.pending[pos:end])
+		pos = next
+
+		if line == "" {
+			if len(dataLines) > 0 || len(ev.Comments) > 0 || ev.ID != "" || ev.Event != "" || ev.Retry != "" {
+				ev.Data = strings.Join(dataLines, "\n")
+				events = append(events, ev)
+			}
+			ev = &Event{}
+			dataLines = nil
+			start = pos
+			continue
+		}
+
+		name, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch name {
+		case "":
+			ev.Comments = append(ev.Comments, line[1:])
+		case "data":
+			dataLines = append(dataLines, value)
+		case "event":
+			ev.Event = value
+		case "id":
+			ev.ID = value
+		case "retry":
+			ev.Retry = value
+		}
+		// the unknown fields are ignored, as the spec requires
+	}
+
+	p.pending = p.pending[start:]
+	if p.MaxEventSize > 0 && len(p.pending) > p.MaxEventSize {
+		return events, ErrEventTooLarge
+	}
+	return events, nil
+}
+
+// Transformer rewrites the events in the streaming body. The hook is called with each event, and
+// can modify the event in place. The event is dropped if the hook returns false.
+type Transformer struct {
+	parser Parser
+	hook   func(ev *Event) bool
+	// set when the rest of the stream is passed through
+	bypass bool
+}
+
+func NewTransformer(maxEventSize int, hook func(ev *Event) bool) *Transformer {
+	return &Transformer{
+		parser: Parser{MaxEventSize: maxEventSize},
+		hook:   hook,
+	}
+}
+
+// Transform returns the transformed events in the data. The incomplete event is returned in the
+// following calls once it's completed, or in the end of the stream as it is. If an event is too
+// large, the error is returned, and the rest of the stream is passed through without transforming.
+func (t *Transformer) Transform(data []byte, endStream bool) ([]byte, error) {
+	if t.bypass {
+		return data, nil
+	}
+
+	events, err := t.parser.Feed(data)
+	var buf bytes.Buffer
+	for _, ev := range events {
+		if t.hook(ev) {
+			buf.Write(ev.Bytes())
+		}
+	}
+	if err != nil || endStream {
+		t.bypass = err != nil
+		buf.Write(t.parser.pending)
+		t.parser.pending = nil
+	}
+	return buf.Bytes(), err
+}
@@ -0,0 +1,92 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sse
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestIsEventStream(t *testing.T) {
+	for ct, exp := range map[string]bool{
+		"text/event-stream":                true,
+		"Text/Event-Stream; charset=utf-8": true,
+		"application/json":                 false,
+		"":                                 false,
+	} {
+		hdr := envoy.NewResponseHeaderMap(http.Header{})
+		if ct != "" {
+			hdr.Set("content-type", ct)
+		}
+		assert.Equal(t, exp, IsEventStream(hdr), ct)
+	}
+}
+
+func TestParser(t *testing.T) {
+	p := &Parser{}
+	events, err := p.Feed([]byte(": ping\n\nid: 1\nevent: delta\ndata: {\"a\":\ndata:1}\nfoo: bar\n\nretry: 10"))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, []string{" ping"}, events[0].Comments)
+	assert.Equal(t, &Event{ID: "1", Event: "delta", Data: "{\"a\":\n1}"}, events[1])
+	assert.Equal(t, "retry: 10", string(p.Pending()))
+
+	// CRLF split between the chunks
+	events, err = p.Feed([]byte("00\r"))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	events, err = p.Feed([]byte("\ndata: x\r\n\r\ndata: y\r\rdata: z\n\n"))
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, &Event{Retry: "1000", Data: "x"}, events[0])
+	assert.Equal(t, "message", events[1].Type())
+	assert.Equal(t, "z", events[2].Data)
+	assert.Empty(t, p.Pending())
+
+	p = &Parser{MaxEventSize: 8}
+	_, err = p.Feed([]byte("data: 0123456789"))
+	assert.ErrorIs(t, err, ErrEventTooLarge)
+}
+
+func TestEventBytes(t *testing.T) {
+	ev := &Event{Comments: []string{" ok"}, ID: "1", Event: "delta", Data: "a\nb", Retry: "10"}
+	assert.Equal(t, ": ok\nid: 1\nevent: delta\nretry: 10\ndata: a\ndata: b\n\n", string(ev.Bytes()))
+}
+
+func TestTransformer(t *testing.T) {
+	tr := NewTransformer(16, func(ev *Event) bool {
+		ev.Data += "!"
+		return ev.Event != "drop"
+	})
+	res, err := tr.Transform([]byte("data: a\n\nevent: drop\ndata: b\n\ndata: "), false)
+	require.NoError(t, err)
+	assert.Equal(t, "data: a!\n\n", string(res))
+	res, err = tr.Transform([]byte("c\n\ndata: d"), true)
+	require.NoError(t, err)
+	assert.Equal(t, "data: c!\n\ndata: d", string(res))
+
+	tr = NewTransformer(8, func(ev *Event) bool { return true })
+	res, err = tr.Transform([]byte("data: 0123456789"), false)
+	assert.ErrorIs(t, err, ErrEventTooLarge)
+	assert.Equal(t, "data: 0123456789", string(res))
+	res, err = tr.Transform([]byte("\n\ndata: x\n\n"), true)
+	require.NoError(t, err)
+	assert.Equal(t, "\n\ndata: x\n\n", string(res))
+}
@@ -15,16 +15,54 @@
 package plugins
 
 import (
+	_ "mosn.io/htnn/plugins/plugins/abtest"
+	_ "mosn.io/htnn/plugins/plugins/accesslog"
+	_ "mosn.io/htnn/plugins/plugins/aicache"
+	_ "mosn.io/htnn/plugins/plugins/aiproxy"
+	_ "mosn.io/htnn/plugins/plugins/aitokenlimit"
+	_ "mosn.io/htnn/plugins/plugins/cache"
+	_ "mosn.io/htnn/plugins/plugins/canary"
 	_ "mosn.io/htnn/plugins/plugins/casbin"
 	_ "mosn.io/htnn/plugins/plugins/celscript"
+	_ "mosn.io/htnn/plugins/plugins/circuitbreaker"
+	_ "mosn.io/htnn/plugins/plugins/compression"
 	_ "mosn.io/htnn/plugins/plugins/consumerrestriction"
+	_ "mosn.io/htnn/plugins/plugins/correlationid"
+	_ "mosn.io/htnn/plugins/plugins/csrf"
 	_ "mosn.io/htnn/plugins/plugins/debugmode"
 	_ "mosn.io/htnn/plugins/plugins/demo"
+	_ "mosn.io/htnn/plugins/plugins/dubboproxy"
 	_ "mosn.io/htnn/plugins/plugins/extauth"
+	_ "mosn.io/htnn/plugins/plugins/filescan"
+	_ "mosn.io/htnn/plugins/plugins/fingerprint"
+	_ "mosn.io/htnn/plugins/plugins/graphql"
 	_ "mosn.io/htnn/plugins/plugins/hmacauth"
+	_ "mosn.io/htnn/plugins/plugins/idempotency"
+	_ "mosn.io/htnn/plugins/plugins/iprestriction"
+	_ "mosn.io/htnn/plugins/plugins/jsonminify"
+	_ "mosn.io/htnn/plugins/plugins/jwtissuer"
+	_ "mosn.io/htnn/plugins/plugins/kafkaevent"
 	_ "mosn.io/htnn/plugins/plugins/keyauth"
 	_ "mosn.io/htnn/plugins/plugins/limitcountredis"
 	_ "mosn.io/htnn/plugins/plugins/limitreq"
+	_ "mosn.io/htnn/plugins/plugins/maintenance"
+	_ "mosn.io/htnn/plugins/plugins/methodoverride"
+	_ "mosn.io/htnn/plugins/plugins/metrics"
+	_ "mosn.io/htnn/plugins/plugins/mirror"
+	_ "mosn.io/htnn/plugins/plugins/mock"
 	_ "mosn.io/htnn/plugins/plugins/oidc"
 	_ "mosn.io/htnn/plugins/plugins/opa"
+	_ "mosn.io/htnn/plugins/plugins/redirect"
+	_ "mosn.io/htnn/plugins/plugins/requestdecompression"
+	_ "mosn.io/htnn/plugins/plugins/requesthardening"
+	_ "mosn.io/htnn/plugins/plugins/responsesigning"
+	_ "mosn.io/htnn/plugins/plugins/responsetransformer"
+	_ "mosn.io/htnn/plugins/plugins/retry"
+	_ "mosn.io/htnn/plugins/plugins/sentinel"
+	_ "mosn.io/htnn/plugins/plugins/soap"
+	_ "mosn.io/htnn/plugins/plugins/sse"
+	_ "mosn.io/htnn/plugins/plugins/staleiferror"
+	_ "mosn.io/htnn/plugins/plugins/tenant"
+	_ "mosn.io/htnn/plugins/plugins/traceenrichment"
+	_ "mosn.io/htnn/plugins/plugins/trafficsteering"
 )
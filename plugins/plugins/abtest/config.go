@@ -0,0 +1,86 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abtest
+
+import (
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/abtest"
+)
+
+func init() {
+	plugins.RegisterPlugin(abtest.Name, &plugin{})
+	filtermanager.RegisterMetrics(abtest.Name, writeMetrics)
+}
+
+type plugin struct {
+	abtest.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type variant struct {
+	name string
+	// upper is the upper bound (exclusive) of the variant's bucket range
+	upper uint64
+	stats *variantStats
+}
+
+type experiment struct {
+	*abtest.Experiment
+
+	header   string
+	variants []*variant
+	total    uint64
+	none     *variantStats
+}
+
+type config struct {
+	abtest.CustomConfig
+
+	experiments []*experiment
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.experiments = make([]*experiment, len(conf.Experiments))
+	for i, e := range conf.Experiments {
+		exp := &experiment{
+			Experiment: e,
+			header:     e.HeaderName(),
+			variants:   make([]*variant, 0, len(e.Variants)),
+			none:       getVariantStats(e.Name, noneVariant),
+		}
+		for _, v := range e.Variants {
+			if v.Weight == 0 {
+				continue
+			}
+			exp.total += uint64(v.Weight)
+			exp.variants = append(exp.variants, &variant{
+				name:  v.Name,
+				upper: exp.total,
+				stats: getVariantStats(e.Name, v.Name),
+			})
+		}
+		conf.experiments[i] = exp
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "cookie",
+			input: `{"experiments":[{"name":"new-home","user":{"cookie":"uid"},"variants":[{"name":"a","weight":50},{"name":"b","weight":50}]}]}`,
+		},
+		{
+			name:  "header and consumer",
+			input: `{"experiments":[{"name":"x","user":{"header":"x-user-id"},"variants":[{"name":"a","weight":1}]},{"name":"y","header":"x-exp-y","user":{"consumer":true},"variants":[{"name":"a","weight":1},{"name":"off"}]}]}`,
+		},
+		{
+			name:  "experiments are required",
+			input: `{}`,
+			err:   "invalid Config.Experiments: value must contain at least 1 item(s)",
+		},
+		{
+			name:  "bad name",
+			input: `{"experiments":[{"name":"a b","user":{"cookie":"uid"},"variants":[{"name":"a","weight":1}]}]}`,
+			err:   "invalid Experiment.Name: value does not match regex pattern",
+		},
+		{
+			name:  "user is required",
+			input: `{"experiments":[{"name":"x","variants":[{"name":"a","weight":1}]}]}`,
+			err:   "invalid Experiment.User: value is required",
+		},
+		{
+			name:  "empty user source",
+			input: `{"experiments":[{"name":"x","user":{},"variants":[{"name":"a","weight":1}]}]}`,
+			err:   "invalid UserSource.Source: value is required",
+		},
+		{
+			name:  "consumer is false",
+			input: `{"experiments":[{"name":"x","user":{"consumer":false},"variants":[{"name":"a","weight":1}]}]}`,
+			err:   "experiment x: consumer should be true",
+		},
+		{
+			name:  "variants are required",
+			input: `{"experiments":[{"name":"x","user":{"cookie":"uid"}}]}`,
+			err:   "invalid Experiment.Variants: value must contain at least 1 item(s)",
+		},
+		{
+			name:  "duplicate experiment",
+			input: `{"experiments":[{"name":"x","user":{"cookie":"uid"},"variants":[{"name":"a","weight":1}]},{"name":"x","user":{"cookie":"uid"},"variants":[{"name":"a","weight":1}]}]}`,
+			err:   "duplicate experiment x",
+		},
+		{
+			name:  "duplicate header",
+			input: `{"experiments":[{"name":"x","user":{"cookie":"uid"},"variants":[{"name":"a","weight":1}]},{"name":"y","header":"X-AB-X","user":{"cookie":"uid"},"variants":[{"name":"a","weight":1}]}]}`,
+			err:   "duplicate header X-AB-X",
+		},
+		{
+			name:  "duplicate variant",
+			input: `{"experiments":[{"name":"x","user":{"cookie":"uid"},"variants":[{"name":"a","weight":1},{"name":"a","weight":1}]}]}`,
+			err:   "experiment x: duplicate variant a",
+		},
+		{
+			name:  "zero weight",
+			input: `{"experiments":[{"name":"x","user":{"cookie":"uid"},"variants":[{"name":"a"}]}]}`,
+			err:   "experiment x: the total weight of variants should be greater than 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
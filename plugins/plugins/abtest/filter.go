@@ -0,0 +1,91 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abtest
+
+import (
+	"hash/fnv"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/abtest"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+func (f *filter) getUser(headers api.RequestHeaderMap, e *experiment) string {
+	switch src := e.User.Source.(type) {
+	case *abtest.UserSource_Header:
+		v, _ := headers.Get(src.Header)
+		return v
+	case *abtest.UserSource_Cookie:
+		c := headers.Cookie(src.Cookie)
+		if c == nil {
+			return ""
+		}
+		return c.Value
+	case *abtest.UserSource_Consumer:
+		c := f.callbacks.GetConsumer()
+		if c == nil {
+			return ""
+		}
+		return c.Name()
+	}
+	return ""
+}
+
+// bucket assigns the user to a variant deterministically. The name of the experiment is hashed
+// with the user, so that the same user may get different variants in different experiments.
+func (e *experiment) bucket(user string) *variant {
+	h := fnv.New64a()
+	h.Write([]byte(e.Name))
+	h.Write([]byte{0})
+	h.Write([]byte(user))
+	n := h.Sum64() % e.total
+	for _, v := range e.variants {
+		if n < v.upper {
+			return v
+		}
+	}
+	// unreachable
+	return e.variants[len(e.variants)-1]
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	for _, e := range f.config.experiments {
+		user := f.getUser(headers, e)
+		if user == "" {
+			// don't let the client choose the variant
+			headers.Del(e.header)
+			e.none.requests.Add(1)
+			continue
+		}
+
+		v := e.bucket(user)
+		headers.Set(e.header, v.name)
+		v.stats.requests.Add(1)
+	}
+	return api.Continue
+}
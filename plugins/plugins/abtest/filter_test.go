@@ -0,0 +1,127 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abtest
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func run(conf *config, cb api.FilterCallbackHandler, hdr http.Header) api.RequestHeaderMap {
+	h := http.Header{
+		":authority": {"test.local"},
+		":method":    {"GET"},
+		":path":      {"/"},
+	}
+	for k, v := range hdr {
+		h[k] = v
+	}
+	headers := envoy.NewRequestHeaderMap(h)
+	f := factory(conf, cb)
+	f.DecodeHeaders(headers, true)
+	return headers
+}
+
+func TestABTest(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"experiments":[
+		{"name":"cookie","user":{"cookie":"uid"},"variants":[{"name":"a","weight":1},{"name":"b","weight":1}]},
+		{"name":"header","header":"x-exp","user":{"header":"x-user"},"variants":[{"name":"off"},{"name":"on","weight":1}]},
+		{"name":"consumer","user":{"consumer":true},"variants":[{"name":"a","weight":3},{"name":"b","weight":1}]}
+	]}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetConsumer(&testConsumer{name: "alice"})
+	h := run(conf, cb, http.Header{
+		"Cookie":      {"uid=u1"},
+		"X-User":      {"u1"},
+		"X-Ab-Cookie": {"b"},
+	})
+	cookieVariant, _ := h.Get("x-ab-cookie")
+	assert.Contains(t, []string{"a", "b"}, cookieVariant)
+	v, _ := h.Get("x-exp")
+	assert.Equal(t, "on", v)
+	consumerVariant, _ := h.Get("x-ab-consumer")
+	assert.Contains(t, []string{"a", "b"}, consumerVariant)
+
+	// deterministic
+	for i := 0; i < 10; i++ {
+		h = run(conf, cb, http.Header{"Cookie": {"uid=u1"}})
+		v, _ = h.Get("x-ab-cookie")
+		assert.Equal(t, cookieVariant, v)
+		v, _ = h.Get("x-ab-consumer")
+		assert.Equal(t, consumerVariant, v)
+	}
+
+	// unknown user
+	h = run(conf, envoy.NewFilterCallbackHandler(), http.Header{
+		"X-Ab-Cookie":   {"b"},
+		"X-Exp":         {"off"},
+		"X-Ab-Consumer": {"a"},
+	})
+	for _, name := range []string{"x-ab-cookie", "x-exp", "x-ab-consumer"} {
+		_, ok := h.Get(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestABTestDistribution(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"experiments":[
+		{"name":"dist","user":{"header":"x-user"},"variants":[{"name":"a","weight":20},{"name":"b","weight":80}]}
+	]}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	cnt := map[string]int{}
+	cb := envoy.NewFilterCallbackHandler()
+	for i := 0; i < 10000; i++ {
+		h := run(conf, cb, http.Header{"X-User": {fmt.Sprintf("user-%d", i)}})
+		v, _ := h.Get("x-ab-dist")
+		cnt[v]++
+	}
+	assert.InDelta(t, 2000, cnt["a"], 200)
+	assert.InDelta(t, 8000, cnt["b"], 200)
+	run(conf, cb, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeMetrics(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "# TYPE htnn_ab_test_requests_total counter\n")
+	assert.Contains(t, out, fmt.Sprintf("htnn_ab_test_requests_total{experiment=\"dist\",variant=\"a\"} %d\n", cnt["a"]))
+	assert.Contains(t, out, fmt.Sprintf("htnn_ab_test_requests_total{experiment=\"dist\",variant=\"b\"} %d\n", cnt["b"]))
+	assert.Contains(t, out, "htnn_ab_test_requests_total{experiment=\"dist\",variant=\"__none__\"} 1\n")
+}
@@ -0,0 +1,85 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abtest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// noneVariant is the label of the requests which can't be bucketed as the user is unknown
+	noneVariant = "__none__"
+)
+
+type variantKey struct {
+	experiment string
+	variant    string
+}
+
+type variantStats struct {
+	requests atomic.Uint64
+}
+
+// The stats are kept across the configuration updates, so the counters are monotonic
+var statsByVariant sync.Map
+
+func getVariantStats(experiment, variant string) *variantStats {
+	key := variantKey{experiment: experiment, variant: variant}
+	v, ok := statsByVariant.Load(key)
+	if !ok {
+		v, _ = statsByVariant.LoadOrStore(key, &variantStats{})
+	}
+	return v.(*variantStats)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func writeMetrics(w io.Writer) error {
+	type entry struct {
+		key   variantKey
+		stats *variantStats
+	}
+	var list []entry
+	statsByVariant.Range(func(k, v any) bool {
+		list = append(list, entry{key: k.(variantKey), stats: v.(*variantStats)})
+		return true
+	})
+	if len(list) == 0 {
+		return nil
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].key.experiment != list[j].key.experiment {
+			return list[i].key.experiment < list[j].key.experiment
+		}
+		return list[i].key.variant < list[j].key.variant
+	})
+
+	bw := bufio.NewWriter(w)
+	name := "htnn_ab_test_requests_total"
+	fmt.Fprintf(bw, "# HELP %s Number of requests bucketed into each variant of the experiment.\n", name)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", name)
+	for _, e := range list {
+		fmt.Fprintf(bw, "%s{experiment=\"%s\",variant=\"%s\"} %d\n", name,
+			labelValueEscaper.Replace(e.key.experiment), labelValueEscaper.Replace(e.key.variant),
+			e.stats.requests.Load())
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,167 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"net/http"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/plugins/pkg/kafka"
+	"mosn.io/htnn/types/plugins/accesslog"
+)
+
+const (
+	defaultFacility = "local0"
+	defaultAppName  = "htnn"
+)
+
+func init() {
+	plugins.RegisterPlugin(accesslog.Name, &plugin{})
+	filtermanager.RegisterMetrics(accesslog.Name, writeMetrics)
+}
+
+type plugin struct {
+	accesslog.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	accesslog.CustomConfig
+
+	formatter formatter
+	sinks     []*sink
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	switch conf.Format {
+	case accesslog.Format_CLF:
+		conf.formatter = &clfFormatter{}
+	case accesslog.Format_TEXT:
+		tpl, err := accesslog.CompileTemplate(conf.Template)
+		if err != nil {
+			return err
+		}
+		conf.formatter = &textFormatter{tpl: tpl}
+	default:
+		fields := conf.Fields
+		if len(fields) == 0 {
+			fields = defaultFields
+		}
+		f, err := newJSONFormatter(fields)
+		if err != nil {
+			return err
+		}
+		conf.formatter = f
+	}
+
+	conf.sinks = make([]*sink, 0, len(conf.Sinks))
+	for _, s := range conf.Sinks {
+		conf.sinks = append(conf.sinks, conf.newSink(s))
+	}
+	// the sinks' goroutines don't reference the config, so it can be collected once it's replaced
+	sinks := conf.sinks
+	runtime.SetFinalizer(conf, func(conf *config) {
+		// don't block the finalizer goroutine when flushing the pending logs
+		for _, s := range sinks {
+			s.stop()
+		}
+	})
+	return nil
+}
+
+func batchOptions(b *accesslog.Batch) (size int, flushInterval, timeout time.Duration) {
+	size = defaultBatchSize
+	flushInterval = defaultFlushInterval
+	timeout = defaultSendTimeout
+	if b == nil {
+		return
+	}
+	if b.MaxSize > 0 {
+		size = int(b.MaxSize)
+	}
+	if b.FlushInterval != nil {
+		flushInterval = b.FlushInterval.AsDuration()
+	}
+	if b.Timeout != nil {
+		timeout = b.Timeout.AsDuration()
+	}
+	return
+}
+
+func (conf *config) newSink(s *accesslog.Sink) *sink {
+	switch v := s.Sink.(type) {
+	case *accesslog.Sink_Syslog:
+		cfg := v.Syslog
+		facility := defaultFacility
+		if cfg.Facility != "" {
+			facility = cfg.Facility
+		}
+		appName := defaultAppName
+		if cfg.AppName != "" {
+			appName = cfg.AppName
+		}
+		hostname, _ := os.Hostname()
+		if hostname == "" {
+			hostname = "-"
+		}
+		w := &syslogWriter{
+			network:  strings.ToLower(cfg.Network.String()),
+			address:  cfg.Address,
+			priority: accesslog.Facilities[facility]*8 + syslogSeverity,
+			hostname: hostname,
+			appName:  appName,
+			timeout:  defaultSendTimeout,
+		}
+		return newSink("syslog", w, 1, defaultFlushInterval)
+	case *accesslog.Sink_Kafka:
+		cfg := v.Kafka
+		size, flushInterval, timeout := batchOptions(cfg.Batch)
+		client := &http.Client{Timeout: timeout}
+		w := &kafkaWriter{
+			producer: kafka.NewRESTProducer(client, cfg.RestProxyUrl, cfg.Topic),
+			json:     conf.Format == accesslog.Format_JSON,
+		}
+		return newSink("kafka", w, size, flushInterval)
+	case *accesslog.Sink_Http:
+		cfg := v.Http
+		size, flushInterval, timeout := batchOptions(cfg.Batch)
+		contentType := "text/plain"
+		if conf.Format == accesslog.Format_JSON {
+			contentType = "application/x-ndjson"
+		}
+		w := &httpWriter{
+			client:      &http.Client{Timeout: timeout},
+			url:         cfg.Url,
+			headers:     cfg.Headers,
+			contentType: contentType,
+		}
+		return newSink("http", w, size, flushInterval)
+	default:
+		return newSink("stdout", newStdoutWriter(), 1, defaultFlushInterval)
+	}
+}
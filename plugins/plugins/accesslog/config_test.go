@@ -0,0 +1,121 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "default",
+			input: `{"sinks":[{"stdout":{}}]}`,
+		},
+		{
+			name:  "json",
+			input: `{"fields":{"code":"${response.code}","token":"${plugin_state.keyAuth.token}","type":"${response.header.content-type}"},"sinks":[{"http":{"url":"http://log.local/","headers":{"x-api-key":"key"},"batch":{"maxSize":10,"flushInterval":"5s"}}}]}`,
+		},
+		{
+			name:  "text",
+			input: `{"format":"TEXT","template":"${request.method} ${request.path} ${duration}ms","sinks":[{"syslog":{"network":"TCP","address":"syslog.local:514","facility":"local7"}}]}`,
+		},
+		{
+			name:  "clf",
+			input: `{"format":"CLF","sinks":[{"kafka":{"restProxyUrl":"http://kafka-rest.local:8082","topic":"logs"}}]}`,
+		},
+		{
+			name:  "no sinks",
+			input: `{}`,
+			err:   "invalid Config.Sinks: value must contain at least 1 item(s)",
+		},
+		{
+			name:  "empty sink",
+			input: `{"sinks":[{}]}`,
+			err:   "invalid Sink.Sink: value is required",
+		},
+		{
+			name:  "bad url",
+			input: `{"sinks":[{"http":{"url":"/logs"}}]}`,
+			err:   "invalid HTTP.Url: value must be absolute",
+		},
+		{
+			name:  "missing topic",
+			input: `{"sinks":[{"kafka":{"restProxyUrl":"http://kafka-rest.local:8082"}}]}`,
+			err:   "invalid Kafka.Topic: value length must be at least 1 runes",
+		},
+		{
+			name:  "bad batch size",
+			input: `{"sinks":[{"http":{"url":"http://log.local/","batch":{"maxSize":10001}}}]}`,
+			err:   "invalid Batch.MaxSize: value must be less than or equal to 10000",
+		},
+		{
+			name:  "bad facility",
+			input: `{"sinks":[{"syslog":{"address":"syslog.local:514","facility":"local8"}}]}`,
+			err:   "unknown syslog facility local8",
+		},
+		{
+			name:  "template is required",
+			input: `{"format":"TEXT","sinks":[{"stdout":{}}]}`,
+			err:   "template is required in TEXT format",
+		},
+		{
+			name:  "template in JSON format",
+			input: `{"template":"${request.path}","sinks":[{"stdout":{}}]}`,
+			err:   "template is only used in TEXT format",
+		},
+		{
+			name:  "fields in CLF format",
+			input: `{"format":"CLF","fields":{"path":"${request.path}"},"sinks":[{"stdout":{}}]}`,
+			err:   "fields is only used in JSON format",
+		},
+		{
+			name:  "unknown variable",
+			input: `{"fields":{"code":"${response.status}"},"sinks":[{"stdout":{}}]}`,
+			err:   "bad template of field code: unknown variable: response.status",
+		},
+		{
+			name:  "bad plugin state",
+			input: `{"format":"TEXT","template":"${plugin_state.keyAuth}","sinks":[{"stdout":{}}]}`,
+			err:   "bad template: unknown variable: plugin_state.keyAuth",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+				for _, s := range conf.sinks {
+					s.close()
+				}
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
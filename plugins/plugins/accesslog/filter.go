@@ -0,0 +1,62 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	start time.Time
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.start = time.Now()
+	return api.Continue
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	if reqHeaders == nil {
+		return
+	}
+
+	c := &logContext{
+		headers:     reqHeaders,
+		respHeaders: respHeaders,
+		callbacks:   f.callbacks,
+		start:       f.start,
+		end:         time.Now(),
+	}
+	entry := f.config.formatter.format(c)
+	for _, s := range f.config.sinks {
+		s.log(entry)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type streamInfo struct {
+	*envoy.StreamInfo
+}
+
+func (i *streamInfo) Protocol() (string, bool) {
+	return "HTTP/1.1", true
+}
+
+func (i *streamInfo) ResponseCode() (uint32, bool) {
+	return 200, true
+}
+
+func (i *streamInfo) UpstreamRemoteAddress() (string, bool) {
+	return "10.0.0.1:8080", true
+}
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+type memoryWriter struct {
+	lock    sync.Mutex
+	entries []string
+}
+
+func (w *memoryWriter) write(entries [][]byte) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	for _, e := range entries {
+		w.entries = append(w.entries, string(e))
+	}
+	return nil
+}
+
+func (w *memoryWriter) close() {}
+
+// logRequest runs a request through the plugin and returns the log written
+func logRequest(t *testing.T, input string, consumer api.Consumer) string {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+	require.NoError(t, conf.Validate())
+	require.NoError(t, conf.Init(nil))
+	for _, s := range conf.sinks {
+		s.close()
+	}
+	w := &memoryWriter{}
+	s := newSink("memory", w, 1, time.Second)
+	conf.sinks = []*sink{s}
+
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetStreamInfo(&streamInfo{StreamInfo: &envoy.StreamInfo{}})
+	if consumer != nil {
+		cb.SetConsumer(consumer)
+	}
+	cb.PluginState().Set("keyAuth", "token", "secret")
+	cb.PluginState().Set("limitReq", "remaining", 9)
+
+	f := factory(conf, cb)
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":authority": {"test.local"},
+		":method":    {"GET"},
+		":path":      {"/echo?a=1"},
+		"User-Agent": {"curl/8.0"},
+	})
+	f.DecodeHeaders(hdr, true)
+	respHdr := envoy.NewResponseHeaderMap(http.Header{
+		":status":        {"200"},
+		"Content-Length": {"42"},
+		"Content-Type":   {"text/plain"},
+	})
+	f.OnLog(hdr, nil, respHdr, nil)
+
+	s.close()
+	require.Len(t, w.entries, 1)
+	return w.entries[0]
+}
+
+func TestJSONFormat(t *testing.T) {
+	entry := logRequest(t, `{"sinks":[{"stdout":{}}]}`, &testConsumer{name: "alice"})
+	var log map[string]any
+	require.NoError(t, json.Unmarshal([]byte(entry), &log))
+	assert.Equal(t, "GET", log["method"])
+	assert.Equal(t, "/echo?a=1", log["path"])
+	assert.Equal(t, "HTTP/1.1", log["protocol"])
+	assert.Equal(t, float64(200), log["response_code"])
+	assert.Equal(t, "183.128.130.43", log["source_ip"])
+	assert.Equal(t, "10.0.0.1:8080", log["upstream_host"])
+	assert.Equal(t, "alice", log["consumer"])
+	assert.Equal(t, "curl/8.0", log["user_agent"])
+	assert.Equal(t, "", log["request_id"])
+	assert.IsType(t, float64(0), log["duration"])
+	_, err := time.Parse(startTimeLayout, log["start_time"].(string))
+	assert.NoError(t, err)
+
+	entry = logRequest(t, `{"fields":{"token":"${plugin_state.keyAuth.token}","remaining":"${plugin_state.limitReq.remaining}","type":"${response.header.Content-Type}","url":"<${request.host}${request.path}>"},"sinks":[{"stdout":{}}]}`, nil)
+	assert.Equal(t, `{"remaining":"9","token":"secret","type":"text/plain","url":"<test.local/echo?a=1>"}`, entry)
+}
+
+func TestCLFFormat(t *testing.T) {
+	entry := logRequest(t, `{"format":"CLF","sinks":[{"stdout":{}}]}`, nil)
+	prefix, rest, found := strings.Cut(entry, " [")
+	require.True(t, found)
+	assert.Equal(t, "183.128.130.43 - -", prefix)
+	ts, rest, found := strings.Cut(rest, "] ")
+	require.True(t, found)
+	_, err := time.Parse(clfTimeLayout, ts)
+	assert.NoError(t, err)
+	assert.Equal(t, `"GET /echo?a=1 HTTP/1.1" 200 42`, rest)
+
+	entry = logRequest(t, `{"format":"CLF","sinks":[{"stdout":{}}]}`, &testConsumer{name: "alice"})
+	assert.True(t, strings.HasPrefix(entry, "183.128.130.43 - alice ["), entry)
+}
+
+func TestTextFormat(t *testing.T) {
+	entry := logRequest(t, `{"format":"TEXT","template":"${request.method} ${query.a} ${response.code} ${consumer.name} ${upstream.cluster}|","sinks":[{"stdout":{}}]}`, &testConsumer{name: "alice"})
+	assert.Equal(t, "GET 1 200 alice |", entry)
+}
@@ -0,0 +1,254 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+	"mosn.io/htnn/types/plugins/accesslog"
+)
+
+const (
+	startTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+	clfTimeLayout   = "02/Jan/2006:15:04:05 -0700"
+)
+
+var defaultFields = map[string]string{
+	"start_time":            "${start_time}",
+	"method":                "${request.method}",
+	"path":                  "${request.path}",
+	"protocol":              "${request.protocol}",
+	"response_code":         "${response.code}",
+	"response_code_details": "${response.code_details}",
+	"duration":              "${duration}",
+	"source_ip":             "${source.ip}",
+	"upstream_host":         "${upstream.address}",
+	"upstream_cluster":      "${upstream.cluster}",
+	"route":                 "${route.name}",
+	"consumer":              "${consumer.name}",
+	"user_agent":            "${header.user-agent}",
+	"request_id":            "${header.x-request-id}",
+}
+
+// logContext contains what is needed to render a log entry
+type logContext struct {
+	headers     api.RequestHeaderMap
+	respHeaders api.ResponseHeaderMap
+	callbacks   api.FilterCallbackHandler
+	start       time.Time
+	end         time.Time
+}
+
+func (c *logContext) lookup(name string) (string, error) {
+	info := c.callbacks.StreamInfo()
+	switch name {
+	case "request.protocol":
+		protocol, _ := info.Protocol()
+		return protocol, nil
+	case "response.code":
+		code, ok := info.ResponseCode()
+		if !ok {
+			return "", nil
+		}
+		return strconv.FormatUint(uint64(code), 10), nil
+	case "response.code_details":
+		details, _ := info.ResponseCodeDetails()
+		return details, nil
+	case "upstream.address":
+		addr, _ := info.UpstreamRemoteAddress()
+		return addr, nil
+	case "upstream.cluster":
+		cluster, _ := info.UpstreamClusterName()
+		return cluster, nil
+	case "duration":
+		if c.start.IsZero() {
+			return "", nil
+		}
+		return strconv.FormatInt(c.end.Sub(c.start).Milliseconds(), 10), nil
+	case "start_time":
+		if c.start.IsZero() {
+			return "", nil
+		}
+		return c.start.Format(startTimeLayout), nil
+	}
+
+	if header, ok := strings.CutPrefix(name, "response.header."); ok {
+		if c.respHeaders == nil {
+			return "", nil
+		}
+		v, _ := c.respHeaders.Get(strings.ToLower(header))
+		return v, nil
+	}
+	if s, ok := strings.CutPrefix(name, "plugin_state."); ok {
+		ns, key, _ := strings.Cut(s, ".")
+		v := c.callbacks.PluginState().Get(ns, key)
+		if v == nil {
+			return "", nil
+		}
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", nil
+		}
+		return string(b), nil
+	}
+	return "", nil
+}
+
+func (c *logContext) render(tpl *interpolation.Template) string {
+	s, _ := tpl.RenderWith(c.headers, c.callbacks, c.lookup, nil)
+	return s
+}
+
+type formatter interface {
+	format(c *logContext) []byte
+}
+
+type jsonField struct {
+	name string
+	tpl  *interpolation.Template
+	// numeric is true if the field is written as a number
+	numeric bool
+}
+
+type jsonFormatter struct {
+	fields []*jsonField
+}
+
+func newJSONFormatter(fields map[string]string) (*jsonFormatter, error) {
+	f := &jsonFormatter{
+		fields: make([]*jsonField, 0, len(fields)),
+	}
+	for name, s := range fields {
+		tpl, err := accesslog.CompileTemplate(s)
+		if err != nil {
+			return nil, err
+		}
+		f.fields = append(f.fields, &jsonField{
+			name: name,
+			tpl:  tpl,
+			// write the number variables as numbers, so that they can be aggregated without conversion
+			numeric: s == "${response.code}" || s == "${duration}",
+		})
+	}
+	sort.Slice(f.fields, func(i, j int) bool {
+		return f.fields[i].name < f.fields[j].name
+	})
+	return f, nil
+}
+
+func (f *jsonFormatter) format(c *logContext) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range f.fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeJSONString(&buf, field.name)
+		buf.WriteByte(':')
+		v := c.render(field.tpl)
+		if field.numeric {
+			if v == "" {
+				buf.WriteString("null")
+			} else {
+				buf.WriteString(v)
+			}
+			continue
+		}
+		writeJSONString(&buf, v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
+
+func writeJSONString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	// remove the newline added by the Encoder
+	buf.Truncate(buf.Len() - 1)
+}
+
+// clfFormatter formats the log in the Common Log Format:
+// `host ident authuser [date] "request line" status bytes`
+type clfFormatter struct{}
+
+func (f *clfFormatter) format(c *logContext) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(orDash(sourceIP(c.callbacks)))
+	buf.WriteString(" - ")
+	consumer := ""
+	if cs := c.callbacks.GetConsumer(); cs != nil {
+		consumer = cs.Name()
+	}
+	buf.WriteString(orDash(consumer))
+	buf.WriteString(" [")
+	start := c.start
+	if start.IsZero() {
+		start = c.end
+	}
+	buf.WriteString(start.Format(clfTimeLayout))
+	buf.WriteString(`] "`)
+	protocol, _ := c.callbacks.StreamInfo().Protocol()
+	buf.WriteString(c.headers.Method())
+	buf.WriteByte(' ')
+	buf.WriteString(c.headers.Path())
+	if protocol != "" {
+		buf.WriteByte(' ')
+		buf.WriteString(protocol)
+	}
+	buf.WriteString(`" `)
+	code, _ := c.lookup("response.code")
+	buf.WriteString(orDash(code))
+	buf.WriteByte(' ')
+	size := ""
+	if c.respHeaders != nil {
+		size, _ = c.respHeaders.Get("content-length")
+	}
+	buf.WriteString(orDash(size))
+	return buf.Bytes()
+}
+
+func sourceIP(callbacks api.FilterCallbackHandler) string {
+	addr := callbacks.StreamInfo().DownstreamRemoteParsedAddress()
+	if addr == nil {
+		return ""
+	}
+	return addr.IP
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+type textFormatter struct {
+	tpl *interpolation.Template
+}
+
+func (f *textFormatter) format(c *logContext) []byte {
+	return []byte(c.render(f.tpl))
+}
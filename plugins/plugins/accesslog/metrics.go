@@ -0,0 +1,73 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+type sinkStats struct {
+	dropped atomic.Uint64
+	failed  atomic.Uint64
+}
+
+// The stats are kept across the configuration updates, so the counters are monotonic
+var statsBySink sync.Map
+
+func getSinkStats(kind string) *sinkStats {
+	v, ok := statsBySink.Load(kind)
+	if !ok {
+		v, _ = statsBySink.LoadOrStore(kind, &sinkStats{})
+	}
+	return v.(*sinkStats)
+}
+
+func writeMetrics(w io.Writer) error {
+	type entry struct {
+		kind  string
+		stats *sinkStats
+	}
+	var list []entry
+	statsBySink.Range(func(k, v any) bool {
+		list = append(list, entry{kind: k.(string), stats: v.(*sinkStats)})
+		return true
+	})
+	if len(list) == 0 {
+		return nil
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].kind < list[j].kind
+	})
+
+	bw := bufio.NewWriter(w)
+	name := "htnn_access_log_dropped_total"
+	fmt.Fprintf(bw, "# HELP %s Number of access logs dropped because the sink's queue is full.\n", name)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", name)
+	for _, e := range list {
+		fmt.Fprintf(bw, "%s{sink=\"%s\"} %d\n", name, e.kind, e.stats.dropped.Load())
+	}
+	name = "htnn_access_log_failed_total"
+	fmt.Fprintf(bw, "# HELP %s Number of access logs failed to be sent.\n", name)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", name)
+	for _, e := range list {
+		fmt.Fprintf(bw, "%s{sink=\"%s\"} %d\n", name, e.kind, e.stats.failed.Load())
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,311 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/kafka"
+)
+
+const (
+	// the logs are dropped when the queue is full, so that the requests are not blocked
+	queueSize = 4096
+
+	defaultBatchSize     = 100
+	defaultFlushInterval = 1 * time.Second
+	defaultSendTimeout   = 5 * time.Second
+
+	// the failures are logged at most once in this interval, to avoid flooding the error log
+	errorLogInterval = 10 * time.Second
+)
+
+// writer sends the logs to the destination. It's only called in the sink's goroutine.
+type writer interface {
+	write(entries [][]byte) error
+	close()
+}
+
+// sink sends the logs asynchronously
+type sink struct {
+	kind          string
+	w             writer
+	batchSize     int
+	flushInterval time.Duration
+	stats         *sinkStats
+
+	queue     chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// the fields below are only accessed in the sink's goroutine
+	lastErrorLog time.Time
+	failures     int
+}
+
+func newSink(kind string, w writer, batchSize int, flushInterval time.Duration) *sink {
+	s := &sink{
+		kind:          kind,
+		w:             w,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		stats:         getSinkStats(kind),
+		queue:         make(chan []byte, queueSize),
+		done:          make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// log enqueues the log. It never blocks.
+func (s *sink) log(entry []byte) {
+	select {
+	case s.queue <- entry:
+	default:
+		s.stats.dropped.Add(1)
+	}
+}
+
+// stop stops the sink once the pending logs are flushed, without waiting for it. The log method
+// should not be called after it.
+func (s *sink) stop() {
+	s.closeOnce.Do(func() {
+		close(s.queue)
+	})
+}
+
+// close is like stop, but waits until the pending logs are flushed.
+func (s *sink) close() {
+	s.stop()
+	<-s.done
+}
+
+func (s *sink) run() {
+	defer close(s.done)
+	defer s.w.close()
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, s.batchSize)
+	for {
+		select {
+		case entry, ok := <-s.queue:
+			if !ok {
+				s.flush(batch)
+				return
+			}
+			batch = append(batch, entry)
+			if len(batch) >= s.batchSize {
+				s.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			s.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (s *sink) flush(batch [][]byte) {
+	if len(batch) == 0 {
+		return
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			s.onFailure(len(batch), fmt.Errorf("panic: %v", p))
+		}
+	}()
+
+	if err := s.w.write(batch); err != nil {
+		s.onFailure(len(batch), err)
+	}
+}
+
+func (s *sink) onFailure(n int, err error) {
+	s.stats.failed.Add(uint64(n))
+	s.failures += n
+
+	now := time.Now()
+	if now.Sub(s.lastErrorLog) < errorLogInterval {
+		return
+	}
+	api.LogErrorf("failed to send %d access logs to %s, last error: %v", s.failures, s.kind, err)
+	s.lastErrorLog = now
+	s.failures = 0
+}
+
+// The logs written to the stdout by different sinks should not be interleaved
+var stdoutLock sync.Mutex
+
+type stdoutWriter struct {
+	out io.Writer
+}
+
+func newStdoutWriter() *stdoutWriter {
+	return &stdoutWriter{out: os.Stdout}
+}
+
+func (w *stdoutWriter) write(entries [][]byte) error {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		buf.Write(entry)
+		buf.WriteByte('\n')
+	}
+
+	stdoutLock.Lock()
+	defer stdoutLock.Unlock()
+	_, err := w.out.Write(buf.Bytes())
+	return err
+}
+
+func (w *stdoutWriter) close() {}
+
+const (
+	// the severity of the access log is informational
+	syslogSeverity = 6
+	// the timestamp in RFC 5424 allows up to 6 digits of fractional seconds
+	syslogTimeLayout = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// syslogWriter sends the logs in RFC 5424 format. Over TCP, the messages are framed with the
+// octet counting described in RFC 6587.
+type syslogWriter struct {
+	network  string
+	address  string
+	priority int
+	hostname string
+	appName  string
+	timeout  time.Duration
+
+	conn net.Conn
+}
+
+func (w *syslogWriter) write(entries [][]byte) error {
+	if w.conn == nil {
+		conn, err := net.DialTimeout(w.network, w.address, w.timeout)
+		if err != nil {
+			return err
+		}
+		w.conn = conn
+	}
+
+	now := time.Now().Format(syslogTimeLayout)
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		buf.Reset()
+		fmt.Fprintf(&buf, "<%d>1 %s %s %s - - - ", w.priority, now, w.hostname, w.appName)
+		buf.Write(entry)
+		msg := buf.Bytes()
+		if w.network == "tcp" {
+			msg = append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+		}
+
+		_ = w.conn.SetWriteDeadline(time.Now().Add(w.timeout))
+		if _, err := w.conn.Write(msg); err != nil {
+			// reconnect in the next write
+			w.conn.Close()
+			w.conn = nil
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *syslogWriter) close() {
+	if w.conn != nil {
+		w.conn.Close()
+		w.conn = nil
+	}
+}
+
+// httpWriter posts the logs in a batch, separated by newlines
+type httpWriter struct {
+	client      *http.Client
+	url         string
+	headers     map[string]string
+	contentType string
+}
+
+func (w *httpWriter) write(entries [][]byte) error {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		buf.Write(entry)
+		buf.WriteByte('\n')
+	}
+	return post(w.client, w.url, w.contentType, w.headers, &buf)
+}
+
+func (w *httpWriter) close() {
+	w.client.CloseIdleConnections()
+}
+
+// kafkaWriter produces the logs to Kafka. If the logs are in JSON format, the records are
+// embedded JSON, otherwise they are strings.
+type kafkaWriter struct {
+	producer *kafka.RESTProducer
+	json     bool
+}
+
+func (w *kafkaWriter) write(entries [][]byte) error {
+	records := make([]kafka.Record, len(entries))
+	for i, entry := range entries {
+		if w.json {
+			records[i].Value = json.RawMessage(entry)
+		} else {
+			records[i].Value = string(entry)
+		}
+	}
+	return w.producer.Produce(records)
+}
+
+func (w *kafkaWriter) close() {
+	w.producer.Close()
+}
+
+func post(client *http.Client, target string, contentType string, headers map[string]string, body io.Reader) error {
+	req, err := http.NewRequest(http.MethodPost, target, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("content-type", contentType)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.New("unexpected status code " + strconv.Itoa(resp.StatusCode))
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/plugins/pkg/kafka"
+)
+
+func TestHTTPSink(t *testing.T) {
+	bodies := make(chan string, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("content-type"))
+		assert.Equal(t, "key", r.Header.Get("x-api-key"))
+		body, _ := io.ReadAll(r.Body)
+		bodies <- string(body)
+	}))
+	defer srv.Close()
+
+	w := &httpWriter{
+		client:      srv.Client(),
+		url:         srv.URL,
+		headers:     map[string]string{"x-api-key": "key"},
+		contentType: "application/x-ndjson",
+	}
+	s := newSink("http", w, 2, time.Hour)
+	s.log([]byte(`{"a":1}`))
+	s.log([]byte(`{"a":2}`))
+	s.log([]byte(`{"a":3}`))
+	assert.Equal(t, "{\"a\":1}\n{\"a\":2}\n", <-bodies)
+	// the pending logs are flushed when the sink is closed
+	s.close()
+	assert.Equal(t, "{\"a\":3}\n", <-bodies)
+}
+
+func TestHTTPSinkFlushInterval(t *testing.T) {
+	bodies := make(chan string, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- string(body)
+	}))
+	defer srv.Close()
+
+	w := &httpWriter{client: srv.Client(), url: srv.URL, contentType: "text/plain"}
+	s := newSink("http", w, 100, 10*time.Millisecond)
+	defer s.close()
+	s.log([]byte("GET /"))
+	select {
+	case body := <-bodies:
+		assert.Equal(t, "GET /\n", body)
+	case <-time.After(time.Second):
+		t.Fatal("the logs are not flushed")
+	}
+}
+
+func TestKafkaSink(t *testing.T) {
+	bodies := make(chan string, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/topics/access-logs", r.URL.Path)
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("content-type"))
+		body, _ := io.ReadAll(r.Body)
+		bodies <- string(body)
+	}))
+	defer srv.Close()
+
+	s := newSink("kafka", &kafkaWriter{producer: kafka.NewRESTProducer(srv.Client(), srv.URL, "access-logs"), json: true}, 10, time.Hour)
+	s.log([]byte(`{"a":1}`))
+	s.log([]byte(`{"a":2}`))
+	s.close()
+	assert.Equal(t, `{"records":[{"value":{"a":1}},{"value":{"a":2}}]}`, <-bodies)
+
+	s = newSink("kafka", &kafkaWriter{producer: kafka.NewRESTProducer(srv.Client(), srv.URL, "access-logs")}, 10, time.Hour)
+	s.log([]byte(`GET "/"`))
+	s.close()
+	assert.Equal(t, `{"records":[{"value":"GET \"/\""}]}`, <-bodies)
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	msgs := make(chan string, 10)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			size, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(size))
+			buf := make([]byte, n)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			msgs <- string(buf)
+		}
+	}()
+
+	w := &syslogWriter{
+		network:  "tcp",
+		address:  ln.Addr().String(),
+		priority: 16*8 + syslogSeverity,
+		hostname: "gateway",
+		appName:  "htnn",
+		timeout:  time.Second,
+	}
+	s := newSink("syslog", w, 1, time.Hour)
+	s.log([]byte("GET / 200"))
+	s.log([]byte("GET /a b 404"))
+	s.close()
+
+	for _, log := range []string{"GET / 200", "GET /a b 404"} {
+		msg := <-msgs
+		assert.True(t, strings.HasPrefix(msg, "<134>1 "), msg)
+		assert.True(t, strings.HasSuffix(msg, " gateway htnn - - - "+log), msg)
+	}
+}
+
+func TestSyslogSinkUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	w := &syslogWriter{
+		network:  "udp",
+		address:  conn.LocalAddr().String(),
+		priority: 1*8 + syslogSeverity,
+		hostname: "gateway",
+		appName:  "app",
+		timeout:  time.Second,
+	}
+	s := newSink("syslog", w, 1, time.Hour)
+	s.log([]byte("GET / 200"))
+	s.close()
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	msg := string(buf[:n])
+	assert.True(t, strings.HasPrefix(msg, "<14>1 "), msg)
+	assert.True(t, strings.HasSuffix(msg, " gateway app - - - GET / 200"), msg)
+}
+
+type blockingWriter struct {
+	unblock chan struct{}
+}
+
+func (w *blockingWriter) write(entries [][]byte) error {
+	<-w.unblock
+	return io.ErrClosedPipe
+}
+
+func (w *blockingWriter) close() {}
+
+func TestSinkDropAndFail(t *testing.T) {
+	stats := getSinkStats("test")
+	dropped, failed := stats.dropped.Load(), stats.failed.Load()
+
+	w := &blockingWriter{unblock: make(chan struct{})}
+	s := newSink("test", w, 1, time.Hour)
+	for i := 0; i < queueSize+2; i++ {
+		s.log([]byte("log"))
+	}
+	// one is being sent, so at least one is dropped
+	assert.GreaterOrEqual(t, stats.dropped.Load()-dropped, uint64(1))
+	close(w.unblock)
+	s.close()
+	assert.Equal(t, uint64(queueSize+2), stats.dropped.Load()-dropped+stats.failed.Load()-failed)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeMetrics(&buf))
+	assert.Contains(t, buf.String(), "# TYPE htnn_access_log_dropped_total counter\n")
+	assert.Contains(t, buf.String(), "htnn_access_log_failed_total{sink=\"test\"} "+
+		strconv.FormatUint(stats.failed.Load(), 10)+"\n")
+}
+
+func TestStdoutWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &stdoutWriter{out: &buf}
+	require.NoError(t, w.write([][]byte{[]byte("a"), []byte("b")}))
+	assert.Equal(t, "a\nb\n", buf.String())
+}
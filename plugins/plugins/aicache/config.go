@@ -0,0 +1,110 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aicache
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/aicache"
+)
+
+const (
+	defaultTimeout             = 3 * time.Second
+	defaultSimilarityThreshold = 0.9
+	defaultTTL                 = time.Hour
+	defaultPrefix              = "htnn-ai-cache"
+	defaultMaxBodySize         = 1 << 20
+)
+
+func init() {
+	plugins.RegisterPlugin(aicache.Name, &plugin{})
+}
+
+type plugin struct {
+	aicache.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	aicache.CustomConfig
+
+	embedder  *embedder
+	store     store
+	timeout   time.Duration
+	threshold float64
+	ttl       time.Duration
+	// the request body larger than it is not inspected either
+	maxBodySize int
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.timeout = defaultTimeout
+	if conf.Embedding.Timeout != nil {
+		conf.timeout = conf.Embedding.Timeout.AsDuration()
+	}
+	conf.embedder = &embedder{
+		client: &http.Client{Timeout: conf.timeout},
+		url:    conf.Embedding.Url,
+		model:  conf.Embedding.Model,
+		apiKey: conf.Embedding.ApiKey,
+	}
+
+	r := conf.Redis
+	var tlsConfig *tls.Config
+	if r.Tls {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: r.TlsSkipVerify,
+		}
+	}
+	prefix := conf.Prefix
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+	conf.store = newRedisStore(redis.NewClient(&redis.Options{
+		Addr:      r.Address,
+		Username:  r.Username,
+		Password:  r.Password,
+		TLSConfig: tlsConfig,
+		// the reply of FT.SEARCH in RESP3 is not supported by go-redis yet
+		Protocol: 2,
+	}), prefix)
+
+	conf.threshold = defaultSimilarityThreshold
+	if conf.SimilarityThreshold > 0 {
+		conf.threshold = conf.SimilarityThreshold
+	}
+	conf.ttl = defaultTTL
+	if conf.Ttl != nil {
+		conf.ttl = conf.Ttl.AsDuration()
+	}
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+	return nil
+}
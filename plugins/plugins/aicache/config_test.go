@@ -0,0 +1,74 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aicache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "sanity",
+			input: `{"embedding":{"url":"http://tei.default:8080/v1/embeddings"},"redis":{"address":"127.0.0.1:6379"}}`,
+		},
+		{
+			name:  "embedding is required",
+			input: `{"redis":{"address":"127.0.0.1:6379"}}`,
+			err:   "invalid Config.Embedding: value is required",
+		},
+		{
+			name:  "bad embedding url",
+			input: `{"embedding":{"url":"/v1/embeddings"},"redis":{"address":"127.0.0.1:6379"}}`,
+			err:   "invalid Embedding.Url: value must be absolute",
+		},
+		{
+			name:  "bad redis address",
+			input: `{"embedding":{"url":"http://tei.default:8080/v1/embeddings"},"redis":{"address":"127.0.0.1"}}`,
+			err:   "bad address 127.0.0.1",
+		},
+		{
+			name:  "bad threshold",
+			input: `{"embedding":{"url":"http://tei.default:8080/v1/embeddings"},"redis":{"address":"127.0.0.1:6379"},"similarityThreshold":1.1}`,
+			err:   "invalid Config.SimilarityThreshold: value must be inside range [0, 1]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			err := conf.Validate()
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, conf.Init(nil))
+			assert.Equal(t, 0.9, conf.threshold)
+			assert.Equal(t, time.Hour, conf.ttl)
+			assert.Equal(t, "htnn-ai-cache", conf.store.(*redisStore).index)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aicache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// embedder calls the OpenAI-compatible embeddings API
+type embedder struct {
+	client *http.Client
+	url    string
+	model  string
+	apiKey string
+}
+
+type embeddingRequest struct {
+	Model string `json:"model,omitempty"`
+	Input string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (e *embedder) embed(ctx context.Context, text string) ([]float32, error) {
+	b, _ := json.Marshal(&embeddingRequest{Model: e.model, Input: text})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("content-type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, body)
+	}
+
+	var res embeddingResponse
+	if err := json.Unmarshal(body, &res); err != nil {
+		return nil, err
+	}
+	if len(res.Data) == 0 || len(res.Data[0].Embedding) == 0 {
+		return nil, errors.New("embedding is missing in the response")
+	}
+	return res.Data[0].Embedding, nil
+}
+
+// contentText returns the text of the message content, which is either a string or a list of
+// parts like `{"type":"text","text":"..."}`
+func contentText(content any) string {
+	switch c := content.(type) {
+	case string:
+		return c
+	case []any:
+		var texts []string
+		for _, part := range c {
+			if p, ok := part.(map[string]any); ok {
+				if text, ok := p["text"].(string); ok {
+					texts = append(texts, text)
+				}
+			}
+		}
+		return strings.Join(texts, "\n")
+	}
+	return ""
+}
+
+// promptText returns the text to embed for the chat completions and completions requests. The
+// whole conversation is used, so that the same question in different contexts is not mixed up.
+func promptText(body map[string]any) string {
+	if prompt, ok := body["prompt"].(string); ok {
+		return prompt
+	}
+	messages, _ := body["messages"].([]any)
+	var sb strings.Builder
+	for _, m := range messages {
+		msg, ok := m.(map[string]any)
+		if !ok {
+			continue
+		}
+		role, _ := msg["role"].(string)
+		text := contentText(msg["content"])
+		if text == "" {
+			continue
+		}
+		sb.WriteString(role)
+		sb.WriteString(": ")
+		sb.WriteString(text)
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,155 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aicache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+// cacheStatusHeader tells the client whether the response is from the cache
+const cacheStatusHeader = "x-htnn-ai-cache"
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	// set if the response should be cached
+	model  string
+	prompt string
+	vec    []float32
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	if endStream || headers.Method() != http.MethodPost {
+		return api.Continue
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	config := f.config
+	if data == nil || data.Len() == 0 || data.Len() > config.maxBodySize {
+		return api.Continue
+	}
+
+	var body map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data.Bytes()))
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		return api.Continue
+	}
+	if stream, _ := body["stream"].(bool); stream {
+		// the streaming responses are not cached
+		return api.Continue
+	}
+	prompt := promptText(body)
+	if prompt == "" {
+		return api.Continue
+	}
+	model, _ := body["model"].(string)
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.timeout)
+	defer cancel()
+	// the cache is best-effort, so the errors don't fail the request
+	vec, err := config.embedder.embed(ctx, prompt)
+	if err != nil {
+		api.LogErrorf("aiCache: failed to embed the prompt: %v", err)
+		return api.Continue
+	}
+	resp, err := config.store.search(ctx, model, vec, config.threshold)
+	if err != nil {
+		api.LogErrorf("aiCache: failed to search the cache: %v", err)
+		return api.Continue
+	}
+	if resp != nil {
+		hdr := http.Header{}
+		hdr.Set("content-type", "application/json")
+		hdr.Set(cacheStatusHeader, "HIT")
+		return &api.LocalResponse{Code: http.StatusOK, Msg: string(resp), Header: hdr}
+	}
+
+	f.model = model
+	f.prompt = prompt
+	f.vec = vec
+	return api.Continue
+}
+
+func (f *filter) cacheable(headers api.ResponseHeaderMap) bool {
+	if status, _ := headers.Get(":status"); status != "200" {
+		return false
+	}
+	if enc, ok := headers.Get("content-encoding"); ok && enc != "" && !strings.EqualFold(enc, "identity") {
+		return false
+	}
+	ct, _ := headers.Get("content-type")
+	mediaType, _, _ := mime.ParseMediaType(ct)
+	if mediaType != "application/json" {
+		return false
+	}
+	if cl, ok := headers.Get("content-length"); ok {
+		n, err := strconv.Atoi(cl)
+		if err == nil && n > f.config.maxBodySize {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.vec == nil {
+		return api.Continue
+	}
+	headers.Set(cacheStatusHeader, "MISS")
+	if endStream || !f.cacheable(headers) {
+		return api.Continue
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	config := f.config
+	if data == nil || data.Len() == 0 || data.Len() > config.maxBodySize {
+		return api.Continue
+	}
+
+	resp := bytes.Clone(data.Bytes())
+	model, prompt, vec := f.model, f.prompt, f.vec
+	// don't delay the response
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), config.timeout)
+		defer cancel()
+		if err := config.store.save(ctx, model, prompt, vec, resp, config.ttl); err != nil {
+			api.LogErrorf("aiCache: failed to save the response: %v", err)
+		}
+	}()
+	return api.Continue
+}
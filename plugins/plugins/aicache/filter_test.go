@@ -0,0 +1,193 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aicache
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type entry struct {
+	model string
+	vec   []float32
+	resp  []byte
+}
+
+type memoryStore struct {
+	lock    sync.Mutex
+	entries []*entry
+	saved   chan struct{}
+}
+
+func cosine(a, b []float32) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i] * b[i])
+		na += float64(a[i] * a[i])
+		nb += float64(b[i] * b[i])
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+func (s *memoryStore) search(ctx context.Context, model string, vec []float32, threshold float64) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, e := range s.entries {
+		if e.model == model && cosine(e.vec, vec) >= threshold {
+			return e.resp, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *memoryStore) save(ctx context.Context, model string, prompt string, vec []float32, resp []byte, ttl time.Duration) error {
+	s.lock.Lock()
+	s.entries = append(s.entries, &entry{model: model, vec: vec, resp: resp})
+	s.lock.Unlock()
+	s.saved <- struct{}{}
+	return nil
+}
+
+// embeddingServer embeds the text by the given vectors
+func embeddingServer(t *testing.T, vectors map[string][]float32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer key", r.Header.Get("authorization"))
+		var req embeddingRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "bge-m3", req.Model)
+		vec, ok := vectors[req.Input]
+		if !ok {
+			w.WriteHeader(500)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"data": []any{map[string]any{"embedding": vec}},
+		})
+	}))
+}
+
+func newConfig(t *testing.T, url string) (*config, *memoryStore) {
+	input := `{"embedding":{"url":"` + url + `","model":"bge-m3","apiKey":"key"},"redis":{"address":"127.0.0.1:6379"}}`
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+	require.NoError(t, conf.Init(nil))
+	s := &memoryStore{saved: make(chan struct{}, 1)}
+	conf.store = s
+	return conf, s
+}
+
+func ask(conf *config, model string, question string, upstream string) (api.ResultAction, api.ResponseHeaderMap) {
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	hdr := envoy.NewRequestHeaderMap(http.Header{":method": []string{"POST"}})
+	if res := f.DecodeHeaders(hdr, false); res != api.WaitAllData {
+		return res, nil
+	}
+	body, _ := json.Marshal(map[string]any{
+		"model":    model,
+		"messages": []any{map[string]any{"role": "user", "content": question}},
+	})
+	if res := f.DecodeRequest(hdr, envoy.NewBufferInstance(body), nil); res != api.Continue {
+		return res, nil
+	}
+
+	respHdr := envoy.NewResponseHeaderMap(http.Header{
+		":status":      []string{"200"},
+		"Content-Type": []string{"application/json"},
+	})
+	if res := f.EncodeHeaders(respHdr, false); res == api.WaitAllData {
+		f.EncodeResponse(respHdr, envoy.NewBufferInstance([]byte(upstream)), nil)
+	}
+	return api.Continue, respHdr
+}
+
+func TestSemanticCache(t *testing.T) {
+	srv := embeddingServer(t, map[string][]float32{
+		"user: What is HTNN?\n":              {1, 0, 0},
+		"user: What's HTNN?\n":               {0.99, 0.1, 0},
+		"user: How to install Envoy?\n":      {0, 1, 0},
+		"user: What is Envoy?\n":             {0.5, 0.5, 0.7},
+		"user: Which models do you serve?\n": {0, 0, 1},
+	})
+	defer srv.Close()
+	conf, s := newConfig(t, srv.URL)
+
+	res, respHdr := ask(conf, "qwen", "What is HTNN?", `{"id":"1"}`)
+	require.Equal(t, api.Continue, res)
+	status, _ := respHdr.Get(cacheStatusHeader)
+	assert.Equal(t, "MISS", status)
+	<-s.saved
+
+	// similar question
+	res, _ = ask(conf, "qwen", "What's HTNN?", `{"id":"2"}`)
+	resp, ok := res.(*api.LocalResponse)
+	require.True(t, ok, res)
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, `{"id":"1"}`, resp.Msg)
+	assert.Equal(t, "HIT", resp.Header.Get(cacheStatusHeader))
+
+	// different model
+	res, _ = ask(conf, "llama", "What's HTNN?", `{"id":"3"}`)
+	assert.Equal(t, api.Continue, res)
+	<-s.saved
+
+	// different question
+	res, _ = ask(conf, "qwen", "How to install Envoy?", `{"id":"4"}`)
+	assert.Equal(t, api.Continue, res)
+	<-s.saved
+
+	// the embedding fails
+	res, respHdr = ask(conf, "qwen", "Unknown", `{"id":"5"}`)
+	assert.Equal(t, api.Continue, res)
+	_, ok = respHdr.Get(cacheStatusHeader)
+	assert.False(t, ok)
+	assert.Len(t, s.entries, 3)
+}
+
+func TestSkipStream(t *testing.T) {
+	conf, _ := newConfig(t, "http://127.0.0.1:1/v1/embeddings")
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	hdr := envoy.NewRequestHeaderMap(http.Header{":method": []string{"POST"}})
+	require.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+	body := `{"model":"qwen","stream":true,"messages":[{"role":"user","content":"Hi"}]}`
+	require.Equal(t, api.Continue, f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(body)), nil))
+	assert.Nil(t, f.vec)
+}
+
+func TestPromptText(t *testing.T) {
+	assert.Equal(t, "Say hi", promptText(map[string]any{"prompt": "Say hi"}))
+	assert.Equal(t, "system: Be brief\nuser: Look\nat it\n", promptText(map[string]any{
+		"messages": []any{
+			map[string]any{"role": "system", "content": "Be brief"},
+			map[string]any{"role": "user", "content": []any{
+				map[string]any{"type": "text", "text": "Look"},
+				map[string]any{"type": "image_url", "image_url": map[string]any{"url": "http://x"}},
+				map[string]any{"type": "text", "text": "at it"},
+			}},
+		},
+	}))
+}
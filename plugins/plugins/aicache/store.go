@@ -0,0 +1,170 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aicache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type store interface {
+	// search returns the response cached for the most similar prompt of the model, or nil if the
+	// similarity is less than the threshold
+	search(ctx context.Context, model string, vec []float32, threshold float64) ([]byte, error)
+	// save caches the response of the prompt for the given duration
+	save(ctx context.Context, model string, prompt string, vec []float32, resp []byte, ttl time.Duration) error
+}
+
+// redisStore stores the cached responses as hashes, and finds them by the vector similarity
+// search of RediSearch
+type redisStore struct {
+	client *redis.Client
+	index  string
+	prefix string
+
+	indexed atomic.Bool
+}
+
+func newRedisStore(client *redis.Client, prefix string) *redisStore {
+	return &redisStore{
+		client: client,
+		index:  prefix,
+		prefix: prefix + "|",
+	}
+}
+
+// ensureIndex creates the index once the dimension of the vectors is known
+func (s *redisStore) ensureIndex(ctx context.Context, dim int) error {
+	if s.indexed.Load() {
+		return nil
+	}
+	err := s.client.Do(ctx, "FT.CREATE", s.index, "ON", "HASH", "PREFIX", "1", s.prefix,
+		"SCHEMA", "model", "TAG", "embedding", "VECTOR", "HNSW", "6",
+		"TYPE", "FLOAT32", "DIM", dim, "DISTANCE_METRIC", "COSINE").Err()
+	// the index may be created by other gateway instances
+	if err != nil && !strings.Contains(err.Error(), "Index already exists") {
+		return err
+	}
+	s.indexed.Store(true)
+	return nil
+}
+
+func vectorBytes(vec []float32) []byte {
+	b := make([]byte, 4*len(vec))
+	for i, v := range vec {
+		binary.LittleEndian.PutUint32(b[i*4:], math.Float32bits(v))
+	}
+	return b
+}
+
+// modelTag returns the value of the model TAG, which can't be empty
+func modelTag(model string) string {
+	if model == "" {
+		return "__none__"
+	}
+	return model
+}
+
+// escapeTag escapes the punctuation and spaces in the TAG query
+func escapeTag(tag string) string {
+	var sb strings.Builder
+	for _, r := range tag {
+		if !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// parseSearchResult parses the RESP2 reply of FT.SEARCH, which is like
+// `[total, key, [field, value, ...]]`. It returns the cosine distance and the response.
+func parseSearchResult(res []interface{}) (float64, []byte, error) {
+	if len(res) < 3 {
+		return 0, nil, nil
+	}
+	fields, ok := res[2].([]interface{})
+	if !ok {
+		return 0, nil, errors.New("unexpected reply of FT.SEARCH")
+	}
+	var (
+		distance = -1.0
+		resp     []byte
+	)
+	for i := 0; i+1 < len(fields); i += 2 {
+		name, _ := fields[i].(string)
+		value, _ := fields[i+1].(string)
+		switch name {
+		case "score":
+			d, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return 0, nil, fmt.Errorf("bad score %s: %w", value, err)
+			}
+			distance = d
+		case "response":
+			resp = []byte(value)
+		}
+	}
+	if distance < 0 || resp == nil {
+		return 0, nil, errors.New("unexpected reply of FT.SEARCH")
+	}
+	return distance, resp, nil
+}
+
+func (s *redisStore) search(ctx context.Context, model string, vec []float32, threshold float64) ([]byte, error) {
+	if err := s.ensureIndex(ctx, len(vec)); err != nil {
+		return nil, err
+	}
+	query := fmt.Sprintf("(@model:{%s})=>[KNN 1 @embedding $vec AS score]", escapeTag(modelTag(model)))
+	res, err := s.client.Do(ctx, "FT.SEARCH", s.index, query, "PARAMS", "2", "vec", vectorBytes(vec),
+		"RETURN", "2", "score", "response", "DIALECT", "2").Slice()
+	if err != nil {
+		return nil, err
+	}
+	distance, resp, err := parseSearchResult(res)
+	if err != nil || resp == nil {
+		return nil, err
+	}
+	// the cosine distance is `1 - cosine similarity`
+	if 1-distance < threshold {
+		return nil, nil
+	}
+	return resp, nil
+}
+
+func (s *redisStore) save(ctx context.Context, model string, prompt string, vec []float32, resp []byte, ttl time.Duration) error {
+	if err := s.ensureIndex(ctx, len(vec)); err != nil {
+		return err
+	}
+	sum := sha256.Sum256([]byte(model + "\n" + prompt))
+	key := s.prefix + hex.EncodeToString(sum[:])
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "model", modelTag(model), "embedding", vectorBytes(vec), "response", resp)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
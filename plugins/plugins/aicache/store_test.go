@@ -0,0 +1,47 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aicache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSearchResult(t *testing.T) {
+	distance, resp, err := parseSearchResult([]interface{}{int64(0)})
+	require.NoError(t, err)
+	assert.Nil(t, resp)
+
+	distance, resp, err = parseSearchResult([]interface{}{
+		int64(1), "htnn-ai-cache|abc", []interface{}{"score", "0.05", "response", `{"id":"1"}`},
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.05, distance, 1e-9)
+	assert.Equal(t, `{"id":"1"}`, string(resp))
+
+	_, _, err = parseSearchResult([]interface{}{int64(1), "htnn-ai-cache|abc", []interface{}{"score", "x"}})
+	assert.ErrorContains(t, err, "bad score x")
+}
+
+func TestModelTag(t *testing.T) {
+	assert.Equal(t, "__none__", escapeTag(modelTag("")))
+	assert.Equal(t, `Qwen\/Qwen2\.5\-7B`, escapeTag(modelTag("Qwen/Qwen2.5-7B")))
+}
+
+func TestVectorBytes(t *testing.T) {
+	assert.Equal(t, []byte{0, 0, 0x80, 0x3f, 0, 0, 0, 0xc0}, vectorBytes([]float32{1, -2}))
+}
@@ -0,0 +1,137 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aiproxy
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/aiproxy"
+)
+
+const (
+	defaultMaxBodySize     = 8 << 20
+	defaultOpenAIURL       = "https://api.openai.com"
+	defaultAzureAPIVersion = "2024-06-01"
+)
+
+func init() {
+	plugins.RegisterPlugin(aiproxy.Name, &plugin{})
+}
+
+type plugin struct {
+	aiproxy.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	aiproxy.CustomConfig
+
+	providers   []*provider
+	maxBodySize int
+}
+
+func parseURL(s string) (host string, basePath string, err error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", "", err
+	}
+	if u.Host == "" {
+		return "", "", fmt.Errorf("bad url %s: host is required", s)
+	}
+	return u.Host, strings.TrimSuffix(u.Path, "/"), nil
+}
+
+func newProvider(p *aiproxy.Provider) (*provider, error) {
+	var (
+		rawURL string
+		tr     translator
+	)
+	switch t := p.Type.(type) {
+	case *aiproxy.Provider_Openai:
+		rawURL = t.Openai.Url
+		if rawURL == "" {
+			rawURL = defaultOpenAIURL
+		}
+	case *aiproxy.Provider_Azure:
+		rawURL = t.Azure.Url
+	case *aiproxy.Provider_Vllm:
+		rawURL = t.Vllm.Url
+	}
+
+	host, basePath, err := parseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if azure := p.GetAzure(); azure != nil {
+		apiVersion := defaultAzureAPIVersion
+		if azure.ApiVersion != "" {
+			apiVersion = azure.ApiVersion
+		}
+		tr = &azureTranslator{basePath: basePath, apiVersion: apiVersion}
+	} else {
+		tr = &openAITranslator{basePath: basePath}
+	}
+
+	models := make(map[string]bool, len(p.Models))
+	for _, m := range p.Models {
+		models[m] = true
+	}
+	return &provider{
+		name:         p.Name,
+		models:       models,
+		modelMapping: p.ModelMapping,
+		apiKey:       p.ApiKey,
+		host:         host,
+		translator:   tr,
+	}, nil
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.providers = make([]*provider, 0, len(conf.Providers))
+	for _, p := range conf.Providers {
+		prov, err := newProvider(p)
+		if err != nil {
+			return fmt.Errorf("bad provider %s: %w", p.Name, err)
+		}
+		conf.providers = append(conf.providers, prov)
+	}
+
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+	return nil
+}
+
+// selectProvider returns the first provider which serves the model
+func (conf *config) selectProvider(model string) *provider {
+	for _, p := range conf.providers {
+		if p.serves(model) {
+			return p
+		}
+	}
+	return nil
+}
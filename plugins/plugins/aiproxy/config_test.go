@@ -0,0 +1,85 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aiproxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+		host  string
+	}{
+		{
+			name:  "default OpenAI url",
+			input: `{"providers":[{"name":"openai","openai":{}}]}`,
+			host:  "api.openai.com",
+		},
+		{
+			name:  "vLLM",
+			input: `{"providers":[{"name":"local","vllm":{"url":"http://vllm.default:8000"}}]}`,
+			host:  "vllm.default:8000",
+		},
+		{
+			name:  "providers are required",
+			input: `{}`,
+			err:   "invalid Config.Providers: value must contain at least 1 item(s)",
+		},
+		{
+			name:  "type is required",
+			input: `{"providers":[{"name":"openai"}]}`,
+			err:   "invalid Provider.Type: value is required",
+		},
+		{
+			name:  "azure url is required",
+			input: `{"providers":[{"name":"azure","azure":{}}]}`,
+			err:   "invalid AzureOpenAI.Url: value must be absolute",
+		},
+		{
+			name:  "duplicate provider",
+			input: `{"providers":[{"name":"openai","openai":{}},{"name":"openai","vllm":{"url":"http://vllm.default:8000"}}]}`,
+			err:   "duplicate provider openai",
+		},
+		{
+			name:  "host is required",
+			input: `{"providers":[{"name":"local","vllm":{"url":"http:vllm"}}]}`,
+			err:   "bad provider local: bad url http:vllm: host is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			err := conf.Validate()
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.host, conf.providers[0].host)
+		})
+	}
+}
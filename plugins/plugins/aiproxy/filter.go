@@ -0,0 +1,154 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aiproxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/aiproxy"
+)
+
+// providerHeader carries the selected provider, so that the request can be routed by it
+const providerHeader = "x-htnn-ai-provider"
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	endpoint string
+}
+
+type apiError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+	Code    string `json:"code,omitempty"`
+}
+
+// errorResponse replies the error in the format of OpenAI API, so that the SDKs can handle it
+func errorResponse(status int, code string, msg string) *api.LocalResponse {
+	body, _ := json.Marshal(map[string]*apiError{
+		"error": {
+			Message: msg,
+			Type:    "invalid_request_error",
+			Code:    code,
+		},
+	})
+	return &api.LocalResponse{
+		Code:   status,
+		Msg:    string(body),
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+	}
+}
+
+func matchEndpoint(path string) string {
+	for _, ep := range endpoints {
+		if strings.HasSuffix(path, "/"+ep) {
+			return ep
+		}
+	}
+	return ""
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	path := headers.URL().Path
+	f.endpoint = matchEndpoint(path)
+	if f.endpoint == "" {
+		return errorResponse(http.StatusNotFound, "unknown_url", "unknown API "+path)
+	}
+	if headers.Method() != http.MethodPost {
+		return errorResponse(http.StatusMethodNotAllowed, "", "method "+headers.Method()+" is not allowed")
+	}
+	if endStream {
+		return errorResponse(http.StatusBadRequest, "", "request body is required")
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	if data == nil || data.Len() == 0 {
+		return errorResponse(http.StatusBadRequest, "", "request body is required")
+	}
+	config := f.config
+	if data.Len() > config.maxBodySize {
+		return errorResponse(http.StatusRequestEntityTooLarge, "", "request body is too large")
+	}
+
+	// keep the fields unknown to us as they are
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(data.Bytes(), &body); err != nil {
+		api.LogInfof("aiProxy: failed to decode request body: %v", err)
+		return errorResponse(http.StatusBadRequest, "", "bad JSON body")
+	}
+	var model string
+	if raw, ok := body["model"]; ok {
+		if err := json.Unmarshal(raw, &model); err != nil {
+			return errorResponse(http.StatusBadRequest, "", "model should be a string")
+		}
+	}
+	if model == "" {
+		return errorResponse(http.StatusBadRequest, "", "model is required")
+	}
+
+	p := config.selectProvider(model)
+	if p == nil {
+		return errorResponse(http.StatusNotFound, "model_not_found", "model "+model+" does not exist")
+	}
+
+	upstreamModel := p.upstreamModel(model)
+	if upstreamModel != model {
+		body["model"], _ = json.Marshal(upstreamModel)
+		b, err := json.Marshal(body)
+		if err != nil {
+			api.LogErrorf("aiProxy: failed to encode request body: %v", err)
+			return errorResponse(http.StatusInternalServerError, "", "failed to encode request body")
+		}
+		_ = data.Set(b)
+		headers.Set("content-length", strconv.Itoa(len(b)))
+	}
+
+	// the credential of the client should not be leaked to the provider
+	headers.Del("authorization")
+	headers.Del("api-key")
+	if p.apiKey != "" {
+		p.translator.setAPIKey(headers, p.apiKey)
+	}
+	headers.SetHost(p.host)
+	headers.SetPath(p.translator.path(f.endpoint, upstreamModel))
+	headers.Set(providerHeader, p.name)
+
+	state := f.callbacks.PluginState()
+	state.Set(aiproxy.Name, "provider", p.name)
+	state.Set(aiproxy.Name, "model", model)
+
+	if len(config.providers) > 1 {
+		// route the request by the provider header
+		f.callbacks.ClearRouteCache()
+	}
+	// the response, including the server-sent events, is passed through without buffering
+	return api.Continue
+}
@@ -0,0 +1,206 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aiproxy
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+const providers = `{"providers":[
+	{"name":"azure","models":["gpt-4o"],"modelMapping":{"gpt-4o":"gpt4o-prod"},"apiKey":"azure-key",
+	 "azure":{"url":"https://res.openai.azure.com/"}},
+	{"name":"local","models":["qwen"],"modelMapping":{"qwen":"Qwen2-72B-Instruct"},
+	 "vllm":{"url":"http://vllm.default:8000"}},
+	{"name":"openai","apiKey":"sk-xxx","openai":{}}
+]}`
+
+func TestProxy(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		hdr      map[string]string
+		model    string
+		provider string
+	}{
+		{
+			name: "azure",
+			path: "/v1/chat/completions",
+			body: `{"model":"gpt-4o","stream":true,"messages":[{"role":"user","content":"hi"}]}`,
+			hdr: map[string]string{
+				":authority":    "res.openai.azure.com",
+				":path":         "/openai/deployments/gpt4o-prod/chat/completions?api-version=2024-06-01",
+				"api-key":       "azure-key",
+				"authorization": "",
+			},
+			model:    "gpt4o-prod",
+			provider: "azure",
+		},
+		{
+			name: "vLLM without api key",
+			path: "/v1/completions",
+			body: `{"model":"qwen","prompt":"hi"}`,
+			hdr: map[string]string{
+				":authority":    "vllm.default:8000",
+				":path":         "/v1/completions",
+				"authorization": "",
+			},
+			model:    "Qwen2-72B-Instruct",
+			provider: "local",
+		},
+		{
+			name: "fallback to OpenAI",
+			path: "/openai/v1/embeddings?x=1",
+			body: `{"model":"text-embedding-3-small","input":"hi"}`,
+			hdr: map[string]string{
+				":authority":    "api.openai.com",
+				":path":         "/v1/embeddings",
+				"authorization": "Bearer sk-xxx",
+			},
+			model:    "text-embedding-3-small",
+			provider: "openai",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewFilterCallbackHandler()
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(providers), conf))
+			require.NoError(t, conf.Init(nil))
+			f := factory(conf, cb)
+			hdr := envoy.NewRequestHeaderMap(http.Header{
+				":authority":    []string{"gateway.local"},
+				":method":       []string{"POST"},
+				":path":         []string{tt.path},
+				"Authorization": []string{"Bearer client-key"},
+			})
+			require.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+			buf := envoy.NewBufferInstance([]byte(tt.body))
+			require.Equal(t, api.Continue, f.DecodeRequest(hdr, buf, nil))
+
+			for k, v := range tt.hdr {
+				actual, _ := hdr.Get(k)
+				assert.Equal(t, v, actual, k)
+			}
+			actual, _ := hdr.Get(providerHeader)
+			assert.Equal(t, tt.provider, actual)
+
+			var body map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &body))
+			assert.Equal(t, tt.model, body["model"])
+			assert.Equal(t, tt.provider, cb.PluginState().Get("aiProxy", "provider"))
+		})
+	}
+}
+
+func TestReject(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		method string
+		path   string
+		body   string
+		code   int
+		msg    string
+	}{
+		{
+			name:   "unknown API",
+			method: "GET",
+			path:   "/v1/models",
+			code:   404,
+			msg:    "unknown API /v1/models",
+		},
+		{
+			name:   "bad method",
+			method: "GET",
+			path:   "/v1/chat/completions",
+			code:   405,
+			msg:    "method GET is not allowed",
+		},
+		{
+			name: "bad body",
+			body: `{"model":`,
+			code: 400,
+			msg:  "bad JSON body",
+		},
+		{
+			name: "model is required",
+			body: `{"messages":[]}`,
+			code: 400,
+			msg:  "model is required",
+		},
+		{
+			name:  "model not found",
+			input: `{"providers":[{"name":"local","models":["qwen"],"vllm":{"url":"http://vllm.default:8000"}}]}`,
+			body:  `{"model":"gpt-4o"}`,
+			code:  404,
+			msg:   "model gpt-4o does not exist",
+		},
+		{
+			name:  "body too large",
+			input: `{"maxBodySize":10,"providers":[{"name":"openai","openai":{}}]}`,
+			body:  `{"model":"gpt-4o","messages":[]}`,
+			code:  413,
+			msg:   "request body is too large",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.input
+			if input == "" {
+				input = providers
+			}
+			method := tt.method
+			if method == "" {
+				method = "POST"
+			}
+			path := tt.path
+			if path == "" {
+				path = "/v1/chat/completions"
+			}
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+			require.NoError(t, conf.Init(nil))
+			f := factory(conf, envoy.NewFilterCallbackHandler())
+			hdr := envoy.NewRequestHeaderMap(http.Header{
+				":method": []string{method},
+				":path":   []string{path},
+			})
+
+			res := f.DecodeHeaders(hdr, false)
+			if res == api.WaitAllData {
+				res = f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(tt.body)), nil)
+			}
+			resp, ok := res.(*api.LocalResponse)
+			require.True(t, ok, res)
+			assert.Equal(t, tt.code, resp.Code)
+
+			var body map[string]*apiError
+			require.NoError(t, json.Unmarshal([]byte(resp.Msg), &body))
+			assert.Equal(t, tt.msg, body["error"].Message)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aiproxy
+
+import (
+	"net/url"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+// endpoints are the OpenAI APIs which can be proxied. As `completions` is the suffix of
+// `chat/completions`, the longer one should be matched first.
+var endpoints = []string{
+	"chat/completions",
+	"completions",
+	"embeddings",
+}
+
+// translator translates the OpenAI-compatible request into the provider's one
+type translator interface {
+	// path returns the path of the given API, like `chat/completions`, in the provider
+	path(endpoint string, model string) string
+	// setAPIKey sets the API key in the way the provider expects
+	setAPIKey(headers api.RequestHeaderMap, key string)
+}
+
+// openAITranslator is used by OpenAI and the OpenAI-compatible servers like vLLM
+type openAITranslator struct {
+	basePath string
+}
+
+func (t *openAITranslator) path(endpoint string, model string) string {
+	return t.basePath + "/v1/" + endpoint
+}
+
+func (t *openAITranslator) setAPIKey(headers api.RequestHeaderMap, key string) {
+	headers.Set("authorization", "Bearer "+key)
+}
+
+// azureTranslator addresses the model by the deployment name in the path
+type azureTranslator struct {
+	basePath   string
+	apiVersion string
+}
+
+func (t *azureTranslator) path(endpoint string, model string) string {
+	return t.basePath + "/openai/deployments/" + url.PathEscape(model) + "/" + endpoint +
+		"?api-version=" + url.QueryEscape(t.apiVersion)
+}
+
+func (t *azureTranslator) setAPIKey(headers api.RequestHeaderMap, key string) {
+	headers.Set("api-key", key)
+}
+
+type provider struct {
+	name         string
+	models       map[string]bool
+	modelMapping map[string]string
+	apiKey       string
+	host         string
+	translator   translator
+}
+
+func (p *provider) serves(model string) bool {
+	return len(p.models) == 0 || p.models[model]
+}
+
+// upstreamModel returns the model name used in the provider
+func (p *provider) upstreamModel(model string) string {
+	if m, ok := p.modelMapping[model]; ok {
+		return m
+	}
+	return model
+}
@@ -0,0 +1,109 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aitokenlimit
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/redis/go-redis/v9"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/aitokenlimit"
+)
+
+const (
+	defaultPrefix = "htnn-ai-token-limit"
+	// the request and the response larger than it are not inspected
+	maxBodySize = 8 << 20
+)
+
+func init() {
+	plugins.RegisterPlugin(aitokenlimit.Name, &plugin{})
+}
+
+type plugin struct {
+	aitokenlimit.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type limiter struct {
+	script expr.Script
+	tokens uint64
+	window int64
+	prefix string
+}
+
+type config struct {
+	aitokenlimit.CustomConfig
+
+	store    store
+	limiters []*limiter
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	var tlsConfig *tls.Config
+	if conf.Tls {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: conf.TlsSkipVerify,
+		}
+	}
+
+	var client redis.UniversalClient
+	if cluster := conf.GetCluster(); cluster != nil {
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     cluster.Addresses,
+			Username:  conf.Username,
+			Password:  conf.Password,
+			TLSConfig: tlsConfig,
+		})
+	} else {
+		client = redis.NewClient(&redis.Options{
+			Addr:      conf.GetAddress(),
+			Username:  conf.Username,
+			Password:  conf.Password,
+			TLSConfig: tlsConfig,
+		})
+	}
+	conf.store = &redisStore{client: client}
+
+	prefix := conf.Prefix
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+	conf.limiters = make([]*limiter, len(conf.Rules))
+	for i, rule := range conf.Rules {
+		conf.limiters[i] = &limiter{
+			tokens: rule.Tokens,
+			window: rule.TimeWindow.Seconds,
+			prefix: fmt.Sprintf("%s|%d", prefix, i),
+		}
+		if rule.Key != "" {
+			script, _ := expr.CompileCel(rule.Key, cel.StringType)
+			conf.limiters[i].script = script
+		}
+	}
+	return nil
+}
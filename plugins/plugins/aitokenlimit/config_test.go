@@ -0,0 +1,90 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aitokenlimit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "sanity",
+			input: `{"address":"127.0.0.1:6379","rules":[{"timeWindow":"60s","tokens":1000}]}`,
+		},
+		{
+			name:  "cluster",
+			input: `{"cluster":{"addresses":["127.0.0.1:6379"]},"rules":[{"timeWindow":"86400s","tokens":1000,"key":"request.header('x-tenant')"}]}`,
+		},
+		{
+			name:  "source is required",
+			input: `{"rules":[{"timeWindow":"60s","tokens":1000}]}`,
+			err:   "invalid Config.Source: value is required",
+		},
+		{
+			name:  "bad address",
+			input: `{"address":"127.0.0.1","rules":[{"timeWindow":"60s","tokens":1000}]}`,
+			err:   "bad address 127.0.0.1",
+		},
+		{
+			name:  "rules are required",
+			input: `{"address":"127.0.0.1:6379"}`,
+			err:   "invalid Config.Rules: value must contain between 1 and 8 items, inclusive",
+		},
+		{
+			name:  "bad window",
+			input: `{"address":"127.0.0.1:6379","rules":[{"timeWindow":"0.1s","tokens":1000}]}`,
+			err:   "invalid Rule.TimeWindow: value must be greater than or equal to 1s",
+		},
+		{
+			name:  "bad tokens",
+			input: `{"address":"127.0.0.1:6379","rules":[{"timeWindow":"60s"}]}`,
+			err:   "invalid Rule.Tokens: value must be greater than or equal to 1",
+		},
+		{
+			name:  "bad key",
+			input: `{"address":"127.0.0.1:6379","rules":[{"timeWindow":"60s","tokens":1000,"key":"1 + 1"}]}`,
+			err:   "bad rule 0",
+		},
+		{
+			name:  "password is required",
+			input: `{"address":"127.0.0.1:6379","username":"user","rules":[{"timeWindow":"60s","tokens":1000}]}`,
+			err:   "password is required when username is set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			err := conf.Validate()
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, conf.Init(nil))
+			assert.Equal(t, "htnn-ai-token-limit|0", conf.limiters[0].prefix)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aitokenlimit
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+
+	"mosn.io/htnn/plugins/pkg/sse"
+)
+
+// estimateTokens approximates the number of tokens in the text when the provider doesn't report
+// the usage. An English token is about 4 characters, while a CJK character is about one token.
+func estimateTokens(s string) int64 {
+	var ascii, others int64
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			others++
+		}
+	}
+	return (ascii+3)/4 + others
+}
+
+// estimateText sums the tokens of all the strings in the JSON value
+func estimateText(v any) int64 {
+	switch v := v.(type) {
+	case string:
+		return estimateTokens(v)
+	case []any:
+		var n int64
+		for _, e := range v {
+			n += estimateText(e)
+		}
+		return n
+	case map[string]any:
+		var n int64
+		// skip the fields like `role` and `type`
+		for _, k := range []string{"content", "text"} {
+			n += estimateText(v[k])
+		}
+		return n
+	}
+	return 0
+}
+
+// estimatePrompt approximates the prompt tokens of the chat completions, completions and
+// embeddings requests
+func estimatePrompt(body map[string]any) int64 {
+	var n int64
+	for _, field := range []string{"messages", "prompt", "input"} {
+		if v, ok := body[field]; ok {
+			n += estimateText(v)
+		}
+	}
+	return n
+}
+
+type usage struct {
+	TotalTokens int64 `json:"total_tokens"`
+}
+
+type choice struct {
+	Text    string `json:"text"`
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	Delta struct {
+		Content string `json:"content"`
+	} `json:"delta"`
+}
+
+type response struct {
+	Usage   *usage    `json:"usage"`
+	Choices []*choice `json:"choices"`
+}
+
+// counter counts the tokens in the response
+type counter struct {
+	// the total tokens reported by the provider
+	total int64
+	// the estimated completion tokens, used when the usage is not reported
+	completion int64
+
+	parser sse.Parser
+}
+
+func (c *counter) onResponse(data []byte) {
+	var resp response
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return
+	}
+	if resp.Usage != nil && resp.Usage.TotalTokens > 0 {
+		c.total = resp.Usage.TotalTokens
+	}
+	for _, ch := range resp.Choices {
+		c.completion += estimateTokens(ch.Text) + estimateTokens(ch.Message.Content) +
+			estimateTokens(ch.Delta.Content)
+	}
+}
+
+// onEvents parses the server-sent events. The data may end in the middle of an event.
+func (c *counter) onEvents(data []byte) {
+	events, _ := c.parser.Feed(data)
+	for _, ev := range events {
+		payload := strings.TrimSpace(ev.Data)
+		if len(payload) == 0 || payload[0] != '{' {
+			// like `[DONE]`
+			continue
+		}
+		c.onResponse([]byte(payload))
+	}
+}
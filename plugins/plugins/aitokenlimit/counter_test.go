@@ -0,0 +1,57 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aitokenlimit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, int64(0), estimateTokens(""))
+	assert.Equal(t, int64(3), estimateTokens("Hello, world"))
+	assert.Equal(t, int64(4), estimateTokens("你好世界"))
+	assert.Equal(t, int64(6), estimatePrompt(map[string]any{
+		"messages": []any{
+			map[string]any{"role": "system", "content": "Be brief"},
+			map[string]any{"role": "user", "content": []any{
+				map[string]any{"type": "text", "text": "你好世界"},
+			}},
+		},
+	}))
+}
+
+func TestCountEvents(t *testing.T) {
+	c := &counter{}
+	c.onEvents([]byte("data: {\"choices\":[{\"delta\":{\"content\":\"Hello, \"}}]}\n\ndata: {\"choi"))
+	c.onEvents([]byte("ces\":[{\"delta\":{\"content\":\"world\"}}],\"usage\":null}\n\n"))
+	assert.Equal(t, int64(0), c.total)
+	assert.Equal(t, int64(4), c.completion)
+
+	c.onEvents([]byte(": ping\n\ndata: {\"choices\":[],\"usage\":{\"prompt_tokens\":9,\"completion_tokens\":2,\"total_tokens\":11}}\n\ndata: [DONE]\n\n"))
+	assert.Equal(t, int64(11), c.total)
+}
+
+func TestCountResponse(t *testing.T) {
+	c := &counter{}
+	c.onResponse([]byte(`{"choices":[{"message":{"role":"assistant","content":"Hello, world"}}],"usage":{"total_tokens":20}}`))
+	assert.Equal(t, int64(20), c.total)
+	assert.Equal(t, int64(3), c.completion)
+
+	c = &counter{}
+	c.onResponse([]byte(`not json`))
+	assert.Equal(t, int64(0), c.total)
+}
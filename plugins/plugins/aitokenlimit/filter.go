@@ -0,0 +1,256 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aitokenlimit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/sse"
+	"mosn.io/htnn/types/pkg/expr"
+)
+
+// the timeout to record the consumed tokens, which is done after the response is sent
+const consumeTimeout = 5 * time.Second
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	keys    []string
+	expires []time.Duration
+	// the quota of the most restrictive rule
+	limit     uint64
+	remaining int64
+	reset     int64
+
+	prompt       int64
+	status       int
+	stream       bool
+	body         []byte
+	bodyTooLarge bool
+	counter      counter
+}
+
+func (f *filter) getKey(script expr.Script, headers api.RequestHeaderMap) string {
+	var key string
+	if script != nil {
+		res, err := script.EvalWithRequest(f.callbacks, headers)
+		if err == nil {
+			key = res.(string)
+		}
+		if key == "" {
+			api.LogInfo("aiTokenLimit filter uses client IP as key because the configured key is empty")
+		}
+	} else if consumer := f.callbacks.GetConsumer(); consumer != nil {
+		key = consumer.Name()
+	}
+	if key == "" {
+		key = f.callbacks.StreamInfo().DownstreamRemoteParsedAddress().IP
+	}
+	return key
+}
+
+func (f *filter) limitErr(err error) api.ResultAction {
+	config := f.config
+	api.LogErrorf("failed to limit tokens: %v", err)
+
+	if config.FailureModeDeny {
+		status := 500
+		if config.StatusOnError != 0 {
+			status = int(config.StatusOnError)
+		}
+		return &api.LocalResponse{Code: status}
+	}
+	return api.Continue
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	// The windows are aligned to the Unix epoch, so that the limiters in different Envoy
+	// instances share the same window. For example, the daily budget is reset at 00:00 UTC.
+	now := time.Now().Unix()
+	n := len(config.limiters)
+	keys := make([]string, n)
+	expires := make([]time.Duration, n)
+	resets := make([]int64, n)
+	for i, l := range config.limiters {
+		start := now - now%l.window
+		keys[i] = fmt.Sprintf("%s|%s|%d", l.prefix, f.getKey(l.script, headers), start)
+		resets[i] = start + l.window - now
+		expires[i] = time.Duration(resets[i]) * time.Second
+	}
+
+	used, err := config.store.used(context.Background(), keys)
+	if err != nil {
+		return f.limitErr(err)
+	}
+	f.keys = keys
+	f.expires = expires
+
+	f.remaining = -1
+	for i, l := range config.limiters {
+		remaining := int64(l.tokens) - used[i]
+		if remaining <= 0 {
+			hdr := http.Header{}
+			hdr.Set("x-envoy-ratelimited", "true")
+			hdr.Set("retry-after", strconv.FormatInt(resets[i], 10))
+			status := 429
+			if config.RateLimitedStatus >= 400 {
+				status = int(config.RateLimitedStatus)
+			}
+			return &api.LocalResponse{Code: status, Msg: "token budget is exhausted", Header: hdr}
+		}
+		if f.remaining < 0 || remaining < f.remaining {
+			f.limit = l.tokens
+			f.remaining = remaining
+			f.reset = resets[i]
+		}
+	}
+
+	// so that the usage in the response can be read
+	headers.Del("accept-encoding")
+	if endStream {
+		return api.Continue
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	if data == nil || data.Len() == 0 || data.Len() > maxBodySize {
+		return api.Continue
+	}
+
+	var body map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data.Bytes()))
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		// leave the bad request to the provider
+		return api.Continue
+	}
+	f.prompt = estimatePrompt(body)
+
+	if stream, _ := body["stream"].(bool); !stream {
+		return api.Continue
+	}
+	// ask the provider to report the usage in the last event of the stream
+	opts, _ := body["stream_options"].(map[string]any)
+	if include, _ := opts["include_usage"].(bool); include {
+		return api.Continue
+	}
+	if opts == nil {
+		opts = map[string]any{}
+	}
+	opts["include_usage"] = true
+	body["stream_options"] = opts
+	b, err := json.Marshal(body)
+	if err != nil {
+		api.LogErrorf("aiTokenLimit: failed to encode request body: %v", err)
+		return api.Continue
+	}
+	_ = data.Set(b)
+	headers.Set("content-length", strconv.Itoa(len(b)))
+	return api.Continue
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if s, ok := headers.Get(":status"); ok {
+		f.status, _ = strconv.Atoi(s)
+	}
+	f.stream = sse.IsEventStream(headers)
+
+	if f.config.EnableLimitQuotaHeaders && f.keys != nil {
+		// the names follow the ones used by OpenAI
+		headers.Set("x-ratelimit-limit-tokens", strconv.FormatUint(f.limit, 10))
+		headers.Set("x-ratelimit-remaining-tokens", strconv.FormatInt(f.remaining, 10))
+		headers.Set("x-ratelimit-reset-tokens", strconv.FormatInt(f.reset, 10)+"s")
+	}
+	return api.Continue
+}
+
+func (f *filter) EncodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	if f.keys == nil || f.status < 200 || f.status >= 300 {
+		return api.Continue
+	}
+
+	if f.stream {
+		f.counter.onEvents(data.Bytes())
+		return api.Continue
+	}
+
+	if f.bodyTooLarge {
+		return api.Continue
+	}
+	if len(f.body)+data.Len() > maxBodySize {
+		api.LogInfo("aiTokenLimit: response body is too large to count the tokens")
+		f.bodyTooLarge = true
+		f.body = nil
+		return api.Continue
+	}
+	f.body = append(f.body, data.Bytes()...)
+	if endStream {
+		f.counter.onResponse(f.body)
+		f.body = nil
+	}
+	return api.Continue
+}
+
+// tokens returns the tokens consumed by the request. If the provider doesn't report the usage,
+// like the stream is interrupted, the tokens are estimated.
+func (f *filter) tokens() int64 {
+	if f.counter.total > 0 {
+		return f.counter.total
+	}
+	return f.prompt + f.counter.completion
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	if f.keys == nil || f.status < 200 || f.status >= 300 {
+		return
+	}
+	tokens := f.tokens()
+	if tokens <= 0 {
+		return
+	}
+
+	st := f.config.store
+	keys := f.keys
+	expires := f.expires
+	// OnLog runs in the Envoy's thread, so the I/O should not block it
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), consumeTimeout)
+		defer cancel()
+		if err := st.consume(ctx, keys, expires, tokens); err != nil {
+			api.LogErrorf("failed to consume %d tokens: %v", tokens, err)
+		}
+	}()
+}
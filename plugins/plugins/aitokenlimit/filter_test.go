@@ -0,0 +1,184 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aitokenlimit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type memoryStore struct {
+	lock     sync.Mutex
+	counts   map[string]int64
+	consumed chan int64
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		counts:   map[string]int64{},
+		consumed: make(chan int64, 1),
+	}
+}
+
+func (s *memoryStore) used(ctx context.Context, keys []string) ([]int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	res := make([]int64, len(keys))
+	for i, k := range keys {
+		res[i] = s.counts[k]
+	}
+	return res, nil
+}
+
+func (s *memoryStore) consume(ctx context.Context, keys []string, expires []time.Duration, tokens int64) error {
+	s.lock.Lock()
+	for _, k := range keys {
+		s.counts[k] += tokens
+	}
+	s.lock.Unlock()
+	s.consumed <- tokens
+	return nil
+}
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func newConfig(t *testing.T, input string) (*config, *memoryStore) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+	require.NoError(t, conf.Init(nil))
+	s := newMemoryStore()
+	conf.store = s
+	return conf, s
+}
+
+const chatRequest = `{"model":"gpt-4o","stream":true,"messages":[{"role":"user","content":"Hello, world"}]}`
+
+// chat sends a streaming chat request as the consumer, and returns the request body sent to the
+// upstream and the response headers
+func chat(t *testing.T, conf *config, consumer string, events ...string) (string, api.ResponseHeaderMap, api.ResultAction) {
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetConsumer(&testConsumer{name: consumer})
+	f := factory(conf, cb)
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":method":         []string{"POST"},
+		":path":           []string{"/v1/chat/completions"},
+		"Accept-Encoding": []string{"gzip"},
+	})
+	res := f.DecodeHeaders(hdr, false)
+	if res != api.WaitAllData {
+		return "", nil, res
+	}
+	_, ok := hdr.Get("accept-encoding")
+	assert.False(t, ok)
+	buf := envoy.NewBufferInstance([]byte(chatRequest))
+	require.Equal(t, api.Continue, f.DecodeRequest(hdr, buf, nil))
+
+	respHdr := envoy.NewResponseHeaderMap(http.Header{
+		":status":      []string{"200"},
+		"Content-Type": []string{"text/event-stream"},
+	})
+	f.EncodeHeaders(respHdr, false)
+	for i, e := range events {
+		f.EncodeData(envoy.NewBufferInstance([]byte(e)), i == len(events)-1)
+	}
+	f.OnLog(hdr, nil, respHdr, nil)
+	return buf.String(), respHdr, nil
+}
+
+func TestLimitTokens(t *testing.T) {
+	conf, s := newConfig(t, `{"address":"127.0.0.1:6379","enableLimitQuotaHeaders":true,"rules":[
+		{"timeWindow":"60s","tokens":100},
+		{"timeWindow":"86400s","tokens":120}
+	]}`)
+
+	body, respHdr, _ := chat(t, conf, "alice",
+		"data: {\"choices\":[{\"delta\":{\"content\":\"Hi\"}}]}\n\n",
+		"data: {\"choices\":[],\"usage\":{\"total_tokens\":110}}\n\ndata: [DONE]\n\n",
+	)
+	var req map[string]any
+	require.NoError(t, json.Unmarshal([]byte(body), &req))
+	assert.Equal(t, map[string]any{"include_usage": true}, req["stream_options"])
+	limit, _ := respHdr.Get("x-ratelimit-limit-tokens")
+	assert.Equal(t, "100", limit)
+	remaining, _ := respHdr.Get("x-ratelimit-remaining-tokens")
+	assert.Equal(t, "100", remaining)
+	assert.Equal(t, int64(110), <-s.consumed)
+
+	// the budget is exhausted
+	_, _, res := chat(t, conf, "alice")
+	resp, ok := res.(*api.LocalResponse)
+	require.True(t, ok, res)
+	assert.Equal(t, 429, resp.Code)
+	assert.NotEmpty(t, resp.Header.Get("retry-after"))
+
+	// the budget is per consumer
+	_, respHdr, _ = chat(t, conf, "bob",
+		"data: {\"choices\":[{\"delta\":{\"content\":\"Hello, world\"}}]}\n\n",
+	)
+	remaining, _ = respHdr.Get("x-ratelimit-remaining-tokens")
+	assert.Equal(t, "100", remaining)
+	// the stream is interrupted, so the tokens are estimated
+	assert.Equal(t, int64(6), <-s.consumed)
+
+	var keys []string
+	for k := range s.counts {
+		keys = append(keys, k)
+	}
+	assert.Len(t, keys, 4)
+	for _, k := range keys {
+		assert.True(t, strings.HasPrefix(k, "htnn-ai-token-limit|"), k)
+	}
+}
+
+func TestSkipFailedResponse(t *testing.T) {
+	conf, s := newConfig(t, `{"address":"127.0.0.1:6379","rules":[{"timeWindow":"60s","tokens":100}]}`)
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	hdr := envoy.NewRequestHeaderMap(http.Header{":method": []string{"POST"}})
+	require.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+	require.Equal(t, api.Continue, f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(chatRequest)), nil))
+	respHdr := envoy.NewResponseHeaderMap(http.Header{":status": []string{"400"}})
+	f.EncodeHeaders(respHdr, false)
+	f.EncodeData(envoy.NewBufferInstance([]byte(`{"error":{}}`)), true)
+	f.OnLog(hdr, nil, respHdr, nil)
+
+	select {
+	case <-s.consumed:
+		t.Fatal("tokens should not be consumed")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
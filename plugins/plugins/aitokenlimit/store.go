@@ -0,0 +1,73 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aitokenlimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// store keeps the tokens used in each window
+type store interface {
+	// used returns the tokens used under the keys
+	used(ctx context.Context, keys []string) ([]int64, error)
+	// consume adds the tokens to the keys, which expire after the given durations
+	consume(ctx context.Context, keys []string, expires []time.Duration, tokens int64) error
+}
+
+type redisStore struct {
+	client redis.UniversalClient
+}
+
+func (s *redisStore) used(ctx context.Context, keys []string) ([]int64, error) {
+	// Redis cluster doesn't support MGET across multiple slots, so the keys are read one by one
+	// in a pipeline.
+	cmds, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, k := range keys {
+			pipe.Get(ctx, k)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	res := make([]int64, len(cmds))
+	for i, cmd := range cmds {
+		n, err := cmd.(*redis.StringCmd).Int64()
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				continue
+			}
+			return nil, err
+		}
+		res[i] = n
+	}
+	return res, nil
+}
+
+func (s *redisStore) consume(ctx context.Context, keys []string, expires []time.Duration, tokens int64) error {
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, k := range keys {
+			pipe.IncrBy(ctx, k, tokens)
+			pipe.Expire(ctx, k, expires[i])
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,217 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/cache"
+)
+
+const (
+	defaultMaxEntries  = 10000
+	defaultMaxBodySize = 1 << 20
+	defaultPurgeHeader = "x-htnn-cache-purge"
+	defaultRedisPrefix = "htnn_cache"
+)
+
+// The status codes which are heuristically cacheable, see
+// https://www.rfc-editor.org/rfc/rfc9110#section-15.1
+var defaultStatuses = []uint32{200, 203, 204, 300, 301, 308, 404, 405, 410, 414, 501}
+
+func init() {
+	plugins.RegisterPlugin(cache.Name, &plugin{})
+}
+
+type plugin struct {
+	cache.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	cache.CustomConfig
+
+	store store
+
+	keyHeaders []string
+	queryArgs  []string
+
+	defaultTTL  time.Duration
+	maxTTL      time.Duration
+	staleTTL    time.Duration
+	maxBodySize int
+	statuses    map[int]bool
+
+	purgeSecret []byte
+	purgeHeader string
+}
+
+func sortedLower(items []string) []string {
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		res = append(res, strings.ToLower(item))
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if mem := conf.GetMemory(); mem != nil {
+		maxEntries := defaultMaxEntries
+		if mem.MaxEntries > 0 {
+			maxEntries = int(mem.MaxEntries)
+		}
+		conf.store = newMemoryStore(maxEntries)
+
+	} else {
+		r := conf.GetRedis()
+		var tlsConfig *tls.Config
+		if r.Tls {
+			tlsConfig = &tls.Config{
+				InsecureSkipVerify: r.TlsSkipVerify,
+			}
+		}
+		prefix := r.Prefix
+		if prefix == "" {
+			prefix = defaultRedisPrefix
+		}
+		conf.store = newRedisStore(redis.NewClient(&redis.Options{
+			Addr:      r.Address,
+			Username:  r.Username,
+			Password:  r.Password,
+			TLSConfig: tlsConfig,
+		}), prefix)
+	}
+
+	if key := conf.Key; key != nil {
+		// the query arguments are case-sensitive
+		conf.queryArgs = append([]string{}, key.QueryArgs...)
+		sort.Strings(conf.queryArgs)
+		conf.keyHeaders = sortedLower(key.Headers)
+	}
+
+	conf.defaultTTL = conf.DefaultTtl.AsDuration()
+	conf.maxTTL = conf.MaxTtl.AsDuration()
+	conf.staleTTL = conf.StaleWhileRevalidate.AsDuration()
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+
+	statuses := conf.Statuses
+	if len(statuses) == 0 {
+		statuses = defaultStatuses
+	}
+	conf.statuses = make(map[int]bool, len(statuses))
+	for _, s := range statuses {
+		conf.statuses[int(s)] = true
+	}
+
+	if purge := conf.Purge; purge != nil {
+		conf.purgeSecret = []byte(purge.Secret)
+		conf.purgeHeader = defaultPurgeHeader
+		if purge.Header != "" {
+			conf.purgeHeader = strings.ToLower(purge.Header)
+		}
+	}
+	return nil
+}
+
+// cacheKey generates the key from the request's scheme, host, path, and the configured query
+// arguments and headers.
+func (conf *config) cacheKey(headers api.RequestHeaderMap) string {
+	var sb strings.Builder
+	u := headers.URL()
+	sb.WriteString(headers.Scheme())
+	sb.WriteString("://")
+	sb.WriteString(headers.Host())
+	sb.WriteString(u.Path)
+	if len(conf.queryArgs) > 0 {
+		query := u.Query()
+		for _, arg := range conf.queryArgs {
+			for _, v := range query[arg] {
+				sb.WriteString("\n")
+				sb.WriteString(arg)
+				sb.WriteString("=")
+				sb.WriteString(v)
+			}
+		}
+	} else if u.RawQuery != "" {
+		sb.WriteString("?")
+		sb.WriteString(u.RawQuery)
+	}
+	for _, h := range conf.keyHeaders {
+		sb.WriteString("\n")
+		sb.WriteString(h)
+		sb.WriteString(":")
+		sb.WriteString(strings.Join(headers.Values(h), ","))
+	}
+
+	sum := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func (conf *config) isKeyHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, h := range conf.keyHeaders {
+		if h == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Hop-by-hop headers and the headers which are generated by the plugin are not stored
+var unstoredHeaders = map[string]bool{
+	"connection":          true,
+	"keep-alive":          true,
+	"proxy-authenticate":  true,
+	"proxy-authorization": true,
+	"te":                  true,
+	"trailer":             true,
+	"transfer-encoding":   true,
+	"upgrade":             true,
+	"content-length":      true,
+	"age":                 true,
+	cacheStatusHeader:     true,
+}
+
+func storedHeader(headers api.ResponseHeaderMap) http.Header {
+	hdr := http.Header{}
+	headers.Range(func(k, v string) bool {
+		if k[0] != ':' && !unstoredHeaders[strings.ToLower(k)] {
+			hdr.Add(k, v)
+		}
+		return true
+	})
+	return hdr
+}
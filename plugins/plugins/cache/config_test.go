@@ -0,0 +1,127 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "backend required",
+			input: `{}`,
+			err:   "invalid Config.Backend: value is required",
+		},
+		{
+			name:  "bad redis address",
+			input: `{"redis":{"address":"127.0.0.1"}}`,
+			err:   "bad address 127.0.0.1",
+		},
+		{
+			name:  "password required",
+			input: `{"redis":{"address":"127.0.0.1:6379", "username":"user"}}`,
+			err:   "password is required when username is set",
+		},
+		{
+			name:  "bad ttl",
+			input: `{"memory":{}, "defaultTtl":"0s"}`,
+			err:   "invalid Config.DefaultTtl",
+		},
+		{
+			name:  "bad status",
+			input: `{"memory":{}, "statuses":[100]}`,
+			err:   "invalid Config.Statuses[0]",
+		},
+		{
+			name:  "purge secret required",
+			input: `{"memory":{}, "purge":{}}`,
+			err:   "invalid Purge.Secret",
+		},
+		{
+			name: "ok",
+			input: `{
+				"memory": {"maxEntries": 100},
+				"key": {"headers": ["Accept-Encoding"], "queryArgs": ["page"]},
+				"defaultTtl": "60s",
+				"maxTtl": "3600s",
+				"staleWhileRevalidate": "10s",
+				"statuses": [200],
+				"purge": {"secret": "secret"}
+			}`,
+		},
+		{
+			name:  "redis",
+			input: `{"redis":{"address":"127.0.0.1:6379", "username":"user", "password":"pass"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"memory": {},
+		"key": {"headers": ["Accept-Encoding"], "queryArgs": ["page", "id"]}
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	key := func(path string, encoding string) string {
+		h := http.Header{}
+		h.Set(":path", path)
+		if encoding != "" {
+			h.Set("accept-encoding", encoding)
+		}
+		return conf.cacheKey(envoy.NewRequestHeaderMap(h))
+	}
+
+	assert.Equal(t, key("/a?id=1&page=2", "gzip"), key("/a?page=2&ts=3&id=1", "gzip"))
+	assert.NotEqual(t, key("/a?id=1&page=2", "gzip"), key("/a?id=1&page=3", "gzip"))
+	assert.NotEqual(t, key("/a?id=1", "gzip"), key("/a?id=1", ""))
+	assert.NotEqual(t, key("/a", ""), key("/b", ""))
+
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"memory": {}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	assert.NotEqual(t, key("/a?ts=1", ""), key("/a?ts=2", ""))
+}
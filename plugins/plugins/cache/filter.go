@@ -0,0 +1,224 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const (
+	cacheStatusHeader = "x-cache-status"
+
+	statusHit     = "HIT"
+	statusMiss    = "MISS"
+	statusStale   = "STALE"
+	statusExpired = "EXPIRED"
+	statusBypass  = "BYPASS"
+
+	// the lock is released after the response is stored. The TTL is to avoid the lock being held
+	// forever when the upstream doesn't respond.
+	refreshLockTTL = 10 * time.Second
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	key         string
+	cacheStatus string
+	authorized  bool
+	// store is true if the response should be stored when it's cacheable
+	store  bool
+	locked bool
+
+	status int
+	header http.Header
+	ttl    time.Duration
+}
+
+func (f *filter) purge(headers api.RequestHeaderMap, token string) api.ResultAction {
+	config := f.config
+	expected := hmac.New(sha256.New, config.purgeSecret)
+	expected.Write([]byte(headers.Host() + headers.Path()))
+	actual, err := hex.DecodeString(token)
+	if err != nil || !hmac.Equal(actual, expected.Sum(nil)) {
+		return &api.LocalResponse{Code: http.StatusForbidden, Msg: "invalid purge token"}
+	}
+
+	err = config.store.del(context.Background(), config.cacheKey(headers))
+	if err != nil {
+		api.LogErrorf("cache: failed to purge: %v", err)
+		return &api.LocalResponse{Code: http.StatusServiceUnavailable}
+	}
+	return &api.LocalResponse{Code: http.StatusOK, Msg: "purged"}
+}
+
+func (f *filter) respond(e *entry, now time.Time, cacheStatus string) api.ResultAction {
+	hdr := e.Header.Clone()
+	if hdr == nil {
+		hdr = http.Header{}
+	}
+	hdr.Set("age", strconv.Itoa(int(e.age(now).Seconds())))
+	hdr.Set(cacheStatusHeader, cacheStatus)
+	return &api.LocalResponse{Code: e.Status, Msg: string(e.Body), Header: hdr}
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	if config.purgeSecret != nil {
+		if token, ok := headers.Get(config.purgeHeader); ok {
+			return f.purge(headers, token)
+		}
+	}
+
+	if headers.Method() != http.MethodGet {
+		return api.Continue
+	}
+	cc := parseCacheControl(headers.Values("cache-control"))
+	if _, ok := cc["no-store"]; ok {
+		f.cacheStatus = statusBypass
+		return api.Continue
+	}
+
+	f.key = config.cacheKey(headers)
+	_, f.authorized = headers.Get("authorization")
+	f.store = true
+	f.cacheStatus = statusMiss
+	if _, ok := cc["no-cache"]; ok {
+		// the client asks to validate the response with the upstream
+		return api.Continue
+	}
+
+	ctx := context.Background()
+	e, err := config.store.get(ctx, f.key)
+	if err != nil {
+		api.LogErrorf("cache: failed to get the cached response: %v", err)
+		return api.Continue
+	}
+	if e == nil {
+		return api.Continue
+	}
+
+	now := time.Now()
+	if e.fresh(now) {
+		return f.respond(e, now, statusHit)
+	}
+
+	f.cacheStatus = statusExpired
+	if e.age(now) < e.TTL+config.staleTTL {
+		locked, err := config.store.lock(ctx, f.key, refreshLockTTL)
+		if err != nil {
+			api.LogErrorf("cache: failed to lock: %v", err)
+			return api.Continue
+		}
+		if !locked {
+			// another request is refreshing the response
+			return f.respond(e, now, statusStale)
+		}
+		f.locked = true
+	}
+	return api.Continue
+}
+
+func (f *filter) unlock() {
+	if !f.locked {
+		return
+	}
+	f.locked = false
+	err := f.config.store.unlock(context.Background(), f.key)
+	if err != nil {
+		api.LogErrorf("cache: failed to unlock: %v", err)
+	}
+}
+
+func (f *filter) save(body []byte) {
+	defer f.unlock()
+
+	e := &entry{
+		Status:   f.status,
+		Header:   f.header,
+		Body:     body,
+		StoredAt: time.Now(),
+		TTL:      f.ttl,
+	}
+	err := f.config.store.set(context.Background(), f.key, e, f.ttl+f.config.staleTTL)
+	if err != nil {
+		api.LogErrorf("cache: failed to store the response: %v", err)
+	}
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	if !f.store {
+		if f.cacheStatus != "" {
+			headers.Set(cacheStatusHeader, f.cacheStatus)
+		}
+		return api.Continue
+	}
+
+	ttl := config.cacheable(headers, f.authorized)
+	if cl, ok := headers.Get("content-length"); ok {
+		n, err := strconv.Atoi(cl)
+		if err == nil && n > config.maxBodySize {
+			ttl = 0
+		}
+	}
+	if ttl <= 0 {
+		f.unlock()
+		headers.Set(cacheStatusHeader, f.cacheStatus)
+		return api.Continue
+	}
+
+	status, _ := headers.Get(":status")
+	f.status, _ = strconv.Atoi(status)
+	f.header = storedHeader(headers)
+	f.ttl = ttl
+	headers.Set(cacheStatusHeader, f.cacheStatus)
+	if endStream {
+		f.save(nil)
+		return api.Continue
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	if data == nil {
+		f.save(nil)
+		return api.Continue
+	}
+	if data.Len() > f.config.maxBodySize {
+		f.unlock()
+		return api.Continue
+	}
+	f.save(append([]byte{}, data.Bytes()...))
+	return api.Continue
+}
@@ -0,0 +1,287 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func request(conf *config, reqHeader http.Header) (*filter, api.ResultAction) {
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	h := http.Header{}
+	h.Set(":method", "GET")
+	h.Set(":path", "/")
+	h.Set(":authority", "example.com")
+	for k, v := range reqHeader {
+		h[k] = v
+	}
+	return f, f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+}
+
+// respond sends the response through the filter and returns the x-cache-status
+func respond(t *testing.T, f *filter, rspHeader http.Header, body string) string {
+	h := http.Header{}
+	h.Set(":status", "200")
+	for k, v := range rspHeader {
+		h[k] = v
+	}
+	hdr := envoy.NewResponseHeaderMap(h)
+	res := f.EncodeHeaders(hdr, body == "")
+	if res == api.WaitAllData {
+		require.Equal(t, api.Continue, f.EncodeResponse(hdr, envoy.NewBufferInstance([]byte(body)), nil))
+	}
+	v, _ := hdr.Get(cacheStatusHeader)
+	return v
+}
+
+func TestCacheHit(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"memory":{}}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	f, res := request(conf, nil)
+	require.Equal(t, api.Continue, res)
+	status := respond(t, f, http.Header{
+		"Cache-Control": []string{"public, max-age=60"},
+		"Content-Type":  []string{"text/plain"},
+		"Connection":    []string{"keep-alive"},
+	}, "hello")
+	assert.Equal(t, statusMiss, status)
+
+	_, res = request(conf, nil)
+	resp := res.(*api.LocalResponse)
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, "hello", resp.Msg)
+	assert.Equal(t, "text/plain", resp.Header.Get("content-type"))
+	assert.Equal(t, "", resp.Header.Get("connection"))
+	assert.Equal(t, "0", resp.Header.Get("age"))
+	assert.Equal(t, statusHit, resp.Header.Get(cacheStatusHeader))
+
+	// the other paths are not affected
+	_, res = request(conf, http.Header{":path": []string{"/other"}})
+	assert.Equal(t, api.Continue, res)
+	// the client asks to bypass the cache
+	f, res = request(conf, http.Header{"Cache-Control": []string{"no-store"}})
+	assert.Equal(t, api.Continue, res)
+	assert.Equal(t, statusBypass, respond(t, f, nil, "hi"))
+	// the client asks to revalidate
+	f, res = request(conf, http.Header{"Cache-Control": []string{"no-cache"}})
+	assert.Equal(t, api.Continue, res)
+	assert.Equal(t, statusMiss, respond(t, f, http.Header{"Cache-Control": []string{"max-age=60"}}, "updated"))
+	_, res = request(conf, nil)
+	assert.Equal(t, "updated", res.(*api.LocalResponse).Msg)
+	// only GET is cached
+	_, res = request(conf, http.Header{":method": []string{"POST"}})
+	assert.Equal(t, api.Continue, res)
+}
+
+func TestCacheable(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     string
+		status     string
+		header     http.Header
+		authorized bool
+		ttl        time.Duration
+	}{
+		{
+			name:   "max-age",
+			header: http.Header{"Cache-Control": []string{"max-age=60"}},
+			ttl:    60 * time.Second,
+		},
+		{
+			name:   "s-maxage first",
+			header: http.Header{"Cache-Control": []string{"max-age=60", "s-maxage=10"}},
+			ttl:    10 * time.Second,
+		},
+		{
+			name:   "age",
+			header: http.Header{"Cache-Control": []string{"max-age=60"}, "Age": []string{"20"}},
+			ttl:    40 * time.Second,
+		},
+		{
+			name: "expires",
+			header: http.Header{
+				"Date":    []string{"Mon, 02 Jan 2006 15:04:05 GMT"},
+				"Expires": []string{"Mon, 02 Jan 2006 15:05:05 GMT"},
+			},
+			ttl: 60 * time.Second,
+		},
+		{
+			name:   "bad expires",
+			header: http.Header{"Expires": []string{"0"}},
+		},
+		{
+			name:   "no ttl",
+			header: http.Header{},
+		},
+		{
+			name:   "default ttl",
+			config: `{"memory":{}, "defaultTtl":"5s"}`,
+			header: http.Header{},
+			ttl:    5 * time.Second,
+		},
+		{
+			name:   "max ttl",
+			config: `{"memory":{}, "maxTtl":"5s"}`,
+			header: http.Header{"Cache-Control": []string{"max-age=60"}},
+			ttl:    5 * time.Second,
+		},
+		{
+			name:   "private",
+			header: http.Header{"Cache-Control": []string{"private, max-age=60"}},
+		},
+		{
+			name:   "no-store",
+			header: http.Header{"Cache-Control": []string{"no-store"}},
+		},
+		{
+			name:   "status",
+			status: "500",
+			header: http.Header{"Cache-Control": []string{"max-age=60"}},
+		},
+		{
+			name:   "set-cookie",
+			header: http.Header{"Cache-Control": []string{"max-age=60"}, "Set-Cookie": []string{"a=b"}},
+		},
+		{
+			name:       "authorized",
+			header:     http.Header{"Cache-Control": []string{"max-age=60"}},
+			authorized: true,
+		},
+		{
+			name:       "authorized and public",
+			header:     http.Header{"Cache-Control": []string{"public, max-age=60"}},
+			authorized: true,
+			ttl:        60 * time.Second,
+		},
+		{
+			name:   "vary not in key",
+			header: http.Header{"Cache-Control": []string{"max-age=60"}, "Vary": []string{"Accept-Encoding"}},
+		},
+		{
+			name:   "vary in key",
+			config: `{"memory":{}, "key":{"headers":["accept-encoding"]}}`,
+			header: http.Header{"Cache-Control": []string{"max-age=60"}, "Vary": []string{"Accept-Encoding"}},
+			ttl:    60 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.config
+			if input == "" {
+				input = `{"memory":{}}`
+			}
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+			require.NoError(t, conf.Init(nil))
+			h := tt.header.Clone()
+			status := tt.status
+			if status == "" {
+				status = "200"
+			}
+			h.Set(":status", status)
+			assert.Equal(t, tt.ttl, conf.cacheable(envoy.NewResponseHeaderMap(h), tt.authorized))
+		})
+	}
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"memory":{}, "staleWhileRevalidate":"60s"}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f, _ := request(conf, nil)
+	respond(t, f, http.Header{"Cache-Control": []string{"max-age=10"}}, "old")
+
+	// make the entry stale
+	ctx := context.Background()
+	e, _ := conf.store.get(ctx, f.key)
+	e.StoredAt = e.StoredAt.Add(-20 * time.Second)
+
+	// the first request refreshes the entry
+	refresher, res := request(conf, nil)
+	assert.Equal(t, api.Continue, res)
+	// the others get the stale one
+	_, res = request(conf, nil)
+	resp := res.(*api.LocalResponse)
+	assert.Equal(t, "old", resp.Msg)
+	assert.Equal(t, statusStale, resp.Header.Get(cacheStatusHeader))
+	assert.Equal(t, "20", resp.Header.Get("age"))
+
+	assert.Equal(t, statusExpired, respond(t, refresher, http.Header{"Cache-Control": []string{"max-age=10"}}, "new"))
+	_, res = request(conf, nil)
+	resp = res.(*api.LocalResponse)
+	assert.Equal(t, "new", resp.Msg)
+	assert.Equal(t, statusHit, resp.Header.Get(cacheStatusHeader))
+
+	// the entry is out of the stale period
+	e, _ = conf.store.get(ctx, f.key)
+	e.StoredAt = e.StoredAt.Add(-100 * time.Second)
+	_, res = request(conf, nil)
+	assert.Equal(t, api.Continue, res)
+	_, res = request(conf, nil)
+	assert.Equal(t, api.Continue, res)
+}
+
+func TestMaxBodySize(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"memory":{}, "maxBodySize":4}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f, _ := request(conf, nil)
+	respond(t, f, http.Header{"Cache-Control": []string{"max-age=10"}}, "hello")
+	_, res := request(conf, nil)
+	assert.Equal(t, api.Continue, res)
+
+	f, _ = request(conf, nil)
+	respond(t, f, http.Header{"Cache-Control": []string{"max-age=10"}, "Content-Length": []string{"5"}}, "hello")
+	_, res = request(conf, nil)
+	assert.Equal(t, api.Continue, res)
+}
+
+func TestPurge(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"memory":{}, "purge":{"secret":"secret", "header":"X-Purge"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f, _ := request(conf, http.Header{":path": []string{"/a?b=1"}})
+	respond(t, f, http.Header{"Cache-Control": []string{"max-age=10"}}, "hello")
+	_, res := request(conf, http.Header{":path": []string{"/a?b=1"}})
+	require.IsType(t, &api.LocalResponse{}, res)
+
+	_, res = request(conf, http.Header{":path": []string{"/a?b=1"}, "X-Purge": []string{"bad"}})
+	assert.Equal(t, 403, res.(*api.LocalResponse).Code)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("example.com/a?b=1"))
+	token := hex.EncodeToString(mac.Sum(nil))
+	_, res = request(conf, http.Header{":path": []string{"/a?b=1"}, "X-Purge": []string{token}})
+	assert.Equal(t, 200, res.(*api.LocalResponse).Code)
+
+	_, res = request(conf, http.Header{":path": []string{"/a?b=1"}})
+	assert.Equal(t, api.Continue, res)
+}
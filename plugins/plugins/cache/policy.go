@@ -0,0 +1,134 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/sse"
+)
+
+// parseCacheControl parses the Cache-Control header into directives. The directive names are
+// lowercased, and the quoted values are unquoted.
+func parseCacheControl(values []string) map[string]string {
+	directives := map[string]string{}
+	for _, value := range values {
+		for _, d := range strings.Split(value, ",") {
+			d = strings.TrimSpace(d)
+			if d == "" {
+				continue
+			}
+			name, arg, _ := strings.Cut(d, "=")
+			directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(arg), `"`)
+		}
+	}
+	return directives
+}
+
+func parseSeconds(s string) (time.Duration, bool) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return time.Duration(n) * time.Second, true
+}
+
+// freshnessLifetime calculates how long the response is fresh, following
+// https://www.rfc-editor.org/rfc/rfc9111#section-4.2.1
+func (conf *config) freshnessLifetime(headers api.ResponseHeaderMap, cc map[string]string) time.Duration {
+	var ttl time.Duration
+	if v, ok := cc["s-maxage"]; ok {
+		ttl, _ = parseSeconds(v)
+	} else if v, ok := cc["max-age"]; ok {
+		ttl, _ = parseSeconds(v)
+	} else if v, ok := headers.Get("expires"); ok {
+		expires, err := http.ParseTime(v)
+		if err != nil {
+			// invalid Expires means the response is already expired
+			return 0
+		}
+		date := time.Now()
+		if v, ok := headers.Get("date"); ok {
+			if t, err := http.ParseTime(v); err == nil {
+				date = t
+			}
+		}
+		ttl = expires.Sub(date)
+	} else {
+		ttl = conf.defaultTTL
+	}
+
+	if v, ok := headers.Get("age"); ok {
+		if age, ok := parseSeconds(v); ok {
+			ttl -= age
+		}
+	}
+	if conf.maxTTL > 0 && ttl > conf.maxTTL {
+		ttl = conf.maxTTL
+	}
+	return ttl
+}
+
+// cacheable returns the TTL of the response, or 0 if the response can't be stored in the shared
+// cache. See https://www.rfc-editor.org/rfc/rfc9111#section-3
+func (conf *config) cacheable(headers api.ResponseHeaderMap, authorized bool) time.Duration {
+	status, _ := headers.Get(":status")
+	code, _ := strconv.Atoi(status)
+	if !conf.statuses[code] {
+		return 0
+	}
+	if sse.IsEventStream(headers) {
+		// the event stream may never end, so it can't be buffered
+		return 0
+	}
+
+	cc := parseCacheControl(headers.Values("cache-control"))
+	for _, d := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := cc[d]; ok {
+			return 0
+		}
+	}
+	if authorized {
+		_, public := cc["public"]
+		_, sMaxAge := cc["s-maxage"]
+		_, mustRevalidate := cc["must-revalidate"]
+		if !public && !sMaxAge && !mustRevalidate {
+			return 0
+		}
+	}
+	if _, ok := headers.Get("set-cookie"); ok {
+		// avoid leaking the cookie to the other clients
+		return 0
+	}
+	for _, v := range headers.Values("vary") {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			// the response varies with the headers which are not in the cache key
+			if name == "*" || (name != "" && !conf.isKeyHeader(name)) {
+				return 0
+			}
+		}
+	}
+
+	ttl := conf.freshnessLifetime(headers, cc)
+	if ttl < 0 {
+		return 0
+	}
+	return ttl
+}
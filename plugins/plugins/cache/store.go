@@ -0,0 +1,148 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"github.com/redis/go-redis/v9"
+)
+
+type entry struct {
+	Status   int           `json:"status"`
+	Header   http.Header   `json:"header"`
+	Body     []byte        `json:"body"`
+	StoredAt time.Time     `json:"storedAt"`
+	TTL      time.Duration `json:"ttl"`
+}
+
+func (e *entry) age(now time.Time) time.Duration {
+	return now.Sub(e.StoredAt)
+}
+
+func (e *entry) fresh(now time.Time) bool {
+	return e.age(now) < e.TTL
+}
+
+type store interface {
+	// get returns nil if the entry is not found
+	get(ctx context.Context, key string) (*entry, error)
+	// set stores the entry for the given duration, which includes the stale period
+	set(ctx context.Context, key string, e *entry, expire time.Duration) error
+	del(ctx context.Context, key string) error
+	// lock returns true if the lock of the key is acquired. It's used to ensure only one request
+	// is sent to refresh the stale entry.
+	lock(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	unlock(ctx context.Context, key string) error
+}
+
+type memoryStore struct {
+	entries *ttlcache.Cache[string, *entry]
+	locks   *ttlcache.Cache[string, struct{}]
+}
+
+func newMemoryStore(maxEntries int) *memoryStore {
+	return &memoryStore{
+		entries: ttlcache.New(
+			ttlcache.WithCapacity[string, *entry](uint64(maxEntries)),
+			ttlcache.WithDisableTouchOnHit[string, *entry](),
+		),
+		locks: ttlcache.New(
+			ttlcache.WithCapacity[string, struct{}](uint64(maxEntries)),
+			ttlcache.WithDisableTouchOnHit[string, struct{}](),
+		),
+	}
+}
+
+func (s *memoryStore) get(ctx context.Context, key string) (*entry, error) {
+	item := s.entries.Get(key)
+	if item == nil {
+		return nil, nil
+	}
+	return item.Value(), nil
+}
+
+func (s *memoryStore) set(ctx context.Context, key string, e *entry, expire time.Duration) error {
+	s.entries.Set(key, e, expire)
+	return nil
+}
+
+func (s *memoryStore) del(ctx context.Context, key string) error {
+	s.entries.Delete(key)
+	return nil
+}
+
+func (s *memoryStore) lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	_, found := s.locks.GetOrSet(key, struct{}{}, ttlcache.WithTTL[string, struct{}](ttl))
+	return !found, nil
+}
+
+func (s *memoryStore) unlock(ctx context.Context, key string) error {
+	s.locks.Delete(key)
+	return nil
+}
+
+type redisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+func newRedisStore(client *redis.Client, prefix string) *redisStore {
+	return &redisStore{
+		client: client,
+		prefix: prefix + "|",
+	}
+}
+
+func (s *redisStore) get(ctx context.Context, key string) (*entry, error) {
+	b, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	e := &entry{}
+	err = json.Unmarshal(b, e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *redisStore) set(ctx context.Context, key string, e *entry, expire time.Duration) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.prefix+key, b, expire).Err()
+}
+
+func (s *redisStore) del(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key).Err()
+}
+
+func (s *redisStore) lock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.prefix+key+"|lock", 1, ttl).Result()
+}
+
+func (s *redisStore) unlock(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key+"|lock").Err()
+}
@@ -0,0 +1,144 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/canary"
+)
+
+const (
+	defaultTimeout     = 30 * time.Second
+	defaultMaxBodySize = 1 << 20
+	defaultCookieName  = "htnn-canary"
+)
+
+func init() {
+	plugins.RegisterPlugin(canary.Name, &plugin{})
+}
+
+type plugin struct {
+	canary.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type valueMatcher struct {
+	name    string
+	matcher expr.Matcher
+}
+
+type rule struct {
+	headers   []*valueMatcher
+	cookies   []*valueMatcher
+	consumers map[string]bool
+}
+
+type config struct {
+	canary.CustomConfig
+
+	baseURL     string
+	client      *http.Client
+	maxBodySize int
+	rules       []*rule
+
+	cookieName   string
+	cookieMaxAge int
+}
+
+func buildValueMatchers(matchers []*canary.ValueMatcher) ([]*valueMatcher, error) {
+	res := make([]*valueMatcher, 0, len(matchers))
+	for _, m := range matchers {
+		matcher, err := expr.BuildStringMatcher(m.Value)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &valueMatcher{name: m.Name, matcher: matcher})
+	}
+	return res, nil
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	u, err := canary.ClusterURL(conf.Cluster)
+	if err != nil {
+		return err
+	}
+	conf.baseURL = strings.TrimSuffix(u.String(), "/")
+
+	timeout := defaultTimeout
+	if conf.Timeout != nil {
+		timeout = conf.Timeout.AsDuration()
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// pass the compressed response to the client as it is
+	transport.DisableCompression = true
+	conf.client = &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// the redirect should be followed by the client
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+
+	conf.rules = make([]*rule, 0, len(conf.Rules))
+	for _, r := range conf.Rules {
+		headers, err := buildValueMatchers(r.Headers)
+		if err != nil {
+			return err
+		}
+		cookies, err := buildValueMatchers(r.Cookies)
+		if err != nil {
+			return err
+		}
+		consumers := make(map[string]bool, len(r.Consumers))
+		for _, c := range r.Consumers {
+			consumers[c] = true
+		}
+		conf.rules = append(conf.rules, &rule{
+			headers:   headers,
+			cookies:   cookies,
+			consumers: consumers,
+		})
+	}
+
+	if conf.Sticky != nil {
+		conf.cookieName = defaultCookieName
+		if conf.Sticky.CookieName != "" {
+			conf.cookieName = conf.Sticky.CookieName
+		}
+		if conf.Sticky.MaxAge != nil {
+			conf.cookieMaxAge = int(conf.Sticky.MaxAge.AsDuration().Seconds())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		err     string
+		baseURL string
+	}{
+		{
+			name:    "host and port",
+			input:   `{"cluster":"canary.default:8080","weight":10}`,
+			baseURL: "http://canary.default:8080",
+		},
+		{
+			name:    "https",
+			input:   `{"cluster":"https://canary.default:8443/"}`,
+			baseURL: "https://canary.default:8443",
+		},
+		{
+			name:  "cluster is required",
+			input: `{"weight":10}`,
+			err:   "invalid Config.Cluster: value length must be at least 1 runes",
+		},
+		{
+			name:  "bad scheme",
+			input: `{"cluster":"grpc://canary.default:8080"}`,
+			err:   "bad cluster grpc://canary.default:8080: unsupported scheme grpc",
+		},
+		{
+			name:  "bad weight",
+			input: `{"cluster":"canary.default:8080","weight":101}`,
+			err:   "invalid Config.Weight: value must be less than or equal to 100",
+		},
+		{
+			name:  "empty rule",
+			input: `{"cluster":"canary.default:8080","rules":[{}]}`,
+			err:   "rule should have at least one condition",
+		},
+		{
+			name:  "matcher is required",
+			input: `{"cluster":"canary.default:8080","rules":[{"headers":[{"name":"x-canary"}]}]}`,
+			err:   "invalid ValueMatcher.Value: value is required",
+		},
+		{
+			name:  "bad regex",
+			input: `{"cluster":"canary.default:8080","rules":[{"cookies":[{"name":"user","value":{"regex":"("}}]}]}`,
+			err:   "missing closing )",
+		},
+		{
+			name:  "bad max age",
+			input: `{"cluster":"canary.default:8080","sticky":{"maxAge":"0s"}}`,
+			err:   "invalid Sticky.MaxAge: value must be greater than 0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			err := conf.Validate()
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, conf.Init(nil))
+			assert.Equal(t, tt.baseURL, conf.baseURL)
+		})
+	}
+}
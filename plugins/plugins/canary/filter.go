@@ -0,0 +1,212 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const (
+	upstreamCanary = "canary"
+	upstreamStable = "stable"
+)
+
+// hopHeaders are the hop-by-hop headers which should not be forwarded
+var hopHeaders = map[string]bool{
+	"connection":        true,
+	"keep-alive":        true,
+	"proxy-connection":  true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+	"te":                true,
+	"trailer":           true,
+	"content-length":    true,
+}
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	setCookie string
+}
+
+func (f *filter) matchRule(r *rule, headers api.RequestHeaderMap) bool {
+	for _, m := range r.headers {
+		v, ok := headers.Get(m.name)
+		if !ok || !m.matcher.Match(v) {
+			return false
+		}
+	}
+	for _, m := range r.cookies {
+		c := headers.Cookie(m.name)
+		if c == nil || !m.matcher.Match(c.Value) {
+			return false
+		}
+	}
+	if len(r.consumers) > 0 {
+		consumer := f.callbacks.GetConsumer()
+		if consumer == nil || !r.consumers[consumer.Name()] {
+			return false
+		}
+	}
+	return true
+}
+
+// toCanary decides whether the request should be routed to the canary upstream
+func (f *filter) toCanary(headers api.RequestHeaderMap) bool {
+	conf := f.config
+	for _, r := range conf.rules {
+		if f.matchRule(r, headers) {
+			return true
+		}
+	}
+
+	weight := int(conf.Weight)
+	if conf.cookieName != "" {
+		if c := headers.Cookie(conf.cookieName); c != nil {
+			// the assignment is ignored once the canary is rolled back or fully rolled out
+			switch c.Value {
+			case upstreamCanary:
+				if weight > 0 {
+					return true
+				}
+			case upstreamStable:
+				if weight < 100 {
+					return false
+				}
+			}
+		}
+	}
+
+	canary := weight > 0 && rand.Intn(100) < weight
+	if conf.cookieName != "" {
+		value := upstreamStable
+		if canary {
+			value = upstreamCanary
+		}
+		cookie := &http.Cookie{
+			Name:     conf.cookieName,
+			Value:    value,
+			Path:     "/",
+			MaxAge:   conf.cookieMaxAge,
+			HttpOnly: true,
+		}
+		f.setCookie = cookie.String()
+	}
+	return canary
+}
+
+func (f *filter) proxy(headers api.RequestHeaderMap, body []byte) api.ResultAction {
+	conf := f.config
+	var reader io.Reader
+	if len(body) > 0 {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(headers.Method(), conf.baseURL+headers.Path(), reader)
+	if err != nil {
+		api.LogErrorf("failed to create canary request: %v", err)
+		return &api.LocalResponse{Code: http.StatusInternalServerError}
+	}
+	headers.Range(func(k, v string) bool {
+		k = strings.ToLower(k)
+		if strings.HasPrefix(k, ":") || k == "host" || hopHeaders[k] {
+			return true
+		}
+		req.Header.Add(k, v)
+		return true
+	})
+	req.Host = headers.Host()
+
+	resp, err := conf.client.Do(req)
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			api.LogWarnf("timeout to send request to canary upstream %s: %v", conf.Cluster, err)
+			return &api.LocalResponse{Code: http.StatusGatewayTimeout}
+		}
+		api.LogErrorf("failed to send request to canary upstream %s: %v", conf.Cluster, err)
+		return &api.LocalResponse{Code: http.StatusServiceUnavailable}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		api.LogErrorf("failed to read response from canary upstream %s: %v", conf.Cluster, err)
+		return &api.LocalResponse{Code: http.StatusBadGateway}
+	}
+
+	hdr := http.Header{}
+	for k, vs := range resp.Header {
+		if hopHeaders[strings.ToLower(k)] {
+			continue
+		}
+		hdr[k] = vs
+	}
+	if f.setCookie != "" {
+		hdr.Add("set-cookie", f.setCookie)
+		f.setCookie = ""
+	}
+	return &api.LocalResponse{Code: resp.StatusCode, Msg: string(respBody), Header: hdr}
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	if !f.toCanary(headers) {
+		return api.Continue
+	}
+	if _, ok := headers.Get("upgrade"); ok {
+		// the upgraded connection can't be proxied
+		api.LogInfof("route the upgrade request to the stable upstream")
+		return api.Continue
+	}
+	if endStream {
+		return f.proxy(headers, nil)
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	var body []byte
+	if data != nil {
+		if data.Len() > f.config.maxBodySize {
+			return &api.LocalResponse{Code: http.StatusRequestEntityTooLarge}
+		}
+		body = data.Bytes()
+	}
+	return f.proxy(headers, body)
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.setCookie != "" {
+		headers.Add("set-cookie", f.setCookie)
+	}
+	return api.Continue
+}
@@ -0,0 +1,245 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func startCanary(t *testing.T) string {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(100 * time.Millisecond)
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("x-host", r.Host)
+		w.Header().Set("x-method", r.Method)
+		w.Header().Set("x-path", r.URL.RequestURI())
+		w.Header().Set("x-foo", r.Header.Get("foo"))
+		w.Header().Set("x-connection", r.Header.Get("proxy-connection"))
+		w.Header().Set("content-type", "text/plain")
+		w.WriteHeader(201)
+		_, _ = w.Write([]byte("canary:" + string(body)))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.Listener.Addr().String()
+}
+
+func newConfig(t *testing.T, input string, cluster string) *config {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+	conf.Cluster = cluster
+	require.NoError(t, conf.Validate())
+	require.NoError(t, conf.Init(nil))
+	return conf
+}
+
+func newHeaders(hdr http.Header) *envoy.RequestHeaderMap {
+	h := http.Header{
+		":authority": {"test.local"},
+		":method":    {"GET"},
+		":path":      {"/users?a=1"},
+	}
+	for k, v := range hdr {
+		h[k] = v
+	}
+	return envoy.NewRequestHeaderMap(h)
+}
+
+func TestCanaryProxy(t *testing.T) {
+	addr := startCanary(t)
+	conf := newConfig(t, `{"weight":100,"timeout":"0.05s"}`, addr)
+
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	hdr := newHeaders(http.Header{
+		"Foo":              {"bar"},
+		"Proxy-Connection": {"keep-alive"},
+	})
+	resp := f.DecodeHeaders(hdr, true).(*api.LocalResponse)
+	assert.Equal(t, 201, resp.Code)
+	assert.Equal(t, "canary:", resp.Msg)
+	assert.Equal(t, "test.local", resp.Header.Get("x-host"))
+	assert.Equal(t, "GET", resp.Header.Get("x-method"))
+	assert.Equal(t, "/users?a=1", resp.Header.Get("x-path"))
+	assert.Equal(t, "bar", resp.Header.Get("x-foo"))
+	assert.Equal(t, "", resp.Header.Get("x-connection"))
+	assert.Equal(t, "", resp.Header.Get("content-length"))
+	assert.Equal(t, "text/plain", resp.Header.Get("content-type"))
+
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	hdr = newHeaders(http.Header{":method": {"POST"}})
+	assert.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+	resp = f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte("hello")), nil).(*api.LocalResponse)
+	assert.Equal(t, "canary:hello", resp.Msg)
+	assert.Equal(t, "POST", resp.Header.Get("x-method"))
+
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	hdr = newHeaders(http.Header{":method": {"POST"}})
+	assert.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+	resp = f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(strings.Repeat("a", 1<<20+1))), nil).(*api.LocalResponse)
+	assert.Equal(t, 413, resp.Code)
+
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	hdr = newHeaders(http.Header{":path": {"/slow"}})
+	assert.Equal(t, &api.LocalResponse{Code: 504}, f.DecodeHeaders(hdr, true))
+
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	hdr = newHeaders(http.Header{"Upgrade": {"websocket"}})
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+
+	conf = newConfig(t, `{"weight":100}`, "127.0.0.1:1")
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	assert.Equal(t, &api.LocalResponse{Code: 503}, f.DecodeHeaders(newHeaders(nil), true))
+}
+
+func TestCanaryRules(t *testing.T) {
+	addr := startCanary(t)
+	conf := newConfig(t, `{
+		"rules": [
+			{"headers": [{"name": "x-canary", "value": {"exact": "true"}}]},
+			{
+				"cookies": [{"name": "user", "value": {"prefix": "beta-"}}],
+				"consumers": ["alice"]
+			}
+		]
+	}`, addr)
+
+	tests := []struct {
+		name     string
+		hdr      http.Header
+		consumer string
+		canary   bool
+	}{
+		{
+			name:   "header matched",
+			hdr:    http.Header{"X-Canary": {"true"}},
+			canary: true,
+		},
+		{
+			name: "header mismatched",
+			hdr:  http.Header{"X-Canary": {"false"}},
+		},
+		{
+			name:     "cookie and consumer matched",
+			hdr:      http.Header{"Cookie": {"user=beta-1"}},
+			consumer: "alice",
+			canary:   true,
+		},
+		{
+			name:     "consumer mismatched",
+			hdr:      http.Header{"Cookie": {"user=beta-1"}},
+			consumer: "bob",
+		},
+		{
+			name: "no consumer",
+			hdr:  http.Header{"Cookie": {"user=beta-1"}},
+		},
+		{
+			name:     "no cookie",
+			consumer: "alice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewFilterCallbackHandler()
+			if tt.consumer != "" {
+				cb.SetConsumer(&testConsumer{name: tt.consumer})
+			}
+			f := factory(conf, cb)
+			res := f.DecodeHeaders(newHeaders(tt.hdr), true)
+			if tt.canary {
+				assert.Equal(t, 201, res.(*api.LocalResponse).Code)
+			} else {
+				assert.Equal(t, api.Continue, res)
+			}
+		})
+	}
+}
+
+func TestCanarySticky(t *testing.T) {
+	addr := startCanary(t)
+
+	send := func(conf *config, cookie string) (api.ResultAction, string) {
+		f := factory(conf, envoy.NewFilterCallbackHandler())
+		hdr := http.Header{}
+		if cookie != "" {
+			hdr.Set("cookie", cookie)
+		}
+		res := f.DecodeHeaders(newHeaders(hdr), true)
+		if lr, ok := res.(*api.LocalResponse); ok {
+			return res, lr.Header.Get("set-cookie")
+		}
+		rspHdr := envoy.NewResponseHeaderMap(http.Header{})
+		f.EncodeHeaders(rspHdr, true)
+		setCookie, _ := rspHdr.Get("set-cookie")
+		return res, setCookie
+	}
+
+	conf := newConfig(t, `{"weight":100,"sticky":{"maxAge":"3600s"}}`, addr)
+	res, setCookie := send(conf, "")
+	assert.Equal(t, 201, res.(*api.LocalResponse).Code)
+	assert.Equal(t, "htnn-canary=canary; Path=/; Max-Age=3600; HttpOnly", setCookie)
+	// fully rolled out
+	res, setCookie = send(conf, "htnn-canary=stable")
+	assert.Equal(t, 201, res.(*api.LocalResponse).Code)
+	assert.Equal(t, "htnn-canary=canary; Path=/; Max-Age=3600; HttpOnly", setCookie)
+
+	conf = newConfig(t, `{"weight":50,"sticky":{"cookieName":"group"}}`, addr)
+	for i := 0; i < 10; i++ {
+		res, setCookie = send(conf, "group=canary")
+		assert.Equal(t, 201, res.(*api.LocalResponse).Code)
+		assert.Equal(t, "", setCookie)
+		res, setCookie = send(conf, "group=stable")
+		assert.Equal(t, api.Continue, res)
+		assert.Equal(t, "", setCookie)
+	}
+	res, setCookie = send(conf, "group=unknown")
+	if res == api.Continue {
+		assert.Equal(t, "group=stable; Path=/; HttpOnly", setCookie)
+	} else {
+		assert.Equal(t, "group=canary; Path=/; HttpOnly", setCookie)
+	}
+
+	// rolled back
+	conf = newConfig(t, `{"sticky":{}}`, addr)
+	res, setCookie = send(conf, "htnn-canary=canary")
+	assert.Equal(t, api.Continue, res)
+	assert.Equal(t, "htnn-canary=stable; Path=/; HttpOnly", setCookie)
+}
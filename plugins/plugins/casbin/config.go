@@ -15,11 +15,20 @@
 package casbin
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
+	stringadapter "github.com/casbin/casbin/v2/persist/string-adapter"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/plugins"
@@ -27,6 +36,13 @@ import (
 	casbintype "mosn.io/htnn/types/plugins/casbin"
 )
 
+const (
+	defaultRefreshInterval = 60 * time.Second
+	fetchTimeout           = 10 * time.Second
+	// maxFetchedSize limits the size of the model or policy fetched from the URL
+	maxFetchedSize = 16 * 1024 * 1024
+)
+
 func init() {
 	plugins.RegisterPlugin(casbintype.Name, &plugin{})
 }
@@ -44,7 +60,7 @@ func (p *plugin) Config() api.PluginConfig {
 }
 
 type config struct {
-	casbintype.Config
+	casbintype.CustomConfig
 
 	lock *sync.RWMutex
 
@@ -54,52 +70,192 @@ type config struct {
 	updating   atomic.Bool
 
 	watcher *file.Watcher
+
+	client *http.Client
+	// fetched caches the content fetched from the URL
+	fetched     map[string]string
+	fetchedLock sync.Mutex
+	done        chan struct{}
 }
 
 func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 	conf.lock = &sync.RWMutex{}
+	conf.fetched = make(map[string]string)
 
-	f := file.Stat(conf.Rule.Model)
-
-	conf.modelFile = f
-
-	f = file.Stat(conf.Rule.Policy)
+	rule := conf.Rule
+	var files []*file.File
+	if rule.GetModel() != "" {
+		conf.modelFile = file.Stat(rule.GetModel())
+		files = append(files, conf.modelFile)
+	}
+	if rule.GetPolicy() != "" {
+		conf.policyFile = file.Stat(rule.GetPolicy())
+		files = append(files, conf.policyFile)
+	}
 
-	conf.policyFile = f
+	var urls []string
+	if rule.GetModelUrl() != "" {
+		urls = append(urls, rule.GetModelUrl())
+	}
+	if rule.GetPolicyUrl() != "" {
+		urls = append(urls, rule.GetPolicyUrl())
+	}
+	if len(urls) > 0 {
+		conf.client = &http.Client{Timeout: fetchTimeout}
+		for _, u := range urls {
+			content, err := conf.fetch(u)
+			if err != nil {
+				return err
+			}
+			conf.fetched[u] = content
+		}
+	}
 
-	e, err := casbin.NewEnforcer(conf.Rule.Model, conf.Rule.Policy)
+	e, err := conf.newEnforcer()
 	if err != nil {
 		return err
 	}
 	conf.enforcer = e
 
-	watcher, err := file.NewWatcher()
-	if err != nil {
-		return err
-	}
+	if len(files) > 0 {
+		watcher, err := file.NewWatcher()
+		if err != nil {
+			return err
+		}
 
-	conf.watcher = watcher
+		conf.watcher = watcher
 
-	err = conf.watcher.AddFiles(conf.modelFile, conf.policyFile)
-	if err != nil {
-		return err
+		err = conf.watcher.AddFiles(files...)
+		if err != nil {
+			return err
+		}
+
+		conf.watcher.Start(conf.reloadEnforcer)
 	}
 
-	conf.watcher.Start(conf.reloadEnforcer)
+	if len(urls) > 0 {
+		interval := defaultRefreshInterval
+		if rule.RefreshInterval != nil {
+			interval = rule.RefreshInterval.AsDuration()
+		}
+		conf.done = make(chan struct{})
+		go conf.refresh(urls, interval)
+	}
 
 	runtime.SetFinalizer(conf, func(conf *config) {
-		err := conf.watcher.Stop()
-		if err != nil {
-			api.LogErrorf("failed to stop watcher, err: %v", err)
+		if conf.watcher != nil {
+			err := conf.watcher.Stop()
+			if err != nil {
+				api.LogErrorf("failed to stop watcher, err: %v", err)
+			}
+		}
+		if conf.done != nil {
+			close(conf.done)
 		}
 	})
 	return nil
 }
 
+func (conf *config) fetch(url string) (string, error) {
+	resp, err := conf.client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch %s: %s", url, resp.Status)
+	}
+	b, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchedSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	if len(b) > maxFetchedSize {
+		return "", fmt.Errorf("failed to fetch %s: content is too large", url)
+	}
+	return string(b), nil
+}
+
+func (conf *config) getFetched(url string) string {
+	conf.fetchedLock.Lock()
+	defer conf.fetchedLock.Unlock()
+	return conf.fetched[url]
+}
+
+// refresh fetches the model and the policy from the URL periodically, and reloads the enforcer
+// once they are changed.
+func (conf *config) refresh(urls []string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			changed := false
+			for _, u := range urls {
+				content, err := conf.fetch(u)
+				if err != nil {
+					api.LogErrorf("failed to refresh casbin data: %v", err)
+					continue
+				}
+				conf.fetchedLock.Lock()
+				if conf.fetched[u] != content {
+					conf.fetched[u] = content
+					changed = true
+				}
+				conf.fetchedLock.Unlock()
+			}
+			if changed {
+				conf.reloadEnforcer()
+			}
+		case <-conf.done:
+			return
+		}
+	}
+}
+
+func (conf *config) newModel() (model.Model, error) {
+	rule := conf.Rule
+	switch {
+	case rule.GetModel() != "":
+		return model.NewModelFromFile(rule.GetModel())
+	case rule.GetModelText() != "":
+		return model.NewModelFromString(rule.GetModelText())
+	case rule.GetModelUrl() != "":
+		return model.NewModelFromString(conf.getFetched(rule.GetModelUrl()))
+	}
+	return nil, errors.New("model is required")
+}
+
+func (conf *config) newAdapter() (persist.Adapter, error) {
+	rule := conf.Rule
+	switch {
+	case rule.GetPolicy() != "":
+		return fileadapter.NewAdapter(rule.GetPolicy()), nil
+	case rule.GetPolicyText() != "":
+		return stringadapter.NewAdapter(rule.GetPolicyText()), nil
+	case rule.GetPolicyUrl() != "":
+		return stringadapter.NewAdapter(conf.getFetched(rule.GetPolicyUrl())), nil
+	}
+	return nil, errors.New("policy is required")
+}
+
+func (conf *config) newEnforcer() (*casbin.Enforcer, error) {
+	m, err := conf.newModel()
+	if err != nil {
+		return nil, err
+	}
+	adapter, err := conf.newAdapter()
+	if err != nil {
+		return nil, err
+	}
+	return casbin.NewEnforcer(m, adapter)
+}
+
 func (conf *config) reloadEnforcer() {
 	if !conf.updating.Load() {
 		conf.updating.Store(true)
-		api.LogWarnf("policy %s or model %s changed, reload enforcer", conf.policyFile.Name, conf.modelFile.Name)
+		api.LogWarnf("casbin model or policy changed, reload enforcer")
 
 		go func() {
 			defer func() {
@@ -108,14 +264,14 @@ func (conf *config) reloadEnforcer() {
 				}
 				conf.updating.Store(false)
 			}()
-			e, err := casbin.NewEnforcer(conf.Rule.Model, conf.Rule.Policy)
+			e, err := conf.newEnforcer()
 			if err != nil {
 				api.LogErrorf("failed to update Enforcer: %v", err)
 			} else {
 				conf.lock.Lock()
 				conf.enforcer = e
 				conf.lock.Unlock()
-				api.LogWarnf("policy %s or model %s changed, enforcer reloaded", conf.policyFile.Name, conf.modelFile.Name)
+				api.LogWarnf("casbin model or policy changed, enforcer reloaded")
 			}
 		}()
 	}
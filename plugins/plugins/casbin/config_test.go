@@ -46,6 +46,32 @@ func TestBadConfig(t *testing.T) {
 			}`,
 			err: "Policy: value length must be at least 1 runes",
 		},
+		{
+			name: "bad model url",
+			input: `{
+				"rule": {
+					"modelUrl": "/model.conf",
+					"policyText": "p, *, /, GET"
+				},
+				"token": {
+					"name": "role"
+				}
+			}`,
+			err: "ModelUrl: value must be absolute",
+		},
+		{
+			name: "token name is required",
+			input: `{
+				"rule": {
+					"model": "./config/model.conf",
+					"policy": "./config/policy.csv"
+				},
+				"token": {
+					"source": "JWT_CLAIM"
+				}
+			}`,
+			err: "token name is required",
+		},
 	}
 
 	for _, tt := range tests {
@@ -15,7 +15,10 @@
 package casbin
 
 import (
+	"strings"
+
 	"mosn.io/htnn/api/pkg/filtermanager/api"
+	casbintype "mosn.io/htnn/types/plugins/casbin"
 )
 
 func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
@@ -32,23 +35,67 @@ type filter struct {
 	config    *config
 }
 
+// subjects returns the subjects to enforce. The request is allowed if any of them is allowed.
+func (f *filter) subjects(headers api.RequestHeaderMap) []string {
+	token := f.config.Token
+	switch token.Source {
+	case casbintype.Config_CONSUMER:
+		if consumer := f.callbacks.GetConsumer(); consumer != nil {
+			return []string{consumer.Name()}
+		}
+	case casbintype.Config_JWT_CLAIM:
+		name := token.JwtHeader
+		if name == "" {
+			name = "authorization"
+		}
+		raw, _ := headers.Get(name)
+		if len(raw) > 7 && strings.EqualFold(raw[:7], "bearer ") {
+			raw = raw[7:]
+		}
+		if raw == "" {
+			break
+		}
+		subs, err := claimSubjects(raw, token.Name)
+		if err != nil {
+			api.LogInfof("failed to get subject from jwt: %v", err)
+			break
+		}
+		if len(subs) > 0 {
+			return subs
+		}
+	default:
+		role, _ := headers.Get(token.Name)
+		return []string{role}
+	}
+	// role can be ""
+	return []string{""}
+}
+
 func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
 	conf := f.config
-	role, _ := headers.Get(conf.Token.Name) // role can be ""
+	subs := f.subjects(headers)
 	url := headers.URL()
 
 	conf.lock.RLock()
-	ok, err := f.config.enforcer.Enforce(role, url.Path, headers.Method())
-	conf.lock.RUnlock()
-
-	if !ok {
+	allowed := false
+	for _, role := range subs {
+		ok, err := conf.enforcer.Enforce(role, url.Path, headers.Method())
+		if ok {
+			allowed = true
+			break
+		}
 		if err != nil {
 			api.LogErrorf("failed to enforce %s: %v", role, err)
 		}
-		api.LogInfof("reject forbidden user %s", role)
-		return &api.LocalResponse{
-			Code: 403,
-		}
 	}
-	return api.Continue
+	conf.lock.RUnlock()
+
+	if allowed {
+		return api.Continue
+	}
+
+	api.LogInfof("reject forbidden user %s", strings.Join(subs, ","))
+	return &api.LocalResponse{
+		Code: 403,
+	}
 }
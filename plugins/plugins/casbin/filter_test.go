@@ -15,11 +15,19 @@
 package casbin
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
@@ -64,13 +72,15 @@ func TestCasbin(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			cb := envoy.NewFilterCallbackHandler()
 			c := &config{
-				Config: casbin.Config{
-					Rule: &casbin.Config_Rule{
-						Model:  "./testdata/model.conf",
-						Policy: "./testdata/policy.csv",
-					},
-					Token: &casbin.Config_Token{
-						Name: "user",
+				CustomConfig: casbin.CustomConfig{
+					Config: casbin.Config{
+						Rule: &casbin.Config_Rule{
+							ModelSource:  &casbin.Config_Rule_Model{Model: "./testdata/model.conf"},
+							PolicySource: &casbin.Config_Rule_Policy{Policy: "./testdata/policy.csv"},
+						},
+						Token: &casbin.Config_Token{
+							Name: "user",
+						},
 					},
 				},
 			}
@@ -96,3 +106,175 @@ func TestCasbin(t *testing.T) {
 		})
 	}
 }
+
+func decode(conf *config, cb api.FilterCallbackHandler, header http.Header) int {
+	f := factory(conf, cb)
+	res := f.DecodeHeaders(envoy.NewRequestHeaderMap(header), true)
+	if lr, ok := res.(*api.LocalResponse); ok {
+		return lr.Code
+	}
+	return 200
+}
+
+func readFile(t *testing.T, name string) string {
+	b, err := os.ReadFile(name)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func jwtWithClaims(claims map[string]interface{}) string {
+	b, _ := json.Marshal(claims)
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString(b) + ".sig"
+}
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func TestCasbinInlineRule(t *testing.T) {
+	rule, _ := json.Marshal(map[string]string{
+		"modelText":  readFile(t, "./testdata/model.conf"),
+		"policyText": readFile(t, "./testdata/policy.csv"),
+	})
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"rule":`+string(rule)+`,"token":{"name":"user"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	assert.Equal(t, 200, decode(conf, envoy.NewFilterCallbackHandler(),
+		http.Header{"User": []string{"alice"}, ":path": []string{"/other"}}))
+	assert.Equal(t, 403, decode(conf, envoy.NewFilterCallbackHandler(),
+		http.Header{"User": []string{"bob"}, ":path": []string{"/other"}}))
+}
+
+func TestCasbinRuleFromURL(t *testing.T) {
+	var policy atomic.Value
+	policy.Store(readFile(t, "./testdata/policy.csv"))
+	model := readFile(t, "./testdata/model.conf")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/model.conf":
+			w.Write([]byte(model))
+		case "/policy.csv":
+			w.Write([]byte(policy.Load().(string)))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer srv.Close()
+
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"rule":{
+		"modelUrl": "`+srv.URL+`/model.conf",
+		"policyUrl": "`+srv.URL+`/policy.csv",
+		"refreshInterval": "1s"
+	},"token":{"name":"user"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	hdr := http.Header{"User": []string{"bob"}, ":path": []string{"/other"}}
+	assert.Equal(t, 403, decode(conf, envoy.NewFilterCallbackHandler(), hdr))
+
+	policy.Store(policy.Load().(string) + "\ng, bob, admin\n")
+	assert.Eventually(t, func() bool {
+		return decode(conf, envoy.NewFilterCallbackHandler(), hdr) == 200
+	}, 5*time.Second, 100*time.Millisecond)
+
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"rule":{
+		"model": "./testdata/model.conf",
+		"policyUrl": "`+srv.URL+`/not_found.csv"
+	},"token":{"name":"user"}}`), conf))
+	assert.ErrorContains(t, conf.Init(nil), "404 Not Found")
+}
+
+func TestCasbinSubjectFromConsumer(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"rule":{
+		"model": "./testdata/model.conf",
+		"policy": "./testdata/policy.csv"
+	},"token":{"source":"CONSUMER"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	hdr := http.Header{"User": []string{"alice"}, ":path": []string{"/other"}}
+	assert.Equal(t, 403, decode(conf, envoy.NewFilterCallbackHandler(), hdr))
+
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetConsumer(&testConsumer{name: "alice"})
+	assert.Equal(t, 200, decode(conf, cb, hdr))
+
+	cb = envoy.NewFilterCallbackHandler()
+	cb.SetConsumer(&testConsumer{name: "bob"})
+	assert.Equal(t, 403, decode(conf, cb, hdr))
+}
+
+func TestCasbinSubjectFromJWTClaim(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"rule":{
+		"model": "./testdata/model.conf",
+		"policy": "./testdata/policy.csv"
+	},"token":{"source":"JWT_CLAIM","name":"realm.roles"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	tests := []struct {
+		name   string
+		header http.Header
+		status int
+	}{
+		{
+			name: "nested claim",
+			header: http.Header{"Authorization": []string{"Bearer " + jwtWithClaims(map[string]interface{}{
+				"realm": map[string]interface{}{"roles": []string{"bob", "alice"}},
+			})}},
+			status: 200,
+		},
+		{
+			name: "not matched",
+			header: http.Header{"Authorization": []string{"Bearer " + jwtWithClaims(map[string]interface{}{
+				"realm": map[string]interface{}{"roles": "bob"},
+			})}},
+			status: 403,
+		},
+		{
+			name: "claim not found",
+			header: http.Header{"Authorization": []string{"Bearer " + jwtWithClaims(map[string]interface{}{
+				"sub": "alice",
+			})}},
+			status: 403,
+		},
+		{
+			name:   "malformed jwt",
+			header: http.Header{"Authorization": []string{"Bearer alice"}},
+			status: 403,
+		},
+		{
+			name:   "no jwt",
+			header: http.Header{},
+			status: 403,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.header.Set(":path", "/other")
+			assert.Equal(t, tt.status, decode(conf, envoy.NewFilterCallbackHandler(), tt.header))
+		})
+	}
+
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"rule":{
+		"model": "./testdata/model.conf",
+		"policy": "./testdata/policy.csv"
+	},"token":{"source":"JWT_CLAIM","name":"sub","jwtHeader":"x-jwt"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	hdr := http.Header{
+		"X-Jwt": []string{jwtWithClaims(map[string]interface{}{"sub": "alice"})},
+		":path": []string{"/other"},
+	}
+	assert.Equal(t, 200, decode(conf, envoy.NewFilterCallbackHandler(), hdr))
+}
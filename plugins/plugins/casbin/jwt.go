@@ -0,0 +1,74 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package casbin
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// claimSubjects gets the subjects from the claim of the JWT. The JWT is not verified, so it should
+// be verified by the authentication plugin before. The nested claim can be accessed with ".".
+func claimSubjects(raw string, name string) ([]string, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed jwt")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed jwt payload: %w", err)
+	}
+	var claims map[string]interface{}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("malformed jwt claims: %w", err)
+	}
+
+	v, ok := claims[name]
+	if !ok {
+		var cur interface{} = claims
+		for _, key := range strings.Split(name, ".") {
+			m, ok := cur.(map[string]interface{})
+			if !ok {
+				return nil, nil
+			}
+			cur, ok = m[key]
+			if !ok {
+				return nil, nil
+			}
+		}
+		v = cur
+	}
+
+	switch v := v.(type) {
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		subs := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				subs = append(subs, s)
+			}
+		}
+		return subs, nil
+	case nil:
+		return nil, nil
+	default:
+		return []string{fmt.Sprint(v)}, nil
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"sync"
+	"time"
+
+	"mosn.io/htnn/api/pkg/circuitbreaker"
+)
+
+type result int
+
+const (
+	resultSuccess result = iota
+	resultFailure
+	// the request is aborted before the response is received
+	resultAborted
+)
+
+const (
+	bucketNum = 10
+)
+
+type bucket struct {
+	start    int64
+	total    uint32
+	failures uint32
+	slows    uint32
+}
+
+// breaker tracks the requests in a sliding window, which is split into buckets. Unlike the
+// breaker in the api/pkg/circuitbreaker, which counts the consecutive failures of the calls
+// to an external service, this one trips on the percentage of the failed or slow requests.
+type breaker struct {
+	failurePercentage uint32
+	slowPercentage    uint32
+	minRequests       uint32
+	openDuration      time.Duration
+	halfOpenRequests  uint32
+	bucketWidth       int64
+
+	lock     sync.Mutex
+	state    circuitbreaker.State
+	openedAt time.Time
+	// generation is increased when the state changes, so that the result of the requests started
+	// in the previous state is ignored
+	generation uint64
+	probes     uint32
+	succeeded  uint32
+	buckets    [bucketNum]bucket
+}
+
+// setState should be called with the lock held
+func (b *breaker) setState(state circuitbreaker.State, now time.Time) {
+	b.state = state
+	b.generation++
+	b.probes = 0
+	b.succeeded = 0
+	b.buckets = [bucketNum]bucket{}
+	if state == circuitbreaker.StateOpen {
+		b.openedAt = now
+	}
+}
+
+// allow returns whether the request is allowed, and the generation of the breaker which should
+// be passed to the record. If the request is not allowed, the time to wait before retrying is
+// returned.
+func (b *breaker) allow(now time.Time) (ok bool, generation uint64, retryAfter time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state == circuitbreaker.StateOpen {
+		elapsed := now.Sub(b.openedAt)
+		if elapsed < b.openDuration {
+			return false, 0, b.openDuration - elapsed
+		}
+		b.setState(circuitbreaker.StateHalfOpen, now)
+	}
+
+	if b.state == circuitbreaker.StateHalfOpen {
+		if b.probes+b.succeeded >= b.halfOpenRequests {
+			// wait for the result of the probes
+			return false, 0, 0
+		}
+		b.probes++
+	}
+	return true, b.generation, 0
+}
+
+// record records the result of the request. It returns true if the breaker trips.
+func (b *breaker) record(now time.Time, generation uint64, res result, slow bool) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if generation != b.generation {
+		return false
+	}
+
+	if b.state == circuitbreaker.StateHalfOpen {
+		switch {
+		case res == resultAborted:
+			b.probes--
+		case res == resultFailure || slow:
+			b.setState(circuitbreaker.StateOpen, now)
+			return true
+		default:
+			b.probes--
+			b.succeeded++
+			if b.succeeded >= b.halfOpenRequests {
+				b.setState(circuitbreaker.StateClosed, now)
+			}
+		}
+		return false
+	}
+
+	if res == resultAborted {
+		return false
+	}
+
+	ts := now.UnixNano()
+	bucketStart := ts - ts%b.bucketWidth
+	bkt := &b.buckets[(ts/b.bucketWidth)%bucketNum]
+	if bkt.start != bucketStart {
+		*bkt = bucket{start: bucketStart}
+	}
+	bkt.total++
+	if res == resultFailure {
+		bkt.failures++
+	}
+	if slow {
+		bkt.slows++
+	}
+
+	var total, failures, slows uint32
+	windowStart := bucketStart - b.bucketWidth*(bucketNum-1)
+	for i := range b.buckets {
+		bkt := &b.buckets[i]
+		if bkt.start >= windowStart {
+			total += bkt.total
+			failures += bkt.failures
+			slows += bkt.slows
+		}
+	}
+	if total < b.minRequests {
+		return false
+	}
+	if (b.failurePercentage > 0 && uint64(failures)*100 >= uint64(total)*uint64(b.failurePercentage)) ||
+		(b.slowPercentage > 0 && uint64(slows)*100 >= uint64(total)*uint64(b.slowPercentage)) {
+		b.setState(circuitbreaker.StateOpen, now)
+		return true
+	}
+	return false
+}
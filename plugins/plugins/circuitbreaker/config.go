@@ -0,0 +1,100 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/circuitbreaker"
+)
+
+const (
+	defaultMinRequests      = 10
+	defaultWindow           = 10 * time.Second
+	defaultOpenDuration     = 30 * time.Second
+	defaultHalfOpenRequests = 1
+)
+
+func init() {
+	plugins.RegisterPlugin(circuitbreaker.Name, &plugin{})
+}
+
+type plugin struct {
+	circuitbreaker.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	circuitbreaker.CustomConfig
+
+	failureStatusCodes map[int]bool
+	slowThreshold      time.Duration
+	// As the configuration is per route, the breaker tracks the requests of the route
+	breaker *breaker
+	now     func() time.Time
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if len(conf.FailureStatusCodes) > 0 {
+		conf.failureStatusCodes = make(map[int]bool, len(conf.FailureStatusCodes))
+		for _, code := range conf.FailureStatusCodes {
+			conf.failureStatusCodes[int(code)] = true
+		}
+	}
+	if conf.SlowRequestThreshold != nil {
+		conf.slowThreshold = conf.SlowRequestThreshold.AsDuration()
+	}
+
+	b := &breaker{
+		failurePercentage: conf.FailurePercentage,
+		slowPercentage:    conf.SlowPercentage,
+		minRequests:       defaultMinRequests,
+		openDuration:      defaultOpenDuration,
+		halfOpenRequests:  defaultHalfOpenRequests,
+	}
+	if conf.MinRequests > 0 {
+		b.minRequests = conf.MinRequests
+	}
+	window := defaultWindow
+	if conf.Window != nil {
+		window = conf.Window.AsDuration()
+	}
+	b.bucketWidth = int64(window / bucketNum)
+	if conf.OpenDuration != nil {
+		b.openDuration = conf.OpenDuration.AsDuration()
+	}
+	if conf.HalfOpenRequests > 0 {
+		b.halfOpenRequests = conf.HalfOpenRequests
+	}
+	conf.breaker = b
+	conf.now = time.Now
+	return nil
+}
+
+func (conf *config) isFailure(status int) bool {
+	if conf.failureStatusCodes == nil {
+		return status >= 500
+	}
+	return conf.failureStatusCodes[status]
+}
@@ -0,0 +1,85 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "failure percentage",
+			input: `{"failurePercentage":50}`,
+		},
+		{
+			name:  "slow percentage",
+			input: `{"slowRequestThreshold":"1s","slowPercentage":50,"failureStatusCodes":[502,503],"window":"60s","openDuration":"10s","halfOpenRequests":3}`,
+		},
+		{
+			name:  "threshold is required",
+			input: `{"minRequests":3}`,
+			err:   "failurePercentage or slowPercentage is required",
+		},
+		{
+			name:  "bad percentage",
+			input: `{"failurePercentage":101}`,
+			err:   "invalid Config.FailurePercentage: value must be less than or equal to 100",
+		},
+		{
+			name:  "bad status code",
+			input: `{"failurePercentage":50,"failureStatusCodes":[600]}`,
+			err:   "invalid Config.FailureStatusCodes[0]: value must be inside range [100, 599]",
+		},
+		{
+			name:  "window is too small",
+			input: `{"failurePercentage":50,"window":"0.5s"}`,
+			err:   "invalid Config.Window: value must be greater than or equal to 1s",
+		},
+		{
+			name:  "slow threshold without percentage",
+			input: `{"failurePercentage":50,"slowRequestThreshold":"1s"}`,
+			err:   "slowRequestThreshold and slowPercentage should be set together",
+		},
+		{
+			name:  "slow percentage without threshold",
+			input: `{"slowPercentage":50}`,
+			err:   "slowRequestThreshold and slowPercentage should be set together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
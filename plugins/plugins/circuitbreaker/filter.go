@@ -0,0 +1,86 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	start      time.Time
+	generation uint64
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	now := f.config.now()
+	ok, generation, retryAfter := f.config.breaker.allow(now)
+	if !ok {
+		secs := int(math.Ceil(retryAfter.Seconds()))
+		if secs < 1 {
+			secs = 1
+		}
+		hdr := http.Header{}
+		hdr.Set("Retry-After", strconv.Itoa(secs))
+		return &api.LocalResponse{Code: 503, Msg: "circuit breaker is open", Header: hdr}
+	}
+
+	f.start = now
+	f.generation = generation
+	return api.Continue
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	if f.start.IsZero() {
+		// the request is not passed to the upstream by this plugin
+		return
+	}
+
+	now := f.config.now()
+	res := resultAborted
+	if respHeaders != nil {
+		s, _ := respHeaders.Get(":status")
+		status, err := strconv.Atoi(s)
+		if err == nil {
+			res = resultSuccess
+			if f.config.isFailure(status) {
+				res = resultFailure
+			}
+		}
+	}
+	slow := f.config.slowThreshold > 0 && now.Sub(f.start) > f.config.slowThreshold
+
+	if f.config.breaker.record(now, f.generation, res, slow) {
+		api.LogWarnf("circuit breaker of route %s is open", f.callbacks.StreamInfo().GetRouteName())
+	}
+}
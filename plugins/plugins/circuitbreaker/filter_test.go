@@ -0,0 +1,175 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package circuitbreaker
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newConfig(t *testing.T, input string) (*config, *clock) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+	require.NoError(t, conf.Init(nil))
+	c := &clock{now: time.Unix(1700000000, 0)}
+	conf.now = c.Now
+	return conf, c
+}
+
+type request struct {
+	f   api.Filter
+	hdr api.RequestHeaderMap
+	res api.ResultAction
+}
+
+func send(conf *config) *request {
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":authority": {"test.local"},
+		":method":    {"GET"},
+		":path":      {"/"},
+	})
+	return &request{f: f, hdr: hdr, res: f.DecodeHeaders(hdr, true)}
+}
+
+// finish ends the request with the given status. The status 0 means the request is aborted.
+func (r *request) finish(status int) {
+	var respHdr api.ResponseHeaderMap
+	if status != 0 {
+		respHdr = envoy.NewResponseHeaderMap(http.Header{":status": {strconv.Itoa(status)}})
+	}
+	r.f.OnLog(r.hdr, nil, respHdr, nil)
+}
+
+func (r *request) rejected(t *testing.T) string {
+	t.Helper()
+	resp, ok := r.res.(*api.LocalResponse)
+	require.True(t, ok, "request should be rejected")
+	assert.Equal(t, 503, resp.Code)
+	return resp.Header.Get("Retry-After")
+}
+
+func call(conf *config, status int) {
+	r := send(conf)
+	if r.res == api.Continue {
+		r.finish(status)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	conf, c := newConfig(t, `{"failurePercentage":50,"minRequests":4,"openDuration":"5s","halfOpenRequests":2}`)
+
+	call(conf, 200)
+	call(conf, 500)
+	call(conf, 200)
+	// aborted requests are not counted
+	call(conf, 0)
+	assert.Equal(t, api.Continue, send(conf).res)
+	call(conf, 503)
+
+	assert.Equal(t, "5", send(conf).rejected(t))
+	c.Advance(3500 * time.Millisecond)
+	assert.Equal(t, "2", send(conf).rejected(t))
+
+	// half-open
+	c.Advance(2 * time.Second)
+	p1 := send(conf)
+	p2 := send(conf)
+	assert.Equal(t, api.Continue, p1.res)
+	assert.Equal(t, api.Continue, p2.res)
+	assert.Equal(t, "1", send(conf).rejected(t))
+	p1.finish(200)
+	assert.Equal(t, "1", send(conf).rejected(t))
+	// the aborted probe is replaced by another one
+	p2.finish(0)
+	p3 := send(conf)
+	assert.Equal(t, api.Continue, p3.res)
+	p3.finish(204)
+
+	// closed and the stats are reset
+	call(conf, 500)
+	call(conf, 500)
+	call(conf, 500)
+	assert.Equal(t, api.Continue, send(conf).res)
+	call(conf, 500)
+	assert.Equal(t, "5", send(conf).rejected(t))
+
+	// the failed probe opens the breaker again
+	c.Advance(5 * time.Second)
+	p1 = send(conf)
+	p2 = send(conf)
+	p1.finish(502)
+	assert.Equal(t, "5", send(conf).rejected(t))
+	// the stale probe is ignored
+	p2.finish(200)
+	c.Advance(5 * time.Second)
+	p1 = send(conf)
+	assert.Equal(t, api.Continue, p1.res)
+	assert.Equal(t, api.Continue, send(conf).res)
+	assert.Equal(t, "1", send(conf).rejected(t))
+}
+
+func TestCircuitBreakerWindow(t *testing.T) {
+	conf, c := newConfig(t, `{"failurePercentage":50,"minRequests":2,"window":"10s"}`)
+
+	call(conf, 500)
+	c.Advance(10 * time.Second)
+	// the previous failure is out of the window
+	call(conf, 200)
+	call(conf, 200)
+	call(conf, 500)
+	assert.Equal(t, api.Continue, send(conf).res)
+
+	c.Advance(9 * time.Second)
+	call(conf, 500)
+	assert.Equal(t, "30", send(conf).rejected(t))
+}
+
+func TestCircuitBreakerStatusCodesAndSlowRequests(t *testing.T) {
+	conf, c := newConfig(t, `{"failurePercentage":100,"failureStatusCodes":[429],"slowRequestThreshold":"1s","slowPercentage":50,"minRequests":2}`)
+
+	call(conf, 500)
+	call(conf, 429)
+	call(conf, 503)
+	assert.Equal(t, api.Continue, send(conf).res)
+
+	for i := 0; i < 3; i++ {
+		r := send(conf)
+		c.Advance(1500 * time.Millisecond)
+		r.finish(200)
+	}
+	assert.Equal(t, "30", send(conf).rejected(t))
+}
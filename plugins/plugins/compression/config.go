@@ -0,0 +1,134 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/compression"
+)
+
+const (
+	defaultMinSize     = 1024
+	defaultMaxBodySize = 1 << 20
+)
+
+var (
+	defaultEncodings = []compression.Encoding{
+		compression.Encoding_ZSTD,
+		compression.Encoding_BR,
+		compression.Encoding_GZIP,
+	}
+	defaultContentTypes = []string{
+		"text/html",
+		"text/plain",
+		"text/css",
+		"text/javascript",
+		"text/xml",
+		"application/javascript",
+		"application/json",
+		"application/xml",
+		"image/svg+xml",
+	}
+)
+
+func init() {
+	plugins.RegisterPlugin(compression.Name, &plugin{})
+}
+
+type plugin struct {
+	compression.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	compression.Config
+
+	encoders     []*encoder
+	minSize      int
+	maxBodySize  int
+	contentTypes map[string]bool
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	encodings := conf.Encodings
+	if len(encodings) == 0 {
+		encodings = defaultEncodings
+	}
+	for _, e := range encodings {
+		conf.encoders = append(conf.encoders, encoders[e])
+	}
+
+	conf.minSize = defaultMinSize
+	if conf.MinSize > 0 {
+		conf.minSize = int(conf.MinSize)
+	}
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+
+	contentTypes := conf.ContentTypes
+	if len(contentTypes) == 0 {
+		contentTypes = defaultContentTypes
+	}
+	conf.contentTypes = make(map[string]bool, len(contentTypes))
+	for _, ct := range contentTypes {
+		conf.contentTypes[strings.ToLower(ct)] = true
+	}
+	return nil
+}
+
+// chooseEncoder picks the most preferred encoder accepted by the client. The encodings
+// explicitly rejected with `q=0` are skipped.
+func (conf *config) chooseEncoder(acceptEncoding string) *encoder {
+	accepted := map[string]bool{}
+	wildcard := false
+	for _, item := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(item, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		ok := true
+		for _, p := range strings.Split(params, ";") {
+			k, v, found := strings.Cut(strings.TrimSpace(p), "=")
+			if found && strings.TrimSpace(k) == "q" {
+				q, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+				ok = err == nil && q > 0
+			}
+		}
+		if name == "*" {
+			wildcard = ok
+			continue
+		}
+		accepted[name] = ok
+	}
+
+	for _, e := range conf.encoders {
+		ok, found := accepted[e.name]
+		if ok || (!found && wildcard) {
+			return e
+		}
+	}
+	return nil
+}
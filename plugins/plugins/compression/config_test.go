@@ -0,0 +1,101 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "default",
+			input: `{}`,
+		},
+		{
+			name:  "bad encoding",
+			input: `{"encodings":["deflate"]}`,
+			err:   "invalid value for enum",
+		},
+		{
+			name:  "bad content type",
+			input: `{"contentTypes":[""]}`,
+			err:   "invalid Config.ContentTypes[0]",
+		},
+		{
+			name:  "ok",
+			input: `{"encodings":["GZIP"], "minSize":1, "maxBodySize":100, "contentTypes":["text/plain"], "decompressRequest":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestChooseEncoder(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, conf.Init(nil))
+
+	tests := []struct {
+		acceptEncoding string
+		encoding       string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"gzip, deflate, br", "br"},
+		{"gzip, br;q=0.5, zstd", "zstd"},
+		{"gzip, zstd;q=0, BR;q=0.1", "br"},
+		{"*", "zstd"},
+		{"*, zstd;q=0", "br"},
+		{"*;q=0, gzip", "gzip"},
+	}
+	for _, tt := range tests {
+		e := conf.chooseEncoder(tt.acceptEncoding)
+		if tt.encoding == "" {
+			assert.Nil(t, e, tt.acceptEncoding)
+		} else if assert.NotNil(t, e, tt.acceptEncoding) {
+			assert.Equal(t, tt.encoding, e.name, tt.acceptEncoding)
+		}
+	}
+
+	conf = &config{}
+	conf.Encodings = defaultEncodings[2:]
+	require.NoError(t, conf.Init(nil))
+	assert.Nil(t, conf.chooseEncoder("br, zstd"))
+}
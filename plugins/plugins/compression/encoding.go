@@ -0,0 +1,120 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+
+	"mosn.io/htnn/types/plugins/compression"
+)
+
+type encoder struct {
+	name      string
+	newWriter func(w io.Writer) (io.WriteCloser, error)
+	newReader func(r io.Reader) (io.ReadCloser, error)
+}
+
+type zstdReader struct {
+	*zstd.Decoder
+}
+
+func (r *zstdReader) Close() error {
+	r.Decoder.Close()
+	return nil
+}
+
+var encoders = map[compression.Encoding]*encoder{
+	compression.Encoding_GZIP: {
+		name: "gzip",
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(w), nil
+		},
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			return gzip.NewReader(r)
+		},
+	},
+	compression.Encoding_BR: {
+		name: "br",
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return brotli.NewWriter(w), nil
+		},
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			return io.NopCloser(brotli.NewReader(r)), nil
+		},
+	},
+	compression.Encoding_ZSTD: {
+		name: "zstd",
+		newWriter: func(w io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+		},
+		newReader: func(r io.Reader) (io.ReadCloser, error) {
+			d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+			if err != nil {
+				return nil, err
+			}
+			return &zstdReader{d}, nil
+		},
+	},
+}
+
+func encoderByName(name string) *encoder {
+	for _, e := range encoders {
+		if e.name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+func (e *encoder) compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := e.newWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	_, err = w.Write(data)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress returns errTooLarge if the decompressed data is larger than the limit
+func (e *encoder) decompress(data []byte, limit int) ([]byte, error) {
+	r, err := e.newReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	// read one more byte to know if the data exceeds the limit
+	res, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(res) > limit {
+		return nil, errTooLarge
+	}
+	return res, nil
+}
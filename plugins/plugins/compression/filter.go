@@ -0,0 +1,163 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/sse"
+)
+
+var errTooLarge = errors.New("decompressed body is too large")
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	acceptEncoding string
+	decoder        *encoder
+	encoder        *encoder
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.acceptEncoding, _ = headers.Get("accept-encoding")
+
+	if !f.config.DecompressRequest || endStream {
+		return api.Continue
+	}
+	enc, ok := headers.Get("content-encoding")
+	if !ok {
+		return api.Continue
+	}
+	f.decoder = encoderByName(strings.ToLower(strings.TrimSpace(enc)))
+	if f.decoder == nil {
+		// let the upstream handle the unknown encodings
+		return api.Continue
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	if data == nil || data.Len() == 0 {
+		headers.Del("content-encoding")
+		return api.Continue
+	}
+
+	body, err := f.decoder.decompress(data.Bytes(), f.config.maxBodySize)
+	if err != nil {
+		if errors.Is(err, errTooLarge) {
+			return &api.LocalResponse{Code: http.StatusRequestEntityTooLarge}
+		}
+		api.LogInfof("compression: failed to decompress request body: %v", err)
+		return &api.LocalResponse{Code: http.StatusBadRequest, Msg: "bad compressed body"}
+	}
+	_ = data.Set(body)
+	headers.Del("content-encoding")
+	headers.Set("content-length", strconv.Itoa(len(body)))
+	return api.Continue
+}
+
+// compressible checks if the response should be compressed according to the headers
+func (f *filter) compressible(headers api.ResponseHeaderMap) bool {
+	config := f.config
+	if _, ok := headers.Get("content-encoding"); ok {
+		return false
+	}
+	if sse.IsEventStream(headers) {
+		// the event stream may never end, so it can't be buffered
+		return false
+	}
+	for _, cc := range headers.Values("cache-control") {
+		if strings.Contains(strings.ToLower(cc), "no-transform") {
+			return false
+		}
+	}
+	ct, ok := headers.Get("content-type")
+	if !ok {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil || !config.contentTypes[mediaType] {
+		return false
+	}
+	return true
+}
+
+func addVary(headers api.ResponseHeaderMap) {
+	for _, v := range headers.Values("vary") {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			if name == "*" || strings.EqualFold(name, "accept-encoding") {
+				return
+			}
+		}
+	}
+	headers.Add("vary", "Accept-Encoding")
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if endStream || !f.compressible(headers) {
+		return api.Continue
+	}
+	// the response varies with the Accept-Encoding, no matter whether it's compressed
+	addVary(headers)
+
+	config := f.config
+	if cl, ok := headers.Get("content-length"); ok {
+		n, err := strconv.Atoi(cl)
+		if err == nil && (n < config.minSize || n > config.maxBodySize) {
+			return api.Continue
+		}
+	}
+	f.encoder = config.chooseEncoder(f.acceptEncoding)
+	if f.encoder == nil {
+		return api.Continue
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	if data == nil || data.Len() < f.config.minSize || data.Len() > f.config.maxBodySize {
+		return api.Continue
+	}
+
+	body, err := f.encoder.compress(data.Bytes())
+	if err != nil {
+		api.LogErrorf("compression: failed to compress response body: %v", err)
+		return api.Continue
+	}
+	_ = data.Set(body)
+	headers.Set("content-encoding", f.encoder.name)
+	headers.Set("content-length", strconv.Itoa(len(body)))
+	// the compressed body is no longer byte-for-byte identical
+	if etag, ok := headers.Get("etag"); ok && !strings.HasPrefix(etag, "W/") {
+		headers.Set("etag", "W/"+etag)
+	}
+	return api.Continue
+}
@@ -0,0 +1,187 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestCompressResponse(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{}`), conf))
+	require.NoError(t, conf.Init(nil))
+	body := strings.Repeat("hello world ", 200)
+
+	for _, name := range []string{"gzip", "br", "zstd"} {
+		t.Run(name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+			h := http.Header{}
+			h.Set("accept-encoding", name)
+			f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+
+			rh := http.Header{}
+			rh.Set("content-type", "text/html; charset=utf-8")
+			rh.Set("etag", `"abc"`)
+			rh.Set("vary", "Origin")
+			hdr := envoy.NewResponseHeaderMap(rh)
+			require.Equal(t, api.WaitAllData, f.EncodeHeaders(hdr, false))
+			buf := envoy.NewBufferInstance([]byte(body))
+			require.Equal(t, api.Continue, f.EncodeResponse(hdr, buf, nil))
+
+			v, _ := hdr.Get("content-encoding")
+			assert.Equal(t, name, v)
+			v, _ = hdr.Get("content-length")
+			assert.Equal(t, strconv.Itoa(buf.Len()), v)
+			v, _ = hdr.Get("etag")
+			assert.Equal(t, `W/"abc"`, v)
+			assert.Equal(t, []string{"Origin", "Accept-Encoding"}, hdr.Values("vary"))
+			assert.Less(t, buf.Len(), len(body))
+
+			decompressed, err := encoderByName(name).decompress(buf.Bytes(), len(body))
+			require.NoError(t, err)
+			assert.Equal(t, body, string(decompressed))
+		})
+	}
+}
+
+func TestSkipCompression(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"encodings":["GZIP"], "minSize":10, "maxBodySize":100}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		header         map[string]string
+		body           string
+		wait           bool
+	}{
+		{
+			name:           "not accepted",
+			acceptEncoding: "br",
+			header:         map[string]string{"content-type": "text/plain"},
+		},
+		{
+			name:           "content type mismatched",
+			acceptEncoding: "gzip",
+			header:         map[string]string{"content-type": "image/png"},
+		},
+		{
+			name:           "already compressed",
+			acceptEncoding: "gzip",
+			header:         map[string]string{"content-type": "text/plain", "content-encoding": "br"},
+		},
+		{
+			name:           "no-transform",
+			acceptEncoding: "gzip",
+			header:         map[string]string{"content-type": "text/plain", "cache-control": "no-transform"},
+		},
+		{
+			name:           "too small",
+			acceptEncoding: "gzip",
+			header:         map[string]string{"content-type": "text/plain", "content-length": "5"},
+		},
+		{
+			name:           "too large",
+			acceptEncoding: "gzip",
+			header:         map[string]string{"content-type": "text/plain", "content-length": "101"},
+		},
+		{
+			name:           "too small without content-length",
+			acceptEncoding: "gzip",
+			header:         map[string]string{"content-type": "text/plain"},
+			body:           "hello",
+			wait:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+			h := http.Header{}
+			h.Set("accept-encoding", tt.acceptEncoding)
+			f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+
+			rh := http.Header{}
+			for k, v := range tt.header {
+				rh.Set(k, v)
+			}
+			hdr := envoy.NewResponseHeaderMap(rh)
+			res := f.EncodeHeaders(hdr, false)
+			if !tt.wait {
+				assert.Equal(t, api.Continue, res)
+				return
+			}
+			require.Equal(t, api.WaitAllData, res)
+			buf := envoy.NewBufferInstance([]byte(tt.body))
+			f.EncodeResponse(hdr, buf, nil)
+			assert.Equal(t, tt.body, buf.String())
+			_, ok := hdr.Get("content-encoding")
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestDecompressRequest(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"decompressRequest":true, "maxBodySize":100}`), conf))
+	require.NoError(t, conf.Init(nil))
+	body := strings.Repeat("a", 100)
+	compressed, err := encoderByName("zstd").compress([]byte(body))
+	require.NoError(t, err)
+
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	h := http.Header{}
+	h.Set("content-encoding", "zstd")
+	hdr := envoy.NewRequestHeaderMap(h)
+	require.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+	buf := envoy.NewBufferInstance(compressed)
+	require.Equal(t, api.Continue, f.DecodeRequest(hdr, buf, nil))
+	assert.Equal(t, body, buf.String())
+	_, ok := hdr.Get("content-encoding")
+	assert.False(t, ok)
+	v, _ := hdr.Get("content-length")
+	assert.Equal(t, "100", v)
+
+	// too large
+	compressed, err = encoderByName("gzip").compress([]byte(body + "a"))
+	require.NoError(t, err)
+	h.Set("content-encoding", "gzip")
+	hdr = envoy.NewRequestHeaderMap(h)
+	require.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+	res := f.DecodeRequest(hdr, envoy.NewBufferInstance(compressed), nil)
+	assert.Equal(t, 413, res.(*api.LocalResponse).Code)
+
+	// bad data
+	h.Set("content-encoding", "br")
+	hdr = envoy.NewRequestHeaderMap(h)
+	require.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+	res = f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte("not compressed")), nil)
+	assert.Equal(t, 400, res.(*api.LocalResponse).Code)
+
+	// unknown encoding
+	h.Set("content-encoding", "deflate")
+	assert.Equal(t, api.Continue, f.DecodeHeaders(envoy.NewRequestHeaderMap(h), false))
+}
@@ -0,0 +1,74 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlationid
+
+import (
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/correlationid"
+)
+
+const (
+	defaultHeader = "x-correlation-id"
+	// the incoming ID longer than this is ignored
+	maxIDLength = 128
+)
+
+func init() {
+	plugins.RegisterPlugin(correlationid.Name, &plugin{})
+}
+
+type plugin struct {
+	correlationid.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	correlationid.Config
+
+	header   string
+	generate func() string
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.header = defaultHeader
+	if conf.Header != "" {
+		conf.header = conf.Header
+	}
+
+	switch conf.Generator {
+	case correlationid.Generator_ULID:
+		conf.generate = func() string {
+			return newULID(time.Now())
+		}
+	case correlationid.Generator_SNOWFLAKE:
+		s := getSnowflake(conf.WorkerId)
+		conf.generate = func() string {
+			return s.next(time.Now())
+		}
+	default:
+		conf.generate = newUUIDv7
+	}
+	return nil
+}
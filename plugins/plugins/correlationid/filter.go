@@ -0,0 +1,81 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlationid
+
+import (
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/correlationid"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	id string
+}
+
+func isValidID(id string) bool {
+	if id == "" || len(id) > maxIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		// visible ASCII characters
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	id := ""
+	if !config.Regenerate {
+		id, _ = headers.Get(config.header)
+		if !isValidID(id) {
+			id = ""
+		}
+	}
+	if id == "" {
+		id = config.generate()
+		headers.Set(config.header, id)
+	}
+	if config.OverrideRequestId {
+		headers.Set("x-request-id", id)
+	}
+
+	f.id = id
+	// expose the ID to other plugins
+	f.callbacks.PluginState().Set(correlationid.Name, "id", id)
+	// expose the ID to the access log, via %DYNAMIC_METADATA(htnn:correlation_id)%
+	f.callbacks.StreamInfo().DynamicMetadata().Set("htnn", "correlation_id", id)
+	return api.Continue
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.config.IncludeInResponse && f.id != "" {
+		headers.Set(f.config.header, f.id)
+	}
+	return api.Continue
+}
@@ -0,0 +1,140 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlationid
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestCorrelationID(t *testing.T) {
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	ulidPattern := regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`)
+	snowflakePattern := regexp.MustCompile(`^[0-9]+$`)
+
+	tests := []struct {
+		name     string
+		input    string
+		header   http.Header
+		respName string
+		pattern  *regexp.Regexp
+		expect   string
+	}{
+		{
+			name:     "generate UUIDv7",
+			input:    `{}`,
+			respName: "x-correlation-id",
+			pattern:  uuidPattern,
+		},
+		{
+			name:     "generate ULID",
+			input:    `{"generator":"ULID","header":"x-trace"}`,
+			respName: "x-trace",
+			pattern:  ulidPattern,
+		},
+		{
+			name:     "generate snowflake",
+			input:    `{"generator":"SNOWFLAKE"}`,
+			respName: "x-correlation-id",
+			pattern:  snowflakePattern,
+		},
+		{
+			name:     "propagate",
+			input:    `{}`,
+			header:   http.Header{"X-Correlation-Id": {"abc-123"}},
+			respName: "x-correlation-id",
+			expect:   "abc-123",
+		},
+		{
+			name:     "regenerate",
+			input:    `{"regenerate":true}`,
+			header:   http.Header{"X-Correlation-Id": {"abc-123"}},
+			respName: "x-correlation-id",
+			pattern:  uuidPattern,
+		},
+		{
+			name:     "invalid incoming ID",
+			input:    `{}`,
+			header:   http.Header{"X-Correlation-Id": {strings.Repeat("a", maxIDLength+1)}},
+			respName: "x-correlation-id",
+			pattern:  uuidPattern,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			require.NoError(t, conf.Validate())
+			require.NoError(t, conf.Init(nil))
+
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb)
+			h := http.Header{}
+			for k, v := range tt.header {
+				h[k] = v
+			}
+			hdr := envoy.NewRequestHeaderMap(h)
+			assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+
+			id, _ := hdr.Get(tt.respName)
+			if tt.expect != "" {
+				assert.Equal(t, tt.expect, id)
+			} else {
+				assert.Regexp(t, tt.pattern, id)
+			}
+			assert.Equal(t, id, cb.PluginState().Get("correlationId", "id"))
+			v, _ := cb.StreamInfo().DynamicMetadata().GetString("htnn", "correlation_id")
+			assert.Equal(t, id, v)
+			_, ok := hdr.Get("x-request-id")
+			assert.False(t, ok)
+
+			// not included in response by default
+			rspHdr := envoy.NewResponseHeaderMap(http.Header{})
+			assert.Equal(t, api.Continue, f.EncodeHeaders(rspHdr, true))
+			_, ok = rspHdr.Get(tt.respName)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestCorrelationIDInResponse(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"includeInResponse":true,"overrideRequestId":true}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		"X-Correlation-Id": {"abc-123"},
+		"X-Request-Id":     {"generated-by-envoy"},
+	})
+	f.DecodeHeaders(hdr, true)
+	v, _ := hdr.Get("x-request-id")
+	assert.Equal(t, "abc-123", v)
+
+	rspHdr := envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(rspHdr, true)
+	v, _ = rspHdr.Get("x-correlation-id")
+	assert.Equal(t, "abc-123", v)
+}
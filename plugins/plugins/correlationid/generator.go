@@ -0,0 +1,115 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlationid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func newUUIDv7() string {
+	id, err := uuid.NewV7()
+	if err != nil {
+		// only happens when the random source is broken
+		api.LogErrorf("failed to generate UUIDv7: %v", err)
+		return uuid.NewString()
+	}
+	return id.String()
+}
+
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID generates a ULID, which contains a 48 bits timestamp in milliseconds and 80 bits randomness,
+// encoded in Crockford's base32.
+func newULID(now time.Time) string {
+	var b [16]byte
+	ms := uint64(now.UnixMilli())
+	b[0] = byte(ms >> 40)
+	b[1] = byte(ms >> 32)
+	binary.BigEndian.PutUint32(b[2:], uint32(ms))
+	_, _ = rand.Read(b[6:])
+
+	// 128 bits are encoded into 26 characters, 5 bits per character. The first character
+	// only contains 3 bits.
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockfordBase32[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+const (
+	// 2024-01-01T00:00:00Z
+	snowflakeEpoch    = 1704067200000
+	snowflakeSeqBits  = 12
+	snowflakeWorkBits = 10
+	snowflakeMaxSeq   = 1<<snowflakeSeqBits - 1
+)
+
+// snowflake generates 63 bits IDs, which contain a 41 bits timestamp in milliseconds, a 10 bits worker ID
+// and a 12 bits sequence number.
+type snowflake struct {
+	workerID uint64
+
+	lock   sync.Mutex
+	lastMs int64
+	seq    uint64
+}
+
+// The generators are shared by the configurations with the same worker ID, so that the IDs
+// generated for different routes don't conflict.
+var snowflakes sync.Map
+
+func getSnowflake(workerID uint32) *snowflake {
+	v, ok := snowflakes.Load(workerID)
+	if !ok {
+		v, _ = snowflakes.LoadOrStore(workerID, &snowflake{workerID: uint64(workerID)})
+	}
+	return v.(*snowflake)
+}
+
+func (s *snowflake) next(now time.Time) string {
+	s.lock.Lock()
+	ms := now.UnixMilli() - snowflakeEpoch
+	if ms < s.lastMs {
+		// the clock goes backward, keep using the last timestamp
+		ms = s.lastMs
+	}
+	if ms == s.lastMs {
+		s.seq++
+		if s.seq > snowflakeMaxSeq {
+			// borrow the next millisecond instead of waiting
+			ms++
+			s.seq = 0
+		}
+	} else {
+		s.seq = 0
+	}
+	s.lastMs = ms
+	id := uint64(ms)<<(snowflakeWorkBits+snowflakeSeqBits) | s.workerID<<snowflakeSeqBits | s.seq
+	s.lock.Unlock()
+	return strconv.FormatUint(id, 10)
+}
@@ -0,0 +1,99 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlationid
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUIDv7(t *testing.T) {
+	id, err := uuid.Parse(newUUIDv7())
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(7), id.Version())
+}
+
+func TestULID(t *testing.T) {
+	now := time.UnixMilli(1469918176385)
+	id := newULID(now)
+	assert.Len(t, id, 26)
+	// the timestamp part from the ULID spec
+	assert.Equal(t, "01ARYZ6S41", id[:10])
+	assert.NotEqual(t, id, newULID(now))
+}
+
+func TestSnowflake(t *testing.T) {
+	s := &snowflake{workerID: 5}
+	now := time.UnixMilli(snowflakeEpoch + 1000)
+
+	parse := func(id string) (ms uint64, worker uint64, seq uint64) {
+		n, err := strconv.ParseUint(id, 10, 64)
+		require.NoError(t, err)
+		return n >> 22, n >> 12 & 0x3ff, n & 0xfff
+	}
+
+	ms, worker, seq := parse(s.next(now))
+	assert.Equal(t, uint64(1000), ms)
+	assert.Equal(t, uint64(5), worker)
+	assert.Equal(t, uint64(0), seq)
+	_, _, seq = parse(s.next(now))
+	assert.Equal(t, uint64(1), seq)
+
+	// clock goes backward
+	ms, _, seq = parse(s.next(now.Add(-time.Second)))
+	assert.Equal(t, uint64(1000), ms)
+	assert.Equal(t, uint64(2), seq)
+
+	// sequence overflows
+	for i := 0; i < snowflakeMaxSeq-2; i++ {
+		s.next(now)
+	}
+	ms, _, seq = parse(s.next(now))
+	assert.Equal(t, uint64(1001), ms)
+	assert.Equal(t, uint64(0), seq)
+
+	ms, _, seq = parse(s.next(now.Add(10 * time.Millisecond)))
+	assert.Equal(t, uint64(1010), ms)
+	assert.Equal(t, uint64(0), seq)
+}
+
+func TestSnowflakeUnique(t *testing.T) {
+	s := getSnowflake(1)
+	assert.Same(t, s, getSnowflake(1))
+
+	var lock sync.Mutex
+	seen := map[string]bool{}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5000; j++ {
+				id := s.next(time.Now())
+				lock.Lock()
+				seen[id] = true
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 20000)
+}
@@ -0,0 +1,144 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/csrf"
+)
+
+const (
+	defaultCookieName = "htnn_csrf"
+	defaultCookiePath = "/"
+	defaultHeaderName = "x-csrf-token"
+)
+
+func init() {
+	plugins.RegisterPlugin(csrf.Name, &plugin{})
+}
+
+type plugin struct {
+	csrf.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	csrf.Config
+
+	cookieName  string
+	headerName  string
+	exemptRules []*exemptRule
+}
+
+type exemptRule struct {
+	// path is nil if all paths are matched
+	path expr.Matcher
+	// methods is empty if all methods are matched
+	methods map[string]bool
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.cookieName = defaultCookieName
+	if c := conf.Cookie; c != nil && c.Name != "" {
+		conf.cookieName = c.Name
+	}
+	conf.headerName = defaultHeaderName
+	if conf.HeaderName != "" {
+		conf.headerName = conf.HeaderName
+	}
+
+	conf.exemptRules = make([]*exemptRule, 0, len(conf.ExemptPaths))
+	for _, p := range conf.ExemptPaths {
+		if p.Path == nil && len(p.Methods) == 0 {
+			return errors.New("exempt path should specify either path or methods")
+		}
+
+		rule := &exemptRule{}
+		if p.Path != nil {
+			m, err := expr.BuildStringMatcher(p.Path)
+			if err != nil {
+				return err
+			}
+			rule.path = m
+		}
+		if len(p.Methods) > 0 {
+			rule.methods = make(map[string]bool, len(p.Methods))
+			for _, method := range p.Methods {
+				rule.methods[method] = true
+			}
+		}
+		conf.exemptRules = append(conf.exemptRules, rule)
+	}
+	return nil
+}
+
+func (r *exemptRule) match(headers api.RequestHeaderMap) bool {
+	if len(r.methods) > 0 && !r.methods[headers.Method()] {
+		return false
+	}
+	if r.path != nil && !r.path.Match(headers.URL().Path) {
+		return false
+	}
+	return true
+}
+
+// exempt returns true if the request doesn't need to be verified
+func (conf *config) exempt(headers api.RequestHeaderMap) bool {
+	for _, r := range conf.exemptRules {
+		if r.match(headers) {
+			return true
+		}
+	}
+	return false
+}
+
+func (conf *config) sign(nonce string) string {
+	mac := hmac.New(sha256.New, []byte(conf.Secret))
+	mac.Write([]byte(nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// generateToken generates a token in the format of "nonce.signature", so that the token set by
+// the other sites, like a compromised subdomain, can be detected.
+func (conf *config) generateToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	nonce := base64.RawURLEncoding.EncodeToString(b)
+	return nonce + "." + conf.sign(nonce)
+}
+
+func (conf *config) verifyToken(token string) bool {
+	nonce, sig, found := strings.Cut(token, ".")
+	if !found || nonce == "" {
+		return false
+	}
+	return hmac.Equal([]byte(sig), []byte(conf.sign(nonce)))
+}
@@ -0,0 +1,95 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+			err:   "invalid Config.Secret: value length must be at least 1 runes",
+		},
+		{
+			name:  "bad cookie name",
+			input: `{"secret":"s", "cookie":{"name":"a b"}}`,
+			err:   "invalid Cookie.Name: value does not match regex pattern",
+		},
+		{
+			name:  "bad method",
+			input: `{"secret":"s", "exemptPaths":[{"methods":["post"]}]}`,
+			err:   "invalid ExemptPath.Methods[0]: value does not match regex pattern",
+		},
+		{
+			name:  "empty exempt path",
+			input: `{"secret":"s", "exemptPaths":[{}]}`,
+			err:   "exempt path should specify either path or methods",
+		},
+		{
+			name:  "bad regex",
+			input: `{"secret":"s", "exemptPaths":[{"path":{"regex":"("}}]}`,
+			err:   "missing closing )",
+		},
+		{
+			name:  "ok",
+			input: `{"secret":"s", "exemptPaths":[{"path":{"prefix":"/webhook/"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	conf := &config{}
+	conf.Secret = "secret"
+	token := conf.generateToken()
+	assert.True(t, conf.verifyToken(token))
+	assert.NotEqual(t, token, conf.generateToken())
+
+	assert.False(t, conf.verifyToken(""))
+	assert.False(t, conf.verifyToken("nonce"))
+	assert.False(t, conf.verifyToken("."+conf.sign("")))
+	assert.False(t, conf.verifyToken(token+"x"))
+
+	other := &config{}
+	other.Secret = "other"
+	assert.False(t, other.verifyToken(token))
+}
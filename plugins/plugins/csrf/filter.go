@@ -0,0 +1,114 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/csrf"
+)
+
+// The safe methods defined in RFC 9110 don't change the state, so they don't need to be verified
+var safeMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	// newToken is the token issued to the client which doesn't have a valid one
+	newToken string
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	if config.exempt(headers) {
+		return api.Continue
+	}
+
+	var token string
+	if cookie := headers.Cookie(config.cookieName); cookie != nil && config.verifyToken(cookie.Value) {
+		token = cookie.Value
+	}
+
+	if safeMethods[headers.Method()] {
+		if token == "" {
+			f.newToken = config.generateToken()
+		}
+		return api.Continue
+	}
+
+	if token == "" {
+		api.LogInfof("csrf: missing or invalid token in cookie %s", config.cookieName)
+		return &api.LocalResponse{Code: 403, Msg: "invalid CSRF token"}
+	}
+	value, _ := headers.Get(config.headerName)
+	if subtle.ConstantTimeCompare([]byte(value), []byte(token)) != 1 {
+		api.LogInfof("csrf: token in header %s mismatched", config.headerName)
+		return &api.LocalResponse{Code: 403, Msg: "invalid CSRF token"}
+	}
+	return api.Continue
+}
+
+func (f *filter) newCookie(value string) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:  f.config.cookieName,
+		Value: value,
+		Path:  defaultCookiePath,
+		// The cookie should be readable by the scripts, so that they can send the token in the header
+		HttpOnly: false,
+	}
+	c := f.config.Cookie
+	if c == nil {
+		return cookie
+	}
+
+	cookie.Domain = c.Domain
+	if c.Path != "" {
+		cookie.Path = c.Path
+	}
+	cookie.Secure = c.Secure
+	switch c.SameSite {
+	case csrf.Cookie_LAX:
+		cookie.SameSite = http.SameSiteLaxMode
+	case csrf.Cookie_STRICT:
+		cookie.SameSite = http.SameSiteStrictMode
+	case csrf.Cookie_NONE:
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	return cookie
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.newToken != "" {
+		headers.Add("set-cookie", f.newCookie(f.newToken).String())
+	}
+	return api.Continue
+}
@@ -0,0 +1,164 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csrf
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestIssueToken(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"secret": "secret",
+		"cookie": {"name": "csrf", "sameSite": "STRICT", "secure": true, "domain": "example.com"}
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	h := http.Header{}
+	h.Set(":method", "GET")
+	h.Set(":path", "/")
+	res := f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+	assert.Equal(t, api.Continue, res)
+
+	rh := envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(rh, true)
+	v, _ := rh.Get("set-cookie")
+	cookies := (&http.Response{Header: http.Header{"Set-Cookie": []string{v}}}).Cookies()
+	require.Len(t, cookies, 1)
+	cookie := cookies[0]
+	assert.Equal(t, "csrf", cookie.Name)
+	assert.True(t, conf.verifyToken(cookie.Value))
+	assert.Equal(t, "/", cookie.Path)
+	assert.Equal(t, "example.com", cookie.Domain)
+	assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite)
+	assert.True(t, cookie.Secure)
+	assert.False(t, cookie.HttpOnly)
+
+	// don't reissue the valid token
+	f = factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	h.Set("cookie", "csrf="+cookie.Value)
+	f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+	rh = envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(rh, true)
+	_, ok := rh.Get("set-cookie")
+	assert.False(t, ok)
+}
+
+func TestVerifyToken(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"secret": "secret",
+		"headerName": "x-token",
+		"exemptPaths": [
+			{"path": {"prefix": "/webhook/"}, "methods": ["POST"]}
+		]
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+	token := conf.generateToken()
+	forged := "nonce." + (&config{}).sign("nonce")
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		cookie string
+		header string
+		code   int
+	}{
+		{
+			name:   "safe method",
+			method: "HEAD",
+			path:   "/",
+		},
+		{
+			name:   "matched",
+			method: "POST",
+			path:   "/",
+			cookie: token,
+			header: token,
+		},
+		{
+			name:   "no cookie",
+			method: "POST",
+			path:   "/",
+			header: token,
+			code:   403,
+		},
+		{
+			name:   "no header",
+			method: "DELETE",
+			path:   "/",
+			cookie: token,
+			code:   403,
+		},
+		{
+			name:   "mismatched",
+			method: "PUT",
+			path:   "/",
+			cookie: token,
+			header: conf.generateToken(),
+			code:   403,
+		},
+		{
+			name:   "forged",
+			method: "POST",
+			path:   "/",
+			cookie: forged,
+			header: forged,
+			code:   403,
+		},
+		{
+			name:   "exempt",
+			method: "POST",
+			path:   "/webhook/github",
+		},
+		{
+			name:   "method not exempted",
+			method: "PUT",
+			path:   "/webhook/github",
+			code:   403,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+			h := http.Header{}
+			h.Set(":method", tt.method)
+			h.Set(":path", tt.path)
+			if tt.cookie != "" {
+				h.Set("cookie", "htnn_csrf="+tt.cookie)
+			}
+			if tt.header != "" {
+				h.Set("x-token", tt.header)
+			}
+			res := f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+			if tt.code == 0 {
+				assert.Equal(t, api.Continue, res)
+			} else {
+				assert.Equal(t, tt.code, res.(*api.LocalResponse).Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dubboproxy
+
+import (
+	"strconv"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/dubboproxy"
+)
+
+const (
+	defaultTimeout = 3 * time.Second
+)
+
+func init() {
+	plugins.RegisterPlugin(dubboproxy.Name, &plugin{})
+}
+
+type plugin struct {
+	dubboproxy.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	dubboproxy.CustomConfig
+
+	client      *client
+	timeout     time.Duration
+	attachments map[string]string
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.client = newClient(conf.Addresses)
+
+	conf.timeout = defaultTimeout
+	if conf.Timeout != nil {
+		conf.timeout = conf.Timeout.AsDuration()
+	}
+
+	conf.attachments = make(map[string]string, len(conf.Attachments)+6)
+	for k, v := range conf.Attachments {
+		conf.attachments[k] = v
+	}
+	// the attachments required by the generic invocation can't be overridden
+	conf.attachments["path"] = conf.Interface
+	conf.attachments["interface"] = conf.Interface
+	conf.attachments["generic"] = "true"
+	conf.attachments["timeout"] = strconv.FormatInt(conf.timeout.Milliseconds(), 10)
+	if conf.Version != "" {
+		conf.attachments["version"] = conf.Version
+	}
+	if conf.Group != "" {
+		conf.attachments["group"] = conf.Group
+	}
+	return nil
+}
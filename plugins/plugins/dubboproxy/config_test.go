@@ -0,0 +1,105 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dubboproxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	conf := &config{}
+	err := protojson.Unmarshal([]byte(`{
+		"addresses": ["127.0.0.1:20880"],
+		"interface": "org.apache.dubbo.samples.UserService",
+		"method": "getUser",
+		"version": "1.0.0",
+		"attachments": {"foo": "bar", "generic": "false"}
+	}`), conf)
+	require.NoError(t, err)
+	require.NoError(t, conf.Validate())
+	require.NoError(t, conf.Init(nil))
+
+	assert.Equal(t, defaultTimeout, conf.timeout)
+	assert.Equal(t, map[string]string{
+		"foo":       "bar",
+		"generic":   "true",
+		"path":      "org.apache.dubbo.samples.UserService",
+		"interface": "org.apache.dubbo.samples.UserService",
+		"timeout":   "3000",
+		"version":   "1.0.0",
+	}, conf.attachments)
+
+	conf = &config{}
+	err = protojson.Unmarshal([]byte(`{
+		"addresses": ["127.0.0.1:20880"],
+		"interface": "org.apache.dubbo.samples.UserService",
+		"method": "getUser",
+		"timeout": "0.5s"
+	}`), conf)
+	require.NoError(t, err)
+	require.NoError(t, conf.Init(nil))
+	assert.Equal(t, 500*time.Millisecond, conf.timeout)
+	assert.Equal(t, "500", conf.attachments["timeout"])
+}
+
+func TestBadConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "no addresses",
+			input: `{"interface":"a.B","method":"c"}`,
+			err:   "invalid Config.Addresses: value must contain at least 1 item(s)",
+		},
+		{
+			name:  "bad address",
+			input: `{"addresses":["127.0.0.1"],"interface":"a.B","method":"c"}`,
+			err:   "bad address 127.0.0.1",
+		},
+		{
+			name:  "no interface",
+			input: `{"addresses":["127.0.0.1:20880"],"method":"c"}`,
+			err:   "invalid Config.Interface: value length must be at least 1 runes",
+		},
+		{
+			name:  "no method",
+			input: `{"addresses":["127.0.0.1:20880"],"interface":"a.B"}`,
+			err:   "invalid Config.Method: value length must be at least 1 runes",
+		},
+		{
+			name:  "bad timeout",
+			input: `{"addresses":["127.0.0.1:20880"],"interface":"a.B","method":"c","timeout":"0s"}`,
+			err:   "invalid Config.Timeout: value must be greater than 0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dubboproxy
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	headerLength = 16
+	magicHigh    = 0xda
+	magicLow     = 0xbb
+
+	flagRequest = 0x80
+	flagTwoWay  = 0x40
+	flagEvent   = 0x20
+	// the serialization id of hessian2
+	serializationHessian2 = 2
+
+	statusOK            = 20
+	statusClientTimeout = 30
+	statusServerTimeout = 31
+	statusBadRequest    = 40
+	statusNotFound      = 60
+
+	dubboVersion    = "2.0.2"
+	genericMethod   = "$invoke"
+	genericParamsDS = "Ljava/lang/String;[Ljava/lang/String;[Ljava/lang/Object;"
+
+	// maxBodyLength is the default payload limit of Dubbo
+	maxBodyLength = 8 * 1024 * 1024
+
+	maxIdleConnsPerAddr = 16
+	// The provider sends heartbeat to the idle connection and closes it if there is no reply.
+	// Drop the connection idle for a while to avoid using a closed connection.
+	maxIdleTime = 30 * time.Second
+)
+
+const (
+	responseWithException = iota
+	responseValue
+	responseNullValue
+	responseWithExceptionWithAttachments
+	responseValueWithAttachments
+	responseNullValueWithAttachments
+)
+
+var requestID atomic.Int64
+
+// invocation is a Dubbo generic invocation
+type invocation struct {
+	iface       string
+	version     string
+	method      string
+	paramTypes  []string
+	args        []interface{}
+	attachments map[string]string
+}
+
+// dubboError is returned when the provider responses with a non-OK status
+type dubboError struct {
+	status byte
+	msg    string
+}
+
+func (e *dubboError) Error() string {
+	return fmt.Sprintf("dubbo status %d: %s", e.status, e.msg)
+}
+
+// remoteException is returned when the invoked method throws an exception
+type remoteException struct {
+	class string
+	msg   string
+}
+
+func (e *remoteException) Error() string {
+	if e.class == "" {
+		return e.msg
+	}
+	return e.class + ": " + e.msg
+}
+
+func encodeRequest(id int64, inv *invocation) ([]byte, error) {
+	e := &hessianEncoder{}
+	e.writeString(dubboVersion)
+	e.writeString(inv.iface)
+	if inv.version != "" {
+		e.writeString(inv.version)
+	} else {
+		e.writeNull()
+	}
+	e.writeString(genericMethod)
+	e.writeString(genericParamsDS)
+
+	e.writeString(inv.method)
+	e.writeTypedList("[string", len(inv.paramTypes))
+	for _, typ := range inv.paramTypes {
+		e.writeString(typ)
+	}
+	e.writeTypedList("[object", len(inv.args))
+	for _, arg := range inv.args {
+		if err := e.writeValue(arg); err != nil {
+			return nil, err
+		}
+	}
+	e.writeStringMap(inv.attachments)
+
+	body := e.bytes()
+	if len(body) > maxBodyLength {
+		return nil, errors.New("request is too large")
+	}
+	return appendHeader(nil, flagRequest|flagTwoWay|serializationHessian2, 0, id, body), nil
+}
+
+func appendHeader(buf []byte, flag byte, status byte, id int64, body []byte) []byte {
+	buf = append(buf, magicHigh, magicLow, flag, status)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(id))
+	buf = binary.BigEndian.AppendUint32(buf, uint32(len(body)))
+	return append(buf, body...)
+}
+
+type frame struct {
+	flag   byte
+	status byte
+	id     int64
+	body   []byte
+}
+
+func readFrame(r io.Reader) (*frame, error) {
+	hdr := make([]byte, headerLength)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return nil, err
+	}
+	if hdr[0] != magicHigh || hdr[1] != magicLow {
+		return nil, errors.New("bad dubbo magic number")
+	}
+	n := binary.BigEndian.Uint32(hdr[12:])
+	if n > maxBodyLength {
+		return nil, fmt.Errorf("dubbo response is too large: %d", n)
+	}
+	f := &frame{
+		flag:   hdr[2],
+		status: hdr[3],
+		id:     int64(binary.BigEndian.Uint64(hdr[4:12])),
+		body:   make([]byte, n),
+	}
+	if _, err := io.ReadFull(r, f.body); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func decodeResponse(f *frame) (interface{}, error) {
+	if f.flag&0x1f != serializationHessian2 {
+		return nil, fmt.Errorf("unsupported serialization %d", f.flag&0x1f)
+	}
+
+	d := newHessianDecoder(f.body)
+	if f.status != statusOK {
+		msg, err := d.readString()
+		if err != nil {
+			msg = "unknown error"
+		}
+		return nil, &dubboError{status: f.status, msg: msg}
+	}
+
+	flag, err := d.readInt()
+	if err != nil {
+		return nil, err
+	}
+	switch flag {
+	case responseNullValue, responseNullValueWithAttachments:
+		return nil, nil
+	case responseValue, responseValueWithAttachments:
+		v, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+		return toJSONValue(v), nil
+	case responseWithException, responseWithExceptionWithAttachments:
+		v, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+		e := &remoteException{}
+		switch v := v.(type) {
+		case *hessianObject:
+			e.class = v.class
+			e.msg, _ = v.fields["detailMessage"].(string)
+		case string:
+			e.msg = v
+		default:
+			e.msg = "unknown exception"
+		}
+		return nil, e
+	}
+	return nil, fmt.Errorf("unknown response flag %d", flag)
+}
+
+type idleConn struct {
+	conn     net.Conn
+	idleFrom time.Time
+}
+
+// client sends the Dubbo requests to the providers. The connections are reused, but each
+// connection only has one in-flight request.
+type client struct {
+	addresses []string
+	next      atomic.Uint32
+
+	lock sync.Mutex
+	idle map[string][]*idleConn
+}
+
+func newClient(addresses []string) *client {
+	return &client{
+		addresses: addresses,
+		idle:      make(map[string][]*idleConn),
+	}
+}
+
+func (c *client) pickAddress() string {
+	n := c.next.Add(1)
+	return c.addresses[int(n)%len(c.addresses)]
+}
+
+func (c *client) getIdleConn(addr string) net.Conn {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	conns := c.idle[addr]
+	for len(conns) > 0 {
+		ic := conns[len(conns)-1]
+		conns = conns[:len(conns)-1]
+		if time.Since(ic.idleFrom) < maxIdleTime {
+			c.idle[addr] = conns
+			return ic.conn
+		}
+		ic.conn.Close()
+	}
+	c.idle[addr] = conns
+	return nil
+}
+
+func (c *client) putIdleConn(addr string, conn net.Conn) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if len(c.idle[addr]) >= maxIdleConnsPerAddr {
+		conn.Close()
+		return
+	}
+	c.idle[addr] = append(c.idle[addr], &idleConn{conn: conn, idleFrom: time.Now()})
+}
+
+func (c *client) invoke(inv *invocation, timeout time.Duration) (interface{}, error) {
+	id := requestID.Add(1)
+	req, err := encodeRequest(id, inv)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(timeout)
+	addr := c.pickAddress()
+	conn := c.getIdleConn(addr)
+	if conn != nil {
+		f, err := roundTrip(conn, req, id, deadline)
+		if err == nil {
+			c.putIdleConn(addr, conn)
+			return decodeResponse(f)
+		}
+		conn.Close()
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil, err
+		}
+		// the reused connection may be closed by the provider, retry with a new one
+	}
+
+	conn, err = net.DialTimeout("tcp", addr, time.Until(deadline))
+	if err != nil {
+		return nil, err
+	}
+	f, err := roundTrip(conn, req, id, deadline)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	c.putIdleConn(addr, conn)
+	return decodeResponse(f)
+}
+
+func roundTrip(conn net.Conn, req []byte, id int64, deadline time.Time) (*frame, error) {
+	err := conn.SetDeadline(deadline)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(req); err != nil {
+		return nil, err
+	}
+
+	for {
+		f, err := readFrame(conn)
+		if err != nil {
+			return nil, err
+		}
+		if f.flag&flagEvent != 0 {
+			if f.flag&flagRequest != 0 && f.flag&flagTwoWay != 0 {
+				// reply the heartbeat
+				e := &hessianEncoder{}
+				e.writeNull()
+				resp := appendHeader(nil, flagEvent|serializationHessian2, statusOK, f.id, e.bytes())
+				if _, err := conn.Write(resp); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		if f.flag&flagRequest != 0 {
+			return nil, errors.New("unexpected dubbo request from provider")
+		}
+		if f.id != id {
+			return nil, errors.New("mismatched dubbo response id " + strconv.FormatInt(f.id, 10))
+		}
+		return f, nil
+	}
+}
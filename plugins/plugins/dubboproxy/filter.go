@@ -0,0 +1,136 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dubboproxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+func jsonResponse(code int, v interface{}) *api.LocalResponse {
+	b, err := json.Marshal(v)
+	if err != nil {
+		api.LogErrorf("failed to marshal dubbo response: %v", err)
+		return &api.LocalResponse{Code: http.StatusInternalServerError}
+	}
+	hdr := http.Header{}
+	hdr.Set("content-type", "application/json")
+	return &api.LocalResponse{Code: code, Msg: string(b), Header: hdr}
+}
+
+func errorResponse(code int, msg string) *api.LocalResponse {
+	return jsonResponse(code, map[string]string{"error": msg})
+}
+
+// parseArgs parses the arguments from the request body, which should be a JSON array
+func (conf *config) parseArgs(body []byte) ([]interface{}, error) {
+	var args []interface{}
+	if len(bytes.TrimSpace(body)) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		err := dec.Decode(&args)
+		if err != nil {
+			return nil, errors.New("the body should be a JSON array of the arguments")
+		}
+		if dec.More() {
+			return nil, errors.New("unexpected data after the arguments")
+		}
+	}
+	if len(args) != len(conf.ParamTypes) {
+		return nil, fmt.Errorf("expect %d arguments, got %d", len(conf.ParamTypes), len(args))
+	}
+	return args, nil
+}
+
+func (f *filter) invoke(body []byte) api.ResultAction {
+	conf := f.config
+	args, err := conf.parseArgs(body)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	inv := &invocation{
+		iface:       conf.Interface,
+		version:     conf.Version,
+		method:      conf.Method,
+		paramTypes:  conf.ParamTypes,
+		args:        args,
+		attachments: conf.attachments,
+	}
+	res, err := conf.client.invoke(inv, conf.timeout)
+	if err == nil {
+		return jsonResponse(http.StatusOK, res)
+	}
+
+	var remoteErr *remoteException
+	var dubboErr *dubboError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &remoteErr):
+		api.LogInfof("dubbo method %s.%s throws %v", conf.Interface, conf.Method, err)
+		return errorResponse(http.StatusInternalServerError, remoteErr.Error())
+	case errors.As(err, &dubboErr):
+		api.LogWarnf("failed to invoke dubbo method %s.%s: %v", conf.Interface, conf.Method, err)
+		switch dubboErr.status {
+		case statusClientTimeout, statusServerTimeout:
+			return errorResponse(http.StatusGatewayTimeout, dubboErr.msg)
+		case statusBadRequest:
+			return errorResponse(http.StatusBadRequest, dubboErr.msg)
+		case statusNotFound:
+			return errorResponse(http.StatusNotFound, dubboErr.msg)
+		}
+		return errorResponse(http.StatusBadGateway, dubboErr.msg)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		api.LogWarnf("timeout to invoke dubbo method %s.%s: %v", conf.Interface, conf.Method, err)
+		return errorResponse(http.StatusGatewayTimeout, "timeout")
+	}
+	api.LogErrorf("failed to invoke dubbo method %s.%s: %v", conf.Interface, conf.Method, err)
+	return errorResponse(http.StatusServiceUnavailable, "failed to invoke dubbo service")
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	if endStream {
+		return f.invoke(nil)
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	var body []byte
+	if data != nil {
+		body = data.Bytes()
+	}
+	return f.invoke(body)
+}
@@ -0,0 +1,371 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dubboproxy
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type dubboRequest struct {
+	iface       string
+	version     string
+	method      string
+	paramTypes  []interface{}
+	args        []interface{}
+	attachments map[string]interface{}
+}
+
+// dubboServer is a fake Dubbo provider which supports the generic invocation
+type dubboServer struct {
+	lock   sync.Mutex
+	handle func(conn net.Conn, req *dubboRequest) (status byte, body []byte)
+	conns  atomic.Int32
+}
+
+func (s *dubboServer) setHandler(h func(conn net.Conn, req *dubboRequest) (byte, []byte)) {
+	s.lock.Lock()
+	s.handle = h
+	s.lock.Unlock()
+}
+
+func (s *dubboServer) serve(conn net.Conn, req *dubboRequest) (byte, []byte) {
+	s.lock.Lock()
+	h := s.handle
+	s.lock.Unlock()
+	return h(conn, req)
+}
+
+func decodeRequest(t *testing.T, body []byte) *dubboRequest {
+	d := newHessianDecoder(body)
+	var values []interface{}
+	for d.pos < len(d.data) {
+		v, err := d.readValue()
+		require.NoError(t, err)
+		values = append(values, v)
+	}
+	require.Len(t, values, 9)
+	assert.Equal(t, dubboVersion, values[0])
+	assert.Equal(t, genericMethod, values[3])
+	assert.Equal(t, genericParamsDS, values[4])
+
+	req := &dubboRequest{
+		iface:       values[1].(string),
+		method:      values[5].(string),
+		paramTypes:  values[6].([]interface{}),
+		args:        values[7].([]interface{}),
+		attachments: values[8].(map[string]interface{}),
+	}
+	req.version, _ = values[2].(string)
+	return req
+}
+
+func startDubboServer(t *testing.T, srv *dubboServer) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { lis.Close() })
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			srv.conns.Add(1)
+			go func() {
+				defer conn.Close()
+				for {
+					f, err := readFrame(conn)
+					if err != nil {
+						return
+					}
+					if f.flag&flagEvent != 0 {
+						// heartbeat response
+						continue
+					}
+					req := decodeRequest(t, f.body)
+					status, body := srv.serve(conn, req)
+					if body == nil {
+						// close the connection without response
+						return
+					}
+					resp := appendHeader(nil, serializationHessian2, status, f.id, body)
+					if _, err := conn.Write(resp); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return lis.Addr().String()
+}
+
+func valueBody(v interface{}) []byte {
+	e := &hessianEncoder{}
+	if v == nil {
+		e.writeInt(responseNullValue)
+		return e.bytes()
+	}
+	e.writeInt(responseValueWithAttachments)
+	_ = e.writeValue(v)
+	e.writeStringMap(map[string]string{"dubbo": dubboVersion})
+	return e.bytes()
+}
+
+func exceptionBody(class string, msg string) []byte {
+	e := &hessianEncoder{}
+	e.writeInt(responseWithException)
+	e.buf = append(e.buf, 'C')
+	e.writeString(class)
+	e.writeInt(1)
+	e.writeString("detailMessage")
+	e.buf = append(e.buf, 0x60)
+	e.writeString(msg)
+	return e.bytes()
+}
+
+func errorBody(msg string) []byte {
+	e := &hessianEncoder{}
+	e.writeString(msg)
+	return e.bytes()
+}
+
+func newTestConfig(t *testing.T, input string, addr string) *config {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+	conf.Addresses = []string{addr}
+	require.NoError(t, conf.Validate())
+	require.NoError(t, conf.Init(nil))
+	return conf
+}
+
+func TestDubboProxy(t *testing.T) {
+	srv := &dubboServer{}
+	addr := startDubboServer(t, srv)
+
+	tests := []struct {
+		name   string
+		input  string
+		body   string
+		handle func(conn net.Conn, req *dubboRequest) (byte, []byte)
+		code   int
+		msg    string
+	}{
+		{
+			name: "sanity",
+			input: `{
+				"interface": "org.apache.dubbo.samples.UserService",
+				"version": "1.0.0",
+				"group": "test",
+				"method": "getUser",
+				"paramTypes": ["java.lang.Long", "org.apache.dubbo.samples.Query"],
+				"attachments": {"foo": "bar"}
+			}`,
+			body: `[1, {"class": "org.apache.dubbo.samples.Query", "fields": ["name"]}]`,
+			handle: func(conn net.Conn, req *dubboRequest) (byte, []byte) {
+				assert.Equal(t, "org.apache.dubbo.samples.UserService", req.iface)
+				assert.Equal(t, "1.0.0", req.version)
+				assert.Equal(t, "getUser", req.method)
+				assert.Equal(t, []interface{}{"java.lang.Long", "org.apache.dubbo.samples.Query"}, req.paramTypes)
+				assert.Equal(t, []interface{}{
+					int32(1),
+					map[string]interface{}{
+						"class":  "org.apache.dubbo.samples.Query",
+						"fields": []interface{}{"name"},
+					},
+				}, req.args)
+				assert.Equal(t, map[string]interface{}{
+					"foo":       "bar",
+					"generic":   "true",
+					"group":     "test",
+					"interface": "org.apache.dubbo.samples.UserService",
+					"path":      "org.apache.dubbo.samples.UserService",
+					"timeout":   "3000",
+					"version":   "1.0.0",
+				}, req.attachments)
+				return statusOK, valueBody(map[string]interface{}{"id": json.Number("1"), "name": "Alice"})
+			},
+			code: 200,
+			msg:  `{"id":1,"name":"Alice"}`,
+		},
+		{
+			name:  "no arguments",
+			input: `{"interface": "a.B", "method": "ping"}`,
+			handle: func(conn net.Conn, req *dubboRequest) (byte, []byte) {
+				assert.Empty(t, req.args)
+				assert.Empty(t, req.paramTypes)
+				assert.Equal(t, "", req.version)
+				return statusOK, valueBody(nil)
+			},
+			code: 200,
+			msg:  `null`,
+		},
+		{
+			name:  "heartbeat",
+			input: `{"interface": "a.B", "method": "ping"}`,
+			handle: func(conn net.Conn, req *dubboRequest) (byte, []byte) {
+				e := &hessianEncoder{}
+				e.writeNull()
+				hb := appendHeader(nil, flagRequest|flagTwoWay|flagEvent|serializationHessian2, 0, 1, e.bytes())
+				_, err := conn.Write(hb)
+				require.NoError(t, err)
+				return statusOK, valueBody("pong")
+			},
+			code: 200,
+			msg:  `"pong"`,
+		},
+		{
+			name:  "wrong number of arguments",
+			input: `{"interface": "a.B", "method": "c", "paramTypes": ["java.lang.String"]}`,
+			body:  `["a", "b"]`,
+			code:  400,
+			msg:   `{"error":"expect 1 arguments, got 2"}`,
+		},
+		{
+			name:  "bad body",
+			input: `{"interface": "a.B", "method": "c", "paramTypes": ["java.lang.String"]}`,
+			body:  `{"a": "b"}`,
+			code:  400,
+			msg:   `{"error":"the body should be a JSON array of the arguments"}`,
+		},
+		{
+			name:  "exception",
+			input: `{"interface": "a.B", "method": "c"}`,
+			handle: func(conn net.Conn, req *dubboRequest) (byte, []byte) {
+				return statusOK, exceptionBody("java.lang.IllegalArgumentException", "bad id")
+			},
+			code: 500,
+			msg:  `{"error":"java.lang.IllegalArgumentException: bad id"}`,
+		},
+		{
+			name:  "service not found",
+			input: `{"interface": "a.B", "method": "c"}`,
+			handle: func(conn net.Conn, req *dubboRequest) (byte, []byte) {
+				return statusNotFound, errorBody("Not found exported service")
+			},
+			code: 404,
+			msg:  `{"error":"Not found exported service"}`,
+		},
+		{
+			name:  "server timeout",
+			input: `{"interface": "a.B", "method": "c"}`,
+			handle: func(conn net.Conn, req *dubboRequest) (byte, []byte) {
+				return statusServerTimeout, errorBody("timeout")
+			},
+			code: 504,
+			msg:  `{"error":"timeout"}`,
+		},
+		{
+			name:  "service error",
+			input: `{"interface": "a.B", "method": "c"}`,
+			handle: func(conn net.Conn, req *dubboRequest) (byte, []byte) {
+				return 70, errorBody("service error")
+			},
+			code: 502,
+			msg:  `{"error":"service error"}`,
+		},
+		{
+			name:  "timeout",
+			input: `{"interface": "a.B", "method": "c", "timeout": "0.1s"}`,
+			handle: func(conn net.Conn, req *dubboRequest) (byte, []byte) {
+				time.Sleep(200 * time.Millisecond)
+				return statusOK, valueBody(nil)
+			},
+			code: 504,
+			msg:  `{"error":"timeout"}`,
+		},
+		{
+			name:  "connection closed",
+			input: `{"interface": "a.B", "method": "c"}`,
+			handle: func(conn net.Conn, req *dubboRequest) (byte, []byte) {
+				return statusOK, nil
+			},
+			code: 503,
+			msg:  `{"error":"failed to invoke dubbo service"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.setHandler(tt.handle)
+			conf := newTestConfig(t, tt.input, addr)
+			f := factory(conf, envoy.NewFilterCallbackHandler())
+			hdr := envoy.NewRequestHeaderMap(http.Header{
+				":method": {"POST"},
+				":path":   {"/"},
+			})
+
+			var res api.ResultAction
+			if tt.body == "" {
+				res = f.DecodeHeaders(hdr, true)
+			} else {
+				assert.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+				res = f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(tt.body)), nil)
+			}
+			lr, ok := res.(*api.LocalResponse)
+			require.True(t, ok)
+			assert.Equal(t, tt.code, lr.Code)
+			assert.Equal(t, tt.msg, lr.Msg)
+			assert.Equal(t, "application/json", lr.Header.Get("content-type"))
+		})
+	}
+}
+
+func TestDubboProxyReuseConnection(t *testing.T) {
+	var served atomic.Int32
+	srv := &dubboServer{
+		handle: func(conn net.Conn, req *dubboRequest) (byte, []byte) {
+			served.Add(1)
+			return statusOK, valueBody(req.args[0])
+		},
+	}
+	addr := startDubboServer(t, srv)
+	conf := newTestConfig(t, `{"interface": "a.B", "method": "echo", "paramTypes": ["java.lang.String"]}`, addr)
+
+	for i := 0; i < 3; i++ {
+		f := factory(conf, envoy.NewFilterCallbackHandler())
+		hdr := envoy.NewRequestHeaderMap(http.Header{})
+		f.DecodeHeaders(hdr, false)
+		res := f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(`["hi"]`)), nil)
+		assert.Equal(t, `"hi"`, res.(*api.LocalResponse).Msg)
+	}
+	assert.Equal(t, int32(3), served.Load())
+	assert.Equal(t, int32(1), srv.conns.Load())
+
+	// the idle connection is closed by the provider, should retry with a new connection
+	conn := conf.client.getIdleConn(addr)
+	require.NotNil(t, conn)
+	conn.Close()
+	conf.client.putIdleConn(addr, conn)
+
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	hdr := envoy.NewRequestHeaderMap(http.Header{})
+	f.DecodeHeaders(hdr, false)
+	res := f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(`["hello"]`)), nil)
+	assert.Equal(t, `"hello"`, res.(*api.LocalResponse).Msg)
+	assert.Equal(t, int32(2), srv.conns.Load())
+}
@@ -0,0 +1,787 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dubboproxy
+
+// This file implements the subset of Hessian 2.0 serialization which is required to do the Dubbo
+// generic invocation. See http://hessian.caucho.com/doc/hessian-serialization.html for the grammar.
+// The values are converted from/to the values produced by encoding/json.
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf16"
+)
+
+type hessianEncoder struct {
+	buf []byte
+}
+
+func (e *hessianEncoder) bytes() []byte {
+	return e.buf
+}
+
+func (e *hessianEncoder) writeNull() {
+	e.buf = append(e.buf, 'N')
+}
+
+func (e *hessianEncoder) writeBool(b bool) {
+	if b {
+		e.buf = append(e.buf, 'T')
+	} else {
+		e.buf = append(e.buf, 'F')
+	}
+}
+
+func (e *hessianEncoder) writeInt(v int32) {
+	switch {
+	case -0x10 <= v && v <= 0x2f:
+		e.buf = append(e.buf, byte(v+0x90))
+	case -0x800 <= v && v <= 0x7ff:
+		e.buf = append(e.buf, byte(0xc8+(v>>8)), byte(v))
+	case -0x40000 <= v && v <= 0x3ffff:
+		e.buf = append(e.buf, byte(0xd4+(v>>16)), byte(v>>8), byte(v))
+	default:
+		e.buf = append(e.buf, 'I')
+		e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(v))
+	}
+}
+
+func (e *hessianEncoder) writeLong(v int64) {
+	switch {
+	case -0x08 <= v && v <= 0x0f:
+		e.buf = append(e.buf, byte(v+0xe0))
+	case -0x800 <= v && v <= 0x7ff:
+		e.buf = append(e.buf, byte(0xf8+(v>>8)), byte(v))
+	case -0x40000 <= v && v <= 0x3ffff:
+		e.buf = append(e.buf, byte(0x3c+(v>>16)), byte(v>>8), byte(v))
+	case math.MinInt32 <= v && v <= math.MaxInt32:
+		e.buf = append(e.buf, 0x59)
+		e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(v))
+	default:
+		e.buf = append(e.buf, 'L')
+		e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(v))
+	}
+}
+
+func (e *hessianEncoder) writeDouble(v float64) {
+	switch {
+	case v == 0 && !math.Signbit(v):
+		e.buf = append(e.buf, 0x5b)
+	case v == 1:
+		e.buf = append(e.buf, 0x5c)
+	case v == math.Trunc(v) && -0x80 <= v && v <= 0x7f:
+		e.buf = append(e.buf, 0x5d, byte(int8(v)))
+	case v == math.Trunc(v) && -0x8000 <= v && v <= 0x7fff:
+		e.buf = append(e.buf, 0x5e)
+		e.buf = binary.BigEndian.AppendUint16(e.buf, uint16(int16(v)))
+	default:
+		e.buf = append(e.buf, 'D')
+		e.buf = binary.BigEndian.AppendUint64(e.buf, math.Float64bits(v))
+	}
+}
+
+// appendUTF16 appends the UTF-16 code units like Java does. The supplementary characters are
+// written as the surrogate pairs, each of them takes three bytes.
+func appendUTF16(buf []byte, units []uint16) []byte {
+	for _, u := range units {
+		switch {
+		case u < 0x80:
+			buf = append(buf, byte(u))
+		case u < 0x800:
+			buf = append(buf, byte(0xc0|u>>6), byte(0x80|u&0x3f))
+		default:
+			buf = append(buf, byte(0xe0|u>>12), byte(0x80|(u>>6)&0x3f), byte(0x80|u&0x3f))
+		}
+	}
+	return buf
+}
+
+func (e *hessianEncoder) writeString(s string) {
+	units := utf16.Encode([]rune(s))
+	const chunkSize = 0x8000
+	for len(units) > chunkSize {
+		// split the chunk between the surrogate pair is allowed, as Java does
+		e.buf = append(e.buf, 'R')
+		e.buf = binary.BigEndian.AppendUint16(e.buf, chunkSize)
+		e.buf = appendUTF16(e.buf, units[:chunkSize])
+		units = units[chunkSize:]
+	}
+
+	n := len(units)
+	switch {
+	case n <= 0x1f:
+		e.buf = append(e.buf, byte(n))
+	case n <= 0x3ff:
+		e.buf = append(e.buf, byte(0x30+(n>>8)), byte(n))
+	default:
+		e.buf = append(e.buf, 'S')
+		e.buf = binary.BigEndian.AppendUint16(e.buf, uint16(n))
+	}
+	e.buf = appendUTF16(e.buf, units)
+}
+
+// writeTypedList writes a fixed length list with the given type, like `[string`
+func (e *hessianEncoder) writeTypedList(typ string, n int) {
+	e.buf = append(e.buf, 'V')
+	e.writeString(typ)
+	e.writeInt(int32(n))
+}
+
+func (e *hessianEncoder) writeStringMap(m map[string]string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	e.buf = append(e.buf, 'H')
+	for _, k := range keys {
+		e.writeString(k)
+		e.writeString(m[k])
+	}
+	e.buf = append(e.buf, 'Z')
+}
+
+// writeValue writes the value decoded by encoding/json with UseNumber.
+func (e *hessianEncoder) writeValue(v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		e.writeNull()
+	case bool:
+		e.writeBool(v)
+	case string:
+		e.writeString(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			if math.MinInt32 <= i && i <= math.MaxInt32 {
+				e.writeInt(int32(i))
+			} else {
+				e.writeLong(i)
+			}
+			return nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return err
+		}
+		e.writeDouble(f)
+	case float64:
+		e.writeDouble(v)
+	case []interface{}:
+		// untyped fixed length list
+		e.buf = append(e.buf, 'X')
+		e.writeInt(int32(len(v)))
+		for _, item := range v {
+			if err := e.writeValue(item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		e.buf = append(e.buf, 'H')
+		for _, k := range keys {
+			e.writeString(k)
+			if err := e.writeValue(v[k]); err != nil {
+				return err
+			}
+		}
+		e.buf = append(e.buf, 'Z')
+	default:
+		return fmt.Errorf("unsupported type %T", v)
+	}
+	return nil
+}
+
+var errUnexpectedEOF = errors.New("hessian: unexpected end of data")
+
+type classDef struct {
+	name   string
+	fields []string
+}
+
+// hessianObject is a Java object which is not a map
+type hessianObject struct {
+	class  string
+	fields map[string]interface{}
+}
+
+type hessianDecoder struct {
+	data []byte
+	pos  int
+
+	types   []string
+	classes []*classDef
+	refs    []interface{}
+	// building marks the refs which are still being decoded, to break the circular references
+	building []bool
+}
+
+func newHessianDecoder(data []byte) *hessianDecoder {
+	return &hessianDecoder{data: data}
+}
+
+func (d *hessianDecoder) readByte() (byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, errUnexpectedEOF
+	}
+	b := d.data[d.pos]
+	d.pos++
+	return b, nil
+}
+
+func (d *hessianDecoder) peekByte() (byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, errUnexpectedEOF
+	}
+	return d.data[d.pos], nil
+}
+
+func (d *hessianDecoder) next(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.data) {
+		return nil, errUnexpectedEOF
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *hessianDecoder) readInt() (int32, error) {
+	v, err := d.readValue()
+	if err != nil {
+		return 0, err
+	}
+	switch v := v.(type) {
+	case int32:
+		return v, nil
+	case int64:
+		return int32(v), nil
+	}
+	return 0, fmt.Errorf("hessian: expect int, got %T", v)
+}
+
+func (d *hessianDecoder) readString() (string, error) {
+	v, err := d.readValue()
+	if err != nil {
+		return "", err
+	}
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", nil
+	}
+	return "", fmt.Errorf("hessian: expect string, got %T", v)
+}
+
+// readUTF16 reads n UTF-16 code units encoded like Java does
+func (d *hessianDecoder) readUTF16(units []uint16, n int) ([]uint16, error) {
+	for i := 0; i < n; i++ {
+		b, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case b < 0x80:
+			units = append(units, uint16(b))
+		case b&0xe0 == 0xc0:
+			b1, err := d.readByte()
+			if err != nil {
+				return nil, err
+			}
+			units = append(units, uint16(b&0x1f)<<6|uint16(b1&0x3f))
+		case b&0xf0 == 0xe0:
+			bs, err := d.next(2)
+			if err != nil {
+				return nil, err
+			}
+			units = append(units, uint16(b&0x0f)<<12|uint16(bs[0]&0x3f)<<6|uint16(bs[1]&0x3f))
+		case b&0xf8 == 0xf0:
+			// standard UTF-8 encoding of supplementary character, which takes two units
+			bs, err := d.next(3)
+			if err != nil {
+				return nil, err
+			}
+			r := rune(b&0x07)<<18 | rune(bs[0]&0x3f)<<12 | rune(bs[1]&0x3f)<<6 | rune(bs[2]&0x3f)
+			r1, r2 := utf16.EncodeRune(r)
+			units = append(units, uint16(r1), uint16(r2))
+			i++
+		default:
+			return nil, fmt.Errorf("hessian: bad utf-8 byte 0x%x", b)
+		}
+	}
+	return units, nil
+}
+
+func (d *hessianDecoder) readStringChunks(tag byte) (string, error) {
+	var units []uint16
+	for {
+		var n int
+		final := true
+		switch {
+		case tag <= 0x1f:
+			n = int(tag)
+		case 0x30 <= tag && tag <= 0x33:
+			b, err := d.readByte()
+			if err != nil {
+				return "", err
+			}
+			n = int(tag-0x30)<<8 | int(b)
+		case tag == 'S' || tag == 'R':
+			bs, err := d.next(2)
+			if err != nil {
+				return "", err
+			}
+			n = int(binary.BigEndian.Uint16(bs))
+			final = tag == 'S'
+		default:
+			return "", fmt.Errorf("hessian: bad string chunk tag 0x%x", tag)
+		}
+
+		var err error
+		units, err = d.readUTF16(units, n)
+		if err != nil {
+			return "", err
+		}
+		if final {
+			return string(utf16.Decode(units)), nil
+		}
+		tag, err = d.readByte()
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func (d *hessianDecoder) readBinaryChunks(tag byte) ([]byte, error) {
+	var data []byte
+	for {
+		var n int
+		final := true
+		switch {
+		case 0x20 <= tag && tag <= 0x2f:
+			n = int(tag - 0x20)
+		case 0x34 <= tag && tag <= 0x37:
+			b, err := d.readByte()
+			if err != nil {
+				return nil, err
+			}
+			n = int(tag-0x34)<<8 | int(b)
+		case tag == 'B' || tag == 'A':
+			bs, err := d.next(2)
+			if err != nil {
+				return nil, err
+			}
+			n = int(binary.BigEndian.Uint16(bs))
+			final = tag == 'B'
+		default:
+			return nil, fmt.Errorf("hessian: bad binary chunk tag 0x%x", tag)
+		}
+
+		bs, err := d.next(n)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, bs...)
+		if final {
+			return data, nil
+		}
+		tag, err = d.readByte()
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (d *hessianDecoder) readType() (string, error) {
+	b, err := d.peekByte()
+	if err != nil {
+		return "", err
+	}
+	if b <= 0x1f || (0x30 <= b && b <= 0x33) || b == 'S' || b == 'R' {
+		typ, err := d.readString()
+		if err != nil {
+			return "", err
+		}
+		d.types = append(d.types, typ)
+		return typ, nil
+	}
+
+	idx, err := d.readInt()
+	if err != nil {
+		return "", err
+	}
+	if idx < 0 || int(idx) >= len(d.types) {
+		return "", fmt.Errorf("hessian: bad type ref %d", idx)
+	}
+	return d.types[idx], nil
+}
+
+func (d *hessianDecoder) addRef(v interface{}) int {
+	d.refs = append(d.refs, v)
+	d.building = append(d.building, true)
+	return len(d.refs) - 1
+}
+
+func (d *hessianDecoder) setRef(idx int, v interface{}) {
+	d.refs[idx] = v
+	d.building[idx] = false
+}
+
+func (d *hessianDecoder) readList(n int) ([]interface{}, error) {
+	list := make([]interface{}, 0, max(min(n, 1024), 0))
+	idx := d.addRef(list)
+	for i := 0; n < 0 || i < n; i++ {
+		if n < 0 {
+			b, err := d.peekByte()
+			if err != nil {
+				return nil, err
+			}
+			if b == 'Z' {
+				d.pos++
+				break
+			}
+		}
+		v, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	d.setRef(idx, list)
+	return list, nil
+}
+
+func mapKey(k interface{}) string {
+	switch k := k.(type) {
+	case string:
+		return k
+	case nil:
+		return "null"
+	}
+	b, err := json.Marshal(k)
+	if err != nil {
+		return fmt.Sprint(k)
+	}
+	return string(b)
+}
+
+func (d *hessianDecoder) readMap() (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	idx := d.addRef(m)
+	for {
+		b, err := d.peekByte()
+		if err != nil {
+			return nil, err
+		}
+		if b == 'Z' {
+			d.pos++
+			break
+		}
+		k, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+		m[mapKey(k)] = v
+	}
+	d.setRef(idx, m)
+	return m, nil
+}
+
+func (d *hessianDecoder) readClassDef() error {
+	name, err := d.readString()
+	if err != nil {
+		return err
+	}
+	n, err := d.readInt()
+	if err != nil {
+		return err
+	}
+	if n < 0 || int(n) > len(d.data)-d.pos {
+		return fmt.Errorf("hessian: bad field count %d", n)
+	}
+	def := &classDef{name: name, fields: make([]string, n)}
+	for i := range def.fields {
+		def.fields[i], err = d.readString()
+		if err != nil {
+			return err
+		}
+	}
+	d.classes = append(d.classes, def)
+	return nil
+}
+
+func (d *hessianDecoder) readObject(defIdx int) (interface{}, error) {
+	if defIdx < 0 || defIdx >= len(d.classes) {
+		return nil, fmt.Errorf("hessian: bad class ref %d", defIdx)
+	}
+	def := d.classes[defIdx]
+	obj := &hessianObject{class: def.name, fields: make(map[string]interface{}, len(def.fields))}
+	idx := d.addRef(obj)
+	for _, f := range def.fields {
+		v, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+		obj.fields[f] = v
+	}
+
+	var res interface{} = obj
+	switch def.name {
+	case "java.math.BigDecimal", "java.math.BigInteger":
+		if s, ok := obj.fields["value"].(string); ok {
+			res = json.Number(s)
+		}
+	default:
+		if _, ok := obj.fields["name"]; ok && len(def.fields) == 1 {
+			// enum
+			res = obj.fields["name"]
+		}
+	}
+	d.setRef(idx, res)
+	return res, nil
+}
+
+func (d *hessianDecoder) readValue() (interface{}, error) {
+	tag, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case tag == 'N':
+		return nil, nil
+	case tag == 'T':
+		return true, nil
+	case tag == 'F':
+		return false, nil
+
+	// int
+	case 0x80 <= tag && tag <= 0xbf:
+		return int32(tag) - 0x90, nil
+	case 0xc0 <= tag && tag <= 0xcf:
+		b, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		return (int32(tag)-0xc8)<<8 | int32(b), nil
+	case 0xd0 <= tag && tag <= 0xd7:
+		bs, err := d.next(2)
+		if err != nil {
+			return nil, err
+		}
+		return (int32(tag)-0xd4)<<16 | int32(bs[0])<<8 | int32(bs[1]), nil
+	case tag == 'I':
+		bs, err := d.next(4)
+		if err != nil {
+			return nil, err
+		}
+		return int32(binary.BigEndian.Uint32(bs)), nil
+
+	// long
+	case 0xd8 <= tag && tag <= 0xef:
+		return int64(tag) - 0xe0, nil
+	case 0xf0 <= tag:
+		b, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		return (int64(tag)-0xf8)<<8 | int64(b), nil
+	case 0x38 <= tag && tag <= 0x3f:
+		bs, err := d.next(2)
+		if err != nil {
+			return nil, err
+		}
+		return (int64(tag)-0x3c)<<16 | int64(bs[0])<<8 | int64(bs[1]), nil
+	case tag == 0x59:
+		bs, err := d.next(4)
+		if err != nil {
+			return nil, err
+		}
+		return int64(int32(binary.BigEndian.Uint32(bs))), nil
+	case tag == 'L':
+		bs, err := d.next(8)
+		if err != nil {
+			return nil, err
+		}
+		return int64(binary.BigEndian.Uint64(bs)), nil
+
+	// double
+	case tag == 0x5b:
+		return float64(0), nil
+	case tag == 0x5c:
+		return float64(1), nil
+	case tag == 0x5d:
+		b, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		return float64(int8(b)), nil
+	case tag == 0x5e:
+		bs, err := d.next(2)
+		if err != nil {
+			return nil, err
+		}
+		return float64(int16(binary.BigEndian.Uint16(bs))), nil
+	case tag == 0x5f:
+		bs, err := d.next(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(int32(binary.BigEndian.Uint32(bs))) / 1000, nil
+	case tag == 'D':
+		bs, err := d.next(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(bs)), nil
+
+	// date, converted to RFC 3339 string
+	case tag == 0x4a:
+		bs, err := d.next(8)
+		if err != nil {
+			return nil, err
+		}
+		ms := int64(binary.BigEndian.Uint64(bs))
+		return time.UnixMilli(ms).UTC().Format(time.RFC3339Nano), nil
+	case tag == 0x4b:
+		bs, err := d.next(4)
+		if err != nil {
+			return nil, err
+		}
+		minutes := int64(int32(binary.BigEndian.Uint32(bs)))
+		return time.Unix(minutes*60, 0).UTC().Format(time.RFC3339), nil
+
+	case tag <= 0x1f, 0x30 <= tag && tag <= 0x33, tag == 'S', tag == 'R':
+		return d.readStringChunks(tag)
+	case 0x20 <= tag && tag <= 0x2f, 0x34 <= tag && tag <= 0x37, tag == 'B', tag == 'A':
+		return d.readBinaryChunks(tag)
+
+	// list
+	case tag == 0x55: // variable length typed list
+		if _, err := d.readType(); err != nil {
+			return nil, err
+		}
+		return d.readList(-1)
+	case tag == 'V': // fixed length typed list
+		if _, err := d.readType(); err != nil {
+			return nil, err
+		}
+		n, err := d.readInt()
+		if err != nil {
+			return nil, err
+		}
+		return d.readList(int(n))
+	case tag == 0x57: // variable length untyped list
+		return d.readList(-1)
+	case tag == 'X': // fixed length untyped list
+		n, err := d.readInt()
+		if err != nil {
+			return nil, err
+		}
+		return d.readList(int(n))
+	case 0x70 <= tag && tag <= 0x77:
+		if _, err := d.readType(); err != nil {
+			return nil, err
+		}
+		return d.readList(int(tag - 0x70))
+	case 0x78 <= tag && tag <= 0x7f:
+		return d.readList(int(tag - 0x78))
+
+	// map
+	case tag == 'M':
+		if _, err := d.readType(); err != nil {
+			return nil, err
+		}
+		return d.readMap()
+	case tag == 'H':
+		return d.readMap()
+
+	// object
+	case tag == 'C':
+		if err := d.readClassDef(); err != nil {
+			return nil, err
+		}
+		// the class definition is followed by the object
+		return d.readValue()
+	case tag == 'O':
+		idx, err := d.readInt()
+		if err != nil {
+			return nil, err
+		}
+		return d.readObject(int(idx))
+	case 0x60 <= tag && tag <= 0x6f:
+		return d.readObject(int(tag - 0x60))
+
+	case tag == 'Q':
+		idx, err := d.readInt()
+		if err != nil {
+			return nil, err
+		}
+		if idx < 0 || int(idx) >= len(d.refs) {
+			return nil, fmt.Errorf("hessian: bad ref %d", idx)
+		}
+		if d.building[idx] {
+			// circular reference can't be represented in JSON
+			return nil, nil
+		}
+		return d.refs[idx], nil
+	}
+
+	return nil, fmt.Errorf("hessian: unknown tag 0x%x", tag)
+}
+
+// toJSONValue converts the decoded value to the value which can be marshaled by encoding/json
+func toJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *hessianObject:
+		m := make(map[string]interface{}, len(v.fields))
+		for k, f := range v.fields {
+			m[k] = toJSONValue(f)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, f := range v {
+			m[k] = toJSONValue(f)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = toJSONValue(item)
+		}
+		return list
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	}
+	return v
+}
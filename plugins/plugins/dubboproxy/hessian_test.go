@@ -0,0 +1,223 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dubboproxy
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHessianRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input interface{}
+		want  interface{}
+	}{
+		{name: "null", input: nil, want: nil},
+		{name: "true", input: true, want: true},
+		{name: "small int", input: json.Number("-16"), want: int32(-16)},
+		{name: "int", input: json.Number("2047"), want: int32(2047)},
+		{name: "three bytes int", input: json.Number("-262144"), want: int32(-262144)},
+		{name: "four bytes int", input: json.Number("2147483647"), want: int32(2147483647)},
+		{name: "long", input: json.Number("2147483648"), want: int64(2147483648)},
+		{name: "large long", input: json.Number("-9223372036854775808"), want: int64(-9223372036854775808)},
+		{name: "zero double", input: json.Number("0.0"), want: float64(0)},
+		{name: "byte double", input: 12.0, want: float64(12)},
+		{name: "short double", input: -300.0, want: float64(-300)},
+		{name: "double", input: json.Number("3.14"), want: 3.14},
+		{name: "short string", input: "hello", want: "hello"},
+		{name: "unicode string", input: "你好, 🌍", want: "你好, 🌍"},
+		{name: "medium string", input: strings.Repeat("a", 1000), want: strings.Repeat("a", 1000)},
+		{name: "long string", input: strings.Repeat("中", 0x8000+3), want: strings.Repeat("中", 0x8000+3)},
+		{
+			name:  "list",
+			input: []interface{}{"a", json.Number("1"), []interface{}{}},
+			want:  []interface{}{"a", int32(1), []interface{}{}},
+		},
+		{
+			name: "map",
+			input: map[string]interface{}{
+				"class": "org.apache.dubbo.samples.User",
+				"age":   json.Number("18"),
+				"tags":  []interface{}{"x"},
+			},
+			want: map[string]interface{}{
+				"class": "org.apache.dubbo.samples.User",
+				"age":   int32(18),
+				"tags":  []interface{}{"x"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &hessianEncoder{}
+			require.NoError(t, e.writeValue(tt.input))
+			d := newHessianDecoder(e.bytes())
+			v, err := d.readValue()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, v)
+			assert.Equal(t, len(e.bytes()), d.pos)
+		})
+	}
+}
+
+func TestHessianDecode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  interface{}
+		err   string
+	}{
+		{
+			name:  "compact long",
+			input: []byte{0xf0, 0x00},
+			want:  int64(-2048),
+		},
+		{
+			name:  "three bytes long",
+			input: []byte{0x3f, 0xff, 0xff},
+			want:  int64(262143),
+		},
+		{
+			name:  "int as long",
+			input: []byte{0x59, 0x80, 0x00, 0x00, 0x00},
+			want:  int64(-2147483648),
+		},
+		{
+			name:  "double in mills",
+			input: []byte{0x5f, 0x00, 0x00, 0x04, 0xd2},
+			want:  1.234,
+		},
+		{
+			name:  "date",
+			input: []byte{0x4a, 0x00, 0x00, 0x00, 0xd0, 0x4b, 0x92, 0x84, 0xb8},
+			want:  "1998-05-08T09:51:31Z",
+		},
+		{
+			name:  "compact date",
+			input: []byte{0x4b, 0x00, 0xe3, 0x83, 0x8f},
+			want:  "1998-05-08T09:51:00Z",
+		},
+		{
+			name:  "binary",
+			input: []byte{0x23, 0x01, 0x02, 0x03},
+			want:  []byte{1, 2, 3},
+		},
+		{
+			name:  "chunked binary",
+			input: []byte{'A', 0x00, 0x01, 0x01, 'B', 0x00, 0x01, 0x02},
+			want:  []byte{1, 2},
+		},
+		{
+			name:  "chunked string",
+			input: []byte{'R', 0x00, 0x02, 'h', 'e', 0x03, 'l', 'l', 'o'},
+			want:  "hello",
+		},
+		{
+			name: "surrogate pair",
+			// 🌍 in CESU-8, which is written by Java
+			input: []byte{0x02, 0xed, 0xa0, 0xbc, 0xed, 0xbc, 0x8d},
+			want:  "🌍",
+		},
+		{
+			name:  "typed list",
+			input: []byte{'V', 0x04, '[', 'i', 'n', 't', 0x92, 0x90, 0x91},
+			want:  []interface{}{int32(0), int32(1)},
+		},
+		{
+			name:  "compact typed list with type ref",
+			input: []byte{0x7a, 0x71, 0x04, '[', 'i', 'n', 't', 0x91, 0x71, 0x90, 0x92},
+			want:  []interface{}{[]interface{}{int32(1)}, []interface{}{int32(2)}},
+		},
+		{
+			name:  "bad type ref",
+			input: []byte{0x72, 0x90, 0x91, 0x92},
+			err:   "bad type ref",
+		},
+		{
+			name:  "variable length list",
+			input: []byte{0x57, 0x91, 0x92, 'Z'},
+			want:  []interface{}{int32(1), int32(2)},
+		},
+		{
+			name:  "compact untyped list",
+			input: []byte{0x79, 0x01, 'a'},
+			want:  []interface{}{"a"},
+		},
+		{
+			name:  "typed map",
+			input: []byte{'M', 0x08, 'j', 'a', 'v', 'a', '.', 'M', 'a', 'p', 0x91, 0x01, 'a', 'N', 0x01, 'b', 'Z'},
+			want:  map[string]interface{}{"1": "a", "null": "b"},
+		},
+		{
+			name: "object",
+			input: []byte{
+				'C', 0x04, 'U', 's', 'e', 'r', 0x92, 0x04, 'n', 'a', 'm', 'e', 0x03, 'a', 'g', 'e',
+				0x60, 0x03, 'B', 'o', 'b', 0xa2,
+			},
+			want: map[string]interface{}{"name": "Bob", "age": int32(18)},
+		},
+		{
+			name: "enum and big decimal",
+			input: []byte{
+				'C', 0x05, 'C', 'o', 'l', 'o', 'r', 0x91, 0x04, 'n', 'a', 'm', 'e',
+				'C', 0x14, 'j', 'a', 'v', 'a', '.', 'm', 'a', 't', 'h', '.', 'B', 'i', 'g', 'D', 'e', 'c', 'i', 'm', 'a', 'l',
+				0x91, 0x05, 'v', 'a', 'l', 'u', 'e',
+				0x7a, 0x60, 0x03, 'R', 'E', 'D', 0x61, 0x04, '1', '.', '1', '0',
+			},
+			want: []interface{}{"RED", json.Number("1.10")},
+		},
+		{
+			name: "circular reference",
+			input: []byte{
+				'C', 0x04, 'N', 'o', 'd', 'e', 0x92, 0x04, 'n', 'a', 'm', 'e', 0x04, 'n', 'e', 'x', 't',
+				0x60, 0x01, 'a', 'Q', 0x90,
+			},
+			want: map[string]interface{}{"name": "a", "next": nil},
+		},
+		{
+			name:  "shared reference",
+			input: []byte{0x7a, 'H', 0x01, 'a', 0x91, 'Z', 'Q', 0x91},
+			want:  []interface{}{map[string]interface{}{"a": int32(1)}, map[string]interface{}{"a": int32(1)}},
+		},
+		{
+			name:  "truncated",
+			input: []byte{'I', 0x00},
+			err:   "unexpected end of data",
+		},
+		{
+			name:  "unknown tag",
+			input: []byte{0x40},
+			err:   "unknown tag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newHessianDecoder(tt.input).readValue()
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, toJSONValue(v))
+		})
+	}
+}
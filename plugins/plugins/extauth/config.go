@@ -15,9 +15,16 @@
 package extauth
 
 import (
+	"crypto/tls"
 	"net/http"
 	"time"
 
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"mosn.io/htnn/api/pkg/circuitbreaker"
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/plugins"
 	"mosn.io/htnn/types/pkg/expr"
@@ -44,11 +51,19 @@ type config struct {
 	extauth.Config
 
 	client                  *http.Client
+	authClient              authv3.AuthorizationClient
+	timeout                 time.Duration
+	breaker                 *circuitbreaker.Breaker
+	headerToAuthMatcher     expr.Matcher
 	headerToUpstreamMatcher expr.Matcher
 	headerToClientMatcher   expr.Matcher
 }
 
 func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if conf.GetGrpcService() != nil {
+		return conf.initGrpcService()
+	}
+
 	du := 200 * time.Millisecond
 	timeout := conf.GetHttpService().GetTimeout()
 	if timeout != nil {
@@ -56,6 +71,17 @@ func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 	}
 
 	conf.client = &http.Client{Timeout: du}
+	if cbConf := conf.GetHttpService().GetCircuitBreaker(); cbConf != nil {
+		conf.breaker = circuitbreaker.Get(extauth.Name+":"+conf.GetHttpService().GetUrl(), cbConf.ToConfig())
+	}
+
+	if hdrs := conf.GetHttpService().GetAuthorizationRequest().GetAllowedHeaders(); len(hdrs) > 0 {
+		matcher, err := expr.BuildRepeatedStringMatcherIgnoreCase(hdrs)
+		if err != nil {
+			return err
+		}
+		conf.headerToAuthMatcher = matcher
+	}
 
 	resp := conf.GetHttpService().GetAuthorizationResponse()
 	if resp != nil {
@@ -76,3 +102,54 @@ func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 	}
 	return nil
 }
+
+func (conf *config) initGrpcService() error {
+	gs := conf.GetGrpcService()
+	conf.timeout = 200 * time.Millisecond
+	if gs.GetTimeout() != nil {
+		conf.timeout = gs.GetTimeout().AsDuration()
+	}
+
+	creds := insecure.NewCredentials()
+	if gs.GetTls() {
+		creds = credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: gs.TlsSkipVerify,
+		})
+	}
+	// The connection is established lazily, so it won't block the configuration loading
+	conn, err := grpc.NewClient(gs.GetTarget(), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return err
+	}
+	conf.authClient = authv3.NewAuthorizationClient(conn)
+
+	if cbConf := gs.GetCircuitBreaker(); cbConf != nil {
+		conf.breaker = circuitbreaker.Get(extauth.Name+":"+gs.GetTarget(), cbConf.ToConfig())
+	}
+
+	if len(gs.GetAllowedHeaders()) > 0 {
+		matcher, err := expr.BuildRepeatedStringMatcherIgnoreCase(gs.GetAllowedHeaders())
+		if err != nil {
+			return err
+		}
+		conf.headerToAuthMatcher = matcher
+	}
+	return nil
+}
+
+func (conf *config) statusOnError() int {
+	var code int
+	if conf.GetGrpcService() != nil {
+		code = int(conf.GetGrpcService().GetStatusOnError())
+	} else {
+		code = int(conf.GetHttpService().GetStatusOnError())
+	}
+	if code == 0 {
+		code = 403
+	}
+	return code
+}
+
+func (conf *config) withRequestBody() bool {
+	return conf.GetHttpService().GetWithRequestBody() || conf.GetGrpcService().GetWithRequestBody()
+}
@@ -19,6 +19,9 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 )
@@ -35,9 +38,29 @@ type filter struct {
 
 	callbacks api.FilterCallbackHandler
 	config    *config
+
+	// headers added to the response when the gRPC authorization service allows the request
+	respHeadersToAdd []*corev3.HeaderValueOption
+}
+
+func (f *filter) onError(headers api.RequestHeaderMap) api.ResultAction {
+	if f.config.GetFailureModeAllow() {
+		if f.config.GetFailureModeAllowHeaderAdd() {
+			headers.Set("x-envoy-auth-failure-mode-allowed", "true")
+		}
+		return api.Continue
+	}
+	return &api.LocalResponse{Code: f.config.statusOnError()}
 }
 
 func (f *filter) check(headers api.RequestHeaderMap, data api.BufferInstance) api.ResultAction {
+	if f.config.authClient != nil {
+		return f.checkGrpc(headers, data)
+	}
+	return f.checkHTTP(headers, data)
+}
+
+func (f *filter) checkHTTP(headers api.RequestHeaderMap, data api.BufferInstance) api.ResultAction {
 	hs := f.config.GetHttpService()
 	uri := hs.GetUrl()
 	path, err := url.JoinPath(uri, headers.Path())
@@ -52,10 +75,23 @@ func (f *filter) check(headers api.RequestHeaderMap, data api.BufferInstance) ap
 		return &api.LocalResponse{Code: 503}
 	}
 	req.Host = headers.Host()
+	if f.config.headerToAuthMatcher != nil {
+		headers.Range(func(k, v string) bool {
+			if !strings.HasPrefix(k, ":") && f.config.headerToAuthMatcher.Match(k) {
+				req.Header.Add(k, v)
+			}
+			return true
+		})
+	}
 	authz, ok := headers.Get("authorization")
 	if ok {
 		req.Header.Set("authorization", authz)
 	}
+	if name := hs.GetAuthorizationRequest().GetConsumerHeader(); name != "" {
+		if consumer := f.callbacks.GetConsumer(); consumer != nil {
+			req.Header.Set(name, consumer.Name())
+		}
+	}
 	headersToAdd := hs.GetAuthorizationRequest().GetHeadersToAdd()
 	for _, h := range headersToAdd {
 		// Envoy doesn't support adding multiple same name headers here,
@@ -67,24 +103,27 @@ func (f *filter) check(headers api.RequestHeaderMap, data api.BufferInstance) ap
 		req.Body = io.NopCloser(bytes.NewReader(data.Bytes()))
 	}
 
+	var done func(success bool)
+	if f.config.breaker != nil {
+		done, err = f.config.breaker.Allow()
+		if err != nil {
+			api.LogWarnf("skip calling ext authz server: %v", err)
+			return f.onError(headers)
+		}
+	}
+
 	rsp, err := f.config.client.Do(req)
+	if done != nil {
+		done(err == nil && rsp.StatusCode < 500)
+	}
 	if err != nil || rsp.StatusCode >= 500 {
 		if err != nil {
 			api.LogWarnf("failed to call ext authz server: %v", err)
 		} else {
+			rsp.Body.Close()
 			api.LogWarnf("failed to call ext authz server: %s", rsp.Status)
 		}
-		if f.config.GetFailureModeAllow() {
-			if f.config.GetFailureModeAllowHeaderAdd() {
-				headers.Set("x-envoy-auth-failure-mode-allowed", "true")
-			}
-			return api.Continue
-		}
-		code := int(hs.GetStatusOnError())
-		if code == 0 {
-			code = 403
-		}
-		return &api.LocalResponse{Code: code}
+		return f.onError(headers)
 	}
 
 	rsp.Body.Close()
@@ -114,7 +153,7 @@ func (f *filter) check(headers api.RequestHeaderMap, data api.BufferInstance) ap
 }
 
 func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
-	if f.config.GetHttpService().GetWithRequestBody() {
+	if f.config.withRequestBody() {
 		return api.WaitAllData
 	}
 	return f.check(headers, nil)
@@ -123,3 +162,8 @@ func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api
 func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
 	return f.check(headers, data)
 }
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	applyHeaderOptions(headers, f.respHeadersToAdd)
+	return api.Continue
+}
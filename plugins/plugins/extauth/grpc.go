@@ -0,0 +1,222 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extauth
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+// headerMap is the common part of the request and response header maps
+type headerMap interface {
+	Add(key, value string)
+	Set(key, value string)
+	Values(key string) []string
+	Del(key string)
+}
+
+func headerOptionValue(h *corev3.HeaderValue) string {
+	if h.Value != "" {
+		return h.Value
+	}
+	return string(h.RawValue)
+}
+
+// applyHeaderOptions follows the semantics of the headers in Envoy's ext_authz CheckResponse
+func applyHeaderOptions(headers headerMap, opts []*corev3.HeaderValueOption) {
+	for _, opt := range opts {
+		h := opt.GetHeader()
+		if h == nil || h.Key == "" {
+			continue
+		}
+		k, v := h.Key, headerOptionValue(h)
+		if opt.Append != nil {
+			if opt.Append.Value {
+				headers.Add(k, v)
+			} else {
+				headers.Set(k, v)
+			}
+			continue
+		}
+
+		exists := len(headers.Values(k)) > 0
+		switch opt.AppendAction {
+		case corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD:
+			headers.Add(k, v)
+		case corev3.HeaderValueOption_ADD_IF_ABSENT:
+			if !exists {
+				headers.Set(k, v)
+			}
+		case corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD:
+			headers.Set(k, v)
+		case corev3.HeaderValueOption_OVERWRITE_IF_EXISTS:
+			if exists {
+				headers.Set(k, v)
+			}
+		}
+	}
+}
+
+func (f *filter) buildCheckRequest(headers api.RequestHeaderMap, data api.BufferInstance) *authv3.CheckRequest {
+	hdrs := map[string]string{}
+	headers.Range(func(k, v string) bool {
+		k = strings.ToLower(k)
+		if f.config.headerToAuthMatcher != nil && !strings.HasPrefix(k, ":") &&
+			k != "authorization" && !f.config.headerToAuthMatcher.Match(k) {
+			return true
+		}
+		if old, ok := hdrs[k]; ok {
+			// join the headers with the same name like Envoy
+			hdrs[k] = old + "," + v
+		} else {
+			hdrs[k] = v
+		}
+		return true
+	})
+
+	u := headers.URL()
+	httpReq := &authv3.AttributeContext_HttpRequest{
+		Id:       headers.GetRaw("x-request-id"),
+		Method:   headers.Method(),
+		Headers:  hdrs,
+		Path:     headers.Path(),
+		Host:     headers.Host(),
+		Scheme:   headers.Scheme(),
+		Query:    u.RawQuery,
+		Fragment: u.Fragment,
+		Size:     -1,
+	}
+	if data != nil {
+		body := data.Bytes()
+		httpReq.Size = int64(len(body))
+		if utf8.Valid(body) {
+			httpReq.Body = string(body)
+		} else {
+			httpReq.RawBody = body
+		}
+	}
+
+	attrs := &authv3.AttributeContext{
+		Request: &authv3.AttributeContext_Request{
+			Time: timestamppb.Now(),
+			Http: httpReq,
+		},
+	}
+	if host, port, err := net.SplitHostPort(f.callbacks.StreamInfo().DownstreamRemoteAddress()); err == nil {
+		portValue, _ := strconv.ParseUint(port, 10, 32)
+		attrs.Source = &authv3.AttributeContext_Peer{
+			Address: &corev3.Address{
+				Address: &corev3.Address_SocketAddress{
+					SocketAddress: &corev3.SocketAddress{
+						Address: host,
+						PortSpecifier: &corev3.SocketAddress_PortValue{
+							PortValue: uint32(portValue),
+						},
+					},
+				},
+			},
+		}
+	}
+	if consumer := f.callbacks.GetConsumer(); consumer != nil {
+		attrs.ContextExtensions = map[string]string{
+			"consumer": consumer.Name(),
+		}
+	}
+	return &authv3.CheckRequest{Attributes: attrs}
+}
+
+func (f *filter) checkGrpc(headers api.RequestHeaderMap, data api.BufferInstance) api.ResultAction {
+	gs := f.config.GetGrpcService()
+	req := f.buildCheckRequest(headers, data)
+
+	ctx, cancel := context.WithTimeout(context.Background(), f.config.timeout)
+	defer cancel()
+	if md := gs.GetInitialMetadata(); len(md) > 0 {
+		kv := make([]string, 0, 2*len(md))
+		for _, h := range md {
+			kv = append(kv, strings.ToLower(h.Key), h.Value)
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, kv...)
+	}
+
+	var done func(success bool)
+	var err error
+	if f.config.breaker != nil {
+		done, err = f.config.breaker.Allow()
+		if err != nil {
+			api.LogWarnf("skip calling ext authz server: %v", err)
+			return f.onError(headers)
+		}
+	}
+
+	rsp, err := f.config.authClient.Check(ctx, req)
+	if done != nil {
+		done(err == nil)
+	}
+	if err != nil {
+		api.LogWarnf("failed to call ext authz server: %v", err)
+		return f.onError(headers)
+	}
+
+	if codes.Code(rsp.GetStatus().GetCode()) != codes.OK {
+		denied := rsp.GetDeniedResponse()
+		code := int(denied.GetStatus().GetCode())
+		if code == 0 {
+			code = 403
+		}
+		hdr := http.Header{}
+		applyHeaderOptions(hdr, denied.GetHeaders())
+		return &api.LocalResponse{Code: code, Msg: denied.GetBody(), Header: hdr}
+	}
+
+	okRsp := rsp.GetOkResponse()
+	if okRsp == nil {
+		return api.Continue
+	}
+	applyHeaderOptions(headers, okRsp.Headers)
+	for _, k := range okRsp.HeadersToRemove {
+		// pseudo headers and Host can't be removed, like Envoy
+		if strings.HasPrefix(k, ":") || strings.EqualFold(k, "host") {
+			continue
+		}
+		headers.Del(k)
+	}
+	if len(okRsp.QueryParametersToSet) > 0 || len(okRsp.QueryParametersToRemove) > 0 {
+		u := headers.URL()
+		query := u.Query()
+		for _, k := range okRsp.QueryParametersToRemove {
+			query.Del(k)
+		}
+		for _, p := range okRsp.QueryParametersToSet {
+			query.Set(p.Key, p.Value)
+		}
+		u.RawQuery = query.Encode()
+		headers.SetPath(u.RequestURI())
+	}
+	f.respHeadersToAdd = okRsp.ResponseHeadersToAdd
+	return api.Continue
+}
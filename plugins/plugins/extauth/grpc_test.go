@@ -0,0 +1,310 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extauth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type authServer struct {
+	authv3.UnimplementedAuthorizationServer
+
+	check func(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error)
+}
+
+func (s *authServer) Check(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	return s.check(ctx, req)
+}
+
+func startAuthServer(t *testing.T, srv *authServer) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	authv3.RegisterAuthorizationServer(s, srv)
+	go func() {
+		_ = s.Serve(lis)
+	}()
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func header(k, v string, action corev3.HeaderValueOption_HeaderAppendAction) *corev3.HeaderValueOption {
+	return &corev3.HeaderValueOption{
+		Header:       &corev3.HeaderValue{Key: k, Value: v},
+		AppendAction: action,
+	}
+}
+
+func TestExtAuthGrpc(t *testing.T) {
+	srv := &authServer{}
+	addr := startAuthServer(t, srv)
+
+	tests := []struct {
+		name   string
+		input  string
+		hdr    map[string][]string
+		check  func(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error)
+		res    api.ResultAction
+		upHdr  map[string][]string
+		noHdr  []string
+		path   string
+		rspHdr map[string][]string
+	}{
+		{
+			name: "allowed",
+			input: `{"grpcService":{
+				"initialMetadata": [{"key": "X-Token", "value": "secret"}]
+			}}`,
+			hdr: map[string][]string{
+				"Authorization": {"Bearer token"},
+				"Foo":           {"bar", "baz"},
+				"Remove-Me":     {"x"},
+				"Keep":          {"old"},
+			},
+			check: func(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+				assert.Equal(t, []string{"secret"}, md.Get("x-token"))
+
+				attrs := req.GetAttributes()
+				assert.Equal(t, "183.128.130.43", attrs.GetSource().GetAddress().GetSocketAddress().GetAddress())
+				assert.Equal(t, "alice", attrs.GetContextExtensions()["consumer"])
+				r := attrs.GetRequest().GetHttp()
+				assert.Equal(t, "DELETE", r.Method)
+				assert.Equal(t, "test.local", r.Host)
+				assert.Equal(t, "/users?a=1", r.Path)
+				assert.Equal(t, "a=1", r.Query)
+				assert.Equal(t, "Bearer token", r.Headers["authorization"])
+				assert.Equal(t, "bar,baz", r.Headers["foo"])
+				return &authv3.CheckResponse{
+					Status: &rpcstatus.Status{Code: int32(codes.OK)},
+					HttpResponse: &authv3.CheckResponse_OkResponse{
+						OkResponse: &authv3.OkHttpResponse{
+							Headers: []*corev3.HeaderValueOption{
+								header("x-user", "alice", corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD),
+								header("foo", "qux", corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD),
+								header("keep", "new", corev3.HeaderValueOption_ADD_IF_ABSENT),
+								{
+									Header: &corev3.HeaderValue{Key: "x-legacy", Value: "v"},
+									Append: wrapperspb.Bool(false),
+								},
+							},
+							HeadersToRemove:         []string{"remove-me", ":path"},
+							QueryParametersToSet:    []*corev3.QueryParameter{{Key: "b", Value: "2"}},
+							QueryParametersToRemove: []string{"a"},
+							ResponseHeadersToAdd: []*corev3.HeaderValueOption{
+								header("x-auth", "ok", corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD),
+							},
+						},
+					},
+				}, nil
+			},
+			upHdr: map[string][]string{
+				"x-user":   {"alice"},
+				"foo":      {"bar", "baz", "qux"},
+				"keep":     {"old"},
+				"x-legacy": {"v"},
+			},
+			noHdr: []string{"remove-me"},
+			path:  "/users?b=2",
+			rspHdr: map[string][]string{
+				"x-auth": {"ok"},
+			},
+		},
+		{
+			name: "only allowed headers are sent",
+			input: `{"grpcService":{
+				"allowedHeaders": [{"exact": "foo"}]
+			}}`,
+			hdr: map[string][]string{
+				"Authorization": {"Bearer token"},
+				"Foo":           {"bar"},
+				"Other":         {"not passed"},
+			},
+			check: func(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+				hdrs := req.GetAttributes().GetRequest().GetHttp().GetHeaders()
+				assert.Equal(t, "Bearer token", hdrs["authorization"])
+				assert.Equal(t, "bar", hdrs["foo"])
+				assert.NotContains(t, hdrs, "other")
+				return &authv3.CheckResponse{}, nil
+			},
+		},
+		{
+			name:  "denied",
+			input: `{"grpcService":{}}`,
+			check: func(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+				return &authv3.CheckResponse{
+					Status: &rpcstatus.Status{Code: int32(codes.PermissionDenied)},
+					HttpResponse: &authv3.CheckResponse_DeniedResponse{
+						DeniedResponse: &authv3.DeniedHttpResponse{
+							Status: &typev3.HttpStatus{Code: typev3.StatusCode_Unauthorized},
+							Headers: []*corev3.HeaderValueOption{
+								header("www-authenticate", "Bearer", corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD),
+							},
+							Body: "not authorized",
+						},
+					},
+				}, nil
+			},
+			res: &api.LocalResponse{Code: 401, Msg: "not authorized",
+				Header: http.Header(map[string][]string{
+					"Www-Authenticate": {"Bearer"},
+				}),
+			},
+		},
+		{
+			name:  "denied without response",
+			input: `{"grpcService":{}}`,
+			check: func(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+				return &authv3.CheckResponse{
+					Status: &rpcstatus.Status{Code: int32(codes.Unauthenticated)},
+				}, nil
+			},
+			res: &api.LocalResponse{Code: 403, Header: http.Header{}},
+		},
+		{
+			name: "auth error",
+			input: `{"grpcService":{
+				"statusOnError": 503
+			}}`,
+			check: func(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+				return nil, errors.New("ouch")
+			},
+			res: &api.LocalResponse{Code: 503},
+		},
+		{
+			name: "auth error, but allow mode failure and add header",
+			input: `{
+				"grpcService": {},
+				"failureModeAllow": true,
+				"failureModeAllowHeaderAdd": true
+			}`,
+			check: func(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+				return nil, errors.New("ouch")
+			},
+			upHdr: map[string][]string{
+				"x-envoy-auth-failure-mode-allowed": {"true"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.check = tt.check
+			cb := envoy.NewFilterCallbackHandler()
+			cb.SetConsumer(&testConsumer{name: "alice"})
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			conf.GetGrpcService().Target = addr
+			require.NoError(t, conf.Validate())
+			require.NoError(t, conf.Init(nil))
+
+			f := factory(conf, cb)
+			defaultHdr := map[string][]string{
+				":authority": {"test.local"},
+				":method":    {"DELETE"},
+				":path":      {"/users?a=1"},
+			}
+			for k, v := range tt.hdr {
+				defaultHdr[k] = v
+			}
+			hdr := envoy.NewRequestHeaderMap(http.Header(defaultHdr))
+			res := f.DecodeHeaders(hdr, true)
+			if tt.res != nil {
+				assert.Equal(t, tt.res, res)
+				return
+			}
+			assert.Equal(t, api.Continue, res)
+
+			for k, v := range tt.upHdr {
+				assert.Equal(t, v, hdr.Values(k))
+			}
+			for _, k := range tt.noHdr {
+				assert.Empty(t, hdr.Values(k))
+			}
+			if tt.path != "" {
+				assert.Equal(t, tt.path, hdr.Path())
+			}
+
+			rspHdr := envoy.NewResponseHeaderMap(http.Header{})
+			f.EncodeHeaders(rspHdr, true)
+			for k, v := range tt.rspHdr {
+				assert.Equal(t, v, rspHdr.Values(k))
+			}
+		})
+	}
+}
+
+func TestExtAuthGrpcWithBody(t *testing.T) {
+	srv := &authServer{
+		check: func(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+			r := req.GetAttributes().GetRequest().GetHttp()
+			if r.Body == "" {
+				assert.Equal(t, []byte{0xff, 0xfe}, r.RawBody)
+			} else {
+				assert.Equal(t, "hello", r.Body)
+			}
+			assert.Equal(t, int64(len(r.Body)+len(r.RawBody)), r.Size)
+			return &authv3.CheckResponse{}, nil
+		},
+	}
+	addr := startAuthServer(t, srv)
+
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"grpcService":{"withRequestBody":true}}`), conf))
+	conf.GetGrpcService().Target = addr
+	require.NoError(t, conf.Init(nil))
+
+	for _, body := range [][]byte{[]byte("hello"), {0xff, 0xfe}} {
+		f := factory(conf, envoy.NewFilterCallbackHandler())
+		hdr := envoy.NewRequestHeaderMap(http.Header{
+			":authority": {"test.local"},
+			":method":    {"POST"},
+			":path":      {"/"},
+		})
+		assert.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+		assert.Equal(t, api.Continue, f.DecodeRequest(hdr, envoy.NewBufferInstance(body), nil))
+	}
+}
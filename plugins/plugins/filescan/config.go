@@ -0,0 +1,116 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filescan
+
+import (
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/circuitbreaker"
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/filescan"
+)
+
+const (
+	defaultTimeout     = 30 * time.Second
+	defaultMaxFileSize = 10 << 20
+	defaultMaxBodySize = 32 << 20
+)
+
+func init() {
+	plugins.RegisterPlugin(filescan.Name, &plugin{})
+}
+
+type plugin struct {
+	filescan.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	filescan.CustomConfig
+
+	maxFileSize int
+	maxBodySize int
+	scanner     scanner
+	breaker     *circuitbreaker.Breaker
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.maxFileSize = defaultMaxFileSize
+	if conf.MaxFileSize > 0 {
+		conf.maxFileSize = int(conf.MaxFileSize)
+	}
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+	timeout := defaultTimeout
+	if conf.Timeout != nil {
+		timeout = conf.Timeout.AsDuration()
+	}
+
+	var target string
+	if icap := conf.GetIcap(); icap != nil {
+		s, err := newICAPScanner(icap.Url, timeout)
+		if err != nil {
+			return err
+		}
+		conf.scanner = s
+		target = icap.Url
+	} else {
+		addr := conf.GetClamav().Address
+		s := &clamavScanner{
+			network: "tcp",
+			address: addr,
+			timeout: timeout,
+		}
+		if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+			s.network = "unix"
+			s.address = path
+		}
+		conf.scanner = s
+		target = addr
+	}
+
+	if conf.CircuitBreaker != nil {
+		conf.breaker = circuitbreaker.Get(filescan.Name+":"+target, conf.CircuitBreaker.ToConfig())
+	}
+	return nil
+}
+
+// scan returns the name of the threat if the content is infected
+func (conf *config) scan(content []byte) (string, error) {
+	var done func(success bool)
+	if conf.breaker != nil {
+		var err error
+		done, err = conf.breaker.Allow()
+		if err != nil {
+			return "", err
+		}
+	}
+	threat, err := conf.scanner.scan(content)
+	if done != nil {
+		done(err == nil)
+	}
+	return threat, err
+}
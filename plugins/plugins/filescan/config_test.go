@@ -0,0 +1,75 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filescan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "clamav",
+			input: `{"clamav":{"address":"127.0.0.1:3310"},"timeout":"10s","maxFileSize":1024,"failOpen":true}`,
+		},
+		{
+			name:  "icap",
+			input: `{"icap":{"url":"icap://127.0.0.1/avscan"},"circuitBreaker":{}}`,
+		},
+		{
+			name:  "scanner is required",
+			input: `{}`,
+			err:   "invalid Config.Scanner: value is required",
+		},
+		{
+			name:  "clamav address is required",
+			input: `{"clamav":{}}`,
+			err:   "invalid Clamav.Address: value length must be at least 1 runes",
+		},
+		{
+			name:  "bad ICAP url",
+			input: `{"icap":{"url":"http://127.0.0.1/avscan"}}`,
+			err:   "bad ICAP url http://127.0.0.1/avscan: scheme should be icap",
+		},
+		{
+			name:  "bad timeout",
+			input: `{"clamav":{"address":"127.0.0.1:3310"},"timeout":"0s"}`,
+			err:   "invalid Config.Timeout: value must be greater than 0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filescan
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/filescan"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	boundary string
+}
+
+var errFileTooLarge = errors.New("file is too large")
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	if endStream {
+		return api.Continue
+	}
+	ct, _ := headers.Get("content-type")
+	mediaType, params, err := mime.ParseMediaType(ct)
+	if err != nil || mediaType != "multipart/form-data" {
+		// only the files uploaded via form are scanned
+		return api.Continue
+	}
+	if params["boundary"] == "" {
+		return &api.LocalResponse{Code: http.StatusBadRequest, Msg: "missing multipart boundary"}
+	}
+	if cl, ok := headers.Get("content-length"); ok {
+		if n, err := strconv.Atoi(cl); err == nil && n > f.config.maxBodySize {
+			return &api.LocalResponse{Code: http.StatusRequestEntityTooLarge, Msg: "request body is too large"}
+		}
+	}
+
+	f.boundary = params["boundary"]
+	return api.WaitAllData
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	if data == nil || data.Len() == 0 {
+		return api.Continue
+	}
+	if data.Len() > f.config.maxBodySize {
+		return &api.LocalResponse{Code: http.StatusRequestEntityTooLarge, Msg: "request body is too large"}
+	}
+
+	mr := multipart.NewReader(bytes.NewReader(data.Bytes()), f.boundary)
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return api.Continue
+		}
+		if err != nil {
+			return &api.LocalResponse{Code: http.StatusBadRequest, Msg: "bad multipart body"}
+		}
+
+		filename := part.FileName()
+		if filename == "" {
+			// not a file
+			continue
+		}
+		content, err := f.readFile(part)
+		if err != nil {
+			if errors.Is(err, errFileTooLarge) {
+				return &api.LocalResponse{Code: http.StatusRequestEntityTooLarge, Msg: "file is too large"}
+			}
+			return &api.LocalResponse{Code: http.StatusBadRequest, Msg: "bad multipart body"}
+		}
+		if res := f.scan(filename, content); res != nil {
+			return res
+		}
+	}
+}
+
+func (f *filter) readFile(part *multipart.Part) ([]byte, error) {
+	content, err := io.ReadAll(io.LimitReader(part, int64(f.config.maxFileSize)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > f.config.maxFileSize {
+		return nil, errFileTooLarge
+	}
+	return content, nil
+}
+
+func (f *filter) scan(filename string, content []byte) api.ResultAction {
+	threat, err := f.config.scan(content)
+	if err != nil {
+		if f.config.FailOpen {
+			api.LogWarnf("failed to scan file %s, let it pass: %v", filename, err)
+			return nil
+		}
+		api.LogErrorf("failed to scan file %s: %v", filename, err)
+		return &api.LocalResponse{Code: http.StatusServiceUnavailable}
+	}
+	if threat == "" {
+		return nil
+	}
+
+	api.LogInfof("fileScan: reject file %s infected with %s", filename, threat)
+	f.callbacks.PluginState().Set(filescan.Name, "threat", threat)
+	return &api.LocalResponse{Code: http.StatusForbidden, Msg: "infected file is detected"}
+}
@@ -0,0 +1,180 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filescan
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	"mosn.io/htnn/types/plugins/filescan"
+)
+
+type formFile struct {
+	field    string
+	filename string
+	content  string
+}
+
+func newForm(t *testing.T, files ...formFile) (string, []byte) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	require.NoError(t, w.SetBoundary("boundary"))
+	require.NoError(t, w.WriteField("name", eicar))
+	for _, f := range files {
+		fw, err := w.CreateFormFile(f.field, f.filename)
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return w.FormDataContentType(), buf.Bytes()
+}
+
+func TestFileScan(t *testing.T) {
+	addr := fakeClamd(t)
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"clamav":{"address":"`+addr+`"},"maxFileSize":1024}`), conf))
+	require.NoError(t, conf.Init(nil))
+	ct, infected := newForm(t, formFile{"a", "a.txt", "hello"}, formFile{"b", "b.com", eicar})
+
+	tests := []struct {
+		name   string
+		config string
+		ct     string
+		body   []byte
+		res    api.ResultAction
+	}{
+		{
+			name: "not a form",
+			ct:   "application/json",
+			body: []byte(eicar),
+		},
+		{
+			name: "clean",
+			ct:   ct,
+			body: func() []byte {
+				_, body := newForm(t, formFile{"a", "a.txt", "hello"})
+				return body
+			}(),
+		},
+		{
+			name: "infected",
+			ct:   ct,
+			body: infected,
+			res:  &api.LocalResponse{Code: 403, Msg: "infected file is detected"},
+		},
+		{
+			name: "file too large",
+			ct:   ct,
+			body: func() []byte {
+				_, body := newForm(t, formFile{"a", "a.txt", string(make([]byte, 1025))})
+				return body
+			}(),
+			res: &api.LocalResponse{Code: 413, Msg: "file is too large"},
+		},
+		{
+			name: "missing boundary",
+			ct:   "multipart/form-data",
+			body: infected,
+			res:  &api.LocalResponse{Code: 400, Msg: "missing multipart boundary"},
+		},
+		{
+			name: "truncated body",
+			ct:   ct,
+			body: []byte("--boundary\r\nContent-Disposition: form-data; name=\"a\"; filename=\"a.txt\"\r\n\r\nhello"),
+			res:  &api.LocalResponse{Code: 400, Msg: "bad multipart body"},
+		},
+		{
+			name:   "body too large",
+			config: `{"clamav":{"address":"` + addr + `"},"maxBodySize":16}`,
+			ct:     ct,
+			body:   infected,
+			res:    &api.LocalResponse{Code: 413, Msg: "request body is too large"},
+		},
+		{
+			name:   "fail closed",
+			config: `{"clamav":{"address":"127.0.0.1:1"}}`,
+			ct:     ct,
+			body:   infected,
+			res:    &api.LocalResponse{Code: 503},
+		},
+		{
+			name:   "fail open",
+			config: `{"clamav":{"address":"127.0.0.1:1"},"failOpen":true}`,
+			ct:     ct,
+			body:   infected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := conf
+			if tt.config != "" {
+				c = &config{}
+				require.NoError(t, protojson.Unmarshal([]byte(tt.config), c))
+				require.NoError(t, c.Init(nil))
+			}
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(c, cb)
+			hdr := envoy.NewRequestHeaderMap(http.Header{
+				":method":      {"POST"},
+				":path":        {"/upload"},
+				"Content-Type": {tt.ct},
+			})
+			res := f.DecodeHeaders(hdr, false)
+			if res == api.WaitAllData {
+				res = f.DecodeRequest(hdr, envoy.NewBufferInstance(tt.body), nil)
+			}
+			if tt.res == nil {
+				assert.Equal(t, api.Continue, res)
+			} else {
+				assert.Equal(t, tt.res, res)
+			}
+		})
+	}
+
+	cb := envoy.NewFilterCallbackHandler()
+	f := factory(conf, cb)
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":method":      {"POST"},
+		":path":        {"/upload"},
+		"Content-Type": {ct},
+	})
+	require.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+	f.DecodeRequest(hdr, envoy.NewBufferInstance(infected), nil)
+	assert.Equal(t, "Eicar-Signature", cb.PluginState().Get(filescan.Name, "threat"))
+}
+
+func TestContentLength(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"clamav":{"address":"127.0.0.1:3310"},"maxBodySize":16}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":method":        {"POST"},
+		":path":          {"/upload"},
+		"Content-Type":   {"multipart/form-data; boundary=x"},
+		"Content-Length": {"17"},
+	})
+	assert.Equal(t, &api.LocalResponse{Code: 413, Msg: "request body is too large"}, f.DecodeHeaders(hdr, false))
+}
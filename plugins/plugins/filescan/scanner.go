@@ -0,0 +1,183 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filescan
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the content is sent to the scanner in chunks of this size
+const chunkSize = 64 << 10
+
+type scanner interface {
+	// scan returns the name of the threat if the content is infected
+	scan(content []byte) (string, error)
+}
+
+// clamavScanner streams the content to clamd with the INSTREAM command. Each chunk is prefixed
+// with its length in 4 bytes network order, and the stream is terminated by a zero-length chunk.
+type clamavScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+func (s *clamavScanner) scan(content []byte) (string, error) {
+	conn, err := net.DialTimeout(s.network, s.address, s.timeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(s.timeout))
+
+	w := bufio.NewWriterSize(conn, chunkSize+4)
+	// the "z" prefix means the command and the reply are terminated by NUL
+	_, _ = w.WriteString("zINSTREAM\x00")
+	var size [4]byte
+	for len(content) > 0 {
+		n := min(len(content), chunkSize)
+		binary.BigEndian.PutUint32(size[:], uint32(n))
+		_, _ = w.Write(size[:])
+		_, _ = w.Write(content[:n])
+		content = content[n:]
+	}
+	binary.BigEndian.PutUint32(size[:], 0)
+	_, _ = w.Write(size[:])
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil {
+		return "", err
+	}
+	return parseClamavReply(strings.TrimSuffix(reply, "\x00"))
+}
+
+// parseClamavReply parses the reply like "stream: OK", "stream: Eicar-Signature FOUND" or
+// "INSTREAM size limit exceeded. ERROR".
+func parseClamavReply(reply string) (string, error) {
+	result := strings.TrimPrefix(reply, "stream: ")
+	if result == "OK" {
+		return "", nil
+	}
+	if threat, ok := strings.CutSuffix(result, " FOUND"); ok && threat != "" {
+		return threat, nil
+	}
+	return "", fmt.Errorf("unexpected reply from clamd: %q", reply)
+}
+
+const defaultICAPPort = "1344"
+
+// icapScanner sends the content as the body of an HTTP response in RESPMOD mode (RFC 3507).
+// As we allow 204, the ICAP server responds with 204 if the content is clean. Otherwise, it
+// responds with 200 and a modified response, like a blocking page.
+type icapScanner struct {
+	service string
+	host    string
+	address string
+	timeout time.Duration
+}
+
+func newICAPScanner(rawURL string, timeout time.Duration) (*icapScanner, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), defaultICAPPort)
+	}
+	return &icapScanner{
+		service: u.String(),
+		host:    u.Host,
+		address: address,
+		timeout: timeout,
+	}, nil
+}
+
+func (s *icapScanner) scan(content []byte) (string, error) {
+	conn, err := net.DialTimeout("tcp", s.address, s.timeout)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(s.timeout))
+
+	resHdr := "HTTP/1.1 200 OK\r\nContent-Type: application/octet-stream\r\nContent-Length: " +
+		strconv.Itoa(len(content)) + "\r\n\r\n"
+	w := bufio.NewWriterSize(conn, chunkSize+16)
+	fmt.Fprintf(w, "RESPMOD %s ICAP/1.0\r\nHost: %s\r\nAllow: 204\r\nEncapsulated: res-hdr=0, res-body=%d\r\n\r\n",
+		s.service, s.host, len(resHdr))
+	_, _ = w.WriteString(resHdr)
+	for len(content) > 0 {
+		n := min(len(content), chunkSize)
+		fmt.Fprintf(w, "%x\r\n", n)
+		_, _ = w.Write(content[:n])
+		_, _ = w.WriteString("\r\n")
+		content = content[n:]
+	}
+	_, _ = w.WriteString("0\r\n\r\n")
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	r := textproto.NewReader(bufio.NewReader(conn))
+	line, err := r.ReadLine()
+	if err != nil {
+		return "", err
+	}
+	proto, status, ok := strings.Cut(line, " ")
+	if !ok || !strings.HasPrefix(proto, "ICAP/") {
+		return "", errors.New("bad ICAP status line: " + line)
+	}
+	hdr, err := r.ReadMIMEHeader()
+	if err != nil {
+		return "", err
+	}
+
+	code, _, _ := strings.Cut(status, " ")
+	switch code {
+	case "204":
+		return "", nil
+	case "200":
+		return icapThreat(hdr), nil
+	}
+	return "", errors.New("unexpected status from ICAP server: " + status)
+}
+
+// icapThreat extracts the name of the threat from the headers like
+// "X-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Signature;" or "X-Virus-ID: Eicar-Signature".
+func icapThreat(hdr textproto.MIMEHeader) string {
+	for _, field := range strings.Split(hdr.Get("X-Infection-Found"), ";") {
+		if threat, ok := strings.CutPrefix(strings.TrimSpace(field), "Threat="); ok && threat != "" {
+			return threat
+		}
+	}
+	if threat := hdr.Get("X-Virus-ID"); threat != "" {
+		return threat
+	}
+	// the content is modified without telling the reason
+	return "unknown"
+}
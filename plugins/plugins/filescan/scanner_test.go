@@ -0,0 +1,191 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filescan
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// serve accepts the connections and handles each of them with the given function
+func serve(t *testing.T, handle func(conn net.Conn)) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handle(conn)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+// fakeClamd detects the EICAR test file like clamd
+func fakeClamd(t *testing.T) string {
+	return serve(t, func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+		cmd, err := r.ReadString(0)
+		if err != nil || cmd != "zINSTREAM\x00" {
+			return
+		}
+		var content bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&content, r, int64(size)); err != nil {
+				return
+			}
+		}
+		if strings.Contains(content.String(), "EICAR") {
+			conn.Write([]byte("stream: Eicar-Signature FOUND\x00"))
+		} else {
+			conn.Write([]byte("stream: OK\x00"))
+		}
+	})
+}
+
+// fakeICAPServer detects the EICAR test file like c-icap
+func fakeICAPServer(t *testing.T) string {
+	return serve(t, func(conn net.Conn) {
+		r := bufio.NewReader(conn)
+		tp := textproto.NewReader(r)
+		line, err := tp.ReadLine()
+		if err != nil || !strings.HasPrefix(line, "RESPMOD ") {
+			return
+		}
+		if _, err := tp.ReadMIMEHeader(); err != nil {
+			return
+		}
+		res, err := http.ReadResponse(r, nil)
+		if err != nil {
+			return
+		}
+		// the body of the encapsulated response is chunked
+		body, err := io.ReadAll(httpChunkedReader(r))
+		if err != nil || strconv.Itoa(len(body)) != res.Header.Get("Content-Length") {
+			return
+		}
+		if strings.Contains(string(body), "EICAR") {
+			conn.Write([]byte("ICAP/1.0 200 OK\r\nX-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Signature;\r\nEncapsulated: res-hdr=0, null-body=19\r\n\r\nHTTP/1.1 403 Forbidden\r\n\r\n"))
+		} else {
+			conn.Write([]byte("ICAP/1.0 204 No Content\r\n\r\n"))
+		}
+	})
+}
+
+func httpChunkedReader(r *bufio.Reader) io.Reader {
+	pr, pw := io.Pipe()
+	go func() {
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			n, err := strconv.ParseInt(strings.TrimSpace(line), 16, 64)
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			if n == 0 {
+				r.ReadString('\n')
+				pw.Close()
+				return
+			}
+			if _, err := io.CopyN(pw, r, n); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			r.ReadString('\n')
+		}
+	}()
+	return pr
+}
+
+func TestClamavScanner(t *testing.T) {
+	s := &clamavScanner{network: "tcp", address: fakeClamd(t), timeout: time.Second}
+	threat, err := s.scan([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "", threat)
+
+	// the content is sent in several chunks
+	content := append(bytes.Repeat([]byte("a"), chunkSize*2), eicar...)
+	threat, err = s.scan(content)
+	require.NoError(t, err)
+	assert.Equal(t, "Eicar-Signature", threat)
+
+	s.address = "127.0.0.1:1"
+	_, err = s.scan([]byte("hello"))
+	assert.Error(t, err)
+}
+
+func TestParseClamavReply(t *testing.T) {
+	threat, err := parseClamavReply("stream: OK")
+	require.NoError(t, err)
+	assert.Equal(t, "", threat)
+	threat, err = parseClamavReply("stream: Win.Test.EICAR_HDB-1 FOUND")
+	require.NoError(t, err)
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", threat)
+	_, err = parseClamavReply("INSTREAM size limit exceeded. ERROR")
+	assert.ErrorContains(t, err, "unexpected reply from clamd")
+}
+
+func TestICAPScanner(t *testing.T) {
+	s, err := newICAPScanner("icap://"+fakeICAPServer(t)+"/avscan", time.Second)
+	require.NoError(t, err)
+	threat, err := s.scan([]byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, "", threat)
+
+	content := append(bytes.Repeat([]byte("a"), chunkSize*2), eicar...)
+	threat, err = s.scan(content)
+	require.NoError(t, err)
+	assert.Equal(t, "Eicar-Signature", threat)
+
+	s, err = newICAPScanner("icap://localhost/avscan", time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:1344", s.address)
+}
+
+func TestICAPThreat(t *testing.T) {
+	assert.Equal(t, "Eicar", icapThreat(map[string][]string{"X-Virus-Id": {"Eicar"}}))
+	assert.Equal(t, "unknown", icapThreat(map[string][]string{}))
+}
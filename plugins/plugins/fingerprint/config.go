@@ -0,0 +1,127 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fingerprint
+
+import (
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/fingerprint"
+)
+
+// The headers added by the proxies are not part of the header fingerprint, as they don't come
+// from the client.
+var (
+	proxyHeaders = map[string]bool{
+		"forwarded":    true,
+		"via":          true,
+		"x-request-id": true,
+		"x-real-ip":    true,
+	}
+	proxyHeaderPrefixes = []string{"x-forwarded-", "x-envoy-"}
+)
+
+func init() {
+	plugins.RegisterPlugin(fingerprint.Name, &plugin{})
+}
+
+type plugin struct {
+	fingerprint.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type fingerprintSet struct {
+	ja3    map[string]bool
+	ja4    map[string]bool
+	header map[string]bool
+}
+
+func newFingerprintSet(fps *fingerprint.Fingerprints) *fingerprintSet {
+	if fps == nil {
+		return nil
+	}
+	toSet := func(values []string) map[string]bool {
+		m := make(map[string]bool, len(values))
+		for _, v := range values {
+			m[strings.ToLower(v)] = true
+		}
+		return m
+	}
+	return &fingerprintSet{
+		ja3:    toSet(fps.Ja3),
+		ja4:    toSet(fps.Ja4),
+		header: toSet(fps.Header),
+	}
+}
+
+func (s *fingerprintSet) match(fps *fingerprints) bool {
+	return (fps.ja3 != "" && s.ja3[fps.ja3]) ||
+		(fps.ja4 != "" && s.ja4[fps.ja4]) ||
+		s.header[fps.header]
+}
+
+type config struct {
+	fingerprint.CustomConfig
+
+	ja3Header      string
+	ja4Header      string
+	ignoredHeaders map[string]bool
+	allow          *fingerprintSet
+	deny           *fingerprintSet
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.ja3Header = strings.ToLower(conf.Ja3Header)
+	conf.ja4Header = strings.ToLower(conf.Ja4Header)
+
+	conf.ignoredHeaders = make(map[string]bool, len(conf.IgnoredHeaders))
+	for _, h := range conf.IgnoredHeaders {
+		conf.ignoredHeaders[strings.ToLower(h)] = true
+	}
+	// the headers carrying the fingerprints are not sent by the client
+	sources := []string{conf.ja3Header, conf.ja4Header}
+	if fwd := conf.ForwardHeaders; fwd != nil {
+		sources = append(sources, fwd.Ja3, fwd.Ja4, fwd.Header)
+	}
+	for _, h := range sources {
+		if h != "" {
+			conf.ignoredHeaders[strings.ToLower(h)] = true
+		}
+	}
+
+	conf.allow = newFingerprintSet(conf.Allow)
+	conf.deny = newFingerprintSet(conf.Deny)
+	return nil
+}
+
+func (conf *config) ignored(name string) bool {
+	if proxyHeaders[name] || conf.ignoredHeaders[name] {
+		return true
+	}
+	for _, prefix := range proxyHeaderPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
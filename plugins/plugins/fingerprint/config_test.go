@@ -0,0 +1,72 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fingerprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+		},
+		{
+			name: "ok",
+			input: `{"ja3Header":"x-ja3","ja4Header":"x-ja4","ignoredHeaders":["x-trace"],
+				"forwardHeaders":{"header":"x-header-fingerprint"},
+				"deny":{"ja3":["e7d705a3286e19ea42f587b344ee6865"],"ja4":["t13d1516h2_8daaf6152771_02713d6af862"]}}`,
+		},
+		{
+			name:  "ja3 header is required",
+			input: `{"allow":{"ja3":["e7d705a3286e19ea42f587b344ee6865"]}}`,
+			err:   "ja3Header is required to match the JA3 fingerprints",
+		},
+		{
+			name:  "ja4 header is required",
+			input: `{"deny":{"ja4":["t13d1516h2_8daaf6152771_02713d6af862"]}}`,
+			err:   "ja4Header is required to match the JA4 fingerprints",
+		},
+		{
+			name:  "empty fingerprint",
+			input: `{"deny":{"header":[""]}}`,
+			err:   "invalid Fingerprints.Header[0]: value length must be at least 1 runes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
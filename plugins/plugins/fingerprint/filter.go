@@ -0,0 +1,89 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fingerprint
+
+import (
+	"net/http"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/fingerprint"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	fps := &fingerprints{}
+	if config.ja3Header != "" {
+		v, _ := headers.Get(config.ja3Header)
+		fps.ja3 = strings.ToLower(strings.TrimSpace(v))
+	}
+	if config.ja4Header != "" {
+		v, _ := headers.Get(config.ja4Header)
+		fps.ja4 = strings.ToLower(strings.TrimSpace(v))
+	}
+	protocol, _ := f.callbacks.StreamInfo().Protocol()
+	fps.header = config.headerFingerprint(headers, protocol)
+
+	state := f.callbacks.PluginState()
+	if fps.ja3 != "" {
+		state.Set(fingerprint.Name, "ja3", fps.ja3)
+	}
+	if fps.ja4 != "" {
+		state.Set(fingerprint.Name, "ja4", fps.ja4)
+	}
+	state.Set(fingerprint.Name, "header", fps.header)
+
+	if config.deny != nil && config.deny.match(fps) {
+		api.LogInfof("fingerprint: reject denied client, ja3: %s, ja4: %s, header: %s", fps.ja3, fps.ja4, fps.header)
+		return &api.LocalResponse{Code: http.StatusForbidden}
+	}
+	if config.allow != nil && !config.allow.match(fps) {
+		api.LogInfof("fingerprint: reject unknown client, ja3: %s, ja4: %s, header: %s", fps.ja3, fps.ja4, fps.header)
+		return &api.LocalResponse{Code: http.StatusForbidden}
+	}
+
+	if fwd := config.ForwardHeaders; fwd != nil {
+		// the headers sent by the client are overridden, so that they can't be forged
+		forward(headers, fwd.Ja3, fps.ja3)
+		forward(headers, fwd.Ja4, fps.ja4)
+		forward(headers, fwd.Header, fps.header)
+	}
+	return api.Continue
+}
+
+func forward(headers api.RequestHeaderMap, name string, value string) {
+	if name == "" {
+		return
+	}
+	if value == "" {
+		headers.Del(name)
+		return
+	}
+	headers.Set(name, value)
+}
@@ -0,0 +1,182 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fingerprint
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	"mosn.io/htnn/types/plugins/fingerprint"
+)
+
+const (
+	ja3 = "e7d705a3286e19ea42f587b344ee6865"
+	ja4 = "t13d1516h2_8daaf6152771_02713d6af862"
+	// the fingerprint of the browser request below
+	browser = "ge11cn03enus_98d9c6caaddf"
+)
+
+type streamInfo struct {
+	*envoy.StreamInfo
+}
+
+func (i *streamInfo) Protocol() (string, bool) {
+	return "HTTP/1.1", true
+}
+
+func browserRequest() http.Header {
+	return http.Header{
+		":method":         {"GET"},
+		":path":           {"/"},
+		"User-Agent":      {"Mozilla/5.0"},
+		"Accept":          {"text/html", "application/xhtml+xml"},
+		"Accept-Language": {"en-US,en;q=0.9"},
+		"Cookie":          {"a=b"},
+		"X-Forwarded-For": {"1.1.1.1"},
+		"X-Request-Id":    {"abc"},
+		"X-Ja3":           {ja3},
+		"X-Ja4":           {ja4},
+	}
+}
+
+func send(conf *config, header http.Header) (api.ResultAction, api.FilterCallbackHandler, api.RequestHeaderMap) {
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetStreamInfo(&streamInfo{StreamInfo: &envoy.StreamInfo{}})
+	f := factory(conf, cb)
+	hdr := envoy.NewRequestHeaderMap(header)
+	return f.DecodeHeaders(hdr, true), cb, hdr
+}
+
+func TestHeaderFingerprint(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"ja3Header":"x-ja3","ja4Header":"x-ja4"}`), conf))
+	require.NoError(t, conf.Init(nil))
+	res, cb, _ := send(conf, browserRequest())
+	assert.Equal(t, api.Continue, res)
+	assert.Equal(t, browser, cb.PluginState().Get(fingerprint.Name, "header"))
+	assert.Equal(t, ja3, cb.PluginState().Get(fingerprint.Name, "ja3"))
+	assert.Equal(t, ja4, cb.PluginState().Get(fingerprint.Name, "ja4"))
+
+	// the value of the headers doesn't matter
+	hdr := browserRequest()
+	hdr.Set("User-Agent", "curl/8.0")
+	hdr.Set("Referer", "http://example.com")
+	hdr.Set("X-Forwarded-Proto", "https")
+	_, cb, _ = send(conf, hdr)
+	assert.Equal(t, "ge11cr03enus_98d9c6caaddf", cb.PluginState().Get(fingerprint.Name, "header"))
+
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"ignoredHeaders":["Accept-Language"]}`), conf))
+	require.NoError(t, conf.Init(nil))
+	_, cb, _ = send(conf, browserRequest())
+	assert.Equal(t, "ge11cn04enus_", cb.PluginState().Get(fingerprint.Name, "header").(string)[:13])
+	assert.Nil(t, cb.PluginState().Get(fingerprint.Name, "ja3"))
+
+	_, cb, _ = send(conf, http.Header{":method": {"DELETE"}})
+	assert.Equal(t, "de11nn000000_e3b0c44298fc", cb.PluginState().Get(fingerprint.Name, "header"))
+}
+
+func TestLanguageCode(t *testing.T) {
+	assert.Equal(t, "zhcn", languageCode("zh-CN,zh;q=0.9"))
+	assert.Equal(t, "en00", languageCode("en;q=0.8"))
+	assert.Equal(t, "0000", languageCode("*"))
+}
+
+func TestAllowDeny(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		header func(h http.Header)
+		denied bool
+	}{
+		{
+			name:   "deny ja3",
+			config: `{"ja3Header":"x-ja3","deny":{"ja3":["E7D705A3286E19EA42F587B344EE6865"]}}`,
+			denied: true,
+		},
+		{
+			name:   "deny header fingerprint",
+			config: `{"ja3Header":"x-ja3","ja4Header":"x-ja4","deny":{"header":["` + browser + `"]}}`,
+			denied: true,
+		},
+		{
+			name:   "not denied",
+			config: `{"ja4Header":"x-ja4","deny":{"ja4":["t13d1516h2_8daaf6152771_b186095e22b6"]}}`,
+		},
+		{
+			name:   "allow ja4",
+			config: `{"ja4Header":"x-ja4","allow":{"ja4":["` + ja4 + `"]}}`,
+		},
+		{
+			name:   "not allowed",
+			config: `{"ja4Header":"x-ja4","allow":{"ja4":["t13d1516h2_8daaf6152771_b186095e22b6"]}}`,
+			denied: true,
+		},
+		{
+			name:   "missing fingerprint is not allowed",
+			config: `{"ja3Header":"x-ja3","allow":{"ja3":["` + ja3 + `"]}}`,
+			header: func(h http.Header) { h.Del("X-Ja3") },
+			denied: true,
+		},
+		{
+			name:   "deny first",
+			config: `{"ja3Header":"x-ja3","ja4Header":"x-ja4","allow":{"ja3":["` + ja3 + `"]},"deny":{"header":["` + browser + `"]}}`,
+			denied: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdr := browserRequest()
+			if tt.header != nil {
+				tt.header(hdr)
+			}
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.config), conf))
+			require.NoError(t, conf.Init(nil))
+			res, _, _ := send(conf, hdr)
+			if tt.denied {
+				assert.Equal(t, &api.LocalResponse{Code: 403}, res)
+			} else {
+				assert.Equal(t, api.Continue, res)
+			}
+		})
+	}
+}
+
+func TestForwardHeaders(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"ja3Header":"x-ja3","ja4Header":"x-ja4","forwardHeaders":{"ja3":"x-fp-ja3","ja4":"x-fp-ja4","header":"x-fp-header"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	hdr := browserRequest()
+	hdr.Del("X-Ja4")
+	// forged by the client
+	hdr.Set("X-Fp-Ja4", ja4)
+	hdr.Set("X-Fp-Header", "forged")
+	_, _, headers := send(conf, hdr)
+
+	v, _ := headers.Get("x-fp-ja3")
+	assert.Equal(t, ja3, v)
+	_, ok := headers.Get("x-fp-ja4")
+	assert.False(t, ok)
+	v, _ = headers.Get("x-fp-header")
+	assert.Equal(t, browser, v)
+}
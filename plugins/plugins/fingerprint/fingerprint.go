@@ -0,0 +1,110 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fingerprint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+type fingerprints struct {
+	ja3    string
+	ja4    string
+	header string
+}
+
+// httpVersion returns the HTTP version in two digits, like "11" for HTTP/1.1
+func httpVersion(protocol string) string {
+	switch protocol {
+	case "HTTP/1.0":
+		return "10"
+	case "HTTP/1.1":
+		return "11"
+	case "HTTP/2":
+		return "20"
+	case "HTTP/3":
+		return "30"
+	}
+	return "00"
+}
+
+// languageCode returns the first four alphanumeric characters of the primary language in the
+// Accept-Language header, padded with "0".
+func languageCode(acceptLanguage string) string {
+	lang, _, _ := strings.Cut(acceptLanguage, ",")
+	lang, _, _ = strings.Cut(lang, ";")
+	var b strings.Builder
+	for _, c := range strings.ToLower(lang) {
+		if b.Len() == 4 {
+			break
+		}
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+		}
+	}
+	for b.Len() < 4 {
+		b.WriteByte('0')
+	}
+	return b.String()
+}
+
+// headerFingerprint is similar to JA4H. It's in the format of
+// `$method$version$cookie$referer$count$language_$hash`:
+//
+//   - method: the first two letters of the method in lowercase, like "ge" for GET.
+//   - version: the HTTP version in two digits, like "11" for HTTP/1.1.
+//   - cookie: "c" if the Cookie header is present, otherwise "n".
+//   - referer: "r" if the Referer header is present, otherwise "n".
+//   - count: the number of the headers in two digits, excluding the Cookie and Referer.
+//   - language: the first four characters of the primary language in the Accept-Language header.
+//   - hash: the first 12 hex characters of the SHA256 of the header names joined with ",".
+//
+// Unlike JA4H, the header names are sorted before hashing, as the order of the headers is not
+// available to the Go plugins.
+func (conf *config) headerFingerprint(headers api.RequestHeaderMap, protocol string) string {
+	method := strings.ToLower(headers.Method())
+	if len(method) > 2 {
+		method = method[:2]
+	}
+	cookie := "n"
+	referer := "n"
+	var names []string
+	headers.Range(func(key, value string) bool {
+		name := strings.ToLower(key)
+		switch {
+		case strings.HasPrefix(name, ":"):
+		case name == "cookie":
+			cookie = "c"
+		case name == "referer":
+			referer = "r"
+		case !conf.ignored(name):
+			names = append(names, name)
+		}
+		return true
+	})
+	slices.Sort(names)
+	// the header with multiple values is counted once
+	names = slices.Compact(names)
+
+	lang, _ := headers.Get("accept-language")
+	sum := sha256.Sum256([]byte(strings.Join(names, ",")))
+	return fmt.Sprintf("%s%s%s%s%02d%s_%s", method, httpVersion(protocol), cookie, referer,
+		min(len(names), 99), languageCode(lang), hex.EncodeToString(sum[:6]))
+}
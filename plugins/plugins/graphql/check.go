@@ -0,0 +1,136 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// stats is the statistics of a selection set, with the fragments expanded
+type stats struct {
+	depth         int
+	complexity    int
+	aliases       int
+	introspection bool
+}
+
+func saturatingAdd(a, b int) int {
+	if a > math.MaxInt32-b {
+		return math.MaxInt32
+	}
+	return a + b
+}
+
+func (s *stats) merge(o *stats) {
+	s.depth = max(s.depth, o.depth)
+	s.complexity = saturatingAdd(s.complexity, o.complexity)
+	s.aliases = saturatingAdd(s.aliases, o.aliases)
+	s.introspection = s.introspection || o.introspection
+}
+
+type analyzer struct {
+	doc *document
+	// fragments caches the stats of the fragments, so that the fragments used repeatedly
+	// are only analyzed once
+	fragments map[string]*stats
+	visiting  map[string]bool
+}
+
+func (a *analyzer) fragmentStats(name string) (*stats, error) {
+	if s, ok := a.fragments[name]; ok {
+		return s, nil
+	}
+	frag, ok := a.doc.fragments[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown fragment %s", name)
+	}
+	if a.visiting[name] {
+		return nil, fmt.Errorf("fragment %s spreads itself", name)
+	}
+
+	a.visiting[name] = true
+	s, err := a.selectionStats(frag.selections)
+	delete(a.visiting, name)
+	if err != nil {
+		return nil, err
+	}
+	a.fragments[name] = s
+	return s, nil
+}
+
+func (a *analyzer) selectionStats(sels []*selection) (*stats, error) {
+	res := &stats{}
+	for _, sel := range sels {
+		switch {
+		case sel.spread != "":
+			s, err := a.fragmentStats(sel.spread)
+			if err != nil {
+				return nil, err
+			}
+			res.merge(s)
+		case sel.name == "":
+			// inline fragment
+			s, err := a.selectionStats(sel.selections)
+			if err != nil {
+				return nil, err
+			}
+			res.merge(s)
+		default:
+			s, err := a.selectionStats(sel.selections)
+			if err != nil {
+				return nil, err
+			}
+			field := &stats{
+				depth:         s.depth + 1,
+				complexity:    saturatingAdd(s.complexity, 1),
+				aliases:       s.aliases,
+				introspection: s.introspection || sel.name == "__schema" || sel.name == "__type",
+			}
+			if sel.alias != "" {
+				field.aliases = saturatingAdd(field.aliases, 1)
+			}
+			res.merge(field)
+		}
+	}
+	return res, nil
+}
+
+// selectOperation returns the operation to execute, like the GraphQL server does
+func (doc *document) selectOperation(name string) (*operation, error) {
+	if name == "" {
+		if len(doc.operations) > 1 {
+			return nil, errors.New("operationName is required for the document with multiple operations")
+		}
+		return doc.operations[0], nil
+	}
+	for _, op := range doc.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %s", name)
+}
+
+// analyze returns the stats of the operation
+func (doc *document) analyze(op *operation) (*stats, error) {
+	a := &analyzer{
+		doc:       doc,
+		fragments: map[string]*stats{},
+		visiting:  map[string]bool{},
+	}
+	return a.selectionStats(op.selections)
+}
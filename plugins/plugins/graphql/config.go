@@ -0,0 +1,119 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/jellydator/ttlcache/v3"
+	"golang.org/x/time/rate"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+	"mosn.io/htnn/types/plugins/graphql"
+)
+
+const (
+	defaultMaxBodySize = 1 << 20
+)
+
+func init() {
+	plugins.RegisterPlugin(graphql.Name, &plugin{})
+}
+
+type plugin struct {
+	graphql.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type operationLimiter struct {
+	average uint32
+	buckets *ttlcache.Cache[string, *rate.Limiter]
+	script  expr.Script
+}
+
+type config struct {
+	graphql.CustomConfig
+
+	maxBodySize int
+	limiters    map[string]*operationLimiter
+}
+
+func newOperationLimiter(limit *graphql.Config_OperationLimit) *operationLimiter {
+	period := time.Second
+	if limit.Period != nil {
+		period = limit.Period.AsDuration()
+	}
+	burst := limit.Burst
+	if burst == 0 {
+		burst = 1
+	}
+
+	rps := float64(time.Duration(limit.Average)*time.Second) / float64(period)
+	limitRate := rate.Limit(rps)
+	ttl := 2 * time.Second
+	if rps < 1 {
+		ttl += time.Duration(1/rps) * time.Second // ensure the bucket is not expired too early
+	}
+	loader := ttlcache.LoaderFunc[string, *rate.Limiter](
+		func(c *ttlcache.Cache[string, *rate.Limiter], key string) *ttlcache.Item[string, *rate.Limiter] {
+			return c.Set(key, rate.NewLimiter(limitRate, int(burst)), ttlcache.DefaultTTL)
+		},
+	)
+	l := &operationLimiter{
+		average: limit.Average,
+		buckets: ttlcache.New(
+			ttlcache.WithTTL[string, *rate.Limiter](ttl),
+			ttlcache.WithLoader[string, *rate.Limiter](loader),
+		),
+	}
+	if limit.Key != "" {
+		l.script, _ = expr.CompileCel(limit.Key, cel.StringType)
+	}
+	return l
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+
+	if len(conf.OperationLimits) == 0 {
+		return nil
+	}
+	conf.limiters = make(map[string]*operationLimiter, len(conf.OperationLimits))
+	for _, limit := range conf.OperationLimits {
+		l := newOperationLimiter(limit)
+		go l.buckets.Start()
+		conf.limiters[limit.OperationName] = l
+	}
+	runtime.SetFinalizer(conf, func(conf *config) {
+		for _, l := range conf.limiters {
+			l.buckets.Stop()
+		}
+	})
+	return nil
+}
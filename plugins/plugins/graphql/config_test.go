@@ -0,0 +1,77 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+		},
+		{
+			name: "ok",
+			input: `{"maxDepth":10,"maxComplexity":100,"maxAliases":5,"disableIntrospection":true,
+				"operationLimits":[{"operationName":"Search","average":1,"period":"60s","key":"request.header('x-user')"}]}`,
+		},
+		{
+			name:  "operation name is required",
+			input: `{"operationLimits":[{"average":1}]}`,
+			err:   "invalid Config_OperationLimit.OperationName: value length must be at least 1 runes",
+		},
+		{
+			name:  "invalid average",
+			input: `{"operationLimits":[{"operationName":"Search"}]}`,
+			err:   "invalid Config_OperationLimit.Average: value must be greater than 0",
+		},
+		{
+			name: "duplicate operation",
+			input: `{"operationLimits":[{"operationName":"Search","average":1},
+				{"operationName":"Search","average":2}]}`,
+			err: "duplicate operation limit for Search",
+		},
+		{
+			name:  "bad expr",
+			input: `{"operationLimits":[{"operationName":"Search","average":1,"key":"request.header"}]}`,
+			err:   "unexpected failed resolution",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	contentType string
+}
+
+type graphqlRequest struct {
+	Query         string `json:"query"`
+	OperationName string `json:"operationName"`
+}
+
+// errorResponse returns the error in the format of GraphQL response
+func errorResponse(code int, msg string) *api.LocalResponse {
+	b, _ := json.Marshal(map[string]interface{}{
+		"errors": []interface{}{
+			map[string]string{"message": msg},
+		},
+	})
+	hdr := http.Header{}
+	hdr.Set("content-type", "application/json")
+	return &api.LocalResponse{Code: code, Msg: string(b), Header: hdr}
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	switch headers.Method() {
+	case http.MethodGet:
+		query := headers.URL().Query()
+		if query.Get("query") == "" {
+			// not a GraphQL request, like the request to the GraphQL IDE
+			return api.Continue
+		}
+		return f.check(headers, []*graphqlRequest{{
+			Query:         query.Get("query"),
+			OperationName: query.Get("operationName"),
+		}})
+	case http.MethodPost:
+		if endStream {
+			return api.Continue
+		}
+		ct, _ := headers.Get("content-type")
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || (mediaType != "application/json" && mediaType != "application/graphql") {
+			return errorResponse(http.StatusUnsupportedMediaType, "unsupported content type")
+		}
+		f.contentType = mediaType
+		return api.WaitAllData
+	}
+	return api.Continue
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	if data == nil || data.Len() == 0 {
+		return api.Continue
+	}
+	if data.Len() > f.config.maxBodySize {
+		return errorResponse(http.StatusRequestEntityTooLarge, "request body is too large")
+	}
+
+	body := data.Bytes()
+	if f.contentType == "application/graphql" {
+		return f.check(headers, []*graphqlRequest{{
+			Query:         string(body),
+			OperationName: headers.URL().Query().Get("operationName"),
+		}})
+	}
+
+	var reqs []*graphqlRequest
+	var err error
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(body, &reqs)
+	} else {
+		req := &graphqlRequest{}
+		err = json.Unmarshal(body, req)
+		reqs = append(reqs, req)
+	}
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, "bad JSON body")
+	}
+	return f.check(headers, reqs)
+}
+
+func (f *filter) checkOperation(req *graphqlRequest) (*operation, error) {
+	config := f.config
+	doc, err := parse(req.Query)
+	if err != nil {
+		return nil, err
+	}
+	op, err := doc.selectOperation(req.OperationName)
+	if err != nil {
+		return nil, err
+	}
+	s, err := doc.analyze(op)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.DisableIntrospection && s.introspection {
+		return nil, errors.New("introspection is disabled")
+	}
+	if config.MaxDepth > 0 && s.depth > int(config.MaxDepth) {
+		return nil, fmt.Errorf("query depth %d exceeds the limit %d", s.depth, config.MaxDepth)
+	}
+	if config.MaxComplexity > 0 && s.complexity > int(config.MaxComplexity) {
+		return nil, fmt.Errorf("query complexity %d exceeds the limit %d", s.complexity, config.MaxComplexity)
+	}
+	if config.MaxAliases > 0 && s.aliases > int(config.MaxAliases) {
+		return nil, fmt.Errorf("query aliases %d exceeds the limit %d", s.aliases, config.MaxAliases)
+	}
+	return op, nil
+}
+
+func (f *filter) check(headers api.RequestHeaderMap, reqs []*graphqlRequest) api.ResultAction {
+	config := f.config
+	if config.MaxBatchSize > 0 && len(reqs) > int(config.MaxBatchSize) {
+		return errorResponse(http.StatusBadRequest,
+			fmt.Sprintf("batch size %d exceeds the limit %d", len(reqs), config.MaxBatchSize))
+	}
+
+	ops := make([]*operation, 0, len(reqs))
+	for _, req := range reqs {
+		if req == nil || req.Query == "" {
+			// let the GraphQL server handle the persisted query
+			continue
+		}
+		op, err := f.checkOperation(req)
+		if err != nil {
+			api.LogInfof("graphql: reject query: %v", err)
+			return errorResponse(http.StatusBadRequest, err.Error())
+		}
+		ops = append(ops, op)
+	}
+
+	for _, op := range ops {
+		l, ok := config.limiters[op.name]
+		if !ok {
+			continue
+		}
+		if res := f.limit(headers, l); res != nil {
+			return res
+		}
+	}
+	return api.Continue
+}
+
+func (f *filter) limit(headers api.RequestHeaderMap, l *operationLimiter) api.ResultAction {
+	var key string
+	if l.script != nil {
+		res, err := l.script.EvalWithRequest(f.callbacks, headers)
+		if err != nil {
+			api.LogErrorf("failed to eval script with request: %v", err)
+			return &api.LocalResponse{Code: 503}
+		}
+		key = res.(string)
+	}
+	if key == "" {
+		key = f.callbacks.StreamInfo().DownstreamRemoteParsedAddress().IP
+	}
+
+	res := l.buckets.Get(key).Value().Reserve()
+	delay := res.Delay()
+	if delay == 0 {
+		return nil
+	}
+	res.Cancel()
+
+	rsp := errorResponse(http.StatusTooManyRequests, "too many requests")
+	reset := strconv.Itoa(int(math.Ceil(delay.Seconds())))
+	rsp.Header.Set("RateLimit-Limit", strconv.FormatUint(uint64(l.average), 10))
+	rsp.Header.Set("RateLimit-Remaining", "0")
+	rsp.Header.Set("RateLimit-Reset", reset)
+	rsp.Header.Set("Retry-After", reset)
+	return rsp
+}
@@ -0,0 +1,209 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestGraphQL(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"maxDepth": 3,
+		"maxComplexity": 6,
+		"maxAliases": 1,
+		"disableIntrospection": true,
+		"maxBatchSize": 2,
+		"maxBodySize": 1024
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	tests := []struct {
+		name  string
+		get   string
+		ct    string
+		body  string
+		res   api.ResultAction
+		check func(t *testing.T, res *api.LocalResponse)
+	}{
+		{
+			name: "GET",
+			get:  `{ user { name } }`,
+		},
+		{
+			name: "GET without query",
+			get:  "",
+		},
+		{
+			name: "POST JSON",
+			ct:   "application/json; charset=utf-8",
+			body: `{"query":"query A { a } query B { b { c { d } } }","operationName":"A","variables":{"x":1}}`,
+		},
+		{
+			name: "POST GraphQL",
+			ct:   "application/graphql",
+			body: `{ a { b } }`,
+		},
+		{
+			name: "persisted query",
+			ct:   "application/json",
+			body: `{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"abc"}}}`,
+		},
+		{
+			name: "batch",
+			ct:   "application/json",
+			body: ` [{"query":"{ a }"},{"query":"{ b }"}]`,
+		},
+		{
+			name: "batch too large",
+			ct:   "application/json",
+			body: `[{"query":"{ a }"},{"query":"{ b }"},{"query":"{ c }"}]`,
+			res:  errorResponse(400, "batch size 3 exceeds the limit 2"),
+		},
+		{
+			name: "unsupported content type",
+			ct:   "text/plain",
+			body: `{ a }`,
+			res:  errorResponse(415, "unsupported content type"),
+		},
+		{
+			name: "body too large",
+			ct:   "application/graphql",
+			body: "{ a }" + string(make([]byte, 1024)),
+			res:  errorResponse(413, "request body is too large"),
+		},
+		{
+			name: "bad JSON",
+			ct:   "application/json",
+			body: `{"query":`,
+			res:  errorResponse(400, "bad JSON body"),
+		},
+		{
+			name: "syntax error",
+			get:  `{ a `,
+			res:  errorResponse(400, "syntax error at 4: unexpected end of document"),
+		},
+		{
+			name: "too deep",
+			ct:   "application/json",
+			body: `{"query":"{ a { b { c { d } } } }"}`,
+			res:  errorResponse(400, "query depth 4 exceeds the limit 3"),
+		},
+		{
+			name: "too complex",
+			get:  `{ a b c ...F } fragment F on Q { d e f g }`,
+			res:  errorResponse(400, "query complexity 7 exceeds the limit 6"),
+		},
+		{
+			name: "too many aliases",
+			get:  `{ a: x b: x }`,
+			res:  errorResponse(400, "query aliases 2 exceeds the limit 1"),
+		},
+		{
+			name: "introspection",
+			ct:   "application/json",
+			body: `[{"query":"{ a }"},{"query":"{ __schema { types { name } } }"}]`,
+			res:  errorResponse(400, "introspection is disabled"),
+		},
+		{
+			name: "operation name is required",
+			ct:   "application/json",
+			body: `{"query":"query A { a } query B { b }"}`,
+			res:  errorResponse(400, "operationName is required for the document with multiple operations"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb)
+			var res api.ResultAction
+			if tt.body == "" {
+				path := "/graphql"
+				if tt.get != "" {
+					path += "?query=" + url.QueryEscape(tt.get)
+				}
+				hdr := envoy.NewRequestHeaderMap(http.Header{
+					":method": {"GET"},
+					":path":   {path},
+				})
+				res = f.DecodeHeaders(hdr, true)
+			} else {
+				hdr := envoy.NewRequestHeaderMap(http.Header{
+					":method":      {"POST"},
+					":path":        {"/graphql"},
+					"Content-Type": {tt.ct},
+				})
+				res = f.DecodeHeaders(hdr, false)
+				if res == api.WaitAllData {
+					res = f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(tt.body)), nil)
+				}
+			}
+			if tt.res == nil {
+				assert.Equal(t, api.Continue, res)
+			} else {
+				assert.Equal(t, tt.res, res)
+			}
+		})
+	}
+}
+
+func TestOperationLimit(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"operationLimits": [
+			{"operationName": "Search", "average": 1, "period": "60s"},
+			{"operationName": "Login", "average": 1, "period": "60s", "key": "request.header('x-user')"}
+		]
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	send := func(user string, query string) api.ResultAction {
+		f := factory(conf, envoy.NewFilterCallbackHandler())
+		hdr := envoy.NewRequestHeaderMap(http.Header{
+			":method": {"GET"},
+			":path":   {"/graphql?query=" + url.QueryEscape(query)},
+			"X-User":  {user},
+		})
+		return f.DecodeHeaders(hdr, true)
+	}
+
+	assert.Equal(t, api.Continue, send("", `query Search { a }`))
+	resp := send("", `query Search { a }`).(*api.LocalResponse)
+	assert.Equal(t, 429, resp.Code)
+	assert.Equal(t, `{"errors":[{"message":"too many requests"}]}`, resp.Msg)
+	assert.Equal(t, "1", resp.Header.Get("RateLimit-Limit"))
+	assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", resp.Header.Get("RateLimit-Reset"))
+	assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+
+	// other operations are not limited
+	assert.Equal(t, api.Continue, send("", `query List { a }`))
+	assert.Equal(t, api.Continue, send("", `{ a }`))
+
+	// limit by the key
+	assert.Equal(t, api.Continue, send("alice", `query Login { a }`))
+	assert.Equal(t, api.Continue, send("bob", `query Login { a }`))
+	assert.Equal(t, 429, send("alice", `query Login { a }`).(*api.LocalResponse).Code)
+}
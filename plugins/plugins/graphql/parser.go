@@ -0,0 +1,606 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+// This file implements a parser of the GraphQL executable documents, which only keeps the
+// information required to check the limits. See https://spec.graphql.org/October2021/#sec-Document
+// for the grammar.
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// maxNesting limits the nesting of the selection sets, values and types during parsing,
+// so that the deeply nested document can't exhaust the stack.
+const maxNesting = 512
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenNumber
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+type lexer struct {
+	src string
+	pos int
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || ('0' <= c && c <= '9')
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' && l.src[l.pos] != '\r' {
+				l.pos++
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) errorf(pos int, format string, args ...interface{}) error {
+	return fmt.Errorf("syntax error at %d: %s", pos, fmt.Sprintf(format, args...))
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.IndexByte("!$&():=@[]{}|", c) >= 0:
+		l.pos++
+		return token{kind: tokenPunctuator, value: string(c), pos: start}, nil
+	case c == '.':
+		if !strings.HasPrefix(l.src[l.pos:], "...") {
+			return token{}, l.errorf(start, "unexpected %q", c)
+		}
+		l.pos += 3
+		return token{kind: tokenPunctuator, value: "...", pos: start}, nil
+	case isNameStart(c):
+		for l.pos < len(l.src) && isNameContinue(l.src[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], pos: start}, nil
+	case c == '-' || isDigit(c):
+		return l.readNumber()
+	case c == '"':
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			return l.readBlockString()
+		}
+		return l.readString()
+	}
+	return token{}, l.errorf(start, "unexpected %q", c)
+}
+
+func (l *lexer) readDigits() int {
+	start := l.pos
+	for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+		l.pos++
+	}
+	return l.pos - start
+}
+
+func (l *lexer) readNumber() (token, error) {
+	start := l.pos
+	if l.src[l.pos] == '-' {
+		l.pos++
+	}
+	if l.readDigits() == 0 {
+		return token{}, l.errorf(start, "bad number")
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		l.pos++
+		if l.readDigits() == 0 {
+			return token{}, l.errorf(start, "bad number")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		l.pos++
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.pos++
+		}
+		if l.readDigits() == 0 {
+			return token{}, l.errorf(start, "bad number")
+		}
+	}
+	if l.pos < len(l.src) && (isNameStart(l.src[l.pos]) || l.src[l.pos] == '.') {
+		return token{}, l.errorf(start, "bad number")
+	}
+	return token{kind: tokenNumber, value: l.src[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) readString() (token, error) {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case '"':
+			l.pos++
+			return token{kind: tokenString, value: l.src[start:l.pos], pos: start}, nil
+		case '\\':
+			l.pos += 2
+		case '\n', '\r':
+			return token{}, l.errorf(start, "unterminated string")
+		default:
+			l.pos++
+		}
+	}
+	return token{}, l.errorf(start, "unterminated string")
+}
+
+func (l *lexer) readBlockString() (token, error) {
+	start := l.pos
+	l.pos += 3
+	for l.pos < len(l.src) {
+		switch {
+		case strings.HasPrefix(l.src[l.pos:], `\"""`):
+			l.pos += 4
+		case strings.HasPrefix(l.src[l.pos:], `"""`):
+			l.pos += 3
+			return token{kind: tokenString, value: l.src[start:l.pos], pos: start}, nil
+		default:
+			l.pos++
+		}
+	}
+	return token{}, l.errorf(start, "unterminated string")
+}
+
+type selection struct {
+	// alias and name are set for the field
+	alias string
+	name  string
+	// spread is set for the fragment spread
+	spread string
+	// selections of the field or the inline fragment
+	selections []*selection
+}
+
+type operation struct {
+	// typ is one of query, mutation and subscription
+	typ        string
+	name       string
+	selections []*selection
+}
+
+type fragment struct {
+	name       string
+	selections []*selection
+}
+
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type parser struct {
+	lexer   lexer
+	tok     token
+	nesting int
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return p.lexer.errorf(p.tok.pos, "unexpected end of document")
+	}
+	return p.lexer.errorf(p.tok.pos, "unexpected %q", p.tok.value)
+}
+
+func (p *parser) peek(punct string) bool {
+	return p.tok.kind == tokenPunctuator && p.tok.value == punct
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.peek(punct) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+func (p *parser) skip(punct string) (bool, error) {
+	if !p.peek(punct) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) enter() error {
+	p.nesting++
+	if p.nesting > maxNesting {
+		return errors.New("document is nested too deeply")
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.nesting--
+}
+
+func parse(src string) (*document, error) {
+	p := &parser{lexer: lexer{src: src}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: map[string]*fragment{}}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			sels, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, &operation{typ: "query", selections: sels})
+		case p.tok.kind == tokenName && (p.tok.value == "query" || p.tok.value == "mutation" || p.tok.value == "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.tok.kind == tokenName && p.tok.value == "fragment":
+			frag, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[frag.name]; ok {
+				return nil, fmt.Errorf("duplicate fragment %s", frag.name)
+			}
+			doc.fragments[frag.name] = frag
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, errors.New("no operation in the document")
+	}
+	return doc, nil
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{typ: p.tok.value}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName {
+		op.name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek("(") {
+		if err := p.variableDefinitions(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	op.selections = sels
+	return op, nil
+}
+
+func (p *parser) fragment() (*fragment, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName && p.tok.value == "on" {
+		return nil, p.unexpected()
+	}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.typeCondition(); err != nil {
+		return nil, err
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+	sels, err := p.selectionSet()
+	if err != nil {
+		return nil, err
+	}
+	return &fragment{name: name, selections: sels}, nil
+}
+
+func (p *parser) typeCondition() error {
+	if p.tok.kind != tokenName || p.tok.value != "on" {
+		return p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return err
+	}
+	_, err := p.name()
+	return err
+}
+
+func (p *parser) variableDefinitions() error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for {
+		if ok, err := p.skip(")"); ok || err != nil {
+			return err
+		}
+		if err := p.variable(); err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		if err := p.typeRef(); err != nil {
+			return err
+		}
+		if ok, err := p.skip("="); err != nil {
+			return err
+		} else if ok {
+			if err := p.value(true); err != nil {
+				return err
+			}
+		}
+		if err := p.directives(); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) variable() error {
+	if err := p.expect("$"); err != nil {
+		return err
+	}
+	_, err := p.name()
+	return err
+}
+
+func (p *parser) typeRef() error {
+	if err := p.enter(); err != nil {
+		return err
+	}
+	defer p.leave()
+
+	if ok, err := p.skip("["); err != nil {
+		return err
+	} else if ok {
+		if err := p.typeRef(); err != nil {
+			return err
+		}
+		if err := p.expect("]"); err != nil {
+			return err
+		}
+	} else if _, err := p.name(); err != nil {
+		return err
+	}
+	_, err := p.skip("!")
+	return err
+}
+
+func (p *parser) value(isConst bool) error {
+	if err := p.enter(); err != nil {
+		return err
+	}
+	defer p.leave()
+
+	switch {
+	case p.peek("$") && !isConst:
+		return p.variable()
+	case p.tok.kind == tokenNumber, p.tok.kind == tokenString, p.tok.kind == tokenName:
+		return p.advance()
+	case p.peek("["):
+		if err := p.advance(); err != nil {
+			return err
+		}
+		for {
+			if ok, err := p.skip("]"); ok || err != nil {
+				return err
+			}
+			if err := p.value(isConst); err != nil {
+				return err
+			}
+		}
+	case p.peek("{"):
+		if err := p.advance(); err != nil {
+			return err
+		}
+		for {
+			if ok, err := p.skip("}"); ok || err != nil {
+				return err
+			}
+			if _, err := p.name(); err != nil {
+				return err
+			}
+			if err := p.expect(":"); err != nil {
+				return err
+			}
+			if err := p.value(isConst); err != nil {
+				return err
+			}
+		}
+	}
+	return p.unexpected()
+}
+
+func (p *parser) arguments() error {
+	if ok, err := p.skip("("); !ok || err != nil {
+		return err
+	}
+	for {
+		if ok, err := p.skip(")"); ok || err != nil {
+			return err
+		}
+		if _, err := p.name(); err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		if err := p.value(false); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *parser) directives() error {
+	for p.peek("@") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if _, err := p.name(); err != nil {
+			return err
+		}
+		if err := p.arguments(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *parser) selectionSet() ([]*selection, error) {
+	if err := p.enter(); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var sels []*selection
+	for {
+		if ok, err := p.skip("}"); err != nil {
+			return nil, err
+		} else if ok {
+			break
+		}
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	if len(sels) == 0 {
+		return nil, p.lexer.errorf(p.tok.pos, "empty selection set")
+	}
+	return sels, nil
+}
+
+func (p *parser) selection() (*selection, error) {
+	if ok, err := p.skip("..."); err != nil {
+		return nil, err
+	} else if ok {
+		if p.tok.kind == tokenName && p.tok.value != "on" {
+			sel := &selection{spread: p.tok.value}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			return sel, p.directives()
+		}
+
+		// inline fragment
+		if p.tok.kind == tokenName {
+			if err := p.typeCondition(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.directives(); err != nil {
+			return nil, err
+		}
+		sels, err := p.selectionSet()
+		if err != nil {
+			return nil, err
+		}
+		return &selection{selections: sels}, nil
+	}
+
+	sel := &selection{}
+	name, err := p.name()
+	if err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		sel.alias = name
+		name, err = p.name()
+		if err != nil {
+			return nil, err
+		}
+	}
+	sel.name = name
+	if err := p.arguments(); err != nil {
+		return nil, err
+	}
+	if err := p.directives(); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		sel.selections, err = p.selectionSet()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sel, nil
+}
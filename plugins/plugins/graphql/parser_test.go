@@ -0,0 +1,215 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+		stats stats
+	}{
+		{
+			name:  "shorthand",
+			input: `{ a b { c } }`,
+			stats: stats{depth: 2, complexity: 3},
+		},
+		{
+			name: "full",
+			input: `
+				# comment
+				query GetUser($id: ID! = "1", $tags: [String!]! = ["a"], $filter: Filter = {a: {b: [1, 2.5e3, -3]}}) @cached(ttl: 60) {
+					user(id: $id, filter: {name: """block "quoted" \""" string""", enabled: true, role: ADMIN, none: null}) @include(if: true) {
+						name,
+						first: friends(first: 10) {
+							...UserFields
+						}
+						second: friends(after: "\"x\"") {
+							... on User @skip(if: false) { id }
+							... { __typename }
+						}
+					}
+				}
+				fragment UserFields on User { id name }
+			`,
+			stats: stats{depth: 3, complexity: 8, aliases: 2},
+		},
+		{
+			name:  "introspection",
+			input: `query { __schema { types { name } } }`,
+			stats: stats{depth: 3, complexity: 3, introspection: true},
+		},
+		{
+			name:  "introspection in fragment",
+			input: `query { ...F } fragment F on Query { __type(name: "User") { name } }`,
+			stats: stats{depth: 2, complexity: 2, introspection: true},
+		},
+		{
+			name:  "unicode and BOM",
+			input: "\uFEFF{ a(s: \"你好\\u4F60\") }",
+			stats: stats{depth: 1, complexity: 1},
+		},
+		{
+			name:  "fragments used repeatedly",
+			input: `{ ...A ...A } fragment A on Q { a: x ...B } fragment B on Q { b { c } }`,
+			stats: stats{depth: 2, complexity: 6, aliases: 2},
+		},
+		{
+			name:  "empty",
+			input: ` `,
+			err:   "no operation in the document",
+		},
+		{
+			name:  "only fragment",
+			input: `fragment A on Q { a }`,
+			err:   "no operation in the document",
+		},
+		{
+			name:  "type definition",
+			input: `type Query { a: String }`,
+			err:   `syntax error at 0: unexpected "type"`,
+		},
+		{
+			name:  "unclosed",
+			input: `{ a { b }`,
+			err:   "unexpected end of document",
+		},
+		{
+			name:  "empty selection set",
+			input: `{ }`,
+			err:   "empty selection set",
+		},
+		{
+			name:  "variable in const value",
+			input: `query ($a: Int = $b) { a }`,
+			err:   `unexpected "$"`,
+		},
+		{
+			name:  "bad number",
+			input: `{ a(n: 1.) }`,
+			err:   "bad number",
+		},
+		{
+			name:  "bad name after number",
+			input: `{ a(n: 1x) }`,
+			err:   "bad number",
+		},
+		{
+			name:  "unterminated string",
+			input: "{ a(s: \"abc\n\") }",
+			err:   "unterminated string",
+		},
+		{
+			name:  "unterminated block string",
+			input: `{ a(s: """abc) }`,
+			err:   "unterminated string",
+		},
+		{
+			name:  "bad dots",
+			input: `{ ..A }`,
+			err:   `unexpected '.'`,
+		},
+		{
+			name:  "fragment named on",
+			input: `{ a } fragment on on Q { a }`,
+			err:   `unexpected "on"`,
+		},
+		{
+			name:  "duplicate fragment",
+			input: `{ ...A } fragment A on Q { a } fragment A on Q { b }`,
+			err:   "duplicate fragment A",
+		},
+		{
+			name:  "unknown fragment",
+			input: `{ ...A }`,
+			err:   "unknown fragment A",
+		},
+		{
+			name:  "fragment cycle",
+			input: `{ ...A } fragment A on Q { a { ...B } } fragment B on Q { b { ...A } }`,
+			err:   "fragment A spreads itself",
+		},
+		{
+			name:  "too deep",
+			input: strings.Repeat("{ a ", 1000) + strings.Repeat("}", 1000),
+			err:   "document is nested too deeply",
+		},
+		{
+			name:  "too deep value",
+			input: "{ a(v: " + strings.Repeat("[", 1000) + strings.Repeat("]", 1000) + ") }",
+			err:   "document is nested too deeply",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parse(tt.input)
+			var s *stats
+			if err == nil {
+				var op *operation
+				op, err = doc.selectOperation("")
+				require.NoError(t, err)
+				s, err = doc.analyze(op)
+			}
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.stats, *s)
+		})
+	}
+}
+
+func TestFragmentExpansion(t *testing.T) {
+	// each fragment doubles the fields, which is analyzed in linear time
+	var sb strings.Builder
+	sb.WriteString("{ ...F0 }\n")
+	for i := 0; i < 64; i++ {
+		fmt.Fprintf(&sb, "fragment F%d on Q { ...F%d ...F%d }\n", i, i+1, i+1)
+	}
+	sb.WriteString("fragment F64 on Q { a }")
+
+	doc, err := parse(sb.String())
+	require.NoError(t, err)
+	s, err := doc.analyze(doc.operations[0])
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.depth)
+	assert.Equal(t, 1<<31-1, s.complexity)
+}
+
+func TestSelectOperation(t *testing.T) {
+	doc, err := parse(`query A { a } mutation B { b } subscription C { c }`)
+	require.NoError(t, err)
+
+	op, err := doc.selectOperation("B")
+	require.NoError(t, err)
+	assert.Equal(t, "mutation", op.typ)
+	assert.Equal(t, "B", op.name)
+
+	_, err = doc.selectOperation("")
+	assert.ErrorContains(t, err, "operationName is required")
+	_, err = doc.selectOperation("D")
+	assert.ErrorContains(t, err, "unknown operation D")
+}
@@ -0,0 +1,160 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/idempotency"
+)
+
+const (
+	defaultHeader      = "idempotency-key"
+	defaultTTL         = 24 * time.Hour
+	defaultLockTimeout = 30 * time.Second
+	defaultPrefix      = "htnn-idempotency"
+	defaultMaxBodySize = 1 << 20
+
+	maxKeyLength = 255
+)
+
+var defaultMethods = []string{http.MethodPost, http.MethodPatch}
+
+func init() {
+	plugins.RegisterPlugin(idempotency.Name, &plugin{})
+}
+
+type plugin struct {
+	idempotency.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	idempotency.CustomConfig
+
+	store       store
+	header      string
+	methods     map[string]bool
+	ttl         time.Duration
+	lockTimeout time.Duration
+	maxBodySize int
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	r := conf.Redis
+	var tlsConfig *tls.Config
+	if r.Tls {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: r.TlsSkipVerify,
+		}
+	}
+	prefix := conf.Prefix
+	if prefix == "" {
+		prefix = defaultPrefix
+	}
+	conf.store = newRedisStore(redis.NewClient(&redis.Options{
+		Addr:      r.Address,
+		Username:  r.Username,
+		Password:  r.Password,
+		TLSConfig: tlsConfig,
+	}), prefix)
+
+	conf.header = defaultHeader
+	if conf.Header != "" {
+		conf.header = strings.ToLower(conf.Header)
+	}
+	methods := conf.Methods
+	if len(methods) == 0 {
+		methods = defaultMethods
+	}
+	conf.methods = make(map[string]bool, len(methods))
+	for _, m := range methods {
+		conf.methods[m] = true
+	}
+
+	conf.ttl = defaultTTL
+	if conf.Ttl != nil {
+		conf.ttl = conf.Ttl.AsDuration()
+	}
+	conf.lockTimeout = defaultLockTimeout
+	if conf.LockTimeout != nil {
+		conf.lockTimeout = conf.LockTimeout.AsDuration()
+	}
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+	return nil
+}
+
+// storeKey scopes the idempotency key to the consumer and the request target, so that the same key
+// sent by different clients or to different APIs won't share the response.
+func (conf *config) storeKey(headers api.RequestHeaderMap, consumer api.Consumer, key string) string {
+	var sb strings.Builder
+	if consumer != nil {
+		sb.WriteString(consumer.Name())
+	}
+	sb.WriteString("\n")
+	sb.WriteString(headers.Method())
+	sb.WriteString("\n")
+	sb.WriteString(headers.Host())
+	sb.WriteString(headers.Path())
+	sb.WriteString("\n")
+	sb.WriteString(key)
+
+	sum := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// Hop-by-hop headers and the headers which are generated by the plugin are not stored
+var unstoredHeaders = map[string]bool{
+	"connection":          true,
+	"keep-alive":          true,
+	"proxy-authenticate":  true,
+	"proxy-authorization": true,
+	"te":                  true,
+	"trailer":             true,
+	"transfer-encoding":   true,
+	"upgrade":             true,
+	"content-length":      true,
+	replayedHeader:        true,
+}
+
+func storedHeader(headers api.ResponseHeaderMap) http.Header {
+	hdr := http.Header{}
+	headers.Range(func(k, v string) bool {
+		if k[0] != ':' && !unstoredHeaders[strings.ToLower(k)] {
+			hdr.Add(k, v)
+		}
+		return true
+	})
+	return hdr
+}
@@ -0,0 +1,78 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+			err:   "invalid Config.Redis: value is required",
+		},
+		{
+			name:  "bad address",
+			input: `{"redis":{"address":"127.0.0.1"}}`,
+			err:   "bad address 127.0.0.1",
+		},
+		{
+			name:  "username without password",
+			input: `{"redis":{"address":"127.0.0.1:6379","username":"user"}}`,
+			err:   "password is required when username is set",
+		},
+		{
+			name:  "bad method",
+			input: `{"redis":{"address":"127.0.0.1:6379"},"methods":["post"]}`,
+			err:   "invalid Config.Methods[0]: value does not match regex pattern",
+		},
+		{
+			name:  "bad ttl",
+			input: `{"redis":{"address":"127.0.0.1:6379"},"ttl":"0s"}`,
+			err:   "invalid Config.Ttl: value must be greater than 0s",
+		},
+		{
+			name:  "ok",
+			input: `{"redis":{"address":"127.0.0.1:6379"},"methods":["POST"],"ttl":"3600s","lockTimeout":"10s"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const (
+	// the header added to the replayed response
+	replayedHeader = "idempotent-replayed"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	key string
+	// reserved is true if the key is reserved by this request and its response is not stored yet
+	reserved bool
+
+	status int
+	header http.Header
+}
+
+func (f *filter) onStoreError(err error) api.ResultAction {
+	api.LogErrorf("idempotency: failed to access the store: %v", err)
+	if f.config.FailureModeDeny {
+		return &api.LocalResponse{Code: http.StatusServiceUnavailable}
+	}
+	return api.Continue
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	if !config.methods[headers.Method()] {
+		return api.Continue
+	}
+
+	key, _ := headers.Get(config.header)
+	if key == "" {
+		if config.Required {
+			return &api.LocalResponse{Code: http.StatusBadRequest, Msg: "missing idempotency key"}
+		}
+		return api.Continue
+	}
+	if len(key) > maxKeyLength {
+		return &api.LocalResponse{Code: http.StatusBadRequest, Msg: "idempotency key is too long"}
+	}
+
+	f.key = config.storeKey(headers, f.callbacks.GetConsumer(), key)
+	ctx := context.Background()
+	reserved, err := config.store.reserve(ctx, f.key, config.lockTimeout)
+	if err != nil {
+		return f.onStoreError(err)
+	}
+	if reserved {
+		f.reserved = true
+		return api.Continue
+	}
+
+	e, err := config.store.get(ctx, f.key)
+	if err != nil {
+		return f.onStoreError(err)
+	}
+	// the entry may be expired after the reservation fails, let the client retry
+	if e == nil || e.Pending {
+		return &api.LocalResponse{Code: http.StatusConflict, Msg: "a request with the same idempotency key is in progress"}
+	}
+
+	hdr := e.Header.Clone()
+	if hdr == nil {
+		hdr = http.Header{}
+	}
+	hdr.Set(replayedHeader, "true")
+	return &api.LocalResponse{Code: e.Status, Msg: string(e.Body), Header: hdr}
+}
+
+// release removes the reservation, so that the client can retry the request
+func (f *filter) release() {
+	if !f.reserved {
+		return
+	}
+	f.reserved = false
+	// If the lock is timed out, the key may be reserved by another request. It's fine to remove it,
+	// because both requests are sent to the upstream in this case.
+	err := f.config.store.del(context.Background(), f.key)
+	if err != nil {
+		api.LogErrorf("idempotency: failed to release the key: %v", err)
+	}
+}
+
+func (f *filter) save(body []byte) {
+	f.reserved = false
+	e := &entry{
+		Status: f.status,
+		Header: f.header,
+		Body:   body,
+	}
+	err := f.config.store.set(context.Background(), f.key, e, f.config.ttl)
+	if err != nil {
+		api.LogErrorf("idempotency: failed to store the response: %v", err)
+	}
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if !f.reserved {
+		return api.Continue
+	}
+
+	status, _ := headers.Get(":status")
+	f.status, _ = strconv.Atoi(status)
+	// The server errors are not stored, so that the client can retry the request
+	if f.status >= 500 {
+		f.release()
+		return api.Continue
+	}
+	if cl, ok := headers.Get("content-length"); ok {
+		n, err := strconv.Atoi(cl)
+		if err == nil && n > f.config.maxBodySize {
+			f.release()
+			return api.Continue
+		}
+	}
+
+	f.header = storedHeader(headers)
+	if endStream {
+		f.save(nil)
+		return api.Continue
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	if data == nil {
+		f.save(nil)
+		return api.Continue
+	}
+	if data.Len() > f.config.maxBodySize {
+		f.release()
+		return api.Continue
+	}
+	f.save(append([]byte{}, data.Bytes()...))
+	return api.Continue
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+	// the request is finished without the response, like the upstream is reset
+	f.release()
+}
@@ -0,0 +1,194 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type memoryStore struct {
+	lock    sync.Mutex
+	entries map[string]*entry
+	err     error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{entries: map[string]*entry{}}
+}
+
+func (s *memoryStore) reserve(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return false, s.err
+	}
+	if _, ok := s.entries[key]; ok {
+		return false, nil
+	}
+	s.entries[key] = &entry{Pending: true}
+	return true, nil
+}
+
+func (s *memoryStore) get(ctx context.Context, key string) (*entry, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.entries[key], s.err
+}
+
+func (s *memoryStore) set(ctx context.Context, key string, e *entry, ttl time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.entries[key] = e
+	return s.err
+}
+
+func (s *memoryStore) del(ctx context.Context, key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.entries, key)
+	return s.err
+}
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func newConfig(t *testing.T, input string) (*config, *memoryStore) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+	require.NoError(t, conf.Init(nil))
+	s := newMemoryStore()
+	conf.store = s
+	return conf, s
+}
+
+func requestHeaders(method string, key string) api.RequestHeaderMap {
+	h := http.Header{}
+	h.Set(":method", method)
+	h.Set(":authority", "test.local")
+	h.Set(":path", "/payments")
+	if key != "" {
+		h.Set("Idempotency-Key", key)
+	}
+	return envoy.NewRequestHeaderMap(h)
+}
+
+func TestReplay(t *testing.T) {
+	conf, _ := newConfig(t, `{"redis":{"address":"127.0.0.1:6379"}}`)
+
+	// the first request is sent to the upstream
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	assert.Equal(t, api.Continue, f.DecodeHeaders(requestHeaders("POST", "k1"), true))
+
+	// the retry while the first request is in progress
+	f2 := factory(conf, envoy.NewFilterCallbackHandler())
+	res := f2.DecodeHeaders(requestHeaders("POST", "k1"), true)
+	lr, ok := res.(*api.LocalResponse)
+	require.True(t, ok)
+	assert.Equal(t, 409, lr.Code)
+
+	respHdr := envoy.NewResponseHeaderMap(http.Header{
+		":status":        {"201"},
+		"Content-Type":   {"application/json"},
+		"Content-Length": {"13"},
+	})
+	assert.Equal(t, api.WaitAllData, f.EncodeHeaders(respHdr, false))
+	assert.Equal(t, api.Continue, f.EncodeResponse(respHdr, envoy.NewBufferInstance([]byte(`{"id":"p_1"}`+"\n")), nil))
+	f.OnLog(nil, nil, respHdr, nil)
+
+	// the retry after the first request is finished
+	f3 := factory(conf, envoy.NewFilterCallbackHandler())
+	res = f3.DecodeHeaders(requestHeaders("POST", "k1"), true)
+	assert.Equal(t, &api.LocalResponse{
+		Code: 201,
+		Msg:  `{"id":"p_1"}` + "\n",
+		Header: http.Header{
+			"Content-Type":        {"application/json"},
+			"Idempotent-Replayed": {"true"},
+		},
+	}, res)
+
+	// the key is scoped to the consumer
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetConsumer(&testConsumer{name: "alice"})
+	f4 := factory(conf, cb)
+	assert.Equal(t, api.Continue, f4.DecodeHeaders(requestHeaders("POST", "k1"), true))
+}
+
+func TestNotStored(t *testing.T) {
+	conf, s := newConfig(t, `{"redis":{"address":"127.0.0.1:6379"},"maxBodySize":4}`)
+
+	for _, status := range []string{"500", "503"} {
+		f := factory(conf, envoy.NewFilterCallbackHandler())
+		assert.Equal(t, api.Continue, f.DecodeHeaders(requestHeaders("POST", "k1"), true))
+		respHdr := envoy.NewResponseHeaderMap(http.Header{":status": {status}})
+		assert.Equal(t, api.Continue, f.EncodeHeaders(respHdr, false))
+		assert.Empty(t, s.entries)
+	}
+
+	// too large
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	assert.Equal(t, api.Continue, f.DecodeHeaders(requestHeaders("POST", "k1"), true))
+	respHdr := envoy.NewResponseHeaderMap(http.Header{":status": {"200"}})
+	assert.Equal(t, api.WaitAllData, f.EncodeHeaders(respHdr, false))
+	assert.Equal(t, api.Continue, f.EncodeResponse(respHdr, envoy.NewBufferInstance([]byte("12345")), nil))
+	assert.Empty(t, s.entries)
+
+	// no response
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	assert.Equal(t, api.Continue, f.DecodeHeaders(requestHeaders("POST", "k1"), true))
+	assert.Len(t, s.entries, 1)
+	f.OnLog(nil, nil, nil, nil)
+	assert.Empty(t, s.entries)
+}
+
+func TestBypass(t *testing.T) {
+	conf, s := newConfig(t, `{"redis":{"address":"127.0.0.1:6379"},"required":true}`)
+
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	assert.Equal(t, api.Continue, f.DecodeHeaders(requestHeaders("GET", ""), true))
+	res := f.DecodeHeaders(requestHeaders("POST", ""), true)
+	assert.Equal(t, &api.LocalResponse{Code: 400, Msg: "missing idempotency key"}, res)
+	res = f.DecodeHeaders(requestHeaders("PATCH", string(make([]byte, 256))), true)
+	assert.Equal(t, &api.LocalResponse{Code: 400, Msg: "idempotency key is too long"}, res)
+
+	s.err = errors.New("connection refused")
+	assert.Equal(t, api.Continue, f.DecodeHeaders(requestHeaders("POST", "k1"), true))
+
+	conf, s = newConfig(t, `{"redis":{"address":"127.0.0.1:6379"},"failureModeDeny":true}`)
+	s.err = errors.New("connection refused")
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	assert.Equal(t, &api.LocalResponse{Code: 503}, f.DecodeHeaders(requestHeaders("POST", "k1"), true))
+}
@@ -0,0 +1,89 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type entry struct {
+	// Pending is true if the first request with the key is still in progress
+	Pending bool        `json:"pending,omitempty"`
+	Status  int         `json:"status,omitempty"`
+	Header  http.Header `json:"header,omitempty"`
+	Body    []byte      `json:"body,omitempty"`
+}
+
+var pendingEntry = []byte(`{"pending":true}`)
+
+type store interface {
+	// reserve marks the key as pending for the given duration. It returns false if the key
+	// already exists.
+	reserve(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// get returns nil if the entry is not found
+	get(ctx context.Context, key string) (*entry, error)
+	set(ctx context.Context, key string, e *entry, ttl time.Duration) error
+	del(ctx context.Context, key string) error
+}
+
+type redisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+func newRedisStore(client *redis.Client, prefix string) *redisStore {
+	return &redisStore{
+		client: client,
+		prefix: prefix + "|",
+	}
+}
+
+func (s *redisStore) reserve(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.prefix+key, pendingEntry, ttl).Result()
+}
+
+func (s *redisStore) get(ctx context.Context, key string) (*entry, error) {
+	b, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	e := &entry{}
+	err = json.Unmarshal(b, e)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *redisStore) set(ctx context.Context, key string, e *entry, ttl time.Duration) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, s.prefix+key, b, ttl).Err()
+}
+
+func (s *redisStore) del(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key).Err()
+}
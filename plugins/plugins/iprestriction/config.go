@@ -0,0 +1,126 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iprestriction
+
+import (
+	"net/netip"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/plugins/pkg/geoip"
+	"mosn.io/htnn/types/plugins/iprestriction"
+)
+
+func init() {
+	plugins.RegisterPlugin(iprestriction.Name, &plugin{})
+}
+
+type plugin struct {
+	iprestriction.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type countryLookuper interface {
+	// Country returns the ISO 3166-1 alpha-2 code of the country, or empty if not found
+	Country(ip netip.Addr) (string, error)
+}
+
+type config struct {
+	iprestriction.CustomConfig
+
+	allow []netip.Prefix
+	deny  []netip.Prefix
+
+	geoIP          countryLookuper
+	allowCountries map[string]bool
+	denyCountries  map[string]bool
+}
+
+func toPrefixes(ips []string) []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(ips))
+	for _, ip := range ips {
+		// already validated
+		prefix, _ := iprestriction.ParsePrefix(ip)
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes
+}
+
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.allow = toPrefixes(conf.Allow)
+	conf.deny = toPrefixes(conf.Deny)
+
+	if geo := conf.GeoIp; geo != nil {
+		db, err := geoip.Load(geo.Database)
+		if err != nil {
+			return err
+		}
+		conf.geoIP = db
+		conf.allowCountries = toSet(geo.AllowCountries)
+		conf.denyCountries = toSet(geo.DenyCountries)
+	}
+	return nil
+}
+
+func matchPrefixes(prefixes []netip.Prefix, ip netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowed checks the IP against the IP lists first, then the countries
+func (conf *config) allowed(ip netip.Addr) bool {
+	if matchPrefixes(conf.deny, ip) {
+		return false
+	}
+	if len(conf.allow) > 0 && !matchPrefixes(conf.allow, ip) {
+		return false
+	}
+
+	if conf.geoIP == nil {
+		return true
+	}
+	country, err := conf.geoIP.Country(ip)
+	if err != nil {
+		api.LogErrorf("failed to look up the country of %s: %v", ip, err)
+		// the country is unknown
+		country = ""
+	}
+	if conf.denyCountries[country] {
+		return false
+	}
+	if len(conf.allowCountries) > 0 && !conf.allowCountries[country] {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iprestriction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+			err:   "at least one of allow, deny and geoIp should be specified",
+		},
+		{
+			name:  "bad ip",
+			input: `{"allow":["1.1.1"]}`,
+			err:   "bad ip 1.1.1",
+		},
+		{
+			name:  "bad cidr",
+			input: `{"deny":["1.1.1.1/33"]}`,
+			err:   "bad ip 1.1.1.1/33",
+		},
+		{
+			name:  "bad xff hops",
+			input: `{"allow":["1.1.1.1"], "xffNumTrustedHops": 11}`,
+			err:   "invalid Config.XffNumTrustedHops",
+		},
+		{
+			name:  "bad country",
+			input: `{"geoIp":{"database":"/geoip.mmdb", "allowCountries":["us"]}}`,
+			err:   "invalid GeoIP.AllowCountries[0]",
+		},
+		{
+			name:  "database not found",
+			input: `{"geoIp":{"database":"/not/found.mmdb", "denyCountries":["US"]}}`,
+			err:   "failed to load GeoIP database",
+		},
+		{
+			name:  "ok",
+			input: `{"allow":["10.0.0.0/8", "::ffff:192.168.0.0/112", "2001:db8::1"], "deny":["10.0.0.1"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
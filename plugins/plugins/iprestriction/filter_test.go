@@ -0,0 +1,166 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package iprestriction
+
+import (
+	"errors"
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type fakeGeoIP map[string]string
+
+func (g fakeGeoIP) Country(ip netip.Addr) (string, error) {
+	if ip.String() == "6.6.6.6" {
+		return "", errors.New("corrupted")
+	}
+	return g[ip.String()], nil
+}
+
+func TestIPRestriction(t *testing.T) {
+	geoIP := fakeGeoIP{
+		"1.1.1.1": "US",
+		"2.2.2.2": "CN",
+		"3.3.3.3": "JP",
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		xff     []string
+		allowed bool
+	}{
+		{
+			name:    "remote address allowed",
+			input:   `{"allow":["183.128.0.0/16"]}`,
+			allowed: true,
+		},
+		{
+			name:  "remote address not allowed",
+			input: `{"allow":["183.129.0.0/16"]}`,
+		},
+		{
+			name:  "deny first",
+			input: `{"allow":["183.128.0.0/16"], "deny":["183.128.130.43"]}`,
+		},
+		{
+			name:    "ignore xff by default",
+			input:   `{"deny":["10.0.0.1"]}`,
+			xff:     []string{"10.0.0.1"},
+			allowed: true,
+		},
+		{
+			name:  "xff",
+			input: `{"deny":["10.0.0.1"], "xffNumTrustedHops": 2}`,
+			xff:   []string{"1.1.1.1, 10.0.0.1", "192.168.0.1"},
+		},
+		{
+			name:    "xff, forged address is skipped",
+			input:   `{"allow":["10.0.0.1"], "xffNumTrustedHops": 1}`,
+			xff:     []string{"1.1.1.1,10.0.0.1"},
+			allowed: true,
+		},
+		{
+			name:    "xff, not enough hops",
+			input:   `{"allow":["183.128.130.43"], "xffNumTrustedHops": 2}`,
+			xff:     []string{"10.0.0.1"},
+			allowed: true,
+		},
+		{
+			name:  "xff, bad ip",
+			input: `{"deny":["10.0.0.1"], "xffNumTrustedHops": 1}`,
+			xff:   []string{"unknown"},
+		},
+		{
+			name:    "ipv4-mapped",
+			input:   `{"allow":["10.0.0.0/8"], "xffNumTrustedHops": 1}`,
+			xff:     []string{"::ffff:10.0.0.1"},
+			allowed: true,
+		},
+		{
+			name:    "ipv6",
+			input:   `{"allow":["2001:db8::/32"], "xffNumTrustedHops": 1}`,
+			xff:     []string{"2001:db8::1"},
+			allowed: true,
+		},
+		{
+			name:    "allow countries",
+			input:   `{"geoIp":{"database":"x", "allowCountries":["US", "JP"]}, "xffNumTrustedHops": 1}`,
+			xff:     []string{"3.3.3.3"},
+			allowed: true,
+		},
+		{
+			name:  "country not allowed",
+			input: `{"geoIp":{"database":"x", "allowCountries":["US", "JP"]}, "xffNumTrustedHops": 1}`,
+			xff:   []string{"2.2.2.2"},
+		},
+		{
+			name:  "unknown country not allowed",
+			input: `{"geoIp":{"database":"x", "allowCountries":["US", "JP"]}, "xffNumTrustedHops": 1}`,
+			xff:   []string{"6.6.6.6"},
+		},
+		{
+			name:  "deny countries",
+			input: `{"geoIp":{"database":"x", "denyCountries":["CN"]}, "xffNumTrustedHops": 1}`,
+			xff:   []string{"2.2.2.2"},
+		},
+		{
+			name:    "country not denied",
+			input:   `{"geoIp":{"database":"x", "denyCountries":["CN"]}, "xffNumTrustedHops": 1}`,
+			xff:     []string{"4.4.4.4"},
+			allowed: true,
+		},
+		{
+			name:  "ip and country",
+			input: `{"allow":["1.0.0.0/8"], "geoIp":{"database":"x", "denyCountries":["US"]}, "xffNumTrustedHops": 1}`,
+			xff:   []string{"1.1.1.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			require.NoError(t, conf.Validate())
+			conf.allow = toPrefixes(conf.Allow)
+			conf.deny = toPrefixes(conf.Deny)
+			if geo := conf.GeoIp; geo != nil {
+				conf.geoIP = geoIP
+				conf.allowCountries = toSet(geo.AllowCountries)
+				conf.denyCountries = toSet(geo.DenyCountries)
+			}
+
+			f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+			h := http.Header{}
+			for _, xff := range tt.xff {
+				h.Add("x-forwarded-for", xff)
+			}
+			res := f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+			if tt.allowed {
+				assert.Equal(t, api.Continue, res)
+			} else {
+				assert.Equal(t, 403, res.(*api.LocalResponse).Code)
+			}
+		})
+	}
+}
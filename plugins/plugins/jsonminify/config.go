@@ -0,0 +1,77 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonminify
+
+import (
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/jsonminify"
+)
+
+const (
+	defaultMaxBodySize = 1 << 20
+	defaultQueryParam  = "fields"
+)
+
+func init() {
+	plugins.RegisterPlugin(jsonminify.Name, &plugin{})
+}
+
+type plugin struct {
+	jsonminify.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	jsonminify.Config
+
+	maxBodySize  int
+	contentTypes map[string]bool
+	// queryParam is empty if the fields are not filtered
+	queryParam string
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+
+	contentTypes := conf.ContentTypes
+	if len(contentTypes) == 0 {
+		contentTypes = []string{"application/json"}
+	}
+	conf.contentTypes = make(map[string]bool, len(contentTypes))
+	for _, ct := range contentTypes {
+		conf.contentTypes[strings.ToLower(ct)] = true
+	}
+
+	if ff := conf.FieldsFilter; ff != nil {
+		conf.queryParam = ff.QueryParam
+		if conf.queryParam == "" {
+			conf.queryParam = defaultQueryParam
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonminify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+		},
+		{
+			name:  "bad content type",
+			input: `{"contentTypes":[""]}`,
+			err:   "invalid Config.ContentTypes[0]",
+		},
+		{
+			name:  "ok",
+			input: `{"fieldsFilter":{"queryParam":"select"},"maxBodySize":1024,"contentTypes":["application/json","application/problem+json"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				err = conf.Init(nil)
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestConfigDefault(t *testing.T) {
+	conf := &config{}
+	assert.Nil(t, protojson.Unmarshal([]byte(`{"fieldsFilter":{}}`), conf))
+	assert.Nil(t, conf.Init(nil))
+	assert.Equal(t, "fields", conf.queryParam)
+	assert.Equal(t, defaultMaxBodySize, conf.maxBodySize)
+	assert.True(t, conf.contentTypes["application/json"])
+
+	conf = &config{}
+	assert.Nil(t, conf.Init(nil))
+	assert.Equal(t, "", conf.queryParam)
+}
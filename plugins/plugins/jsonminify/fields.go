@@ -0,0 +1,236 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonminify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// the fields nested deeper than it are rejected, to bound the recursion
+const maxFieldsDepth = 16
+
+// selection is the fields to keep in a JSON object. A nil child means the whole field is kept.
+type selection map[string]selection
+
+type fieldsParser struct {
+	s   string
+	pos int
+}
+
+// parseFields parses the fields like "id,user.name,items(id,price)". A dotted path selects a
+// nested field, and the fields in the parentheses are selected from the given field. When the
+// field is an array, the selection is applied to each of its elements.
+func parseFields(s string) (selection, error) {
+	p := &fieldsParser{s: s}
+	sel := selection{}
+	err := p.parseList(sel, 0)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.s) {
+		return nil, p.unexpected()
+	}
+	return sel, nil
+}
+
+func (p *fieldsParser) unexpected() error {
+	if p.pos >= len(p.s) {
+		return errors.New("unexpected end of fields")
+	}
+	return fmt.Errorf("unexpected character %q at position %d of fields", p.s[p.pos], p.pos)
+}
+
+func (p *fieldsParser) peek(c byte) bool {
+	return p.pos < len(p.s) && p.s[p.pos] == c
+}
+
+// parseList parses `item (',' item)*`
+func (p *fieldsParser) parseList(sel selection, depth int) error {
+	for {
+		err := p.parseItem(sel, depth)
+		if err != nil {
+			return err
+		}
+		if !p.peek(',') {
+			return nil
+		}
+		p.pos++
+	}
+}
+
+// parseItem parses `name ('.' name)* ['(' list ')']`
+func (p *fieldsParser) parseItem(sel selection, depth int) error {
+	var path []string
+	for {
+		start := p.pos
+		for p.pos < len(p.s) && !isDelimiter(p.s[p.pos]) {
+			p.pos++
+		}
+		if p.pos == start {
+			return p.unexpected()
+		}
+		path = append(path, p.s[start:p.pos])
+		if !p.peek('.') {
+			break
+		}
+		p.pos++
+	}
+
+	depth += len(path)
+	if depth > maxFieldsDepth {
+		return fmt.Errorf("fields are nested deeper than %d", maxFieldsDepth)
+	}
+
+	node := sel
+	for _, name := range path[:len(path)-1] {
+		node = node.child(name)
+	}
+	last := path[len(path)-1]
+	if !p.peek('(') {
+		node[last] = nil
+		return nil
+	}
+
+	p.pos++
+	err := p.parseList(node.child(last), depth)
+	if err != nil {
+		return err
+	}
+	if !p.peek(')') {
+		return p.unexpected()
+	}
+	p.pos++
+	return nil
+}
+
+// child returns the selection of the given field, creating it if it's missing. If the whole
+// field is already kept, a detached selection is returned so that the field is still kept.
+func (sel selection) child(name string) selection {
+	c, ok := sel[name]
+	if ok && c == nil {
+		return selection{}
+	}
+	if !ok {
+		c = selection{}
+		sel[name] = c
+	}
+	return c
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case ',', '.', '(', ')', ' ', '\t', '\r', '\n':
+		return true
+	}
+	return false
+}
+
+// filterJSON writes the compact form of the JSON value, keeping only the selected fields of the
+// objects. The value should be valid JSON.
+func filterJSON(buf *bytes.Buffer, value []byte, sel selection) error {
+	value = bytes.TrimLeft(value, " \t\r\n")
+	if len(value) == 0 {
+		return errors.New("empty JSON value")
+	}
+	switch value[0] {
+	case '{':
+		return filterObject(buf, value, sel)
+	case '[':
+		return filterArray(buf, value, sel)
+	}
+	return json.Compact(buf, value)
+}
+
+func filterObject(buf *bytes.Buffer, value []byte, sel selection) error {
+	dec := json.NewDecoder(bytes.NewReader(value))
+	// skip the '{'
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	buf.WriteByte('{')
+	first := true
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		var field json.RawMessage
+		if err := dec.Decode(&field); err != nil {
+			return err
+		}
+
+		child, ok := sel[key]
+		if !ok {
+			continue
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		writeString(buf, key)
+		buf.WriteByte(':')
+		if child == nil {
+			err = json.Compact(buf, field)
+		} else {
+			err = filterJSON(buf, field, child)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func filterArray(buf *bytes.Buffer, value []byte, sel selection) error {
+	dec := json.NewDecoder(bytes.NewReader(value))
+	// skip the '['
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	buf.WriteByte('[')
+	first := true
+	for dec.More() {
+		var elem json.RawMessage
+		if err := dec.Decode(&elem); err != nil {
+			return err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		if err := filterJSON(buf, elem, sel); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte(']')
+	return nil
+}
+
+// writeString writes the JSON string without escaping the HTML characters, so the keys are
+// written as they are as much as possible.
+func writeString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	// remove the trailing newline added by the encoder
+	buf.Truncate(buf.Len() - 1)
+}
@@ -0,0 +1,159 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonminify
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		input string
+		sel   selection
+		err   string
+	}{
+		{
+			input: "id",
+			sel:   selection{"id": nil},
+		},
+		{
+			input: "id,user.name,items(id,price)",
+			sel: selection{
+				"id":    nil,
+				"user":  selection{"name": nil},
+				"items": selection{"id": nil, "price": nil},
+			},
+		},
+		{
+			input: "a.b(c,d.e),a.f,a.b(g)",
+			sel: selection{
+				"a": selection{
+					"b": selection{"c": nil, "d": selection{"e": nil}, "g": nil},
+					"f": nil,
+				},
+			},
+		},
+		{
+			// the whole field is kept
+			input: "a,a.b,a(c)",
+			sel:   selection{"a": nil},
+		},
+		{
+			input: "a.b,a",
+			sel:   selection{"a": nil},
+		},
+		{
+			input: "a,",
+			err:   "unexpected end of fields",
+		},
+		{
+			input: "a..b",
+			err:   "unexpected character '.' at position 2",
+		},
+		{
+			input: "a(b",
+			err:   "unexpected end of fields",
+		},
+		{
+			input: "a()",
+			err:   "unexpected character ')' at position 2",
+		},
+		{
+			input: "a)",
+			err:   "unexpected character ')' at position 1",
+		},
+		{
+			input: "a, b",
+			err:   "unexpected character ' ' at position 2",
+		},
+		{
+			input: strings.Repeat("a.", maxFieldsDepth) + "a",
+			err:   "fields are nested deeper than 16",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			sel, err := parseFields(tt.input)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.sel, sel)
+		})
+	}
+}
+
+func TestFilterJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields string
+		input  string
+		output string
+	}{
+		{
+			name:   "object",
+			fields: "id,user.name,missing",
+			input:  `{"id": 1, "secret": "x", "user": {"name": "<a>", "age": 2}}`,
+			output: `{"id":1,"user":{"name":"<a>"}}`,
+		},
+		{
+			name:   "keep order",
+			fields: "b,a",
+			input:  `{"a": 1.50, "c": 3, "b": [1, 2]}`,
+			output: `{"a":1.50,"b":[1,2]}`,
+		},
+		{
+			name:   "array",
+			fields: "items(id)",
+			input:  `{"items": [{"id": 1, "name": "a"}, {"name": "b"}, 3, [{"id": 2, "x": 1}]], "total": 3}`,
+			output: `{"items":[{"id":1},{},3,[{"id":2}]]}`,
+		},
+		{
+			name:   "top-level array",
+			fields: "id",
+			input:  `[{"id": 1, "name": "a"}, {"id": 2}]`,
+			output: `[{"id":1},{"id":2}]`,
+		},
+		{
+			name:   "not object",
+			fields: "a.b",
+			input:  `{"a": "str", "b": null}`,
+			output: `{"a":"str"}`,
+		},
+		{
+			name:   "escaped key",
+			fields: "a\"b",
+			input:  `{"a\"b": 1, "c": 2}`,
+			output: `{"a\"b":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := parseFields(tt.fields)
+			require.NoError(t, err)
+			var buf bytes.Buffer
+			require.NoError(t, filterJSON(&buf, []byte(tt.input), sel))
+			assert.Equal(t, tt.output, buf.String())
+		})
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonminify
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/sse"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	fields       selection
+	filterFields bool
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	if f.config.queryParam == "" {
+		return api.Continue
+	}
+
+	s := headers.URL().Query().Get(f.config.queryParam)
+	if s == "" {
+		return api.Continue
+	}
+	fields, err := parseFields(s)
+	if err != nil {
+		return &api.LocalResponse{Code: 400, Msg: "bad " + f.config.queryParam + ": " + err.Error()}
+	}
+	f.fields = fields
+	return api.Continue
+}
+
+// shouldMinify checks if the response body can be minified according to the headers
+func (f *filter) shouldMinify(headers api.ResponseHeaderMap) bool {
+	config := f.config
+	if enc, ok := headers.Get("content-encoding"); ok && enc != "" && !strings.EqualFold(enc, "identity") {
+		// we can't minify the compressed body
+		return false
+	}
+	if sse.IsEventStream(headers) {
+		// the event stream may never end, so it can't be buffered
+		return false
+	}
+	ct, ok := headers.Get("content-type")
+	if !ok {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil || !config.contentTypes[mediaType] {
+		return false
+	}
+	if cl, ok := headers.Get("content-length"); ok {
+		n, err := strconv.Atoi(cl)
+		if err == nil && n > config.maxBodySize {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if endStream || !f.shouldMinify(headers) {
+		return api.Continue
+	}
+
+	if f.fields != nil {
+		// only filter the successful responses, so that the error details are kept
+		status, _ := headers.Get(":status")
+		f.filterFields = strings.HasPrefix(status, "2")
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	if data == nil || data.Len() == 0 {
+		return api.Continue
+	}
+	if data.Len() > f.config.maxBodySize {
+		api.LogInfof("jsonMinify: body size %d exceeds the limit, skip minifying", data.Len())
+		return api.Continue
+	}
+
+	body := data.Bytes()
+	if !json.Valid(body) {
+		api.LogInfof("jsonMinify: invalid JSON body, skip minifying")
+		return api.Continue
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(body))
+	var err error
+	if f.filterFields {
+		err = filterJSON(&buf, body, f.fields)
+	} else {
+		err = json.Compact(&buf, body)
+	}
+	if err != nil {
+		api.LogInfof("jsonMinify: failed to minify body: %v", err)
+		return api.Continue
+	}
+	_ = data.Set(buf.Bytes())
+	headers.Set("content-length", strconv.Itoa(buf.Len()))
+	return api.Continue
+}
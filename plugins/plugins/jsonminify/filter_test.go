@@ -0,0 +1,171 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonminify
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestBadFields(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"fieldsFilter":{}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	hdr := envoy.NewRequestHeaderMap(http.Header{":path": {"/users?fields=a(b"}})
+	res := f.DecodeHeaders(hdr, true)
+	resp, ok := res.(*api.LocalResponse)
+	require.True(t, ok)
+	assert.Equal(t, 400, resp.Code)
+	assert.Equal(t, "bad fields: unexpected end of fields", resp.Msg)
+
+	// the query parameter is not enabled
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f = factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+}
+
+func TestMinify(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"fieldsFilter":{"queryParam":"select"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	body := `{
+  "id": 1,
+  "name": "a b",
+  "tags": [ "x", "y" ]
+}`
+
+	tests := []struct {
+		name      string
+		path      string
+		header    map[string]string
+		body      string
+		endStream bool
+		wait      bool
+		expected  string
+	}{
+		{
+			name:     "minify",
+			path:     "/users/1",
+			header:   map[string]string{":status": "200", "content-type": "application/json; charset=utf-8"},
+			body:     body,
+			wait:     true,
+			expected: `{"id":1,"name":"a b","tags":["x","y"]}`,
+		},
+		{
+			name:     "filter fields",
+			path:     "/users/1?select=id,tags",
+			header:   map[string]string{":status": "200", "content-type": "application/json"},
+			body:     body,
+			wait:     true,
+			expected: `{"id":1,"tags":["x","y"]}`,
+		},
+		{
+			name:     "don't filter error",
+			path:     "/users/1?select=id",
+			header:   map[string]string{":status": "404", "content-type": "application/json"},
+			body:     `{"error": "not found"}`,
+			wait:     true,
+			expected: `{"error":"not found"}`,
+		},
+		{
+			name:     "invalid JSON",
+			path:     "/users/1",
+			header:   map[string]string{":status": "200", "content-type": "application/json"},
+			body:     `{"id": 1`,
+			wait:     true,
+			expected: `{"id": 1`,
+		},
+		{
+			name:   "content type mismatched",
+			path:   "/users/1",
+			header: map[string]string{":status": "200", "content-type": "text/html"},
+		},
+		{
+			name:   "compressed",
+			path:   "/users/1",
+			header: map[string]string{":status": "200", "content-type": "application/json", "content-encoding": "gzip"},
+		},
+		{
+			name:   "event stream",
+			path:   "/users/1",
+			header: map[string]string{":status": "200", "content-type": "text/event-stream"},
+		},
+		{
+			name:   "too large",
+			path:   "/users/1",
+			header: map[string]string{":status": "200", "content-type": "application/json", "content-length": "1048577"},
+		},
+		{
+			name:      "no body",
+			path:      "/users/1",
+			header:    map[string]string{":status": "204", "content-type": "application/json"},
+			endStream: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+			reqHdr := envoy.NewRequestHeaderMap(http.Header{":path": {tt.path}})
+			require.Equal(t, api.Continue, f.DecodeHeaders(reqHdr, true))
+
+			h := http.Header{}
+			for k, v := range tt.header {
+				h.Set(k, v)
+			}
+			hdr := envoy.NewResponseHeaderMap(h)
+			res := f.EncodeHeaders(hdr, tt.endStream)
+			if !tt.wait {
+				assert.Equal(t, api.Continue, res)
+				return
+			}
+
+			assert.Equal(t, api.WaitAllData, res)
+			buf := envoy.NewBufferInstance([]byte(tt.body))
+			assert.Equal(t, api.Continue, f.EncodeResponse(hdr, buf, nil))
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestMinifyContentLength(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"maxBodySize": 16}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	hdr := envoy.NewResponseHeaderMap(http.Header{"Content-Type": {"application/json"}})
+	require.Equal(t, api.WaitAllData, f.EncodeHeaders(hdr, false))
+
+	buf := envoy.NewBufferInstance([]byte(`{ "a" : 1 }`))
+	f.EncodeResponse(hdr, buf, nil)
+	assert.Equal(t, `{"a":1}`, buf.String())
+	v, _ := hdr.Get("content-length")
+	assert.Equal(t, "7", v)
+
+	// the body without content-length is checked after it's received
+	buf = envoy.NewBufferInstance([]byte(`{ "a" : 1234567 }`))
+	f.EncodeResponse(hdr, buf, nil)
+	assert.Equal(t, `{ "a" : 1234567 }`, buf.String())
+}
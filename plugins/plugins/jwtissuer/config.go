@@ -0,0 +1,117 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtissuer
+
+import (
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/jwtissuer"
+)
+
+const (
+	defaultTTL    = 5 * time.Minute
+	defaultHeader = "authorization"
+
+	// the max number of the tokens cached for reuse
+	tokenCacheCapacity = 10000
+)
+
+func init() {
+	plugins.RegisterPlugin(jwtissuer.Name, &plugin{})
+}
+
+type plugin struct {
+	jwtissuer.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type claim struct {
+	name string
+	tpl  *interpolation.Template
+}
+
+type config struct {
+	jwtissuer.CustomConfig
+
+	signer        signer
+	encodedHeader string
+	subject       *interpolation.Template
+	claims        []*claim
+	ttl           time.Duration
+	header        string
+	// tokens caches the minted tokens by their claims. A token is reused in the first half of its
+	// lifetime, so that the upstream always receives a token which is valid for a while.
+	tokens *ttlcache.Cache[string, string]
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	var err error
+	conf.signer, err = newSigner(conf.Algorithm, conf.SigningKey)
+	if err != nil {
+		return err
+	}
+	conf.encodedHeader = encodeHeader(conf.Algorithm, conf.KeyId)
+
+	if conf.Subject != "" {
+		conf.subject, err = jwtissuer.CompileTemplate(conf.Subject)
+		if err != nil {
+			return err
+		}
+	}
+	for name, value := range conf.Claims {
+		tpl, err := jwtissuer.CompileTemplate(value)
+		if err != nil {
+			return err
+		}
+		conf.claims = append(conf.claims, &claim{name: name, tpl: tpl})
+	}
+	sort.Slice(conf.claims, func(i, j int) bool {
+		return conf.claims[i].name < conf.claims[j].name
+	})
+
+	conf.ttl = defaultTTL
+	if conf.Ttl != nil {
+		conf.ttl = conf.Ttl.AsDuration()
+	}
+	conf.header = defaultHeader
+	if conf.Header != "" {
+		conf.header = conf.Header
+	}
+
+	conf.tokens = ttlcache.New(
+		ttlcache.WithTTL[string, string](conf.ttl/2),
+		ttlcache.WithCapacity[string, string](tokenCacheCapacity),
+		ttlcache.WithDisableTouchOnHit[string, string](),
+	)
+	go conf.tokens.Start()
+	runtime.SetFinalizer(conf, func(conf *config) {
+		conf.tokens.Stop()
+	})
+	return nil
+}
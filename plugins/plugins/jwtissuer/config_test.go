@@ -0,0 +1,88 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtissuer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "hs256",
+			input: `{"signingKey":"secret","issuer":"htnn","audiences":["backend"],"claims":{"tenant":"${header.x-tenant}","email":"${oidc.email}"},"ttl":"60s"}`,
+		},
+		{
+			name:  "issuer is required",
+			input: `{"signingKey":"secret"}`,
+			err:   "invalid Config.Issuer: value length must be at least 1 runes",
+		},
+		{
+			name:  "signing key is required",
+			input: `{"issuer":"htnn"}`,
+			err:   "invalid Config.SigningKey: value length must be at least 1 runes",
+		},
+		{
+			name:  "bad ttl",
+			input: `{"signingKey":"secret","issuer":"htnn","ttl":"86401s"}`,
+			err:   "invalid Config.Ttl: value must be inside range (0s, 24h0m0s]",
+		},
+		{
+			name:  "reserved claim",
+			input: `{"signingKey":"secret","issuer":"htnn","claims":{"exp":"1"}}`,
+			err:   "claim exp is reserved",
+		},
+		{
+			name:  "bad claim",
+			input: `{"signingKey":"secret","issuer":"htnn","claims":{"a":"${oidc}"}}`,
+			err:   "bad claim a: unknown variable: oidc",
+		},
+		{
+			name:  "bad subject",
+			input: `{"signingKey":"secret","issuer":"htnn","subject":"${unknown}"}`,
+			err:   "bad subject: unknown variable: unknown",
+		},
+		{
+			name:  "bad private key",
+			input: `{"algorithm":"RS256","signingKey":"secret","issuer":"htnn"}`,
+			err:   "bad signing key: no PEM block found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtissuer
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/oidc"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	oidcClaims map[string]interface{}
+}
+
+// lookupClaim returns the OIDC claim with the given name. The nested claim can be accessed with ".".
+func lookupClaim(claims map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := claims[name]; ok {
+		return v, true
+	}
+
+	var cur interface{} = claims
+	for _, key := range strings.Split(name, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func (f *filter) lookup(name string) (string, error) {
+	claim, _ := strings.CutPrefix(name, "oidc.")
+	v, ok := lookupClaim(f.oidcClaims, claim)
+	if !ok || v == nil {
+		return "", nil
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", nil
+	}
+	return string(b), nil
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	// the upstream should only trust the token minted by us
+	headers.Del(config.header)
+
+	consumer := f.callbacks.GetConsumer()
+	if claims, ok := f.callbacks.PluginState().Get(oidc.Name, "claims").(map[string]interface{}); ok {
+		f.oidcClaims = claims
+	}
+	if consumer == nil && f.oidcClaims == nil {
+		if config.RejectUnauthenticated {
+			return &api.LocalResponse{Code: 401}
+		}
+		return api.Continue
+	}
+
+	claims := map[string]interface{}{
+		"iss": config.Issuer,
+	}
+	var sub string
+	if config.subject != nil {
+		sub, _ = config.subject.RenderWith(headers, f.callbacks, f.lookup, nil)
+	} else if consumer != nil {
+		sub = consumer.Name()
+	} else {
+		sub, _ = f.lookup("oidc.sub")
+	}
+	if sub != "" {
+		claims["sub"] = sub
+	}
+	switch len(config.Audiences) {
+	case 0:
+	case 1:
+		claims["aud"] = config.Audiences[0]
+	default:
+		claims["aud"] = config.Audiences
+	}
+	for _, c := range config.claims {
+		v, _ := c.tpl.RenderWith(headers, f.callbacks, f.lookup, nil)
+		if v != "" {
+			claims[c.name] = v
+		}
+	}
+
+	token, err := config.getToken(claims)
+	if err != nil {
+		api.LogErrorf("failed to mint token: %v", err)
+		return &api.LocalResponse{Code: 503}
+	}
+	if strings.EqualFold(config.header, defaultHeader) {
+		token = "Bearer " + token
+	}
+	headers.Set(config.header, token)
+	return api.Continue
+}
+
+// getToken returns the cached token with the same claims, or mints a new one.
+func (conf *config) getToken(claims map[string]interface{}) (string, error) {
+	// the keys of the map are sorted when marshalled, so the result is stable
+	b, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	key := string(b)
+	if item := conf.tokens.Get(key); item != nil {
+		return item.Value(), nil
+	}
+
+	now := time.Now()
+	claims["iat"] = now.Unix()
+	claims["nbf"] = now.Unix()
+	claims["exp"] = now.Add(conf.ttl).Unix()
+	token, err := conf.mint(claims)
+	if err != nil {
+		return "", err
+	}
+	conf.tokens.Set(key, token, ttlcache.DefaultTTL)
+	return token, nil
+}
@@ -0,0 +1,244 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtissuer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	"mosn.io/htnn/types/plugins/oidc"
+)
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func newHeaders(hdr http.Header) api.RequestHeaderMap {
+	h := http.Header{
+		":authority": {"test.local"},
+		":method":    {"GET"},
+		":path":      {"/"},
+	}
+	for k, v := range hdr {
+		h[k] = v
+	}
+	return envoy.NewRequestHeaderMap(h)
+}
+
+func decode(t *testing.T, token string) (header map[string]interface{}, claims map[string]interface{}, signingInput string, sig []byte) {
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	b, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &header))
+	b, err = base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &claims))
+	sig, err = base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	return header, claims, parts[0] + "." + parts[1], sig
+}
+
+func TestIssueForConsumer(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"signingKey":"secret","keyId":"k1","issuer":"htnn","audiences":["backend"],"claims":{"tenant":"${header.x-tenant}","empty":"${header.x-none}"},"ttl":"60s"}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetConsumer(&testConsumer{name: "alice"})
+	h := newHeaders(http.Header{
+		"Authorization": {"Bearer edge-token"},
+		"X-Tenant":      {"a"},
+	})
+	f := factory(conf, cb)
+	assert.Equal(t, api.Continue, f.DecodeHeaders(h, true))
+
+	auth, _ := h.Get("authorization")
+	token, ok := strings.CutPrefix(auth, "Bearer ")
+	require.True(t, ok)
+	header, claims, input, sig := decode(t, token)
+	assert.Equal(t, map[string]interface{}{"alg": "HS256", "typ": "JWT", "kid": "k1"}, header)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(input))
+	assert.Equal(t, mac.Sum(nil), sig)
+
+	now := time.Now().Unix()
+	exp := int64(claims["exp"].(float64))
+	assert.InDelta(t, now+60, exp, 2)
+	delete(claims, "exp")
+	delete(claims, "iat")
+	delete(claims, "nbf")
+	assert.Equal(t, map[string]interface{}{
+		"iss":    "htnn",
+		"sub":    "alice",
+		"aud":    "backend",
+		"tenant": "a",
+	}, claims)
+
+	// the token is reused
+	h = newHeaders(http.Header{"X-Tenant": {"a"}})
+	factory(conf, cb).DecodeHeaders(h, true)
+	auth2, _ := h.Get("authorization")
+	assert.Equal(t, auth, auth2)
+
+	// different claims
+	h = newHeaders(http.Header{"X-Tenant": {"b"}})
+	factory(conf, cb).DecodeHeaders(h, true)
+	auth2, _ = h.Get("authorization")
+	assert.NotEqual(t, auth, auth2)
+}
+
+func TestIssueForOIDC(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	input, _ := json.Marshal(map[string]interface{}{
+		"algorithm":  "ES256",
+		"signingKey": pemKey,
+		"issuer":     "htnn",
+		"audiences":  []string{"a", "b"},
+		"header":     "x-internal-token",
+		"claims": map[string]string{
+			"email": "${oidc.email}",
+			"org":   "${oidc.org.name}",
+			"roles": "${oidc.roles}",
+		},
+	})
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal(input, conf))
+	require.NoError(t, conf.Init(nil))
+
+	cb := envoy.NewFilterCallbackHandler()
+	cb.PluginState().Set(oidc.Name, "claims", map[string]interface{}{
+		"sub":   "u1",
+		"email": "u1@example.com",
+		"org":   map[string]interface{}{"name": "mosn"},
+		"roles": []interface{}{"admin"},
+	})
+	h := newHeaders(http.Header{"X-Internal-Token": {"spoofed"}})
+	factory(conf, cb).DecodeHeaders(h, true)
+
+	token, _ := h.Get("x-internal-token")
+	header, claims, signingInput, sig := decode(t, token)
+	assert.Equal(t, "ES256", header["alg"])
+	require.Len(t, sig, 64)
+	digest := sha256.Sum256([]byte(signingInput))
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:])
+	assert.True(t, ecdsa.Verify(&key.PublicKey, digest[:], r, s))
+
+	assert.Equal(t, "u1", claims["sub"])
+	assert.Equal(t, []interface{}{"a", "b"}, claims["aud"])
+	assert.Equal(t, "u1@example.com", claims["email"])
+	assert.Equal(t, "mosn", claims["org"])
+	assert.Equal(t, `["admin"]`, claims["roles"])
+	_, ok := h.Get("authorization")
+	assert.False(t, ok)
+}
+
+func TestIssueWithRSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	input, _ := json.Marshal(map[string]interface{}{
+		"algorithm":  "RS256",
+		"signingKey": pemKey,
+		"issuer":     "htnn",
+		"subject":    "consumer:${consumer.name}",
+	})
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal(input, conf))
+	require.NoError(t, conf.Init(nil))
+
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetConsumer(&testConsumer{name: "alice"})
+	h := newHeaders(nil)
+	factory(conf, cb).DecodeHeaders(h, true)
+
+	auth, _ := h.Get("authorization")
+	header, claims, signingInput, sig := decode(t, strings.TrimPrefix(auth, "Bearer "))
+	assert.Equal(t, "RS256", header["alg"])
+	digest := sha256.Sum256([]byte(signingInput))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], sig))
+	assert.Equal(t, "consumer:alice", claims["sub"])
+}
+
+func TestBadKeyType(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+
+	for _, alg := range []string{"RS256", "ES256"} {
+		input, _ := json.Marshal(map[string]interface{}{
+			"algorithm":  alg,
+			"signingKey": pemKey,
+			"issuer":     "htnn",
+		})
+		conf := &config{}
+		require.NoError(t, protojson.Unmarshal(input, conf))
+		require.NoError(t, conf.Validate())
+		assert.ErrorContains(t, conf.Init(nil), "bad signing key: "+alg+" requires")
+	}
+}
+
+func TestUnauthenticated(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"signingKey":"secret","issuer":"htnn"}`), conf))
+	require.NoError(t, conf.Init(nil))
+	h := newHeaders(http.Header{"Authorization": {"Bearer spoofed"}})
+	assert.Equal(t, api.Continue, factory(conf, envoy.NewFilterCallbackHandler()).DecodeHeaders(h, true))
+	_, ok := h.Get("authorization")
+	assert.False(t, ok)
+
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"signingKey":"secret","issuer":"htnn","rejectUnauthenticated":true}`), conf))
+	require.NoError(t, conf.Init(nil))
+	res := factory(conf, envoy.NewFilterCallbackHandler()).DecodeHeaders(newHeaders(nil), true)
+	assert.Equal(t, 401, res.(*api.LocalResponse).Code)
+}
@@ -0,0 +1,143 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtissuer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"mosn.io/htnn/types/plugins/jwtissuer"
+)
+
+// signer signs the JWT with the configured algorithm
+type signer interface {
+	sign(data []byte) ([]byte, error)
+}
+
+type hmacSigner struct {
+	key []byte
+}
+
+func (s *hmacSigner) sign(data []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(data)
+	return mac.Sum(nil), nil
+}
+
+type rsaSigner struct {
+	key *rsa.PrivateKey
+}
+
+func (s *rsaSigner) sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+	return rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+}
+
+type ecdsaSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *ecdsaSigner) sign(data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+	r, ss, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	// the signature is the concatenation of R and S, as defined in RFC 7518
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	ss.FillBytes(sig[32:])
+	return sig, nil
+}
+
+func parsePrivateKey(data string) (crypto.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %s", block.Type)
+	}
+}
+
+func newSigner(alg jwtissuer.Config_Algorithm, key string) (signer, error) {
+	if alg == jwtissuer.Config_HS256 {
+		return &hmacSigner{key: []byte(key)}, nil
+	}
+
+	privKey, err := parsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("bad signing key: %w", err)
+	}
+	switch alg {
+	case jwtissuer.Config_RS256:
+		k, ok := privKey.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errors.New("bad signing key: RS256 requires a RSA private key")
+		}
+		return &rsaSigner{key: k}, nil
+	case jwtissuer.Config_ES256:
+		k, ok := privKey.(*ecdsa.PrivateKey)
+		if !ok || k.Curve != elliptic.P256() {
+			return nil, errors.New("bad signing key: ES256 requires an ECDSA P-256 private key")
+		}
+		return &ecdsaSigner{key: k}, nil
+	}
+	return nil, fmt.Errorf("unsupported algorithm %s", alg)
+}
+
+// encodeHeader returns the encoded JOSE header, which is the same for all the tokens
+func encodeHeader(alg jwtissuer.Config_Algorithm, kid string) string {
+	header := map[string]string{
+		"alg": alg.String(),
+		"typ": "JWT",
+	}
+	if kid != "" {
+		header["kid"] = kid
+	}
+	b, _ := json.Marshal(header)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (conf *config) mint(claims map[string]interface{}) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := conf.encodedHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sig, err := conf.signer.sign([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
@@ -0,0 +1,116 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaevent
+
+import (
+	"net/http"
+	"runtime"
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/plugins/pkg/kafka"
+	"mosn.io/htnn/types/plugins/kafkaevent"
+)
+
+const (
+	defaultMaxBodySize   = 4 * 1024
+	defaultBatchSize     = 100
+	defaultFlushInterval = 1 * time.Second
+	defaultTimeout       = 5 * time.Second
+	defaultQueueSize     = 10000
+)
+
+func init() {
+	plugins.RegisterPlugin(kafkaevent.Name, &plugin{})
+	filtermanager.RegisterMetrics(kafkaevent.Name, writeMetrics)
+}
+
+type plugin struct {
+	kafkaevent.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	kafkaevent.CustomConfig
+
+	key             *interpolation.Template
+	requestHeaders  []string
+	responseHeaders []string
+	maxBodySize     int
+	publisher       *publisher
+}
+
+func lowerAll(names []string) []string {
+	res := make([]string, len(names))
+	for i, name := range names {
+		res[i] = strings.ToLower(name)
+	}
+	return res
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if conf.Key != "" {
+		key, err := interpolation.Compile(conf.Key)
+		if err != nil {
+			return err
+		}
+		conf.key = key
+	}
+	conf.requestHeaders = lowerAll(conf.RequestHeaders)
+	conf.responseHeaders = lowerAll(conf.ResponseHeaders)
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.BodySampling != nil && conf.BodySampling.MaxSize > 0 {
+		conf.maxBodySize = int(conf.BodySampling.MaxSize)
+	}
+
+	batchSize := defaultBatchSize
+	if conf.BatchSize > 0 {
+		batchSize = int(conf.BatchSize)
+	}
+	flushInterval := defaultFlushInterval
+	if conf.FlushInterval != nil {
+		flushInterval = conf.FlushInterval.AsDuration()
+	}
+	timeout := defaultTimeout
+	if conf.Timeout != nil {
+		timeout = conf.Timeout.AsDuration()
+	}
+	queueSize := defaultQueueSize
+	if conf.QueueSize > 0 {
+		queueSize = int(conf.QueueSize)
+	}
+
+	producer := kafka.NewRESTProducer(&http.Client{Timeout: timeout}, conf.RestProxyUrl, conf.Topic)
+	conf.publisher = newPublisher(producer, conf.Topic, queueSize, batchSize, flushInterval,
+		int(conf.MaxRetries), conf.OverflowPolicy == kafkaevent.OverflowPolicy_DROP_OLDEST)
+	// the publisher's goroutine doesn't reference the config, so it can be collected once it's replaced
+	pub := conf.publisher
+	runtime.SetFinalizer(conf, func(conf *config) {
+		// don't block the finalizer goroutine when sending the pending events
+		pub.stop()
+	})
+	return nil
+}
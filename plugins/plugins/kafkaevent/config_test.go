@@ -0,0 +1,86 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaevent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "sanity",
+			input: `{"restProxyUrl":"http://kafka-rest.local:8082","topic":"events"}`,
+		},
+		{
+			name:  "full",
+			input: `{"restProxyUrl":"http://kafka-rest.local:8082","topic":"events","key":"${consumer.name}","requestHeaders":["x-tenant"],"responseHeaders":["content-type"],"bodySampling":{"percentage":10,"maxSize":1024,"request":true},"batchSize":500,"flushInterval":"5s","timeout":"10s","queueSize":100000,"overflowPolicy":"DROP_OLDEST","maxRetries":3}`,
+		},
+		{
+			name:  "bad url",
+			input: `{"restProxyUrl":"kafka-rest.local","topic":"events"}`,
+			err:   "invalid Config.RestProxyUrl: value must be absolute",
+		},
+		{
+			name:  "missing topic",
+			input: `{"restProxyUrl":"http://kafka-rest.local:8082"}`,
+			err:   "invalid Config.Topic: value length must be at least 1 runes",
+		},
+		{
+			name:  "bad key",
+			input: `{"restProxyUrl":"http://kafka-rest.local:8082","topic":"events","key":"${unknown}"}`,
+			err:   "bad key: unknown variable: unknown",
+		},
+		{
+			name:  "bad percentage",
+			input: `{"restProxyUrl":"http://kafka-rest.local:8082","topic":"events","bodySampling":{"percentage":0,"request":true}}`,
+			err:   "invalid BodySampling.Percentage: value must be inside range (0, 100]",
+		},
+		{
+			name:  "sample nothing",
+			input: `{"restProxyUrl":"http://kafka-rest.local:8082","topic":"events","bodySampling":{"percentage":10}}`,
+			err:   "bodySampling should include the request or the response",
+		},
+		{
+			name:  "too many retries",
+			input: `{"restProxyUrl":"http://kafka-rest.local:8082","topic":"events","maxRetries":11}`,
+			err:   "invalid Config.MaxRetries: value must be less than or equal to 10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+				conf.publisher.close()
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaevent
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/rand"
+	"time"
+	"unicode/utf8"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/kafka"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	start    time.Time
+	key      string
+	sampled  bool
+	reqBody  bodyBuffer
+	respBody bodyBuffer
+}
+
+// bodyBuffer keeps the head of the body, so that the request is not blocked
+type bodyBuffer struct {
+	data      []byte
+	truncated bool
+}
+
+func (b *bodyBuffer) append(data []byte, maxSize int) {
+	if b.truncated {
+		return
+	}
+	if room := maxSize - len(b.data); len(data) > room {
+		data = data[:room]
+		b.truncated = true
+	}
+	// copy the data, as the buffer will be reused by Envoy
+	b.data = append(b.data, data...)
+}
+
+type body struct {
+	Body string `json:"body,omitempty"`
+	// BodyBase64 is used when the body is not a valid UTF-8 string
+	BodyBase64    string `json:"body_base64,omitempty"`
+	BodyTruncated bool   `json:"body_truncated,omitempty"`
+}
+
+func newBody(b *bodyBuffer) body {
+	if utf8.Valid(b.data) {
+		return body{Body: string(b.data), BodyTruncated: b.truncated}
+	}
+	return body{BodyBase64: base64.StdEncoding.EncodeToString(b.data), BodyTruncated: b.truncated}
+}
+
+type requestInfo struct {
+	Method   string            `json:"method"`
+	Host     string            `json:"host"`
+	Path     string            `json:"path"`
+	Protocol string            `json:"protocol,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	body
+}
+
+type responseInfo struct {
+	Code    uint32            `json:"code,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	body
+}
+
+type upstreamInfo struct {
+	Address string `json:"address,omitempty"`
+	Cluster string `json:"cluster,omitempty"`
+}
+
+type event struct {
+	ID         string        `json:"id,omitempty"`
+	StartTime  string        `json:"start_time,omitempty"`
+	DurationMs int64         `json:"duration_ms"`
+	Route      string        `json:"route,omitempty"`
+	Consumer   string        `json:"consumer,omitempty"`
+	SourceIP   string        `json:"source_ip,omitempty"`
+	Request    requestInfo   `json:"request"`
+	Response   responseInfo  `json:"response"`
+	Upstream   *upstreamInfo `json:"upstream,omitempty"`
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	conf := f.config
+	f.start = time.Now()
+	if conf.key != nil {
+		f.key = conf.key.Render(headers, f.callbacks)
+	}
+	if s := conf.BodySampling; s != nil {
+		f.sampled = s.Percentage >= 100 || rand.Intn(100) < int(s.Percentage)
+	}
+	return api.Continue
+}
+
+func (f *filter) DecodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	if f.sampled && f.config.BodySampling.Request {
+		f.reqBody.append(data.Bytes(), f.config.maxBodySize)
+	}
+	return api.Continue
+}
+
+func (f *filter) EncodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	if f.sampled && f.config.BodySampling.Response {
+		f.respBody.append(data.Bytes(), f.config.maxBodySize)
+	}
+	return api.Continue
+}
+
+func pickHeaders(headers api.HeaderMap, names []string) map[string]string {
+	if headers == nil || len(names) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(names))
+	for _, name := range names {
+		if v, ok := headers.Get(name); ok {
+			res[name] = v
+		}
+	}
+	return res
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	if reqHeaders == nil || f.start.IsZero() {
+		return
+	}
+
+	conf := f.config
+	info := f.callbacks.StreamInfo()
+	ev := &event{
+		StartTime:  f.start.Format(time.RFC3339Nano),
+		DurationMs: time.Since(f.start).Milliseconds(),
+		Route:      info.GetRouteName(),
+		Request: requestInfo{
+			Method:  reqHeaders.Method(),
+			Host:    reqHeaders.Host(),
+			Path:    reqHeaders.Path(),
+			Headers: pickHeaders(reqHeaders, conf.requestHeaders),
+		},
+		Response: responseInfo{
+			Headers: pickHeaders(respHeaders, conf.responseHeaders),
+		},
+	}
+	ev.ID, _ = reqHeaders.Get("x-request-id")
+	if c := f.callbacks.GetConsumer(); c != nil {
+		ev.Consumer = c.Name()
+	}
+	if addr := info.DownstreamRemoteParsedAddress(); addr != nil {
+		ev.SourceIP = addr.IP
+	}
+	ev.Request.Protocol, _ = info.Protocol()
+	ev.Response.Code, _ = info.ResponseCode()
+	addr, _ := info.UpstreamRemoteAddress()
+	cluster, _ := info.UpstreamClusterName()
+	if addr != "" || cluster != "" {
+		ev.Upstream = &upstreamInfo{Address: addr, Cluster: cluster}
+	}
+	if f.sampled {
+		if conf.BodySampling.Request {
+			ev.Request.body = newBody(&f.reqBody)
+		}
+		if conf.BodySampling.Response {
+			ev.Response.body = newBody(&f.respBody)
+		}
+	}
+
+	value, err := json.Marshal(ev)
+	if err != nil {
+		api.LogErrorf("failed to marshal event: %v", err)
+		return
+	}
+	r := kafka.Record{Value: json.RawMessage(value)}
+	if f.key != "" {
+		r.Key = f.key
+	}
+	conf.publisher.publish(r)
+}
@@ -0,0 +1,141 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaevent
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type streamInfo struct {
+	*envoy.StreamInfo
+}
+
+func (i *streamInfo) ResponseCode() (uint32, bool) {
+	return 200, true
+}
+
+func (i *streamInfo) UpstreamClusterName() (string, bool) {
+	return "backend", true
+}
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func publishEvent(t *testing.T, input string, reqBody []string, respBody string) (any, map[string]any) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+	require.NoError(t, conf.Validate())
+	require.NoError(t, conf.Init(nil))
+	conf.publisher.close()
+	p := &fakeProducer{}
+	conf.publisher = newPublisher(p, conf.Topic, 10, 1, time.Hour, 0, false)
+
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetStreamInfo(&streamInfo{StreamInfo: &envoy.StreamInfo{}})
+	cb.SetConsumer(&testConsumer{name: "alice"})
+	f := factory(conf, cb)
+	hdr := envoy.NewRequestHeaderMap(http.Header{
+		":authority":   {"test.local"},
+		":method":      {"POST"},
+		":path":        {"/echo"},
+		"X-Request-Id": {"id"},
+		"X-Tenant":     {"a"},
+	})
+	f.DecodeHeaders(hdr, false)
+	for i, data := range reqBody {
+		f.DecodeData(envoy.NewBufferInstance([]byte(data)), i == len(reqBody)-1)
+	}
+	respHdr := envoy.NewResponseHeaderMap(http.Header{
+		":status":      {"200"},
+		"Content-Type": {"application/json"},
+	})
+	f.EncodeHeaders(respHdr, false)
+	f.EncodeData(envoy.NewBufferInstance([]byte(respBody)), true)
+	f.OnLog(hdr, nil, respHdr, nil)
+
+	conf.publisher.close()
+	require.Len(t, p.batches, 1)
+	require.Len(t, p.batches[0], 1)
+	r := p.batches[0][0]
+	var ev map[string]any
+	require.NoError(t, json.Unmarshal(r.Value.(json.RawMessage), &ev))
+	return r.Key, ev
+}
+
+func TestEvent(t *testing.T) {
+	key, ev := publishEvent(t, `{"restProxyUrl":"http://kafka-rest.local:8082","topic":"events"}`,
+		[]string{`{"a":1}`}, `{"b":2}`)
+	assert.Nil(t, key)
+	start := ev["start_time"].(string)
+	_, err := time.Parse(time.RFC3339Nano, start)
+	assert.NoError(t, err)
+	delete(ev, "start_time")
+	assert.IsType(t, float64(0), ev["duration_ms"])
+	delete(ev, "duration_ms")
+	assert.Equal(t, map[string]any{
+		"id":        "id",
+		"consumer":  "alice",
+		"source_ip": "183.128.130.43",
+		"request": map[string]any{
+			"method": "POST",
+			"host":   "test.local",
+			"path":   "/echo",
+		},
+		"response": map[string]any{
+			"code": float64(200),
+		},
+		"upstream": map[string]any{
+			"cluster": "backend",
+		},
+	}, ev)
+}
+
+func TestEventWithHeadersAndBodies(t *testing.T) {
+	key, ev := publishEvent(t, `{"restProxyUrl":"http://kafka-rest.local:8082","topic":"events","key":"${consumer.name}","requestHeaders":["X-Tenant","x-missing"],"responseHeaders":["content-type"],"bodySampling":{"percentage":100,"maxSize":8,"request":true,"response":true}}`,
+		[]string{`{"a":`, `1234}`}, "\xff\xfe")
+	assert.Equal(t, "alice", key)
+	assert.Equal(t, map[string]any{
+		"method":         "POST",
+		"host":           "test.local",
+		"path":           "/echo",
+		"headers":        map[string]any{"x-tenant": "a"},
+		"body":           `{"a":123`,
+		"body_truncated": true,
+	}, ev["request"])
+	assert.Equal(t, map[string]any{
+		"code":        float64(200),
+		"headers":     map[string]any{"content-type": "application/json"},
+		"body_base64": "//4=",
+	}, ev["response"])
+}
@@ -0,0 +1,92 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaevent
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+type topicStats struct {
+	published atomic.Uint64
+	dropped   atomic.Uint64
+	failed    atomic.Uint64
+}
+
+// The stats are kept across the configuration updates, so the counters are monotonic
+var statsByTopic sync.Map
+
+func getTopicStats(topic string) *topicStats {
+	v, ok := statsByTopic.Load(topic)
+	if !ok {
+		v, _ = statsByTopic.LoadOrStore(topic, &topicStats{})
+	}
+	return v.(*topicStats)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func writeMetrics(w io.Writer) error {
+	type entry struct {
+		topic string
+		stats *topicStats
+	}
+	var list []entry
+	statsByTopic.Range(func(k, v any) bool {
+		list = append(list, entry{topic: k.(string), stats: v.(*topicStats)})
+		return true
+	})
+	if len(list) == 0 {
+		return nil
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].topic < list[j].topic
+	})
+
+	bw := bufio.NewWriter(w)
+	for _, m := range []struct {
+		name  string
+		help  string
+		value func(s *topicStats) uint64
+	}{
+		{
+			name:  "htnn_kafka_event_published_total",
+			help:  "Number of events published to Kafka.",
+			value: func(s *topicStats) uint64 { return s.published.Load() },
+		},
+		{
+			name:  "htnn_kafka_event_dropped_total",
+			help:  "Number of events dropped because the queue is full.",
+			value: func(s *topicStats) uint64 { return s.dropped.Load() },
+		},
+		{
+			name:  "htnn_kafka_event_failed_total",
+			help:  "Number of events failed to be published after retries.",
+			value: func(s *topicStats) uint64 { return s.failed.Load() },
+		},
+	} {
+		fmt.Fprintf(bw, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(bw, "# TYPE %s counter\n", m.name)
+		for _, e := range list {
+			fmt.Fprintf(bw, "%s{topic=\"%s\"} %d\n", m.name, labelValueEscaper.Replace(e.topic), m.value(e.stats))
+		}
+	}
+	return bw.Flush()
+}
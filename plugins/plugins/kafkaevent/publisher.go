@@ -0,0 +1,183 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaevent
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/kafka"
+)
+
+const (
+	retryBaseInterval = 100 * time.Millisecond
+	retryMaxInterval  = 5 * time.Second
+
+	// the failures are logged at most once in this interval, to avoid flooding the error log
+	errorLogInterval = 10 * time.Second
+)
+
+type producer interface {
+	Produce(records []kafka.Record) error
+	Close()
+}
+
+// publisher sends the events asynchronously in batches. When the queue is full, either the new
+// event or the oldest event in the queue is dropped, so that the requests are never blocked.
+type publisher struct {
+	producer      producer
+	batchSize     int
+	flushInterval time.Duration
+	maxRetries    int
+	dropOldest    bool
+	stats         *topicStats
+
+	queue    chan kafka.Record
+	done     chan struct{}
+	stopOnce sync.Once
+
+	// the fields below are only accessed in the publisher's goroutine
+	lastErrorLog time.Time
+	failures     int
+}
+
+func newPublisher(p producer, topic string, queueSize int, batchSize int, flushInterval time.Duration,
+	maxRetries int, dropOldest bool) *publisher {
+
+	pub := &publisher{
+		producer:      p,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		maxRetries:    maxRetries,
+		dropOldest:    dropOldest,
+		stats:         getTopicStats(topic),
+		queue:         make(chan kafka.Record, queueSize),
+		done:          make(chan struct{}),
+	}
+	go pub.run()
+	return pub
+}
+
+// publish enqueues the event. It never blocks.
+func (p *publisher) publish(r kafka.Record) {
+	for {
+		select {
+		case p.queue <- r:
+			return
+		default:
+		}
+
+		if !p.dropOldest {
+			p.stats.dropped.Add(1)
+			return
+		}
+		select {
+		case <-p.queue:
+			p.stats.dropped.Add(1)
+		default:
+			// the queue is drained by the publisher's goroutine, try again
+		}
+	}
+}
+
+// stop stops the publisher once the pending events are sent, without waiting for it. The publish
+// method should not be called after it.
+func (p *publisher) stop() {
+	p.stopOnce.Do(func() {
+		close(p.queue)
+	})
+}
+
+// close is like stop, but waits until the pending events are sent.
+func (p *publisher) close() {
+	p.stop()
+	<-p.done
+}
+
+func (p *publisher) run() {
+	defer close(p.done)
+	defer p.producer.Close()
+
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]kafka.Record, 0, p.batchSize)
+	for {
+		select {
+		case r, ok := <-p.queue:
+			if !ok {
+				p.send(batch)
+				return
+			}
+			batch = append(batch, r)
+			if len(batch) >= p.batchSize {
+				p.send(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			p.send(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func retryInterval(attempt int) time.Duration {
+	d := retryBaseInterval << attempt
+	if d > retryMaxInterval || d <= 0 {
+		return retryMaxInterval
+	}
+	return d
+}
+
+func (p *publisher) send(batch []kafka.Record) {
+	if len(batch) == 0 {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			p.onFailure(len(batch), fmt.Errorf("panic: %v", r))
+		}
+	}()
+
+	for attempt := 0; ; attempt++ {
+		err := p.producer.Produce(batch)
+		if err == nil {
+			p.stats.published.Add(uint64(len(batch)))
+			return
+		}
+		if attempt >= p.maxRetries {
+			p.onFailure(len(batch), err)
+			return
+		}
+		api.LogDebugf("failed to publish events, retry later: %v", err)
+		time.Sleep(retryInterval(attempt))
+	}
+}
+
+func (p *publisher) onFailure(n int, err error) {
+	p.stats.failed.Add(uint64(n))
+	p.failures += n
+
+	now := time.Now()
+	if now.Sub(p.lastErrorLog) < errorLogInterval {
+		return
+	}
+	api.LogErrorf("failed to publish %d events, last error: %v", p.failures, err)
+	p.lastErrorLog = now
+	p.failures = 0
+}
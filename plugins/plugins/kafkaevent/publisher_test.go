@@ -0,0 +1,154 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaevent
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/plugins/pkg/kafka"
+)
+
+type fakeProducer struct {
+	lock    sync.Mutex
+	batches [][]kafka.Record
+	// fail the first n calls
+	fail    int
+	calls   int
+	block   chan struct{}
+	blocked chan struct{}
+}
+
+func (p *fakeProducer) Produce(records []kafka.Record) error {
+	if p.block != nil {
+		p.blocked <- struct{}{}
+		<-p.block
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.calls++
+	if p.calls <= p.fail {
+		return errors.New("ouch")
+	}
+	p.batches = append(p.batches, append([]kafka.Record(nil), records...))
+	return nil
+}
+
+func (p *fakeProducer) Close() {}
+
+func values(batches [][]kafka.Record) [][]any {
+	var res [][]any
+	for _, b := range batches {
+		var vs []any
+		for _, r := range b {
+			vs = append(vs, r.Value)
+		}
+		res = append(res, vs)
+	}
+	return res
+}
+
+func TestPublisherBatch(t *testing.T) {
+	p := &fakeProducer{}
+	pub := newPublisher(p, "batch", 10, 2, time.Hour, 0, false)
+	for i := 0; i < 3; i++ {
+		pub.publish(kafka.Record{Value: i})
+	}
+	pub.close()
+	assert.Equal(t, [][]any{{0, 1}, {2}}, values(p.batches))
+	assert.Equal(t, uint64(3), getTopicStats("batch").published.Load())
+
+	var buf bytes.Buffer
+	require.NoError(t, writeMetrics(&buf))
+	assert.Contains(t, buf.String(), "# TYPE htnn_kafka_event_published_total counter\n")
+	assert.Contains(t, buf.String(), "htnn_kafka_event_published_total{topic=\"batch\"} 3\n")
+}
+
+func TestPublisherFlushInterval(t *testing.T) {
+	p := &fakeProducer{}
+	pub := newPublisher(p, "flush", 10, 100, 10*time.Millisecond, 0, false)
+	defer pub.close()
+	pub.publish(kafka.Record{Value: 1})
+	assert.Eventually(t, func() bool {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		return len(p.batches) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPublisherRetry(t *testing.T) {
+	p := &fakeProducer{fail: 2}
+	pub := newPublisher(p, "retry", 10, 1, time.Hour, 2, false)
+	pub.publish(kafka.Record{Value: 1})
+	pub.close()
+	assert.Equal(t, 3, p.calls)
+	assert.Equal(t, [][]any{{1}}, values(p.batches))
+
+	p = &fakeProducer{fail: 2}
+	pub = newPublisher(p, "retry", 10, 1, time.Hour, 1, false)
+	pub.publish(kafka.Record{Value: 1})
+	pub.close()
+	assert.Equal(t, 2, p.calls)
+	assert.Empty(t, p.batches)
+	assert.Equal(t, uint64(1), getTopicStats("retry").failed.Load())
+}
+
+func TestPublisherOverflow(t *testing.T) {
+	for _, tt := range []struct {
+		topic      string
+		dropOldest bool
+		expected   [][]any
+	}{
+		{
+			topic:    "drop-newest",
+			expected: [][]any{{0}, {1}, {2}},
+		},
+		{
+			topic:      "drop-oldest",
+			dropOldest: true,
+			expected:   [][]any{{0}, {3}, {4}},
+		},
+	} {
+		t.Run(tt.topic, func(t *testing.T) {
+			p := &fakeProducer{block: make(chan struct{}), blocked: make(chan struct{}, 10)}
+			pub := newPublisher(p, tt.topic, 2, 1, time.Hour, 0, tt.dropOldest)
+			pub.publish(kafka.Record{Value: 0})
+			// wait until the first event is being sent
+			<-p.blocked
+			for i := 1; i < 5; i++ {
+				pub.publish(kafka.Record{Value: i})
+			}
+			assert.Equal(t, uint64(2), getTopicStats(tt.topic).dropped.Load())
+
+			close(p.block)
+			pub.close()
+			assert.Equal(t, tt.expected, values(p.batches))
+		})
+	}
+}
+
+func TestRetryInterval(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, retryInterval(0))
+	assert.Equal(t, 400*time.Millisecond, retryInterval(2))
+	assert.Equal(t, 5*time.Second, retryInterval(10))
+	assert.Equal(t, 5*time.Second, retryInterval(100))
+}
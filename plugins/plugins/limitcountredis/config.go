@@ -65,42 +65,49 @@ type config struct {
 }
 
 type Limiter struct {
-	script     expr.Script
-	count      uint32
-	timeWindow int64
-	prefix     string
+	script        expr.Script
+	count         uint32
+	timeWindow    int64
+	slidingWindow bool
+	prefix        string
 }
 
 func (conf *config) Init(cb api.ConfigCallbackHandler) error {
-	addr := conf.GetAddress()
-	if addr != "" {
-		opt := &redis.Options{
-			Addr:     addr,
-			Username: conf.Username,
-			Password: conf.Password,
-		}
-		if conf.Tls {
-			opt.TLSConfig = &tls.Config{
-				InsecureSkipVerify: conf.TlsSkipVerify,
-			}
+	var tlsConfig *tls.Config
+	if conf.Tls {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: conf.TlsSkipVerify,
 		}
+	}
 
-		conf.client = redis.NewClient(opt)
+	if addr := conf.GetAddress(); addr != "" {
+		conf.client = redis.NewClient(&redis.Options{
+			Addr:      addr,
+			Username:  conf.Username,
+			Password:  conf.Password,
+			TLSConfig: tlsConfig,
+		})
+
+	} else if sentinel := conf.GetSentinel(); sentinel != nil {
+		// The failover client is a normal client which always talks to the current master
+		conf.client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       sentinel.MasterName,
+			SentinelAddrs:    sentinel.Addresses,
+			SentinelUsername: sentinel.SentinelUsername,
+			SentinelPassword: sentinel.SentinelPassword,
+			Username:         conf.Username,
+			Password:         conf.Password,
+			TLSConfig:        tlsConfig,
+		})
 
 	} else {
 		cluster := conf.GetCluster()
-		opt := &redis.ClusterOptions{
-			Addrs:    cluster.Addresses,
-			Username: conf.Username,
-			Password: conf.Password,
-		}
-		if conf.Tls {
-			opt.TLSConfig = &tls.Config{
-				InsecureSkipVerify: conf.TlsSkipVerify,
-			}
-		}
-
-		conf.clusterClient = redis.NewClusterClient(opt)
+		conf.clusterClient = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     cluster.Addresses,
+			Username:  conf.Username,
+			Password:  conf.Password,
+			TLSConfig: tlsConfig,
+		})
 	}
 
 	prefix := conf.Prefix
@@ -110,9 +117,14 @@ func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 	quotaPolicy := make([]string, len(conf.Rules))
 	for i, rule := range conf.Rules {
 		conf.limiters[i] = &Limiter{
-			count:      rule.Count,
-			timeWindow: rule.TimeWindow.Seconds,
-			prefix:     fmt.Sprintf("%s|%d", prefix, i),
+			count:         rule.Count,
+			timeWindow:    rule.TimeWindow.Seconds,
+			slidingWindow: rule.SlidingWindow,
+			prefix:        fmt.Sprintf("%s|%d", prefix, i),
+		}
+		if rule.SlidingWindow {
+			// use a different key as the data structure is different
+			conf.limiters[i].prefix += "|sliding"
 		}
 		quotaPolicy[i] = fmt.Sprintf("%d;w=%d", rule.Count, rule.TimeWindow.Seconds)
 
@@ -62,6 +62,20 @@ func TestConfig(t *testing.T) {
 			input: `{"address":"127.0.0.1:6479", "prefix":"test", "rules":[{"count":1,"timeWindow":"1s"}], "username":"user"}`,
 			err:   "password is required when username is set",
 		},
+		{
+			name:  "sentinel",
+			input: `{"sentinel":{"addresses":["127.0.0.1:26379"]}, "prefix":"test", "rules":[{"count":1,"timeWindow":"1s"}]}`,
+			err:   "invalid Sentinel.MasterName",
+		},
+		{
+			name:  "invalid sentinel address",
+			input: `{"sentinel":{"addresses":["127.0.0.1"],"masterName":"mymaster"}, "prefix":"test", "rules":[{"count":1,"timeWindow":"1s"}]}`,
+			err:   "bad address 127.0.0.1",
+		},
+		{
+			name:  "pass with sentinel",
+			input: `{"sentinel":{"addresses":["127.0.0.1:26379"],"masterName":"mymaster"}, "prefix":"test", "rules":[{"count":1,"timeWindow":"1s","slidingWindow":true}]}`,
+		},
 		{
 			name:  "pass",
 			input: `{"address":"127.0.0.1:6479", "rules":[{"count":1,"timeWindow":"1s"}], "prefix":"test"}`,
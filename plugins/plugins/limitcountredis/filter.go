@@ -62,35 +62,51 @@ func (f *filter) getKey(script expr.Script, headers api.RequestHeaderMap) string
 }
 
 var (
+	// The sliding window is approximated with the counts of the current and the previous fixed
+	// windows, which are stored in a hash. The time of Redis is used so that the limiters in
+	// different Envoy instances share the same window.
 	redisScript = stringx.CutSpace(`
+	redis.replicate_commands()
 	local res={}
+	local now
 	for i=1,%d do
-		local ttl=redis.call('ttl',KEYS[i])
-		if ttl<0 then
-			redis.call('set',KEYS[i],ARGV[i*2-1]-1,'EX',ARGV[i*2])
-			res[i*2-1]=ARGV[i*2-1]-1
-			res[i*2]=tonumber(ARGV[i*2])
+		local count=tonumber(ARGV[i*3-2])
+		local window=tonumber(ARGV[i*3-1])
+		if ARGV[i*3]=='1' then
+			if not now then
+				local t=redis.call('time')
+				now=t[1]*1000+math.floor(t[2]/1000)
+			end
+			local w=window*1000
+			local cur=math.floor(now/w)
+			local elapsed=now-cur*w
+			local prev=tonumber(redis.call('hget',KEYS[i],cur-1) or 0)
+			local used=math.floor(prev*(w-elapsed)/w)+tonumber(redis.call('hget',KEYS[i],cur) or 0)
+			if used<count then
+				redis.call('hincrby',KEYS[i],cur,1)
+				redis.call('hdel',KEYS[i],cur-2)
+				redis.call('pexpire',KEYS[i],w*2)
+				res[i*2-1]=count-used-1
+			else
+				res[i*2-1]=-1
+			end
+			res[i*2]=math.ceil((w-elapsed)/1000)
 		else
-			res[i*2-1]=redis.call('incrby',KEYS[i],-1)
-			res[i*2]=ttl
+			local ttl=redis.call('ttl',KEYS[i])
+			if ttl<0 then
+				redis.call('set',KEYS[i],count-1,'EX',window)
+				res[i*2-1]=count-1
+				res[i*2]=window
+			else
+				res[i*2-1]=redis.call('incrby',KEYS[i],-1)
+				res[i*2]=ttl
+			end
 		end
 	end
 	return res
 	`)
 
-	redisSingleScript = stringx.CutSpace(`
-	local res={}
-	local ttl=redis.call('ttl',KEYS[1])
-	if ttl<0 then
-		redis.call('set',KEYS[1],ARGV[1]-1,'EX',ARGV[2])
-		res[1]=ARGV[1]-1
-		res[2]=tonumber(ARGV[2])
-	else
-		res[1]=redis.call('incrby',KEYS[1],-1)
-		res[2]=ttl
-	end
-	return res
-	`)
+	redisSingleScript = fmt.Sprintf(redisScript, 1)
 )
 
 func (f *filter) limitCountErr(err error) api.ResultAction {
@@ -112,15 +128,16 @@ func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api
 	config := f.config
 	n := len(config.limiters)
 	keys := make([]string, n)
-	args := make([]interface{}, n*2)
+	args := make([]interface{}, n*3)
 	for i, limiter := range config.limiters {
 		key := f.getKey(limiter.script, headers)
 		keys[i] = limiter.prefix + "|" + key
 
 		api.LogInfof("limitCountRedis filter, key: %s", key)
 
-		args[i*2] = limiter.count
-		args[i*2+1] = limiter.timeWindow
+		args[i*3] = limiter.count
+		args[i*3+1] = limiter.timeWindow
+		args[i*3+2] = limiter.slidingWindow
 	}
 
 	var ress []interface{}
@@ -131,7 +148,7 @@ func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api
 		// this will cause the key imbalence.
 		cmds, err := config.clusterClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
 			for i, k := range keys {
-				pipe.Eval(ctx, redisSingleScript, []string{k}, args[i*2], args[i*2+1])
+				pipe.Eval(ctx, redisSingleScript, []string{k}, args[i*3:i*3+3]...)
 			}
 			return nil
 		})
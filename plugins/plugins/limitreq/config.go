@@ -76,6 +76,9 @@ func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 		ttl += 1 * time.Second
 		conf.maxDelay = time.Second / (time.Duration(rps) * 2)
 	}
+	if conf.NoDelay {
+		conf.maxDelay = 0
+	}
 	loader := ttlcache.LoaderFunc[string, *rate.Limiter](
 		func(c *ttlcache.Cache[string, *rate.Limiter], key string) *ttlcache.Item[string, *rate.Limiter] {
 			bucket := rate.NewLimiter(limitRate, int(burst))
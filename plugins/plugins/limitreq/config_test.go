@@ -59,6 +59,11 @@ func TestConfig(t *testing.T) {
 			input:    `{"average":30, "period":"60s"}`,
 			maxDelay: 500 * time.Millisecond,
 		},
+		{
+			name:     "no delay",
+			input:    `{"average":10, "noDelay":true}`,
+			maxDelay: 0,
+		},
 	}
 
 	for _, tt := range tests {
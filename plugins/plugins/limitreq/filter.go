@@ -15,6 +15,9 @@
 package limitreq
 
 import (
+	"math"
+	"net/http"
+	"strconv"
 	"time"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
@@ -63,8 +66,21 @@ func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api
 
 	if delay > config.maxDelay {
 		res.Cancel()
-		return &api.LocalResponse{Code: 429}
+		return config.rejectResponse(delay)
 	}
 	time.Sleep(delay)
 	return api.Continue
 }
+
+// rejectResponse returns 429 with the rate limit headers. The delay is the time to wait until
+// the request can be accepted.
+// See https://datatracker.ietf.org/doc/draft-ietf-httpapi-ratelimit-headers/
+func (conf *config) rejectResponse(delay time.Duration) *api.LocalResponse {
+	reset := strconv.Itoa(int(math.Ceil(delay.Seconds())))
+	header := http.Header{}
+	header.Set("RateLimit-Limit", strconv.FormatUint(uint64(conf.Average), 10))
+	header.Set("RateLimit-Remaining", "0")
+	header.Set("RateLimit-Reset", reset)
+	header.Set("Retry-After", reset)
+	return &api.LocalResponse{Code: http.StatusTooManyRequests, Header: header}
+}
@@ -0,0 +1,49 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package limitreq
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestRejectWithRateLimitHeaders(t *testing.T) {
+	conf := &config{}
+	conf.Average = 2
+	conf.Period = durationpb.New(60 * time.Second)
+	conf.NoDelay = true
+	require.NoError(t, conf.Init(nil))
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{})
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	resp := f.DecodeHeaders(hdr, true).(*api.LocalResponse)
+	assert.Equal(t, 429, resp.Code)
+	assert.Equal(t, "2", resp.Header.Get("RateLimit-Limit"))
+	assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+	// one request is allowed per 30s
+	assert.Equal(t, "30", resp.Header.Get("RateLimit-Reset"))
+	assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+}
@@ -0,0 +1,94 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/maintenance"
+)
+
+const (
+	defaultAdminHeader = "x-htnn-maintenance"
+	defaultBody        = `{"message":"service is under maintenance"}`
+)
+
+func init() {
+	plugins.RegisterPlugin(maintenance.Name, &plugin{})
+}
+
+type plugin struct {
+	maintenance.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	maintenance.CustomConfig
+
+	// enabled can be switched by the admin requests. It's reset once the configuration is changed.
+	enabled     atomic.Bool
+	status      int
+	body        *interpolation.Template
+	contentType string
+	retryAfter  string
+
+	adminSecret []byte
+	adminHeader string
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.enabled.Store(conf.Enabled)
+	conf.status = 503
+	if conf.Status != 0 {
+		conf.status = int(conf.Status)
+	}
+
+	if conf.Body != "" {
+		// the template is already validated
+		conf.body = interpolation.MustCompile(conf.Body)
+		conf.contentType = "text/html; charset=utf-8"
+	} else {
+		conf.body = interpolation.MustCompile(defaultBody)
+		conf.contentType = "application/json"
+	}
+	if conf.ContentType != "" {
+		conf.contentType = conf.ContentType
+	}
+	if conf.RetryAfter != nil {
+		conf.retryAfter = strconv.Itoa(int(math.Ceil(conf.RetryAfter.AsDuration().Seconds())))
+	}
+
+	if admin := conf.Admin; admin != nil {
+		conf.adminSecret = []byte(admin.Secret)
+		conf.adminHeader = defaultAdminHeader
+		if admin.Header != "" {
+			conf.adminHeader = strings.ToLower(admin.Header)
+		}
+	}
+	return nil
+}
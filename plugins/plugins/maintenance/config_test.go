@@ -0,0 +1,70 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "ok",
+			input: `{"enabled":true,"status":503,"body":"<h1>${request.host} is under maintenance</h1>","retryAfter":"1.5s","admin":{"secret":"s"}}`,
+		},
+		{
+			name:  "empty",
+			input: `{}`,
+		},
+		{
+			name:  "bad body",
+			input: `{"body":"${request.unknown}"}`,
+			err:   "bad body",
+		},
+		{
+			name:  "bad retry after",
+			input: `{"retryAfter":"0s"}`,
+			err:   "invalid Config.RetryAfter: value must be greater than 0s",
+		},
+		{
+			name:  "secret is required",
+			input: `{"admin":{}}`,
+			err:   "invalid Admin.Secret: value length must be at least 1 runes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
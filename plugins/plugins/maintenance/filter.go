@@ -0,0 +1,105 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+// the admin request is rejected if its timestamp is out of this window, to limit the replay attack
+const maxClockSkew = 5 * time.Minute
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+// verifyAdminToken verifies the token in the format of `$action:$timestamp:$signature`, and returns
+// the action. The signature is the hex-encoded HMAC-SHA256 of `$action:$timestamp`.
+func (conf *config) verifyAdminToken(token string, now time.Time) (string, bool) {
+	i := strings.LastIndexByte(token, ':')
+	if i < 0 {
+		return "", false
+	}
+	payload, sig := token[:i], token[i+1:]
+	action, ts, ok := strings.Cut(payload, ":")
+	if !ok || (action != "on" && action != "off") {
+		return "", false
+	}
+	sec, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return "", false
+	}
+	if d := now.Sub(time.Unix(sec, 0)); d > maxClockSkew || d < -maxClockSkew {
+		return "", false
+	}
+
+	expected := hmac.New(sha256.New, conf.adminSecret)
+	expected.Write([]byte(payload))
+	actual, err := hex.DecodeString(sig)
+	if err != nil || !hmac.Equal(actual, expected.Sum(nil)) {
+		return "", false
+	}
+	return action, true
+}
+
+func (f *filter) switchMode(token string) api.ResultAction {
+	action, ok := f.config.verifyAdminToken(token, time.Now())
+	if !ok {
+		return &api.LocalResponse{Code: http.StatusForbidden, Msg: "invalid maintenance token"}
+	}
+
+	enabled := action == "on"
+	f.config.enabled.Store(enabled)
+	api.LogWarnf("maintenance: switch maintenance mode %s by admin request", action)
+	return &api.LocalResponse{Code: http.StatusOK, Msg: "maintenance " + action}
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	if config.adminSecret != nil {
+		if token, ok := headers.Get(config.adminHeader); ok {
+			return f.switchMode(token)
+		}
+	}
+
+	if !config.enabled.Load() {
+		return api.Continue
+	}
+
+	hdr := http.Header{}
+	hdr.Set("content-type", config.contentType)
+	if config.retryAfter != "" {
+		hdr.Set("retry-after", config.retryAfter)
+	}
+	return &api.LocalResponse{Code: config.status, Msg: config.body.Render(headers, f.callbacks), Header: hdr}
+}
@@ -0,0 +1,119 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package maintenance
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func sign(secret string, action string, ts time.Time) string {
+	payload := action + ":" + strconv.FormatInt(ts.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return payload + ":" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func send(conf *config, header http.Header) api.ResultAction {
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set(":authority", "example.com")
+	return f.DecodeHeaders(envoy.NewRequestHeaderMap(header), true)
+}
+
+func TestMaintenance(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"enabled":true}`), conf))
+	require.NoError(t, conf.Init(nil))
+	resp := send(conf, nil).(*api.LocalResponse)
+	assert.Equal(t, 503, resp.Code)
+	assert.Equal(t, `{"message":"service is under maintenance"}`, resp.Msg)
+	assert.Equal(t, "application/json", resp.Header.Get("content-type"))
+	assert.Equal(t, "", resp.Header.Get("retry-after"))
+
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"enabled":true,"status":200,"body":"<h1>${request.host} is under maintenance</h1>","retryAfter":"90.5s"}`), conf))
+	require.NoError(t, conf.Init(nil))
+	resp = send(conf, nil).(*api.LocalResponse)
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, "<h1>example.com is under maintenance</h1>", resp.Msg)
+	assert.Equal(t, "text/html; charset=utf-8", resp.Header.Get("content-type"))
+	assert.Equal(t, "91", resp.Header.Get("retry-after"))
+
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"enabled":true,"body":"down","contentType":"text/plain"}`), conf))
+	require.NoError(t, conf.Init(nil))
+	resp = send(conf, nil).(*api.LocalResponse)
+	assert.Equal(t, "text/plain", resp.Header.Get("content-type"))
+
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{}`), conf))
+	require.NoError(t, conf.Init(nil))
+	assert.Equal(t, api.Continue, send(conf, nil))
+}
+
+func TestAdmin(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"admin":{"secret":"s","header":"X-Admin"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	assert.Equal(t, api.Continue, send(conf, nil))
+
+	now := time.Now()
+	resp := send(conf, http.Header{"X-Admin": {sign("s", "on", now)}}).(*api.LocalResponse)
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, "maintenance on", resp.Msg)
+	resp = send(conf, nil).(*api.LocalResponse)
+	assert.Equal(t, 503, resp.Code)
+
+	resp = send(conf, http.Header{"X-Admin": {sign("s", "off", now)}}).(*api.LocalResponse)
+	assert.Equal(t, "maintenance off", resp.Msg)
+	assert.Equal(t, api.Continue, send(conf, nil))
+
+	invalid := []string{
+		sign("x", "on", now),
+		sign("s", "on", now.Add(-10*time.Minute)),
+		sign("s", "on", now.Add(10*time.Minute)),
+		sign("s", "pause", now),
+		"on:1",
+		"on",
+		"on:abc:def",
+	}
+	for _, token := range invalid {
+		resp = send(conf, http.Header{"X-Admin": {token}}).(*api.LocalResponse)
+		assert.Equal(t, 403, resp.Code, token)
+		assert.Equal(t, "invalid maintenance token", resp.Msg)
+	}
+	assert.Equal(t, api.Continue, send(conf, nil))
+
+	// the header is not special without admin
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{}`), conf))
+	require.NoError(t, conf.Init(nil))
+	assert.Equal(t, api.Continue, send(conf, http.Header{"X-Htnn-Maintenance": {sign("s", "on", now)}}))
+}
@@ -0,0 +1,77 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package methodoverride
+
+import (
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/methodoverride"
+)
+
+const (
+	defaultHeader = "x-http-method-override"
+)
+
+func init() {
+	plugins.RegisterPlugin(methodoverride.Name, &plugin{})
+}
+
+type plugin struct {
+	methodoverride.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	methodoverride.CustomConfig
+
+	header          string
+	overrideMethods map[string]bool
+	allowedMethods  map[string]bool
+	// allow is the value of the Allow header in the 405 response
+	allow string
+}
+
+func toSet(methods []string) map[string]bool {
+	set := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		set[m] = true
+	}
+	return set
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if o := conf.Override; o != nil {
+		conf.header = defaultHeader
+		if o.Header != "" {
+			conf.header = strings.ToLower(o.Header)
+		}
+		conf.overrideMethods = toSet(o.Methods)
+	}
+
+	if len(conf.AllowedMethods) > 0 {
+		conf.allowedMethods = toSet(conf.AllowedMethods)
+		conf.allow = strings.Join(conf.AllowedMethods, ", ")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package methodoverride
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+			err:   "either override or allowedMethods should be specified",
+		},
+		{
+			name:  "override without methods",
+			input: `{"override":{}}`,
+			err:   "invalid Override.Methods: value must contain at least 1 item(s)",
+		},
+		{
+			name:  "bad override method",
+			input: `{"override":{"methods":["delete"]}}`,
+			err:   "invalid Override.Methods[0]: value does not match regex pattern",
+		},
+		{
+			name:  "bad allowed method",
+			input: `{"allowedMethods":["GET", "post"]}`,
+			err:   "invalid Config.AllowedMethods[1]: value does not match regex pattern",
+		},
+		{
+			name:  "ok",
+			input: `{"override":{"header":"X-Method","methods":["PUT","DELETE"]},"allowedMethods":["GET","PUT"]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
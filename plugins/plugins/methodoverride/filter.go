@@ -0,0 +1,74 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package methodoverride
+
+import (
+	"net/http"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/methodoverride"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	method := headers.Method()
+
+	if config.overrideMethods != nil {
+		value, found := headers.Get(config.header)
+		if found {
+			// the header should not be interpreted again by the upstream
+			headers.Del(config.header)
+		}
+		// Only the POST requests are overridden, so that the safe methods can't be turned into the
+		// state-changing ones, which bypasses the protection like CSRF.
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if value != "" && method == http.MethodPost && value != method {
+			if !config.overrideMethods[value] {
+				api.LogInfof("methodOverride: method %s is not allowed to override", value)
+				return &api.LocalResponse{Code: 400, Msg: "method override not allowed"}
+			}
+
+			headers.Set(":method", value)
+			f.callbacks.PluginState().Set(methodoverride.Name, "original_method", method)
+			// the route may be matched by the method
+			f.callbacks.ClearRouteCache()
+			method = value
+		}
+	}
+
+	if config.allowedMethods != nil && !config.allowedMethods[method] {
+		return &api.LocalResponse{
+			Code:   405,
+			Msg:    "method not allowed",
+			Header: http.Header{"Allow": []string{config.allow}},
+		}
+	}
+	return api.Continue
+}
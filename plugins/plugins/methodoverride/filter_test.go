@@ -0,0 +1,130 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package methodoverride
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	"mosn.io/htnn/types/plugins/methodoverride"
+)
+
+func TestMethodOverride(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"override": {"methods": ["PUT", "DELETE"]},
+		"allowedMethods": ["GET", "POST", "PUT", "DELETE"]
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	tests := []struct {
+		name     string
+		method   string
+		override string
+		res      api.ResultAction
+		expected string
+	}{
+		{
+			name:     "override",
+			method:   "POST",
+			override: "delete",
+			res:      api.Continue,
+			expected: "DELETE",
+		},
+		{
+			name:     "no override",
+			method:   "POST",
+			res:      api.Continue,
+			expected: "POST",
+		},
+		{
+			name:     "only POST is overridden",
+			method:   "GET",
+			override: "DELETE",
+			res:      api.Continue,
+			expected: "GET",
+		},
+		{
+			name:     "method not in allowlist",
+			method:   "POST",
+			override: "PATCH",
+			res:      &api.LocalResponse{Code: 400, Msg: "method override not allowed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb)
+			h := http.Header{}
+			h.Set(":method", tt.method)
+			if tt.override != "" {
+				h.Set("X-HTTP-Method-Override", tt.override)
+			}
+			hdr := envoy.NewRequestHeaderMap(h)
+			res := f.DecodeHeaders(hdr, true)
+			assert.Equal(t, tt.res, res)
+			if tt.res != api.Continue {
+				return
+			}
+
+			assert.Equal(t, tt.expected, hdr.Method())
+			_, found := hdr.Get("x-http-method-override")
+			assert.False(t, found)
+			original := cb.PluginState().Get(methodoverride.Name, "original_method")
+			if tt.expected != tt.method {
+				assert.Equal(t, tt.method, original)
+			} else {
+				assert.Nil(t, original)
+			}
+		})
+	}
+}
+
+func TestAllowedMethods(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"override": {"header": "X-Method", "methods": ["PATCH"]},
+		"allowedMethods": ["GET", "POST"]
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	h := http.Header{}
+	h.Set(":method", "GET")
+	assert.Equal(t, api.Continue, f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true))
+
+	h.Set(":method", "DELETE")
+	res := f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+	assert.Equal(t, &api.LocalResponse{
+		Code:   405,
+		Msg:    "method not allowed",
+		Header: http.Header{"Allow": []string{"GET, POST"}},
+	}, res)
+
+	// the overridden method is checked
+	h.Set(":method", "POST")
+	h.Set("X-Method", "PATCH")
+	res = f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+	lr, ok := res.(*api.LocalResponse)
+	require.True(t, ok)
+	assert.Equal(t, 405, lr.Code)
+}
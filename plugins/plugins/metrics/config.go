@@ -0,0 +1,71 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/metrics"
+)
+
+const (
+	defaultMaxRoutes    = 100
+	defaultMaxConsumers = 100
+	defaultMaxTenants   = 100
+)
+
+func init() {
+	plugins.RegisterPlugin(metrics.Name, &plugin{})
+	filtermanager.RegisterMetrics(metrics.Name, writeMetrics)
+}
+
+type plugin struct {
+	metrics.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	metrics.Config
+
+	maxRoutes    int
+	maxConsumers int
+	maxTenants   int
+	registry     *registry
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.maxRoutes = defaultMaxRoutes
+	if conf.MaxRoutes > 0 {
+		conf.maxRoutes = int(conf.MaxRoutes)
+	}
+	conf.maxConsumers = defaultMaxConsumers
+	if conf.MaxConsumers > 0 {
+		conf.maxConsumers = int(conf.MaxConsumers)
+	}
+	conf.maxTenants = defaultMaxTenants
+	if conf.MaxTenants > 0 {
+		conf.maxTenants = int(conf.MaxTenants)
+	}
+	conf.registry = defaultRegistry
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "default",
+			input: `{}`,
+		},
+		{
+			name:  "limits",
+			input: `{"disableConsumerLabel":true,"maxRoutes":1000,"maxConsumers":10}`,
+		},
+		{
+			name:  "too many routes",
+			input: `{"maxRoutes":10001}`,
+			err:   "invalid Config.MaxRoutes: value must be less than or equal to 10000",
+		},
+		{
+			name:  "too many consumers",
+			input: `{"maxConsumers":10001}`,
+			err:   "invalid Config.MaxConsumers: value must be less than or equal to 10000",
+		},
+		{
+			name:  "too many tenants",
+			input: `{"enableTenantLabel":true,"maxTenants":10001}`,
+			err:   "invalid Config.MaxTenants: value must be less than or equal to 10000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/tenant"
+)
+
+// the methods not in the list are recorded as OTHER, to limit the cardinality
+var knownMethods = map[string]struct{}{
+	"GET":     {},
+	"HEAD":    {},
+	"POST":    {},
+	"PUT":     {},
+	"DELETE":  {},
+	"CONNECT": {},
+	"OPTIONS": {},
+	"TRACE":   {},
+	"PATCH":   {},
+}
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	start        time.Time
+	method       string
+	requestSize  int
+	responseSize int
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.start = time.Now()
+	f.method = headers.Method()
+	if _, ok := knownMethods[f.method]; !ok {
+		f.method = "OTHER"
+	}
+	return api.Continue
+}
+
+func (f *filter) DecodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	f.requestSize += data.Len()
+	return api.Continue
+}
+
+func (f *filter) EncodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	f.responseSize += data.Len()
+	return api.Continue
+}
+
+func statusClass(code uint32) string {
+	if code < 100 || code > 599 {
+		// the response is not sent, for example, the client disconnects
+		return "unknown"
+	}
+	return strconv.Itoa(int(code/100)) + "xx"
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	if f.start.IsZero() {
+		return
+	}
+
+	conf := f.config
+	info := f.callbacks.StreamInfo()
+	code, ok := info.ResponseCode()
+	if !ok && respHeaders != nil {
+		status, _ := respHeaders.Get(":status")
+		n, _ := strconv.Atoi(status)
+		code = uint32(n)
+	}
+
+	key := seriesKey{
+		route:       conf.registry.routes.get(info.GetRouteName(), conf.maxRoutes),
+		method:      f.method,
+		statusClass: statusClass(code),
+	}
+	if !conf.DisableConsumerLabel {
+		if c := f.callbacks.GetConsumer(); c != nil {
+			key.consumer = conf.registry.consumers.get(c.Name(), conf.maxConsumers)
+		}
+	}
+	if conf.EnableTenantLabel {
+		if id, ok := f.callbacks.PluginState().Get(tenant.Name, "id").(string); ok {
+			key.tenant = conf.registry.tenants.get(id, conf.maxTenants)
+		}
+	}
+	conf.registry.observe(&observation{
+		key:          key,
+		duration:     time.Since(f.start),
+		requestSize:  f.requestSize,
+		responseSize: f.responseSize,
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type streamInfo struct {
+	*envoy.StreamInfo
+
+	route string
+	code  uint32
+}
+
+func (i *streamInfo) GetRouteName() string {
+	return i.route
+}
+
+func (i *streamInfo) ResponseCode() (uint32, bool) {
+	return i.code, i.code != 0
+}
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func newConfig(t *testing.T, input string) *config {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+	require.NoError(t, conf.Init(nil))
+	// isolate the tests from each other
+	conf.registry = newRegistry()
+	return conf
+}
+
+type request struct {
+	route    string
+	method   string
+	code     uint32
+	consumer string
+	tenant   string
+	reqBody  string
+	respBody string
+}
+
+func run(conf *config, r *request) {
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetStreamInfo(&streamInfo{StreamInfo: &envoy.StreamInfo{}, route: r.route, code: r.code})
+	if r.consumer != "" {
+		cb.SetConsumer(&testConsumer{name: r.consumer})
+	}
+	if r.tenant != "" {
+		cb.PluginState().Set("tenant", "id", r.tenant)
+	}
+
+	f := factory(conf, cb)
+	hdrs := envoy.NewRequestHeaderMap(http.Header{
+		":authority": {"test.local"},
+		":method":    {r.method},
+		":path":      {"/"},
+	})
+	f.DecodeHeaders(hdrs, r.reqBody == "")
+	if r.reqBody != "" {
+		f.DecodeData(envoy.NewBufferInstance([]byte(r.reqBody)), true)
+	}
+	if r.respBody != "" {
+		f.EncodeData(envoy.NewBufferInstance([]byte(r.respBody)), true)
+	}
+	f.OnLog(hdrs, nil, nil, nil)
+}
+
+func TestMetrics(t *testing.T) {
+	conf := newConfig(t, `{}`)
+	run(conf, &request{route: "r1", method: "POST", code: 201, consumer: "alice", reqBody: "hello", respBody: "ok"})
+	run(conf, &request{route: "r1", method: "POST", code: 200, consumer: "alice"})
+	run(conf, &request{route: "r1", method: "PURGE", code: 503})
+
+	var buf bytes.Buffer
+	require.NoError(t, conf.registry.write(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "# TYPE htnn_requests_total counter\n")
+	assert.Contains(t, out, `htnn_requests_total{route="r1",method="POST",status_class="2xx",consumer="alice"} 2`+"\n")
+	assert.Contains(t, out, `htnn_requests_total{route="r1",method="OTHER",status_class="5xx",consumer=""} 1`+"\n")
+	assert.Contains(t, out, "# TYPE htnn_request_duration_seconds histogram\n")
+	assert.Contains(t, out, `htnn_request_duration_seconds_count{route="r1",method="POST",status_class="2xx",consumer="alice"} 2`+"\n")
+	assert.Contains(t, out, `htnn_request_size_bytes_bucket{route="r1",method="POST",status_class="2xx",consumer="alice",le="100"} 2`+"\n")
+	assert.Contains(t, out, `htnn_request_size_bytes_sum{route="r1",method="POST",status_class="2xx",consumer="alice"} 5`+"\n")
+	assert.Contains(t, out, `htnn_response_size_bytes_sum{route="r1",method="POST",status_class="2xx",consumer="alice"} 2`+"\n")
+}
+
+func TestMetricsCardinality(t *testing.T) {
+	conf := newConfig(t, `{"maxRoutes":1,"maxConsumers":1}`)
+	run(conf, &request{route: "r1", method: "GET", code: 200, consumer: "alice"})
+	run(conf, &request{route: "r2", method: "GET", code: 200, consumer: "bob"})
+	run(conf, &request{route: "r1", method: "GET", code: 200, consumer: "alice"})
+
+	var buf bytes.Buffer
+	require.NoError(t, conf.registry.write(&buf))
+	out := buf.String()
+	assert.Contains(t, out, `htnn_requests_total{route="r1",method="GET",status_class="2xx",consumer="alice"} 2`+"\n")
+	assert.Contains(t, out, `htnn_requests_total{route="__other__",method="GET",status_class="2xx",consumer="__other__"} 1`+"\n")
+}
+
+func TestMetricsWithoutConsumerLabel(t *testing.T) {
+	conf := newConfig(t, `{"disableConsumerLabel":true}`)
+	run(conf, &request{route: "r1", method: "GET", consumer: "alice"})
+
+	var buf bytes.Buffer
+	require.NoError(t, conf.registry.write(&buf))
+	assert.Contains(t, buf.String(), `htnn_requests_total{route="r1",method="GET",status_class="unknown",consumer=""} 1`+"\n")
+}
+
+func TestMetricsWithTenantLabel(t *testing.T) {
+	conf := newConfig(t, `{"enableTenantLabel":true,"maxTenants":1}`)
+	run(conf, &request{route: "r1", method: "GET", tenant: "acme"})
+	run(conf, &request{route: "r1", method: "GET", tenant: "umbrella"})
+	run(conf, &request{route: "r1", method: "GET"})
+
+	var buf bytes.Buffer
+	require.NoError(t, conf.registry.write(&buf))
+	out := buf.String()
+	assert.Contains(t, out, `htnn_requests_total{route="r1",method="GET",status_class="unknown",consumer="",tenant="acme"} 1`+"\n")
+	assert.Contains(t, out, `htnn_requests_total{route="r1",method="GET",status_class="unknown",consumer="",tenant="__other__"} 1`+"\n")
+	assert.Contains(t, out, `htnn_requests_total{route="r1",method="GET",status_class="unknown",consumer=""} 1`+"\n")
+	assert.Contains(t, out, `htnn_request_size_bytes_bucket{route="r1",method="GET",status_class="unknown",consumer="",tenant="acme",le="100"} 1`+"\n")
+}
+
+func TestHistogram(t *testing.T) {
+	h := newHistogram([]float64{1, 10})
+	h.observe(0.5)
+	h.observe(1)
+	h.observe(5)
+	h.observe(100)
+
+	var buf bytes.Buffer
+	bw := bufio.NewWriter(&buf)
+	h.write(bw, "m", `a="b"`)
+	require.NoError(t, bw.Flush())
+	assert.Equal(t, `m_bucket{a="b",le="1"} 2
+m_bucket{a="b",le="10"} 3
+m_bucket{a="b",le="+Inf"} 4
+m_sum{a="b"} 106.5
+m_count{a="b"} 4
+`, buf.String())
+}
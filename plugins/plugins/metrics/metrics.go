@@ -0,0 +1,271 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// overflowLabel is used when the number of the label values exceeds the limit
+	overflowLabel = "__other__"
+)
+
+var (
+	durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	sizeBuckets     = []float64{100, 1000, 10000, 100000, 1000000, 10000000}
+)
+
+type histogram struct {
+	buckets []float64
+	// counts[i] is the number of observations which are less than or equal to buckets[i],
+	// and the last one is for +Inf
+	counts []atomic.Uint64
+	// sum is stored as the bits of float64
+	sum atomic.Uint64
+}
+
+func newHistogram(buckets []float64) *histogram {
+	return &histogram{
+		buckets: buckets,
+		counts:  make([]atomic.Uint64, len(buckets)+1),
+	}
+}
+
+func (h *histogram) observe(v float64) {
+	i := sort.SearchFloat64s(h.buckets, v)
+	h.counts[i].Add(1)
+	for {
+		old := h.sum.Load()
+		sum := math.Float64frombits(old) + v
+		if h.sum.CompareAndSwap(old, math.Float64bits(sum)) {
+			return
+		}
+	}
+}
+
+func (h *histogram) write(bw *bufio.Writer, name string, labels string) {
+	var cumulative uint64
+	for i, le := range h.buckets {
+		cumulative += h.counts[i].Load()
+		fmt.Fprintf(bw, "%s_bucket{%s,le=\"%s\"} %d\n", name, labels,
+			strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+	}
+	cumulative += h.counts[len(h.buckets)].Load()
+	fmt.Fprintf(bw, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, cumulative)
+	fmt.Fprintf(bw, "%s_sum{%s} %s\n", name, labels,
+		strconv.FormatFloat(math.Float64frombits(h.sum.Load()), 'g', -1, 64))
+	fmt.Fprintf(bw, "%s_count{%s} %d\n", name, labels, cumulative)
+}
+
+type seriesKey struct {
+	route       string
+	method      string
+	statusClass string
+	consumer    string
+	tenant      string
+}
+
+type series struct {
+	requests     atomic.Uint64
+	duration     *histogram
+	requestSize  *histogram
+	responseSize *histogram
+}
+
+func newSeries() *series {
+	return &series{
+		duration:     newHistogram(durationBuckets),
+		requestSize:  newHistogram(sizeBuckets),
+		responseSize: newHistogram(sizeBuckets),
+	}
+}
+
+// labelValues records the values of a label, to limit the cardinality
+type labelValues struct {
+	lock   sync.RWMutex
+	values map[string]struct{}
+}
+
+// get returns the given value if it's recorded or there is room for it, otherwise overflowLabel.
+func (l *labelValues) get(value string, max int) string {
+	l.lock.RLock()
+	_, ok := l.values[value]
+	full := len(l.values) >= max
+	l.lock.RUnlock()
+	if ok {
+		return value
+	}
+	if full {
+		return overflowLabel
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if _, ok := l.values[value]; !ok {
+		if len(l.values) >= max {
+			return overflowLabel
+		}
+		l.values[value] = struct{}{}
+	}
+	return value
+}
+
+// registry keeps the metrics across the configuration updates, so the counters are monotonic
+type registry struct {
+	routes    labelValues
+	consumers labelValues
+	tenants   labelValues
+
+	lock   sync.RWMutex
+	series map[seriesKey]*series
+}
+
+func newRegistry() *registry {
+	return &registry{
+		routes:    labelValues{values: map[string]struct{}{}},
+		consumers: labelValues{values: map[string]struct{}{}},
+		tenants:   labelValues{values: map[string]struct{}{}},
+		series:    map[seriesKey]*series{},
+	}
+}
+
+var defaultRegistry = newRegistry()
+
+func (r *registry) get(key seriesKey) *series {
+	r.lock.RLock()
+	s, ok := r.series[key]
+	r.lock.RUnlock()
+	if ok {
+		return s
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	s, ok = r.series[key]
+	if !ok {
+		s = newSeries()
+		r.series[key] = s
+	}
+	return s
+}
+
+type observation struct {
+	key          seriesKey
+	duration     time.Duration
+	requestSize  int
+	responseSize int
+}
+
+func (r *registry) observe(o *observation) {
+	s := r.get(o.key)
+	s.requests.Add(1)
+	s.duration.observe(o.duration.Seconds())
+	s.requestSize.observe(float64(o.requestSize))
+	s.responseSize.observe(float64(o.responseSize))
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func (r *registry) write(w io.Writer) error {
+	r.lock.RLock()
+	keys := make([]seriesKey, 0, len(r.series))
+	all := make(map[seriesKey]*series, len(r.series))
+	for k, s := range r.series {
+		keys = append(keys, k)
+		all[k] = s
+	}
+	r.lock.RUnlock()
+	if len(keys) == 0 {
+		return nil
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		if a.statusClass != b.statusClass {
+			return a.statusClass < b.statusClass
+		}
+		if a.consumer != b.consumer {
+			return a.consumer < b.consumer
+		}
+		return a.tenant < b.tenant
+	})
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		labels[i] = fmt.Sprintf(`route="%s",method="%s",status_class="%s",consumer="%s"`,
+			labelValueEscaper.Replace(k.route), k.method, k.statusClass,
+			labelValueEscaper.Replace(k.consumer))
+		if k.tenant != "" {
+			// the series without the tenant label are the same as the ones with an empty tenant
+			labels[i] += fmt.Sprintf(`,tenant="%s"`, labelValueEscaper.Replace(k.tenant))
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	name := "htnn_requests_total"
+	fmt.Fprintf(bw, "# HELP %s Number of requests.\n", name)
+	fmt.Fprintf(bw, "# TYPE %s counter\n", name)
+	for i, k := range keys {
+		fmt.Fprintf(bw, "%s{%s} %d\n", name, labels[i], all[k].requests.Load())
+	}
+
+	for _, m := range []struct {
+		name      string
+		help      string
+		histogram func(s *series) *histogram
+	}{
+		{
+			name:      "htnn_request_duration_seconds",
+			help:      "Time spent on the request, from receiving the request headers to the end of the stream.",
+			histogram: func(s *series) *histogram { return s.duration },
+		},
+		{
+			name:      "htnn_request_size_bytes",
+			help:      "Size of the request body.",
+			histogram: func(s *series) *histogram { return s.requestSize },
+		},
+		{
+			name:      "htnn_response_size_bytes",
+			help:      "Size of the response body.",
+			histogram: func(s *series) *histogram { return s.responseSize },
+		},
+	} {
+		fmt.Fprintf(bw, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(bw, "# TYPE %s histogram\n", m.name)
+		for i, k := range keys {
+			m.histogram(all[k]).write(bw, m.name, labels[i])
+		}
+	}
+	return bw.Flush()
+}
+
+func writeMetrics(w io.Writer) error {
+	return defaultRegistry.write(w)
+}
@@ -0,0 +1,80 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "host and port",
+			input: `{"cluster":"shadow.default:8080","percentage":10}`,
+		},
+		{
+			name:  "https",
+			input: `{"cluster":"https://shadow.default:8443/","percentage":100}`,
+		},
+		{
+			name:  "cluster is required",
+			input: `{"percentage":10}`,
+			err:   "invalid Config.Cluster: value length must be at least 1 runes",
+		},
+		{
+			name:  "percentage is required",
+			input: `{"cluster":"shadow.default:8080"}`,
+			err:   "invalid Config.Percentage: value must be inside range (0, 100]",
+		},
+		{
+			name:  "bad percentage",
+			input: `{"cluster":"shadow.default:8080","percentage":101}`,
+			err:   "invalid Config.Percentage: value must be inside range (0, 100]",
+		},
+		{
+			name:  "bad scheme",
+			input: `{"cluster":"grpc://shadow.default:8080","percentage":10}`,
+			err:   "bad cluster grpc://shadow.default:8080: unsupported scheme grpc",
+		},
+		{
+			name:  "path",
+			input: `{"cluster":"shadow.default:8080/prefix","percentage":10}`,
+			err:   "bad cluster shadow.default:8080/prefix: path is not allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
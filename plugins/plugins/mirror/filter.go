@@ -0,0 +1,135 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const (
+	mirroredHeader = "x-mirrored"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	headers api.RequestHeaderMap
+	body    []byte
+}
+
+// markedHeaders adds the marker header to the mirrored request, without changing the original one
+type markedHeaders struct {
+	api.RequestHeaderMap
+}
+
+func (h *markedHeaders) Range(f func(k, v string) bool) {
+	stopped := false
+	h.RequestHeaderMap.Range(func(k, v string) bool {
+		if strings.EqualFold(k, mirroredHeader) {
+			return true
+		}
+		if !f(k, v) {
+			stopped = true
+			return false
+		}
+		return true
+	})
+	if !stopped {
+		f(mirroredHeader, "true")
+	}
+}
+
+// collectedBody presents the collected body as the last piece of data
+type collectedBody struct {
+	api.BufferInstance
+
+	body []byte
+}
+
+func (b *collectedBody) Bytes() []byte {
+	return b.body
+}
+
+func (b *collectedBody) Len() int {
+	return len(b.body)
+}
+
+func (f *filter) mirror(headers api.RequestHeaderMap, body api.BufferInstance) {
+	f.callbacks.MirrorRequest(f.config.Cluster, &markedHeaders{headers}, body)
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	conf := f.config
+	if conf.Percentage < 100 && rand.Intn(100) >= int(conf.Percentage) {
+		return api.Continue
+	}
+	if endStream {
+		f.mirror(headers, nil)
+		return api.Continue
+	}
+
+	if cl, ok := headers.Get("content-length"); ok {
+		n, err := strconv.Atoi(cl)
+		if err == nil && n > conf.maxBodySize {
+			api.LogDebugf("skip mirroring the request as the body size %d exceeds the limit", n)
+			return api.Continue
+		}
+	}
+	// collect the body without blocking the original request
+	f.headers = headers
+	return api.Continue
+}
+
+func (f *filter) DecodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	if f.headers == nil {
+		return api.Continue
+	}
+
+	if len(f.body)+data.Len() > f.config.maxBodySize {
+		api.LogDebugf("skip mirroring the request as the body size exceeds the limit")
+		f.headers = nil
+		f.body = nil
+		return api.Continue
+	}
+	if endStream {
+		if f.body == nil {
+			// the whole body is in one piece
+			f.mirror(f.headers, data)
+		} else {
+			f.body = append(f.body, data.Bytes()...)
+			f.mirror(f.headers, &collectedBody{BufferInstance: data, body: f.body})
+		}
+		f.headers = nil
+		f.body = nil
+		return api.Continue
+	}
+	// copy the data, as the buffer will be reused by Envoy
+	f.body = append(f.body, data.Bytes()...)
+	return api.Continue
+}
@@ -0,0 +1,116 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func newHeaders(hdr http.Header) api.RequestHeaderMap {
+	h := http.Header{
+		":authority": {"test.local"},
+		":method":    {"POST"},
+		":path":      {"/users"},
+		"X-Mirrored": {"false"},
+	}
+	for k, v := range hdr {
+		h[k] = v
+	}
+	return envoy.NewRequestHeaderMap(h)
+}
+
+func TestMirror(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"cluster":"shadow.default:8080","percentage":100,"maxBodySize":10}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	// without body
+	cb := envoy.NewFilterCallbackHandler()
+	f := factory(conf, cb)
+	hdr := newHeaders(nil)
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+	reqs := cb.MirroredRequests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "shadow.default:8080", reqs[0].Address)
+	assert.Equal(t, []string{"true"}, reqs[0].Headers.Values("x-mirrored"))
+	assert.Equal(t, "/users", reqs[0].Headers.Get(":path"))
+	assert.Nil(t, reqs[0].Body)
+	// the original request is not changed
+	v, _ := hdr.Get("x-mirrored")
+	assert.Equal(t, "false", v)
+
+	// body in one piece
+	cb = envoy.NewFilterCallbackHandler()
+	f = factory(conf, cb)
+	hdr = newHeaders(nil)
+	assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, false))
+	assert.Empty(t, cb.MirroredRequests())
+	assert.Equal(t, api.Continue, f.DecodeData(envoy.NewBufferInstance([]byte("hello")), true))
+	reqs = cb.MirroredRequests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "hello", string(reqs[0].Body))
+
+	// body in multiple pieces
+	cb = envoy.NewFilterCallbackHandler()
+	f = factory(conf, cb)
+	assert.Equal(t, api.Continue, f.DecodeHeaders(newHeaders(nil), false))
+	buf := envoy.NewBufferInstance([]byte("hello"))
+	assert.Equal(t, api.Continue, f.DecodeData(buf, false))
+	// the buffer is reused
+	buf.SetString("world")
+	assert.Equal(t, api.Continue, f.DecodeData(buf, true))
+	reqs = cb.MirroredRequests()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "helloworld", string(reqs[0].Body))
+
+	// body too large
+	cb = envoy.NewFilterCallbackHandler()
+	f = factory(conf, cb)
+	assert.Equal(t, api.Continue, f.DecodeHeaders(newHeaders(nil), false))
+	assert.Equal(t, api.Continue, f.DecodeData(envoy.NewBufferInstance([]byte("hello")), false))
+	assert.Equal(t, api.Continue, f.DecodeData(envoy.NewBufferInstance([]byte("world!")), true))
+	assert.Empty(t, cb.MirroredRequests())
+
+	// content-length too large
+	cb = envoy.NewFilterCallbackHandler()
+	f = factory(conf, cb)
+	assert.Equal(t, api.Continue, f.DecodeHeaders(newHeaders(http.Header{"Content-Length": {"11"}}), false))
+	assert.Equal(t, api.Continue, f.DecodeData(envoy.NewBufferInstance([]byte(strings.Repeat("a", 11))), true))
+	assert.Empty(t, cb.MirroredRequests())
+}
+
+func TestMirrorSampling(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"cluster":"shadow.default:8080","percentage":30}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	mirrored := 0
+	for i := 0; i < 1000; i++ {
+		cb := envoy.NewFilterCallbackHandler()
+		f := factory(conf, cb)
+		f.DecodeHeaders(newHeaders(nil), true)
+		mirrored += len(cb.MirroredRequests())
+	}
+	assert.InDelta(t, 300, mirrored, 100)
+}
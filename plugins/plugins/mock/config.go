@@ -0,0 +1,78 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import (
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/mock"
+)
+
+func init() {
+	plugins.RegisterPlugin(mock.Name, &plugin{})
+}
+
+type plugin struct {
+	mock.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type header struct {
+	name  string
+	value *interpolation.Template
+}
+
+type config struct {
+	mock.CustomConfig
+
+	status  int
+	headers []*header
+	body    *interpolation.Template
+
+	minDelay time.Duration
+	maxDelay time.Duration
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.status = 200
+	if conf.Status != 0 {
+		conf.status = int(conf.Status)
+	}
+
+	// the templates are already validated
+	for _, hdr := range conf.Headers {
+		conf.headers = append(conf.headers, &header{
+			name:  hdr.Key,
+			value: interpolation.MustCompile(hdr.Value),
+		})
+	}
+	conf.body = interpolation.MustCompile(conf.Body)
+
+	if d := conf.Delay; d != nil {
+		conf.minDelay = d.Min.AsDuration()
+		conf.maxDelay = d.Max.AsDuration()
+	}
+	return nil
+}
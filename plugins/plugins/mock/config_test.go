@@ -0,0 +1,82 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+		},
+		{
+			name:  "bad header template",
+			input: `{"headers":[{"key":"x-a","value":"${header.a"}]}`,
+			err:   "bad header x-a",
+		},
+		{
+			name:  "bad body template",
+			input: `{"body":"${unknown}"}`,
+			err:   "bad body",
+		},
+		{
+			name:  "max delay required",
+			input: `{"delay":{"min":"1s"}}`,
+			err:   "invalid Delay.Max: value is required",
+		},
+		{
+			name:  "bad delay",
+			input: `{"delay":{"min":"2s","max":"1s"}}`,
+			err:   "delay min should not be greater than max",
+		},
+		{
+			name: "ok",
+			input: `{
+				"status": 503,
+				"headers": [{"key":"content-type","value":"text/html"}],
+				"body": "<p>${request.host} is under maintenance</p>",
+				"delay": {"min":"0.1s","max":"0.2s"}
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
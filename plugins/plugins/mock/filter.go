@@ -0,0 +1,59 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import (
+	"math/rand"
+	"net/http"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+// delay returns a random duration between the min and max delay
+func (conf *config) delay() time.Duration {
+	if conf.maxDelay == 0 {
+		return 0
+	}
+	return conf.minDelay + time.Duration(rand.Int63n(int64(conf.maxDelay-conf.minDelay)+1))
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	hdr := http.Header{}
+	for _, h := range config.headers {
+		hdr.Add(h.name, h.value.Render(headers, f.callbacks))
+	}
+	body := config.body.Render(headers, f.callbacks)
+
+	if d := config.delay(); d > 0 {
+		time.Sleep(d)
+	}
+	return &api.LocalResponse{Code: config.status, Msg: body, Header: hdr}
+}
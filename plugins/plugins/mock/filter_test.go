@@ -0,0 +1,100 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestMock(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		code   int
+		header http.Header
+		body   string
+	}{
+		{
+			name:   "default",
+			input:  `{}`,
+			code:   200,
+			header: http.Header{},
+		},
+		{
+			name: "templated",
+			input: `{
+				"status": 503,
+				"headers": [
+					{"key":"content-type","value":"application/json"},
+					{"key":"x-id","value":"${query.id}"}
+				],
+				"body": "{\"path\":\"${request.path}\",\"ip\":\"${source.ip}\",\"raw\":\"$${x}\"}"
+			}`,
+			code: 503,
+			header: http.Header{
+				"Content-Type": []string{"application/json"},
+				"X-Id":         []string{"1"},
+			},
+			body: `{"path":"/users?id=1","ip":"183.128.130.43","raw":"${x}"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			require.NoError(t, conf.Init(nil))
+			f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+			h := http.Header{}
+			h.Set(":path", "/users?id=1")
+			res := f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true)
+			resp := res.(*api.LocalResponse)
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Equal(t, tt.header, resp.Header)
+			assert.Equal(t, tt.body, resp.Msg)
+		})
+	}
+}
+
+func TestDelay(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"delay":{"min":"0.05s","max":"0.1s"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	for i := 0; i < 10; i++ {
+		d := conf.delay()
+		assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+		assert.LessOrEqual(t, d, 100*time.Millisecond)
+	}
+
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	start := time.Now()
+	f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"delay":{"max":"0.01s"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	d := conf.delay()
+	assert.LessOrEqual(t, d, 10*time.Millisecond)
+}
@@ -0,0 +1,108 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"net/http"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/pkg/expr"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+type claimRule struct {
+	claim   string
+	matcher expr.Matcher
+}
+
+func (r *claimRule) matches(claims *claimSet) bool {
+	v, ok := claims.lookup(r.claim)
+	if !ok {
+		return false
+	}
+
+	values, ok := v.([]interface{})
+	if !ok {
+		values = []interface{}{v}
+	}
+	for _, value := range values {
+		s, err := encodeClaim(value, oidctype.ClaimToHeader_PLAIN)
+		if err != nil {
+			continue
+		}
+		if r.matcher.Match(s) {
+			return true
+		}
+	}
+	return false
+}
+
+type authorizer struct {
+	deny  []*claimRule
+	allow []*claimRule
+}
+
+func newClaimRules(rules []*oidctype.ClaimRule) ([]*claimRule, error) {
+	res := make([]*claimRule, 0, len(rules))
+	for _, rule := range rules {
+		m, err := expr.BuildStringMatcher(rule.Value)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &claimRule{
+			claim:   rule.Claim,
+			matcher: m,
+		})
+	}
+	return res, nil
+}
+
+func newAuthorizer(conf *oidctype.Authorization) (*authorizer, error) {
+	deny, err := newClaimRules(conf.Deny)
+	if err != nil {
+		return nil, err
+	}
+	allow, err := newClaimRules(conf.Allow)
+	if err != nil {
+		return nil, err
+	}
+	return &authorizer{
+		deny:  deny,
+		allow: allow,
+	}, nil
+}
+
+func (a *authorizer) authorize(claims *claimSet) bool {
+	for _, rule := range a.deny {
+		if rule.matches(claims) {
+			api.LogInfof("denied by the rule of claim %s", rule.claim)
+			return false
+		}
+	}
+	if len(a.allow) == 0 {
+		return true
+	}
+	for _, rule := range a.allow {
+		if rule.matches(claims) {
+			return true
+		}
+	}
+	api.LogInfo("no allow rule matches")
+	return false
+}
+
+func (f *filter) deny() api.ResultAction {
+	return customResponse(f.config.Authorization.GetDeniedResponse(), http.StatusForbidden, "access denied")
+}
@@ -0,0 +1,176 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	apiv1 "mosn.io/htnn/types/plugins/api/v1"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+func TestAuthorize(t *testing.T) {
+	groups := func(g string) *oidctype.ClaimRule {
+		return &oidctype.ClaimRule{
+			Claim: "groups",
+			Value: &apiv1.StringMatcher{MatchPattern: &apiv1.StringMatcher_Exact{Exact: g}},
+		}
+	}
+	emailSuffix := &oidctype.ClaimRule{
+		Claim: "email",
+		Value: &apiv1.StringMatcher{MatchPattern: &apiv1.StringMatcher_Suffix{Suffix: "@corp.com"}},
+	}
+
+	tests := []struct {
+		name    string
+		authz   *oidctype.Authorization
+		token   string
+		allowed bool
+	}{
+		{
+			name:    "no rules",
+			authz:   &oidctype.Authorization{},
+			token:   `{"sub":"alice"}`,
+			allowed: true,
+		},
+		{
+			name:    "allow by array element",
+			authz:   &oidctype.Authorization{Allow: []*oidctype.ClaimRule{groups("admin")}},
+			token:   `{"groups":["dev","admin"]}`,
+			allowed: true,
+		},
+		{
+			name:  "not in allow list",
+			authz: &oidctype.Authorization{Allow: []*oidctype.ClaimRule{groups("admin")}},
+			token: `{"groups":["dev"]}`,
+		},
+		{
+			name:  "claim is missing",
+			authz: &oidctype.Authorization{Allow: []*oidctype.ClaimRule{groups("admin")}},
+			token: `{"sub":"alice"}`,
+		},
+		{
+			name:    "one of the allow rules matches",
+			authz:   &oidctype.Authorization{Allow: []*oidctype.ClaimRule{groups("admin"), emailSuffix}},
+			token:   `{"email":"alice@corp.com"}`,
+			allowed: true,
+		},
+		{
+			name: "deny takes precedence",
+			authz: &oidctype.Authorization{
+				Deny:  []*oidctype.ClaimRule{groups("intern")},
+				Allow: []*oidctype.ClaimRule{emailSuffix},
+			},
+			token: `{"email":"alice@corp.com","groups":["intern"]}`,
+		},
+		{
+			name:    "deny only",
+			authz:   &oidctype.Authorization{Deny: []*oidctype.ClaimRule{groups("intern")}},
+			token:   `{"groups":["dev"]}`,
+			allowed: true,
+		},
+		{
+			name: "non-string claim",
+			authz: &oidctype.Authorization{Allow: []*oidctype.ClaimRule{{
+				Claim: "email_verified",
+				Value: &apiv1.StringMatcher{MatchPattern: &apiv1.StringMatcher_Exact{Exact: "true"}},
+			}}},
+			token:   `{"email_verified":true}`,
+			allowed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := newAuthorizer(tt.authz)
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, a.authorize(newClaimSet(fakeJWT(tt.token), nil)))
+		})
+	}
+
+	_, err := newAuthorizer(&oidctype.Authorization{Deny: []*oidctype.ClaimRule{{
+		Claim: "email",
+		Value: &apiv1.StringMatcher{MatchPattern: &apiv1.StringMatcher_Regex{Regex: "("}},
+	}}})
+	assert.Error(t, err)
+}
+
+func TestAuthorizeRequest(t *testing.T) {
+	conf := getCfg()
+	conf.DisableAccessTokenRefresh = true
+	conf.Authorization = &oidctype.Authorization{
+		Allow: []*oidctype.ClaimRule{{
+			Claim: "groups",
+			Value: &apiv1.StringMatcher{MatchPattern: &apiv1.StringMatcher_Exact{Exact: "admin"}},
+		}},
+	}
+	a, err := newAuthorizer(conf.Authorization)
+	require.NoError(t, err)
+	conf.authorizer = a
+
+	attach := func(idToken string, userinfo string) api.ResultAction {
+		tokens := Tokens{
+			Oauth2Token: &oauth2.Token{
+				AccessToken: "accessToken",
+				Expiry:      time.Now().Add(time.Hour),
+			},
+			IDToken: fakeJWT(idToken),
+		}
+		if userinfo != "" {
+			tokens.UserInfo = []byte(userinfo)
+		}
+		token, _ := conf.cookieEncoding.Encode("htnn_oidc_token_id", tokens)
+		f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+		return f.attachInfo(envoy.NewRequestHeaderMap(http.Header{}), token)
+	}
+
+	assert.Equal(t, api.Continue, attach(`{"groups":["admin"]}`, ""))
+	// the userinfo takes precedence
+	assert.Equal(t, api.Continue, attach(`{"groups":["dev"]}`, `{"groups":["admin"]}`))
+	resp := attach(`{"groups":["dev"]}`, "").(*api.LocalResponse)
+	assert.Equal(t, 403, resp.Code)
+	assert.Equal(t, "access denied", resp.Msg)
+
+	conf.Authorization.DeniedResponse = &oidctype.CustomResponse{
+		Message:    "<p>Ask the admin for access</p>",
+		StatusCode: 401,
+		Headers:    map[string]string{"content-type": "text/html"},
+	}
+	resp = attach(`{"groups":["dev"]}`, "").(*api.LocalResponse)
+	assert.Equal(t, 401, resp.Code)
+	assert.Equal(t, "<p>Ask the admin for access</p>", resp.Msg)
+	assert.Equal(t, "text/html", resp.Header.Get("Content-Type"))
+
+	// bearer token
+	conf.BearerToken = &oidctype.BearerToken{}
+	conf.discovery().bearerVerifier = &oidc.IDTokenVerifier{}
+	patches := gomonkey.ApplyMethodReturn(conf.discovery().bearerVerifier, "Verify",
+		&oidc.IDToken{Audience: []string{conf.ClientId}}, nil)
+	defer patches.Reset()
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	resp = f.handleBearerToken(envoy.NewRequestHeaderMap(http.Header{}), fakeJWT(`{"groups":["dev"]}`)).(*api.LocalResponse)
+	assert.Equal(t, 401, resp.Code)
+	f = factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	assert.Equal(t, api.Continue, f.handleBearerToken(envoy.NewRequestHeaderMap(http.Header{}), fakeJWT(`{"groups":["admin"]}`)))
+}
@@ -0,0 +1,111 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+// See https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+const backchannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+type logoutTokenClaims struct {
+	SID    string                     `json:"sid"`
+	Nonce  *string                    `json:"nonce"`
+	Events map[string]json.RawMessage `json:"events"`
+}
+
+// getSID returns the session ID issued by the OIDC provider, or empty if the provider doesn't
+// issue it.
+func getSID(idToken *oidc.IDToken) string {
+	var claims struct {
+		SID string `json:"sid"`
+	}
+	_ = idToken.Claims(&claims)
+	return claims.SID
+}
+
+func badLogoutRequest(desc string) api.ResultAction {
+	api.LogInfof("bad backchannel logout request: %s", desc)
+	b, _ := json.Marshal(map[string]string{
+		"error":             "invalid_request",
+		"error_description": desc,
+	})
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Cache-Control", "no-store")
+	return &api.LocalResponse{Code: http.StatusBadRequest, Msg: string(b), Header: header}
+}
+
+// handleBackchannelLogout verifies the logout token sent by the OIDC provider, and revokes the
+// sessions identified by it.
+func (f *filter) handleBackchannelLogout(headers api.RequestHeaderMap, body []byte) api.ResultAction {
+	config := f.config
+	if headers.Method() != http.MethodPost {
+		return &api.LocalResponse{Code: http.StatusMethodNotAllowed}
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return badLogoutRequest("bad form")
+	}
+	rawToken := form.Get("logout_token")
+	if rawToken == "" {
+		return badLogoutRequest("logout_token is required")
+	}
+
+	ctx := config.ctxWithClient(context.Background())
+	token, err := f.discovery.verifier.Verify(ctx, rawToken)
+	if err != nil {
+		return badLogoutRequest("bad logout_token: " + err.Error())
+	}
+	claims := &logoutTokenClaims{}
+	err = token.Claims(claims)
+	if err != nil {
+		return badLogoutRequest("bad logout_token: " + err.Error())
+	}
+	if _, ok := claims.Events[backchannelLogoutEvent]; !ok {
+		return badLogoutRequest("bad logout_token: the backchannel logout event is missing")
+	}
+	if claims.Nonce != nil {
+		return badLogoutRequest("bad logout_token: nonce is not allowed")
+	}
+	if token.Subject == "" && claims.SID == "" {
+		return badLogoutRequest("bad logout_token: either sub or sid is required")
+	}
+
+	var revoked int
+	if claims.SID != "" {
+		revoked, err = config.sessionStore.RevokeSID(ctx, claims.SID)
+	} else {
+		revoked, err = config.sessionStore.RevokeSubject(ctx, token.Subject)
+	}
+	if err != nil {
+		api.LogErrorf("failed to revoke sessions: %v", err)
+		return badLogoutRequest("failed to revoke sessions")
+	}
+
+	api.LogInfof("revoked %d sessions by backchannel logout, sub: %s, sid: %s", revoked, token.Subject, claims.SID)
+	header := http.Header{}
+	header.Set("Cache-Control", "no-store")
+	return &api.LocalResponse{Code: http.StatusOK, Header: header}
+}
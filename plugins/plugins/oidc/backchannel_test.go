@@ -0,0 +1,171 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/stretchr/testify/assert"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestBackchannelLogout(t *testing.T) {
+	store := newMemorySessionStore()
+	conf := getCfg()
+	conf.sessionStore = store
+	conf.BackchannelLogoutPath = "/backchannel_logout"
+
+	event := `"events":{"http://schemas.openid.net/event/backchannel-logout":{}}`
+	tokens := map[string]string{
+		"sid":      `{"sid":"s1",` + event + `}`,
+		"sub":      `{` + event + `}`,
+		"no_event": `{"sid":"s1"}`,
+		"nonce":    `{"sid":"s1","nonce":"xxx",` + event + `}`,
+		"no_sid":   `{` + event + `}`,
+	}
+	patches := gomonkey.ApplyMethod(conf.discovery().verifier, "Verify",
+		func(_ *oidc.IDTokenVerifier, _ context.Context, raw string) (*oidc.IDToken, error) {
+			claims, ok := tokens[raw]
+			if !ok {
+				return nil, errors.New("invalid")
+			}
+			token := &oidc.IDToken{}
+			if raw == "sub" {
+				token.Subject = "bob"
+			}
+			// keep the claims in the issuer which is unused in the test
+			token.Issuer = claims
+			return token, nil
+		})
+	patches.ApplyMethod(&oidc.IDToken{}, "Claims", func(token *oidc.IDToken, v interface{}) error {
+		return json.Unmarshal([]byte(token.Issuer), v)
+	})
+	defer patches.Reset()
+
+	reset := func() {
+		expireAt := time.Now().Add(time.Hour)
+		store.sessions = map[string]*session{
+			"1": {Subject: "alice", SID: "s1", ExpireAt: expireAt},
+			"2": {Subject: "alice", SID: "s2", ExpireAt: expireAt},
+			"3": {Subject: "bob", SID: "s3", ExpireAt: expireAt},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		method    string
+		token     string
+		code      int
+		remaining []string
+	}{
+		{
+			name:      "by sid",
+			token:     "sid",
+			code:      200,
+			remaining: []string{"2", "3"},
+		},
+		{
+			name:      "by sub",
+			token:     "sub",
+			code:      200,
+			remaining: []string{"1", "2"},
+		},
+		{
+			name:   "bad method",
+			method: "GET",
+			token:  "sid",
+			code:   405,
+		},
+		{
+			name:  "no token",
+			code:  400,
+			token: "",
+		},
+		{
+			name:  "invalid token",
+			token: "invalid",
+			code:  400,
+		},
+		{
+			name:  "no event",
+			token: "no_event",
+			code:  400,
+		},
+		{
+			name:  "nonce is not allowed",
+			token: "nonce",
+			code:  400,
+		},
+		{
+			name:  "neither sub nor sid",
+			token: "no_sid",
+			code:  400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reset()
+			method := tt.method
+			if method == "" {
+				method = "POST"
+			}
+			f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+			h := http.Header{}
+			h.Set(":method", method)
+			h.Set(":path", "/backchannel_logout")
+			hdr := envoy.NewRequestHeaderMap(h)
+			assert.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+			body := url.Values{"logout_token": {tt.token}}.Encode()
+			resp := f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte(body)), nil).(*api.LocalResponse)
+			assert.Equal(t, tt.code, resp.Code, resp.Msg)
+			if tt.code == 200 {
+				assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+				ids := []string{}
+				for id := range store.sessions {
+					ids = append(ids, id)
+				}
+				assert.ElementsMatch(t, tt.remaining, ids)
+			} else {
+				assert.Len(t, store.sessions, 3)
+			}
+		})
+	}
+
+	// the store is unavailable
+	store.err = errors.New("ouch")
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	h := http.Header{}
+	h.Set(":method", "POST")
+	h.Set(":path", "/backchannel_logout")
+	hdr := envoy.NewRequestHeaderMap(h)
+	assert.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+	resp := f.DecodeRequest(hdr, envoy.NewBufferInstance([]byte("logout_token=sid")), nil).(*api.LocalResponse)
+	assert.Equal(t, 400, resp.Code)
+
+	// no body
+	f = factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	resp = f.DecodeHeaders(hdr, true).(*api.LocalResponse)
+	assert.Equal(t, 400, resp.Code)
+}
@@ -0,0 +1,116 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+// getBearerToken returns the token in the `Authorization: Bearer <token>` header
+func getBearerToken(headers api.RequestHeaderMap) (string, bool) {
+	auth, ok := headers.Get("authorization")
+	if !ok {
+		return "", false
+	}
+	scheme, token, found := strings.Cut(auth, " ")
+	if !found || !strings.EqualFold(scheme, "bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// bearerOnly returns true if the path only accepts the bearer token
+func (f *filter) bearerOnly(path string) bool {
+	for _, prefix := range f.config.BearerToken.PathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func unauthorized(errCode string) api.ResultAction {
+	// See https://www.rfc-editor.org/rfc/rfc6750#section-3
+	challenge := `Bearer`
+	if errCode != "" {
+		challenge += ` error="` + errCode + `"`
+	}
+	header := http.Header{}
+	header.Set("WWW-Authenticate", challenge)
+	return &api.LocalResponse{
+		Code:   http.StatusUnauthorized,
+		Header: header,
+	}
+}
+
+// handleBearerToken verifies the bearer token against the JWKS of the OIDC provider.
+// The request is passed to the upstream with the token as is, unless the token exchange is
+// configured.
+func (f *filter) handleBearerToken(headers api.RequestHeaderMap, rawToken string) api.ResultAction {
+	config := f.config
+	ctx := config.ctxWithClient(context.Background())
+	token, err := f.discovery.bearerVerifier.Verify(ctx, rawToken)
+	if err != nil {
+		api.LogInfof("bad bearer token: %v", err)
+		return unauthorized("invalid_token")
+	}
+
+	audiences := config.BearerToken.Audiences
+	if len(audiences) == 0 {
+		audiences = []string{config.ClientId}
+	}
+	matched := false
+	for _, aud := range token.Audience {
+		if slices.Contains(audiences, aud) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		api.LogInfof("bad bearer token: audience %v doesn't match %v", token.Audience, audiences)
+		return unauthorized("invalid_token")
+	}
+
+	var userinfo json.RawMessage
+	if config.FetchUserinfo && (config.authorizer != nil || len(config.ClaimsToHeaders) > 0) {
+		userinfo, err = f.fetchBearerUserinfo(ctx, rawToken, token.Expiry)
+		if err != nil {
+			api.LogErrorf("failed to fetch userinfo: %v", err)
+			return &api.LocalResponse{Code: 503, Msg: "failed to fetch userinfo"}
+		}
+	}
+	claims := newClaimSet(rawToken, userinfo)
+	if config.authorizer != nil && !config.authorizer.authorize(claims) {
+		return f.deny()
+	}
+	f.setClaimsToHeaders(headers, claims)
+	// expose the claims to other plugins, like jwtIssuer
+	f.callbacks.PluginState().Set(oidctype.Name, "claims", claims.merge())
+	if config.tokenExchanger == nil && !config.DisableAccessTokenForwarding {
+		// pass the token as is
+		return api.Continue
+	}
+	return f.forwardAccessToken(headers, &oauth2.Token{AccessToken: rawToken, TokenType: "Bearer"})
+}
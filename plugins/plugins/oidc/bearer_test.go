@@ -0,0 +1,150 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/stretchr/testify/assert"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+func TestBearerToken(t *testing.T) {
+	conf := getCfg()
+	conf.BearerToken = &oidctype.BearerToken{
+		Audiences:    []string{"api"},
+		PathPrefixes: []string{"/api/"},
+	}
+	conf.discovery().bearerVerifier = &oidc.IDTokenVerifier{}
+	conf.ClaimsToHeaders = []*oidctype.ClaimToHeader{
+		{Claim: "sub", Header: "x-user"},
+	}
+	rawToken := fakeJWT(`{"sub":"alice"}`)
+
+	patches := gomonkey.ApplyMethod(conf.discovery().bearerVerifier, "Verify",
+		func(_ *oidc.IDTokenVerifier, _ context.Context, raw string) (*oidc.IDToken, error) {
+			switch raw {
+			case rawToken:
+				return &oidc.IDToken{Audience: []string{"other", "api"}}, nil
+			case "wrongAudience":
+				return &oidc.IDToken{Audience: []string{conf.ClientId}}, nil
+			}
+			return nil, errors.New("invalid")
+		})
+	patches.ApplyMethodReturn(conf.discovery().oauth2Config, "AuthCodeURL", "http://auth")
+	defer patches.Reset()
+
+	tests := []struct {
+		name    string
+		path    string
+		auth    string
+		code    int
+		wwwAuth string
+	}{
+		{
+			name: "valid token",
+			path: "/api/users",
+			auth: "Bearer " + rawToken,
+		},
+		{
+			name: "scheme is case-insensitive",
+			path: "/",
+			auth: "bearer " + rawToken,
+		},
+		{
+			name:    "invalid token",
+			path:    "/",
+			auth:    "Bearer invalid",
+			code:    401,
+			wwwAuth: `Bearer error="invalid_token"`,
+		},
+		{
+			name:    "wrong audience",
+			path:    "/api/users",
+			auth:    "Bearer wrongAudience",
+			code:    401,
+			wwwAuth: `Bearer error="invalid_token"`,
+		},
+		{
+			name:    "bearer only",
+			path:    "/api/users",
+			code:    401,
+			wwwAuth: "Bearer",
+		},
+		{
+			name:    "bearer only, other scheme",
+			path:    "/api/users",
+			auth:    "Basic xxx",
+			code:    401,
+			wwwAuth: "Bearer",
+		},
+		{
+			name: "redirect",
+			path: "/users",
+			code: 302,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb).(*filter)
+			h := http.Header{}
+			h.Set(":path", tt.path)
+			if tt.auth != "" {
+				h.Set("authorization", tt.auth)
+			}
+			h.Set("x-user", "spoofed")
+			hdr := envoy.NewRequestHeaderMap(h)
+			res := f.DecodeHeaders(hdr, true)
+			if tt.code == 0 {
+				assert.Equal(t, api.Continue, res)
+				user, _ := hdr.Get("x-user")
+				assert.Equal(t, "alice", user)
+				auth, _ := hdr.Get("authorization")
+				assert.Equal(t, tt.auth, auth)
+				return
+			}
+			resp := res.(*api.LocalResponse)
+			assert.Equal(t, tt.code, resp.Code)
+			if tt.wwwAuth != "" {
+				assert.Equal(t, tt.wwwAuth, resp.Header.Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestBearerTokenDefaultAudience(t *testing.T) {
+	conf := getCfg()
+	conf.BearerToken = &oidctype.BearerToken{}
+	conf.discovery().bearerVerifier = &oidc.IDTokenVerifier{}
+	patches := gomonkey.ApplyMethodReturn(conf.discovery().bearerVerifier, "Verify",
+		&oidc.IDToken{Audience: []string{conf.ClientId}}, nil)
+	defer patches.Reset()
+
+	cb := envoy.NewFilterCallbackHandler()
+	f := factory(conf, cb).(*filter)
+	h := http.Header{}
+	h.Set(":path", "/")
+	h.Set("authorization", "Bearer token")
+	assert.Equal(t, api.Continue, f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true))
+}
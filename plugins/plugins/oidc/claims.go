@@ -0,0 +1,197 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+// parseJWTClaims parses the claims from the payload of the JWT without verifying it.
+// The caller should ensure the JWT is trusted.
+func parseJWTClaims(raw string) (map[string]interface{}, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed jwt")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed jwt payload: %w", err)
+	}
+	var claims map[string]interface{}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return nil, fmt.Errorf("malformed jwt claims: %w", err)
+	}
+	return claims, nil
+}
+
+// lookupClaim returns the claim with the given name. The nested claim can be accessed with ".".
+func lookupClaim(claims map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := claims[name]; ok {
+		return v, true
+	}
+
+	var cur interface{} = claims
+	for _, key := range strings.Split(name, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func encodeClaim(v interface{}, encoding oidctype.ClaimToHeader_Encoding) (string, error) {
+	if encoding == oidctype.ClaimToHeader_JSON {
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			if str, ok := item.(string); ok {
+				items[i] = str
+			} else {
+				b, err := json.Marshal(item)
+				if err != nil {
+					return "", err
+				}
+				items[i] = string(b)
+			}
+		}
+		s = strings.Join(items, ",")
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		s = string(b)
+	}
+
+	if encoding == oidctype.ClaimToHeader_BASE64 {
+		s = base64.StdEncoding.EncodeToString([]byte(s))
+	}
+	return s, nil
+}
+
+// pickClaims returns the claims which are passed to the upstream or used in the authorization,
+// so that only the necessary claims are saved.
+func (f *filter) pickClaims(claims map[string]interface{}) map[string]interface{} {
+	var names []string
+	for _, c := range f.config.ClaimsToHeaders {
+		names = append(names, c.Claim)
+	}
+	if a := f.config.authorizer; a != nil {
+		for _, r := range a.deny {
+			names = append(names, r.claim)
+		}
+		for _, r := range a.allow {
+			names = append(names, r.claim)
+		}
+	}
+
+	picked := map[string]interface{}{}
+	for _, name := range names {
+		if v, ok := claims[name]; ok {
+			picked[name] = v
+			continue
+		}
+		top, _, _ := strings.Cut(name, ".")
+		if v, ok := claims[top]; ok {
+			picked[top] = v
+		}
+	}
+	return picked
+}
+
+// claimSet contains the claims of the token and the userinfo
+type claimSet struct {
+	token    map[string]interface{}
+	userinfo map[string]interface{}
+}
+
+func newClaimSet(rawToken string, rawUserinfo json.RawMessage) *claimSet {
+	claims, err := parseJWTClaims(rawToken)
+	if err != nil {
+		// should not happen as the token is verified
+		api.LogErrorf("failed to parse token claims: %v", err)
+	}
+	var userinfo map[string]interface{}
+	if len(rawUserinfo) > 0 {
+		err = json.Unmarshal(rawUserinfo, &userinfo)
+		if err != nil {
+			api.LogErrorf("failed to parse userinfo: %v", err)
+		}
+	}
+	return &claimSet{
+		token:    claims,
+		userinfo: userinfo,
+	}
+}
+
+// merge returns the claims of the token, overridden by the claims from the userinfo.
+func (c *claimSet) merge() map[string]interface{} {
+	merged := make(map[string]interface{}, len(c.token)+len(c.userinfo))
+	for k, v := range c.token {
+		merged[k] = v
+	}
+	for k, v := range c.userinfo {
+		merged[k] = v
+	}
+	return merged
+}
+
+// lookup returns the claim with the given name. The claims from the userinfo take precedence.
+func (c *claimSet) lookup(name string) (interface{}, bool) {
+	v, ok := lookupClaim(c.userinfo, name)
+	if !ok {
+		v, ok = lookupClaim(c.token, name)
+	}
+	return v, ok
+}
+
+func (f *filter) setClaimsToHeaders(headers api.RequestHeaderMap, claims *claimSet) {
+	for _, c := range f.config.ClaimsToHeaders {
+		// remove the header from the client to prevent spoofing
+		headers.Del(c.Header)
+
+		v, ok := claims.lookup(c.Claim)
+		if !ok {
+			continue
+		}
+		s, err := encodeClaim(v, c.Encoding)
+		if err != nil {
+			api.LogErrorf("failed to encode claim %s: %v", c.Claim, err)
+			continue
+		}
+		headers.Set(c.Header, s)
+	}
+}
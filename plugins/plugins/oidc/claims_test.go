@@ -0,0 +1,224 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+func fakeJWT(payload string) string {
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig"
+}
+
+func TestParseJWTClaims(t *testing.T) {
+	claims, err := parseJWTClaims(fakeJWT(`{"sub":"alice"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", claims["sub"])
+
+	_, err = parseJWTClaims("rawIDToken")
+	assert.Error(t, err)
+	_, err = parseJWTClaims("a.!.c")
+	assert.Error(t, err)
+	_, err = parseJWTClaims(fakeJWT(`[]`))
+	assert.Error(t, err)
+}
+
+func TestEncodeClaim(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		encoding oidctype.ClaimToHeader_Encoding
+		res      string
+	}{
+		{
+			name:  "string",
+			value: "alice",
+			res:   "alice",
+		},
+		{
+			name:  "array",
+			value: []interface{}{"admin", "dev", 1.0},
+			res:   "admin,dev,1",
+		},
+		{
+			name:  "object",
+			value: map[string]interface{}{"country": "CN"},
+			res:   `{"country":"CN"}`,
+		},
+		{
+			name:  "bool",
+			value: true,
+			res:   "true",
+		},
+		{
+			name:     "base64",
+			value:    "张三",
+			encoding: oidctype.ClaimToHeader_BASE64,
+			res:      "5byg5LiJ",
+		},
+		{
+			name:     "json",
+			value:    []interface{}{"admin", "dev"},
+			encoding: oidctype.ClaimToHeader_JSON,
+			res:      `["admin","dev"]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := encodeClaim(tt.value, tt.encoding)
+			require.NoError(t, err)
+			assert.Equal(t, tt.res, s)
+		})
+	}
+}
+
+func TestClaimsToHeaders(t *testing.T) {
+	conf := getCfg()
+	conf.DisableAccessTokenRefresh = true
+	conf.ClaimsToHeaders = []*oidctype.ClaimToHeader{
+		{Claim: "sub", Header: "x-user"},
+		{Claim: "email", Header: "x-email"},
+		{Claim: "groups", Header: "x-groups"},
+		{Claim: "address.country", Header: "x-country"},
+		{Claim: "name", Header: "x-name", Encoding: oidctype.ClaimToHeader_BASE64},
+		{Claim: "missing", Header: "x-missing"},
+	}
+	rawIDToken := fakeJWT(`{"sub":"alice","email":"alice@example.com","groups":["admin","dev"],"name":"张三"}`)
+	token, _ := conf.cookieEncoding.Encode("htnn_oidc_token_id", Tokens{
+		Oauth2Token: &oauth2.Token{
+			AccessToken: "accessToken",
+			Expiry:      time.Now().Add(time.Hour),
+		},
+		IDToken:  rawIDToken,
+		UserInfo: []byte(`{"email":"alice@corp.example.com","address":{"country":"CN"}}`),
+	})
+
+	cb := envoy.NewFilterCallbackHandler()
+	f := factory(conf, cb).(*filter)
+	h := http.Header{}
+	h.Set("x-missing", "spoofed")
+	hdr := envoy.NewRequestHeaderMap(h)
+	assert.Equal(t, api.Continue, f.attachInfo(hdr, token))
+
+	get := func(name string) string {
+		v, _ := hdr.Get(name)
+		return v
+	}
+	assert.Equal(t, "alice", get("x-user"))
+	// the userinfo takes precedence
+	assert.Equal(t, "alice@corp.example.com", get("x-email"))
+	assert.Equal(t, "admin,dev", get("x-groups"))
+	assert.Equal(t, "CN", get("x-country"))
+	assert.Equal(t, "5byg5LiJ", get("x-name"))
+	_, ok := hdr.Get("x-missing")
+	assert.False(t, ok)
+
+	claims := cb.PluginState().Get(oidctype.Name, "claims").(map[string]interface{})
+	assert.Equal(t, "alice", claims["sub"])
+	assert.Equal(t, "alice@corp.example.com", claims["email"])
+	assert.Equal(t, map[string]interface{}{"country": "CN"}, claims["address"])
+}
+
+func TestPickClaims(t *testing.T) {
+	conf := getCfg()
+	conf.ClaimsToHeaders = []*oidctype.ClaimToHeader{
+		{Claim: "email", Header: "x-email"},
+		{Claim: "address.country", Header: "x-country"},
+	}
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	assert.Equal(t, map[string]interface{}{
+		"email":   "alice@example.com",
+		"address": map[string]interface{}{"country": "CN"},
+	}, f.pickClaims(map[string]interface{}{
+		"email":   "alice@example.com",
+		"address": map[string]interface{}{"country": "CN"},
+		"picture": "https://example.com/alice.png",
+	}))
+
+	// the claims used in the authorization are kept too
+	conf.authorizer = &authorizer{allow: []*claimRule{{claim: "groups"}}}
+	assert.Equal(t, map[string]interface{}{
+		"email":  "alice@example.com",
+		"groups": []interface{}{"admin"},
+	}, f.pickClaims(map[string]interface{}{
+		"email":   "alice@example.com",
+		"groups":  []interface{}{"admin"},
+		"picture": "https://example.com/alice.png",
+	}))
+}
+
+func TestFetchUserinfo(t *testing.T) {
+	conf := getCfg()
+	conf.DisableAccessTokenRefresh = true
+	conf.FetchUserinfo = true
+	conf.discovery().provider = &oidc.Provider{}
+	conf.ClaimsToHeaders = []*oidctype.ClaimToHeader{
+		{Claim: "email", Header: "x-email"},
+	}
+	verifier := oauth2.GenerateVerifier()
+	state := generateState(conf.ClientSecret, "https://127.0.0.1:2379/x?y=1")
+	token := (&oauth2.Token{
+		AccessToken: "accessToken",
+		Expiry:      time.Now().Add(time.Hour),
+	}).WithExtra(map[string]interface{}{
+		"id_token": fakeJWT(`{"sub":"alice"}`),
+	})
+	nonce, _ := conf.cookieEncoding.Encode("htnn_oidc_nonce_id", "xxx")
+
+	patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "Exchange", token, nil)
+	patches.ApplyMethodReturn(conf.discovery().verifier, "Verify", &oidc.IDToken{
+		Nonce: "xxx", Expiry: time.Now().Add(2 * time.Hour),
+	}, nil)
+	patches.ApplyMethodReturn(conf.discovery().provider, "UserInfo", &oidc.UserInfo{}, nil)
+	patches.ApplyMethod(&oidc.UserInfo{}, "Claims", func(_ *oidc.UserInfo, v interface{}) error {
+		return json.Unmarshal([]byte(`{"email":"alice@example.com","picture":"https://example.com/alice.png"}`), v)
+	})
+	defer patches.Reset()
+
+	cb := envoy.NewFilterCallbackHandler()
+	f := factory(conf, cb).(*filter)
+	h := http.Header{}
+	h.Set(":path", "/echo?code=123&state="+state)
+	h.Set("cookie", "htnn_oidc_nonce_id="+nonce+"; "+encodeAuthState(conf, state, verifier))
+	resp := f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true).(*api.LocalResponse)
+	require.Equal(t, 302, resp.Code, resp.Msg)
+	cookie := resp.Header.Get("Set-Cookie")
+	v := strings.SplitN(strings.Split(cookie, ";")[0], "=", 2)[1]
+
+	tokens := &Tokens{}
+	require.NoError(t, conf.cookieEncoding.Decode("htnn_oidc_token_id", v, tokens))
+	// only the necessary claims are kept
+	assert.JSONEq(t, `{"email":"alice@example.com"}`, string(tokens.UserInfo))
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{})
+	assert.Equal(t, api.Continue, f.attachInfo(hdr, v))
+	email, _ := hdr.Get("x-email")
+	assert.Equal(t, "alice@example.com", email)
+}
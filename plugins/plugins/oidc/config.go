@@ -16,14 +16,22 @@ package oidc
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"runtime"
+	"strings"
 	"time"
 
 	"github.com/avast/retry-go"
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gorilla/securecookie"
+	"github.com/jellydator/ttlcache/v3"
 	"golang.org/x/oauth2"
+	"google.golang.org/protobuf/proto"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/plugins"
@@ -50,11 +58,74 @@ type config struct {
 	oidctype.Config
 
 	opTimeout      time.Duration
-	oauth2Config   *oauth2.Config
-	verifier       *oidc.IDTokenVerifier
+	discoverer     *discoverer
 	cookieEncoding *securecookie.SecureCookie
-	refreshLeeway  time.Duration
-	cookieEntryID  string
+	// cookieCipher encrypts the cookie besides signing it
+	cookieCipher  *securecookie.SecureCookie
+	refreshLeeway time.Duration
+	refreshGrace  time.Duration
+	// authFlowTimeout limits the duration between the authorization request and the callback
+	authFlowTimeout time.Duration
+	cookieEntryID   string
+
+	sessionStore sessionStore
+	authorizer   *authorizer
+	// tokenExchanger exchanges the access token for the token forwarded to the upstream
+	tokenExchanger *tokenExchanger
+	skipAuthRules  []*skipAuthRule
+	// userinfoCache caches the userinfo of the bearer tokens
+	userinfoCache    *ttlcache.Cache[string, json.RawMessage]
+	userinfoCacheTTL time.Duration
+
+	providers []*providerConfig
+}
+
+// providerConfig is the configuration of the provider in the `providers` field. It inherits the
+// top-level configuration except the fields of the provider.
+type providerConfig struct {
+	match *oidctype.ProviderMatch
+	conf  *config
+}
+
+func (p *providerConfig) matches(headers api.RequestHeaderMap, tenantHeader string) bool {
+	m := p.match
+	if m.Host != "" && !strings.EqualFold(m.Host, headers.Host()) {
+		return false
+	}
+	if m.PathPrefix != "" && !strings.HasPrefix(headers.URL().Path, m.PathPrefix) {
+		return false
+	}
+	if m.Tenant != "" {
+		tenant, _ := headers.Get(tenantHeader)
+		if tenant != m.Tenant {
+			return false
+		}
+	}
+	return true
+}
+
+// selectProvider returns the configuration of the provider which matches the request
+func (conf *config) selectProvider(headers api.RequestHeaderMap) *config {
+	for _, p := range conf.providers {
+		if p.matches(headers, conf.TenantHeader) {
+			return p.conf
+		}
+	}
+	return conf
+}
+
+func (conf *config) newProviderConfig(p *oidctype.Provider) *config {
+	sub := &config{}
+	proto.Merge(&sub.Config, &conf.Config)
+	sub.Providers = nil
+	sub.ClientId = p.ClientId
+	sub.ClientSecret = p.ClientSecret
+	sub.Issuer = p.Issuer
+	sub.RedirectUrl = p.RedirectUrl
+	if len(p.Scopes) > 0 {
+		sub.Scopes = p.Scopes
+	}
+	return sub
 }
 
 func (conf *config) ctxWithClient(ctx context.Context) context.Context {
@@ -62,10 +133,56 @@ func (conf *config) ctxWithClient(ctx context.Context) context.Context {
 	return context.WithValue(ctx, oauth2.HTTPClient, httpClient)
 }
 
+// discovery returns the metadata of the OIDC provider, or nil if the provider is not
+// discovered yet
+func (conf *config) discovery() *discovery {
+	return conf.discoverer.current.Load()
+}
+
+func customResponse(resp *oidctype.CustomResponse, defaultCode int, defaultMsg string) *api.LocalResponse {
+	if resp == nil {
+		return &api.LocalResponse{Code: defaultCode, Msg: defaultMsg}
+	}
+
+	code := int(resp.StatusCode)
+	if code == 0 {
+		code = defaultCode
+	}
+	var header http.Header
+	if len(resp.Headers) > 0 {
+		header = http.Header{}
+		for k, v := range resp.Headers {
+			header.Set(k, v)
+		}
+	}
+	return &api.LocalResponse{Code: code, Msg: resp.Message, Header: header}
+}
+
+func (conf *config) unavailableResponse() *api.LocalResponse {
+	return customResponse(conf.GetUnavailableResponse(), http.StatusServiceUnavailable, "OIDC provider is unavailable")
+}
+
 func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 	if conf.IdTokenHeader == "" {
 		conf.IdTokenHeader = "x-id-token"
 	}
+	if conf.TenantHeader == "" {
+		conf.TenantHeader = "x-tenant-id"
+	}
+
+	// create the providers before the top-level configuration is modified
+	for _, p := range conf.Providers {
+		conf.providers = append(conf.providers, &providerConfig{
+			match: p.Match,
+			conf:  conf.newProviderConfig(p),
+		})
+	}
+	for _, p := range conf.providers {
+		err := p.conf.Init(cb)
+		if err != nil {
+			return fmt.Errorf("failed to init provider %s: %w", p.conf.Issuer, err)
+		}
+	}
 
 	du := 3 * time.Second
 	timeout := conf.GetTimeout()
@@ -81,12 +198,88 @@ func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 	}
 	conf.refreshLeeway = du
 
-	ctx := conf.ctxWithClient(context.Background())
-	var provider *oidc.Provider
-	var err error
+	du = time.Hour
+	grace := conf.GetRefreshGracePeriod()
+	if grace != nil {
+		du = grace.AsDuration()
+	}
+	conf.refreshGrace = du
+
+	du = defaultAuthFlowTimeout
+	authFlowTimeout := conf.GetAuthFlowTimeout()
+	if authFlowTimeout != nil {
+		du = authFlowTimeout.AsDuration()
+	}
+	conf.authFlowTimeout = du
+
+	if authz := conf.GetAuthorization(); authz != nil {
+		a, err := newAuthorizer(authz)
+		if err != nil {
+			return err
+		}
+		conf.authorizer = a
+	}
+
+	rules, err := newSkipAuthRules(conf.SkipAuthPaths)
+	if err != nil {
+		return err
+	}
+	conf.skipAuthRules = rules
+
+	if conf.FetchUserinfo && conf.BearerToken != nil {
+		conf.userinfoCacheTTL = defaultUserinfoCacheTTL
+		if ttl := conf.GetUserinfoCacheTtl(); ttl != nil {
+			conf.userinfoCacheTTL = ttl.AsDuration()
+		}
+		conf.userinfoCache = newUserinfoCache()
+	}
+
+	if conf.TokenExchange != nil {
+		conf.tokenExchanger = newTokenExchanger(conf)
+	}
+
+	if store := conf.GetSessionStore(); store != nil {
+		var idleTimeout time.Duration
+		if store.IdleTimeout != nil {
+			idleTimeout = store.IdleTimeout.AsDuration()
+		}
+		redisStore := newRedisSessionStore(store.GetRedis(), idleTimeout)
+		conf.sessionStore = redisStore
+		registerSessionStore(store.GetRedis().Address+"|"+redisStore.prefix, redisStore)
+	}
+	if conf.BackchannelLogoutPath != "" && conf.sessionStore == nil {
+		return errors.New("backchannel logout requires the session store")
+	}
+
+	if !conf.DisableAccessTokenRefresh {
+		conf.Scopes = append(conf.Scopes, oidc.ScopeOfflineAccess)
+	}
+
+	du = defaultDiscoveryBackoff
+	maxBackoff := conf.GetDiscoveryMaxBackoff()
+	if maxBackoff != nil {
+		du = maxBackoff.AsDuration()
+	}
+	d := &discoverer{
+		issuer:          conf.Issuer,
+		clientID:        conf.ClientId,
+		clientSecret:    conf.ClientSecret,
+		redirectURL:     conf.RedirectUrl,
+		scopes:          conf.Scopes,
+		bearerToken:     conf.BearerToken != nil,
+		logoutPath:      conf.LogoutPath,
+		timeout:         conf.opTimeout,
+		maxBackoff:      du,
+		refreshInterval: conf.GetJwksRefreshInterval().AsDuration(),
+		stopCh:          make(chan struct{}),
+	}
+	conf.discoverer = d
+
+	var disc *discovery
 	err = retry.Do(
 		func() error {
-			provider, err = oidc.NewProvider(ctx, conf.Issuer)
+			var err error
+			disc, err = d.discover()
 			return err
 		},
 		retry.RetryIf(func(err error) bool {
@@ -98,24 +291,23 @@ func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 		retry.Delay(500*time.Millisecond),
 	)
 	if err != nil {
-		return err
+		// Don't fail the whole configuration. The requests are rejected until the provider
+		// is discovered in the background.
+		api.LogErrorf("failed to get oidc provider %s, retry in background, err: %v", conf.Issuer, err)
+	} else {
+		d.current.Store(disc)
 	}
-
-	if !conf.DisableAccessTokenRefresh {
-		conf.Scopes = append(conf.Scopes, oidc.ScopeOfflineAccess)
+	if disc == nil || d.refreshInterval > 0 {
+		go d.run()
+		runtime.SetFinalizer(conf, func(conf *config) {
+			conf.discoverer.stop()
+		})
 	}
-	conf.oauth2Config = &oauth2.Config{
-		ClientID:     conf.ClientId,
-		ClientSecret: conf.ClientSecret,
-		// ScopeOpenID is the mandatory scope for all OpenID Connect OAuth2 requests.
-		Scopes:      append([]string{oidc.ScopeOpenID}, conf.Scopes...),
-		RedirectURL: conf.RedirectUrl,
 
-		// Discovery returns the OAuth2 endpoints.
-		Endpoint: provider.Endpoint(),
-	}
-	conf.verifier = provider.Verifier(&oidc.Config{ClientID: conf.ClientId})
-	conf.cookieEncoding = securecookie.New([]byte(conf.ClientSecret), nil)
+	// the size of the cookie is not limited here as large cookies are split
+	conf.cookieEncoding = securecookie.New([]byte(conf.ClientSecret), nil).MaxLength(0)
+	blockKey := sha256.Sum256([]byte(conf.ClientSecret))
+	conf.cookieCipher = securecookie.New([]byte(conf.ClientSecret), blockKey[:]).MaxLength(0)
 	conf.cookieEntryID = base64.RawURLEncoding.EncodeToString([]byte(conf.ClientId))
 	return nil
 }
@@ -15,12 +15,19 @@
 package oidc
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/durationpb"
 
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
 	"mosn.io/htnn/types/plugins/oidc"
 )
 
@@ -31,8 +38,98 @@ func TestBadIssuer(t *testing.T) {
 			Timeout: &durationpb.Duration{Seconds: 1}, // quick fail
 		},
 	}
+	// the discovery is retried in the background
 	err := c.Init(nil)
-	assert.Error(t, err)
+	assert.NoError(t, err)
+	defer c.discoverer.stop()
+	assert.Nil(t, c.discovery())
+
+	f := factory(&c, envoy.NewFilterCallbackHandler())
+	h := http.Header{}
+	h.Set(":path", "/")
+	resp := f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true).(*api.LocalResponse)
+	assert.Equal(t, 503, resp.Code)
+	assert.Equal(t, "OIDC provider is unavailable", resp.Msg)
+
+	c.UnavailableResponse = &oidc.CustomResponse{
+		Message: "<p>Please try again later</p>",
+		Headers: map[string]string{"content-type": "text/html"},
+	}
+	resp = f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true).(*api.LocalResponse)
+	assert.Equal(t, 503, resp.Code)
+	assert.Equal(t, "<p>Please try again later</p>", resp.Msg)
+	assert.Equal(t, "text/html", resp.Header.Get("Content-Type"))
+}
+
+func newDiscoveryServer(t *testing.T, failures int) (*httptest.Server, *atomic.Int32) {
+	var hits atomic.Int32
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		if int(n) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"issuer":%q,"authorization_endpoint":"%[1]s/auth","token_endpoint":"%[1]s/token","jwks_uri":"%[1]s/jwks","end_session_endpoint":"%[1]s/logout"}`, srv.URL)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestDiscoveryRetry(t *testing.T) {
+	srv, hits := newDiscoveryServer(t, 4)
+	c := config{
+		Config: oidc.Config{
+			ClientId:            "a",
+			ClientSecret:        "b",
+			Issuer:              srv.URL,
+			DiscoveryMaxBackoff: &durationpb.Duration{Nanos: int32(10 * time.Millisecond)},
+		},
+	}
+	err := c.Init(nil)
+	assert.NoError(t, err)
+	defer c.discoverer.stop()
+
+	assert.Eventually(t, func() bool {
+		return c.discovery() != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(5), hits.Load())
+	disc := c.discovery()
+	assert.Equal(t, srv.URL+"/logout", disc.endSessionEndpoint)
+	assert.Equal(t, srv.URL+"/token", disc.oauth2Config.Endpoint.TokenURL)
+	assert.Nil(t, disc.bearerVerifier)
+}
+
+func TestDiscoveryRefresh(t *testing.T) {
+	srv, hits := newDiscoveryServer(t, 0)
+	c := config{
+		Config: oidc.Config{
+			ClientId:            "a",
+			ClientSecret:        "b",
+			Issuer:              srv.URL,
+			BearerToken:         &oidc.BearerToken{},
+			JwksRefreshInterval: &durationpb.Duration{Nanos: int32(10 * time.Millisecond)},
+		},
+	}
+	err := c.Init(nil)
+	assert.NoError(t, err)
+	defer c.discoverer.stop()
+
+	first := c.discovery()
+	assert.NotNil(t, first)
+	assert.NotNil(t, first.bearerVerifier)
+	assert.Eventually(t, func() bool {
+		return hits.Load() >= 3 && c.discovery() != first
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// keep the previous metadata if the refresh fails
+	srv.Close()
+	time.Sleep(50 * time.Millisecond)
+	assert.NotNil(t, c.discovery())
+
+	// stopping again, like the finalizer does, is fine
+	c.discoverer.stop()
 }
 
 func TestDefaultValue(t *testing.T) {
@@ -47,6 +144,17 @@ func TestDefaultValue(t *testing.T) {
 	assert.Equal(t, c.IdTokenHeader, "x-id-token")
 }
 
+func TestBackchannelLogoutWithoutSessionStore(t *testing.T) {
+	c := config{
+		Config: oidc.Config{
+			Issuer:                "http://1.1.1.1",
+			BackchannelLogoutPath: "/backchannel_logout",
+		},
+	}
+	err := c.Init(nil)
+	assert.ErrorContains(t, err, "backchannel logout requires the session store")
+}
+
 func TestConfig(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -62,6 +170,80 @@ func TestConfig(t *testing.T) {
 			name:  "leeway can be 0s",
 			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "accessTokenRefreshLeeway":"0s"}`,
 		},
+		{
+			name:  "bad post logout redirect url",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "logoutPath":"/logout", "postLogoutRedirectUrl":"/"}`,
+			err:   "invalid Config.PostLogoutRedirectUrl:",
+		},
+		{
+			name:  "session store without store",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "sessionStore":{"idleTimeout":"600s"}}`,
+			err:   "invalid SessionStore.Store: value is required",
+		},
+		{
+			name:  "redis session store",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "sessionStore":{"redis":{"address":"127.0.0.1:6379"}}}`,
+		},
+		{
+			name:  "empty bearer token path prefix",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "bearerToken":{"pathPrefixes":[""]}}`,
+			err:   "invalid BearerToken.PathPrefixes[0]: value length must be at least 1 runes",
+		},
+		{
+			name:  "bearer token",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "bearerToken":{"audiences":["api"], "pathPrefixes":["/api/"]}}`,
+		},
+		{
+			name:  "provider without match",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "providers":[{"clientId":"c", "clientSecret":"d", "issuer":"https://accounts.example.com", "redirectUrl":"http://127.0.0.1:10000/echo"}]}`,
+			err:   "invalid Provider.Match: value is required",
+		},
+		{
+			name:  "bad provider issuer",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "providers":[{"match":{"host":"a.com"}, "clientId":"c", "clientSecret":"d", "issuer":"example.com", "redirectUrl":"http://127.0.0.1:10000/echo"}]}`,
+			err:   "invalid Provider.Issuer:",
+		},
+		{
+			name:  "claim rule without value",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "authorization":{"allow":[{"claim":"groups"}]}}`,
+			err:   "invalid ClaimRule.Value: value is required",
+		},
+		{
+			name:  "authorization",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "authorization":{"deny":[{"claim":"groups", "value":{"exact":"intern"}}], "allow":[{"claim":"email", "value":{"suffix":"@corp.com"}}], "deniedResponse":{"message":"access denied"}}}`,
+		},
+		{
+			name:  "bad cookie name prefix",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "cookie":{"namePrefix":"a;b"}}`,
+			err:   "invalid Cookie.NamePrefix: value does not match regex pattern",
+		},
+		{
+			name:  "cookie",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "cookie":{"namePrefix":"sso", "domain":"example.com", "path":"/", "sameSite":"LAX", "secure":true, "maxAge":"3600s"}}`,
+		},
+		{
+			name:  "bad skip auth method",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "skipAuthPaths":[{"methods":["get"]}]}`,
+			err:   "invalid SkipAuthPath.Methods[0]: value does not match regex pattern",
+		},
+		{
+			name:  "skip auth paths",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "skipAuthPaths":[{"path":{"prefix":"/static/"}, "methods":["GET"]}, {"path":{"regex":"^/healthz$"}}]}`,
+		},
+		{
+			name:  "bad userinfo cache ttl",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "fetchUserinfo":true, "userinfoCacheTtl":"0s"}`,
+			err:   "invalid Config.UserinfoCacheTtl: value must be greater than 0s",
+		},
+		{
+			name:  "bad auth flow timeout",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "authFlowTimeout":"-1s"}`,
+			err:   "invalid Config.AuthFlowTimeout: value must be greater than 0s",
+		},
+		{
+			name:  "providers",
+			input: `{"clientId":"a", "clientSecret":"b", "issuer":"https://google.com", "redirectUrl":"http://127.0.0.1:10000/echo", "providers":[{"match":{"host":"a.com", "pathPrefix":"/a/", "tenant":"a"}, "clientId":"c", "clientSecret":"d", "issuer":"https://accounts.example.com", "redirectUrl":"http://127.0.0.1:10000/echo"}]}`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -79,3 +261,92 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectProvider(t *testing.T) {
+	conf := getCfg()
+	conf.TenantHeader = "x-tenant"
+	conf.Scopes = []string{"email"}
+	conf.Providers = []*oidc.Provider{
+		{
+			Match:    &oidc.ProviderMatch{Host: "a.example.com"},
+			ClientId: "a",
+		},
+		{
+			Match:    &oidc.ProviderMatch{PathPrefix: "/b/", Tenant: "b"},
+			ClientId: "b",
+			Scopes:   []string{"profile"},
+		},
+		{
+			Match:    &oidc.ProviderMatch{Tenant: "c"},
+			ClientId: "c",
+		},
+	}
+	for _, p := range conf.Providers {
+		conf.providers = append(conf.providers, &providerConfig{
+			match: p.Match,
+			conf:  conf.newProviderConfig(p),
+		})
+	}
+
+	tests := []struct {
+		name     string
+		host     string
+		path     string
+		tenant   string
+		clientID string
+	}{
+		{
+			name:     "host",
+			host:     "A.example.com",
+			path:     "/b/",
+			tenant:   "b",
+			clientID: "a",
+		},
+		{
+			name:     "path prefix and tenant",
+			host:     "example.com",
+			path:     "/b/echo",
+			tenant:   "b",
+			clientID: "b",
+		},
+		{
+			name:     "all conditions should match",
+			host:     "example.com",
+			path:     "/b/echo",
+			clientID: conf.ClientId,
+		},
+		{
+			name:     "tenant",
+			host:     "example.com",
+			path:     "/",
+			tenant:   "c",
+			clientID: "c",
+		},
+		{
+			name:     "fallback",
+			host:     "example.com",
+			path:     "/",
+			tenant:   "d",
+			clientID: conf.ClientId,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			h.Set(":authority", tt.host)
+			h.Set(":path", tt.path)
+			if tt.tenant != "" {
+				h.Set("x-tenant", tt.tenant)
+			}
+			c := conf.selectProvider(envoy.NewRequestHeaderMap(h))
+			assert.Equal(t, tt.clientID, c.ClientId)
+		})
+	}
+
+	// the provider inherits the top-level configuration
+	sub := conf.providers[0].conf
+	assert.Equal(t, conf.IdTokenHeader, sub.IdTokenHeader)
+	assert.Equal(t, []string{"email"}, sub.Scopes)
+	assert.Nil(t, sub.Providers)
+	assert.Equal(t, []string{"profile"}, conf.providers[1].conf.Scopes)
+}
@@ -0,0 +1,135 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+const (
+	defaultCookiePrefix = "htnn_oidc"
+	// maxCookieValueSize leaves room for the name and the attributes of the cookie, as most
+	// browsers limit the size of a cookie to 4096 bytes.
+	maxCookieValueSize = 3800
+)
+
+func (f *filter) CookieName(key string) string {
+	prefix := defaultCookiePrefix
+	if c := f.config.Cookie; c != nil && c.NamePrefix != "" {
+		prefix = c.NamePrefix
+	}
+	return fmt.Sprintf("%s_%s_%s", prefix, key, f.config.cookieEntryID)
+}
+
+// newCookie creates a cookie with the configured attributes. The cookie is removed if maxAge < 0.
+func (f *filter) newCookie(name string, value string, maxAge int) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+	}
+	c := f.config.Cookie
+	if c == nil {
+		return cookie
+	}
+
+	cookie.Domain = c.Domain
+	cookie.Path = c.Path
+	cookie.Secure = c.Secure
+	switch c.SameSite {
+	case oidctype.Cookie_LAX:
+		cookie.SameSite = http.SameSiteLaxMode
+	case oidctype.Cookie_STRICT:
+		cookie.SameSite = http.SameSiteStrictMode
+	case oidctype.Cookie_NONE:
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	return cookie
+}
+
+func chunkCookieName(name string, i int) string {
+	return fmt.Sprintf("%s_%d", name, i)
+}
+
+// newSplitCookies creates the cookies to keep the value. If the value is too large, it is split
+// into multiple cookies. The first cookie is named `name` and its value is prefixed with the number
+// of the cookies, like "3.xxx". The rest are named `name_1`, `name_2`, and so on.
+func (f *filter) newSplitCookies(name string, value string, maxAge int) []*http.Cookie {
+	if len(value) <= maxCookieValueSize {
+		return []*http.Cookie{f.newCookie(name, value, maxAge)}
+	}
+
+	var chunks []string
+	for len(value) > maxCookieValueSize {
+		chunks = append(chunks, value[:maxCookieValueSize])
+		value = value[maxCookieValueSize:]
+	}
+	chunks = append(chunks, value)
+
+	cookies := make([]*http.Cookie, 0, len(chunks))
+	// the encoded value doesn't contain '.', so it can be used as the separator
+	cookies = append(cookies, f.newCookie(name, fmt.Sprintf("%d.%s", len(chunks), chunks[0]), maxAge))
+	for i := 1; i < len(chunks); i++ {
+		cookies = append(cookies, f.newCookie(chunkCookieName(name, i), chunks[i], maxAge))
+	}
+	return cookies
+}
+
+// getSplitCookie reassembles the value split by newSplitCookies. It returns false if the cookie
+// or one of its chunks is missing.
+func getSplitCookie(headers api.RequestHeaderMap, name string) (string, bool) {
+	cookie := headers.Cookie(name)
+	if cookie == nil {
+		return "", false
+	}
+
+	num, first, found := strings.Cut(cookie.Value, ".")
+	if !found {
+		return cookie.Value, true
+	}
+	n, err := strconv.Atoi(num)
+	if err != nil || n < 2 {
+		// let the decoder reject it
+		return cookie.Value, true
+	}
+
+	var sb strings.Builder
+	sb.WriteString(first)
+	for i := 1; i < n; i++ {
+		chunk := headers.Cookie(chunkCookieName(name, i))
+		if chunk == nil {
+			api.LogInfof("chunk %d of cookie %s is missing", i, name)
+			return "", false
+		}
+		sb.WriteString(chunk.Value)
+	}
+	return sb.String(), true
+}
+
+// clearSplitCookies returns the cookies to remove the given cookie and all its chunks
+func (f *filter) clearSplitCookies(headers api.RequestHeaderMap, name string) []string {
+	cookies := []string{f.newCookie(name, "", -1).String()}
+	for i := 1; headers.Cookie(chunkCookieName(name, i)) != nil; i++ {
+		cookies = append(cookies, f.newCookie(chunkCookieName(name, i), "", -1).String())
+	}
+	return cookies
+}
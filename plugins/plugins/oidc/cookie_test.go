@@ -0,0 +1,124 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+func TestCookieAttributes(t *testing.T) {
+	conf := getCfg()
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	assert.Equal(t, "htnn_oidc_token_id", f.CookieName("token"))
+	assert.Equal(t, "htnn_oidc_token_id=v; Max-Age=60; HttpOnly", f.newCookie(f.CookieName("token"), "v", 60).String())
+
+	conf.Cookie = &oidctype.Cookie{
+		NamePrefix: "sso",
+		Domain:     "example.com",
+		Path:       "/app",
+		SameSite:   oidctype.Cookie_STRICT,
+		Secure:     true,
+	}
+	assert.Equal(t, "sso_token_id", f.CookieName("token"))
+	assert.Equal(t, "sso_token_id=v; Path=/app; Domain=example.com; Max-Age=60; HttpOnly; Secure; SameSite=Strict",
+		f.newCookie(f.CookieName("token"), "v", 60).String())
+	assert.Equal(t, "sso_token_id=; Path=/app; Domain=example.com; Max-Age=0; HttpOnly; Secure; SameSite=Strict",
+		f.newCookie(f.CookieName("token"), "", -1).String())
+
+	resp := f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true).(*api.LocalResponse)
+	for _, c := range resp.Header.Values("Set-Cookie") {
+		assert.True(t, strings.HasPrefix(c, "sso_"), c)
+		assert.Contains(t, c, "; Path=/app; Domain=example.com; Max-Age=3600; HttpOnly; Secure; SameSite=Strict")
+	}
+}
+
+func cookieHeader(cookies []*http.Cookie) string {
+	pairs := make([]string, 0, len(cookies))
+	for _, c := range cookies {
+		pairs = append(pairs, c.Name+"="+c.Value)
+	}
+	return strings.Join(pairs, "; ")
+}
+
+func TestSplitCookie(t *testing.T) {
+	conf := getCfg()
+	conf.cookieEncoding.MaxLength(0)
+	conf.Cookie = &oidctype.Cookie{
+		MaxAge: durationpb.New(10 * time.Minute),
+	}
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+
+	userinfo, _ := json.Marshal(map[string]string{"groups": strings.Repeat("g", 8000)})
+	tokens := &Tokens{
+		Oauth2Token: &oauth2.Token{
+			AccessToken: "accessToken",
+			Expiry:      time.Now().Add(time.Hour),
+		},
+		IDToken:       "rawIDToken",
+		IDTokenExpiry: time.Now().Add(time.Hour),
+		UserInfo:      userinfo,
+	}
+	cookies, err := f.saveTokenAsCookie(tokens, "sub", "")
+	require.NoError(t, err)
+	require.Greater(t, len(cookies), 2)
+	name := f.CookieName("token")
+	for i, c := range cookies {
+		assert.LessOrEqual(t, len(c.String()), 4096)
+		assert.Equal(t, 600, c.MaxAge)
+		if i > 0 {
+			assert.Equal(t, chunkCookieName(name, i), c.Name)
+		}
+	}
+
+	h := http.Header{}
+	h.Set("cookie", cookieHeader(cookies))
+	hdr := envoy.NewRequestHeaderMap(h)
+	value, ok := getSplitCookie(hdr, name)
+	require.True(t, ok)
+	sess, err := f.loadSession(context.Background(), value)
+	require.NoError(t, err)
+	assert.Equal(t, "accessToken", sess.Oauth2Token.AccessToken)
+	assert.JSONEq(t, string(userinfo), string(sess.UserInfo))
+
+	cleared := f.clearSplitCookies(hdr, name)
+	assert.Equal(t, len(cookies), len(cleared))
+	assert.Equal(t, "htnn_oidc_token_id_1=; Max-Age=0; HttpOnly", cleared[1])
+
+	// a missing chunk is treated as no cookie
+	h.Set("cookie", cookieHeader(cookies[:len(cookies)-1]))
+	_, ok = getSplitCookie(envoy.NewRequestHeaderMap(h), name)
+	assert.False(t, ok)
+
+	// the small cookie is not split
+	tokens.UserInfo = nil
+	cookies, err = f.saveTokenAsCookie(tokens, "sub", "")
+	require.NoError(t, err)
+	require.Len(t, cookies, 1)
+	assert.NotContains(t, cookies[0].Value, ".")
+}
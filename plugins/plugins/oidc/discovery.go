@@ -0,0 +1,160 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const (
+	minDiscoveryBackoff     = time.Second
+	defaultDiscoveryBackoff = 60 * time.Second
+)
+
+// discovery contains the metadata discovered from the OIDC provider. It is replaced as a whole
+// when the provider is discovered again, so the filter can use it without locking.
+type discovery struct {
+	provider     *oidc.Provider
+	oauth2Config *oauth2.Config
+	verifier     *oidc.IDTokenVerifier
+	// bearerVerifier verifies the bearer token sent by the API clients
+	bearerVerifier     *oidc.IDTokenVerifier
+	endSessionEndpoint string
+}
+
+// discoverer discovers the OIDC provider in the background. It doesn't refer to the config,
+// so the config can be garbage collected and stop the discoverer in its finalizer.
+type discoverer struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	bearerToken  bool
+	logoutPath   string
+	timeout      time.Duration
+
+	maxBackoff      time.Duration
+	refreshInterval time.Duration
+
+	current  atomic.Pointer[discovery]
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+func (d *discoverer) discover() (*discovery, error) {
+	httpClient := &http.Client{Timeout: d.timeout}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+	// The provider keeps the context to fetch the JWKS, so no cancellation here
+	provider, err := oidc.NewProvider(ctx, d.issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}
+	err = provider.Claims(&claims)
+	if err != nil {
+		return nil, err
+	}
+	if d.logoutPath != "" && claims.EndSessionEndpoint == "" {
+		api.LogWarnf("the OIDC provider %s doesn't support RP-initiated logout, only the session will be cleared",
+			d.issuer)
+	}
+
+	disc := &discovery{
+		provider: provider,
+		oauth2Config: &oauth2.Config{
+			ClientID:     d.clientID,
+			ClientSecret: d.clientSecret,
+			// ScopeOpenID is the mandatory scope for all OpenID Connect OAuth2 requests.
+			Scopes:      append([]string{oidc.ScopeOpenID}, d.scopes...),
+			RedirectURL: d.redirectURL,
+
+			// Discovery returns the OAuth2 endpoints.
+			Endpoint: provider.Endpoint(),
+		},
+		verifier:           provider.Verifier(&oidc.Config{ClientID: d.clientID}),
+		endSessionEndpoint: claims.EndSessionEndpoint,
+	}
+	if d.bearerToken {
+		// the audiences are checked in the filter as the verifier only supports one audience
+		disc.bearerVerifier = provider.Verifier(&oidc.Config{SkipClientIDCheck: true})
+	}
+	return disc, nil
+}
+
+// run discovers the provider until it succeeds, and then discovers it periodically if the
+// refresh interval is configured.
+func (d *discoverer) run() {
+	defer func() {
+		if r := recover(); r != nil {
+			api.LogErrorf("recovered from panic: %v", r)
+		}
+	}()
+
+	backoff := min(minDiscoveryBackoff, d.maxBackoff)
+	for {
+		var wait time.Duration
+		if d.current.Load() == nil {
+			wait = backoff
+			backoff = min(backoff*2, d.maxBackoff)
+		} else {
+			if d.refreshInterval == 0 {
+				return
+			}
+			wait = d.refreshInterval
+		}
+
+		select {
+		case <-d.stopCh:
+			return
+		case <-time.After(wait):
+		}
+
+		disc, err := d.discover()
+		if err != nil {
+			if d.current.Load() == nil {
+				api.LogWarnf("failed to get oidc provider %s, retry in %s, err: %v", d.issuer, backoff, err)
+			} else {
+				// keep using the previous metadata
+				api.LogWarnf("failed to refresh oidc provider %s, err: %v", d.issuer, err)
+			}
+			continue
+		}
+		if d.current.Load() == nil {
+			api.LogInfof("oidc provider %s is discovered", d.issuer)
+		}
+		d.current.Store(disc)
+		backoff = min(minDiscoveryBackoff, d.maxBackoff)
+	}
+}
+
+// stop can be called multiple times, for example, explicitly and then in the finalizer of the config
+func (d *discoverer) stop() {
+	d.stopOnce.Do(func() {
+		close(d.stopCh)
+	})
+}
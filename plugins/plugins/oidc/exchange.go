@@ -0,0 +1,183 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"golang.org/x/oauth2"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+// See https://www.rfc-editor.org/rfc/rfc8693
+const (
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+
+	// exchangedTokenCacheSize limits the number of the exchanged tokens kept in memory
+	exchangedTokenCacheSize = 10000
+)
+
+type tokenExchanger struct {
+	conf         *oidctype.TokenExchange
+	clientID     string
+	clientSecret string
+	timeout      time.Duration
+	leeway       time.Duration
+
+	// the exchanged tokens indexed by the hash of the subject tokens
+	cache *ttlcache.Cache[string, *oauth2.Token]
+}
+
+func newTokenExchanger(conf *config) *tokenExchanger {
+	return &tokenExchanger{
+		conf:         conf.TokenExchange,
+		clientID:     conf.ClientId,
+		clientSecret: conf.ClientSecret,
+		timeout:      conf.opTimeout,
+		leeway:       conf.refreshLeeway,
+		cache: ttlcache.New(
+			ttlcache.WithCapacity[string, *oauth2.Token](exchangedTokenCacheSize),
+			ttlcache.WithDisableTouchOnHit[string, *oauth2.Token](),
+		),
+	}
+}
+
+type tokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// hashToken returns the key to cache the data of the token, so the token itself is not kept
+func hashToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(h[:])
+}
+
+// exchange exchanges the subject token for a new token. The new token is cached until it expires.
+func (e *tokenExchanger) exchange(defaultEndpoint string, subjectToken string) (*oauth2.Token, error) {
+	key := hashToken(subjectToken)
+	if item := e.cache.Get(key); item != nil {
+		return item.Value(), nil
+	}
+
+	endpoint := e.conf.TokenEndpoint
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	requestedTokenType := e.conf.RequestedTokenType
+	if requestedTokenType == "" {
+		requestedTokenType = tokenTypeAccessToken
+	}
+	form := url.Values{
+		"grant_type":           {grantTypeTokenExchange},
+		"subject_token":        {subjectToken},
+		"subject_token_type":   {tokenTypeAccessToken},
+		"requested_token_type": {requestedTokenType},
+	}
+	for _, aud := range e.conf.Audiences {
+		form.Add("audience", aud)
+	}
+	for _, res := range e.conf.Resources {
+		form.Add("resource", res)
+	}
+	if len(e.conf.Scopes) > 0 {
+		form.Set("scope", strings.Join(e.conf.Scopes, " "))
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// client_secret_basic, see https://www.rfc-editor.org/rfc/rfc6749#section-2.3.1
+	req.SetBasicAuth(url.QueryEscape(e.clientID), url.QueryEscape(e.clientSecret))
+
+	client := &http.Client{Timeout: e.timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &tokenExchangeResponse{}
+	err = json.Unmarshal(body, res)
+	if err != nil {
+		return nil, fmt.Errorf("bad token exchange response, status: %d, err: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || res.AccessToken == "" {
+		return nil, fmt.Errorf("failed to exchange token, status: %d, error: %s, description: %s",
+			resp.StatusCode, res.Error, res.ErrorDescription)
+	}
+
+	token := &oauth2.Token{
+		AccessToken: res.AccessToken,
+		TokenType:   res.TokenType,
+	}
+	// the issued token may not be an access token
+	if strings.EqualFold(token.TokenType, "N_A") {
+		token.TokenType = ""
+	}
+	if res.ExpiresIn > 0 {
+		ttl := time.Duration(res.ExpiresIn)*time.Second - e.leeway
+		token.Expiry = time.Now().Add(ttl)
+		if ttl > 0 {
+			e.cache.Set(key, token, ttl)
+		}
+	}
+	return token, nil
+}
+
+// forwardAccessToken forwards the access token, or the token exchanged from it, to the upstream
+func (f *filter) forwardAccessToken(headers api.RequestHeaderMap, token *oauth2.Token) api.ResultAction {
+	config := f.config
+	if config.DisableAccessTokenForwarding {
+		headers.Del("authorization")
+		return api.Continue
+	}
+
+	if config.tokenExchanger != nil {
+		exchanged, err := config.tokenExchanger.exchange(f.discovery.oauth2Config.Endpoint.TokenURL, token.AccessToken)
+		if err != nil {
+			api.LogErrorf("failed to exchange token: %v", err)
+			return &api.LocalResponse{Code: 503, Msg: "failed to exchange token"}
+		}
+		token = exchanged
+	}
+
+	headers.Set("authorization", fmt.Sprintf("%s %s", token.Type(), token.AccessToken))
+	return api.Continue
+}
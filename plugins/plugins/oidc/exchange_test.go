@@ -0,0 +1,146 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+func newTokenExchangeServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		id, secret, _ := r.BasicAuth()
+		assert.Equal(t, "9119df09-b20b-4c08-ba08-72472dda2cd2", id)
+		assert.Equal(t, "dSYo5hBwjX_DC57_tfZHlfrDel", secret)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, grantTypeTokenExchange, r.PostForm.Get("grant_type"))
+		assert.Equal(t, tokenTypeAccessToken, r.PostForm.Get("subject_token_type"))
+		assert.Equal(t, tokenTypeAccessToken, r.PostForm.Get("requested_token_type"))
+		assert.Equal(t, []string{"orders", "payments"}, r.PostForm["audience"])
+		assert.Equal(t, "read write", r.PostForm.Get("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		subject := r.PostForm.Get("subject_token")
+		if subject == "invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"invalid_grant","error_description":"subject token is invalid"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"access_token":"exchanged-%s","issued_token_type":%q,"token_type":"bearer","expires_in":3600}`,
+			subject, tokenTypeAccessToken)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+func TestTokenExchange(t *testing.T) {
+	srv, hits := newTokenExchangeServer(t)
+	conf := getCfg()
+	conf.DisableAccessTokenRefresh = true
+	conf.TokenExchange = &oidctype.TokenExchange{
+		TokenEndpoint: srv.URL,
+		Audiences:     []string{"orders", "payments"},
+		Scopes:        []string{"read", "write"},
+	}
+	conf.opTimeout = time.Second
+	conf.tokenExchanger = newTokenExchanger(conf)
+
+	attach := func(accessToken string) (api.ResultAction, api.RequestHeaderMap) {
+		token, _ := conf.cookieEncoding.Encode("htnn_oidc_token_id", Tokens{
+			Oauth2Token: &oauth2.Token{
+				AccessToken: accessToken,
+				Expiry:      time.Now().Add(time.Hour),
+			},
+			IDToken: "rawIDToken",
+		})
+		f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+		hdr := envoy.NewRequestHeaderMap(http.Header{})
+		return f.attachInfo(hdr, token), hdr
+	}
+
+	res, hdr := attach("accessToken")
+	require.Equal(t, api.Continue, res)
+	auth, _ := hdr.Get("authorization")
+	assert.Equal(t, "Bearer exchanged-accessToken", auth)
+	idToken, _ := hdr.Get("my-id-token")
+	assert.Equal(t, "rawIDToken", idToken)
+
+	// the exchanged token is cached
+	res, hdr = attach("accessToken")
+	require.Equal(t, api.Continue, res)
+	auth, _ = hdr.Get("authorization")
+	assert.Equal(t, "Bearer exchanged-accessToken", auth)
+	assert.Equal(t, int32(1), hits.Load())
+
+	res, _ = attach("invalid")
+	resp := res.(*api.LocalResponse)
+	assert.Equal(t, 503, resp.Code)
+
+	// bearer token
+	conf.BearerToken = &oidctype.BearerToken{}
+	conf.discovery().bearerVerifier = &oidc.IDTokenVerifier{}
+	patches := gomonkey.ApplyMethod(conf.discovery().bearerVerifier, "Verify",
+		func(_ *oidc.IDTokenVerifier, _ context.Context, raw string) (*oidc.IDToken, error) {
+			return &oidc.IDToken{Audience: []string{conf.ClientId}}, nil
+		})
+	defer patches.Reset()
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	h := http.Header{}
+	h.Set(":path", "/")
+	h.Set("authorization", "Bearer apiToken")
+	hdr = envoy.NewRequestHeaderMap(h)
+	require.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+	auth, _ = hdr.Get("authorization")
+	assert.Equal(t, "Bearer exchanged-apiToken", auth)
+}
+
+func TestDisableAccessTokenForwarding(t *testing.T) {
+	conf := getCfg()
+	conf.DisableAccessTokenRefresh = true
+	conf.DisableAccessTokenForwarding = true
+	token, _ := conf.cookieEncoding.Encode("htnn_oidc_token_id", Tokens{
+		Oauth2Token: &oauth2.Token{
+			AccessToken: "accessToken",
+			Expiry:      time.Now().Add(time.Hour),
+		},
+		IDToken: "rawIDToken",
+	})
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	h := http.Header{}
+	h.Set("authorization", "Basic xxx")
+	hdr := envoy.NewRequestHeaderMap(h)
+	require.Equal(t, api.Continue, f.attachInfo(hdr, token))
+	_, ok := hdr.Get("authorization")
+	assert.False(t, ok)
+	idToken, _ := hdr.Get("my-id-token")
+	assert.Equal(t, "rawIDToken", idToken)
+}
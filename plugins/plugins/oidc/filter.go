@@ -20,6 +20,8 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -29,33 +31,61 @@ import (
 	"golang.org/x/oauth2"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
 )
 
 func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	conf := c.(*config)
 	return &filter{
 		callbacks: callbacks,
-		config:    c.(*config),
+		config:    conf,
+		discovery: conf.discovery(),
 	}
 }
 
 type filter struct {
 	api.PassThroughFilter
 
-	callbacks   api.FilterCallbackHandler
-	config      *config
-	tokenCookie *http.Cookie
+	callbacks    api.FilterCallbackHandler
+	config       *config
+	discovery    *discovery
+	tokenCookies []*http.Cookie
+	// backchannelLogout is true if the request is a back-channel logout request
+	backchannelLogout bool
+	// sessionID is the ID of the session loaded from the session store
+	sessionID string
 }
 
 type Tokens struct {
 	IDToken     string        `json:"id_token"`
 	Oauth2Token *oauth2.Token `json:"oauth_token"`
+	// IDTokenExpiry is used to refresh the tokens before the ID token expires.
+	// It is zero if the expiry of the ID token is unknown.
+	IDTokenExpiry time.Time `json:"id_token_expiry"`
+	// UserInfo contains the claims fetched from the userinfo endpoint which are passed to the upstream.
+	// It is kept in JSON as the cookie is encoded in gob, which can't encode arbitrary claims.
+	UserInfo json.RawMessage `json:"userinfo,omitempty"`
 }
 
-func generateState(verifier string, secret string, url string) string {
+const defaultAuthFlowTimeout = time.Hour
+
+// AuthState binds the PKCE verifier to the state of the authorization request. It is stored
+// in an encrypted cookie, so the verifier is never exposed to the OIDC provider or the URL.
+type AuthState struct {
+	State    string `json:"state"`
+	Verifier string `json:"verifier"`
+	// ExpireAt is the deadline of the callback
+	ExpireAt time.Time `json:"expire_at"`
+}
+
+func generateState(secret string, url string) string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	id := base64.RawURLEncoding.EncodeToString(b)
 	encodedRedirectURL := base64.URLEncoding.EncodeToString([]byte(url))
-	state := fmt.Sprintf("%s.%s", verifier, encodedRedirectURL)
+	state := fmt.Sprintf("%s.%s", id, encodedRedirectURL)
 	signature := signState(state, secret)
-	// fmt: verifier.originURL.signature
+	// fmt: random.originURL.signature
 	return fmt.Sprintf("%s.%s", state, signature)
 }
 
@@ -75,20 +105,18 @@ func signState(state string, secret string) string {
 	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
 }
 
-func (f *filter) CookieName(key string) string {
-	return fmt.Sprintf("htnn_oidc_%s_%s", key, f.config.cookieEntryID)
-}
+var errBadCookie = errors.New("bad oidc cookie")
 
 func (f *filter) handleInitRequest(headers api.RequestHeaderMap) api.ResultAction {
 	config := f.config
-	o2conf := config.oauth2Config
+	o2conf := f.discovery.oauth2Config
 
 	b := make([]byte, 8)
 	_, _ = rand.Read(b)
 	nonce := base64.RawURLEncoding.EncodeToString(b)
 	verifier := oauth2.GenerateVerifier()
 	originURL := fmt.Sprintf("%s://%s%s", headers.Scheme(), headers.Host(), headers.Path())
-	s := generateState(verifier, config.ClientSecret, originURL)
+	s := generateState(config.ClientSecret, originURL)
 	url := o2conf.AuthCodeURL(s,
 		// use PKCE to protect against CSRF attacks if possible
 		// https://www.ietf.org/archive/id/draft-ietf-oauth-security-topics-22.html#name-countermeasures-6
@@ -101,27 +129,53 @@ func (f *filter) handleInitRequest(headers api.RequestHeaderMap) api.ResultActio
 		api.LogErrorf("failed to encode cookie: %v", err)
 		return &api.LocalResponse{Code: 503, Msg: "failed to encode cookie"}
 	}
-	cookieNonce := &http.Cookie{
-		Name:     cookieName,
-		Value:    n,
-		MaxAge:   int(time.Hour.Seconds()),
-		HttpOnly: true,
-		// TODO: allow configuring the cookie attributes
+	maxAge := int(config.authFlowTimeout.Seconds())
+	cookieNonce := f.newCookie(cookieName, n, maxAge)
+
+	cookieName = f.CookieName("state")
+	st, err := config.cookieCipher.Encode(cookieName, &AuthState{
+		State:    s,
+		Verifier: verifier,
+		ExpireAt: time.Now().Add(config.authFlowTimeout),
+	})
+	if err != nil {
+		api.LogErrorf("failed to encode cookie: %v", err)
+		return &api.LocalResponse{Code: 503, Msg: "failed to encode cookie"}
+	}
+	cookieState := f.newCookie(cookieName, st, maxAge)
+
+	if store := config.sessionStore; store != nil {
+		// track the state in the server side, so that it can only be used once
+		err = store.SaveAuthState(context.Background(), s, nonce, config.authFlowTimeout)
+		if err != nil {
+			api.LogErrorf("failed to save state: %v", err)
+			return &api.LocalResponse{Code: 503, Msg: "failed to save state"}
+		}
 	}
 
 	return &api.LocalResponse{
 		Code: http.StatusFound,
 		Header: http.Header{
 			"Location":   []string{url},
-			"Set-Cookie": []string{cookieNonce.String()},
+			"Set-Cookie": []string{cookieNonce.String(), cookieState.String()},
 		},
 	}
 }
 
 func (f *filter) calculateTokenTTL(accessTokenExpiry time.Time, idTokenExpiry time.Time, refreshEnabled bool) int {
 	if refreshEnabled {
-		// As the access token refresh is enabled, we only need to consider the expiry of id token
-		return int(time.Until(idTokenExpiry).Seconds())
+		// As the access token refresh is enabled, we only need to consider the expiry of id token.
+		// The session is kept for a grace period after the id token expires, so that the tokens
+		// can still be refreshed.
+		expiry := idTokenExpiry
+		if expiry.IsZero() {
+			expiry = accessTokenExpiry
+		}
+		if expiry.IsZero() {
+			// keep the session until the browser is closed
+			return 0
+		}
+		return int(time.Until(expiry.Add(f.config.refreshGrace)).Seconds())
 	}
 
 	// Use the min expiry between id token and access token as the expiry
@@ -147,21 +201,56 @@ func (f *filter) refreshEnabled(token *oauth2.Token) bool {
 
 func (f *filter) handleCallback(headers api.RequestHeaderMap, query url.Values) api.ResultAction {
 	config := f.config
-	o2conf := config.oauth2Config
+	o2conf := f.discovery.oauth2Config
 	ctx := context.Background()
 	code := query.Get("code")
 	state := query.Get("state")
 
 	// Here we provide the mechanism below to ensure the id token is client's:
 	// 1. sign the state to avoid being forged by the attacker
-	// 2. use PKCE to ensure the code is bound with the state, which is trusted after being verified
+	// 2. bind the state with the PKCE verifier in the encrypted cookie, so that the code can only
+	// be exchanged by the client which initiated the authorization request
 	// 3. use nonce to ensure the id token is coming from the authorization request we initiated
 	if !verifyState(state, config.ClientSecret) {
 		api.LogInfof("bad state: %s", state)
 		return &api.LocalResponse{Code: 403, Msg: "bad state"}
 	}
-	verifier, encodedURL, _ := strings.Cut(state, ".")
-	b, _ := base64.URLEncoding.DecodeString(encodedURL)
+	stateCookieName := f.CookieName("state")
+	stateCookie := headers.Cookie(stateCookieName)
+	if stateCookie == nil {
+		api.LogInfof("bad state: %s, state cookie not found", state)
+		return &api.LocalResponse{Code: 403, Msg: "bad state"}
+	}
+	authState := &AuthState{}
+	err := config.cookieCipher.Decode(stateCookieName, stateCookie.Value, authState)
+	if err != nil || authState.State != state {
+		if err != nil {
+			api.LogInfof("bad state cookie: %s, err: %v", stateCookie.Value, err)
+		} else {
+			api.LogInfof("bad state: %s, expected %s", state, authState.State)
+		}
+		return &api.LocalResponse{Code: 403, Msg: "bad state"}
+	}
+	if time.Now().After(authState.ExpireAt) {
+		api.LogInfof("bad state: %s, the auth flow is timed out at %s", state, authState.ExpireAt)
+		return &api.LocalResponse{Code: 403, Msg: "bad state"}
+	}
+	// the stored nonce is empty if the session store is not used
+	var storedNonce string
+	if store := config.sessionStore; store != nil {
+		storedNonce, err = store.ConsumeAuthState(ctx, state)
+		if err != nil {
+			if errors.Is(err, errSessionNotFound) {
+				api.LogInfof("bad state: %s, the state is expired or already used", state)
+				return &api.LocalResponse{Code: 403, Msg: "bad state"}
+			}
+			api.LogErrorf("failed to load state: %v", err)
+			return &api.LocalResponse{Code: 503, Msg: "failed to load state"}
+		}
+	}
+	verifier := authState.Verifier
+	pieces := strings.Split(state, ".")
+	b, _ := base64.URLEncoding.DecodeString(pieces[1])
 	originURL := string(b)
 
 	ctx = config.ctxWithClient(ctx)
@@ -177,7 +266,7 @@ func (f *filter) handleCallback(headers api.RequestHeaderMap, query url.Values)
 		return &api.LocalResponse{Code: 503, Msg: "failed to lookup id token"}
 	}
 
-	idToken, err := config.verifier.Verify(ctx, rawIDToken)
+	idToken, err := f.discovery.verifier.Verify(ctx, rawIDToken)
 	if err != nil {
 		api.LogInfof("bad token: %s", err)
 		return &api.LocalResponse{Code: 503, Msg: "bad token"}
@@ -201,19 +290,40 @@ func (f *filter) handleCallback(headers api.RequestHeaderMap, query url.Values)
 			}
 			return &api.LocalResponse{Code: 403, Msg: "bad nonce"}
 		}
+		if config.sessionStore != nil && storedNonce != idToken.Nonce {
+			api.LogInfof("bad nonce: %s, expected %s", storedNonce, idToken.Nonce)
+			return &api.LocalResponse{Code: 403, Msg: "bad nonce"}
+		}
 	}
 
-	cookie, err := f.saveTokenAsCookie(ctx, oauth2Token, rawIDToken)
+	tokens := &Tokens{
+		Oauth2Token:   oauth2Token,
+		IDToken:       rawIDToken,
+		IDTokenExpiry: idToken.Expiry,
+	}
+	if config.FetchUserinfo {
+		tokens.UserInfo, err = f.fetchUserinfo(ctx, oauth2Token)
+		if err != nil {
+			api.LogErrorf("failed to fetch userinfo: %v", err)
+			return &api.LocalResponse{Code: 503, Msg: "failed to fetch userinfo"}
+		}
+	}
+
+	cookies, err := f.saveTokenAsCookie(tokens, idToken.Subject, getSID(idToken))
 	if err != nil {
 		return &api.LocalResponse{Code: 503, Msg: "failed to save token"}
 	}
 
+	header := http.Header{}
+	header.Set("Location", originURL)
+	for _, cookie := range cookies {
+		header.Add("Set-Cookie", cookie.String())
+	}
+	// the state can't be reused
+	header.Add("Set-Cookie", f.newCookie(stateCookieName, "", -1).String())
 	return &api.LocalResponse{
-		Code: http.StatusFound,
-		Header: http.Header{
-			"Location":   []string{originURL},
-			"Set-Cookie": []string{cookie.String()},
-		},
+		Code:   http.StatusFound,
+		Header: header,
 	}
 }
 
@@ -221,24 +331,38 @@ func (f *filter) attachInfo(headers api.RequestHeaderMap, encodedToken string) a
 	config := f.config
 	ctx := context.Background()
 
-	tokens := &Tokens{}
-	cookieName := f.CookieName("token")
-	err := config.cookieEncoding.Decode(cookieName, encodedToken, tokens)
+	sess, err := f.loadSession(ctx, encodedToken)
 	if err != nil {
-		api.LogInfof("bad oidc cookie: %s, err: %v", encodedToken, err)
-		return &api.LocalResponse{Code: 403, Msg: "bad oidc cookie"}
+		if errors.Is(err, errSessionNotFound) {
+			api.LogInfof("session is expired or revoked, client id: %s", config.ClientId)
+			return f.relogin(headers)
+		}
+		if errors.Is(err, errBadCookie) {
+			api.LogInfof("bad oidc cookie: %s, err: %v", encodedToken, err)
+			return &api.LocalResponse{Code: 403, Msg: "bad oidc cookie"}
+		}
+		api.LogErrorf("failed to load session: %v", err)
+		return &api.LocalResponse{Code: 503, Msg: "failed to load session"}
 	}
+	tokens := &sess.Tokens
 
 	oauth2Token := tokens.Oauth2Token
 	rawIDToken := tokens.IDToken
+	userinfo := tokens.UserInfo
 	if f.refreshEnabled(oauth2Token) {
-		tokenSrc := config.oauth2Config.TokenSource(context.Background(), oauth2Token)
-		tokenSrc = oauth2.ReuseTokenSourceWithExpiry(oauth2Token, tokenSrc, config.refreshLeeway)
+		// refresh the tokens before either the access token or the id token expires
+		tokenToCheck := *oauth2Token
+		idTokenExpiry := tokens.IDTokenExpiry
+		if !idTokenExpiry.IsZero() && (tokenToCheck.Expiry.IsZero() || idTokenExpiry.Before(tokenToCheck.Expiry)) {
+			tokenToCheck.Expiry = idTokenExpiry
+		}
+		tokenSrc := f.discovery.oauth2Config.TokenSource(ctx, &tokenToCheck)
+		tokenSrc = oauth2.ReuseTokenSourceWithExpiry(&tokenToCheck, tokenSrc, config.refreshLeeway)
 		possibleRefreshedToken, err := tokenSrc.Token()
 		if err != nil {
 			api.LogWarnf("failed to refresh access token %s, err: %v, refresh token: %s",
 				oauth2Token.AccessToken, err, oauth2Token.RefreshToken)
-			return &api.LocalResponse{Code: 401}
+			return f.relogin(headers)
 		}
 
 		if possibleRefreshedToken.AccessToken != oauth2Token.AccessToken {
@@ -246,10 +370,39 @@ func (f *filter) attachInfo(headers api.RequestHeaderMap, encodedToken string) a
 			oauth2Token = possibleRefreshedToken
 			newIDToken, ok := getIDToken(oauth2Token)
 			if ok {
+				idToken, err := f.discovery.verifier.Verify(ctx, newIDToken)
+				if err != nil {
+					api.LogErrorf("bad token: %v", err)
+					return f.relogin(headers)
+				}
 				rawIDToken = newIDToken
+				idTokenExpiry = idToken.Expiry
+				sess.Subject = idToken.Subject
+				if sid := getSID(idToken); sid != "" {
+					sess.SID = sid
+				}
+			} else {
+				// The provider doesn't issue a new id token during refresh, so we can't extend
+				// the id token. Keep the old one and stop checking its expiry.
+				idTokenExpiry = time.Time{}
+			}
+
+			if config.FetchUserinfo {
+				newUserinfo, err := f.fetchUserinfo(config.ctxWithClient(ctx), oauth2Token)
+				if err != nil {
+					// the claims may be stale, but it's better than failing the request
+					api.LogWarnf("failed to fetch userinfo after refresh, keep the previous one, err: %v", err)
+				} else {
+					userinfo = newUserinfo
+				}
 			}
 
-			f.tokenCookie, err = f.saveTokenAsCookie(ctx, possibleRefreshedToken, rawIDToken)
+			f.tokenCookies, err = f.saveTokenAsCookie(&Tokens{
+				Oauth2Token:   oauth2Token,
+				IDToken:       rawIDToken,
+				IDTokenExpiry: idTokenExpiry,
+				UserInfo:      userinfo,
+			}, sess.Subject, sess.SID)
 			if err != nil {
 				return &api.LocalResponse{Code: 503, Msg: "failed to save token"}
 			}
@@ -263,16 +416,115 @@ func (f *filter) attachInfo(headers api.RequestHeaderMap, encodedToken string) a
 		}
 	}
 
-	headers.Set("authorization", fmt.Sprintf("%s %s", oauth2Token.Type(), oauth2Token.AccessToken))
+	claims := newClaimSet(rawIDToken, userinfo)
+	if config.authorizer != nil && !config.authorizer.authorize(claims) {
+		return f.deny()
+	}
+	f.setClaimsToHeaders(headers, claims)
+	// expose the claims to other plugins, like jwtIssuer
+	f.callbacks.PluginState().Set(oidctype.Name, "claims", claims.merge())
 	headers.Set(config.IdTokenHeader, rawIDToken)
-	return api.Continue
+	return f.forwardAccessToken(headers, oauth2Token)
 }
 
-func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+// handleLogout clears the session and redirects the user to the OIDC provider to log out.
+// See https://openid.net/specs/openid-connect-rpinitiated-1_0.html
+func (f *filter) handleLogout(headers api.RequestHeaderMap) api.ResultAction {
+	config := f.config
+	ctx := context.Background()
 	cookieName := f.CookieName("token")
-	token := headers.Cookie(cookieName)
-	if token != nil {
-		return f.attachInfo(headers, token.Value)
+	clearCookies := f.clearSplitCookies(headers, cookieName)
+
+	location := config.PostLogoutRedirectUrl
+	if f.discovery.endSessionEndpoint != "" {
+		query := url.Values{}
+		query.Set("client_id", config.ClientId)
+		if config.PostLogoutRedirectUrl != "" {
+			query.Set("post_logout_redirect_uri", config.PostLogoutRedirectUrl)
+		}
+
+		token, ok := getSplitCookie(headers, cookieName)
+		if ok {
+			sess, err := f.loadSession(ctx, token)
+			if err != nil {
+				api.LogInfof("failed to load session from cookie %s, err: %v", token, err)
+			} else if sess.IDToken != "" {
+				query.Set("id_token_hint", sess.IDToken)
+			}
+		}
+
+		location = f.discovery.endSessionEndpoint
+		if strings.Contains(location, "?") {
+			location += "&" + query.Encode()
+		} else {
+			location += "?" + query.Encode()
+		}
+	}
+
+	if config.sessionStore != nil {
+		f.deleteSession(ctx, headers)
+	}
+
+	if location == "" {
+		return &api.LocalResponse{
+			Code: http.StatusOK,
+			Header: http.Header{
+				"Set-Cookie": clearCookies,
+			},
+		}
+	}
+	return &api.LocalResponse{
+		Code: http.StatusFound,
+		Header: http.Header{
+			"Location":   []string{location},
+			"Set-Cookie": clearCookies,
+		},
+	}
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	f.config = f.config.selectProvider(headers)
+	if f.config.skipAuth(headers) {
+		// the identity should not be passed from the client
+		headers.Del(f.config.IdTokenHeader)
+		for _, c := range f.config.ClaimsToHeaders {
+			headers.Del(c.Header)
+		}
+		return api.Continue
+	}
+
+	f.discovery = f.config.discovery()
+	if f.discovery == nil {
+		api.LogInfof("oidc provider %s is not discovered yet", f.config.Issuer)
+		return f.config.unavailableResponse()
+	}
+
+	if f.config.BackchannelLogoutPath != "" && headers.URL().Path == f.config.BackchannelLogoutPath {
+		if endStream {
+			return f.handleBackchannelLogout(headers, nil)
+		}
+		f.backchannelLogout = true
+		return api.WaitAllData
+	}
+
+	if f.config.LogoutPath != "" && headers.URL().Path == f.config.LogoutPath {
+		return f.handleLogout(headers)
+	}
+
+	if f.config.BearerToken != nil {
+		rawToken, ok := getBearerToken(headers)
+		if ok {
+			return f.handleBearerToken(headers, rawToken)
+		}
+		if f.bearerOnly(headers.URL().Path) {
+			api.LogInfo("bearer token is required")
+			return unauthorized("")
+		}
+	}
+
+	token, ok := getSplitCookie(headers, f.CookieName("token"))
+	if ok {
+		return f.attachInfo(headers, token)
 	}
 
 	query := headers.URL().Query()
@@ -284,38 +536,123 @@ func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api
 	return f.handleCallback(headers, query)
 }
 
-func (f *filter) saveTokenAsCookie(ctx context.Context, oauth2Token *oauth2.Token, rawIDToken string) (*http.Cookie, error) {
-	idToken, err := f.config.verifier.Verify(ctx, rawIDToken)
+// relogin clears the session and redirects the client to the OIDC provider to log in again
+func (f *filter) relogin(headers api.RequestHeaderMap) api.ResultAction {
+	res := f.handleInitRequest(headers)
+	if lr, ok := res.(*api.LocalResponse); ok && lr.Code == http.StatusFound {
+		for _, cookie := range f.clearSplitCookies(headers, f.CookieName("token")) {
+			lr.Header.Add("Set-Cookie", cookie)
+		}
+	}
+	return res
+}
+
+// loadSession decodes the session from the cookie. When the session store is used, the cookie
+// only contains the session ID, and the session is fetched from the store.
+func (f *filter) loadSession(ctx context.Context, encodedToken string) (*session, error) {
+	config := f.config
+	cookieName := f.CookieName("token")
+	store := config.sessionStore
+	if store == nil {
+		sess := &session{}
+		err := config.cookieEncoding.Decode(cookieName, encodedToken, &sess.Tokens)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errBadCookie, err)
+		}
+		return sess, nil
+	}
+
+	var id string
+	err := config.cookieEncoding.Decode(cookieName, encodedToken, &id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errBadCookie, err)
+	}
+	sess, err := store.Get(ctx, id)
 	if err != nil {
-		api.LogErrorf("bad token: %v", err)
 		return nil, err
 	}
+	f.sessionID = id
+	return sess, nil
+}
 
+func (f *filter) deleteSession(ctx context.Context, headers api.RequestHeaderMap) {
+	config := f.config
 	cookieName := f.CookieName("token")
-	token, err := f.config.cookieEncoding.Encode(cookieName, Tokens{
-		Oauth2Token: oauth2Token,
-		IDToken:     rawIDToken,
-	})
+	token, ok := getSplitCookie(headers, cookieName)
+	if !ok {
+		return
+	}
+	var id string
+	err := config.cookieEncoding.Decode(cookieName, token, &id)
+	if err != nil {
+		return
+	}
+	err = config.sessionStore.Delete(ctx, id)
+	if err != nil {
+		api.LogErrorf("failed to delete session %s: %v", id, err)
+	}
+}
+
+// saveTokenAsCookie saves the tokens and returns the cookies to keep them. The cookie may be split
+// into multiple cookies if the tokens are too large.
+func (f *filter) saveTokenAsCookie(tokens *Tokens, subject string, sid string) ([]*http.Cookie, error) {
+	oauth2Token := tokens.Oauth2Token
+	ttl := f.calculateTokenTTL(oauth2Token.Expiry, tokens.IDTokenExpiry, f.refreshEnabled(oauth2Token))
+
+	var value interface{} = tokens
+	if store := f.config.sessionStore; store != nil {
+		expireAt := time.Now().Add(time.Duration(ttl) * time.Second)
+		if ttl <= 0 {
+			expireAt = time.Now().Add(defaultSessionTTL)
+		}
+		id := f.sessionID
+		if id == "" {
+			id = generateSessionID()
+		}
+		err := store.Set(context.Background(), id, &session{
+			Tokens:   *tokens,
+			Subject:  subject,
+			SID:      sid,
+			ExpireAt: expireAt,
+		})
+		if err != nil {
+			api.LogErrorf("failed to save session: %v", err)
+			return nil, err
+		}
+		value = id
+	}
+
+	cookieName := f.CookieName("token")
+	token, err := f.config.cookieEncoding.Encode(cookieName, value)
 	if err != nil {
 		api.LogErrorf("failed to encode cookie: %v", err)
 		return nil, err
 	}
 
-	ttl := f.calculateTokenTTL(oauth2Token.Expiry, idToken.Expiry, f.refreshEnabled(oauth2Token))
-	cookie := &http.Cookie{
-		Name:     cookieName,
-		Value:    token,
-		MaxAge:   ttl,
-		HttpOnly: true,
+	maxAge := ttl
+	if d := f.config.GetCookie().GetMaxAge(); d != nil {
+		maxAge = int(d.AsDuration().Seconds())
 	}
+	cookies := f.newSplitCookies(cookieName, token, maxAge)
+
+	api.LogInfof("token saved as %d cookie(s) %+v, client id: %s", len(cookies), cookies[0], f.config.ClientId)
+	return cookies, nil
+}
 
-	api.LogInfof("token saved as cookie %+v, client id: %s", cookie, f.config.ClientId)
-	return cookie, nil
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	if f.backchannelLogout {
+		var body []byte
+		if data != nil {
+			body = data.Bytes()
+		}
+		return f.handleBackchannelLogout(headers, body)
+	}
+	return api.Continue
 }
 
 func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
-	if f.tokenCookie != nil {
-		headers.Add("set-cookie", f.tokenCookie.String())
+	for _, cookie := range f.tokenCookies {
+		headers.Add("set-cookie", cookie.String())
 	}
 	return api.Continue
 }
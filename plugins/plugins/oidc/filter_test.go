@@ -17,6 +17,7 @@ package oidc
 import (
 	"errors"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/gorilla/securecookie"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
@@ -33,6 +35,11 @@ import (
 )
 
 func getCfg() *config {
+	d := &discoverer{}
+	d.current.Store(&discovery{
+		oauth2Config: &oauth2.Config{},
+		verifier:     &oidc.IDTokenVerifier{},
+	})
 	return &config{
 		Config: oidctype.Config{
 			ClientId:      "9119df09-b20b-4c08-ba08-72472dda2cd2",
@@ -40,17 +47,27 @@ func getCfg() *config {
 			RedirectUrl:   "http://127.0.0.1:10000",
 			IdTokenHeader: "my-id-token",
 		},
-		oauth2Config:   &oauth2.Config{},
-		verifier:       &oidc.IDTokenVerifier{},
-		cookieEncoding: securecookie.New([]byte("dSYo5hBwjX_DC57_tfZHlfrDel"), nil),
-		cookieEntryID:  "id",
+		discoverer:      d,
+		cookieEncoding:  securecookie.New([]byte("dSYo5hBwjX_DC57_tfZHlfrDel"), nil),
+		cookieCipher:    securecookie.New([]byte("dSYo5hBwjX_DC57_tfZHlfrDel"), []byte("0123456789abcdef0123456789abcdef")),
+		cookieEntryID:   "id",
+		authFlowTimeout: time.Hour,
 	}
 }
 
+func encodeAuthState(conf *config, state string, verifier string) string {
+	v, _ := conf.cookieCipher.Encode("htnn_oidc_state_id", &AuthState{
+		State:    state,
+		Verifier: verifier,
+		ExpireAt: time.Now().Add(time.Hour),
+	})
+	return "htnn_oidc_state_id=" + v
+}
+
 func TestInitRequest(t *testing.T) {
 	conf := getCfg()
 	url := "http://host.docker.internal:4444/oauth2/auth?client_id=ef34cf65-016c-4b17-9864-8bd04dc22555&code_challenge=i3aZkytxb-6b4zvopxeT8AY21kon7EnJ7TlumdMlVuU&code_challenge_method=S256&nonce=yFyviTyEYAw&redirect_uri=http%3A%2F%2F127.0.0.1%3A10000%2Fecho&response_type=code&scope=openid&state=hqV183kqqtJxk_10F_5Y9"
-	patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "AuthCodeURL", url)
+	patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "AuthCodeURL", url)
 	defer patches.Reset()
 
 	cb := envoy.NewFilterCallbackHandler()
@@ -63,12 +80,48 @@ func TestInitRequest(t *testing.T) {
 	// other fields are checked in the integration test
 }
 
+func TestInitRequestPKCE(t *testing.T) {
+	cb := envoy.NewFilterCallbackHandler()
+	conf := getCfg()
+	f := factory(conf, cb).(*filter)
+	h := http.Header{}
+	hdr := envoy.NewRequestHeaderMap(h)
+	resp := f.DecodeHeaders(hdr, true).(*api.LocalResponse)
+	u, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	query := u.Query()
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	state := query.Get("state")
+	assert.True(t, verifyState(state, conf.ClientSecret))
+
+	var stateCookie string
+	for _, c := range resp.Header.Values("Set-Cookie") {
+		if strings.HasPrefix(c, "htnn_oidc_state_id=") {
+			stateCookie = strings.Split(strings.SplitN(c, "=", 2)[1], ";")[0]
+		}
+	}
+	authState := &AuthState{}
+	require.NoError(t, conf.cookieCipher.Decode("htnn_oidc_state_id", stateCookie, authState))
+	assert.Equal(t, state, authState.State)
+	assert.Equal(t, oauth2.S256ChallengeFromVerifier(authState.Verifier), query.Get("code_challenge"))
+	// the verifier is not leaked
+	assert.NotContains(t, resp.Header.Get("Location"), authState.Verifier)
+	assert.Error(t, conf.cookieEncoding.Decode("htnn_oidc_state_id", stateCookie, authState))
+}
+
 func TestCallback(t *testing.T) {
 	conf := getCfg()
 	conf.DisableAccessTokenRefresh = true
 
 	verifier := oauth2.GenerateVerifier()
-	state := generateState(verifier, conf.ClientSecret, "https://127.0.0.1:2379/x?y=1")
+	state := generateState(conf.ClientSecret, "https://127.0.0.1:2379/x?y=1")
+	stateCookie := encodeAuthState(conf, state, verifier)
+	expiredState, _ := conf.cookieCipher.Encode("htnn_oidc_state_id", &AuthState{
+		State:    state,
+		Verifier: verifier,
+		ExpireAt: time.Now().Add(-time.Second),
+	})
+	expiredStateCookie := "htnn_oidc_state_id=" + expiredState
 	rawIDToken := "rawIDToken"
 	accessToken := "accessToken"
 	token := (&oauth2.Token{
@@ -89,10 +142,10 @@ func TestCallback(t *testing.T) {
 		{
 			name:   "sanity",
 			state:  state,
-			cookie: "htnn_oidc_nonce_id=" + nonce,
+			cookie: "htnn_oidc_nonce_id=" + nonce + "; " + stateCookie,
 			mock: func() *gomonkey.Patches {
-				patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "Exchange", token, nil)
-				patches.ApplyMethodReturn(conf.verifier, "Verify", &oidc.IDToken{
+				patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "Exchange", token, nil)
+				patches.ApplyMethodReturn(conf.discovery().verifier, "Verify", &oidc.IDToken{
 					Nonce: "xxx", Expiry: time.Now().Add(2 * time.Hour),
 				}, nil)
 				return patches
@@ -115,7 +168,7 @@ func TestCallback(t *testing.T) {
 		{
 			name:   "ttl with access token expiry",
 			state:  state,
-			cookie: "htnn_oidc_nonce_id=" + nonce,
+			cookie: "htnn_oidc_nonce_id=" + nonce + "; " + stateCookie,
 			mock: func() *gomonkey.Patches {
 				token := (&oauth2.Token{
 					Expiry:      time.Now().Add(2 * time.Minute),
@@ -123,8 +176,8 @@ func TestCallback(t *testing.T) {
 				}).WithExtra(map[string]interface{}{
 					"id_token": rawIDToken,
 				})
-				patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "Exchange", token, nil)
-				patches.ApplyMethodReturn(conf.verifier, "Verify", &oidc.IDToken{
+				patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "Exchange", token, nil)
+				patches.ApplyMethodReturn(conf.discovery().verifier, "Verify", &oidc.IDToken{
 					Nonce: "xxx", Expiry: time.Now().Add(2 * time.Hour),
 				}, nil)
 				return patches
@@ -139,15 +192,33 @@ func TestCallback(t *testing.T) {
 		{
 			name:   "bad state",
 			state:  state + "x",
+			cookie: "htnn_oidc_nonce_id=" + nonce + "; " + stateCookie,
+			res:    &api.LocalResponse{Code: 403, Msg: "bad state"},
+		},
+		{
+			name:   "no state cookie",
+			state:  state,
 			cookie: "htnn_oidc_nonce_id=" + nonce,
 			res:    &api.LocalResponse{Code: 403, Msg: "bad state"},
 		},
+		{
+			name:   "state mismatch",
+			state:  state,
+			cookie: "htnn_oidc_nonce_id=" + nonce + "; " + encodeAuthState(conf, generateState(conf.ClientSecret, "https://127.0.0.1:2379/x?y=1"), verifier),
+			res:    &api.LocalResponse{Code: 403, Msg: "bad state"},
+		},
+		{
+			name:   "bad state cookie",
+			state:  state,
+			cookie: "htnn_oidc_nonce_id=" + nonce + "; htnn_oidc_state_id=xxx",
+			res:    &api.LocalResponse{Code: 403, Msg: "bad state"},
+		},
 		{
 			name:   "failed to exchange",
 			state:  state,
-			cookie: "htnn_oidc_nonce_id=" + nonce,
+			cookie: "htnn_oidc_nonce_id=" + nonce + "; " + stateCookie,
 			mock: func() *gomonkey.Patches {
-				patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "Exchange", nil, errors.New("timed out"))
+				patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "Exchange", nil, errors.New("timed out"))
 				return patches
 			},
 			res: &api.LocalResponse{Code: 503, Msg: "failed to exchange code to the token"},
@@ -155,9 +226,9 @@ func TestCallback(t *testing.T) {
 		{
 			name:   "failed to lookup token",
 			state:  state,
-			cookie: "htnn_oidc_nonce_id=" + nonce,
+			cookie: "htnn_oidc_nonce_id=" + nonce + "; " + stateCookie,
 			mock: func() *gomonkey.Patches {
-				patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "Exchange", &oauth2.Token{}, nil)
+				patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "Exchange", &oauth2.Token{}, nil)
 				return patches
 			},
 			res: &api.LocalResponse{Code: 503, Msg: "failed to lookup id token"},
@@ -165,10 +236,10 @@ func TestCallback(t *testing.T) {
 		{
 			name:   "bad token",
 			state:  state,
-			cookie: "htnn_oidc_nonce_id=" + nonce,
+			cookie: "htnn_oidc_nonce_id=" + nonce + "; " + stateCookie,
 			mock: func() *gomonkey.Patches {
-				patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "Exchange", token, nil)
-				patches.ApplyMethodReturn(conf.verifier, "Verify", nil, errors.New("ouch"))
+				patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "Exchange", token, nil)
+				patches.ApplyMethodReturn(conf.discovery().verifier, "Verify", nil, errors.New("ouch"))
 				return patches
 			},
 			res: &api.LocalResponse{Code: 503, Msg: "bad token"},
@@ -176,20 +247,27 @@ func TestCallback(t *testing.T) {
 		{
 			name:   "bad nonce",
 			state:  state,
-			cookie: "htnn_oidc_nonce_id=xxy",
+			cookie: "htnn_oidc_nonce_id=xxy; " + stateCookie,
 			mock: func() *gomonkey.Patches {
-				patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "Exchange", token, nil)
-				patches.ApplyMethodReturn(conf.verifier, "Verify", &oidc.IDToken{Nonce: "xxx"}, nil)
+				patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "Exchange", token, nil)
+				patches.ApplyMethodReturn(conf.discovery().verifier, "Verify", &oidc.IDToken{Nonce: "xxx"}, nil)
 				return patches
 			},
 			res: &api.LocalResponse{Code: 403, Msg: "bad nonce"},
 		},
 		{
-			name:  "bad nonce, no cookie",
-			state: state,
+			name:   "auth flow timed out",
+			state:  state,
+			cookie: "htnn_oidc_nonce_id=" + nonce + "; " + expiredStateCookie,
+			res:    &api.LocalResponse{Code: 403, Msg: "bad state"},
+		},
+		{
+			name:   "bad nonce, no cookie",
+			state:  state,
+			cookie: stateCookie,
 			mock: func() *gomonkey.Patches {
-				patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "Exchange", token, nil)
-				patches.ApplyMethodReturn(conf.verifier, "Verify", &oidc.IDToken{Nonce: "xxx"}, nil)
+				patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "Exchange", token, nil)
+				patches.ApplyMethodReturn(conf.discovery().verifier, "Verify", &oidc.IDToken{Nonce: "xxx"}, nil)
 				return patches
 			},
 			res: &api.LocalResponse{Code: 403, Msg: "bad nonce"},
@@ -244,7 +322,8 @@ func (m *mockTokenSource) Token() (*oauth2.Token, error) {
 func TestAttachInfo(t *testing.T) {
 	conf := getCfg()
 	verifier := oauth2.GenerateVerifier()
-	state := generateState(verifier, conf.ClientSecret, "https://127.0.0.1:2379/x?y=1")
+	state := generateState(conf.ClientSecret, "https://127.0.0.1:2379/x?y=1")
+	stateCookie := encodeAuthState(conf, state, verifier)
 	rawIDToken := "rawIDToken"
 	rawIDToken2 := "rawIDToken2"
 	accessToken := "accessToken"
@@ -259,8 +338,8 @@ func TestAttachInfo(t *testing.T) {
 	})
 	nonce, _ := conf.cookieEncoding.Encode("htnn_oidc_nonce_id", "xxx")
 
-	patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "Exchange", token, nil)
-	patches.ApplyMethodReturn(conf.verifier, "Verify", &oidc.IDToken{
+	patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "Exchange", token, nil)
+	patches.ApplyMethodReturn(conf.discovery().verifier, "Verify", &oidc.IDToken{
 		Nonce: "xxx", Expiry: time.Now().Add(2 * time.Hour),
 	}, nil)
 	defer patches.Reset()
@@ -269,7 +348,7 @@ func TestAttachInfo(t *testing.T) {
 	f := factory(conf, cb).(*filter)
 	h := http.Header{}
 	h.Set(":path", "/echo?code=123&state="+state)
-	h.Set("cookie", "htnn_oidc_nonce_id="+nonce)
+	h.Set("cookie", "htnn_oidc_nonce_id="+nonce+"; "+stateCookie)
 	hdr := envoy.NewRequestHeaderMap(h)
 	res := f.DecodeHeaders(hdr, true)
 	resp := res.(*api.LocalResponse)
@@ -299,6 +378,23 @@ func TestAttachInfo(t *testing.T) {
 		"id_token": rawIDToken2,
 	})
 
+	idTokenExpiringToken, _ := conf.cookieEncoding.Encode("htnn_oidc_token_id", Tokens{
+		Oauth2Token: &oauth2.Token{
+			AccessToken:  accessToken,
+			Expiry:       time.Now().Add(1 * time.Hour),
+			RefreshToken: refreshToken,
+		},
+		IDToken:       rawIDToken,
+		IDTokenExpiry: time.Now().Add(-1 * time.Second),
+	})
+	refreshedAccessTokenWithoutIDToken := &oauth2.Token{
+		AccessToken:  accessToken2,
+		Expiry:       time.Now().Add(1 * time.Hour),
+		RefreshToken: refreshToken,
+	}
+	graceConf := getCfg()
+	graceConf.refreshGrace = 30 * time.Minute
+
 	tests := []struct {
 		name          string
 		encodedToken  string
@@ -308,13 +404,14 @@ func TestAttachInfo(t *testing.T) {
 		authorization string
 		idTokenSet    string
 		checkCookie   func(cookie string)
+		checkRes      func(res api.ResultAction)
 	}{
 		{
 			name:         "sanity",
 			encodedToken: v,
 			mock: func() *gomonkey.Patches {
 				tkSrc := &mockTokenSource{}
-				patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "TokenSource", tkSrc)
+				patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "TokenSource", tkSrc)
 				return patches
 			},
 			res:           api.Continue,
@@ -326,7 +423,7 @@ func TestAttachInfo(t *testing.T) {
 			encodedToken: expiredToken,
 			mock: func() *gomonkey.Patches {
 				tkSrc := &mockTokenSource{}
-				patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "TokenSource", tkSrc)
+				patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "TokenSource", tkSrc)
 				patches.ApplyMethodReturn(tkSrc, "Token", refreshedAccessToken, nil)
 				return patches
 			},
@@ -348,11 +445,36 @@ func TestAttachInfo(t *testing.T) {
 			encodedToken: expiredToken,
 			mock: func() *gomonkey.Patches {
 				tkSrc := &mockTokenSource{}
-				patches := gomonkey.ApplyMethodReturn(conf.oauth2Config, "TokenSource", tkSrc)
+				patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "TokenSource", tkSrc)
 				patches.ApplyMethodReturn(tkSrc, "Token", nil, errors.New("failed to refresh"))
 				return patches
 			},
-			res: &api.LocalResponse{Code: 401},
+			checkRes: func(res api.ResultAction) {
+				// redirect to log in again and clear the session
+				resp := res.(*api.LocalResponse)
+				assert.Equal(t, 302, resp.Code)
+				assert.Contains(t, resp.Header.Get("Location"), "state=")
+				assert.Contains(t, resp.Header.Values("Set-Cookie"), "htnn_oidc_token_id=; Max-Age=0; HttpOnly")
+			},
+		},
+		{
+			name:         "refresh before id token expires",
+			encodedToken: idTokenExpiringToken,
+			mock: func() *gomonkey.Patches {
+				tkSrc := &mockTokenSource{}
+				patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "TokenSource", tkSrc)
+				patches.ApplyMethodReturn(tkSrc, "Token", refreshedAccessTokenWithoutIDToken, nil)
+				return patches
+			},
+			config:        graceConf,
+			res:           api.Continue,
+			authorization: "Bearer accessToken2",
+			// keep the old id token if no new one is returned
+			idTokenSet: rawIDToken,
+			checkCookie: func(cookie string) {
+				// the ttl is from the access token expiry plus the grace period
+				assert.Contains(t, cookie, "Max-Age=5399;")
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -370,7 +492,12 @@ func TestAttachInfo(t *testing.T) {
 			h = http.Header{}
 			hdr = envoy.NewRequestHeaderMap(h)
 
-			assert.Equal(t, tt.res, f.attachInfo(hdr, tt.encodedToken))
+			res := f.attachInfo(hdr, tt.encodedToken)
+			if tt.checkRes != nil {
+				tt.checkRes(res)
+			} else {
+				assert.Equal(t, tt.res, res)
+			}
 			bearer, _ := hdr.Get("authorization")
 			idTokenSet, _ := hdr.Get("my-id-token")
 			assert.Equal(t, tt.authorization, bearer)
@@ -386,3 +513,68 @@ func TestAttachInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestLogout(t *testing.T) {
+	conf := getCfg()
+	conf.LogoutPath = "/logout"
+	token, _ := conf.cookieEncoding.Encode("htnn_oidc_token_id", Tokens{
+		Oauth2Token: &oauth2.Token{
+			AccessToken: "accessToken",
+		},
+		IDToken: "rawIDToken",
+	})
+
+	tests := []struct {
+		name               string
+		endSessionEndpoint string
+		postLogoutURL      string
+		cookie             string
+		code               int
+		location           string
+	}{
+		{
+			name:               "sanity",
+			endSessionEndpoint: "http://127.0.0.1:4444/oauth2/sessions/logout",
+			postLogoutURL:      "http://127.0.0.1:10000/",
+			cookie:             "htnn_oidc_token_id=" + token,
+			code:               302,
+			location:           "http://127.0.0.1:4444/oauth2/sessions/logout?client_id=9119df09-b20b-4c08-ba08-72472dda2cd2&id_token_hint=rawIDToken&post_logout_redirect_uri=http%3A%2F%2F127.0.0.1%3A10000%2F",
+		},
+		{
+			name:               "no session",
+			endSessionEndpoint: "http://127.0.0.1:4444/logout?x=y",
+			code:               302,
+			location:           "http://127.0.0.1:4444/logout?x=y&client_id=9119df09-b20b-4c08-ba08-72472dda2cd2",
+		},
+		{
+			name:          "RP-initiated logout is not supported",
+			postLogoutURL: "http://127.0.0.1:10000/",
+			cookie:        "htnn_oidc_token_id=" + token,
+			code:          302,
+			location:      "http://127.0.0.1:10000/",
+		},
+		{
+			name:   "clear session only",
+			cookie: "htnn_oidc_token_id=" + token,
+			code:   200,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf.discovery().endSessionEndpoint = tt.endSessionEndpoint
+			conf.PostLogoutRedirectUrl = tt.postLogoutURL
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb).(*filter)
+			h := http.Header{}
+			h.Set(":path", "/logout")
+			if tt.cookie != "" {
+				h.Set("cookie", tt.cookie)
+			}
+			hdr := envoy.NewRequestHeaderMap(h)
+			resp := f.DecodeHeaders(hdr, true).(*api.LocalResponse)
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Equal(t, tt.location, resp.Header.Get("Location"))
+			assert.Equal(t, "htnn_oidc_token_id=; Max-Age=0; HttpOnly", resp.Header.Get("Set-Cookie"))
+		})
+	}
+}
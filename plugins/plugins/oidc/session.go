@@ -0,0 +1,315 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+const (
+	defaultSessionPrefix = "htnn_oidc"
+	// defaultSessionTTL is used when the expiry of the tokens is unknown
+	defaultSessionTTL = 24 * time.Hour
+	// revokeTimeout is the timeout to revoke the sessions via the admin API
+	revokeTimeout = 10 * time.Second
+)
+
+var errSessionNotFound = errors.New("session not found")
+
+// session is the data kept in the session store
+type session struct {
+	Tokens
+	Subject string `json:"subject,omitempty"`
+	// SID is the session ID issued by the OIDC provider, which is used in the back-channel logout
+	SID string `json:"sid,omitempty"`
+	// ExpireAt is the absolute expiration time of the session. The idle timeout can't extend
+	// the session beyond it.
+	ExpireAt time.Time `json:"expire_at"`
+}
+
+// ttl returns how long the session should be kept from now
+func (s *session) ttl(idleTimeout time.Duration) time.Duration {
+	ttl := time.Until(s.ExpireAt)
+	if idleTimeout > 0 && idleTimeout < ttl {
+		ttl = idleTimeout
+	}
+	return ttl
+}
+
+type sessionStore interface {
+	// Get returns the session with the given ID. errSessionNotFound is returned if the session
+	// is expired or revoked. The session is extended if the idle timeout is configured.
+	Get(ctx context.Context, id string) (*session, error)
+	// Set saves the session with the given ID. The session is kept until it expires.
+	Set(ctx context.Context, id string, s *session) error
+	// Delete removes the session with the given ID.
+	Delete(ctx context.Context, id string) error
+	// RevokeSubject removes all the sessions of the given subject, and returns the number of
+	// the removed sessions.
+	RevokeSubject(ctx context.Context, subject string) (int, error)
+	// RevokeSID removes all the sessions with the given session ID issued by the OIDC provider,
+	// and returns the number of the removed sessions.
+	RevokeSID(ctx context.Context, sid string) (int, error)
+	// SaveAuthState saves the nonce of the authorization request identified by the state. It is
+	// kept until the auth flow times out.
+	SaveAuthState(ctx context.Context, state string, nonce string, ttl time.Duration) error
+	// ConsumeAuthState removes the state and returns its nonce, so that the state can only be
+	// used once. errSessionNotFound is returned if the state is expired or already used.
+	ConsumeAuthState(ctx context.Context, state string) (string, error)
+}
+
+func generateSessionID() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// extendIndexScript extends the TTL of the index if the new TTL is longer,
+// so that the index is kept as long as the sessions in it exist.
+var extendIndexScript = `
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < tonumber(ARGV[1]) then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return 0
+`
+
+type redisSessionStore struct {
+	client      *redis.Client
+	prefix      string
+	idleTimeout time.Duration
+}
+
+func newRedisSessionStore(conf *oidctype.RedisSessionStore, idleTimeout time.Duration) *redisSessionStore {
+	opt := &redis.Options{
+		Addr:     conf.Address,
+		Username: conf.Username,
+		Password: conf.Password,
+	}
+	if conf.Tls {
+		opt.TLSConfig = &tls.Config{
+			InsecureSkipVerify: conf.TlsSkipVerify,
+		}
+	}
+
+	prefix := conf.Prefix
+	if prefix == "" {
+		prefix = defaultSessionPrefix
+	}
+	return &redisSessionStore{
+		client:      redis.NewClient(opt),
+		prefix:      prefix,
+		idleTimeout: idleTimeout,
+	}
+}
+
+func (s *redisSessionStore) sessionKey(id string) string {
+	return s.prefix + ":session:" + id
+}
+
+func (s *redisSessionStore) subjectKey(subject string) string {
+	return s.prefix + ":subject:" + subject
+}
+
+func (s *redisSessionStore) sidKey(sid string) string {
+	return s.prefix + ":sid:" + sid
+}
+
+func (s *redisSessionStore) stateKey(state string) string {
+	return s.prefix + ":state:" + hashToken(state)
+}
+
+func (s *redisSessionStore) Get(ctx context.Context, id string) (*session, error) {
+	key := s.sessionKey(id)
+	data, err := s.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, errSessionNotFound
+		}
+		return nil, err
+	}
+
+	sess := &session{}
+	err = json.Unmarshal(data, sess)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.idleTimeout > 0 {
+		ttl := sess.ttl(s.idleTimeout)
+		if ttl <= 0 {
+			return nil, errSessionNotFound
+		}
+		// sliding expiration
+		err = s.client.PExpire(ctx, key, ttl).Err()
+		if err != nil {
+			// the session is still valid, so we just log the error
+			api.LogWarnf("failed to extend session %s: %v", id, err)
+		}
+	}
+	return sess, nil
+}
+
+func (s *redisSessionStore) Set(ctx context.Context, id string, sess *session) error {
+	ttl := sess.ttl(s.idleTimeout)
+	if ttl <= 0 {
+		return errors.New("session is expired")
+	}
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, s.sessionKey(id), data, ttl)
+		ttl := time.Until(sess.ExpireAt).Milliseconds()
+		if sess.Subject != "" {
+			subjectKey := s.subjectKey(sess.Subject)
+			pipe.SAdd(ctx, subjectKey, id)
+			pipe.Eval(ctx, extendIndexScript, []string{subjectKey}, ttl)
+		}
+		if sess.SID != "" {
+			sidKey := s.sidKey(sess.SID)
+			pipe.SAdd(ctx, sidKey, id)
+			pipe.Eval(ctx, extendIndexScript, []string{sidKey}, ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+func (s *redisSessionStore) Delete(ctx context.Context, id string) error {
+	// The ID left in the subject index is harmless, it will be removed with the index
+	return s.client.Del(ctx, s.sessionKey(id)).Err()
+}
+
+func (s *redisSessionStore) RevokeSubject(ctx context.Context, subject string) (int, error) {
+	return s.revokeIndex(ctx, s.subjectKey(subject))
+}
+
+func (s *redisSessionStore) RevokeSID(ctx context.Context, sid string) (int, error) {
+	return s.revokeIndex(ctx, s.sidKey(sid))
+}
+
+// revokeIndex removes all the sessions in the index and the index itself
+func (s *redisSessionStore) revokeIndex(ctx context.Context, indexKey string) (int, error) {
+	ids, err := s.client.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = s.sessionKey(id)
+	}
+	var del *redis.IntCmd
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(keys) > 0 {
+			del = pipe.Del(ctx, keys...)
+		}
+		pipe.Del(ctx, indexKey)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if del == nil {
+		return 0, nil
+	}
+	return int(del.Val()), nil
+}
+
+func (s *redisSessionStore) SaveAuthState(ctx context.Context, state string, nonce string, ttl time.Duration) error {
+	return s.client.Set(ctx, s.stateKey(state), nonce, ttl).Err()
+}
+
+func (s *redisSessionStore) ConsumeAuthState(ctx context.Context, state string) (string, error) {
+	nonce, err := s.client.GetDel(ctx, s.stateKey(state)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", errSessionNotFound
+		}
+		return "", err
+	}
+	return nonce, nil
+}
+
+// The session stores are indexed by the address and the prefix, so that the sessions can be
+// revoked via the admin API across the configurations.
+var sessionStores sync.Map
+
+func registerSessionStore(key string, store sessionStore) {
+	sessionStores.Store(key, store)
+}
+
+type revokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+// handleRevokeSessions revokes all the sessions of the subject specified in the query, like
+// `DELETE /oidc/sessions?subject=xxx`.
+func handleRevokeSessions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	subject := r.URL.Query().Get("subject")
+	if subject == "" {
+		http.Error(w, "subject is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), revokeTimeout)
+	defer cancel()
+
+	revoked := 0
+	var err error
+	sessionStores.Range(func(k, v any) bool {
+		n, e := v.(sessionStore).RevokeSubject(ctx, subject)
+		if e != nil {
+			api.LogErrorf("failed to revoke sessions of subject %s in %s: %v", subject, k, e)
+			err = e
+			return true
+		}
+		revoked += n
+		return true
+	})
+	if err != nil {
+		http.Error(w, "failed to revoke sessions", http.StatusServiceUnavailable)
+		return
+	}
+
+	api.LogInfof("revoked %d sessions of subject %s", revoked, subject)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(&revokeSessionsResponse{Revoked: revoked})
+}
+
+func init() {
+	filtermanager.HandleAdmin("/oidc/sessions", http.HandlerFunc(handleRevokeSessions))
+}
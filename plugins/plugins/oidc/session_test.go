@@ -0,0 +1,303 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type memorySessionStore struct {
+	lock     sync.Mutex
+	sessions map[string]*session
+	states   map[string]string
+	err      error
+}
+
+func newMemorySessionStore() *memorySessionStore {
+	return &memorySessionStore{
+		sessions: map[string]*session{},
+		states:   map[string]string{},
+	}
+}
+
+func (s *memorySessionStore) Get(ctx context.Context, id string) (*session, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return nil, s.err
+	}
+	sess, ok := s.sessions[id]
+	if !ok || time.Now().After(sess.ExpireAt) {
+		return nil, errSessionNotFound
+	}
+	return sess, nil
+}
+
+func (s *memorySessionStore) Set(ctx context.Context, id string, sess *session) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.sessions[id] = sess
+	return nil
+}
+
+func (s *memorySessionStore) Delete(ctx context.Context, id string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.sessions, id)
+	return nil
+}
+
+func (s *memorySessionStore) RevokeSubject(ctx context.Context, subject string) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	n := 0
+	for id, sess := range s.sessions {
+		if sess.Subject == subject {
+			delete(s.sessions, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *memorySessionStore) RevokeSID(ctx context.Context, sid string) (int, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+	n := 0
+	for id, sess := range s.sessions {
+		if sess.SID == sid {
+			delete(s.sessions, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *memorySessionStore) SaveAuthState(ctx context.Context, state string, nonce string, ttl time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.states[state] = nonce
+	return nil
+}
+
+func (s *memorySessionStore) ConsumeAuthState(ctx context.Context, state string) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.err != nil {
+		return "", s.err
+	}
+	nonce, ok := s.states[state]
+	if !ok {
+		return "", errSessionNotFound
+	}
+	delete(s.states, state)
+	return nonce, nil
+}
+
+func TestSessionTTL(t *testing.T) {
+	s := &session{ExpireAt: time.Now().Add(time.Hour)}
+	assert.InDelta(t, time.Hour.Seconds(), s.ttl(0).Seconds(), 1)
+	assert.Equal(t, time.Minute, s.ttl(time.Minute))
+	assert.InDelta(t, time.Hour.Seconds(), s.ttl(2*time.Hour).Seconds(), 1)
+}
+
+func TestSessionStore(t *testing.T) {
+	store := newMemorySessionStore()
+	registerSessionStore("TestSessionStore", store)
+	defer sessionStores.Delete("TestSessionStore")
+
+	conf := getCfg()
+	conf.sessionStore = store
+	conf.LogoutPath = "/logout"
+	verifier := oauth2.GenerateVerifier()
+	token := (&oauth2.Token{
+		AccessToken:  "accessToken",
+		RefreshToken: "refreshToken",
+		Expiry:       time.Now().Add(1 * time.Hour),
+	}).WithExtra(map[string]interface{}{
+		"id_token": "rawIDToken",
+	})
+	nonce, _ := conf.cookieEncoding.Encode("htnn_oidc_nonce_id", "xxx")
+
+	patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "Exchange", token, nil)
+	patches.ApplyMethodReturn(conf.discovery().verifier, "Verify", &oidc.IDToken{
+		Nonce: "xxx", Subject: "alice", Expiry: time.Now().Add(2 * time.Hour),
+	}, nil)
+	patches.ApplyMethodReturn(conf.discovery().oauth2Config, "TokenSource", &mockTokenSource{})
+	defer patches.Reset()
+
+	login := func() string {
+		// the state can only be used once
+		state := generateState(conf.ClientSecret, "https://127.0.0.1:2379/x?y=1")
+		stateCookie := encodeAuthState(conf, state, verifier)
+		store.states[state] = "xxx"
+		cb := envoy.NewFilterCallbackHandler()
+		f := factory(conf, cb).(*filter)
+		h := http.Header{}
+		h.Set(":path", "/echo?code=123&state="+state)
+		h.Set("cookie", "htnn_oidc_nonce_id="+nonce+"; "+stateCookie)
+		resp := f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true).(*api.LocalResponse)
+		require.Equal(t, 302, resp.Code)
+		cookie := resp.Header.Get("Set-Cookie")
+		assert.Contains(t, cookie, "Max-Age=7199;")
+		return strings.Split(cookie, ";")[0]
+	}
+	access := func(cookie string) api.ResultAction {
+		cb := envoy.NewFilterCallbackHandler()
+		f := factory(conf, cb).(*filter)
+		h := http.Header{}
+		h.Set(":path", "/echo")
+		h.Set("cookie", cookie)
+		hdr := envoy.NewRequestHeaderMap(h)
+		res := f.DecodeHeaders(hdr, true)
+		if res == api.Continue {
+			bearer, _ := hdr.Get("authorization")
+			assert.Equal(t, "Bearer accessToken", bearer)
+		}
+		return res
+	}
+
+	cookie := login()
+	// only the session ID is stored in the cookie
+	v := strings.SplitN(cookie, "=", 2)[1]
+	var id string
+	require.NoError(t, conf.cookieEncoding.Decode("htnn_oidc_token_id", v, &id))
+	assert.Equal(t, "alice", store.sessions[id].Subject)
+	assert.Equal(t, "rawIDToken", store.sessions[id].IDToken)
+	assert.Equal(t, api.Continue, access(cookie))
+
+	// revoke via the admin API
+	cookie2 := login()
+	assert.Equal(t, api.Continue, access(cookie2))
+	rec := httptest.NewRecorder()
+	handleRevokeSessions(rec, httptest.NewRequest("DELETE", "/oidc/sessions?subject=alice", nil))
+	assert.Equal(t, 200, rec.Code)
+	assert.JSONEq(t, `{"revoked":2}`, rec.Body.String())
+	for _, c := range []string{cookie, cookie2} {
+		resp := access(c).(*api.LocalResponse)
+		// log in again
+		assert.Equal(t, 302, resp.Code)
+		assert.Contains(t, resp.Header.Values("Set-Cookie"), "htnn_oidc_token_id=; Max-Age=0; HttpOnly")
+	}
+
+	// log out
+	cookie = login()
+	cb := envoy.NewFilterCallbackHandler()
+	f := factory(conf, cb).(*filter)
+	h := http.Header{}
+	h.Set(":path", "/logout")
+	h.Set("cookie", cookie)
+	resp := f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true).(*api.LocalResponse)
+	assert.Equal(t, 200, resp.Code)
+	assert.Empty(t, store.sessions)
+
+	// store is unavailable
+	cookie = login()
+	store.err = errors.New("ouch")
+	resp = access(cookie).(*api.LocalResponse)
+	assert.Equal(t, 503, resp.Code)
+}
+
+func TestRevokeSessionsBadRequest(t *testing.T) {
+	rec := httptest.NewRecorder()
+	handleRevokeSessions(rec, httptest.NewRequest("GET", "/oidc/sessions?subject=alice", nil))
+	assert.Equal(t, 405, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handleRevokeSessions(rec, httptest.NewRequest("DELETE", "/oidc/sessions", nil))
+	assert.Equal(t, 400, rec.Code)
+}
+
+func TestAuthStateOneTimeUse(t *testing.T) {
+	store := newMemorySessionStore()
+	conf := getCfg()
+	conf.sessionStore = store
+	token := (&oauth2.Token{
+		AccessToken: "accessToken",
+		Expiry:      time.Now().Add(1 * time.Hour),
+	}).WithExtra(map[string]interface{}{
+		"id_token": "rawIDToken",
+	})
+	idToken := &oidc.IDToken{Subject: "alice", Expiry: time.Now().Add(2 * time.Hour)}
+	patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "Exchange", token, nil)
+	patches.ApplyMethodReturn(conf.discovery().verifier, "Verify", idToken, nil)
+	defer patches.Reset()
+
+	// the state and the nonce are saved when the user is redirected to log in
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	resp := f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true).(*api.LocalResponse)
+	require.Equal(t, 302, resp.Code)
+	require.Len(t, store.states, 1)
+	var state, nonce string
+	for state, nonce = range store.states {
+	}
+	idToken.Nonce = nonce
+	cookies := []string{}
+	for _, c := range resp.Header.Values("Set-Cookie") {
+		cookies = append(cookies, strings.Split(c, ";")[0])
+	}
+
+	callback := func() *api.LocalResponse {
+		f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+		h := http.Header{}
+		h.Set(":path", "/echo?code=123&state="+state)
+		h.Set("cookie", strings.Join(cookies, "; "))
+		return f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true).(*api.LocalResponse)
+	}
+	resp = callback()
+	assert.Equal(t, 302, resp.Code, resp.Msg)
+	assert.Empty(t, store.states)
+
+	// replay the callback
+	resp = callback()
+	assert.Equal(t, 403, resp.Code)
+	assert.Equal(t, "bad state", resp.Msg)
+
+	// the nonce should match the stored one
+	store.states[state] = "other"
+	resp = callback()
+	assert.Equal(t, 403, resp.Code)
+	assert.Equal(t, "bad nonce", resp.Msg)
+
+	store.err = errors.New("ouch")
+	resp = callback()
+	assert.Equal(t, 503, resp.Code)
+	assert.Equal(t, "failed to load state", resp.Msg)
+	resp = f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{}), true).(*api.LocalResponse)
+	assert.Equal(t, 503, resp.Code)
+	assert.Equal(t, "failed to save state", resp.Msg)
+}
@@ -0,0 +1,76 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"errors"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/pkg/expr"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+type skipAuthRule struct {
+	// path is nil if all paths are matched
+	path expr.Matcher
+	// methods is empty if all methods are matched
+	methods map[string]bool
+}
+
+func newSkipAuthRules(paths []*oidctype.SkipAuthPath) ([]*skipAuthRule, error) {
+	rules := make([]*skipAuthRule, 0, len(paths))
+	for _, p := range paths {
+		if p.Path == nil && len(p.Methods) == 0 {
+			return nil, errors.New("skip auth path should specify either path or methods")
+		}
+
+		rule := &skipAuthRule{}
+		if p.Path != nil {
+			m, err := expr.BuildStringMatcher(p.Path)
+			if err != nil {
+				return nil, err
+			}
+			rule.path = m
+		}
+		if len(p.Methods) > 0 {
+			rule.methods = make(map[string]bool, len(p.Methods))
+			for _, method := range p.Methods {
+				rule.methods[method] = true
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (r *skipAuthRule) match(headers api.RequestHeaderMap) bool {
+	if len(r.methods) > 0 && !r.methods[headers.Method()] {
+		return false
+	}
+	if r.path != nil && !r.path.Match(headers.URL().Path) {
+		return false
+	}
+	return true
+}
+
+// skipAuth returns true if the request doesn't need to be authenticated
+func (conf *config) skipAuth(headers api.RequestHeaderMap) bool {
+	for _, r := range conf.skipAuthRules {
+		if r.match(headers) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	apiv1 "mosn.io/htnn/types/plugins/api/v1"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+func TestSkipAuth(t *testing.T) {
+	conf := getCfg()
+	conf.ClaimsToHeaders = []*oidctype.ClaimToHeader{{Claim: "email", Header: "x-email"}}
+	conf.SkipAuthPaths = []*oidctype.SkipAuthPath{
+		{
+			Path: &apiv1.StringMatcher{MatchPattern: &apiv1.StringMatcher_Exact{Exact: "/healthz"}},
+		},
+		{
+			Path:    &apiv1.StringMatcher{MatchPattern: &apiv1.StringMatcher_Regex{Regex: `^/static/.*\.(js|css)$`}},
+			Methods: []string{"GET", "HEAD"},
+		},
+		{
+			Methods: []string{"OPTIONS"},
+		},
+	}
+	rules, err := newSkipAuthRules(conf.SkipAuthPaths)
+	require.NoError(t, err)
+	conf.skipAuthRules = rules
+	// the skipped requests don't need the provider
+	conf.discoverer = &discoverer{}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		skip   bool
+	}{
+		{
+			name:   "exact path",
+			method: "POST",
+			path:   "/healthz?x=1",
+			skip:   true,
+		},
+		{
+			name:   "regex path and method",
+			method: "HEAD",
+			path:   "/static/js/app.js",
+			skip:   true,
+		},
+		{
+			name:   "method mismatched",
+			method: "POST",
+			path:   "/static/js/app.js",
+		},
+		{
+			name:   "path mismatched",
+			method: "GET",
+			path:   "/static/index.html",
+		},
+		{
+			name:   "method only",
+			method: "OPTIONS",
+			path:   "/api",
+			skip:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+			h := http.Header{}
+			h.Set(":method", tt.method)
+			h.Set(":path", tt.path)
+			h.Set("my-id-token", "forged")
+			h.Set("x-email", "forged")
+			hdr := envoy.NewRequestHeaderMap(h)
+			res := f.DecodeHeaders(hdr, true)
+			if tt.skip {
+				assert.Equal(t, api.Continue, res)
+				_, ok := hdr.Get("my-id-token")
+				assert.False(t, ok)
+				_, ok = hdr.Get("x-email")
+				assert.False(t, ok)
+			} else {
+				assert.Equal(t, 503, res.(*api.LocalResponse).Code)
+			}
+		})
+	}
+
+	_, err = newSkipAuthRules([]*oidctype.SkipAuthPath{{}})
+	assert.ErrorContains(t, err, "should specify either path or methods")
+}
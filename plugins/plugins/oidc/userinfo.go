@@ -0,0 +1,75 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+	"golang.org/x/oauth2"
+)
+
+const (
+	defaultUserinfoCacheTTL = 5 * time.Minute
+	// userinfoCacheSize limits the number of the userinfo of the bearer tokens kept in memory
+	userinfoCacheSize = 10000
+)
+
+func newUserinfoCache() *ttlcache.Cache[string, json.RawMessage] {
+	return ttlcache.New(
+		ttlcache.WithCapacity[string, json.RawMessage](userinfoCacheSize),
+		ttlcache.WithDisableTouchOnHit[string, json.RawMessage](),
+	)
+}
+
+// fetchUserinfo fetches the claims from the userinfo endpoint. Only the necessary claims are kept.
+func (f *filter) fetchUserinfo(ctx context.Context, token *oauth2.Token) (json.RawMessage, error) {
+	userinfo, err := f.discovery.provider.UserInfo(ctx, oauth2.StaticTokenSource(token))
+	if err != nil {
+		return nil, err
+	}
+	var claims map[string]interface{}
+	err = userinfo.Claims(&claims)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(f.pickClaims(claims))
+}
+
+// fetchBearerUserinfo fetches the userinfo with the bearer token. The result is cached until
+// the cache TTL is reached or the token expires.
+func (f *filter) fetchBearerUserinfo(ctx context.Context, rawToken string, expiry time.Time) (json.RawMessage, error) {
+	config := f.config
+	key := hashToken(rawToken)
+	if item := config.userinfoCache.Get(key); item != nil {
+		return item.Value(), nil
+	}
+
+	userinfo, err := f.fetchUserinfo(ctx, &oauth2.Token{AccessToken: rawToken, TokenType: "Bearer"})
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := config.userinfoCacheTTL
+	if !expiry.IsZero() {
+		ttl = min(ttl, time.Until(expiry))
+	}
+	if ttl > 0 {
+		config.userinfoCache.Set(key, userinfo, ttl)
+	}
+	return userinfo, nil
+}
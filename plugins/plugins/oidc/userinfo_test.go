@@ -0,0 +1,152 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	oidctype "mosn.io/htnn/types/plugins/oidc"
+)
+
+func TestBearerUserinfo(t *testing.T) {
+	conf := getCfg()
+	conf.BearerToken = &oidctype.BearerToken{}
+	conf.FetchUserinfo = true
+	conf.userinfoCache = newUserinfoCache()
+	conf.userinfoCacheTTL = time.Minute
+	conf.discovery().bearerVerifier = &oidc.IDTokenVerifier{}
+	conf.discovery().provider = &oidc.Provider{}
+	conf.ClaimsToHeaders = []*oidctype.ClaimToHeader{
+		{Claim: "email", Header: "x-email"},
+	}
+	rawToken := fakeJWT(`{"sub":"alice"}`)
+	expiredSoonToken := fakeJWT(`{"sub":"bob"}`)
+
+	fetched := 0
+	patches := gomonkey.ApplyMethod(conf.discovery().bearerVerifier, "Verify",
+		func(_ *oidc.IDTokenVerifier, _ context.Context, raw string) (*oidc.IDToken, error) {
+			expiry := time.Now().Add(time.Hour)
+			if raw == expiredSoonToken {
+				expiry = time.Now().Add(-time.Second)
+			}
+			return &oidc.IDToken{Audience: []string{conf.ClientId}, Expiry: expiry}, nil
+		})
+	patches.ApplyMethod(conf.discovery().provider, "UserInfo",
+		func(_ *oidc.Provider, _ context.Context, ts oauth2.TokenSource) (*oidc.UserInfo, error) {
+			fetched++
+			token, _ := ts.Token()
+			if token.AccessToken == "bad" {
+				return nil, errors.New("unauthorized")
+			}
+			return &oidc.UserInfo{}, nil
+		})
+	patches.ApplyMethod(&oidc.UserInfo{}, "Claims", func(_ *oidc.UserInfo, v interface{}) error {
+		return json.Unmarshal([]byte(`{"email":"alice@example.com","picture":"https://example.com/alice.png"}`), v)
+	})
+	defer patches.Reset()
+
+	for i := 0; i < 2; i++ {
+		f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+		h := http.Header{}
+		h.Set(":path", "/")
+		h.Set("authorization", "Bearer "+rawToken)
+		hdr := envoy.NewRequestHeaderMap(h)
+		assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+		email, _ := hdr.Get("x-email")
+		assert.Equal(t, "alice@example.com", email)
+	}
+	// the userinfo is cached
+	assert.Equal(t, 1, fetched)
+
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	_, err := f.fetchBearerUserinfo(context.Background(), expiredSoonToken, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	_, err = f.fetchBearerUserinfo(context.Background(), expiredSoonToken, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	// not cached after the token expires
+	assert.Equal(t, 3, fetched)
+
+	h := http.Header{}
+	h.Set(":path", "/")
+	h.Set("authorization", "Bearer bad")
+	resp := f.DecodeHeaders(envoy.NewRequestHeaderMap(h), true).(*api.LocalResponse)
+	assert.Equal(t, 503, resp.Code)
+	assert.Equal(t, "failed to fetch userinfo", resp.Msg)
+}
+
+func TestRefreshUserinfo(t *testing.T) {
+	conf := getCfg()
+	conf.FetchUserinfo = true
+	conf.discovery().provider = &oidc.Provider{}
+	conf.ClaimsToHeaders = []*oidctype.ClaimToHeader{
+		{Claim: "email", Header: "x-email"},
+	}
+	refreshed := (&oauth2.Token{
+		AccessToken:  "accessToken2",
+		RefreshToken: "refreshToken",
+		Expiry:       time.Now().Add(time.Hour),
+	}).WithExtra(map[string]interface{}{})
+	encoded, _ := conf.cookieEncoding.Encode("htnn_oidc_token_id", Tokens{
+		Oauth2Token: &oauth2.Token{
+			AccessToken:  "accessToken",
+			RefreshToken: "refreshToken",
+			Expiry:       time.Now().Add(-time.Hour),
+		},
+		IDToken:  fakeJWT(`{"sub":"alice"}`),
+		UserInfo: []byte(`{"email":"old@example.com"}`),
+	})
+
+	email := "new@example.com"
+	patches := gomonkey.ApplyMethodReturn(conf.discovery().oauth2Config, "TokenSource", oauth2.StaticTokenSource(refreshed))
+	patches.ApplyMethod(conf.discovery().provider, "UserInfo",
+		func(_ *oidc.Provider, _ context.Context, _ oauth2.TokenSource) (*oidc.UserInfo, error) {
+			if email == "" {
+				return nil, errors.New("unavailable")
+			}
+			return &oidc.UserInfo{}, nil
+		})
+	patches.ApplyMethod(&oidc.UserInfo{}, "Claims", func(_ *oidc.UserInfo, v interface{}) error {
+		return json.Unmarshal([]byte(`{"email":"`+email+`"}`), v)
+	})
+	defer patches.Reset()
+
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	hdr := envoy.NewRequestHeaderMap(http.Header{})
+	assert.Equal(t, api.Continue, f.attachInfo(hdr, encoded))
+	v, _ := hdr.Get("x-email")
+	assert.Equal(t, "new@example.com", v)
+	require.Len(t, f.tokenCookies, 1)
+
+	// keep the previous userinfo if it can't be fetched
+	email = ""
+	f = factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	hdr = envoy.NewRequestHeaderMap(http.Header{})
+	assert.Equal(t, api.Continue, f.attachInfo(hdr, encoded))
+	v, _ = hdr.Get("x-email")
+	assert.Equal(t, "old@example.com", v)
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/open-policy-agent/opa/rego"
 
+	"mosn.io/htnn/api/pkg/circuitbreaker"
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/plugins"
 	"mosn.io/htnn/types/plugins/opa"
@@ -47,8 +48,9 @@ func (p *plugin) Config() api.PluginConfig {
 type config struct {
 	opa.CustomConfig
 
-	client *http.Client
-	query  rego.PreparedEvalQuery
+	client  *http.Client
+	breaker *circuitbreaker.Breaker
+	query   rego.PreparedEvalQuery
 }
 
 var (
@@ -65,6 +67,9 @@ func (conf *config) Init(cb api.ConfigCallbackHandler) error {
 			timeout = 200 * time.Millisecond
 		}
 		conf.client = &http.Client{Timeout: timeout}
+		if remote.CircuitBreaker != nil {
+			conf.breaker = circuitbreaker.Get(opa.Name+":"+remote.Url, remote.CircuitBreaker.ToConfig())
+		}
 		return nil
 	}
 
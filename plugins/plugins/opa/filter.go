@@ -95,7 +95,17 @@ func (f *filter) isAllowed(input map[string]interface{}) (bool, error) {
 
 		path := remote.GetUrl() + "/v1/data/" + remote.GetPolicy()
 		api.LogInfof("send request to opa: %s, param: %s", path, params)
+		var done func(success bool)
+		if f.config.breaker != nil {
+			done, err = f.config.breaker.Allow()
+			if err != nil {
+				return false, err
+			}
+		}
 		resp, err := f.config.client.Post(path, "application/json", bytes.NewReader(params))
+		if done != nil {
+			done(err == nil && resp.StatusCode < 500)
+		}
 		if err != nil {
 			return false, err
 		}
@@ -27,6 +27,7 @@ import (
 
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	v1 "mosn.io/htnn/types/plugins/api/v1"
 	"mosn.io/htnn/types/plugins/opa"
 )
 
@@ -198,3 +199,37 @@ func TestOpaLocal(t *testing.T) {
 		})
 	}
 }
+
+func TestOpaRemoteCircuitBreaker(t *testing.T) {
+	conf := &config{
+		CustomConfig: opa.CustomConfig{
+			Config: opa.Config{
+				ConfigType: &opa.Config_Remote{
+					Remote: &opa.Remote{
+						Url:    "http://127.0.0.1:8181/breaker",
+						Policy: "httpapi/authz",
+						CircuitBreaker: &v1.CircuitBreaker{
+							FailureThreshold: 1,
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.NoError(t, conf.Init(nil))
+
+	called := 0
+	patches := gomonkey.ApplyMethodFunc(conf.client, "Post",
+		func(url, contentType string, body io.Reader) (*http.Response, error) {
+			called++
+			return &http.Response{StatusCode: 500, Body: http.NoBody}, nil
+		})
+	defer patches.Reset()
+
+	hdr := envoy.NewRequestHeaderMap(http.Header{})
+	for i := 0; i < 2; i++ {
+		f := factory(conf, envoy.NewFilterCallbackHandler())
+		assert.Equal(t, &api.LocalResponse{Code: 503}, f.DecodeHeaders(hdr, true))
+	}
+	assert.Equal(t, 1, called)
+}
@@ -0,0 +1,163 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redirect
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/redirect"
+)
+
+const (
+	defaultForceHttpsStatusCode = 301
+	defaultRuleStatusCode       = 302
+	defaultHstsMaxAge           = 365 * 24 * time.Hour
+)
+
+func init() {
+	plugins.RegisterPlugin(redirect.Name, &plugin{})
+}
+
+type plugin struct {
+	redirect.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type rule struct {
+	*redirect.Rule
+
+	regex      *regexp.Regexp
+	statusCode int
+}
+
+type config struct {
+	redirect.CustomConfig
+
+	forceHttpsStatusCode int
+	// httpsPort is empty when the default port is used
+	httpsPort string
+	// hsts is the value of the Strict-Transport-Security header
+	hsts  string
+	rules []*rule
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if fh := conf.ForceHttps; fh != nil {
+		conf.forceHttpsStatusCode = defaultForceHttpsStatusCode
+		if fh.StatusCode != 0 {
+			conf.forceHttpsStatusCode = int(fh.StatusCode)
+		}
+		if fh.Port != 0 && fh.Port != 443 {
+			conf.httpsPort = strconv.Itoa(int(fh.Port))
+		}
+		if fh.Hsts != nil {
+			conf.hsts = hstsValue(fh.Hsts)
+		}
+	}
+
+	conf.rules = make([]*rule, len(conf.Rules))
+	for i, r := range conf.Rules {
+		conf.rules[i] = &rule{
+			Rule:       r,
+			statusCode: defaultRuleStatusCode,
+		}
+		if r.PathRegex != "" {
+			conf.rules[i].regex = regexp.MustCompile(r.PathRegex)
+		}
+		if r.StatusCode != 0 {
+			conf.rules[i].statusCode = int(r.StatusCode)
+		}
+	}
+	return nil
+}
+
+func hstsValue(hsts *redirect.Hsts) string {
+	maxAge := defaultHstsMaxAge
+	if hsts.MaxAge != nil {
+		maxAge = hsts.MaxAge.AsDuration()
+	}
+	value := "max-age=" + strconv.FormatInt(int64(maxAge.Seconds()), 10)
+	if hsts.IncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if hsts.Preload {
+		value += "; preload"
+	}
+	return value
+}
+
+// httpsHost replaces the port of the host with the HTTPS port
+func (conf *config) httpsHost(host string) string {
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	} else {
+		hostname = strings.TrimSuffix(strings.TrimPrefix(hostname, "["), "]")
+	}
+
+	if conf.httpsPort != "" {
+		return net.JoinHostPort(hostname, conf.httpsPort)
+	}
+	if strings.Contains(hostname, ":") {
+		// IPv6 address
+		return "[" + hostname + "]"
+	}
+	return hostname
+}
+
+// location returns the redirect URL if the rule matches the request
+func (r *rule) location(scheme, host, path, query string) (string, bool) {
+	if r.regex != nil {
+		match := r.regex.FindStringSubmatchIndex(path)
+		if match == nil {
+			return "", false
+		}
+		if r.Path != "" {
+			path = string(r.regex.ExpandString(nil, r.Path, path, match))
+		}
+	} else if r.Path != "" {
+		path = r.Path
+	}
+
+	if r.Scheme != "" {
+		scheme = r.Scheme
+	}
+	if r.Host != "" {
+		host = r.Host
+	}
+
+	loc := scheme + "://" + host + path
+	if query != "" && !r.StripQuery {
+		if strings.Contains(path, "?") {
+			loc += "&" + query
+		} else {
+			loc += "?" + query
+		}
+	}
+	return loc, true
+}
@@ -0,0 +1,80 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redirect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "force https",
+			input: `{"forceHttps":{"statusCode":308,"port":8443,"hsts":{"maxAge":"3600s","includeSubdomains":true}}}`,
+		},
+		{
+			name:  "rules",
+			input: `{"rules":[{"pathRegex":"^/old/(.*)$","path":"/new/$1","statusCode":301},{"host":"example.com"}]}`,
+		},
+		{
+			name:  "empty",
+			input: `{}`,
+			err:   "one of forceHttps and rules is required",
+		},
+		{
+			name:  "bad status code",
+			input: `{"rules":[{"host":"example.com","statusCode":200}]}`,
+			err:   "invalid Rule.StatusCode: value must be in list [301 302 307 308]",
+		},
+		{
+			name:  "bad scheme",
+			input: `{"rules":[{"scheme":"ftp"}]}`,
+			err:   "invalid Rule.Scheme: value must be in list [http https]",
+		},
+		{
+			name:  "bad port",
+			input: `{"forceHttps":{"port":65536}}`,
+			err:   "invalid ForceHttps.Port: value must be less than or equal to 65535",
+		},
+		{
+			name:  "bad regex",
+			input: `{"rules":[{"pathRegex":"(","path":"/"}]}`,
+			err:   "bad pathRegex of rule 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
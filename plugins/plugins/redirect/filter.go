@@ -0,0 +1,80 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redirect
+
+import (
+	"net/http"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	https bool
+}
+
+// requestScheme returns the scheme used by the client. The X-Forwarded-Proto header is preferred,
+// so that the requests terminated TLS by the load balancer in front of the gateway are recognized.
+func requestScheme(headers api.RequestHeaderMap) string {
+	if proto, ok := headers.Get("x-forwarded-proto"); ok && proto != "" {
+		proto, _, _ = strings.Cut(proto, ",")
+		return strings.ToLower(strings.TrimSpace(proto))
+	}
+	return headers.Scheme()
+}
+
+func redirectTo(code int, loc string) *api.LocalResponse {
+	hdr := http.Header{}
+	hdr.Set("location", loc)
+	return &api.LocalResponse{Code: code, Header: hdr}
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	scheme := requestScheme(headers)
+	f.https = scheme == "https"
+	if config.ForceHttps != nil && scheme == "http" {
+		return redirectTo(config.forceHttpsStatusCode, "https://"+config.httpsHost(headers.Host())+headers.Path())
+	}
+
+	path, query, _ := strings.Cut(headers.Path(), "?")
+	for _, r := range config.rules {
+		if loc, ok := r.location(scheme, headers.Host(), path, query); ok {
+			api.LogDebugf("redirect: redirect %s to %s", headers.Path(), loc)
+			return redirectTo(r.statusCode, loc)
+		}
+	}
+	return api.Continue
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	// The header sent over HTTP is ignored by the browsers, as it can be injected by the attackers
+	if f.https && f.config.hsts != "" {
+		headers.Set("strict-transport-security", f.config.hsts)
+	}
+	return api.Continue
+}
@@ -0,0 +1,184 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redirect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestForceHttps(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		header http.Header
+		code   int
+		loc    string
+	}{
+		{
+			name:   "http",
+			config: `{"forceHttps":{}}`,
+			header: http.Header{":authority": {"example.com:8080"}, ":path": {"/a?b=1"}},
+			code:   301,
+			loc:    "https://example.com/a?b=1",
+		},
+		{
+			name:   "https",
+			config: `{"forceHttps":{}}`,
+			header: http.Header{":scheme": {"https"}},
+		},
+		{
+			name:   "terminated by load balancer",
+			config: `{"forceHttps":{}}`,
+			header: http.Header{"X-Forwarded-Proto": {"HTTPS"}},
+		},
+		{
+			name:   "forwarded http",
+			config: `{"forceHttps":{"statusCode":308,"port":8443}}`,
+			header: http.Header{":scheme": {"https"}, "X-Forwarded-Proto": {"http"}, ":authority": {"[::1]:8080"}},
+			code:   308,
+			loc:    "https://[::1]:8443/",
+		},
+		{
+			name:   "IPv6 without port",
+			config: `{"forceHttps":{}}`,
+			header: http.Header{":authority": {"[::1]"}},
+			code:   301,
+			loc:    "https://[::1]/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.config), conf))
+			require.NoError(t, conf.Init(nil))
+			f := factory(conf, envoy.NewFilterCallbackHandler())
+			res := f.DecodeHeaders(envoy.NewRequestHeaderMap(tt.header), true)
+			if tt.code == 0 {
+				assert.Equal(t, api.Continue, res)
+				return
+			}
+			resp := res.(*api.LocalResponse)
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Equal(t, tt.loc, resp.Header.Get("location"))
+		})
+	}
+}
+
+func TestHsts(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"forceHttps":{"hsts":{"includeSubdomains":true,"preload":true}}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{":scheme": {"https"}}), true)
+	hdr := envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(hdr, true)
+	v, _ := hdr.Get("strict-transport-security")
+	assert.Equal(t, "max-age=31536000; includeSubDomains; preload", v)
+
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"forceHttps":{"hsts":{"maxAge":"0s"}}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{":scheme": {"https"}}), true)
+	hdr = envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(hdr, true)
+	v, _ = hdr.Get("strict-transport-security")
+	assert.Equal(t, "max-age=0", v)
+
+	// not sent over HTTP
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"forceHttps":{"hsts":{}},"rules":[{"host":"example.com"}]}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	f.DecodeHeaders(envoy.NewRequestHeaderMap(http.Header{":scheme": {"http"}}), true)
+	hdr = envoy.NewResponseHeaderMap(http.Header{})
+	f.EncodeHeaders(hdr, true)
+	_, ok := hdr.Get("strict-transport-security")
+	assert.False(t, ok)
+}
+
+func TestRules(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"rules":[
+		{"pathRegex":"^/old/(?P<rest>.*)$","path":"/new/${rest}","statusCode":301},
+		{"pathRegex":"^/docs$","host":"docs.example.com","path":"/index.html?lang=en","stripQuery":true},
+		{"pathRegex":"^/search","scheme":"https","host":"search.example.com","path":"/q?from=gw","statusCode":307},
+		{"pathRegex":"^/legacy/","host":"legacy.example.com:8080","statusCode":308}
+	]}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	tests := []struct {
+		name string
+		path string
+		code int
+		loc  string
+	}{
+		{
+			name: "regex capture",
+			path: "/old/a/b?c=1",
+			code: 301,
+			loc:  "http://example.com/new/a/b?c=1",
+		},
+		{
+			name: "strip query",
+			path: "/docs?x=1",
+			code: 302,
+			loc:  "http://docs.example.com/index.html?lang=en",
+		},
+		{
+			name: "merge query",
+			path: "/search?k=v",
+			code: 307,
+			loc:  "https://search.example.com/q?from=gw&k=v",
+		},
+		{
+			name: "keep path",
+			path: "/legacy/x",
+			code: 308,
+			loc:  "http://legacy.example.com:8080/legacy/x",
+		},
+		{
+			name: "not matched",
+			path: "/docs/a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler())
+			hdr := envoy.NewRequestHeaderMap(http.Header{
+				":authority": {"example.com"},
+				":path":      {tt.path},
+			})
+			res := f.DecodeHeaders(hdr, true)
+			if tt.code == 0 {
+				assert.Equal(t, api.Continue, res)
+				return
+			}
+			resp := res.(*api.LocalResponse)
+			assert.Equal(t, tt.code, resp.Code)
+			assert.Equal(t, tt.loc, resp.Header.Get("location"))
+		})
+	}
+}
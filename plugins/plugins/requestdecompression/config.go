@@ -0,0 +1,126 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestdecompression
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zlib"
+	"github.com/klauspost/compress/zstd"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/requestdecompression"
+)
+
+const (
+	defaultMaxBodySize = 1 << 20
+)
+
+var errTooLarge = errors.New("decompressed body is too large")
+
+type decoder func(r io.Reader) (io.ReadCloser, error)
+
+type zstdReader struct {
+	*zstd.Decoder
+}
+
+func (r *zstdReader) Close() error {
+	r.Decoder.Close()
+	return nil
+}
+
+var decoders = map[string]decoder{
+	"gzip": func(r io.Reader) (io.ReadCloser, error) {
+		return gzip.NewReader(r)
+	},
+	"br": func(r io.Reader) (io.ReadCloser, error) {
+		return io.NopCloser(brotli.NewReader(r)), nil
+	},
+	"zstd": func(r io.Reader) (io.ReadCloser, error) {
+		d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return &zstdReader{d}, nil
+	},
+	// the `deflate` in HTTP is the zlib format
+	"deflate": func(r io.Reader) (io.ReadCloser, error) {
+		return zlib.NewReader(r)
+	},
+}
+
+// decompress returns errTooLarge if the decompressed data is larger than the limit
+func decompress(dec decoder, data []byte, limit int) ([]byte, error) {
+	r, err := dec(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	// read one more byte to know if the data exceeds the limit
+	res, err := io.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(res) > limit {
+		return nil, errTooLarge
+	}
+	return res, nil
+}
+
+func init() {
+	plugins.RegisterPlugin(requestdecompression.Name, &plugin{})
+}
+
+type plugin struct {
+	requestdecompression.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	requestdecompression.Config
+
+	decoders    map[string]decoder
+	maxBodySize int
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if len(conf.Encodings) == 0 {
+		conf.decoders = decoders
+	} else {
+		conf.decoders = make(map[string]decoder, len(conf.Encodings))
+		for _, name := range conf.Encodings {
+			conf.decoders[name] = decoders[name]
+		}
+	}
+
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestdecompression
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	// the decoders in the order they are applied
+	decoders []decoder
+}
+
+// parseEncodings returns the encodings in the Content-Encoding, in the order they are applied
+func parseEncodings(headers api.RequestHeaderMap) []string {
+	var encodings []string
+	for _, v := range headers.Values("content-encoding") {
+		for _, enc := range strings.Split(v, ",") {
+			enc = strings.ToLower(strings.TrimSpace(enc))
+			if enc != "" && enc != "identity" {
+				encodings = append(encodings, enc)
+			}
+		}
+	}
+	return encodings
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	if endStream {
+		return api.Continue
+	}
+
+	config := f.config
+	encodings := parseEncodings(headers)
+	if len(encodings) == 0 {
+		if _, ok := headers.Get("content-length"); !ok && config.NormalizeChunked {
+			return api.WaitAllData
+		}
+		return api.Continue
+	}
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		dec, ok := config.decoders[encodings[i]]
+		if !ok {
+			if config.RejectUnknownEncoding {
+				return &api.LocalResponse{Code: http.StatusUnsupportedMediaType, Msg: "unsupported content encoding"}
+			}
+			// let the upstream handle the unknown encodings
+			return api.Continue
+		}
+		f.decoders = append(f.decoders, dec)
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	var body []byte
+	if data != nil {
+		body = data.Bytes()
+	}
+
+	if len(body) > 0 {
+		for _, dec := range f.decoders {
+			var err error
+			body, err = decompress(dec, body, f.config.maxBodySize)
+			if err != nil {
+				if errors.Is(err, errTooLarge) {
+					return &api.LocalResponse{Code: http.StatusRequestEntityTooLarge}
+				}
+				api.LogInfof("requestDecompression: failed to decompress request body: %v", err)
+				return &api.LocalResponse{Code: http.StatusBadRequest, Msg: "bad compressed body"}
+			}
+		}
+		if len(f.decoders) > 0 {
+			_ = data.Set(body)
+		}
+	}
+
+	headers.Del("content-encoding")
+	headers.Del("transfer-encoding")
+	headers.Set("content-length", strconv.Itoa(len(body)))
+	return api.Continue
+}
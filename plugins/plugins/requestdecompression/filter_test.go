@@ -0,0 +1,153 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requestdecompression
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func gzipData(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func brData(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	w := brotli.NewWriter(&buf)
+	_, err := w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestDecompress(t *testing.T) {
+	body := []byte(`{"name":"htnn"}`)
+	tests := []struct {
+		name   string
+		config string
+		header http.Header
+		body   []byte
+		res    api.ResultAction
+		code   int
+	}{
+		{
+			name:   "gzip",
+			header: http.Header{"Content-Encoding": []string{"gzip"}, "Content-Length": []string{"100"}},
+			body:   gzipData(t, body),
+		},
+		{
+			name:   "multiple encodings",
+			header: http.Header{"Content-Encoding": []string{"gzip, BR"}},
+			body:   brData(t, gzipData(t, body)),
+		},
+		{
+			name:   "bad body",
+			header: http.Header{"Content-Encoding": []string{"gzip"}},
+			body:   body,
+			code:   400,
+		},
+		{
+			name:   "too large",
+			config: `{"maxBodySize":8}`,
+			header: http.Header{"Content-Encoding": []string{"gzip"}},
+			body:   gzipData(t, body),
+			code:   413,
+		},
+		{
+			name:   "unknown encoding",
+			config: `{"encodings":["gzip"]}`,
+			header: http.Header{"Content-Encoding": []string{"br"}},
+			res:    api.Continue,
+		},
+		{
+			name:   "reject unknown encoding",
+			config: `{"encodings":["gzip"],"rejectUnknownEncoding":true}`,
+			header: http.Header{"Content-Encoding": []string{"br"}},
+			code:   415,
+		},
+		{
+			name:   "chunked",
+			config: `{"normalizeChunked":true}`,
+			header: http.Header{"Transfer-Encoding": []string{"chunked"}},
+			body:   body,
+		},
+		{
+			name:   "not chunked",
+			config: `{"normalizeChunked":true}`,
+			header: http.Header{"Content-Length": []string{"15"}},
+			res:    api.Continue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.config
+			if input == "" {
+				input = "{}"
+			}
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+			require.NoError(t, conf.Init(nil))
+			f := factory(conf, envoy.NewFilterCallbackHandler())
+			hdr := envoy.NewRequestHeaderMap(tt.header)
+			res := f.DecodeHeaders(hdr, false)
+			if tt.res != nil {
+				assert.Equal(t, tt.res, res)
+				return
+			}
+			if tt.code == 415 {
+				assert.Equal(t, tt.code, res.(*api.LocalResponse).Code)
+				return
+			}
+			require.Equal(t, api.WaitAllData, res)
+
+			buf := envoy.NewBufferInstance(tt.body)
+			res = f.DecodeRequest(hdr, buf, nil)
+			if tt.code != 0 {
+				assert.Equal(t, tt.code, res.(*api.LocalResponse).Code)
+				return
+			}
+			require.Equal(t, api.Continue, res)
+			assert.Equal(t, string(body), buf.String())
+			_, ok := hdr.Get("content-encoding")
+			assert.False(t, ok)
+			_, ok = hdr.Get("transfer-encoding")
+			assert.False(t, ok)
+			cl, _ := hdr.Get("content-length")
+			assert.Equal(t, "15", cl)
+		})
+	}
+}
+
+func TestParseEncodings(t *testing.T) {
+	hdr := envoy.NewRequestHeaderMap(http.Header{"Content-Encoding": []string{"identity, gzip", " Zstd "}})
+	assert.Equal(t, "gzip,zstd", strings.Join(parseEncodings(hdr), ","))
+}
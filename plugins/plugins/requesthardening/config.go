@@ -0,0 +1,117 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requesthardening
+
+import (
+	"net"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/requesthardening"
+)
+
+var defaultSingleValueHeaders = []string{"authorization", "content-type", "x-forwarded-host", "x-forwarded-proto"}
+
+func init() {
+	plugins.RegisterPlugin(requesthardening.Name, &plugin{})
+}
+
+type plugin struct {
+	requesthardening.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	requesthardening.Config
+
+	hosts map[string]bool
+	// hostSuffixes are the suffixes of the wildcard hosts, like ".example.com"
+	hostSuffixes       []string
+	singleValueHeaders []string
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.hosts = map[string]bool{}
+	for _, h := range conf.AllowedHosts {
+		h = strings.ToLower(h)
+		if suffix, ok := strings.CutPrefix(h, "*"); ok {
+			conf.hostSuffixes = append(conf.hostSuffixes, suffix)
+		} else {
+			conf.hosts[h] = true
+		}
+	}
+
+	headers := conf.SingleValueHeaders
+	if len(headers) == 0 {
+		headers = defaultSingleValueHeaders
+	}
+	conf.singleValueHeaders = make([]string, len(headers))
+	for i, h := range headers {
+		conf.singleValueHeaders[i] = strings.ToLower(h)
+	}
+	return nil
+}
+
+// normalizeHost returns the host without the port and the trailing dot, in lowercase
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(host, ".")
+	return strings.ToLower(host)
+}
+
+// validHost checks if the host only contains the characters allowed in a domain name or an IP
+func validHost(host string) bool {
+	if host == "" {
+		return false
+	}
+	if strings.HasPrefix(host, "[") || strings.Contains(host, ":") {
+		// IPv6 literal. The brackets are removed when the port is split.
+		return net.ParseIP(strings.Trim(host, "[]")) != nil
+	}
+	for i := 0; i < len(host); i++ {
+		c := host[i]
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '.' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// hostAllowed checks the normalized host against the allowed hosts
+func (conf *config) hostAllowed(host string) bool {
+	if len(conf.AllowedHosts) == 0 {
+		return true
+	}
+	if conf.hosts[host] {
+		return true
+	}
+	for _, suffix := range conf.hostSuffixes {
+		// the wildcard doesn't match the domain itself
+		if len(host) > len(suffix) && strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
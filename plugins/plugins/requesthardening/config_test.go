@@ -0,0 +1,92 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requesthardening
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+		},
+		{
+			name:  "bad host",
+			input: `{"allowedHosts":["a.*.com"]}`,
+			err:   "invalid Config.AllowedHosts[0]",
+		},
+		{
+			name:  "bad header",
+			input: `{"singleValueHeaders":["a:b"]}`,
+			err:   "invalid Config.SingleValueHeaders[0]",
+		},
+		{
+			name:  "ok",
+			input: `{"allowedHosts":["example.com","*.example.com"],"canonicalHost":"example.com","singleValueHeaders":["X-Api-Key"],"rejectDuplicateHeaders":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				err = conf.Init(nil)
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestHostAllowed(t *testing.T) {
+	conf := &config{}
+	conf.AllowedHosts = []string{"Example.com", "*.api.example.com"}
+	assert.Nil(t, conf.Init(nil))
+
+	for host, allowed := range map[string]bool{
+		"example.com":         true,
+		"www.example.com":     false,
+		"a.api.example.com":   true,
+		"a.b.api.example.com": true,
+		"api.example.com":     false,
+		"xapi.example.com":    false,
+	} {
+		assert.Equal(t, allowed, conf.hostAllowed(host), host)
+	}
+
+	assert.Equal(t, "example.com", normalizeHost("Example.COM.:8080"))
+	assert.Equal(t, "::1", normalizeHost("[::1]:80"))
+	assert.True(t, validHost("::1"))
+	assert.True(t, validHost("[::1]"))
+	assert.False(t, validHost("a:b"))
+	assert.False(t, validHost("evil.com/a"))
+	assert.False(t, validHost("user@evil.com"))
+	assert.False(t, validHost(""))
+}
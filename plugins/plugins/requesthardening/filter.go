@@ -0,0 +1,168 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requesthardening
+
+import (
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+func badRequest(msg string) *api.LocalResponse {
+	return &api.LocalResponse{Code: 400, Msg: msg}
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// checkDuplicateHeaders keeps the first value of the headers which should not be repeated
+func (f *filter) checkDuplicateHeaders(headers api.RequestHeaderMap) api.ResultAction {
+	for _, name := range f.config.singleValueHeaders {
+		values := headers.Values(name)
+		if len(values) <= 1 {
+			continue
+		}
+		if f.config.RejectDuplicateHeaders {
+			return badRequest("duplicate header " + name)
+		}
+		headers.Set(name, values[0])
+	}
+	return nil
+}
+
+// checkFraming validates the combinations of Content-Length and Transfer-Encoding, which may
+// be interpreted differently by the gateway and the upstream
+func (f *filter) checkFraming(headers api.RequestHeaderMap) api.ResultAction {
+	cl := ""
+	for _, value := range headers.Values("content-length") {
+		// the identical values are allowed, like "42, 42"
+		for _, v := range strings.Split(value, ",") {
+			v = strings.TrimSpace(v)
+			if !isDigits(v) {
+				return badRequest("invalid content-length")
+			}
+			if cl != "" && v != cl {
+				return badRequest("conflicting content-length")
+			}
+			cl = v
+		}
+	}
+	if cl != "" {
+		headers.Set("content-length", cl)
+	}
+
+	tes := headers.Values("transfer-encoding")
+	if len(tes) == 0 {
+		return nil
+	}
+	if cl != "" {
+		return badRequest("both content-length and transfer-encoding are set")
+	}
+	if len(tes) > 1 || !strings.EqualFold(strings.TrimSpace(tes[0]), "chunked") {
+		return badRequest("unsupported transfer-encoding")
+	}
+	return nil
+}
+
+// checkTarget rejects the request target which is not in the origin form, like the absolute
+// URI, which may be used to smuggle a different host to the upstream
+func (f *filter) checkTarget(headers api.RequestHeaderMap) api.ResultAction {
+	method := headers.Method()
+	if method == "CONNECT" {
+		return nil
+	}
+
+	path := headers.Path()
+	if path == "*" {
+		if method != "OPTIONS" {
+			return badRequest("invalid path")
+		}
+	} else if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") {
+		return badRequest("invalid path")
+	}
+
+	scheme := headers.Scheme()
+	if scheme != "" && scheme != "http" && scheme != "https" {
+		return badRequest("invalid scheme")
+	}
+	for _, proto := range headers.Values("x-forwarded-proto") {
+		if proto != "http" && proto != "https" {
+			return badRequest("invalid x-forwarded-proto")
+		}
+	}
+	return nil
+}
+
+func (f *filter) checkHost(headers api.RequestHeaderMap) api.ResultAction {
+	config := f.config
+	host := normalizeHost(headers.Host())
+	if !validHost(host) {
+		return badRequest("invalid host")
+	}
+	if !config.hostAllowed(host) {
+		return &api.LocalResponse{Code: 421, Msg: "misdirected request"}
+	}
+
+	if fh, ok := headers.Get("x-forwarded-host"); ok {
+		fh = normalizeHost(fh)
+		if !validHost(fh) || !config.hostAllowed(fh) {
+			api.LogInfof("requestHardening: remove disallowed x-forwarded-host %q", fh)
+			headers.Del("x-forwarded-host")
+		}
+	}
+
+	if config.CanonicalHost != "" {
+		headers.SetHost(config.CanonicalHost)
+	}
+	return nil
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	checks := []func(api.RequestHeaderMap) api.ResultAction{
+		f.checkDuplicateHeaders,
+		f.checkFraming,
+		f.checkTarget,
+		f.checkHost,
+	}
+	for _, check := range checks {
+		if res := check(headers); res != nil {
+			return res
+		}
+	}
+	return api.Continue
+}
@@ -0,0 +1,224 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package requesthardening
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestHardening(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		header  http.Header
+		code    int
+		msg     string
+		expect  http.Header
+		removed []string
+	}{
+		{
+			name:  "ok",
+			input: `{}`,
+			header: http.Header{
+				"Content-Length": {"3"},
+			},
+		},
+		{
+			name:  "keep first value",
+			input: `{}`,
+			header: http.Header{
+				"Authorization":  {"a", "b"},
+				"X-Custom":       {"a", "b"},
+				"Content-Length": {"3, 3"},
+			},
+			expect: http.Header{
+				"authorization":  {"a"},
+				"x-custom":       {"a", "b"},
+				"content-length": {"3"},
+			},
+		},
+		{
+			name:  "reject duplicate headers",
+			input: `{"singleValueHeaders":["X-Api-Key"],"rejectDuplicateHeaders":true}`,
+			header: http.Header{
+				"X-Api-Key": {"a", "b"},
+			},
+			code: 400,
+			msg:  "duplicate header x-api-key",
+		},
+		{
+			name:   "invalid content-length",
+			input:  `{}`,
+			header: http.Header{"Content-Length": {"-1"}},
+			code:   400,
+			msg:    "invalid content-length",
+		},
+		{
+			name:   "conflicting content-length",
+			input:  `{}`,
+			header: http.Header{"Content-Length": {"3", "4"}},
+			code:   400,
+			msg:    "conflicting content-length",
+		},
+		{
+			name:   "content-length with transfer-encoding",
+			input:  `{}`,
+			header: http.Header{"Content-Length": {"3"}, "Transfer-Encoding": {"chunked"}},
+			code:   400,
+			msg:    "both content-length and transfer-encoding are set",
+		},
+		{
+			name:   "chunked",
+			input:  `{}`,
+			header: http.Header{"Transfer-Encoding": {"Chunked"}},
+		},
+		{
+			name:   "unsupported transfer-encoding",
+			input:  `{}`,
+			header: http.Header{"Transfer-Encoding": {"gzip, chunked"}},
+			code:   400,
+			msg:    "unsupported transfer-encoding",
+		},
+		{
+			name:   "absolute uri",
+			input:  `{}`,
+			header: http.Header{":path": {"http://evil.com/"}},
+			code:   400,
+			msg:    "invalid path",
+		},
+		{
+			name:   "scheme-relative path",
+			input:  `{}`,
+			header: http.Header{":path": {"//evil.com/"}},
+			code:   400,
+			msg:    "invalid path",
+		},
+		{
+			name:   "asterisk",
+			input:  `{}`,
+			header: http.Header{":method": {"OPTIONS"}, ":path": {"*"}},
+		},
+		{
+			name:   "asterisk with other method",
+			input:  `{}`,
+			header: http.Header{":path": {"*"}},
+			code:   400,
+			msg:    "invalid path",
+		},
+		{
+			name:   "connect",
+			input:  `{}`,
+			header: http.Header{":method": {"CONNECT"}, ":path": {""}},
+		},
+		{
+			name:   "invalid scheme",
+			input:  `{}`,
+			header: http.Header{":scheme": {"ftp"}},
+			code:   400,
+			msg:    "invalid scheme",
+		},
+		{
+			name:   "invalid x-forwarded-proto",
+			input:  `{}`,
+			header: http.Header{"X-Forwarded-Proto": {"https, http"}},
+			code:   400,
+			msg:    "invalid x-forwarded-proto",
+		},
+		{
+			name:   "invalid host",
+			input:  `{}`,
+			header: http.Header{":authority": {"user@evil.com"}},
+			code:   400,
+			msg:    "invalid host",
+		},
+		{
+			name:   "host not allowed",
+			input:  `{"allowedHosts":["example.com"]}`,
+			header: http.Header{":authority": {"evil.com"}},
+			code:   421,
+			msg:    "misdirected request",
+		},
+		{
+			name:  "canonical host",
+			input: `{"allowedHosts":["example.com","*.example.com"],"canonicalHost":"example.com"}`,
+			header: http.Header{
+				":authority":       {"WWW.Example.com.:443"},
+				"X-Forwarded-Host": {"evil.com"},
+			},
+			expect: http.Header{
+				":authority": {"example.com"},
+			},
+			removed: []string{"x-forwarded-host"},
+		},
+		{
+			name:  "allowed x-forwarded-host",
+			input: `{"allowedHosts":["*.example.com"]}`,
+			header: http.Header{
+				":authority":       {"a.example.com"},
+				"X-Forwarded-Host": {"b.example.com"},
+			},
+			expect: http.Header{
+				":authority":       {"a.example.com"},
+				"x-forwarded-host": {"b.example.com"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			require.NoError(t, conf.Validate())
+			require.NoError(t, conf.Init(nil))
+
+			h := http.Header{
+				":authority": {"example.com"},
+				":method":    {"POST"},
+				":path":      {"/echo"},
+				":scheme":    {"https"},
+			}
+			for k, v := range tt.header {
+				h[k] = v
+			}
+			f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+			hdr := envoy.NewRequestHeaderMap(h)
+			res := f.DecodeHeaders(hdr, false)
+			if tt.code != 0 {
+				resp, ok := res.(*api.LocalResponse)
+				require.True(t, ok)
+				assert.Equal(t, tt.code, resp.Code)
+				assert.Equal(t, tt.msg, resp.Msg)
+				return
+			}
+
+			assert.Equal(t, api.Continue, res)
+			for k, v := range tt.expect {
+				assert.Equal(t, v, hdr.Values(k), k)
+			}
+			for _, k := range tt.removed {
+				_, ok := hdr.Get(k)
+				assert.False(t, ok, k)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responsesigning
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/responsesigning"
+)
+
+const (
+	defaultSignatureHeader = "x-htnn-signature"
+	defaultMaxBodySize     = 4 << 20
+)
+
+func init() {
+	plugins.RegisterPlugin(responsesigning.Name, &plugin{})
+}
+
+type plugin struct {
+	responsesigning.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	responsesigning.Config
+
+	newHash         func() hash.Hash
+	algorithm       string
+	signedHeaders   []string
+	signatureHeader string
+	maxBodySize     int
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	switch conf.Algorithm {
+	case responsesigning.Algorithm_HMAC_SHA256:
+		conf.newHash = sha256.New
+		conf.algorithm = "hmac-sha256"
+	case responsesigning.Algorithm_HMAC_SHA384:
+		conf.newHash = sha512.New384
+		conf.algorithm = "hmac-sha384"
+	case responsesigning.Algorithm_HMAC_SHA512:
+		conf.newHash = sha512.New
+		conf.algorithm = "hmac-sha512"
+	}
+
+	conf.signedHeaders = make([]string, len(conf.SignedHeaders))
+	for i, h := range conf.SignedHeaders {
+		conf.signedHeaders[i] = strings.ToLower(h)
+	}
+
+	conf.signatureHeader = defaultSignatureHeader
+	if conf.SignatureHeader != "" {
+		conf.signatureHeader = conf.SignatureHeader
+	}
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+	return nil
+}
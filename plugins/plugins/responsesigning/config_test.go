@@ -0,0 +1,67 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responsesigning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "secret key required",
+			input: `{}`,
+			err:   "invalid Config.SecretKey",
+		},
+		{
+			name:  "bad signed header",
+			input: `{"secretKey":"s","signedHeaders":["a:b"]}`,
+			err:   "invalid Config.SignedHeaders[0]",
+		},
+		{
+			name:  "bad algorithm",
+			input: `{"secretKey":"s","algorithm":10}`,
+			err:   "invalid Config.Algorithm",
+		},
+		{
+			name:  "ok",
+			input: `{"secretKey":"s","keyId":"k1","algorithm":"HMAC_SHA512","signedHeaders":["Content-Type"],"signatureHeader":"x-sig","maxBodySize":1024}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				err = conf.Init(nil)
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responsesigning
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/sse"
+)
+
+const (
+	// The digest of the body, in the format defined in RFC 9530
+	digestHeader = "content-digest"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+// digest returns the value of the Content-Digest header for the body
+func digest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+}
+
+// getSignContent returns the content to sign, which contains the status code, the signed headers
+// and the digest of the body, one per line
+func (f *filter) getSignContent(headers api.ResponseHeaderMap) string {
+	status, _ := headers.Get(":status")
+
+	buf := strings.Builder{}
+	buf.WriteString(status)
+	buf.WriteByte('\n')
+	for _, h := range f.config.signedHeaders {
+		buf.WriteString(h)
+		buf.WriteByte(':')
+		buf.WriteString(strings.Join(headers.Values(h), ", "))
+		buf.WriteByte('\n')
+	}
+	d, _ := headers.Get(digestHeader)
+	buf.WriteString(digestHeader)
+	buf.WriteByte(':')
+	buf.WriteString(d)
+	buf.WriteByte('\n')
+	return buf.String()
+}
+
+func (f *filter) sign(headers api.ResponseHeaderMap, body []byte) {
+	config := f.config
+	headers.Set(digestHeader, digest(body))
+
+	mac := hmac.New(config.newHash, []byte(config.SecretKey))
+	mac.Write([]byte(f.getSignContent(headers)))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	buf := strings.Builder{}
+	if config.KeyId != "" {
+		buf.WriteString(`keyId="`)
+		buf.WriteString(config.KeyId)
+		buf.WriteString(`",`)
+	}
+	buf.WriteString(`algorithm="`)
+	buf.WriteString(config.algorithm)
+	buf.WriteString(`",headers="`)
+	buf.WriteString(strings.Join(config.signedHeaders, " "))
+	buf.WriteString(`",signature="`)
+	buf.WriteString(signature)
+	buf.WriteByte('"')
+	headers.Set(config.signatureHeader, buf.String())
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	// the signature from the upstream can't be trusted
+	headers.Del(config.signatureHeader)
+	headers.Del(digestHeader)
+
+	if endStream {
+		f.sign(headers, nil)
+		return api.Continue
+	}
+	if sse.IsEventStream(headers) {
+		// the event stream may never end, so it can't be buffered
+		return api.Continue
+	}
+	if cl, ok := headers.Get("content-length"); ok {
+		n, err := strconv.Atoi(cl)
+		if err == nil && n > config.maxBodySize {
+			api.LogInfof("responseSigning: body size %d exceeds the limit, skip signing", n)
+			return api.Continue
+		}
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	var body []byte
+	if data != nil {
+		if data.Len() > f.config.maxBodySize {
+			api.LogInfof("responseSigning: body size %d exceeds the limit, skip signing", data.Len())
+			return api.Continue
+		}
+		body = data.Bytes()
+	}
+	f.sign(headers, body)
+	return api.Continue
+}
@@ -0,0 +1,129 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responsesigning
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"hash"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func hmacSign(h func() hash.Hash, key string, content string) string {
+	mac := hmac.New(h, []byte(key))
+	mac.Write([]byte(content))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestSign(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"secretKey":"secret","keyId":"k1","signedHeaders":["Content-Type","X-Multi","X-Missing"]}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	hdr := envoy.NewResponseHeaderMap(http.Header{
+		":status":          {"200"},
+		"Content-Type":     {"application/json"},
+		"X-Multi":          {"a", "b"},
+		"X-Htnn-Signature": {"forged"},
+	})
+	require.Equal(t, api.WaitAllData, f.EncodeHeaders(hdr, false))
+	_, ok := hdr.Get("x-htnn-signature")
+	assert.False(t, ok)
+
+	body := []byte(`{"id":1}`)
+	assert.Equal(t, api.Continue, f.EncodeResponse(hdr, envoy.NewBufferInstance(body), nil))
+
+	sum := sha256.Sum256(body)
+	d := "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+	v, _ := hdr.Get("content-digest")
+	assert.Equal(t, d, v)
+
+	content := "200\ncontent-type:application/json\nx-multi:a, b\nx-missing:\ncontent-digest:" + d + "\n"
+	expected := `keyId="k1",algorithm="hmac-sha256",headers="content-type x-multi x-missing",signature="` +
+		hmacSign(sha256.New, "secret", content) + `"`
+	v, _ = hdr.Get("x-htnn-signature")
+	assert.Equal(t, expected, v)
+}
+
+func TestSignWithoutBody(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"secretKey":"secret","algorithm":"HMAC_SHA512","signatureHeader":"x-sig"}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	hdr := envoy.NewResponseHeaderMap(http.Header{":status": {"204"}})
+	assert.Equal(t, api.Continue, f.EncodeHeaders(hdr, true))
+
+	sum := sha256.Sum256(nil)
+	d := "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+	expected := `algorithm="hmac-sha512",headers="",signature="` +
+		hmacSign(sha512.New, "secret", "204\ncontent-digest:"+d+"\n") + `"`
+	v, _ := hdr.Get("x-sig")
+	assert.Equal(t, expected, v)
+}
+
+func TestSkipSigning(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"secretKey":"secret","maxBodySize":4}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	tests := []struct {
+		name   string
+		header http.Header
+		body   string
+	}{
+		{
+			name:   "event stream",
+			header: http.Header{":status": {"200"}, "Content-Type": {"text/event-stream"}},
+		},
+		{
+			name:   "too large",
+			header: http.Header{":status": {"200"}, "Content-Length": {"5"}},
+		},
+		{
+			// the body without content-length is checked after it's received
+			name:   "too large without content-length",
+			header: http.Header{":status": {"200"}},
+			body:   "12345",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+			hdr := envoy.NewResponseHeaderMap(tt.header)
+			res := f.EncodeHeaders(hdr, false)
+			if tt.body != "" {
+				require.Equal(t, api.WaitAllData, res)
+				f.EncodeResponse(hdr, envoy.NewBufferInstance([]byte(tt.body)), nil)
+			} else {
+				require.Equal(t, api.Continue, res)
+			}
+			_, ok := hdr.Get("x-htnn-signature")
+			assert.False(t, ok)
+			_, ok = hdr.Get("content-digest")
+			assert.False(t, ok)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responsetransformer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+func getField(obj map[string]interface{}, path []string) (interface{}, bool) {
+	var cur interface{} = obj
+	for _, seg := range path {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[seg]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// setField sets the value in the path. The missing or non-object parent fields are replaced with
+// the new objects.
+func setField(obj map[string]interface{}, path []string, value interface{}) {
+	cur := obj
+	for _, seg := range path[:len(path)-1] {
+		next, ok := cur[seg].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			cur[seg] = next
+		}
+		cur = next
+	}
+	cur[path[len(path)-1]] = value
+}
+
+func removeField(obj map[string]interface{}, path []string) {
+	cur := obj
+	for _, seg := range path[:len(path)-1] {
+		next, ok := cur[seg].(map[string]interface{})
+		if !ok {
+			return
+		}
+		cur = next
+	}
+	delete(cur, path[len(path)-1])
+}
+
+// transformFields applies the field operations in the order of keep, remove and set
+func (conf *config) transformFields(body []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	// keep the precision of the numbers
+	dec.UseNumber()
+	var obj map[string]interface{}
+	err := dec.Decode(&obj)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, errors.New("body is not a JSON object")
+	}
+
+	if len(conf.keepFields) > 0 {
+		kept := map[string]interface{}{}
+		for _, path := range conf.keepFields {
+			if v, ok := getField(obj, path); ok {
+				setField(kept, path, v)
+			}
+		}
+		obj = kept
+	}
+	for _, path := range conf.removeFields {
+		removeField(obj, path)
+	}
+	for _, f := range conf.setFields {
+		setField(obj, f.path, f.value)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// don't escape the characters like '<' which are escaped by json.Marshal
+	enc.SetEscapeHTML(false)
+	err = enc.Encode(obj)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func (conf *config) transform(body []byte) ([]byte, error) {
+	if conf.hasFieldOps() {
+		var err error
+		body, err = conf.transformFields(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range conf.regexReplaces {
+		body = r.regex.ReplaceAll(body, []byte(r.replacement))
+	}
+	return body, nil
+}
@@ -0,0 +1,119 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responsetransformer
+
+import (
+	"regexp"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/responsetransformer"
+)
+
+const (
+	defaultMaxBodySize = 1 << 20
+)
+
+func init() {
+	plugins.RegisterPlugin(responsetransformer.Name, &plugin{})
+}
+
+type plugin struct {
+	responsetransformer.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type fieldSetter struct {
+	path  []string
+	value interface{}
+}
+
+type regexReplace struct {
+	regex       *regexp.Regexp
+	replacement string
+}
+
+type config struct {
+	responsetransformer.CustomConfig
+
+	maxBodySize  int
+	contentTypes map[string]bool
+
+	keepFields    [][]string
+	removeFields  [][]string
+	setFields     []*fieldSetter
+	regexReplaces []*regexReplace
+}
+
+func splitPaths(paths []string) [][]string {
+	res := make([][]string, 0, len(paths))
+	for _, p := range paths {
+		res = append(res, strings.Split(p, "."))
+	}
+	return res
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+
+	contentTypes := conf.ContentTypes
+	if len(contentTypes) == 0 {
+		contentTypes = []string{"application/json"}
+	}
+	conf.contentTypes = make(map[string]bool, len(contentTypes))
+	for _, ct := range contentTypes {
+		conf.contentTypes[strings.ToLower(ct)] = true
+	}
+
+	body := conf.Body
+	if body == nil {
+		return nil
+	}
+	conf.keepFields = splitPaths(body.KeepFields)
+	conf.removeFields = splitPaths(body.RemoveFields)
+	for _, f := range body.SetFields {
+		conf.setFields = append(conf.setFields, &fieldSetter{
+			path:  strings.Split(f.Path, "."),
+			value: f.Value.AsInterface(),
+		})
+	}
+	for _, r := range body.RegexReplaces {
+		conf.regexReplaces = append(conf.regexReplaces, &regexReplace{
+			// already validated
+			regex:       regexp.MustCompile(r.Regex),
+			replacement: r.Replacement,
+		})
+	}
+	return nil
+}
+
+func (conf *config) hasFieldOps() bool {
+	return len(conf.keepFields) > 0 || len(conf.removeFields) > 0 || len(conf.setFields) > 0
+}
+
+func (conf *config) transformBody() bool {
+	return conf.hasFieldOps() || len(conf.regexReplaces) > 0
+}
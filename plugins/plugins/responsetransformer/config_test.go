@@ -0,0 +1,88 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responsetransformer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+		},
+		{
+			name:  "bad header",
+			input: `{"setHeaders":[{"key":"","value":"v"}]}`,
+			err:   "invalid HeaderValue.Key",
+		},
+		{
+			name:  "bad path",
+			input: `{"body":{"removeFields":["a..b"]}}`,
+			err:   "bad field path a..b",
+		},
+		{
+			name:  "value required",
+			input: `{"body":{"setFields":[{"path":"a"}]}}`,
+			err:   "invalid Field.Value",
+		},
+		{
+			name:  "bad regex",
+			input: `{"body":{"regexReplaces":[{"regex":"(a"}]}}`,
+			err:   "bad regex (a",
+		},
+		{
+			name: "ok",
+			input: `{
+				"status": 201,
+				"setHeaders": [{"key":"x-a","value":"a"}],
+				"removeHeaders": ["server"],
+				"body": {
+					"keepFields": ["data"],
+					"setFields": [{"path":"data.version","value":2}],
+					"regexReplaces": [{"regex":"http://([a-z.]+)","replacement":"https://$1"}]
+				},
+				"maxBodySize": 1024,
+				"contentTypes": ["application/json", "text/plain"]
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responsetransformer
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/sse"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+// shouldTransformBody checks if the response body can be transformed according to the headers
+func (f *filter) shouldTransformBody(headers api.ResponseHeaderMap) bool {
+	config := f.config
+	if !config.transformBody() {
+		return false
+	}
+
+	if enc, ok := headers.Get("content-encoding"); ok && enc != "" && !strings.EqualFold(enc, "identity") {
+		// we can't transform the compressed body
+		return false
+	}
+	if sse.IsEventStream(headers) {
+		// the event stream may never end, so it can't be buffered
+		return false
+	}
+	ct, ok := headers.Get("content-type")
+	if !ok {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil || !config.contentTypes[mediaType] {
+		return false
+	}
+	if cl, ok := headers.Get("content-length"); ok {
+		n, err := strconv.Atoi(cl)
+		if err == nil && n > config.maxBodySize {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	if config.Status != 0 {
+		headers.Set(":status", strconv.Itoa(int(config.Status)))
+	}
+	for _, name := range config.RemoveHeaders {
+		headers.Del(name)
+	}
+	for _, hdr := range config.SetHeaders {
+		headers.Set(hdr.Key, hdr.Value)
+	}
+	for _, hdr := range config.AddHeaders {
+		headers.Add(hdr.Key, hdr.Value)
+	}
+
+	if endStream || !f.shouldTransformBody(headers) {
+		return api.Continue
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	if data == nil || data.Len() == 0 {
+		return api.Continue
+	}
+	if data.Len() > f.config.maxBodySize {
+		api.LogInfof("responseTransformer: body size %d exceeds the limit, skip transforming", data.Len())
+		return api.Continue
+	}
+
+	body, err := f.config.transform(data.Bytes())
+	if err != nil {
+		api.LogInfof("responseTransformer: failed to transform body: %v", err)
+		return api.Continue
+	}
+	_ = data.Set(body)
+	headers.Set("content-length", strconv.Itoa(len(body)))
+	return api.Continue
+}
@@ -0,0 +1,159 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responsetransformer
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestTransformHeaders(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"status": 201,
+		"setHeaders": [{"key":"x-set","value":"a"}],
+		"addHeaders": [{"key":"x-add","value":"b"}],
+		"removeHeaders": ["server"]
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	h := http.Header{}
+	h.Set(":status", "200")
+	h.Set("server", "legacy")
+	h.Set("x-set", "old")
+	h.Set("x-add", "old")
+	h.Set("content-type", "application/json")
+	hdr := envoy.NewResponseHeaderMap(h)
+	// no body transformation is configured
+	assert.Equal(t, api.Continue, f.EncodeHeaders(hdr, false))
+
+	v, _ := hdr.Get(":status")
+	assert.Equal(t, "201", v)
+	_, ok := hdr.Get("server")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"a"}, hdr.Values("x-set"))
+	assert.Equal(t, []string{"old", "b"}, hdr.Values("x-add"))
+}
+
+func TestTransformBody(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"body": {
+			"keepFields": ["data.id", "data.url", "code", "missing"],
+			"removeFields": ["code"],
+			"setFields": [
+				{"path":"data.version","value":2},
+				{"path":"meta.source","value":"<gateway>"}
+			],
+			"regexReplaces": [{"regex":"http://([a-z.]+)","replacement":"https://$1"}]
+		}
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	tests := []struct {
+		name      string
+		header    map[string]string
+		body      string
+		endStream bool
+		wait      bool
+		expected  string
+	}{
+		{
+			name:     "transform",
+			header:   map[string]string{"content-type": "application/json; charset=utf-8"},
+			body:     `{"code":0,"data":{"id":12345678901234567890,"url":"http://example.com/a","secret":"s"},"extra":[1]}`,
+			wait:     true,
+			expected: `{"data":{"id":12345678901234567890,"url":"https://example.com/a","version":2},"meta":{"source":"<gateway>"}}`,
+		},
+		{
+			name:     "not an object",
+			header:   map[string]string{"content-type": "application/json"},
+			body:     `[1, 2]`,
+			wait:     true,
+			expected: `[1, 2]`,
+		},
+		{
+			name:   "content type mismatched",
+			header: map[string]string{"content-type": "text/html"},
+		},
+		{
+			name:   "compressed",
+			header: map[string]string{"content-type": "application/json", "content-encoding": "gzip"},
+		},
+		{
+			name:   "too large",
+			header: map[string]string{"content-type": "application/json", "content-length": "1048577"},
+		},
+		{
+			name:      "no body",
+			header:    map[string]string{"content-type": "application/json"},
+			endStream: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+			h := http.Header{}
+			for k, v := range tt.header {
+				h.Set(k, v)
+			}
+			hdr := envoy.NewResponseHeaderMap(h)
+			res := f.EncodeHeaders(hdr, tt.endStream)
+			if !tt.wait {
+				assert.Equal(t, api.Continue, res)
+				return
+			}
+
+			assert.Equal(t, api.WaitAllData, res)
+			buf := envoy.NewBufferInstance([]byte(tt.body))
+			assert.Equal(t, api.Continue, f.EncodeResponse(hdr, buf, nil))
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestTransformBodyContentLength(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"maxBodySize": 16,
+		"contentTypes": ["text/plain"],
+		"body": {"regexReplaces": [{"regex":"a+","replacement":"b"}]}
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f := factory(conf, envoy.NewFilterCallbackHandler()).(*filter)
+	h := http.Header{}
+	h.Set("content-type", "text/plain")
+	hdr := envoy.NewResponseHeaderMap(h)
+	require.Equal(t, api.WaitAllData, f.EncodeHeaders(hdr, false))
+
+	buf := envoy.NewBufferInstance([]byte("aaaa"))
+	f.EncodeResponse(hdr, buf, nil)
+	assert.Equal(t, "b", buf.String())
+	v, _ := hdr.Get("content-length")
+	assert.Equal(t, "1", v)
+
+	// the body without content-length is checked after it's received
+	buf = envoy.NewBufferInstance([]byte("aaaaaaaaaaaaaaaaa"))
+	f.EncodeResponse(hdr, buf, nil)
+	assert.Equal(t, "aaaaaaaaaaaaaaaaa", buf.String())
+}
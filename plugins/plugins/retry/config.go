@@ -0,0 +1,96 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/retry"
+)
+
+func init() {
+	plugins.RegisterPlugin(retry.Name, &plugin{})
+}
+
+type plugin struct {
+	retry.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type header struct {
+	name  string
+	value string
+}
+
+type config struct {
+	retry.CustomConfig
+
+	headers []header
+}
+
+func durationToMs(d time.Duration) string {
+	ms := d.Milliseconds()
+	if ms == 0 {
+		// Envoy treats 0 as no timeout
+		ms = 1
+	}
+	return strconv.FormatInt(ms, 10)
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	retryOn := conf.RetryOn
+	if len(conf.RetriableStatusCodes) > 0 {
+		found := false
+		for _, cond := range retryOn {
+			if cond == "retriable-status-codes" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			retryOn = append(retryOn[:len(retryOn):len(retryOn)], "retriable-status-codes")
+		}
+
+		codes := make([]string, len(conf.RetriableStatusCodes))
+		for i, code := range conf.RetriableStatusCodes {
+			codes[i] = strconv.FormatUint(uint64(code), 10)
+		}
+		conf.headers = append(conf.headers, header{"x-envoy-retriable-status-codes", strings.Join(codes, ",")})
+	}
+	if len(retryOn) > 0 {
+		conf.headers = append(conf.headers, header{"x-envoy-retry-on", strings.Join(retryOn, ",")})
+	}
+	if conf.NumRetries > 0 {
+		conf.headers = append(conf.headers, header{"x-envoy-max-retries", strconv.FormatUint(uint64(conf.NumRetries), 10)})
+	}
+	if conf.PerTryTimeout != nil {
+		conf.headers = append(conf.headers, header{"x-envoy-upstream-rq-per-try-timeout-ms", durationToMs(conf.PerTryTimeout.AsDuration())})
+	}
+	if conf.Timeout != nil {
+		conf.headers = append(conf.headers, header{"x-envoy-upstream-rq-timeout-ms", durationToMs(conf.Timeout.AsDuration())})
+	}
+	return nil
+}
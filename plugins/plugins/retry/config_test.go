@@ -0,0 +1,89 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "retry",
+			input: `{"retryOn":["5xx","reset"],"numRetries":3,"perTryTimeout":"1s","timeout":"5s"}`,
+		},
+		{
+			name:  "retriable status codes",
+			input: `{"retriableStatusCodes":[429,503],"numRetries":2}`,
+		},
+		{
+			name:  "timeout only",
+			input: `{"timeout":"10s"}`,
+		},
+		{
+			name:  "bad status code",
+			input: `{"retriableStatusCodes":[99]}`,
+			err:   "invalid Config.RetriableStatusCodes[0]: value must be inside range [100, 599]",
+		},
+		{
+			name:  "too many retries",
+			input: `{"retryOn":["5xx"],"numRetries":11}`,
+			err:   "invalid Config.NumRetries: value must be less than or equal to 10",
+		},
+		{
+			name:  "bad timeout",
+			input: `{"timeout":"0s"}`,
+			err:   "invalid Config.Timeout: value must be greater than 0s",
+		},
+		{
+			name:  "unknown condition",
+			input: `{"retryOn":["5xx","timeout"]}`,
+			err:   "unknown retry condition timeout",
+		},
+		{
+			name:  "retries without condition",
+			input: `{"numRetries":3}`,
+			err:   "retryOn or retriableStatusCodes is required when numRetries is set",
+		},
+		{
+			name:  "per try timeout is too long",
+			input: `{"perTryTimeout":"5s","timeout":"5s"}`,
+			err:   "perTryTimeout should be less than timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
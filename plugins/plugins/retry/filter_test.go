@@ -0,0 +1,101 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		header http.Header
+		expect map[string]string
+	}{
+		{
+			name:  "retry",
+			input: `{"retryOn":["5xx","reset"],"numRetries":3,"perTryTimeout":"1.5s","timeout":"5s"}`,
+			expect: map[string]string{
+				"x-envoy-retry-on":                       "5xx,reset",
+				"x-envoy-max-retries":                    "3",
+				"x-envoy-upstream-rq-per-try-timeout-ms": "1500",
+				"x-envoy-upstream-rq-timeout-ms":         "5000",
+				"x-envoy-retriable-status-codes":         "",
+			},
+		},
+		{
+			name:  "retriable status codes",
+			input: `{"retryOn":["connect-failure"],"retriableStatusCodes":[429,503]}`,
+			expect: map[string]string{
+				"x-envoy-retry-on":               "connect-failure,retriable-status-codes",
+				"x-envoy-retriable-status-codes": "429,503",
+				"x-envoy-max-retries":            "",
+			},
+		},
+		{
+			name:  "retriable status codes with the condition",
+			input: `{"retryOn":["retriable-status-codes"],"retriableStatusCodes":[503]}`,
+			expect: map[string]string{
+				"x-envoy-retry-on":               "retriable-status-codes",
+				"x-envoy-retriable-status-codes": "503",
+			},
+		},
+		{
+			name:  "override the client's headers",
+			input: `{"retryOn":["gateway-error"],"numRetries":1,"timeout":"0.0001s"}`,
+			header: http.Header{
+				"X-Envoy-Retry-On":               {"5xx"},
+				"X-Envoy-Max-Retries":            {"10"},
+				"X-Envoy-Upstream-Rq-Timeout-Ms": {"0"},
+			},
+			expect: map[string]string{
+				"x-envoy-retry-on":               "gateway-error",
+				"x-envoy-max-retries":            "1",
+				"x-envoy-upstream-rq-timeout-ms": "1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			require.NoError(t, conf.Validate())
+			require.NoError(t, conf.Init(nil))
+
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb)
+			h := http.Header{}
+			for k, v := range tt.header {
+				h[k] = v
+			}
+			hdr := envoy.NewRequestHeaderMap(h)
+			assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+			for k, v := range tt.expect {
+				actual, _ := hdr.Get(k)
+				assert.Equal(t, v, actual, k)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinel
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/alibaba/sentinel-golang/core/base"
+	"github.com/alibaba/sentinel-golang/core/circuitbreaker"
+	"github.com/alibaba/sentinel-golang/core/flow"
+	"github.com/alibaba/sentinel-golang/core/hotspot"
+	"github.com/alibaba/sentinel-golang/core/system"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	sentineldc "mosn.io/htnn/plugins/dynamicconfigs/sentinel"
+	"mosn.io/htnn/types/plugins/sentinel"
+)
+
+func init() {
+	plugins.RegisterPlugin(sentinel.Name, &plugin{})
+}
+
+type plugin struct {
+	sentinel.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type ruleKey struct {
+	blockType base.BlockType
+	id        string
+}
+
+type config struct {
+	sentinel.CustomConfig
+
+	blockResponses map[ruleKey]*sentinel.BlockResponse
+	// errorStatusCodes are the status codes counted as errors by the circuit breaker rules,
+	// grouped by resource
+	errorStatusCodes map[string]map[uint32]struct{}
+}
+
+// ruleID returns the ID to look up the block response of the rule. The rule without ID is
+// identified by its position.
+func ruleID(id string, kind string, idx int) string {
+	if id != "" {
+		return id
+	}
+	return fmt.Sprintf("%s-%d", kind, idx)
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if err := sentineldc.Init(); err != nil {
+		return err
+	}
+
+	conf.blockResponses = make(map[ruleKey]*sentinel.BlockResponse)
+	conf.errorStatusCodes = make(map[string]map[uint32]struct{})
+
+	// The rules of Sentinel are global, so the rules of a resource replace the ones loaded by
+	// other routes.
+	if err := conf.loadFlowRules(); err != nil {
+		return err
+	}
+	if err := conf.loadHotSpotRules(); err != nil {
+		return err
+	}
+	return conf.loadCircuitBreakerRules()
+}
+
+func (conf *config) loadFlowRules() error {
+	rulesOfRes := make(map[string][]*flow.Rule)
+	for i, r := range conf.GetFlow().GetRules() {
+		rule := &flow.Rule{
+			ID:                     ruleID(r.Id, "flow", i),
+			Resource:               r.Resource,
+			TokenCalculateStrategy: flow.TokenCalculateStrategy(r.TokenCalculateStrategy),
+			ControlBehavior:        flow.ControlBehavior(r.ControlBehavior),
+			Threshold:              r.Threshold,
+			RelationStrategy:       flow.RelationStrategy(r.RelationStrategy),
+			RefResource:            r.RefResource,
+			MaxQueueingTimeMs:      r.MaxQueueingTimeMs,
+			WarmUpPeriodSec:        r.WarmUpPeriodSec,
+			WarmUpColdFactor:       r.WarmUpColdFactor,
+			StatIntervalInMs:       r.StatIntervalInMs,
+			LowMemUsageThreshold:   r.LowMemUsageThreshold,
+			HighMemUsageThreshold:  r.HighMemUsageThreshold,
+			MemLowWaterMarkBytes:   r.MemLowWaterMarkBytes,
+			MemHighWaterMarkBytes:  r.MemHighWaterMarkBytes,
+		}
+		// Sentinel ignores the invalid rules silently, so we check them first
+		if err := flow.IsValidRule(rule); err != nil {
+			return fmt.Errorf("invalid flow rule %s: %w", rule.ID, err)
+		}
+		rulesOfRes[rule.Resource] = append(rulesOfRes[rule.Resource], rule)
+		conf.blockResponses[ruleKey{base.BlockTypeFlow, rule.ID}] = r.BlockResponse
+	}
+
+	for res, rules := range rulesOfRes {
+		if _, err := flow.LoadRulesOfResource(res, rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (conf *config) loadHotSpotRules() error {
+	rulesOfRes := make(map[string][]*hotspot.Rule)
+	for i, r := range conf.GetHotSpot().GetRules() {
+		items := make(map[interface{}]int64, len(r.SpecificItems))
+		for k, v := range r.SpecificItems {
+			items[k] = v
+		}
+		rule := &hotspot.Rule{
+			ID:                ruleID(r.Id, "hotSpot", i),
+			Resource:          r.Resource,
+			MetricType:        hotspot.MetricType(r.MetricType),
+			ControlBehavior:   hotspot.ControlBehavior(r.ControlBehavior),
+			ParamIndex:        int(r.ParamIndex),
+			ParamKey:          r.ParamKey,
+			Threshold:         r.Threshold,
+			MaxQueueingTimeMs: r.MaxQueueingTimeMs,
+			BurstCount:        r.BurstCount,
+			DurationInSec:     r.DurationInSec,
+			ParamsMaxCapacity: r.ParamsMaxCapacity,
+			SpecificItems:     items,
+		}
+		if err := hotspot.IsValidRule(rule); err != nil {
+			return fmt.Errorf("invalid hot spot rule %s: %w", rule.ID, err)
+		}
+		rulesOfRes[rule.Resource] = append(rulesOfRes[rule.Resource], rule)
+		conf.blockResponses[ruleKey{base.BlockTypeHotSpotParamFlow, rule.ID}] = r.BlockResponse
+	}
+
+	for res, rules := range rulesOfRes {
+		if _, err := hotspot.LoadRulesOfResource(res, rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (conf *config) loadCircuitBreakerRules() error {
+	rulesOfRes := make(map[string][]*circuitbreaker.Rule)
+	for i, r := range conf.GetCircuitBreaker().GetRules() {
+		rule := &circuitbreaker.Rule{
+			Id:                           ruleID(r.Id, "circuitBreaker", i),
+			Resource:                     r.Resource,
+			Strategy:                     circuitbreaker.Strategy(r.Strategy),
+			RetryTimeoutMs:               r.RetryTimeoutMs,
+			MinRequestAmount:             r.MinRequestAmount,
+			StatIntervalMs:               r.StatIntervalMs,
+			StatSlidingWindowBucketCount: r.StatSlidingWindowBucketCount,
+			MaxAllowedRtMs:               r.MaxAllowedRtMs,
+			Threshold:                    r.Threshold,
+			ProbeNum:                     r.ProbeNum,
+		}
+		if err := circuitbreaker.IsValidRule(rule); err != nil {
+			return fmt.Errorf("invalid circuit breaker rule %s: %w", rule.Id, err)
+		}
+		rulesOfRes[rule.Resource] = append(rulesOfRes[rule.Resource], rule)
+		conf.blockResponses[ruleKey{base.BlockTypeCircuitBreaking, rule.Id}] = r.BlockResponse
+
+		if len(r.TriggeredByStatusCodes) > 0 {
+			codes, ok := conf.errorStatusCodes[rule.Resource]
+			if !ok {
+				codes = make(map[uint32]struct{})
+				conf.errorStatusCodes[rule.Resource] = codes
+			}
+			for _, code := range r.TriggeredByStatusCodes {
+				codes[code] = struct{}{}
+			}
+		}
+	}
+
+	for res, rules := range rulesOfRes {
+		if _, err := circuitbreaker.LoadRulesOfResource(res, rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blockResponse returns the response for the request blocked by the rule
+func (conf *config) blockResponse(blockErr *base.BlockError) *api.LocalResponse {
+	var id string
+	switch rule := blockErr.TriggeredRule().(type) {
+	case *flow.Rule:
+		id = rule.ID
+	case *hotspot.Rule:
+		id = rule.ID
+	case *circuitbreaker.Rule:
+		id = rule.Id
+	case *system.Rule:
+		// the system rules are configured via the DynamicConfig
+		br := sentineldc.GetSystemBlockResponse()
+		return newLocalResponse(br.GetMessage(), br.GetStatusCode(), br.GetHeaders())
+	}
+
+	// The rule may be loaded by other routes which share the same resource. In this case,
+	// the default response is used.
+	br := conf.blockResponses[ruleKey{blockErr.BlockType(), id}]
+	return newLocalResponse(br.GetMessage(), br.GetStatusCode(), br.GetHeaders())
+}
+
+func newLocalResponse(msg string, code uint32, headers map[string]string) *api.LocalResponse {
+	if code == 0 {
+		code = http.StatusTooManyRequests
+	}
+	var hdr http.Header
+	if len(headers) > 0 {
+		hdr = make(http.Header, len(headers))
+		for k, v := range headers {
+			hdr.Set(k, v)
+		}
+	}
+	return &api.LocalResponse{Code: int(code), Msg: msg, Header: hdr}
+}
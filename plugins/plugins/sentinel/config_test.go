@@ -0,0 +1,86 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "flow",
+			input: `{"resource":{"key":"x-res"},"flow":{"rules":[{"resource":"cfg-flow","threshold":10,"statIntervalInMs":1000}]}}`,
+		},
+		{
+			name:  "hot spot",
+			input: `{"resource":{"from":"QUERY","key":"res"},"hotSpot":{"params":["uid"],"rules":[{"resource":"cfg-hot-spot","metricType":"QPS","threshold":10,"durationInSec":1}]}}`,
+		},
+		{
+			name:  "circuit breaker",
+			input: `{"resource":{"key":"x-res"},"circuitBreaker":{"rules":[{"resource":"cfg-cb","strategy":"ERROR_COUNT","retryTimeoutMs":1000,"statIntervalMs":1000,"threshold":10,"triggeredByStatusCodes":[503]}]}}`,
+		},
+		{
+			name:  "resource is required",
+			input: `{}`,
+			err:   "invalid Config.Resource: value is required",
+		},
+		{
+			name:  "bad stat interval",
+			input: `{"resource":{"key":"x-res"},"flow":{"rules":[{"resource":"cfg-flow","threshold":10}]}}`,
+			err:   "invalid FlowRule.StatIntervalInMs: value must be greater than 0",
+		},
+		{
+			name:  "warm up without period",
+			input: `{"resource":{"key":"x-res"},"flow":{"rules":[{"id":"warm","resource":"cfg-flow","tokenCalculateStrategy":"WARMUP","threshold":10,"statIntervalInMs":1000}]}}`,
+			err:   "invalid flow rule warm: WarmUpPeriodSec must be great than 0",
+		},
+		{
+			name:  "exclusive param index and key",
+			input: `{"resource":{"key":"x-res"},"hotSpot":{"rules":[{"resource":"cfg-hot-spot","paramIndex":1,"paramKey":"uid","durationInSec":1}]}}`,
+			err:   "invalid hot spot rule hotSpot-0: invalid param index and param key are mutually exclusive",
+		},
+		{
+			name:  "missing retry timeout",
+			input: `{"resource":{"key":"x-res"},"circuitBreaker":{"rules":[{"resource":"cfg-cb","statIntervalMs":1000,"threshold":0.5}]}}`,
+			err:   "invalid circuit breaker rule circuitBreaker-0: invalid RetryTimeoutMs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
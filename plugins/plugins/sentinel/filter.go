@@ -0,0 +1,115 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinel
+
+import (
+	"fmt"
+	"strconv"
+
+	sentinelapi "github.com/alibaba/sentinel-golang/api"
+	"github.com/alibaba/sentinel-golang/core/base"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/sentinel"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	entry *base.SentinelEntry
+}
+
+func getSource(headers api.RequestHeaderMap, source *sentinel.Source) string {
+	if source.From == sentinel.Source_QUERY {
+		return headers.URL().Query().Get(source.Key)
+	}
+	v, _ := headers.Get(source.Key)
+	return v
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	res := getSource(headers, config.Resource)
+	if res == "" {
+		api.LogInfof("sentinel: resource not found in the request, source: %s", config.Resource)
+		return api.Continue
+	}
+
+	// The system rules only work on the inbound traffic
+	opts := []sentinelapi.EntryOption{sentinelapi.WithTrafficType(base.Inbound)}
+	if hotSpot := config.HotSpot; hotSpot != nil {
+		if len(hotSpot.Params) > 0 {
+			args := make([]interface{}, len(hotSpot.Params))
+			for i, param := range hotSpot.Params {
+				// the missing param is skipped by the hot spot rules
+				if v, ok := headers.Get(param); ok {
+					args[i] = v
+				} else if v := headers.URL().Query().Get(param); v != "" {
+					args[i] = v
+				}
+			}
+			opts = append(opts, sentinelapi.WithArgs(args...))
+		}
+		if len(hotSpot.Attachments) > 0 {
+			attachments := make(map[interface{}]interface{}, len(hotSpot.Attachments))
+			for _, source := range hotSpot.Attachments {
+				if v := getSource(headers, source); v != "" {
+					attachments[source.Key] = v
+				}
+			}
+			opts = append(opts, sentinelapi.WithAttachments(attachments))
+		}
+	}
+
+	entry, blockErr := sentinelapi.Entry(res, opts...)
+	if blockErr != nil {
+		api.LogInfof("sentinel: request is blocked, resource: %s, reason: %s", res, blockErr.Error())
+		return config.blockResponse(blockErr)
+	}
+
+	f.entry = entry
+	return api.Continue
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	entry := f.entry
+	if entry == nil {
+		return
+	}
+
+	if codes, ok := f.config.errorStatusCodes[entry.Resource().Name()]; ok && respHeaders != nil {
+		s, _ := respHeaders.Get(":status")
+		status, err := strconv.Atoi(s)
+		if err == nil {
+			if _, ok := codes[uint32(status)]; ok {
+				sentinelapi.TraceError(entry, fmt.Errorf("unexpected status code %d", status))
+			}
+		}
+	}
+	// the RT of the request is recorded when the entry exits
+	entry.Exit()
+}
@@ -0,0 +1,204 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sentinel
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/alibaba/sentinel-golang/core/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type request struct {
+	f   api.Filter
+	hdr api.RequestHeaderMap
+	res api.ResultAction
+}
+
+func send(conf *config, path string, header http.Header) *request {
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	h := http.Header{
+		":authority": {"test.local"},
+		":method":    {"GET"},
+		":path":      {path},
+	}
+	for k, v := range header {
+		h[k] = v
+	}
+	hdr := envoy.NewRequestHeaderMap(h)
+	return &request{f: f, hdr: hdr, res: f.DecodeHeaders(hdr, true)}
+}
+
+func (r *request) finish(status int) {
+	respHdr := envoy.NewResponseHeaderMap(http.Header{":status": {strconv.Itoa(status)}})
+	r.f.OnLog(r.hdr, nil, respHdr, nil)
+}
+
+func call(conf *config, path string, header http.Header, status int) api.ResultAction {
+	r := send(conf, path, header)
+	if r.res == api.Continue {
+		r.finish(status)
+	}
+	return r.res
+}
+
+func TestFlow(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"resource": {"key": "x-res"},
+		"flow": {
+			"rules": [{
+				"resource": "flow",
+				"threshold": 1,
+				"statIntervalInMs": 1000,
+				"blockResponse": {
+					"message": "too many requests",
+					"statusCode": 503,
+					"headers": {"x-blocked": "flow"}
+				}
+			}]
+		}
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	hdr := http.Header{"X-Res": {"flow"}}
+	assert.Equal(t, api.Continue, call(conf, "/", hdr, 200))
+	res := call(conf, "/", hdr, 200)
+	resp, ok := res.(*api.LocalResponse)
+	require.True(t, ok)
+	assert.Equal(t, 503, resp.Code)
+	assert.Equal(t, "too many requests", resp.Msg)
+	assert.Equal(t, "flow", resp.Header.Get("x-blocked"))
+
+	// other resources are not limited
+	assert.Equal(t, api.Continue, call(conf, "/", http.Header{"X-Res": {"other"}}, 200))
+	// the request without resource is skipped
+	assert.Equal(t, api.Continue, call(conf, "/", nil, 200))
+}
+
+func TestHotSpot(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"resource": {"from": "QUERY", "key": "res"},
+		"hotSpot": {
+			"params": ["uid"],
+			"attachments": [{"key": "x-tenant"}],
+			"rules": [{
+				"resource": "hot-spot",
+				"metricType": "QPS",
+				"threshold": 1,
+				"durationInSec": 1,
+				"paramIndex": 0,
+				"specificItems": {"vip": 100}
+			}, {
+				"resource": "hot-spot-attachment",
+				"metricType": "QPS",
+				"threshold": 1,
+				"durationInSec": 1,
+				"paramKey": "x-tenant"
+			}]
+		}
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	path := "/?res=hot-spot"
+	// the params are read from the headers, then the query
+	assert.Equal(t, api.Continue, call(conf, path+"&uid=a", nil, 200))
+	res := call(conf, path, http.Header{"Uid": {"a"}}, 200)
+	resp, ok := res.(*api.LocalResponse)
+	require.True(t, ok)
+	assert.Equal(t, 429, resp.Code)
+
+	assert.Equal(t, api.Continue, call(conf, path+"&uid=b", nil, 200))
+	assert.Equal(t, api.Continue, call(conf, path+"&uid=vip", nil, 200))
+	assert.Equal(t, api.Continue, call(conf, path+"&uid=vip", nil, 200))
+
+	// limit via the attachments
+	path = "/?res=hot-spot-attachment"
+	tenant := http.Header{"X-Tenant": {"acme"}}
+	assert.Equal(t, api.Continue, call(conf, path+"&uid=c", tenant, 200))
+	res = call(conf, path+"&uid=d", tenant, 200)
+	_, ok = res.(*api.LocalResponse)
+	assert.True(t, ok)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"resource": {"key": "x-res"},
+		"circuitBreaker": {
+			"rules": [{
+				"resource": "circuit-breaker",
+				"strategy": "ERROR_COUNT",
+				"retryTimeoutMs": 60000,
+				"minRequestAmount": 1,
+				"statIntervalMs": 10000,
+				"threshold": 2,
+				"triggeredByStatusCodes": [500, 503],
+				"blockResponse": {
+					"statusCode": 503
+				}
+			}]
+		}
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	hdr := http.Header{"X-Res": {"circuit-breaker"}}
+	assert.Equal(t, api.Continue, call(conf, "/", hdr, 500))
+	// not counted as an error
+	assert.Equal(t, api.Continue, call(conf, "/", hdr, 502))
+	assert.Equal(t, api.Continue, call(conf, "/", hdr, 503))
+
+	res := call(conf, "/", hdr, 200)
+	resp, ok := res.(*api.LocalResponse)
+	require.True(t, ok)
+	assert.Equal(t, 503, resp.Code)
+}
+
+func TestSystem(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{
+		"resource": {"key": "x-res"}
+	}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	_, err := system.LoadRules([]*system.Rule{
+		{
+			MetricType:   system.Concurrency,
+			TriggerCount: 1,
+		},
+	})
+	require.NoError(t, err)
+	defer system.ClearRules()
+
+	hdr := http.Header{"X-Res": {"system"}}
+	r := send(conf, "/", hdr)
+	assert.Equal(t, api.Continue, r.res)
+	// the concurrency exceeds the threshold
+	res := send(conf, "/", hdr).res
+	resp, ok := res.(*api.LocalResponse)
+	require.True(t, ok)
+	assert.Equal(t, 429, resp.Code)
+
+	r.finish(200)
+	assert.Equal(t, api.Continue, call(conf, "/", hdr, 200))
+}
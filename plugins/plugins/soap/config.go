@@ -0,0 +1,76 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soap
+
+import (
+	"mime"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/soap"
+)
+
+const (
+	defaultMaxBodySize = 1 << 20
+)
+
+func init() {
+	plugins.RegisterPlugin(soap.Name, &plugin{})
+}
+
+type plugin struct {
+	soap.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	soap.CustomConfig
+
+	template    *interpolation.Template
+	maxBodySize int
+	contentType string
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	var err error
+	conf.template, err = interpolation.CompileWithPrefixes(conf.Template, "body")
+	if err != nil {
+		return err
+	}
+
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+
+	if conf.Version == soap.Config_SOAP12 {
+		params := map[string]string{"charset": "utf-8"}
+		if conf.Action != "" {
+			params["action"] = conf.Action
+		}
+		conf.contentType = mime.FormatMediaType("application/soap+xml", params)
+	} else {
+		conf.contentType = "text/xml; charset=utf-8"
+	}
+	return nil
+}
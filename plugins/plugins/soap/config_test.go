@@ -0,0 +1,81 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		err         string
+		contentType string
+	}{
+		{
+			name:  "template required",
+			input: `{}`,
+			err:   "invalid Config.Template: value length must be at least 1 runes",
+		},
+		{
+			name:  "bad version",
+			input: `{"template":"<a/>","version":2}`,
+			err:   "invalid Config.Version: value must be one of the defined enum values",
+		},
+		{
+			name:  "unknown variable",
+			input: `{"template":"<a>${bodyid}</a>"}`,
+			err:   "bad template: unknown variable: bodyid",
+		},
+		{
+			name:        "SOAP 1.1",
+			input:       `{"template":"<a>${body.id}</a>","action":"urn:GetUser"}`,
+			contentType: "text/xml; charset=utf-8",
+		},
+		{
+			name:        "SOAP 1.2",
+			input:       `{"template":"<a>${body}</a>","version":"SOAP12","action":"urn:GetUser"}`,
+			contentType: `application/soap+xml; action="urn:GetUser"; charset=utf-8`,
+		},
+		{
+			name:        "SOAP 1.2 without action",
+			input:       `{"template":"<a/>","version":"SOAP12"}`,
+			contentType: "application/soap+xml; charset=utf-8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, conf.Init(nil))
+			assert.Equal(t, tt.contentType, conf.contentType)
+			assert.Equal(t, defaultMaxBodySize, conf.maxBodySize)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soap
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/soap"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+var xmlContentTypes = map[string]bool{
+	"text/xml":             true,
+	"application/xml":      true,
+	"application/soap+xml": true,
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	if endStream {
+		// Envoy doesn't allow adding body to the request without body
+		return &api.LocalResponse{Code: http.StatusBadRequest, Msg: "request body is required"}
+	}
+	// so that the response can be converted
+	headers.Del("accept-encoding")
+	return api.WaitAllData
+}
+
+func (f *filter) lookup(body interface{}) func(name string) (string, error) {
+	return func(name string) (string, error) {
+		var v interface{}
+		if name == "body" {
+			v = body
+		} else {
+			v = lookupJSON(body, strings.Split(strings.TrimPrefix(name, "body."), "."))
+		}
+		var buf bytes.Buffer
+		if err := writeXMLContent(&buf, v); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+}
+
+func (f *filter) DecodeRequest(headers api.RequestHeaderMap, data api.BufferInstance, trailers api.RequestTrailerMap) api.ResultAction {
+	if data == nil {
+		return &api.LocalResponse{Code: http.StatusBadRequest, Msg: "request body is required"}
+	}
+
+	config := f.config
+	var body interface{}
+	if data.Len() > config.maxBodySize {
+		return &api.LocalResponse{Code: http.StatusRequestEntityTooLarge}
+	}
+	if data.Len() > 0 {
+		dec := json.NewDecoder(bytes.NewReader(data.Bytes()))
+		dec.UseNumber()
+		if err := dec.Decode(&body); err != nil {
+			api.LogInfof("soap: failed to decode request body: %v", err)
+			return &api.LocalResponse{Code: http.StatusBadRequest, Msg: "bad JSON body"}
+		}
+	}
+
+	envelope, err := config.template.RenderWith(headers, f.callbacks, f.lookup(body), escapeXML)
+	if err != nil {
+		api.LogInfof("soap: failed to render SOAP envelope: %v", err)
+		return &api.LocalResponse{Code: http.StatusBadRequest, Msg: err.Error()}
+	}
+
+	_ = data.SetString(envelope)
+	headers.Set(":method", http.MethodPost)
+	headers.Set("content-type", config.contentType)
+	headers.Set("content-length", strconv.Itoa(len(envelope)))
+	if config.Version == soap.Config_SOAP11 {
+		headers.Set("soapaction", strconv.Quote(config.Action))
+	}
+	return api.Continue
+}
+
+// convertible checks if the response body can be converted according to the headers
+func (f *filter) convertible(headers api.ResponseHeaderMap) bool {
+	if enc, ok := headers.Get("content-encoding"); ok && enc != "" && !strings.EqualFold(enc, "identity") {
+		return false
+	}
+	ct, ok := headers.Get("content-type")
+	if !ok {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil || !xmlContentTypes[mediaType] {
+		return false
+	}
+	if cl, ok := headers.Get("content-length"); ok {
+		n, err := strconv.Atoi(cl)
+		if err == nil && n > f.config.maxBodySize {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if endStream || !f.convertible(headers) {
+		return api.Continue
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	if data == nil || data.Len() == 0 {
+		return api.Continue
+	}
+	if data.Len() > f.config.maxBodySize {
+		api.LogInfof("soap: body size %d exceeds the limit, skip converting", data.Len())
+		return api.Continue
+	}
+
+	body, err := xmlToJSON(data.Bytes())
+	if err != nil {
+		api.LogInfof("soap: failed to convert response body: %v", err)
+		return api.Continue
+	}
+	_ = data.Set(body)
+	headers.Set("content-type", "application/json")
+	headers.Set("content-length", strconv.Itoa(len(body)))
+	return api.Continue
+}
@@ -0,0 +1,265 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soap
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+const envelope = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">` +
+	`<soap:Body><GetUser xmlns="urn:users">${body}</GetUser></soap:Body></soap:Envelope>`
+
+func TestConvertRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		body     string
+		res      api.ResultAction
+		envelope string
+		hdr      map[string]string
+	}{
+		{
+			name:  "sanity",
+			input: `{"action":"urn:GetUser"}`,
+			body:  `{"id": 1, "name": "<Alice>", "tags": ["a", "b"], "address": {"city": "X"}, "vip": true, "memo": null}`,
+			envelope: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>` +
+				`<GetUser xmlns="urn:users"><address><city>X</city></address><id>1</id><memo></memo>` +
+				`<name>&lt;Alice&gt;</name><tags>a</tags><tags>b</tags><vip>true</vip></GetUser>` +
+				`</soap:Body></soap:Envelope>`,
+			hdr: map[string]string{
+				":method":      "POST",
+				"content-type": "text/xml; charset=utf-8",
+				"soapaction":   `"urn:GetUser"`,
+			},
+		},
+		{
+			name:  "SOAP 1.2",
+			input: `{"version":"SOAP12","action":"urn:GetUser"}`,
+			body:  `{}`,
+			envelope: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">` +
+				`<soap:Body><GetUser xmlns="urn:users"></GetUser></soap:Body></soap:Envelope>`,
+			hdr: map[string]string{
+				"content-type": `application/soap+xml; action="urn:GetUser"; charset=utf-8`,
+			},
+		},
+		{
+			name: "fields and variables",
+			input: `{"template":"<a>${body.user.name}|${body.items.1.id}|${body.items}|${body.miss.x}` +
+				`|${header.x-tenant}|${query.lang}</a>"}`,
+			body:     `{"user": {"name": "Bob"}, "items": [{"id": 1}, {"id": 2}]}`,
+			envelope: `<a>Bob|2|<id>1</id><id>2</id>||&lt;t&amp;1&gt;|en</a>`,
+		},
+		{
+			name:     "empty body",
+			input:    `{"template":"<a>${body}</a>"}`,
+			body:     ``,
+			envelope: `<a></a>`,
+		},
+		{
+			name:  "bad JSON",
+			input: `{}`,
+			body:  `{`,
+			res:   &api.LocalResponse{Code: 400, Msg: "bad JSON body"},
+		},
+		{
+			name:  "bad element name",
+			input: `{}`,
+			body:  `{"a b": 1}`,
+			res:   &api.LocalResponse{Code: 400, Msg: `field "a b" can't be used as XML element name`},
+		},
+		{
+			name:  "body too large",
+			input: `{"maxBodySize": 2}`,
+			body:  `{"id": 1}`,
+			res:   &api.LocalResponse{Code: 413},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal([]byte(tt.input), &input))
+			if _, ok := input["template"]; !ok {
+				input["template"] = envelope
+			}
+			b, _ := json.Marshal(input)
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal(b, conf))
+			require.NoError(t, conf.Init(nil))
+
+			f := factory(conf, envoy.NewFilterCallbackHandler())
+			hdr := envoy.NewRequestHeaderMap(http.Header{
+				":method":         {"GET"},
+				":path":           {"/users?lang=en"},
+				"accept-encoding": {"gzip"},
+				"X-Tenant":        {"<t&1>"},
+			})
+			assert.Equal(t, api.WaitAllData, f.DecodeHeaders(hdr, false))
+			_, ok := hdr.Get("accept-encoding")
+			assert.False(t, ok)
+
+			buf := envoy.NewBufferInstance([]byte(tt.body))
+			res := f.DecodeRequest(hdr, buf, nil)
+			if tt.res != nil {
+				assert.Equal(t, tt.res, res)
+				return
+			}
+			assert.Equal(t, api.Continue, res)
+			assert.Equal(t, tt.envelope, buf.String())
+			cl, _ := hdr.Get("content-length")
+			assert.Equal(t, strconv.Itoa(len(tt.envelope)), cl)
+			for k, v := range tt.hdr {
+				actual, _ := hdr.Get(k)
+				assert.Equal(t, v, actual, k)
+			}
+		})
+	}
+}
+
+func TestRequestWithoutBody(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"template":"<a/>"}`), conf))
+	require.NoError(t, conf.Init(nil))
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	hdr := envoy.NewRequestHeaderMap(http.Header{":method": {"GET"}})
+	assert.Equal(t, &api.LocalResponse{Code: 400, Msg: "request body is required"}, f.DecodeHeaders(hdr, true))
+}
+
+func TestConvertResponse(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"template":"<a/>","maxBodySize":1024}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	tests := []struct {
+		name      string
+		header    map[string]string
+		body      string
+		endStream bool
+		wait      bool
+		output    string
+	}{
+		{
+			name:   "SOAP response",
+			header: map[string]string{"content-type": "text/xml; charset=utf-8"},
+			body: `<?xml version="1.0" encoding="utf-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"
+  xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <soap:Header><Trace>1</Trace></soap:Header>
+  <soap:Body>
+    <m:GetUserResponse xmlns:m="urn:users">
+      <m:User id="1">
+        <m:Name>Alice &amp; Bob</m:Name>
+        <m:Tag>a</m:Tag>
+        <m:Tag>b</m:Tag>
+        <m:Phone xsi:nil="true"/>
+        <m:Memo></m:Memo>
+        <m:Address><![CDATA[<none>]]></m:Address>
+      </m:User>
+    </m:GetUserResponse>
+  </soap:Body>
+</soap:Envelope>`,
+			wait: true,
+			output: `{"GetUserResponse":{"User":{"@id":"1","Address":"<none>","Memo":"",` +
+				`"Name":"Alice & Bob","Phone":null,"Tag":["a","b"]}}}`,
+		},
+		{
+			name:   "SOAP fault",
+			header: map[string]string{"content-type": `application/soap+xml; charset=utf-8`},
+			body: `<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope"><env:Body><env:Fault>` +
+				`<env:Code><env:Value>env:Sender</env:Value></env:Code>` +
+				`<env:Reason><env:Text xml:lang="en">bad id</env:Text></env:Reason>` +
+				`</env:Fault></env:Body></env:Envelope>`,
+			wait: true,
+			output: `{"Fault":{"Code":{"Value":"env:Sender"},` +
+				`"Reason":{"Text":{"#text":"bad id","@lang":"en"}}}}`,
+		},
+		{
+			name:   "plain XML",
+			header: map[string]string{"content-type": "application/xml"},
+			body:   `<users><user>a</user><user>b</user></users>`,
+			wait:   true,
+			output: `{"users":{"user":["a","b"]}}`,
+		},
+		{
+			name:   "bad XML",
+			header: map[string]string{"content-type": "application/xml"},
+			body:   `<users>`,
+			wait:   true,
+			output: `<users>`,
+		},
+		{
+			name:   "envelope without body",
+			header: map[string]string{"content-type": "text/xml"},
+			body:   `<Envelope></Envelope>`,
+			wait:   true,
+			output: `<Envelope></Envelope>`,
+		},
+		{
+			name:   "not XML",
+			header: map[string]string{"content-type": "application/json"},
+			body:   `{}`,
+		},
+		{
+			name:   "compressed",
+			header: map[string]string{"content-type": "text/xml", "content-encoding": "gzip"},
+			body:   `<a/>`,
+		},
+		{
+			name:   "too large",
+			header: map[string]string{"content-type": "text/xml", "content-length": "2048"},
+			body:   `<a/>`,
+		},
+		{
+			name:      "no body",
+			header:    map[string]string{"content-type": "text/xml"},
+			endStream: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory(conf, envoy.NewFilterCallbackHandler())
+			h := http.Header{}
+			for k, v := range tt.header {
+				h.Set(k, v)
+			}
+			hdr := envoy.NewResponseHeaderMap(h)
+			res := f.EncodeHeaders(hdr, tt.endStream)
+			if !tt.wait {
+				assert.Equal(t, api.Continue, res)
+				return
+			}
+			assert.Equal(t, api.WaitAllData, res)
+
+			buf := envoy.NewBufferInstance([]byte(tt.body))
+			assert.Equal(t, api.Continue, f.EncodeResponse(hdr, buf, nil))
+			assert.Equal(t, tt.output, buf.String())
+			if tt.output != tt.body {
+				ct, _ := hdr.Get("content-type")
+				assert.Equal(t, "application/json", ct)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soap
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// isXMLName reports whether the name can be used as the XML element name. Only the common
+// characters are allowed.
+func isXMLName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r), r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// lookupJSON returns the value in the given path, like `items.0.id`. Nil is returned if the path
+// doesn't exist.
+func lookupJSON(v interface{}, path []string) interface{} {
+	for _, seg := range path {
+		switch o := v.(type) {
+		case map[string]interface{}:
+			v = o[seg]
+		case []interface{}:
+			idx, err := strconv.Atoi(seg)
+			if err != nil || idx < 0 || idx >= len(o) {
+				return nil
+			}
+			v = o[idx]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+// writeXMLContent writes the JSON value as the content of an XML element. The object is written
+// as the child elements, the array is written as the concatenation of its items, and the other
+// values are written as the text.
+func writeXMLContent(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := writeXMLElement(buf, k, v[k]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := writeXMLContent(buf, item); err != nil {
+				return err
+			}
+		}
+	case string:
+		_ = xml.EscapeText(buf, []byte(v))
+	case json.Number:
+		buf.WriteString(v.String())
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	default:
+		return fmt.Errorf("unsupported type %T", v)
+	}
+	return nil
+}
+
+// writeXMLElement writes the JSON value as the element with the given name. Each item of the
+// array is written as an element with the same name.
+func writeXMLElement(buf *bytes.Buffer, name string, v interface{}) error {
+	if !isXMLName(name) {
+		return fmt.Errorf("field %q can't be used as XML element name", name)
+	}
+	if list, ok := v.([]interface{}); ok {
+		for _, item := range list {
+			if err := writeXMLElement(buf, name, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	buf.WriteString("<" + name + ">")
+	if err := writeXMLContent(buf, v); err != nil {
+		return err
+	}
+	buf.WriteString("</" + name + ">")
+	return nil
+}
+
+type xmlNode struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlNode
+	text     strings.Builder
+}
+
+func parseXML(data []byte) (*xmlNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var stack []*xmlNode
+	var root *xmlNode
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name.Local, attrs: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			} else {
+				return nil, errors.New("multiple root elements")
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("no root element")
+	}
+	return root, nil
+}
+
+func isNil(n *xmlNode) bool {
+	for _, attr := range n.attrs {
+		if attr.Name.Local == "nil" && attr.Value == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// toJSON converts the XML element to the JSON value. The element without attributes and children
+// is converted to its text. Otherwise, it's converted to an object whose fields are the attributes
+// prefixed with `@`, the children, and the text as `#text`. The children with the same name are
+// grouped into an array. The namespaces are dropped.
+func (n *xmlNode) toJSON() interface{} {
+	if isNil(n) {
+		return nil
+	}
+
+	obj := map[string]interface{}{}
+	for _, attr := range n.attrs {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		obj["@"+attr.Name.Local] = attr.Value
+	}
+	if len(obj) == 0 && len(n.children) == 0 {
+		return n.text.String()
+	}
+
+	n.childrenToJSON(obj)
+	if text := strings.TrimSpace(n.text.String()); text != "" {
+		obj["#text"] = text
+	}
+	return obj
+}
+
+func (n *xmlNode) childrenToJSON(obj map[string]interface{}) {
+	for _, child := range n.children {
+		v := child.toJSON()
+		old, ok := obj[child.name]
+		if !ok {
+			obj[child.name] = v
+			continue
+		}
+		// the element is never converted to an array, so the array is the grouped children
+		if list, ok := old.([]interface{}); ok {
+			obj[child.name] = append(list, v)
+		} else {
+			obj[child.name] = []interface{}{old, v}
+		}
+	}
+}
+
+func (n *xmlNode) child(name string) *xmlNode {
+	for _, child := range n.children {
+		if child.name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// xmlToJSON converts the XML document to JSON. For the SOAP envelope, only the content of the
+// Body is converted. For example, `<Body><GetUserResponse>...</GetUserResponse></Body>` is
+// converted to `{"GetUserResponse": ...}`. The SOAP fault is converted to `{"Fault": ...}`.
+func xmlToJSON(data []byte) ([]byte, error) {
+	root, err := parseXML(data)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := map[string]interface{}{}
+	if root.name == "Envelope" {
+		body := root.child("Body")
+		if body == nil {
+			return nil, errors.New("no Body in the SOAP envelope")
+		}
+		body.childrenToJSON(obj)
+	} else {
+		obj[root.name] = root.toJSON()
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(obj); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
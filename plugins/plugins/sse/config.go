@@ -0,0 +1,66 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sse
+
+import (
+	"strconv"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	ssetype "mosn.io/htnn/types/plugins/sse"
+)
+
+const (
+	defaultMaxEventSize = 1 << 20
+)
+
+func init() {
+	plugins.RegisterPlugin(ssetype.Name, &plugin{})
+}
+
+type plugin struct {
+	ssetype.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type config struct {
+	ssetype.Config
+
+	dropEvents   map[string]bool
+	retry        string
+	maxEventSize int
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.dropEvents = make(map[string]bool, len(conf.DropEvents))
+	for _, ev := range conf.DropEvents {
+		conf.dropEvents[ev] = true
+	}
+	if conf.Retry != nil {
+		conf.retry = strconv.FormatInt(conf.Retry.AsDuration().Milliseconds(), 10)
+	}
+	conf.maxEventSize = defaultMaxEventSize
+	if conf.MaxEventSize > 0 {
+		conf.maxEventSize = int(conf.MaxEventSize)
+	}
+	return nil
+}
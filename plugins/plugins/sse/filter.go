@@ -0,0 +1,93 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sse
+
+import (
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/sse"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks   api.FilterCallbackHandler
+	config      *config
+	transformer *sse.Transformer
+
+	events      int
+	lastEventID string
+}
+
+func (f *filter) onEvent(ev *sse.Event) bool {
+	config := f.config
+	if f.events == 0 && config.retry != "" {
+		ev.Retry = config.retry
+	}
+	f.events++
+	if ev.ID != "" {
+		f.lastEventID = ev.ID
+	}
+
+	if config.dropEvents[ev.Type()] {
+		return false
+	}
+	if name, ok := config.RenameEvents[ev.Type()]; ok {
+		ev.Event = name
+		if name == "message" {
+			ev.Event = ""
+		}
+	}
+	return true
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if endStream || !sse.IsEventStream(headers) {
+		return api.Continue
+	}
+
+	// the size of the body is changed
+	headers.Del("content-length")
+	// tell the proxies in front of the gateway, like Nginx, not to buffer the events
+	headers.Set("x-accel-buffering", "no")
+	f.transformer = sse.NewTransformer(f.config.maxEventSize, f.onEvent)
+	return api.Continue
+}
+
+func (f *filter) EncodeData(data api.BufferInstance, endStream bool) api.ResultAction {
+	if f.transformer == nil {
+		return api.Continue
+	}
+
+	res, err := f.transformer.Transform(data.Bytes(), endStream)
+	if err != nil {
+		api.LogInfof("sse: %v, pass through the rest of the stream", err)
+	}
+	if err := data.Set(res); err != nil {
+		api.LogErrorf("sse: failed to set the body: %v", err)
+	}
+
+	// so that the access log can record them via `${plugin_state.sse.events}`
+	state := f.callbacks.PluginState()
+	state.Set("sse", "events", f.events)
+	state.Set("sse", "last_event_id", f.lastEventID)
+	return api.Continue
+}
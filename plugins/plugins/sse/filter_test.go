@@ -0,0 +1,74 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sse
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func TestConfig(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"dropEvents":[""]}`), conf))
+	assert.ErrorContains(t, conf.Validate(), "invalid Config.DropEvents[0]: value length must be at least 1 runes")
+
+	conf = &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"retry":"0s"}`), conf))
+	assert.ErrorContains(t, conf.Validate(), "invalid Config.Retry: value must be greater than 0s")
+}
+
+func TestTransformEvents(t *testing.T) {
+	conf := &config{}
+	input := `{"dropEvents":["ping"],"renameEvents":{"delta":"message","message":"text"},"retry":"3s"}`
+	require.NoError(t, protojson.Unmarshal([]byte(input), conf))
+	require.NoError(t, conf.Validate())
+	require.NoError(t, conf.Init(nil))
+
+	cb := envoy.NewFilterCallbackHandler()
+	f := factory(conf, cb)
+	hdr := envoy.NewResponseHeaderMap(http.Header{
+		"Content-Type":   []string{"text/event-stream"},
+		"Content-Length": []string{"100"},
+	})
+	f.EncodeHeaders(hdr, false)
+	_, ok := hdr.Get("content-length")
+	assert.False(t, ok)
+	v, _ := hdr.Get("x-accel-buffering")
+	assert.Equal(t, "no", v)
+
+	buf := envoy.NewBufferInstance([]byte("id: 1\ndata: a\n\nevent: ping\n\nevent: del"))
+	assert.Equal(t, api.Continue, f.EncodeData(buf, false))
+	assert.Equal(t, "id: 1\nevent: text\nretry: 3000\ndata: a\n\n", buf.String())
+
+	buf = envoy.NewBufferInstance([]byte("ta\ndata: b\n\n"))
+	f.EncodeData(buf, true)
+	assert.Equal(t, "data: b\n\n", buf.String())
+	assert.Equal(t, 3, cb.PluginState().Get("sse", "events"))
+	assert.Equal(t, "1", cb.PluginState().Get("sse", "last_event_id"))
+
+	// not an event stream
+	f = factory(conf, envoy.NewFilterCallbackHandler())
+	f.EncodeHeaders(envoy.NewResponseHeaderMap(http.Header{"Content-Type": []string{"application/json"}}), false)
+	buf = envoy.NewBufferInstance([]byte("event: ping\n\n"))
+	f.EncodeData(buf, true)
+	assert.Equal(t, "event: ping\n\n", buf.String())
+}
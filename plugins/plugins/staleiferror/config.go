@@ -0,0 +1,205 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staleiferror
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/plugins/pkg/sse"
+	"mosn.io/htnn/types/plugins/staleiferror"
+)
+
+const (
+	defaultMaxEntries  = 10000
+	defaultMaxBodySize = 1 << 20
+)
+
+var defaultStatuses = []uint32{500, 502, 503, 504}
+
+func init() {
+	plugins.RegisterPlugin(staleiferror.Name, &plugin{})
+}
+
+type plugin struct {
+	staleiferror.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type entry struct {
+	status   int
+	header   http.Header
+	body     []byte
+	storedAt time.Time
+}
+
+type config struct {
+	staleiferror.CustomConfig
+
+	// entries is nil if the responses are not stored
+	entries     *ttlcache.Cache[string, *entry]
+	maxStale    time.Duration
+	maxBodySize int
+	statuses    map[int]bool
+	fallback    *api.LocalResponse
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	if conf.MaxStale != nil {
+		maxEntries := defaultMaxEntries
+		if conf.MaxEntries > 0 {
+			maxEntries = int(conf.MaxEntries)
+		}
+		conf.maxStale = conf.MaxStale.AsDuration()
+		conf.entries = ttlcache.New(
+			ttlcache.WithCapacity[string, *entry](uint64(maxEntries)),
+			ttlcache.WithTTL[string, *entry](conf.maxStale),
+			ttlcache.WithDisableTouchOnHit[string, *entry](),
+		)
+	}
+	conf.maxBodySize = defaultMaxBodySize
+	if conf.MaxBodySize > 0 {
+		conf.maxBodySize = int(conf.MaxBodySize)
+	}
+
+	statuses := conf.Statuses
+	if len(statuses) == 0 {
+		statuses = defaultStatuses
+	}
+	conf.statuses = make(map[int]bool, len(statuses))
+	for _, s := range statuses {
+		conf.statuses[int(s)] = true
+	}
+
+	if fb := conf.Fallback; fb != nil {
+		hdr := http.Header{}
+		for k, v := range fb.Headers {
+			hdr.Set(k, v)
+		}
+		if fb.Body != "" && hdr.Get("content-type") == "" {
+			// otherwise the body may be wrapped in JSON
+			hdr.Set("content-type", "text/plain")
+		}
+		conf.fallback = &api.LocalResponse{
+			Code:   int(fb.Status),
+			Msg:    fb.Body,
+			Header: hdr,
+		}
+	}
+	return nil
+}
+
+// storeKey generates the key from the request's scheme, host, path with the query string, and
+// the consumer. The Accept-Encoding is also part of the key, as the response may be compressed
+// according to it.
+func storeKey(headers api.RequestHeaderMap, consumer api.Consumer) string {
+	var sb strings.Builder
+	sb.WriteString(headers.Scheme())
+	sb.WriteString("://")
+	sb.WriteString(headers.Host())
+	sb.WriteString(headers.Path())
+	sb.WriteString("\n")
+	if consumer != nil {
+		sb.WriteString(consumer.Name())
+	}
+	sb.WriteString("\n")
+	sb.WriteString(strings.Join(headers.Values("accept-encoding"), ","))
+
+	sum := sha256.Sum256([]byte(sb.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// storable checks if the successful response can be served to the other requests later
+func storable(headers api.ResponseHeaderMap, authorized bool) bool {
+	status, _ := headers.Get(":status")
+	if status != "200" {
+		return false
+	}
+	if sse.IsEventStream(headers) {
+		// the event stream may never end, so it can't be buffered
+		return false
+	}
+	if _, ok := headers.Get("set-cookie"); ok {
+		// avoid leaking the cookie to the other clients
+		return false
+	}
+
+	public := false
+	for _, v := range headers.Values("cache-control") {
+		for _, d := range strings.Split(v, ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(d), "=")
+			switch strings.ToLower(name) {
+			case "no-store", "private":
+				return false
+			case "public":
+				public = true
+			}
+		}
+	}
+	if authorized && !public {
+		return false
+	}
+
+	for _, v := range headers.Values("vary") {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			// the response varies with the headers which are not in the key
+			if name != "" && !strings.EqualFold(name, "accept-encoding") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Hop-by-hop headers and the headers which are generated by the plugin are not stored
+var unstoredHeaders = map[string]bool{
+	"connection":          true,
+	"keep-alive":          true,
+	"proxy-authenticate":  true,
+	"proxy-authorization": true,
+	"te":                  true,
+	"trailer":             true,
+	"transfer-encoding":   true,
+	"upgrade":             true,
+	"content-length":      true,
+	"age":                 true,
+	fallbackHeader:        true,
+}
+
+func storedHeader(headers api.ResponseHeaderMap) http.Header {
+	hdr := http.Header{}
+	headers.Range(func(k, v string) bool {
+		if k[0] != ':' && !unstoredHeaders[strings.ToLower(k)] {
+			hdr.Add(k, v)
+		}
+		return true
+	})
+	return hdr
+}
@@ -0,0 +1,73 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staleiferror
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "empty",
+			input: `{}`,
+			err:   "either maxStale or fallback should be specified",
+		},
+		{
+			name:  "bad maxStale",
+			input: `{"maxStale":"0s"}`,
+			err:   "invalid Config.MaxStale: value must be greater than 0s",
+		},
+		{
+			name:  "bad status",
+			input: `{"maxStale":"60s","statuses":[404]}`,
+			err:   "invalid Config.Statuses[0]: value must be inside range [500, 600)",
+		},
+		{
+			name:  "fallback without status",
+			input: `{"fallback":{"body":"try later"}}`,
+			err:   "invalid Fallback.Status: value must be inside range [200, 600)",
+		},
+		{
+			name:  "ok",
+			input: `{"maxStale":"600s","statuses":[502],"fallback":{"status":200,"body":"{}","headers":{"content-type":"application/json"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staleiferror
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jellydator/ttlcache/v3"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+)
+
+const (
+	// the header added to the response which replaces the error, its value is either `stale` or
+	// `fallback`
+	fallbackHeader = "x-htnn-fallback"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	key        string
+	authorized bool
+
+	header http.Header
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	if f.config.entries == nil || headers.Method() != http.MethodGet {
+		return api.Continue
+	}
+	f.key = storeKey(headers, f.callbacks.GetConsumer())
+	_, f.authorized = headers.Get("authorization")
+	return api.Continue
+}
+
+func (f *filter) replace(now time.Time) api.ResultAction {
+	config := f.config
+	if f.key != "" {
+		if item := config.entries.Get(f.key); item != nil {
+			e := item.Value()
+			hdr := e.header.Clone()
+			hdr.Set("age", strconv.Itoa(int(now.Sub(e.storedAt).Seconds())))
+			hdr.Set(fallbackHeader, "stale")
+			return &api.LocalResponse{Code: e.status, Msg: string(e.body), Header: hdr}
+		}
+	}
+
+	if fb := config.fallback; fb != nil {
+		hdr := fb.Header.Clone()
+		hdr.Set(fallbackHeader, "fallback")
+		return &api.LocalResponse{Code: fb.Code, Msg: fb.Msg, Header: hdr}
+	}
+	return api.Continue
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	status, _ := headers.Get(":status")
+	code, _ := strconv.Atoi(status)
+	if config.statuses[code] {
+		res := f.replace(time.Now())
+		if res != api.Continue {
+			api.LogInfof("staleIfError: replace the response with status %d", code)
+		}
+		return res
+	}
+
+	if f.key == "" || !storable(headers, f.authorized) {
+		return api.Continue
+	}
+	if cl, ok := headers.Get("content-length"); ok {
+		n, err := strconv.Atoi(cl)
+		if err == nil && n > config.maxBodySize {
+			return api.Continue
+		}
+	}
+
+	f.header = storedHeader(headers)
+	if endStream {
+		f.save(nil)
+		return api.Continue
+	}
+	return api.WaitAllData
+}
+
+func (f *filter) save(body []byte) {
+	f.config.entries.Set(f.key, &entry{
+		status:   http.StatusOK,
+		header:   f.header,
+		body:     body,
+		storedAt: time.Now(),
+	}, ttlcache.DefaultTTL)
+}
+
+func (f *filter) EncodeResponse(headers api.ResponseHeaderMap, data api.BufferInstance, trailers api.ResponseTrailerMap) api.ResultAction {
+	if data == nil {
+		f.save(nil)
+		return api.Continue
+	}
+	if data.Len() > f.config.maxBodySize {
+		return api.Continue
+	}
+	f.save(append([]byte{}, data.Bytes()...))
+	return api.Continue
+}
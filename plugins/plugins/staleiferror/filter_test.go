@@ -0,0 +1,167 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staleiferror
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+func requestHeaders(h http.Header) api.RequestHeaderMap {
+	hdr := http.Header{
+		":method":    {"GET"},
+		":authority": {"test.local"},
+		":path":      {"/products?page=1"},
+	}
+	for k, v := range h {
+		hdr[k] = v
+	}
+	return envoy.NewRequestHeaderMap(hdr)
+}
+
+// send runs a request through the plugin with the given response
+func send(conf *config, req http.Header, resp http.Header, body string) api.ResultAction {
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	f.DecodeHeaders(requestHeaders(req), true)
+	respHdr := envoy.NewResponseHeaderMap(resp)
+	res := f.EncodeHeaders(respHdr, body == "")
+	if res == api.WaitAllData {
+		res = f.EncodeResponse(respHdr, envoy.NewBufferInstance([]byte(body)), nil)
+	}
+	return res
+}
+
+func TestStale(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"maxStale":"600s"}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	res := send(conf, nil, http.Header{":status": {"503"}}, "")
+	assert.Equal(t, api.Continue, res)
+
+	res = send(conf, nil, http.Header{
+		":status":        {"200"},
+		"Content-Type":   {"application/json"},
+		"Content-Length": {"2"},
+	}, "[]")
+	assert.Equal(t, api.Continue, res)
+
+	res = send(conf, nil, http.Header{":status": {"503"}}, "")
+	assert.Equal(t, &api.LocalResponse{
+		Code: 200,
+		Msg:  "[]",
+		Header: http.Header{
+			"Content-Type":    {"application/json"},
+			"Age":             {"0"},
+			"X-Htnn-Fallback": {"stale"},
+		},
+	}, res)
+
+	// the status not configured
+	res = send(conf, nil, http.Header{":status": {"501"}}, "")
+	assert.Equal(t, api.Continue, res)
+	// the response is stored per encoding
+	res = send(conf, http.Header{"Accept-Encoding": {"gzip"}}, http.Header{":status": {"503"}}, "")
+	assert.Equal(t, api.Continue, res)
+}
+
+func TestNotStorable(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"maxStale":"600s","maxBodySize":4}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	tests := []struct {
+		name string
+		req  http.Header
+		resp http.Header
+		body string
+	}{
+		{
+			name: "not 200",
+			resp: http.Header{":status": {"404"}},
+		},
+		{
+			name: "no-store",
+			resp: http.Header{":status": {"200"}, "Cache-Control": {"max-age=60, no-store"}},
+		},
+		{
+			name: "private",
+			resp: http.Header{":status": {"200"}, "Cache-Control": {"private"}},
+		},
+		{
+			name: "authorized",
+			req:  http.Header{"Authorization": {"Bearer token"}},
+			resp: http.Header{":status": {"200"}},
+		},
+		{
+			name: "set-cookie",
+			resp: http.Header{":status": {"200"}, "Set-Cookie": {"a=b"}},
+		},
+		{
+			name: "vary",
+			resp: http.Header{":status": {"200"}, "Vary": {"Accept-Encoding, Cookie"}},
+		},
+		{
+			name: "too large",
+			resp: http.Header{":status": {"200"}},
+			body: "12345",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			send(conf, tt.req, tt.resp, tt.body)
+			res := send(conf, tt.req, http.Header{":status": {"500"}}, "")
+			assert.Equal(t, api.Continue, res)
+		})
+	}
+
+	// authorized but public
+	req := http.Header{"Authorization": {"Bearer token"}}
+	send(conf, req, http.Header{":status": {"200"}, "Cache-Control": {"public"}}, "ok")
+	res := send(conf, req, http.Header{":status": {"500"}}, "")
+	lr, ok := res.(*api.LocalResponse)
+	require.True(t, ok)
+	assert.Equal(t, "ok", lr.Msg)
+}
+
+func TestFallback(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"fallback":{"status":200,"body":"try again later"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	res := send(conf, nil, http.Header{":status": {"502"}}, "")
+	assert.Equal(t, &api.LocalResponse{
+		Code: 200,
+		Msg:  "try again later",
+		Header: http.Header{
+			"Content-Type":    {"text/plain"},
+			"X-Htnn-Fallback": {"fallback"},
+		},
+	}, res)
+
+	// the fallback is not changed
+	assert.Equal(t, http.Header{"Content-Type": {"text/plain"}}, conf.fallback.Header)
+
+	res = send(conf, nil, http.Header{":status": {"200"}}, "ok")
+	assert.Equal(t, api.Continue, res)
+}
@@ -0,0 +1,133 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/tenant"
+)
+
+const (
+	defaultHeader = "x-tenant-id"
+)
+
+func init() {
+	plugins.RegisterPlugin(tenant.Name, &plugin{})
+}
+
+type plugin struct {
+	tenant.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+// source derives the tenant from the request. It returns an empty string if no tenant is found.
+type source interface {
+	tenant(headers api.RequestHeaderMap, callbacks api.FilterCallbackHandler) string
+}
+
+type hostSource struct {
+	regex *regexp.Regexp
+}
+
+func (s *hostSource) tenant(headers api.RequestHeaderMap, callbacks api.FilterCallbackHandler) string {
+	host := headers.Host()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	m := s.regex.FindStringSubmatch(host)
+	if len(m) < 2 {
+		return ""
+	}
+	return m[1]
+}
+
+type claimSource struct {
+	path []string
+}
+
+func (s *claimSource) tenant(headers api.RequestHeaderMap, callbacks api.FilterCallbackHandler) string {
+	// the claims of the token verified by the oidc plugin
+	claims, _ := callbacks.PluginState().Get("oidc", "claims").(map[string]interface{})
+	var cur interface{} = claims
+	for _, key := range s.path {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		cur = m[key]
+	}
+
+	switch v := cur.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+type consumerSource struct {
+	tenants map[string]string
+}
+
+func (s *consumerSource) tenant(headers api.RequestHeaderMap, callbacks api.FilterCallbackHandler) string {
+	c := callbacks.GetConsumer()
+	if c == nil {
+		return ""
+	}
+	if len(s.tenants) == 0 {
+		return c.Name()
+	}
+	return s.tenants[c.Name()]
+}
+
+type config struct {
+	tenant.CustomConfig
+
+	sources []source
+	header  string
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	for _, src := range conf.Sources {
+		switch s := src.Source.(type) {
+		case *tenant.Source_Host:
+			conf.sources = append(conf.sources, &hostSource{regex: regexp.MustCompile(s.Host.Regex)})
+		case *tenant.Source_Claim:
+			conf.sources = append(conf.sources, &claimSource{path: strings.Split(s.Claim, ".")})
+		case *tenant.Source_Consumer:
+			conf.sources = append(conf.sources, &consumerSource{tenants: s.Consumer.Tenants})
+		}
+	}
+
+	conf.header = defaultHeader
+	if conf.Header != "" {
+		conf.header = strings.ToLower(conf.Header)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "sanity",
+			input: `{"sources":[{"host":{"regex":"^([^.]+)\\.example\\.com$"}},{"claim":"org.id"},{"consumer":{}}]}`,
+		},
+		{
+			name:  "no source",
+			input: `{}`,
+			err:   "invalid Config.Sources: value must contain at least 1 item(s)",
+		},
+		{
+			name:  "empty source",
+			input: `{"sources":[{}]}`,
+			err:   "invalid Source.Source: value is required",
+		},
+		{
+			name:  "bad regex",
+			input: `{"sources":[{"host":{"regex":"("}}]}`,
+			err:   "bad host regex of source 0",
+		},
+		{
+			name:  "no capture group",
+			input: `{"sources":[{"claim":"tenant"},{"host":{"regex":"example\\.com"}}]}`,
+			err:   "host regex of source 1 should have a capture group",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			err := conf.Validate()
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, conf.Init(nil))
+			assert.Len(t, conf.sources, 3)
+			assert.Equal(t, "x-tenant-id", conf.header)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"net/http"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/tenant"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	id := ""
+	for _, src := range config.sources {
+		id = src.tenant(headers, f.callbacks)
+		if id != "" {
+			break
+		}
+	}
+
+	// the client can't claim a tenant other than the derived one
+	for _, sent := range headers.Values(config.header) {
+		if sent != id {
+			api.LogInfof("tenant: reject the request with spoofed tenant %q, derived tenant: %q", sent, id)
+			return &api.LocalResponse{Code: http.StatusForbidden, Msg: "tenant mismatch"}
+		}
+	}
+
+	if id == "" {
+		if config.Required {
+			return &api.LocalResponse{Code: http.StatusForbidden, Msg: "unknown tenant"}
+		}
+		return api.Continue
+	}
+
+	headers.Set(config.header, id)
+	// so that the metrics and the access log can record the tenant
+	f.callbacks.PluginState().Set(tenant.Name, "id", id)
+	return api.Continue
+}
@@ -0,0 +1,144 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tenant
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func TestTenant(t *testing.T) {
+	input := `{"sources":[
+		{"host":{"regex":"^([^.]+)\\.saas\\.com$"}},
+		{"claim":"org.id"},
+		{"consumer":{"tenants":{"alice":"acme"}}}
+	],"header":"X-Tenant"}`
+
+	tests := []struct {
+		name     string
+		config   string
+		header   http.Header
+		consumer string
+		claims   map[string]interface{}
+		tenant   string
+		code     int
+	}{
+		{
+			name:   "host",
+			header: http.Header{":authority": {"acme.saas.com:8080"}},
+			tenant: "acme",
+		},
+		{
+			name:   "claim",
+			header: http.Header{":authority": {"api.example.com"}},
+			claims: map[string]interface{}{"org": map[string]interface{}{"id": float64(42)}},
+			tenant: "42",
+		},
+		{
+			name:     "consumer",
+			header:   http.Header{":authority": {"api.example.com"}},
+			consumer: "alice",
+			tenant:   "acme",
+		},
+		{
+			name:     "unknown consumer",
+			header:   http.Header{":authority": {"api.example.com"}},
+			consumer: "bob",
+		},
+		{
+			name:     "matched header",
+			header:   http.Header{":authority": {"api.example.com"}, "X-Tenant": {"acme"}},
+			consumer: "alice",
+			tenant:   "acme",
+		},
+		{
+			name:     "spoofed header",
+			header:   http.Header{":authority": {"api.example.com"}, "X-Tenant": {"umbrella"}},
+			consumer: "alice",
+			code:     403,
+		},
+		{
+			name:   "spoofed header without tenant",
+			header: http.Header{":authority": {"api.example.com"}, "X-Tenant": {"acme"}},
+			code:   403,
+		},
+		{
+			name:   "required",
+			config: `{"sources":[{"consumer":{}}],"required":true}`,
+			header: http.Header{":authority": {"api.example.com"}},
+			code:   403,
+		},
+		{
+			name:     "consumer name as tenant",
+			config:   `{"sources":[{"consumer":{}}],"required":true}`,
+			header:   http.Header{":authority": {"api.example.com"}},
+			consumer: "bob",
+			tenant:   "bob",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := input
+			if tt.config != "" {
+				conf = tt.config
+			}
+			c := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(conf), c))
+			require.NoError(t, c.Init(nil))
+			cb := envoy.NewFilterCallbackHandler()
+			if tt.consumer != "" {
+				cb.SetConsumer(&testConsumer{name: tt.consumer})
+			}
+			if tt.claims != nil {
+				cb.PluginState().Set("oidc", "claims", tt.claims)
+			}
+			f := factory(c, cb)
+			hdr := envoy.NewRequestHeaderMap(tt.header)
+			res := f.DecodeHeaders(hdr, true)
+			if tt.code != 0 {
+				resp, ok := res.(*api.LocalResponse)
+				require.True(t, ok, res)
+				assert.Equal(t, tt.code, resp.Code)
+				return
+			}
+
+			require.Equal(t, api.Continue, res)
+			v, _ := hdr.Get(c.header)
+			assert.Equal(t, tt.tenant, v)
+			id, _ := cb.PluginState().Get("tenant", "id").(string)
+			assert.Equal(t, tt.tenant, id)
+		})
+	}
+}
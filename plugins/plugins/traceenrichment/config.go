@@ -0,0 +1,87 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceenrichment
+
+import (
+	"sort"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/plugins/traceenrichment"
+)
+
+func init() {
+	plugins.RegisterPlugin(traceenrichment.Name, &plugin{})
+}
+
+type plugin struct {
+	traceenrichment.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type entry struct {
+	key string
+	tpl *interpolation.Template
+}
+
+type config struct {
+	traceenrichment.CustomConfig
+
+	attributes []*entry
+	baggage    []*entry
+	// baggageKeys is used to replace the entries with the same key from the downstream
+	baggageKeys map[string]struct{}
+}
+
+func compileEntries(m map[string]string, compile func(string) (*interpolation.Template, error)) ([]*entry, error) {
+	entries := make([]*entry, 0, len(m))
+	for k, v := range m {
+		tpl, err := compile(v)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry{key: k, tpl: tpl})
+	}
+	// keep the order stable so that the generated header is stable
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	return entries, nil
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	var err error
+	conf.attributes, err = compileEntries(conf.Attributes, traceenrichment.CompileAttribute)
+	if err != nil {
+		return err
+	}
+	conf.baggage, err = compileEntries(conf.Baggage, traceenrichment.CompileBaggage)
+	if err != nil {
+		return err
+	}
+	conf.baggageKeys = make(map[string]struct{}, len(conf.baggage))
+	for _, e := range conf.baggage {
+		conf.baggageKeys[e.key] = struct{}{}
+	}
+	return nil
+}
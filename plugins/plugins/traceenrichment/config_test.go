@@ -0,0 +1,85 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceenrichment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "start trace",
+			input: `{"startTrace":{"sampledPercentage":10}}`,
+		},
+		{
+			name:  "attributes and baggage",
+			input: `{"attributes":{"htnn.consumer":"${consumer.name}","htnn.decision":"${plugin_state.opa.decision}","htnn.code":"${response.code}"},"baggage":{"tenant":"${header.x-tenant}"}}`,
+		},
+		{
+			name:  "empty",
+			input: `{}`,
+			err:   "one of startTrace, attributes and baggage is required",
+		},
+		{
+			name:  "bad sampled percentage",
+			input: `{"startTrace":{"sampledPercentage":101}}`,
+			err:   "invalid StartTrace.SampledPercentage: value must be inside range (0, 100]",
+		},
+		{
+			name:  "empty attribute name",
+			input: `{"attributes":{"":"${consumer.name}"}}`,
+			err:   "attribute name should not be empty",
+		},
+		{
+			name:  "bad attribute",
+			input: `{"attributes":{"a":"${response.header.x}"}}`,
+			err:   "bad attribute a: unknown variable: response.header.x",
+		},
+		{
+			name:  "bad baggage key",
+			input: `{"baggage":{"a b":"c"}}`,
+			err:   `bad baggage key "a b"`,
+		},
+		{
+			name:  "response is not available in baggage",
+			input: `{"baggage":{"a":"${response.code}"}}`,
+			err:   "bad baggage a: unknown variable: response.code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+				assert.Nil(t, conf.Init(nil))
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
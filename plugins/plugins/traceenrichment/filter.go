@@ -0,0 +1,158 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceenrichment
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/types/plugins/traceenrichment"
+)
+
+const (
+	// the span attributes are written to the dynamic metadata under this namespace, so that they
+	// can be added to the span via Envoy's tracing custom tags
+	metadataNamespace = "htnn.trace"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+
+	reqHeaders        api.RequestHeaderMap
+	attributesWritten bool
+}
+
+func (f *filter) lookup(name string) (string, error) {
+	info := f.callbacks.StreamInfo()
+	switch name {
+	case "response.code":
+		code, ok := info.ResponseCode()
+		if !ok {
+			return "", nil
+		}
+		return strconv.FormatUint(uint64(code), 10), nil
+	case "response.code_details":
+		details, _ := info.ResponseCodeDetails()
+		return details, nil
+	}
+
+	if s, ok := strings.CutPrefix(name, "plugin_state."); ok {
+		ns, key, _ := strings.Cut(s, ".")
+		v := f.callbacks.PluginState().Get(ns, key)
+		if v == nil {
+			return "", nil
+		}
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", nil
+		}
+		return string(b), nil
+	}
+	return "", nil
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	f.reqHeaders = headers
+
+	tp, _ := headers.Get(traceparentHeader)
+	traceID := parseTraceparent(tp)
+	if traceID == "" && config.StartTrace != nil {
+		tp, traceID = newTraceparent(config.StartTrace.SampledPercentage)
+		headers.Set(traceparentHeader, tp)
+		// the tracestate belongs to the invalid traceparent, so it should be discarded
+		headers.Del(tracestateHeader)
+		api.LogDebugf("start a new trace %s", traceID)
+	}
+	if traceID != "" {
+		// expose the trace ID to other plugins
+		f.callbacks.PluginState().Set(traceenrichment.Name, "trace_id", traceID)
+	}
+
+	if len(config.baggage) > 0 {
+		f.injectBaggage(headers)
+	}
+	return api.Continue
+}
+
+func (f *filter) injectBaggage(headers api.RequestHeaderMap) {
+	members := make([]string, 0, len(f.config.baggage))
+	for _, e := range f.config.baggage {
+		v, _ := e.tpl.RenderWith(headers, f.callbacks, f.lookup, nil)
+		if v == "" {
+			continue
+		}
+		members = append(members, e.key+"="+encodeBaggageValue(v))
+	}
+	if len(members) == 0 {
+		return
+	}
+
+	origin, _ := headers.Get(baggageHeader)
+	baggage := mergeBaggage(origin, members, f.config.baggageKeys)
+	if len(baggage) > maxBaggageSize {
+		api.LogInfof("baggage is not injected as its size %d exceeds the limit %d", len(baggage), maxBaggageSize)
+		return
+	}
+	headers.Set(baggageHeader, baggage)
+}
+
+func (f *filter) writeAttributes(headers api.RequestHeaderMap) {
+	if f.attributesWritten || len(f.config.attributes) == 0 {
+		return
+	}
+	f.attributesWritten = true
+
+	md := f.callbacks.StreamInfo().DynamicMetadata()
+	for _, e := range f.config.attributes {
+		v, _ := e.tpl.RenderWith(headers, f.callbacks, f.lookup, nil)
+		if v == "" {
+			continue
+		}
+		md.Set(metadataNamespace, e.key, v)
+	}
+}
+
+func (f *filter) EncodeHeaders(headers api.ResponseHeaderMap, endStream bool) api.ResultAction {
+	if f.reqHeaders != nil {
+		f.writeAttributes(f.reqHeaders)
+	}
+	return api.Continue
+}
+
+func (f *filter) OnLog(reqHeaders api.RequestHeaderMap, reqTrailers api.RequestTrailerMap,
+	respHeaders api.ResponseHeaderMap, respTrailers api.ResponseTrailerMap) {
+
+	// the response is sent by the plugins before this one, like the one denied by the
+	// authorization plugin
+	if reqHeaders != nil {
+		f.writeAttributes(reqHeaders)
+	}
+}
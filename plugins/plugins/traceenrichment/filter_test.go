@@ -0,0 +1,197 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceenrichment
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+	"mosn.io/htnn/types/plugins/traceenrichment"
+)
+
+type streamInfo struct {
+	*envoy.StreamInfo
+}
+
+func (i *streamInfo) ResponseCode() (uint32, bool) {
+	return 403, true
+}
+
+type testConsumer struct {
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func (c *testConsumer) PluginConfig(name string) api.PluginConsumerConfig {
+	return nil
+}
+
+func newHeaders(hdr http.Header) api.RequestHeaderMap {
+	h := http.Header{
+		":authority": {"test.local"},
+		":method":    {"GET"},
+		":path":      {"/"},
+	}
+	for k, v := range hdr {
+		h[k] = v
+	}
+	return envoy.NewRequestHeaderMap(h)
+}
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		tp      string
+		traceID string
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", ""},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", ""},
+		{"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", ""},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", ""},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", ""},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.traceID, parseTraceparent(tt.tp), tt.tp)
+	}
+}
+
+func TestStartTrace(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"startTrace":{"sampledPercentage":100}}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	cb := envoy.NewFilterCallbackHandler()
+	h := newHeaders(http.Header{
+		"Traceparent": {"invalid"},
+		"Tracestate":  {"vendor=value"},
+	})
+	f := factory(conf, cb)
+	f.DecodeHeaders(h, true)
+
+	tp, _ := h.Get("traceparent")
+	traceID := parseTraceparent(tp)
+	assert.NotEmpty(t, traceID)
+	assert.Equal(t, "01", tp[53:])
+	_, ok := h.Get("tracestate")
+	assert.False(t, ok)
+	assert.Equal(t, traceID, cb.PluginState().Get(traceenrichment.Name, "trace_id"))
+
+	// continue the trace
+	cb = envoy.NewFilterCallbackHandler()
+	origin := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"
+	h = newHeaders(http.Header{
+		"Traceparent": {origin},
+		"Tracestate":  {"vendor=value"},
+	})
+	f = factory(conf, cb)
+	f.DecodeHeaders(h, true)
+	tp, _ = h.Get("traceparent")
+	assert.Equal(t, origin, tp)
+	ts, _ := h.Get("tracestate")
+	assert.Equal(t, "vendor=value", ts)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", cb.PluginState().Get(traceenrichment.Name, "trace_id"))
+}
+
+func TestNotStartTrace(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"attributes":{"a":"b"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	h := newHeaders(nil)
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	f.DecodeHeaders(h, true)
+	_, ok := h.Get("traceparent")
+	assert.False(t, ok)
+}
+
+func TestBaggage(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"baggage":{"tenant":"${header.x-tenant}","consumer":"${consumer.name}","empty":"${header.x-none}","decision":"${plugin_state.authz.decision}"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetConsumer(&testConsumer{name: "alice"})
+	cb.PluginState().Set("authz", "decision", map[string]any{"allow": true})
+	h := newHeaders(http.Header{
+		"X-Tenant": {"a b,c"},
+		"Baggage":  {"userId=1, tenant=old;prop, other=2"},
+	})
+	f := factory(conf, cb)
+	f.DecodeHeaders(h, true)
+
+	baggage, _ := h.Get("baggage")
+	assert.Equal(t, `userId=1,other=2,consumer=alice,decision={%22allow%22:true},tenant=a%20b%2Cc`, baggage)
+}
+
+func TestBaggageTooLarge(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"baggage":{"tenant":"${header.x-tenant}"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+	large := make([]byte, maxBaggageSize)
+	for i := range large {
+		large[i] = 'a'
+	}
+	h := newHeaders(http.Header{
+		"X-Tenant": {string(large)},
+		"Baggage":  {"userId=1"},
+	})
+	f := factory(conf, envoy.NewFilterCallbackHandler())
+	f.DecodeHeaders(h, true)
+
+	baggage, _ := h.Get("baggage")
+	assert.Equal(t, "userId=1", baggage)
+}
+
+func TestAttributes(t *testing.T) {
+	conf := &config{}
+	require.NoError(t, protojson.Unmarshal([]byte(`{"attributes":{"htnn.consumer":"${consumer.name}","htnn.route":"${route.name}","htnn.code":"${response.code}","htnn.decision":"${plugin_state.authz.decision}"}}`), conf))
+	require.NoError(t, conf.Init(nil))
+
+	for _, localReply := range []bool{false, true} {
+		cb := envoy.NewFilterCallbackHandler()
+		cb.SetStreamInfo(&streamInfo{StreamInfo: &envoy.StreamInfo{}})
+		cb.SetConsumer(&testConsumer{name: "alice"})
+		cb.PluginState().Set("authz", "decision", "deny")
+		h := newHeaders(nil)
+		f := factory(conf, cb)
+		if localReply {
+			f.OnLog(h, nil, nil, nil)
+		} else {
+			f.DecodeHeaders(h, true)
+			f.EncodeHeaders(envoy.NewResponseHeaderMap(http.Header{}), true)
+			// the attributes are only written once
+			cb.PluginState().Set("authz", "decision", "allow")
+			f.OnLog(h, nil, nil, nil)
+		}
+
+		md := cb.StreamInfo().DynamicMetadata().Get(metadataNamespace)
+		assert.Equal(t, map[string]interface{}{
+			"htnn.consumer": "alice",
+			"htnn.code":     "403",
+			"htnn.decision": "deny",
+		}, md)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceenrichment
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	traceparentHeader = "traceparent"
+	tracestateHeader  = "tracestate"
+	baggageHeader     = "baggage"
+
+	// the length of `version-traceid-parentid-flags` in version 00
+	traceparentLength = 55
+	// the limit of the baggage header, defined in the W3C Baggage specification
+	maxBaggageSize = 8192
+)
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAllZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
+
+// parseTraceparent returns the trace ID of the W3C traceparent header, or an empty string if the
+// header is invalid.
+func parseTraceparent(tp string) string {
+	if len(tp) < traceparentLength || tp[2] != '-' || tp[35] != '-' || tp[52] != '-' {
+		return ""
+	}
+	version, traceID, parentID, flags := tp[:2], tp[3:35], tp[36:52], tp[53:55]
+	if !isLowerHex(version) || version == "ff" {
+		return ""
+	}
+	// the future versions may append fields after the flags
+	if len(tp) > traceparentLength && (version == "00" || tp[traceparentLength] != '-') {
+		return ""
+	}
+	if !isLowerHex(traceID) || isAllZero(traceID) || !isLowerHex(parentID) || isAllZero(parentID) ||
+		!isLowerHex(flags) {
+		return ""
+	}
+	return traceID
+}
+
+// newTraceparent generates a traceparent header of a new trace. The trace is sampled at the given
+// percentage.
+func newTraceparent(sampledPercentage uint32) (tp string, traceID string) {
+	var b [24]byte
+	_, _ = rand.Read(b[:])
+	// the random bytes are never all zero in practice
+	traceID = hex.EncodeToString(b[:16])
+	flags := "00"
+	if binary.BigEndian.Uint32(b[12:16])%100 < sampledPercentage {
+		flags = "01"
+	}
+	return "00-" + traceID + "-" + hex.EncodeToString(b[16:]) + "-" + flags, traceID
+}
+
+// isBaggageOctet reports whether the byte can be written in the baggage value without
+// percent-encoding.
+func isBaggageOctet(c byte) bool {
+	return c == 0x21 || (c >= 0x23 && c <= 0x2B) || (c >= 0x2D && c <= 0x3A) ||
+		(c >= 0x3C && c <= 0x5B) || (c >= 0x5D && c <= 0x7E)
+}
+
+func encodeBaggageValue(s string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		// '%' is a baggage octet, but it's used for the escape
+		if isBaggageOctet(c) && c != '%' {
+			sb.WriteByte(c)
+			continue
+		}
+		sb.WriteByte('%')
+		sb.WriteByte(hexDigits[c>>4])
+		sb.WriteByte(hexDigits[c&0xF])
+	}
+	return sb.String()
+}
+
+// mergeBaggage adds the members to the baggage header from the downstream. The members from the
+// downstream are replaced if they have the same key.
+func mergeBaggage(header string, members []string, keys map[string]struct{}) string {
+	var kept []string
+	for _, m := range strings.Split(header, ",") {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+		key, _, _ := strings.Cut(m, "=")
+		if _, ok := keys[strings.TrimSpace(key)]; ok {
+			continue
+		}
+		kept = append(kept, m)
+	}
+	return strings.Join(append(kept, members...), ",")
+}
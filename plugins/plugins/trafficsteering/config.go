@@ -0,0 +1,127 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trafficsteering
+
+import (
+	"net/netip"
+	"runtime"
+	"time"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/plugins/pkg/geoip"
+	"mosn.io/htnn/types/plugins/trafficsteering"
+)
+
+const (
+	defaultHeader        = "x-htnn-upstream"
+	defaultProbeInterval = 10 * time.Second
+	defaultProbeTimeout  = 1 * time.Second
+)
+
+func init() {
+	plugins.RegisterPlugin(trafficsteering.Name, &plugin{})
+}
+
+type plugin struct {
+	trafficsteering.Plugin
+}
+
+func (p *plugin) Factory() api.FilterFactory {
+	return factory
+}
+
+func (p *plugin) Config() api.PluginConfig {
+	return &config{}
+}
+
+type countryLookuper interface {
+	// Country returns the ISO 3166-1 alpha-2 code of the country, or empty if not found
+	Country(ip netip.Addr) (string, error)
+}
+
+type config struct {
+	trafficsteering.CustomConfig
+
+	header string
+
+	geoIP countryLookuper
+	// countries maps the countries to the names of the upstreams serving them
+	countries map[string]string
+
+	prober *prober
+}
+
+func (conf *config) Init(cb api.ConfigCallbackHandler) error {
+	conf.header = defaultHeader
+	if conf.Header != "" {
+		conf.header = conf.Header
+	}
+
+	if geo := conf.GeoIp; geo != nil {
+		db, err := geoip.Load(geo.Database)
+		if err != nil {
+			return err
+		}
+		conf.geoIP = db
+		conf.countries = map[string]string{}
+		for _, u := range conf.Upstreams {
+			for _, c := range u.Countries {
+				conf.countries[c] = u.Name
+			}
+		}
+	}
+
+	if probe := conf.LatencyProbe; probe != nil {
+		interval := defaultProbeInterval
+		if probe.Interval != nil {
+			interval = probe.Interval.AsDuration()
+		}
+		timeout := defaultProbeTimeout
+		if probe.Timeout != nil {
+			timeout = probe.Timeout.AsDuration()
+		}
+		addrs := make([]string, len(conf.Upstreams))
+		for i, u := range conf.Upstreams {
+			addrs[i] = u.ProbeAddress
+		}
+		conf.prober = newProber(addrs, interval, timeout)
+		conf.prober.start()
+		runtime.SetFinalizer(conf, func(conf *config) {
+			conf.prober.stop()
+		})
+	}
+	return nil
+}
+
+// choose returns the name of the upstream for the client. The country of the client is checked
+// first, then the latency of the upstreams. The country is skipped if the IP is invalid.
+func (conf *config) choose(ip netip.Addr) string {
+	if conf.geoIP != nil && ip.IsValid() {
+		country, err := conf.geoIP.Country(ip)
+		if err != nil {
+			api.LogErrorf("failed to look up the country of %s: %v", ip, err)
+		} else if name, ok := conf.countries[country]; ok {
+			return name
+		}
+	}
+
+	if conf.prober != nil {
+		if i := conf.prober.fastest(); i >= 0 {
+			return conf.Upstreams[i].Name
+		}
+	}
+	return conf.Upstreams[0].Name
+}
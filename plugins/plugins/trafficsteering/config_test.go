@@ -0,0 +1,106 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trafficsteering
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "upstreams required",
+			input: `{"latencyProbe":{}}`,
+			err:   "invalid Config.Upstreams",
+		},
+		{
+			name:  "bad name",
+			input: `{"upstreams":[{"name":"a b"}],"geoIp":{"database":"x"}}`,
+			err:   "invalid Upstream.Name",
+		},
+		{
+			name:  "bad country",
+			input: `{"upstreams":[{"name":"us","countries":["us"]}],"geoIp":{"database":"x"}}`,
+			err:   "invalid Upstream.Countries[0]",
+		},
+		{
+			name:  "bad interval",
+			input: `{"upstreams":[{"name":"us","probeAddress":"us.local:80"}],"latencyProbe":{"interval":"0s"}}`,
+			err:   "invalid LatencyProbe.Interval",
+		},
+		{
+			name:  "strategy required",
+			input: `{"upstreams":[{"name":"us"}]}`,
+			err:   "either geoIp or latencyProbe should be specified",
+		},
+		{
+			name:  "duplicate upstream",
+			input: `{"upstreams":[{"name":"us"},{"name":"us"}],"geoIp":{"database":"x"}}`,
+			err:   "duplicate upstream us",
+		},
+		{
+			name:  "duplicate country",
+			input: `{"upstreams":[{"name":"us","countries":["US"]},{"name":"eu","countries":["DE","US"]}],"geoIp":{"database":"x"}}`,
+			err:   "country US is served by both upstream us and eu",
+		},
+		{
+			name:  "probe address required",
+			input: `{"upstreams":[{"name":"us"}],"latencyProbe":{}}`,
+			err:   "probeAddress of upstream us is required by latencyProbe",
+		},
+		{
+			name:  "bad probe address",
+			input: `{"upstreams":[{"name":"us","probeAddress":"us.local"}],"latencyProbe":{}}`,
+			err:   "bad probeAddress of upstream us",
+		},
+		{
+			name:  "database not found",
+			input: `{"upstreams":[{"name":"us","countries":["US"]}],"geoIp":{"database":"/not/found.mmdb"}}`,
+			err:   "failed to load GeoIP database",
+		},
+		{
+			name:  "ok",
+			input: `{"upstreams":[{"name":"us","probeAddress":"127.0.0.1:1"},{"name":"eu","probeAddress":"127.0.0.1:2"}],"latencyProbe":{"interval":"60s","timeout":"0.1s"},"header":"x-region"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			err := protojson.Unmarshal([]byte(tt.input), conf)
+			if err == nil {
+				err = conf.Validate()
+			}
+			if err == nil {
+				err = conf.Init(nil)
+				if conf.prober != nil {
+					conf.prober.stop()
+				}
+			}
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
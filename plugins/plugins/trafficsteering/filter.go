@@ -0,0 +1,57 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trafficsteering
+
+import (
+	"net/netip"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/plugins/pkg/request"
+	"mosn.io/htnn/types/plugins/trafficsteering"
+)
+
+func factory(c interface{}, callbacks api.FilterCallbackHandler) api.Filter {
+	return &filter{
+		callbacks: callbacks,
+		config:    c.(*config),
+	}
+}
+
+type filter struct {
+	api.PassThroughFilter
+
+	callbacks api.FilterCallbackHandler
+	config    *config
+}
+
+func (f *filter) DecodeHeaders(headers api.RequestHeaderMap, endStream bool) api.ResultAction {
+	config := f.config
+	s := request.ClientIP(headers, f.callbacks, int(config.XffNumTrustedHops))
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		api.LogInfof("trafficSteering: bad client IP %q: %v", s, err)
+	}
+
+	// the upstream chosen by the client is overridden
+	name := config.choose(ip.Unmap())
+	headers.Set(config.header, name)
+	f.callbacks.PluginState().Set(trafficsteering.Name, "upstream", name)
+
+	if len(config.Upstreams) > 1 {
+		// route the request by the header
+		f.callbacks.ClearRouteCache()
+	}
+	return api.Continue
+}
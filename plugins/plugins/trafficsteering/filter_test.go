@@ -0,0 +1,187 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trafficsteering
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
+)
+
+type fakeGeoIP map[string]string
+
+func (g fakeGeoIP) Country(ip netip.Addr) (string, error) {
+	if ip.String() == "6.6.6.6" {
+		return "", errors.New("corrupted")
+	}
+	return g[ip.String()], nil
+}
+
+func TestSteering(t *testing.T) {
+	geoIP := fakeGeoIP{
+		"1.1.1.1": "US",
+		"2.2.2.2": "DE",
+		"3.3.3.3": "JP",
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		xff       string
+		latencies []int64
+		upstream  string
+	}{
+		{
+			name:     "country",
+			input:    `{"upstreams":[{"name":"us","countries":["US"]},{"name":"eu","countries":["DE"]}],"geoIp":{"database":"x"},"xffNumTrustedHops":1}`,
+			xff:      "2.2.2.2",
+			upstream: "eu",
+		},
+		{
+			name:     "unknown country",
+			input:    `{"upstreams":[{"name":"us","countries":["US"]},{"name":"eu","countries":["DE"]}],"geoIp":{"database":"x"},"xffNumTrustedHops":1}`,
+			xff:      "3.3.3.3",
+			upstream: "us",
+		},
+		{
+			name:     "lookup failed",
+			input:    `{"upstreams":[{"name":"us","countries":["US"]},{"name":"eu","countries":["DE"]}],"geoIp":{"database":"x"},"xffNumTrustedHops":1}`,
+			xff:      "6.6.6.6",
+			upstream: "us",
+		},
+		{
+			name:      "fallback to latency",
+			input:     `{"upstreams":[{"name":"us","countries":["US"],"probeAddress":"us:80"},{"name":"eu","probeAddress":"eu:80"}],"geoIp":{"database":"x"},"latencyProbe":{},"xffNumTrustedHops":1}`,
+			xff:       "3.3.3.3",
+			latencies: []int64{20, 10},
+			upstream:  "eu",
+		},
+		{
+			name:      "country first",
+			input:     `{"upstreams":[{"name":"us","countries":["US"],"probeAddress":"us:80"},{"name":"eu","probeAddress":"eu:80"}],"geoIp":{"database":"x"},"latencyProbe":{},"xffNumTrustedHops":1}`,
+			xff:       "1.1.1.1",
+			latencies: []int64{20, 10},
+			upstream:  "us",
+		},
+		{
+			name:      "skip unreachable",
+			input:     `{"upstreams":[{"name":"us","probeAddress":"us:80"},{"name":"eu","probeAddress":"eu:80"},{"name":"ap","probeAddress":"ap:80"}],"latencyProbe":{}}`,
+			latencies: []int64{20, -1, 30},
+			upstream:  "us",
+		},
+		{
+			name:      "all unreachable",
+			input:     `{"upstreams":[{"name":"us","probeAddress":"us:80"},{"name":"eu","probeAddress":"eu:80"}],"latencyProbe":{},"header":"x-region"}`,
+			latencies: []int64{-1, -1},
+			upstream:  "us",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &config{}
+			require.NoError(t, protojson.Unmarshal([]byte(tt.input), conf))
+			require.NoError(t, conf.Validate())
+			conf.header = defaultHeader
+			if conf.Header != "" {
+				conf.header = conf.Header
+			}
+			if conf.GeoIp != nil {
+				conf.geoIP = geoIP
+				conf.countries = map[string]string{}
+				for _, u := range conf.Upstreams {
+					for _, c := range u.Countries {
+						conf.countries[c] = u.Name
+					}
+				}
+			}
+			if tt.latencies != nil {
+				conf.prober = newProber(make([]string, len(tt.latencies)), time.Second, time.Second)
+				for i, l := range tt.latencies {
+					conf.prober.latencies[i].Store(l)
+				}
+			}
+
+			cb := envoy.NewFilterCallbackHandler()
+			f := factory(conf, cb).(*filter)
+			h := http.Header{}
+			// the upstream chosen by the client is overridden
+			h.Set(conf.header, "forged")
+			if tt.xff != "" {
+				h.Set("x-forwarded-for", tt.xff)
+			}
+			hdr := envoy.NewRequestHeaderMap(h)
+			assert.Equal(t, api.Continue, f.DecodeHeaders(hdr, true))
+			v, _ := hdr.Get(conf.header)
+			assert.Equal(t, tt.upstream, v)
+			assert.Equal(t, tt.upstream, cb.PluginState().Get("trafficSteering", "upstream"))
+		})
+	}
+}
+
+func TestProber(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	// the port of the closed listener refuses the connection
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := closed.Addr().String()
+	closed.Close()
+
+	p := newProber([]string{closedAddr, ln.Addr().String()}, time.Hour, time.Second)
+	assert.Equal(t, -1, p.fastest())
+	p.probeAll()
+	assert.Equal(t, int64(-1), p.latencies[0].Load())
+	assert.GreaterOrEqual(t, p.latencies[1].Load(), int64(0))
+	assert.Equal(t, 1, p.fastest())
+
+	p.start()
+	p.stop()
+	// stop is idempotent
+	p.stop()
+}
+
+func TestProberSmoothing(t *testing.T) {
+	p := newProber([]string{"a:80"}, time.Second, time.Second)
+	p.record(0, 100)
+	assert.Equal(t, int64(100), p.latencies[0].Load())
+	p.record(0, 500)
+	assert.Equal(t, int64(200), p.latencies[0].Load())
+	p.record(0, -1)
+	assert.Equal(t, int64(-1), p.latencies[0].Load())
+	p.record(0, 300)
+	assert.Equal(t, int64(300), p.latencies[0].Load())
+}
@@ -0,0 +1,128 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trafficsteering
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The latency is smoothed with the exponentially weighted moving average, so that the chosen
+// upstream doesn't flap because of a single slow probe. The weight of the latest probe is 1/4.
+const latencyWeightShift = 2
+
+// prober measures the latency of the upstreams by connecting to them periodically
+type prober struct {
+	addrs    []string
+	interval time.Duration
+	timeout  time.Duration
+	// latencies are the smoothed latencies in nanoseconds. A negative value means the upstream is
+	// unreachable or not probed yet.
+	latencies []atomic.Int64
+
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newProber(addrs []string, interval time.Duration, timeout time.Duration) *prober {
+	p := &prober{
+		addrs:     addrs,
+		interval:  interval,
+		timeout:   timeout,
+		latencies: make([]atomic.Int64, len(addrs)),
+		done:      make(chan struct{}),
+	}
+	for i := range p.latencies {
+		p.latencies[i].Store(-1)
+	}
+	return p
+}
+
+func (p *prober) start() {
+	go p.run()
+}
+
+func (p *prober) stop() {
+	p.stopOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+func (p *prober) run() {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.probeAll()
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *prober) probeAll() {
+	var wg sync.WaitGroup
+	for i, addr := range p.addrs {
+		wg.Add(1)
+		go func(i int, addr string) {
+			defer wg.Done()
+			p.record(i, p.probe(addr))
+		}(i, addr)
+	}
+	wg.Wait()
+}
+
+// probe returns how long it takes to connect to the address, or a negative value if it fails
+func (p *prober) probe(addr string) time.Duration {
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", addr, p.timeout)
+	if err != nil {
+		return -1
+	}
+	latency := time.Since(start)
+	conn.Close()
+	return latency
+}
+
+func (p *prober) record(i int, latency time.Duration) {
+	prev := p.latencies[i].Load()
+	cur := int64(latency)
+	if cur >= 0 && prev >= 0 {
+		cur = prev + (cur-prev)>>latencyWeightShift
+	}
+	p.latencies[i].Store(cur)
+}
+
+// fastest returns the index of the reachable upstream with the lowest latency, or -1 if none of
+// them is reachable
+func (p *prober) fastest() int {
+	best := -1
+	var bestLatency int64
+	for i := range p.latencies {
+		l := p.latencies[i].Load()
+		if l < 0 {
+			continue
+		}
+		if best < 0 || l < bestLatency {
+			best = i
+			bestLatency = l
+		}
+	}
+	return best
+}
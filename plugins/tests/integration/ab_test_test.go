@@ -0,0 +1,102 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestABTest(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddConsumer("rick", map[string]interface{}{
+			"auth": map[string]interface{}{
+				"keyAuth": `{"key":"rick"}`,
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewPluinConfig([]*model.FilterConfig{
+		{
+			Name: "keyAuth",
+			Config: map[string]interface{}{
+				"keys": []interface{}{
+					map[string]interface{}{
+						"name": "Authorization",
+					},
+				},
+			},
+		},
+		{
+			Name: "abTest",
+			Config: map[string]interface{}{
+				"experiments": []interface{}{
+					map[string]interface{}{
+						"name": "consumer",
+						"user": map[string]interface{}{
+							"consumer": true,
+						},
+						"variants": []interface{}{
+							map[string]interface{}{"name": "a", "weight": 0},
+							map[string]interface{}{"name": "b", "weight": 1},
+						},
+					},
+					map[string]interface{}{
+						"name":   "cookie",
+						"header": "x-variant",
+						"user": map[string]interface{}{
+							"cookie": "uid",
+						},
+						"variants": []interface{}{
+							map[string]interface{}{"name": "on", "weight": 1},
+						},
+					},
+				},
+			},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Add("Authorization", "rick")
+	hdr.Add("Cookie", "uid=morty")
+	resp, err := dp.Get("/echo", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "b", resp.Header.Get("echo-x-ab-consumer"))
+	assert.Equal(t, "on", resp.Header.Get("echo-x-variant"))
+
+	// the variant sent by the client is dropped when the user is unknown
+	hdr = http.Header{}
+	hdr.Add("Authorization", "rick")
+	hdr.Add("x-variant", "on")
+	resp, err = dp.Get("/echo", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "b", resp.Header.Get("echo-x-ab-consumer"))
+	assert.Equal(t, "", resp.Header.Get("echo-x-variant"))
+}
@@ -0,0 +1,94 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestAccessLog(t *testing.T) {
+	logs := make(chan []byte, 10)
+	addr := startHostServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logs" || r.Header.Get("x-api-key") != "key" {
+			w.WriteHeader(403)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		logs <- body
+	}))
+
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("accessLog", map[string]interface{}{
+		"fields": map[string]interface{}{
+			"method": "${request.method}",
+			"path":   "${request.path}",
+			"status": "${response.code}",
+			"tenant": "${header.x-tenant}",
+		},
+		"sinks": []interface{}{
+			map[string]interface{}{
+				"http": map[string]interface{}{
+					"url": "http://" + addr + "/logs",
+					"headers": map[string]interface{}{
+						"x-api-key": "key",
+					},
+					"batch": map[string]interface{}{
+						"flushInterval": "0.1s",
+					},
+				},
+			},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("x-tenant", "a")
+	resp, err := dp.Get("/echo", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	select {
+	case body := <-logs:
+		lines := bytes.Split(bytes.TrimSpace(body), []byte("\n"))
+		require.Len(t, lines, 1)
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal(lines[0], &entry))
+		assert.Equal(t, map[string]interface{}{
+			"method": "GET",
+			"path":   "/echo",
+			"status": float64(200),
+			"tenant": "a",
+		}, entry)
+	case <-time.After(5 * time.Second):
+		t.Fatal("access log not received")
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+	"mosn.io/htnn/api/plugins/tests/integration/helper"
+)
+
+func TestAICache(t *testing.T) {
+	// a fake embedding API which puts the similar questions close to each other
+	addr := startHostServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(400)
+			return
+		}
+		vec := []float32{0, 1, 0}
+		if strings.Contains(req.Input, "What is HTNN?") {
+			vec = []float32{1, 0, 0}
+		} else if strings.Contains(req.Input, "What's HTNN?") {
+			vec = []float32{0.99, 0.1, 0}
+		}
+		w.Header().Set("content-type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []interface{}{
+				map[string]interface{}{"embedding": vec},
+			},
+		})
+	}))
+
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(completionRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	helper.WaitServiceUp(t, ":6381", "redis-stack")
+
+	config := controlplane.NewSinglePluinConfig("aiCache", map[string]interface{}{
+		"embedding": map[string]interface{}{
+			"url": "http://" + addr + "/v1/embeddings",
+		},
+		"redis": map[string]interface{}{
+			"address": "redis-stack:6379",
+		},
+		// use a new index in each run
+		"prefix": fmt.Sprintf("htnn-ai-cache-%d", time.Now().UnixNano()),
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	ask := func(question string) *http.Response {
+		hdr := http.Header{}
+		hdr.Set("content-type", "application/json")
+		resp, err := dp.Post("/v1/chat/completions", hdr, strings.NewReader(
+			fmt.Sprintf(`{"model":"llama","messages":[{"role":"user","content":%q}]}`, question)))
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := ask("What is HTNN?")
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "MISS", resp.Header.Get("x-htnn-ai-cache"))
+	expected, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	// the response is saved after it's sent
+	require.Eventually(t, func() bool {
+		resp = ask("What's HTNN?")
+		return resp.Header.Get("x-htnn-ai-cache") == "HIT"
+	}, 3*time.Second, 100*time.Millisecond)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(body))
+
+	resp = ask("Who are you?")
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "MISS", resp.Header.Get("x-htnn-ai-cache"))
+}
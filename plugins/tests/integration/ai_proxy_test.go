@@ -0,0 +1,104 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+// llmRoute is a fake LLM provider which returns the translated request in the response headers
+const llmRoute = `
+match:
+  prefix: /v1/
+direct_response:
+  status: 200
+  body:
+    inline_string: '{"choices":[]}'
+response_headers_to_add:
+- header:
+    key: echo-path
+    value: "%REQ(:path)%"
+- header:
+    key: echo-host
+    value: "%REQ(:authority)%"
+- header:
+    key: echo-authorization
+    value: "%REQ(authorization)%"
+- header:
+    key: echo-x-htnn-ai-provider
+    value: "%REQ(x-htnn-ai-provider)%"
+`
+
+func TestAIProxy(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(llmRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("aiProxy", map[string]interface{}{
+		"providers": []interface{}{
+			map[string]interface{}{
+				"name":   "vllm",
+				"models": []interface{}{"llama"},
+				"modelMapping": map[string]interface{}{
+					"llama": "meta-llama/Llama-3.1-8B",
+				},
+				"apiKey": "key",
+				"vllm": map[string]interface{}{
+					"url": "http://vllm.default:8000",
+				},
+			},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("content-type", "application/json")
+	// the credential of the client is not sent to the provider
+	hdr.Set("authorization", "Bearer client")
+	resp, err := dp.Post("/v1/chat/completions", hdr,
+		strings.NewReader(`{"model":"llama","messages":[{"role":"user","content":"hi"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "/v1/chat/completions", resp.Header.Get("echo-path"))
+	assert.Equal(t, "vllm.default:8000", resp.Header.Get("echo-host"))
+	assert.Equal(t, "Bearer key", resp.Header.Get("echo-authorization"))
+	assert.Equal(t, "vllm", resp.Header.Get("echo-x-htnn-ai-provider"))
+
+	resp, err = dp.Post("/v1/chat/completions", hdr,
+		strings.NewReader(`{"model":"gpt-4o","messages":[{"role":"user","content":"hi"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"code":"model_not_found"`)
+
+	resp, err = dp.Get("/v1/models", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+}
@@ -0,0 +1,97 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+	"mosn.io/htnn/api/plugins/tests/integration/helper"
+)
+
+// completionRoute is a fake LLM provider which consumes 10 tokens per request
+const completionRoute = `
+match:
+  path: /v1/chat/completions
+direct_response:
+  status: 200
+  body:
+    inline_string: '{"choices":[{"message":{"role":"assistant","content":"hello"}}],"usage":{"prompt_tokens":8,"completion_tokens":2,"total_tokens":10}}'
+response_headers_to_add:
+- header:
+    key: content-type
+    value: application/json
+`
+
+func TestAITokenLimit(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(completionRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	helper.WaitServiceUp(t, ":6379", "redis")
+
+	config := controlplane.NewSinglePluinConfig("aiTokenLimit", map[string]interface{}{
+		"prefix":  "5f0c6a3e",
+		"address": "redis:6379",
+		"rules": []interface{}{
+			map[string]interface{}{
+				"tokens":     10,
+				"timeWindow": "3600s",
+				"key":        `request.header("x-key")`,
+			},
+		},
+		"enableLimitQuotaHeaders": true,
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	key := time.Now().Format(time.RFC3339Nano)
+	complete := func(key string) *http.Response {
+		hdr := http.Header{}
+		hdr.Set("x-key", key)
+		hdr.Set("content-type", "application/json")
+		resp, err := dp.Post("/v1/chat/completions", hdr,
+			strings.NewReader(`{"model":"llama","messages":[{"role":"user","content":"hi"}]}`))
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := complete(key)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "10", resp.Header.Get("x-ratelimit-limit-tokens"))
+	assert.Equal(t, "10", resp.Header.Get("x-ratelimit-remaining-tokens"))
+
+	// the tokens are added to the budget after the response is finished
+	require.Eventually(t, func() bool {
+		resp = complete(key)
+		return resp.StatusCode == 429
+	}, 3*time.Second, 100*time.Millisecond)
+	assert.NotEmpty(t, resp.Header.Get("retry-after"))
+
+	// the budget of other keys is not affected
+	resp = complete(key + "-other")
+	assert.Equal(t, 200, resp.StatusCode)
+}
@@ -0,0 +1,90 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+	"mosn.io/htnn/api/plugins/tests/integration/helper"
+)
+
+func TestCache(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	helper.WaitServiceUp(t, ":6379", "redis")
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+	}{
+		{
+			name: "memory",
+			config: controlplane.NewSinglePluinConfig("cache", map[string]interface{}{
+				"memory":     map[string]interface{}{},
+				"defaultTtl": "60s",
+			}),
+		},
+		{
+			name: "redis",
+			config: controlplane.NewSinglePluinConfig("cache", map[string]interface{}{
+				"redis": map[string]interface{}{
+					"address": "redis:6379",
+					"prefix":  "3b9f6a1d",
+				},
+				"defaultTtl": "60s",
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+
+			path := "/echo?case=" + tt.name
+			hdr := http.Header{}
+			hdr.Set("x-id", "1")
+			resp, err := dp.Get(path, hdr)
+			require.NoError(t, err)
+			assert.Equal(t, 200, resp.StatusCode)
+			assert.Equal(t, "MISS", resp.Header.Get("x-cache-status"))
+
+			// the response is served from the cache, so the header echoed is still the first one
+			hdr.Set("x-id", "2")
+			resp, err = dp.Get(path, hdr)
+			require.NoError(t, err)
+			assert.Equal(t, 200, resp.StatusCode)
+			assert.Equal(t, "HIT", resp.Header.Get("x-cache-status"))
+			assert.Equal(t, "1", resp.Header.Get("echo-x-id"))
+
+			hdr.Set("cache-control", "no-store")
+			resp, err = dp.Get(path, hdr)
+			require.NoError(t, err)
+			assert.Equal(t, "BYPASS", resp.Header.Get("x-cache-status"))
+			assert.Equal(t, "2", resp.Header.Get("echo-x-id"))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestCanary(t *testing.T) {
+	paths := make(chan string, 1)
+	addr := startHostServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.URL.RequestURI()
+		w.Header().Set("x-upstream", "canary")
+		w.WriteHeader(201)
+	}))
+
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("canary", map[string]interface{}{
+		"cluster": addr,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"headers": []interface{}{
+					map[string]interface{}{
+						"name":  "x-canary",
+						"value": map[string]interface{}{"exact": "true"},
+					},
+				},
+			},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp, err := dp.Get("/echo?a=1", http.Header{"x-canary": []string{"true"}})
+	require.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, "canary", resp.Header.Get("x-upstream"))
+	assert.Equal(t, "/echo?a=1", <-paths)
+
+	// the other requests go to the upstream of the route
+	resp, err = dp.Get("/echo?a=1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "", resp.Header.Get("x-upstream"))
+	assert.Equal(t, "/echo?a=1", resp.Header.Get("echo-path"))
+}
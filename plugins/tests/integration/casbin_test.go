@@ -87,6 +87,21 @@ g, bob, admin
 				assert.Equal(t, 200, resp.StatusCode)
 			},
 		},
+		{
+			name: "inline rule",
+			config: controlplane.NewSinglePluinConfig("casbin", map[string]interface{}{
+				"rule": map[string]string{
+					"modelText":  model,
+					"policyText": policy,
+				},
+				"token": map[string]string{
+					"name": "customer",
+				},
+			}),
+			expect: func(t *testing.T, resp *http.Response) {
+				assert.Equal(t, 200, resp.StatusCode)
+			},
+		},
 		{
 			name: "change config",
 			config: controlplane.NewSinglePluinConfig("casbin", map[string]interface{}{
@@ -0,0 +1,59 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(unavailableRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("circuitBreaker", map[string]interface{}{
+		"failurePercentage": 50,
+		"minRequests":       2,
+		"openDuration":      "10s",
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp, err := dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	for i := 0; i < 2; i++ {
+		resp, err = dp.Get("/unavailable", nil)
+		require.NoError(t, err)
+		assert.Equal(t, 503, resp.StatusCode)
+	}
+
+	// the breaker is open, so the request doesn't reach the upstream
+	resp, err = dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 503, resp.StatusCode)
+	assert.Equal(t, "", resp.Header.Get("echo-path"))
+	assert.NotEmpty(t, resp.Header.Get("retry-after"))
+}
@@ -0,0 +1,86 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"bytes"
+	"compress/gzip"
+	_ "embed"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+var (
+	//go:embed compression_route.yml
+	compressionRoute string
+)
+
+func TestCompression(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(compressionRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("compression", map[string]interface{}{
+		"encodings":         []interface{}{"GZIP"},
+		"minSize":           10,
+		"contentTypes":      []interface{}{"text/plain"},
+		"decompressRequest": true,
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	body := strings.Repeat("hello ", 100)
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	_, _ = w.Write([]byte(body))
+	require.NoError(t, w.Close())
+
+	// the request body is decompressed before reaching the upstream
+	hdr := http.Header{}
+	hdr.Set("content-encoding", "gzip")
+	hdr.Set("accept-encoding", "identity")
+	resp, err := dp.Post("/echo", hdr, bytes.NewReader(compressed.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "", resp.Header.Get("echo-content-encoding"))
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(b))
+
+	hdr = http.Header{}
+	hdr.Set("accept-encoding", "gzip")
+	resp, err = dp.Get("/compressible", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "gzip", resp.Header.Get("content-encoding"))
+	assert.Equal(t, "Accept-Encoding", resp.Header.Get("vary"))
+	r, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	b, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, strings.TrimSpace(body), string(b))
+}
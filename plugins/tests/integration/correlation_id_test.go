@@ -0,0 +1,60 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestCorrelationID(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("correlationId", map[string]interface{}{
+		"generator":         "ULID",
+		"includeInResponse": true,
+		"overrideRequestId": true,
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp, err := dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	id := resp.Header.Get("x-correlation-id")
+	assert.Regexp(t, regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`), id)
+	assert.Equal(t, id, resp.Header.Get("echo-x-correlation-id"))
+	assert.Equal(t, id, resp.Header.Get("echo-x-request-id"))
+
+	// the ID sent by the client is kept
+	hdr := http.Header{}
+	hdr.Add("x-correlation-id", "abc")
+	resp, err = dp.Get("/echo", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "abc", resp.Header.Get("x-correlation-id"))
+	assert.Equal(t, "abc", resp.Header.Get("echo-x-correlation-id"))
+}
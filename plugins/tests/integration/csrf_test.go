@@ -0,0 +1,76 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestCSRF(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("csrf", map[string]interface{}{
+		"secret": "secret",
+		"exemptPaths": []interface{}{
+			map[string]interface{}{
+				"path": map[string]interface{}{
+					"prefix": "/echo/webhook",
+				},
+			},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp, err := dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	var token string
+	for _, c := range resp.Cookies() {
+		if c.Name == "htnn_csrf" {
+			token = c.Value
+		}
+	}
+	require.NotEmpty(t, token)
+
+	hdr := http.Header{}
+	hdr.Set("Cookie", "htnn_csrf="+token)
+	resp, err = dp.Post("/echo", hdr, strings.NewReader("any"))
+	require.NoError(t, err)
+	assert.Equal(t, 403, resp.StatusCode)
+
+	hdr.Set("x-csrf-token", token)
+	resp, err = dp.Post("/echo", hdr, strings.NewReader("any"))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	// the valid token is not reissued
+	assert.Empty(t, resp.Header.Values("Set-Cookie"))
+
+	resp, err = dp.Post("/echo/webhook", nil, strings.NewReader("any"))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
@@ -0,0 +1,89 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestDubboProxy(t *testing.T) {
+	// a fake Dubbo provider which accepts the invocation but never responds
+	lis, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer lis.Close()
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		hdr := make([]byte, 16)
+		if _, err := io.ReadFull(conn, hdr); err == nil {
+			received <- hdr
+		}
+		_, _ = io.Copy(io.Discard, conn)
+	}()
+
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	port := lis.Addr().(*net.TCPAddr).Port
+	config := controlplane.NewSinglePluinConfig("dubboProxy", map[string]interface{}{
+		"addresses":  []interface{}{fmt.Sprintf("host.docker.internal:%d", port)},
+		"interface":  "org.apache.dubbo.samples.UserService",
+		"version":    "1.0.0",
+		"method":     "getUser",
+		"paramTypes": []interface{}{"java.lang.Long", "java.lang.String"},
+		"timeout":    "0.2s",
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp, err := dp.Post("/users", nil, strings.NewReader(`[1]`))
+	require.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("content-type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"error":"expect 2 arguments, got 1"}`, string(body))
+
+	// the request is converted into a Dubbo invocation instead of being sent to the upstream
+	resp, err = dp.Post("/users", http.Header{"content-type": []string{"application/json"}}, strings.NewReader(`[1, "us"]`))
+	require.NoError(t, err)
+	assert.Equal(t, 504, resp.StatusCode)
+	assert.Equal(t, "", resp.Header.Get("echo-path"))
+	select {
+	case hdr := <-received:
+		assert.Equal(t, []byte{0xda, 0xbb}, hdr[:2], "not a Dubbo request")
+	case <-time.After(time.Second):
+		t.Fatal("the invocation is not received")
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+// startFakeClamd starts a clamd which detects the EICAR test file
+func startFakeClamd(t *testing.T) string {
+	lis, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		lis.Close()
+	})
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				cmd, err := r.ReadString(0)
+				if err != nil || cmd != "zINSTREAM\x00" {
+					return
+				}
+				var content bytes.Buffer
+				for {
+					var size uint32
+					if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+						return
+					}
+					if size == 0 {
+						break
+					}
+					if _, err := io.CopyN(&content, r, int64(size)); err != nil {
+						return
+					}
+				}
+				if strings.Contains(content.String(), "EICAR") {
+					_, _ = conn.Write([]byte("stream: Eicar-Signature FOUND\x00"))
+				} else {
+					_, _ = conn.Write([]byte("stream: OK\x00"))
+				}
+			}()
+		}
+	}()
+	return fmt.Sprintf("host.docker.internal:%d", lis.Addr().(*net.TCPAddr).Port)
+}
+
+func uploadFile(t *testing.T, dp *dataplane.DataPlane, content string) *http.Response {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	part, err := w.CreateFormFile("file", "test.txt")
+	require.NoError(t, err)
+	_, _ = part.Write([]byte(content))
+	require.NoError(t, w.Close())
+
+	hdr := http.Header{}
+	hdr.Set("content-type", w.FormDataContentType())
+	resp, err := dp.Post("/echo", hdr, &body)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestFileScan(t *testing.T) {
+	addr := startFakeClamd(t)
+
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("fileScan", map[string]interface{}{
+		"clamav": map[string]interface{}{
+			"address": addr,
+		},
+		"timeout": "1s",
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp := uploadFile(t, dp, "hello")
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "hello")
+
+	resp = uploadFile(t, dp, "X5O!P%@AP[4\\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*")
+	assert.Equal(t, 403, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "infected file is detected", string(body))
+}
@@ -0,0 +1,65 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestFingerprint(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("fingerprint", map[string]interface{}{
+		"ja3Header": "x-ja3",
+		"forwardHeaders": map[string]interface{}{
+			"ja3":    "x-fp-ja3",
+			"header": "x-fp-header",
+		},
+		"deny": map[string]interface{}{
+			"ja3": []interface{}{"e7d705a3286e19ea42f587b344ee6865"},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("x-ja3", "771a9c4b3e8bd9c5f7f16d1b9c6f2b6d")
+	// the forwarded header sent by the client is overridden
+	hdr.Set("x-fp-header", "forged")
+	resp, err := dp.Get("/echo", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "771a9c4b3e8bd9c5f7f16d1b9c6f2b6d", resp.Header.Get("echo-x-fp-ja3"))
+	assert.Regexp(t, regexp.MustCompile(`^ge11nn\d{2}0000_[0-9a-f]{12}$`), resp.Header.Get("echo-x-fp-header"))
+
+	// the fingerprints are matched case-insensitively
+	hdr = http.Header{}
+	hdr.Set("x-ja3", "E7D705A3286E19EA42F587B344EE6865")
+	resp, err = dp.Get("/echo", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 403, resp.StatusCode)
+}
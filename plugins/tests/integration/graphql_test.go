@@ -0,0 +1,60 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestGraphQL(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("graphql", map[string]interface{}{
+		"maxDepth":             2,
+		"disableIntrospection": true,
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("content-type", "application/json")
+	resp, err := dp.Post("/echo", hdr, strings.NewReader(`{"query":"{ user { name } }"}`))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	resp, err = dp.Post("/echo", hdr, strings.NewReader(`{"query":"{ user { friends { name } } }"}`))
+	require.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"errors":[{"message":"query depth 3 exceeds the limit 2"}]}`, string(body))
+
+	resp, err = dp.Get("/echo?query=%7B__schema%7Btypes%7Bname%7D%7D%7D", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
@@ -0,0 +1,93 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+	"mosn.io/htnn/api/plugins/tests/integration/helper"
+)
+
+func TestIdempotency(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	helper.WaitServiceUp(t, ":6379", "redis")
+
+	config := controlplane.NewSinglePluinConfig("idempotency", map[string]interface{}{
+		"redis": map[string]interface{}{
+			"address": "redis:6379",
+		},
+		"prefix":   "4c1e9b7d",
+		"required": true,
+		"ttl":      "60s",
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	// use a new key in each run
+	key := time.Now().Format(time.RFC3339Nano)
+	post := func(path string, key string, body string) *http.Response {
+		hdr := http.Header{}
+		if key != "" {
+			hdr.Set("idempotency-key", key)
+		}
+		resp, err := dp.Post(path, hdr, strings.NewReader(body))
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := post("/echo", key, "first")
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "", resp.Header.Get("idempotent-replayed"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(body))
+
+	// the retried request gets the stored response
+	resp = post("/echo", key, "second")
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "true", resp.Header.Get("idempotent-replayed"))
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(body))
+
+	// the key is scoped to the path
+	resp = post("/echo?a=1", key, "third")
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "third", string(body))
+
+	resp = post("/echo", "", "no key")
+	assert.Equal(t, 400, resp.StatusCode)
+
+	// the methods not deduplicated are passed
+	resp, err = dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
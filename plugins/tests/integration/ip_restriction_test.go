@@ -0,0 +1,76 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestIPRestriction(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("ipRestriction", map[string]interface{}{
+		"allow":             []interface{}{"10.0.0.0/8"},
+		"deny":              []interface{}{"10.0.0.1"},
+		"xffNumTrustedHops": 1,
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	tests := []struct {
+		name string
+		xff  string
+		code int
+	}{
+		{
+			name: "allowed",
+			xff:  "10.0.0.2",
+			code: 200,
+		},
+		{
+			name: "denied",
+			xff:  "10.0.0.1",
+			code: 403,
+		},
+		{
+			name: "not allowed",
+			xff:  "192.168.0.1",
+			code: 403,
+		},
+		{
+			name: "forged",
+			xff:  "10.0.0.2, 192.168.0.1",
+			code: 403,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hdr := http.Header{}
+			hdr.Set("x-forwarded-for", tt.xff)
+			resp, _ := dp.Head("/echo", hdr)
+			assert.Equal(t, tt.code, resp.StatusCode)
+		})
+	}
+}
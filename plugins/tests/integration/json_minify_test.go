@@ -0,0 +1,100 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+const prettyJSONRoute = `
+match:
+  path: /pretty
+direct_response:
+  status: 200
+  body:
+    inline_string: |
+      {
+        "id": 1,
+        "name": "htnn",
+        "items": [
+          {"id": 2, "price": 3, "note": "a"}
+        ]
+      }
+response_headers_to_add:
+- header:
+    key: content-type
+    value: application/json; charset=utf-8
+`
+
+func TestJSONMinify(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(prettyJSONRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("jsonMinify", map[string]interface{}{
+		"fieldsFilter": map[string]interface{}{},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	tests := []struct {
+		name   string
+		path   string
+		code   int
+		expect string
+	}{
+		{
+			name:   "minify",
+			path:   "/pretty",
+			code:   200,
+			expect: `{"id":1,"name":"htnn","items":[{"id":2,"price":3,"note":"a"}]}`,
+		},
+		{
+			name:   "partial response",
+			path:   "/pretty?fields=name,items(id,price)",
+			code:   200,
+			expect: `{"name":"htnn","items":[{"id":2,"price":3}]}`,
+		},
+		{
+			name: "malformed fields",
+			path: "/pretty?fields=items(id",
+			code: 400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := dp.Get(tt.path, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode)
+			if tt.code == 200 {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expect, string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestJWTIssuer(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddConsumer("rick", map[string]interface{}{
+			"auth": map[string]interface{}{
+				"keyAuth": `{"key":"rick"}`,
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewPluinConfig([]*model.FilterConfig{
+		{
+			Name: "keyAuth",
+			Config: map[string]interface{}{
+				"keys": []interface{}{
+					map[string]interface{}{
+						"name": "x-key",
+					},
+				},
+			},
+		},
+		{
+			Name: "jwtIssuer",
+			Config: map[string]interface{}{
+				"signingKey": "secret",
+				"issuer":     "https://gateway.internal",
+				"audiences":  []interface{}{"backend"},
+				"claims": map[string]interface{}{
+					"tenant": "${header.x-tenant}",
+				},
+				"rejectUnauthenticated": true,
+			},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("x-key", "rick")
+	hdr.Set("x-tenant", "a")
+	// the forged token is replaced
+	hdr.Set("Authorization", "Bearer forged")
+	resp, err := dp.Get("/echo", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	token, ok := strings.CutPrefix(resp.Header.Get("echo-authorization"), "Bearer ")
+	require.True(t, ok)
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), parts[2])
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]interface{}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, "https://gateway.internal", claims["iss"])
+	assert.Equal(t, "rick", claims["sub"])
+	assert.Equal(t, "backend", claims["aud"])
+	assert.Equal(t, "a", claims["tenant"])
+	assert.Greater(t, claims["exp"], claims["iat"])
+
+	resp, err = dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
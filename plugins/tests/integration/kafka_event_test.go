@@ -0,0 +1,107 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+type kafkaRecord struct {
+	Key   string `json:"key"`
+	Value struct {
+		Request struct {
+			Method  string            `json:"method"`
+			Path    string            `json:"path"`
+			Headers map[string]string `json:"headers"`
+			Body    string            `json:"body"`
+		} `json:"request"`
+		Response struct {
+			Code uint32 `json:"code"`
+		} `json:"response"`
+	} `json:"value"`
+}
+
+func TestKafkaEvent(t *testing.T) {
+	produced := make(chan []kafkaRecord, 10)
+	// a fake Kafka REST Proxy
+	addr := startHostServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/topics/gateway-events" {
+			w.WriteHeader(404)
+			return
+		}
+		var req struct {
+			Records []kafkaRecord `json:"records"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(400)
+			return
+		}
+		produced <- req.Records
+		w.Header().Set("content-type", "application/vnd.kafka.v2+json")
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":0}]}`))
+	}))
+
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("kafkaEvent", map[string]interface{}{
+		"restProxyUrl":   "http://" + addr,
+		"topic":          "gateway-events",
+		"key":            "${header.x-tenant}",
+		"requestHeaders": []interface{}{"x-tenant"},
+		"bodySampling": map[string]interface{}{
+			"percentage": 100,
+			"request":    true,
+		},
+		"flushInterval": "0.1s",
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("x-tenant", "a")
+	hdr.Set("x-secret", "s")
+	resp, err := dp.Post("/echo", hdr, strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	select {
+	case records := <-produced:
+		require.Len(t, records, 1)
+		r := records[0]
+		assert.Equal(t, "a", r.Key)
+		assert.Equal(t, "POST", r.Value.Request.Method)
+		assert.Equal(t, "/echo", r.Value.Request.Path)
+		// only the configured headers are included
+		assert.Equal(t, map[string]string{"x-tenant": "a"}, r.Value.Request.Headers)
+		assert.Equal(t, "hello", r.Value.Request.Body)
+		assert.Equal(t, uint32(200), r.Value.Response.Code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("event not received")
+	}
+}
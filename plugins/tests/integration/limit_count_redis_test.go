@@ -186,6 +186,65 @@ func TestLimitCountRedis(t *testing.T) {
 				assert.Equal(t, "1", resp.Header.Get("X-Ratelimit-Reset"))
 			},
 		},
+		{
+			name: "sliding window",
+			config: controlplane.NewSinglePluinConfig("limitCountRedis", map[string]interface{}{
+				"prefix":                  "5b0f3a4e",
+				"address":                 "redis:6379",
+				"enableLimitQuotaHeaders": true,
+				"rules": []interface{}{
+					map[string]interface{}{
+						"count":         1,
+						"timeWindow":    "1s",
+						"key":           `request.header("x-key")`,
+						"slidingWindow": true,
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Add("x-key", "1")
+				resp, _ := dp.Head("/echo", hdr)
+				assert.Equal(t, 200, resp.StatusCode)
+				assert.Equal(t, "0", resp.Header.Get("X-Ratelimit-Remaining"))
+				assert.Equal(t, "1", resp.Header.Get("X-Ratelimit-Reset"))
+				resp, _ = dp.Head("/echo", hdr)
+				assert.Equal(t, 429, resp.StatusCode)
+				assert.Equal(t, "true", resp.Header.Get("X-Envoy-Ratelimited"))
+				resp, _ = dp.Head("/echo", nil)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				// the previous window is slided out
+				time.Sleep(2 * time.Second)
+				resp, _ = dp.Head("/echo", hdr)
+				assert.Equal(t, 200, resp.StatusCode)
+			},
+		},
+		{
+			name: "sentinel",
+			config: controlplane.NewSinglePluinConfig("limitCountRedis", map[string]interface{}{
+				"prefix": "9a3c6de1",
+				"sentinel": map[string]interface{}{
+					"addresses":  []interface{}{"redis-sentinel:26379"},
+					"masterName": "mymaster",
+				},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"count":      1,
+						"timeWindow": "1s",
+						"key":        `request.header("x-key")`,
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{}
+				hdr.Add("x-key", "1")
+				resp, _ := dp.Head("/echo", hdr)
+				assert.Equal(t, 200, resp.StatusCode)
+				resp, _ = dp.Head("/echo", hdr)
+				assert.Equal(t, 429, resp.StatusCode)
+			},
+		},
 		{
 			name: "passwd",
 			config: controlplane.NewSinglePluinConfig("limitCountRedis", map[string]interface{}{
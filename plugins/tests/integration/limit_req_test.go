@@ -75,6 +75,25 @@ func TestLimitReq(t *testing.T) {
 				assert.Equal(t, 200, resp.StatusCode)
 				resp, _ = dp.Head("/echo", nil)
 				assert.Equal(t, 429, resp.StatusCode)
+				assert.Equal(t, "1", resp.Header.Get("RateLimit-Limit"))
+				assert.Equal(t, "0", resp.Header.Get("RateLimit-Remaining"))
+				assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+			},
+		},
+		{
+			name: "no delay",
+			config: controlplane.NewSinglePluinConfig("limitReq", map[string]interface{}{
+				"average": 1,
+				"period":  "0.1s",
+				"noDelay": true,
+			}),
+			run: func(t *testing.T) {
+				resp, _ := dp.Head("/echo", nil)
+				assert.Equal(t, 200, resp.StatusCode)
+
+				time.Sleep(50 * time.Millisecond)
+				resp, _ = dp.Head("/echo", nil)
+				assert.Equal(t, 429, resp.StatusCode)
 			},
 		},
 		{
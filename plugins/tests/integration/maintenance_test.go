@@ -0,0 +1,94 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func maintenanceToken(action string, secret string) string {
+	msg := fmt.Sprintf("%s:%d", action, time.Now().Unix())
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(msg))
+	return msg + ":" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestMaintenance(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("maintenance", map[string]interface{}{
+		"enabled":    false,
+		"body":       "<h1>We will be back soon</h1>",
+		"retryAfter": "600s",
+		"admin": map[string]interface{}{
+			"secret": "secret",
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp, err := dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	switchMode := func(token string) *http.Response {
+		hdr := http.Header{}
+		hdr.Set("x-htnn-maintenance", token)
+		resp, err := dp.Get("/echo", hdr)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp = switchMode(maintenanceToken("on", "wrong"))
+	assert.Equal(t, 403, resp.StatusCode)
+
+	resp = switchMode(maintenanceToken("on", "secret"))
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "maintenance on", string(body))
+
+	resp, err = dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 503, resp.StatusCode)
+	assert.Equal(t, "text/html; charset=utf-8", resp.Header.Get("content-type"))
+	assert.Equal(t, "600", resp.Header.Get("retry-after"))
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "<h1>We will be back soon</h1>", string(body))
+
+	resp = switchMode(maintenanceToken("off", "secret"))
+	assert.Equal(t, 200, resp.StatusCode)
+	resp, err = dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
@@ -0,0 +1,74 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestMethodOverride(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("methodOverride", map[string]interface{}{
+		"override": map[string]interface{}{
+			"methods": []interface{}{"PUT", "DELETE"},
+		},
+		"allowedMethods": []interface{}{"GET", "POST", "PUT"},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("x-http-method-override", "put")
+	resp, err := dp.Post("/echo", hdr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "PUT", resp.Header.Get("echo-method"))
+	assert.Equal(t, "", resp.Header.Get("echo-x-http-method-override"))
+
+	// the overridden method is checked against the allowed methods
+	hdr.Set("x-http-method-override", "DELETE")
+	resp, err = dp.Post("/echo", hdr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 405, resp.StatusCode)
+	assert.Equal(t, "GET, POST, PUT", resp.Header.Get("allow"))
+
+	hdr.Set("x-http-method-override", "PATCH")
+	resp, err = dp.Post("/echo", hdr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	// only the POST requests are overridden
+	hdr.Set("x-http-method-override", "PUT")
+	resp, err = dp.Get("/echo", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "GET", resp.Header.Get("echo-method"))
+
+	resp, err = dp.Patch("/echo", nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 405, resp.StatusCode)
+}
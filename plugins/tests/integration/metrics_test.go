@@ -0,0 +1,73 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestMetrics(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Envs: map[string]string{
+			"HTNN_PHASE_METRICS_ADDR": "0.0.0.0:9080",
+		},
+		Ports: []int{9080},
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("metrics", map[string]interface{}{})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp, err := dp.Post("/echo", nil, strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	labels := `route="[^"]*",method="POST",status_class="2xx",consumer=""`
+	expected := []*regexp.Regexp{
+		regexp.MustCompile(`(?m)^htnn_requests_total\{` + labels + `\} 1$`),
+		regexp.MustCompile(`(?m)^htnn_request_size_bytes_sum\{` + labels + `\} 5$`),
+		regexp.MustCompile(`(?m)^htnn_request_duration_seconds_count\{` + labels + `\} 1$`),
+	}
+	// the request is recorded after the response is sent
+	require.Eventually(t, func() bool {
+		resp, err := http.Get("http://0.0.0.0:9080/metrics")
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		for _, re := range expected {
+			if !re.Match(body) {
+				return false
+			}
+		}
+		return true
+	}, 3*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,77 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestMirror(t *testing.T) {
+	mirrored := make(chan *http.Request, 1)
+	bodies := make(chan string, 1)
+	addr := startHostServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mirrored <- r
+		bodies <- string(body)
+		// the response of the mirrored request is discarded
+		w.WriteHeader(500)
+	}))
+
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("mirror", map[string]interface{}{
+		"cluster":    addr,
+		"percentage": 100,
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("x-user", "alice")
+	resp, err := dp.Post("/echo?a=1", hdr, strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	// the original request is not changed
+	assert.Equal(t, "", resp.Header.Get("echo-x-mirrored"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+
+	select {
+	case r := <-mirrored:
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/echo?a=1", r.URL.RequestURI())
+		assert.Equal(t, "localhost:10000-shadow", r.Host)
+		assert.Equal(t, "alice", r.Header.Get("x-user"))
+		assert.Equal(t, "true", r.Header.Get("x-mirrored"))
+		assert.Equal(t, "hello", <-bodies)
+	case <-time.After(3 * time.Second):
+		t.Fatal("mirrored request not received")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestMock(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("mock", map[string]interface{}{
+		"status": 503,
+		"headers": []interface{}{
+			map[string]interface{}{"key": "content-type", "value": "application/json"},
+			map[string]interface{}{"key": "x-user", "value": "${header.x-user}"},
+		},
+		"body": `{"msg":"${request.method} ${request.path} is under maintenance"}`,
+		"delay": map[string]interface{}{
+			"min": "0.1s",
+			"max": "0.2s",
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("x-user", "alice")
+	start := time.Now()
+	resp, err := dp.Get("/echo?a=1", hdr)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	assert.Equal(t, 503, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("content-type"))
+	assert.Equal(t, "alice", resp.Header.Get("x-user"))
+	// the request doesn't reach the upstream
+	assert.Equal(t, "", resp.Header.Get("echo-path"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"msg":"GET /echo?a=1 is under maintenance"}`, string(body))
+}
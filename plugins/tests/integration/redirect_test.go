@@ -0,0 +1,84 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestRedirect(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("redirect", map[string]interface{}{
+		"rules": []interface{}{
+			map[string]interface{}{
+				"pathRegex":  `^/blog/(\d+)/(.*)$`,
+				"path":       "/posts/$2?year=$1",
+				"statusCode": 301,
+			},
+			map[string]interface{}{
+				"pathRegex": `^/docs(/.*)?$`,
+				"host":      "docs.example.com",
+				"path":      "$1",
+			},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	tests := []struct {
+		name     string
+		path     string
+		code     int
+		location string
+	}{
+		{
+			name:     "moved permanently",
+			path:     "/blog/2024/hello?ref=x",
+			code:     301,
+			location: "http://localhost:10000/posts/hello?year=2024&ref=x",
+		},
+		{
+			name:     "another host",
+			path:     "/docs/start",
+			code:     302,
+			location: "http://docs.example.com/start",
+		},
+		{
+			name: "not matched",
+			path: "/echo",
+			code: 200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := dp.Get(tt.path, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode)
+			assert.Equal(t, tt.location, resp.Header.Get("location"))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func gzipBody(t *testing.T, s string) *bytes.Buffer {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return &buf
+}
+
+func TestRequestDecompression(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("requestDecompression", map[string]interface{}{
+		"encodings":             []interface{}{"gzip"},
+		"maxBodySize":           1024,
+		"rejectUnknownEncoding": true,
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("content-encoding", "gzip")
+	resp, err := dp.Post("/echo", hdr, gzipBody(t, `{"user":"rick"}`))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "", resp.Header.Get("echo-content-encoding"))
+	assert.Equal(t, "15", resp.Header.Get("echo-content-length"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"user":"rick"}`, string(body))
+
+	// decompression bomb
+	resp, err = dp.Post("/echo", hdr, gzipBody(t, strings.Repeat("a", 4096)))
+	require.NoError(t, err)
+	assert.Equal(t, 413, resp.StatusCode)
+
+	resp, err = dp.Post("/echo", hdr, strings.NewReader("not gzip"))
+	require.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	hdr.Set("content-encoding", "br")
+	resp, err = dp.Post("/echo", hdr, strings.NewReader("whatever"))
+	require.NoError(t, err)
+	assert.Equal(t, 415, resp.StatusCode)
+}
@@ -0,0 +1,68 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestRequestHardening(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("requestHardening", map[string]interface{}{
+		"allowedHosts":  []interface{}{"localhost"},
+		"canonicalHost": "example.com",
+		// Envoy merges the repeated inline headers like authorization, so use a custom one
+		"singleValueHeaders": []interface{}{"x-single"},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Add("x-single", "a")
+	hdr.Add("x-single", "b")
+	hdr.Set("x-forwarded-host", "evil.com")
+	resp, err := dp.Get("/echo", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []string{"a"}, resp.Header.Values("echo-x-single"))
+	assert.Equal(t, "", resp.Header.Get("echo-x-forwarded-host"))
+	assert.Equal(t, "example.com", resp.Header.Get("echo-authority"))
+
+	resp, err = dp.Get("//evil.com/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+
+	config = controlplane.NewSinglePluinConfig("requestHardening", map[string]interface{}{
+		"allowedHosts":           []interface{}{"*.example.com"},
+		"rejectDuplicateHeaders": true,
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp, err = dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 421, resp.StatusCode)
+}
@@ -0,0 +1,66 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestResponseSigning(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("responseSigning", map[string]interface{}{
+		"secretKey":     "secret",
+		"keyId":         "k1",
+		"signedHeaders": []interface{}{"echo-x-id"},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("x-id", "1")
+	resp, err := dp.Post("/echo", hdr, strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+
+	sum := sha256.Sum256(body)
+	digest := "sha-256=:" + base64.StdEncoding.EncodeToString(sum[:]) + ":"
+	assert.Equal(t, digest, resp.Header.Get("content-digest"))
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("200\necho-x-id:1\ncontent-digest:" + digest + "\n"))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	assert.Equal(t, `keyId="k1",algorithm="hmac-sha256",headers="echo-x-id",signature="`+signature+`"`,
+		resp.Header.Get("x-htnn-signature"))
+}
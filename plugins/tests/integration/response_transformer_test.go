@@ -0,0 +1,75 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	_ "embed"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+var (
+	//go:embed response_transformer_route.yml
+	responseTransformerRoute string
+)
+
+func TestResponseTransformer(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(responseTransformerRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("responseTransformer", map[string]interface{}{
+		"status": 201,
+		"setHeaders": []interface{}{
+			map[string]interface{}{"key": "x-gateway", "value": "htnn"},
+		},
+		"removeHeaders": []interface{}{"x-legacy"},
+		"body": map[string]interface{}{
+			"keepFields": []interface{}{"data.id", "data.url"},
+			"setFields": []interface{}{
+				map[string]interface{}{"path": "data.version", "value": 2},
+			},
+			"regexReplaces": []interface{}{
+				map[string]interface{}{"regex": "http://", "replacement": "https://"},
+			},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp, err := dp.Get("/legacy", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, "htnn", resp.Header.Get("x-gateway"))
+	assert.Equal(t, "", resp.Header.Get("x-legacy"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"data":{"id":1,"url":"https://example.com/a","version":2}}`, string(body))
+
+	// the content type mismatched
+	resp, _ = dp.Get("/echo", nil)
+	assert.Equal(t, 201, resp.StatusCode)
+	assert.Equal(t, "htnn", resp.Header.Get("x-gateway"))
+}
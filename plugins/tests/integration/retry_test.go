@@ -0,0 +1,64 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+const unavailableRoute = `
+match:
+  path: /unavailable
+direct_response:
+  status: 503
+`
+
+func TestRetry(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(unavailableRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("retry", map[string]interface{}{
+		"retriableStatusCodes": []interface{}{503},
+		"numRetries":           2,
+		"timeout":              "5s",
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	retried := envoyCounter(t, "cluster.backend.upstream_rq_retry")
+	resp, err := dp.Get("/unavailable", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 503, resp.StatusCode)
+	// the route doesn't have a retry policy, the retries are driven by the plugin
+	assert.Eventually(t, func() bool {
+		return envoyCounter(t, "cluster.backend.upstream_rq_retry")-retried == 2
+	}, 3*time.Second, 50*time.Millisecond)
+
+	resp, err = dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
@@ -0,0 +1,129 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager"
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestSentinel(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(unavailableRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	tests := []struct {
+		name   string
+		config *filtermanager.FilterManagerConfig
+		run    func(t *testing.T)
+	}{
+		{
+			name: "flow",
+			config: controlplane.NewSinglePluinConfig("sentinel", map[string]interface{}{
+				"resource": map[string]interface{}{
+					"key": "x-res",
+				},
+				"flow": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{
+							"resource":         "flow",
+							"threshold":        1,
+							"statIntervalInMs": 60000,
+							"blockResponse": map[string]interface{}{
+								"message": "blocked",
+								"headers": map[string]interface{}{
+									"x-blocked": "flow",
+								},
+							},
+						},
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				hdr := http.Header{"x-res": []string{"flow"}}
+				resp, err := dp.Get("/echo", hdr)
+				require.NoError(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+				resp, err = dp.Get("/echo", hdr)
+				require.NoError(t, err)
+				assert.Equal(t, 429, resp.StatusCode)
+				assert.Equal(t, "flow", resp.Header.Get("x-blocked"))
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, "blocked", string(body))
+
+				// the request without resource is not limited
+				resp, err = dp.Get("/echo", nil)
+				require.NoError(t, err)
+				assert.Equal(t, 200, resp.StatusCode)
+			},
+		},
+		{
+			name: "circuit breaker",
+			config: controlplane.NewSinglePluinConfig("sentinel", map[string]interface{}{
+				"resource": map[string]interface{}{
+					"from": "QUERY",
+					"key":  "res",
+				},
+				"circuitBreaker": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{
+							"resource":               "circuit-breaker",
+							"strategy":               "ERROR_COUNT",
+							"retryTimeoutMs":         60000,
+							"minRequestAmount":       1,
+							"statIntervalMs":         60000,
+							"threshold":              1,
+							"triggeredByStatusCodes": []interface{}{503},
+							"blockResponse": map[string]interface{}{
+								"statusCode": 503,
+								"message":    "circuit breaker is open",
+							},
+						},
+					},
+				},
+			}),
+			run: func(t *testing.T) {
+				resp, err := dp.Get("/unavailable?res=circuit-breaker", nil)
+				require.NoError(t, err)
+				assert.Equal(t, 503, resp.StatusCode)
+				resp, err = dp.Get("/echo?res=circuit-breaker", nil)
+				require.NoError(t, err)
+				assert.Equal(t, 503, resp.StatusCode)
+				body, _ := io.ReadAll(resp.Body)
+				assert.Equal(t, "circuit breaker is open", string(body))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controlPlane.UseGoPluginConfig(t, tt.config, dp)
+			tt.run(t)
+		})
+	}
+}
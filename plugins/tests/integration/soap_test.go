@@ -0,0 +1,75 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	_ "embed"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+var (
+	//go:embed soap_route.yml
+	soapRoute string
+)
+
+func TestSoap(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(soapRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("soap", map[string]interface{}{
+		"action":   "urn:GetUser",
+		"template": `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><GetUser xmlns="urn:users">${body}</GetUser></soap:Body></soap:Envelope>`,
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("content-type", "application/json")
+	resp, err := dp.Post("/echo", hdr, strings.NewReader(`{"id":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "POST", resp.Header.Get("echo-method"))
+	assert.Equal(t, "text/xml; charset=utf-8", resp.Header.Get("echo-content-type"))
+	assert.Equal(t, `"urn:GetUser"`, resp.Header.Get("echo-soapaction"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body><GetUser xmlns="urn:users"><id>1</id></GetUser></soap:Body></soap:Envelope>`, string(body))
+
+	resp, err = dp.Post("/soap", hdr, strings.NewReader(`{"id":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("content-type"))
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"GetUserResponse":{"User":{"Id":"1","Name":"Alice"}}}`, string(body))
+
+	resp, err = dp.Post("/echo", hdr, strings.NewReader(`{`))
+	require.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
@@ -0,0 +1,74 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+const eventStreamRoute = `
+match:
+  path: /events
+direct_response:
+  status: 200
+  body:
+    inline_string: "id: 1\nevent: delta\ndata: hi\n\nevent: ping\ndata: 1\n\n: keepalive\ndata: done\nunknown: x\n\n"
+response_headers_to_add:
+- header:
+    key: content-type
+    value: text/event-stream
+`
+
+func TestSSE(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(eventStreamRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("sse", map[string]interface{}{
+		"dropEvents": []interface{}{"ping"},
+		"renameEvents": map[string]interface{}{
+			"delta": "message",
+		},
+		"retry": "3s",
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp, err := dp.Get("/events", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "no", resp.Header.Get("x-accel-buffering"))
+	assert.Equal(t, "", resp.Header.Get("content-length"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "id: 1\nretry: 3000\ndata: hi\n\n: keepalive\ndata: done\n\n", string(body))
+
+	// other responses are not touched
+	resp, err = dp.Get("/echo", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "", resp.Header.Get("x-accel-buffering"))
+}
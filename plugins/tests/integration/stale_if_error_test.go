@@ -0,0 +1,92 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+// the header is not a part of the key of the stored response, so it can make the same request fail
+const flakyFailedRoute = `
+match:
+  path: /flaky
+  headers:
+  - name: x-fail
+    present_match: true
+direct_response:
+  status: 503
+`
+
+const flakyRoute = `
+match:
+  path: /flaky
+direct_response:
+  status: 200
+  body:
+    inline_string: good
+`
+
+func TestStaleIfError(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddBackendRoute(flakyFailedRoute).AddBackendRoute(flakyRoute).
+			AddBackendRoute(unavailableRoute),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("staleIfError", map[string]interface{}{
+		"maxStale": "60s",
+		"fallback": map[string]interface{}{
+			"status": 200,
+			"body":   "fallback",
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	resp, err := dp.Get("/flaky", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "", resp.Header.Get("x-htnn-fallback"))
+
+	hdr := http.Header{}
+	hdr.Set("x-fail", "true")
+	resp, err = dp.Get("/flaky", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "stale", resp.Header.Get("x-htnn-fallback"))
+	assert.NotEmpty(t, resp.Header.Get("age"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "good", string(body))
+
+	resp, err = dp.Get("/unavailable", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "fallback", resp.Header.Get("x-htnn-fallback"))
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "fallback", string(body))
+}
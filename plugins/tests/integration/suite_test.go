@@ -15,10 +15,20 @@
 package integration
 
 import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
 	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
 )
 
@@ -35,3 +45,40 @@ func TestMain(m *testing.M) {
 
 	os.Exit(m.Run())
 }
+
+// startHostServer starts an HTTP server on the host, and returns the address to access it from the
+// data plane. It's used as the upstream which is called by the plugins directly.
+func startHostServer(t *testing.T, h http.Handler) string {
+	// listen to all interfaces so that it's accessible from the container
+	lis, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	srv := &http.Server{Handler: h}
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(func() {
+		srv.Close()
+	})
+	return fmt.Sprintf("host.docker.internal:%d", lis.Addr().(*net.TCPAddr).Port)
+}
+
+// envoyCounter returns the value of the given counter from the admin API of the data plane. Zero is
+// returned if the counter is not created yet.
+func envoyCounter(t *testing.T, name string) int {
+	filter := "^" + regexp.QuoteMeta(name) + "$"
+	resp, err := http.Get("http://0.0.0.0:9998/stats?filter=" + url.QueryEscape(filter))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, 200, resp.StatusCode)
+
+	sc := bufio.NewScanner(resp.Body)
+	for sc.Scan() {
+		k, v, found := strings.Cut(sc.Text(), ": ")
+		if found && k == name {
+			n, err := strconv.Atoi(v)
+			require.NoError(t, err)
+			return n
+		}
+	}
+	return 0
+}
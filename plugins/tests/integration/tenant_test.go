@@ -0,0 +1,122 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestTenant(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, &dataplane.Option{
+		Bootstrap: dataplane.Bootstrap().AddConsumer("rick", map[string]interface{}{
+			"auth": map[string]interface{}{
+				"keyAuth": `{"key":"rick"}`,
+			},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewPluinConfig([]*model.FilterConfig{
+		{
+			Name: "keyAuth",
+			Config: map[string]interface{}{
+				"keys": []interface{}{
+					map[string]interface{}{
+						"name": "Authorization",
+					},
+				},
+			},
+		},
+		{
+			Name: "tenant",
+			Config: map[string]interface{}{
+				"sources": []interface{}{
+					map[string]interface{}{
+						"host": map[string]interface{}{
+							"regex": `^([^.]+)\.example\.com$`,
+						},
+					},
+					map[string]interface{}{
+						"consumer": map[string]interface{}{
+							"tenants": map[string]interface{}{
+								"rick": "acme",
+							},
+						},
+					},
+				},
+				"required": true,
+			},
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	tests := []struct {
+		name   string
+		header http.Header
+		code   int
+		tenant string
+	}{
+		{
+			name:   "derived from consumer",
+			header: http.Header{"Authorization": []string{"rick"}},
+			code:   200,
+			tenant: "acme",
+		},
+		{
+			name: "same tenant sent by the client",
+			header: http.Header{
+				"Authorization": []string{"rick"},
+				"X-Tenant-Id":   []string{"acme"},
+			},
+			code:   200,
+			tenant: "acme",
+		},
+		{
+			name: "spoofed tenant",
+			header: http.Header{
+				"Authorization": []string{"rick"},
+				"X-Tenant-Id":   []string{"umbrella"},
+			},
+			code: 403,
+		},
+		{
+			name: "no tenant",
+			code: 403,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := dp.Get("/echo", tt.header)
+			require.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode)
+			if tt.code == 200 {
+				assert.Equal(t, tt.tenant, resp.Header.Get("echo-x-tenant-id"))
+			}
+		})
+	}
+}
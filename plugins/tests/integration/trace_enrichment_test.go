@@ -0,0 +1,68 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestTraceEnrichment(t *testing.T) {
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("traceEnrichment", map[string]interface{}{
+		"startTrace": map[string]interface{}{
+			"sampledPercentage": 100,
+		},
+		"baggage": map[string]interface{}{
+			"tenant": "${header.x-tenant}",
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	hdr := http.Header{}
+	hdr.Set("x-tenant", "a")
+	hdr.Set("tracestate", "vendor=value")
+	resp, err := dp.Get("/echo", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Regexp(t, regexp.MustCompile(`^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`), resp.Header.Get("echo-traceparent"))
+	assert.Equal(t, "", resp.Header.Get("echo-tracestate"))
+	assert.Equal(t, "tenant=a", resp.Header.Get("echo-baggage"))
+
+	// the trace carried by the request is kept, and the baggage entry is overridden
+	tp := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00"
+	hdr = http.Header{}
+	hdr.Set("x-tenant", "b")
+	hdr.Set("traceparent", tp)
+	hdr.Set("baggage", "tenant=a,user=rick")
+	resp, err = dp.Get("/echo", hdr)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, tp, resp.Header.Get("echo-traceparent"))
+	assert.Equal(t, "user=rick,tenant=b", resp.Header.Get("echo-baggage"))
+}
@@ -0,0 +1,81 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"mosn.io/htnn/api/plugins/tests/integration/controlplane"
+	"mosn.io/htnn/api/plugins/tests/integration/dataplane"
+)
+
+func TestTrafficSteering(t *testing.T) {
+	// the probe only establishes the TCP connection
+	lis, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer lis.Close()
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	dp, err := dataplane.StartDataPlane(t, nil)
+	if err != nil {
+		t.Fatalf("failed to start data plane: %v", err)
+		return
+	}
+	defer dp.Stop()
+
+	config := controlplane.NewSinglePluinConfig("trafficSteering", map[string]interface{}{
+		"upstreams": []interface{}{
+			map[string]interface{}{
+				"name": "down",
+				// nothing listens to this port
+				"probeAddress": "127.0.0.1:1",
+			},
+			map[string]interface{}{
+				"name":         "up",
+				"probeAddress": fmt.Sprintf("host.docker.internal:%d", lis.Addr().(*net.TCPAddr).Port),
+			},
+		},
+		"latencyProbe": map[string]interface{}{
+			"interval": "0.1s",
+			"timeout":  "0.5s",
+		},
+	})
+	controlPlane.UseGoPluginConfig(t, config, dp)
+
+	// the header sent by the client is overridden
+	hdr := http.Header{}
+	hdr.Set("x-htnn-upstream", "down")
+	require.Eventually(t, func() bool {
+		resp, err := dp.Get("/echo", hdr)
+		if err != nil || resp.StatusCode != 200 {
+			return false
+		}
+		return resp.Header.Get("echo-x-htnn-upstream") == "up"
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -303,13 +303,25 @@ type Message struct {
 
 func exactCommonField(field *parser.OneofField, n int) *parser.Field {
 	return &parser.Field{
-		FieldName:    field.FieldName,
+		FieldName:   field.FieldName,
+		Type:        field.Type,
+		FieldNumber: field.FieldNumber,
+		Comments:    field.Comments,
+		// For oneof fields, we document the requirement according to the number of fields in the oneof,
+		// so the validation rules of the field are not copied.
+		IsRequired: n == 1,
+	}
+}
+
+func exactMapField(field *parser.MapField) *parser.Field {
+	return &parser.Field{
+		FieldName:    field.MapName,
 		Type:         field.Type,
 		FieldNumber:  field.FieldNumber,
 		FieldOptions: field.FieldOptions,
 		Comments:     field.Comments,
-		// For oneof fields, we document the requirement according to the number of fields in the oneof.
-		IsRequired: n == 1,
+		// Like repeated fields, map fields are only required when they have a validation rule.
+		IsRepeated: true,
 	}
 }
 
@@ -327,7 +339,7 @@ func parseField(fs map[string]Field, field *parser.Field) {
 
 	if len(field.FieldOptions) > 0 {
 		for _, option := range field.FieldOptions {
-			if option.OptionName == "(validate.rules).repeated" {
+			if option.OptionName == "(validate.rules).repeated" || option.OptionName == "(validate.rules).map" {
 				f.Required = true
 			}
 			if strings.Contains(option.Constant, "required:true") {
@@ -336,6 +348,10 @@ func parseField(fs map[string]Field, field *parser.Field) {
 			if strings.Contains(option.Constant, "ignore_empty:true") {
 				f.Required = false
 			}
+			// the zero value is a defined enum value
+			if option.OptionName == "(validate.rules).enum.defined_only" {
+				f.Required = false
+			}
 		}
 	}
 	fs[snakeToCamel(field.FieldName)] = f
@@ -349,6 +365,8 @@ func parseMessage(ms map[string]Message, msg *parser.Message) {
 		switch field := body.(type) {
 		case *parser.Field:
 			parseField(m.Fields, field)
+		case *parser.MapField:
+			parseField(m.Fields, exactMapField(field))
 		case *parser.Message:
 			parseMessage(ms, field)
 		case *parser.Oneof:
@@ -15,6 +15,7 @@
 package v1
 
 import (
+	"slices"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,13 +25,25 @@ type ConditionType string
 
 const (
 	ConditionAccepted ConditionType = "Accepted"
+	// ConditionOverridden shows whether the plugins in the policy are overridden by the other policies
+	ConditionOverridden ConditionType = "Overridden"
+	// ConditionResolvedRefs shows whether the resource referred by the policy is resolved
+	ConditionResolvedRefs ConditionType = "ResolvedRefs"
 )
 
 type ConditionReason string
 
 const (
-	ReasonAccepted ConditionReason = "Accepted"
-	ReasonInvalid  ConditionReason = "Invalid"
+	ReasonAccepted      ConditionReason = "Accepted"
+	ReasonInvalid       ConditionReason = "Invalid"
+	ReasonOverridden    ConditionReason = "Overridden"
+	ReasonNotOverridden ConditionReason = "NotOverridden"
+	ReasonResolvedRefs  ConditionReason = "ResolvedRefs"
+	// ReasonForbidden means the resource uses the plugins which are not allowed by PluginConfigPolicy
+	ReasonForbidden ConditionReason = "Forbidden"
+	// ReasonUnresolved means the sensitive fields of the resource can't be resolved, like the referred
+	// Secret doesn't exist. Unlike ReasonInvalid, the resource is retried in the next reconciliation.
+	ReasonUnresolved ConditionReason = "Unresolved"
 )
 
 func needUpdateCondition(a, b metav1.Condition) bool {
@@ -63,6 +76,15 @@ func addOrUpdateCondition(conditions []metav1.Condition, one metav1.Condition) (
 	return conditions, changed
 }
 
+func removeCondition(conditions []metav1.Condition, tp ConditionType) ([]metav1.Condition, bool) {
+	for i, cond := range conditions {
+		if cond.Type == string(tp) {
+			return slices.Delete(conditions, i, i+1), true
+		}
+	}
+	return conditions, false
+}
+
 func addOrUpdateAcceptedCondition(conditions []metav1.Condition,
 	observedGeneration int64, reason ConditionReason, msg ...string) ([]metav1.Condition, bool) {
 
@@ -83,6 +105,20 @@ func addOrUpdateAcceptedCondition(conditions []metav1.Condition,
 		} else {
 			c.Message = "The resource is invalid"
 		}
+	case ReasonForbidden:
+		c.Status = metav1.ConditionFalse
+		if len(msg) > 0 {
+			c.Message = msg[0]
+		} else {
+			c.Message = "The resource uses forbidden plugins"
+		}
+	case ReasonUnresolved:
+		c.Status = metav1.ConditionFalse
+		if len(msg) > 0 {
+			c.Message = msg[0]
+		} else {
+			c.Message = "The sensitive fields of the resource can't be resolved"
+		}
 	}
 	return addOrUpdateCondition(conditions, c)
 }
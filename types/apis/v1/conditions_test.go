@@ -50,3 +50,93 @@ func TestAddOrUpdate(t *testing.T) {
 	assert.Equal(t, update, p.Status.Conditions[0])
 	assert.True(t, changed)
 }
+
+func TestSetOverridden(t *testing.T) {
+	p := &FilterPolicy{}
+	p.SetAccepted(gwapiv1a2.PolicyReasonAccepted)
+	assert.True(t, p.IsAccepted())
+
+	p.SetOverridden(map[string][]string{
+		"limitReq": {"default/a"},
+		"animal":   {"default/a", "default/b"},
+	})
+	assert.Equal(t, 3, len(p.Status.Conditions))
+	c := p.Status.Conditions[2]
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, string(ReasonOverridden), c.Reason)
+	assert.Equal(t, "plugin animal is overridden by default/a, default/b; plugin limitReq is overridden by default/a", c.Message)
+
+	p.Status.Reset()
+	p.SetOverridden(nil)
+	c = p.Status.Conditions[2]
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, string(ReasonNotOverridden), c.Reason)
+	assert.True(t, p.Status.IsChanged())
+
+	p.Status.Reset()
+	p.SetOverridden(nil)
+	assert.False(t, p.Status.IsChanged())
+
+	p.RemoveOverridden()
+	assert.Equal(t, 2, len(p.Status.Conditions))
+	assert.True(t, p.Status.IsChanged())
+
+	p.SetAccepted(gwapiv1a2.PolicyReasonTargetNotFound)
+	assert.False(t, p.IsAccepted())
+}
+
+func TestSetAcceptedResolvedRefs(t *testing.T) {
+	p := &FilterPolicy{}
+	p.SetAccepted(gwapiv1a2.PolicyReasonTargetNotFound, "sectionName a not found")
+	assert.Equal(t, 2, len(p.Status.Conditions))
+	c := p.Status.Conditions[1]
+	assert.Equal(t, string(ConditionResolvedRefs), c.Type)
+	assert.Equal(t, metav1.ConditionFalse, c.Status)
+	assert.Equal(t, string(gwapiv1a2.PolicyReasonTargetNotFound), c.Reason)
+	assert.Equal(t, "sectionName a not found", c.Message)
+
+	p.SetAccepted(gwapiv1a2.PolicyReasonAccepted)
+	c = p.Status.Conditions[1]
+	assert.Equal(t, metav1.ConditionTrue, c.Status)
+	assert.Equal(t, string(ReasonResolvedRefs), c.Reason)
+
+	p.Status.Reset()
+	p.SetAccepted(gwapiv1a2.PolicyReasonInvalid)
+	assert.Equal(t, 1, len(p.Status.Conditions))
+	assert.True(t, p.Status.IsChanged())
+}
+
+func TestSetPlugins(t *testing.T) {
+	p := &FilterPolicy{}
+	p.Generation = 2
+	p.SetPlugins([]PluginStatus{
+		{Name: "animal", Reason: ReasonAccepted},
+		{Name: "limitReq", Reason: ReasonInvalid, Message: "bad config"},
+		{Name: "animal", SectionName: "route", Reason: ReasonAccepted},
+	})
+	assert.True(t, p.Status.IsChanged())
+
+	p.Status.Reset()
+	p.SetOverridden(map[string][]string{
+		"animal":   {"default/a"},
+		"limitReq": {"default/a"},
+	})
+	assert.Equal(t, []PluginStatus{
+		{Name: "animal", Reason: ReasonOverridden, Message: "The plugin is overridden by default/a"},
+		{Name: "limitReq", Reason: ReasonInvalid, Message: "bad config"},
+		{Name: "animal", SectionName: "route", Reason: ReasonOverridden, Message: "The plugin is overridden by default/a"},
+	}, p.Status.Plugins)
+
+	p.RemoveOverridden()
+	assert.Equal(t, ReasonAccepted, p.Status.Plugins[0].Reason)
+	assert.Equal(t, "", p.Status.Plugins[0].Message)
+
+	p.Status.Reset()
+	p.SetAppliedGeneration()
+	assert.Equal(t, int64(2), p.Status.AppliedGeneration)
+	assert.True(t, p.Status.IsChanged())
+
+	p.Status.Reset()
+	p.SetAppliedGeneration()
+	assert.False(t, p.Status.IsChanged())
+}
@@ -17,9 +17,13 @@ limitations under the License.
 package v1
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
@@ -31,6 +35,7 @@ type FilterPolicySpec struct {
 	// TargetRef is the name of the resource this policy is being attached to.
 	// This Policy and the TargetRef MUST be in the same namespace.
 	// FilterPolicy in embedded mode can have no targetRef.
+	// When targeting HTTPRoute, the SectionName is the index of the rule, starting from 0.
 	//
 	// +optional
 	TargetRef *gwapiv1a2.PolicyTargetReferenceWithSectionName `json:"targetRef"`
@@ -46,6 +51,97 @@ type FilterPolicySpec struct {
 	// +listType=map
 	// +listMapKey=sectionName
 	SubPolicies []FilterSubPolicy `json:"subPolicies,omitempty"`
+
+	// MergeStrategy overrides the default merge strategy of the plugins in this policy,
+	// when they are merged with the same plugins from the less specific policies.
+	// The strategy can be `replace`, `deepMerge` or `append`.
+	//
+	// +kubebuilder:validation:Enum=replace;deepMerge;append
+	// +optional
+	MergeStrategy string `json:"mergeStrategy,omitempty"`
+
+	// Priority decides which policy wins when multiple policies configure the same plugin
+	// to the same target. The policy with higher priority wins. Policies with the same
+	// priority are ordered by the creation timestamp, then by the namespace and name.
+	// Policies targeting a more specific scope always win over the less specific ones,
+	// regardless of the priority.
+	//
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// DisabledFilters opts the target out of the filters configured by the FilterPolicies
+	// targeting the Gateway, so that the Gateway can have default filters for all the routes.
+	// It can't be used when targeting the Gateway.
+	//
+	// +listType=set
+	// +optional
+	DisabledFilters []string `json:"disabledFilters,omitempty"`
+
+	// Rollout pushes the new configuration of this policy to the canary gateway pods first.
+	// The configuration is promoted to all the pods or rolled back according to the analysis.
+	//
+	// +optional
+	Rollout *FilterPolicyRollout `json:"rollout,omitempty"`
+}
+
+// FilterPolicyRollout defines how to roll out the new configuration of the policy
+type FilterPolicyRollout struct {
+	// Selector selects the canary gateway pods by labels. The percentage of the pods which
+	// receive the new configuration is decided by how many pods carry the labels.
+	//
+	// +kubebuilder:validation:MinProperties=1
+	Selector map[string]string `json:"selector"`
+	// Analysis decides whether the new configuration is healthy.
+	Analysis RolloutAnalysis `json:"analysis"`
+}
+
+// RolloutAnalysis defines the analysis which runs during the rollout
+type RolloutAnalysis struct {
+	// Query is a PromQL expression which returns a non-empty result when the canary is unhealthy,
+	// like `sum(rate(istio_requests_total{response_code=~"5.."}[1m])) > 1`.
+	// It's run by the Prometheus server configured in the controller.
+	// Once it returns anything, the rollout is rolled back.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+	// Interval is the interval between two queries. Default to 1m.
+	//
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Duration is how long the analysis should pass before the new configuration is promoted.
+	// Default to 10m.
+	//
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// RolloutPhase is the phase of the rollout
+type RolloutPhase string
+
+const (
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	RolloutPhasePromoted    RolloutPhase = "Promoted"
+	RolloutPhaseRolledBack  RolloutPhase = "RolledBack"
+)
+
+// FilterPolicyRolloutStatus describes the state of the rollout
+type FilterPolicyRolloutStatus struct {
+	// Phase is one of Progressing, Promoted and RolledBack.
+	Phase RolloutPhase `json:"phase"`
+	// Generation is the generation of the policy which is rolled out.
+	Generation int64 `json:"generation"`
+	// StartTime is the time when the rollout of the generation starts.
+	StartTime metav1.Time `json:"startTime"`
+	// Message is a human readable message indicating details about the rollout.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Stable is the spec promoted last time, which is applied to the gateway pods not selected
+	// as the canary.
+	//
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Stable *runtime.RawExtension `json:"stable,omitempty"`
 }
 
 // FilterSubPolicy defines the sub-policy
@@ -68,9 +164,41 @@ type FilterPolicyStatus struct {
 	// +listMapKey=type
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// Plugins describe whether each plugin in the policy is accepted.
+	//
+	// +optional
+	// +listType=atomic
+	Plugins []PluginStatus `json:"plugins,omitempty"`
+
+	// AppliedGeneration is the generation of the policy which is applied to the data plane.
+	//
+	// +optional
+	AppliedGeneration int64 `json:"appliedGeneration,omitempty"`
+
+	// Rollout describes the state of the rollout if the policy has the rollout configured.
+	//
+	// +optional
+	Rollout *FilterPolicyRolloutStatus `json:"rollout,omitempty"`
+
 	ChangeDetector `json:",inline"`
 }
 
+// PluginStatus describes the status of a plugin in the policy
+type PluginStatus struct {
+	// Name is the name of the plugin.
+	Name string `json:"name"`
+	// SectionName is the section name of the sub-policy which contains the plugin.
+	//
+	// +optional
+	SectionName gwapiv1.SectionName `json:"sectionName,omitempty"`
+	// Reason is one of Accepted, Invalid and Overridden.
+	Reason ConditionReason `json:"reason"`
+	// Message is a human readable message indicating details about the status.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 //+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
@@ -125,13 +253,141 @@ func (p *FilterPolicy) SetAccepted(reason gwapiv1a2.PolicyConditionReason, msg .
 		} else {
 			c.Message = "The policy targets non-existent resource"
 		}
+	case gwapiv1a2.PolicyConditionReason(ReasonForbidden):
+		c.Status = metav1.ConditionFalse
+		if len(msg) > 0 {
+			c.Message = msg[0]
+		} else {
+			c.Message = "The policy uses forbidden plugins"
+		}
+	case gwapiv1a2.PolicyConditionReason(ReasonUnresolved):
+		c.Status = metav1.ConditionFalse
+		if len(msg) > 0 {
+			c.Message = msg[0]
+		} else {
+			c.Message = "The sensitive fields of the policy can't be resolved"
+		}
+	}
+	conds, changed := addOrUpdateCondition(p.Status.Conditions, c)
+	p.Status.Conditions = conds
+
+	// The target is only resolved after the policy is validated
+	resolved := c
+	resolved.Type = string(ConditionResolvedRefs)
+	var resolvedChanged bool
+	switch reason {
+	case gwapiv1a2.PolicyReasonAccepted:
+		resolved.Reason = string(ReasonResolvedRefs)
+		resolved.Message = "The target has been resolved"
+		p.Status.Conditions, resolvedChanged = addOrUpdateCondition(p.Status.Conditions, resolved)
+	case gwapiv1a2.PolicyReasonTargetNotFound:
+		p.Status.Conditions, resolvedChanged = addOrUpdateCondition(p.Status.Conditions, resolved)
+	default:
+		p.Status.Conditions, resolvedChanged = removeCondition(p.Status.Conditions, ConditionResolvedRefs)
+	}
+	changed = changed || resolvedChanged
+
+	if changed {
+		p.Status.MarkAsChanged()
+	}
+}
+
+// SetPlugins sets the status of each plugin in the policy
+func (p *FilterPolicy) SetPlugins(plugins []PluginStatus) {
+	if slices.Equal(p.Status.Plugins, plugins) {
+		return
+	}
+	p.Status.Plugins = plugins
+	p.Status.MarkAsChanged()
+}
+
+// SetAppliedGeneration records that the current generation of the policy is applied to the data plane
+func (p *FilterPolicy) SetAppliedGeneration() {
+	if p.Status.AppliedGeneration == p.Generation {
+		return
+	}
+	p.Status.AppliedGeneration = p.Generation
+	p.Status.MarkAsChanged()
+}
+
+// SetOverridden sets the condition which shows the plugins overridden by the other policies.
+// The overridden is a map from the plugin name to the policies whose configuration is used instead,
+// named as `$namespace/$name`. A plugin is overridden only if it doesn't take effect anywhere.
+func (p *FilterPolicy) SetOverridden(overridden map[string][]string) {
+	c := metav1.Condition{
+		Type:               string(ConditionOverridden),
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		ObservedGeneration: p.Generation,
+	}
+	if len(overridden) == 0 {
+		c.Status = metav1.ConditionFalse
+		c.Reason = string(ReasonNotOverridden)
+		c.Message = "No plugin is overridden"
+	} else {
+		names := make([]string, 0, len(overridden))
+		for name := range overridden {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		msgs := make([]string, len(names))
+		for i, name := range names {
+			msgs[i] = fmt.Sprintf("plugin %s is overridden by %s", name, strings.Join(overridden[name], ", "))
+		}
+		c.Status = metav1.ConditionTrue
+		c.Reason = string(ReasonOverridden)
+		c.Message = strings.Join(msgs, "; ")
 	}
+
 	conds, changed := addOrUpdateCondition(p.Status.Conditions, c)
 	p.Status.Conditions = conds
 
 	if changed {
 		p.Status.MarkAsChanged()
 	}
+	p.setPluginsOverridden(overridden)
+}
+
+// RemoveOverridden removes the condition set by SetOverridden, which is used when the policy is not accepted
+func (p *FilterPolicy) RemoveOverridden() {
+	conds, changed := removeCondition(p.Status.Conditions, ConditionOverridden)
+	p.Status.Conditions = conds
+
+	if changed {
+		p.Status.MarkAsChanged()
+	}
+	p.setPluginsOverridden(nil)
+}
+
+func (p *FilterPolicy) setPluginsOverridden(overridden map[string][]string) {
+	plugins := slices.Clone(p.Status.Plugins)
+	for i := range plugins {
+		ps := &plugins[i]
+		if ps.Reason == ReasonInvalid {
+			continue
+		}
+		if by, ok := overridden[ps.Name]; ok {
+			ps.Reason = ReasonOverridden
+			ps.Message = "The plugin is overridden by " + strings.Join(by, ", ")
+		} else {
+			ps.Reason = ReasonAccepted
+			ps.Message = ""
+		}
+	}
+	p.SetPlugins(plugins)
+}
+
+// IsAccepted returns whether the policy is accepted in the current generation
+func (p *FilterPolicy) IsAccepted() bool {
+	for _, cond := range p.Status.Conditions {
+		if cond.ObservedGeneration != p.Generation {
+			continue
+		}
+		if cond.Type == string(gwapiv1a2.PolicyConditionAccepted) {
+			return cond.Status == metav1.ConditionTrue
+		}
+	}
+	return false
 }
 
 func (p *FilterPolicy) IsValid() bool {
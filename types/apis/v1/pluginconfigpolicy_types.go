@@ -0,0 +1,167 @@
+/*
+Copyright The HTNN Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PluginConfigPolicySpec defines the desired state of PluginConfigPolicy
+type PluginConfigPolicySpec struct {
+	// Namespaces are the namespaces restricted by this policy.
+	// If it's not specified, all namespaces are restricted.
+	//
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Plugins are the plugins allowed to be used in the namespaces.
+	// The plugins not in the list are forbidden.
+	//
+	// +listType=map
+	// +listMapKey=name
+	Plugins []PluginAllowance `json:"plugins"`
+}
+
+// PluginAllowance defines a plugin which is allowed to use, and the bounds of its configuration
+type PluginAllowance struct {
+	// Name is the name of the plugin.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Fields restrict the value of the fields in the plugin configuration.
+	//
+	// +optional
+	Fields []FieldConstraint `json:"fields,omitempty"`
+}
+
+// FieldConstraint restricts the value of a field in the plugin configuration.
+// The constraint is not checked when the field is not configured.
+type FieldConstraint struct {
+	// Path is the path of the field in the plugin configuration, separated by `.`, like `rules.burst`.
+	// When the path goes through a list, every element of the list is checked.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// Minimum is the minimum value of the numeric field.
+	//
+	// +optional
+	Minimum *int64 `json:"minimum,omitempty"`
+
+	// Maximum is the maximum value of the numeric field.
+	//
+	// +optional
+	Maximum *int64 `json:"maximum,omitempty"`
+
+	// Values are the allowed values of the field. The numbers and booleans are compared in their string form.
+	//
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+// PluginConfigPolicyStatus defines the observed state of PluginConfigPolicy
+type PluginConfigPolicyStatus struct {
+	// Conditions describe the current conditions.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	ChangeDetector `json:",inline"`
+}
+
+//+genclient
+//+genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:subresource:status
+
+// PluginConfigPolicy is the Schema for the pluginconfigpolicies API.
+// It restricts the plugins and the plugin configuration which can be used in the namespaces.
+type PluginConfigPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PluginConfigPolicySpec   `json:"spec,omitempty"`
+	Status PluginConfigPolicyStatus `json:"status,omitempty"`
+}
+
+func (p *PluginConfigPolicy) IsSpecChanged() bool {
+	if len(p.Status.Conditions) == 0 {
+		// newly created
+		return true
+	}
+	for _, cond := range p.Status.Conditions {
+		if cond.ObservedGeneration != p.Generation {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *PluginConfigPolicy) SetAccepted(reason ConditionReason, msg ...string) {
+	conds, changed := addOrUpdateAcceptedCondition(p.Status.Conditions, p.Generation, reason, msg...)
+	p.Status.Conditions = conds
+
+	if changed {
+		p.Status.MarkAsChanged()
+	}
+}
+
+func (p *PluginConfigPolicy) IsValid() bool {
+	for _, cond := range p.Status.Conditions {
+		if cond.ObservedGeneration != p.Generation {
+			continue
+		}
+		if cond.Type == string(ConditionAccepted) && cond.Reason == string(ReasonInvalid) {
+			return false
+		}
+	}
+	return true
+}
+
+// Restricts returns whether the namespace is restricted by this policy
+func (p *PluginConfigPolicy) Restricts(namespace string) bool {
+	return len(p.Spec.Namespaces) == 0 || slices.Contains(p.Spec.Namespaces, namespace)
+}
+
+// Allowance returns the allowance of the plugin, or nil if the plugin is forbidden
+func (p *PluginConfigPolicy) Allowance(name string) *PluginAllowance {
+	for i := range p.Spec.Plugins {
+		if p.Spec.Plugins[i].Name == name {
+			return &p.Spec.Plugins[i]
+		}
+	}
+	return nil
+}
+
+//+kubebuilder:object:root=true
+
+// PluginConfigPolicyList contains a list of PluginConfigPolicy
+type PluginConfigPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PluginConfigPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PluginConfigPolicy{}, &PluginConfigPolicyList{})
+}
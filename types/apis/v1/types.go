@@ -16,9 +16,145 @@ limitations under the License.
 
 package v1
 
-import runtime "k8s.io/apimachinery/pkg/runtime"
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+
+	fmModel "mosn.io/htnn/api/pkg/filtermanager/model"
+	"mosn.io/htnn/api/pkg/headerops"
+)
 
 // Plugin defines the plugin configuration
 type Plugin struct {
 	Config runtime.RawExtension `json:"config"`
+	// Sampling makes the plugin only run for a percentage of requests.
+	// The sampling decision is made by the trace ID or the request ID, so it's consistent
+	// across the whole trace. Only Go plugins support sampling.
+	//
+	// +optional
+	Sampling *PluginSampling `json:"sampling,omitempty"`
+	// HeaderOps manipulates the headers before the plugin processes them.
+	// Only Go plugins support headerOps.
+	//
+	// +optional
+	HeaderOps *PluginHeaderOps `json:"headerOps,omitempty"`
+	// Match makes the plugin only run for the requests which match all the conditions.
+	// Only Go plugins support match.
+	//
+	// +optional
+	Match *PluginMatch `json:"match,omitempty"`
+}
+
+// PluginSampling defines the sampling configuration of the plugin
+type PluginSampling struct {
+	// Percentage is the percentage of requests that the plugin will run for.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage int32 `json:"percentage"`
+}
+
+// PluginHeaderOps defines the header operations applied to the request and the response
+type PluginHeaderOps struct {
+	// +optional
+	Request []PluginHeaderOp `json:"request,omitempty"`
+	// +optional
+	Response []PluginHeaderOp `json:"response,omitempty"`
+}
+
+// PluginHeaderOp defines a header operation
+type PluginHeaderOp struct {
+	// Action is the operation to the header.
+	//
+	// +kubebuilder:validation:Enum=add;set;remove;rename
+	Action string `json:"action"`
+	// Name is the name of the header.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Value is the header value used by add and set. It supports variables like `${header.x-tenant}`.
+	//
+	// +optional
+	Value string `json:"value,omitempty"`
+	// NewName is the new name of the header used by rename.
+	//
+	// +optional
+	NewName string `json:"newName,omitempty"`
+	// When is the list of conditions. The operation is applied only when all of them are met.
+	//
+	// +optional
+	When []PluginHeaderCondition `json:"when,omitempty"`
+}
+
+// PluginHeaderCondition matches the given header. If none of
+// Present, Equals and Regex is specified, it matches when the header is present.
+type PluginHeaderCondition struct {
+	// +kubebuilder:validation:MinLength=1
+	Header string `json:"header"`
+	// +optional
+	Present *bool `json:"present,omitempty"`
+	// +optional
+	Equals string `json:"equals,omitempty"`
+	// +optional
+	Regex string `json:"regex,omitempty"`
+}
+
+// PluginMatch defines the conditions of the requests that the plugin runs for
+type PluginMatch struct {
+	// Methods is the list of HTTP methods. The request matches when its method is one of them.
+	//
+	// +optional
+	Methods []string `json:"methods,omitempty"`
+	// Headers is the list of header conditions. The request matches when all of them are met.
+	//
+	// +optional
+	Headers []PluginHeaderCondition `json:"headers,omitempty"`
+}
+
+func convertPluginHeaderConditions(conds []PluginHeaderCondition) []*headerops.Condition {
+	if len(conds) == 0 {
+		return nil
+	}
+	res := make([]*headerops.Condition, len(conds))
+	for i, c := range conds {
+		res[i] = &headerops.Condition{
+			Header:  c.Header,
+			Present: c.Present,
+			Equals:  c.Equals,
+			Regex:   c.Regex,
+		}
+	}
+	return res
+}
+
+func convertPluginHeaderOps(ops []PluginHeaderOp) []*headerops.Op {
+	if len(ops) == 0 {
+		return nil
+	}
+	res := make([]*headerops.Op, len(ops))
+	for i, op := range ops {
+		res[i] = &headerops.Op{
+			Action:  op.Action,
+			Name:    op.Name,
+			Value:   op.Value,
+			NewName: op.NewName,
+			When:    convertPluginHeaderConditions(op.When),
+		}
+	}
+	return res
+}
+
+// ToConfig converts the PluginHeaderOps to the configuration used by the data plane
+func (h *PluginHeaderOps) ToConfig() *headerops.Config {
+	return &headerops.Config{
+		Request:  convertPluginHeaderOps(h.Request),
+		Response: convertPluginHeaderOps(h.Response),
+	}
+}
+
+// ToConfig converts the PluginMatch to the configuration used by the data plane
+func (m *PluginMatch) ToConfig() *fmModel.Match {
+	return &fmModel.Match{
+		Methods: m.Methods,
+		Headers: convertPluginHeaderConditions(m.Headers),
+	}
 }
@@ -15,8 +15,12 @@
 package v1
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 
 	istiov1a3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
@@ -25,6 +29,8 @@ import (
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"mosn.io/htnn/api/pkg/dynamicconfig"
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/headerops"
 	"mosn.io/htnn/api/pkg/plugins"
 	"mosn.io/htnn/types/pkg/proto"
 	"mosn.io/htnn/types/pkg/registry"
@@ -65,6 +71,10 @@ func ValidateEmbeddedFilterPolicy(policy *FilterPolicy, gk schema.GroupKind) err
 	if err := isEmbeddedPolicySupported(gk); err != nil {
 		return err
 	}
+	if policy.Spec.Rollout != nil {
+		// the embedded policy doesn't have status to record the rollout
+		return errors.New("rollout is not supported by embedded policy")
+	}
 
 	policy = policy.DeepCopy()
 	setTargetRef(policy, gk)
@@ -86,6 +96,10 @@ func ValidateEmbeddedFilterPolicyStrictly(policy *FilterPolicy, gk schema.GroupK
 	if err := isEmbeddedPolicySupported(gk); err != nil {
 		return err
 	}
+	if policy.Spec.Rollout != nil {
+		// the embedded policy doesn't have status to record the rollout
+		return errors.New("rollout is not supported by embedded policy")
+	}
 
 	policy = policy.DeepCopy()
 	setTargetRef(policy, gk)
@@ -121,15 +135,113 @@ func ValidateHTTPFilterPolicyStrictly(policy *HTTPFilterPolicy) error {
 	return ValidateFilterPolicyStrictly(&p)
 }
 
-func validateFilter(name string, filter Plugin, strict bool, targetGateway bool) error {
+// validationError is implemented by the errors generated by protoc-gen-validate
+type validationError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// fieldError reports the error with the path of the invalid field. When the error is generated by
+// protoc-gen-validate, the path is extended to the innermost field which fails the validation,
+// so that the users can locate the field without knowing the proto message names.
+func fieldError(path string, err error) error {
+	reason := err.Error()
+	for {
+		ve, ok := err.(validationError)
+		if !ok {
+			break
+		}
+
+		if field := ve.Field(); field != "" {
+			path += "." + strings.ToLower(field[:1]) + field[1:]
+		}
+		reason = ve.Reason()
+		cause := ve.Cause()
+		if cause == nil {
+			break
+		}
+		if _, ok := cause.(validationError); !ok {
+			reason += ": " + cause.Error()
+			break
+		}
+		err = cause
+	}
+	return fmt.Errorf("%s: %s", path, reason)
+}
+
+// validatePluginConfig runs the same unmarshal & validation as the data plane
+func validatePluginConfig(path string, data []byte, conf api.PluginConfig, strict bool) error {
+	var err error
+	if strict {
+		err = proto.UnmarshalJSONStrictly(data, conf)
+	} else {
+		err = proto.UnmarshalJSON(data, conf)
+	}
+	if err != nil {
+		return fmt.Errorf("%s.config: failed to unmarshal: %w", path, err)
+	}
+
+	if err := conf.Validate(); err != nil {
+		return fieldError(path+".config", err)
+	}
+	return nil
+}
+
+func validateFilter(path string, name string, filter Plugin, strict bool, targetGateway bool) error {
 	p := plugins.LoadPluginType(name)
 	if p == nil {
 		if strict {
-			return errors.New("unknown http filter: " + name)
+			if err := plugins.ValidatePluginName(name); err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+			if reason, ok := plugins.LoadDisabledPluginReason(name); ok {
+				return fmt.Errorf("%s: http filter %s is disabled: %s", path, name, reason)
+			}
+			return fmt.Errorf("%s: unknown http filter: %s", path, name)
 		}
 		return nil
 	}
 
+	if filter.Sampling != nil {
+		// The plugin type doesn't implement NativePlugin, so we check the order instead
+		switch p.Order().Position {
+		case plugins.OrderPositionOuter, plugins.OrderPositionInner,
+			plugins.OrderPositionListener, plugins.OrderPositionNetwork:
+			return fmt.Errorf("%s.sampling: sampling is not supported by native plugin %s", path, name)
+		}
+		if filter.Sampling.Percentage < 0 || filter.Sampling.Percentage > 100 {
+			return fmt.Errorf("%s.sampling.percentage: should be in [0, 100]", path)
+		}
+	}
+
+	if filter.HeaderOps != nil {
+		switch p.Order().Position {
+		case plugins.OrderPositionOuter, plugins.OrderPositionInner,
+			plugins.OrderPositionListener, plugins.OrderPositionNetwork:
+			return fmt.Errorf("%s.headerOps: headerOps is not supported by native plugin %s", path, name)
+		}
+		if _, err := headerops.Compile(filter.HeaderOps.ToConfig()); err != nil {
+			return fmt.Errorf("%s.headerOps: %w", path, err)
+		}
+	}
+
+	if filter.Match != nil {
+		switch p.Order().Position {
+		case plugins.OrderPositionOuter, plugins.OrderPositionInner,
+			plugins.OrderPositionListener, plugins.OrderPositionNetwork:
+			return fmt.Errorf("%s.match: match is not supported by native plugin %s", path, name)
+		}
+		for i, method := range filter.Match.Methods {
+			if method == "" || strings.ToUpper(method) != method {
+				return fmt.Errorf("%s.match.methods[%d]: method should be non-empty and uppercase", path, i)
+			}
+		}
+		if _, err := headerops.CompileConditions(filter.Match.ToConfig().Headers); err != nil {
+			return fmt.Errorf("%s.match.headers: %w", path, err)
+		}
+	}
+
 	if targetGateway {
 		switch p.Order().Position {
 		case plugins.OrderPositionOuter, plugins.OrderPositionInner:
@@ -137,29 +249,30 @@ func validateFilter(name string, filter Plugin, strict bool, targetGateway bool)
 			// be more than 20 native plugins in the future, and it's not reasonable to provide
 			// such number (20 x the number of LDS) of ECDS resources. Perhaps we can use
 			// composite filter to solve this problem?
-			return errors.New("configure native plugins to the Gateway is not implemented")
+			return fmt.Errorf("%s: configure native plugins to the Gateway is not implemented", path)
 		}
 	} else {
 		switch p.Order().Position {
 		case plugins.OrderPositionListener, plugins.OrderPositionNetwork:
-			return errors.New("configure layer 4 plugins to route is invalid")
+			return fmt.Errorf("%s: configure layer 4 plugins to route is invalid", path)
 		}
 	}
 
-	data := filter.Config.Raw
-	conf := p.Config()
-	var err error
-	if strict {
-		err = proto.UnmarshalJSONStrictly(data, conf)
-	} else {
-		err = proto.UnmarshalJSON(data, conf)
+	return validatePluginConfig(path, filter.Config.Raw, p.Config(), strict)
+}
+
+func validateRollout(rollout *FilterPolicyRollout) error {
+	if len(rollout.Selector) == 0 {
+		return errors.New("spec.rollout.selector: should not be empty")
 	}
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal for filter %s: %w", name, err)
+	if rollout.Analysis.Query == "" {
+		return errors.New("spec.rollout.analysis.query: should not be empty")
 	}
-
-	if err := conf.Validate(); err != nil {
-		return fmt.Errorf("invalid config for filter %s: %w", name, err)
+	if d := rollout.Analysis.Interval; d != nil && d.Duration <= 0 {
+		return errors.New("spec.rollout.analysis.interval: should be positive")
+	}
+	if d := rollout.Analysis.Duration; d != nil && d.Duration <= 0 {
+		return errors.New("spec.rollout.analysis.duration: should be positive")
 	}
 	return nil
 }
@@ -184,7 +297,13 @@ func validateFilterPolicy(policy *FilterPolicy, strict bool) error {
 		}
 
 		if ref.Kind == "HTTPRoute" {
-			return errors.New("targetRef.SectionName is not supported for HTTPRoute")
+			if _, err := HTTPRouteRuleIndex(string(*ref.SectionName)); err != nil {
+				return fmt.Errorf("targetRef.SectionName: %w", err)
+			}
+		}
+
+		if ref.Kind == "ServiceEntry" || ref.Kind == "DestinationRule" {
+			return fmt.Errorf("targetRef.SectionName is not supported for %s", ref.Kind)
 		}
 	}
 
@@ -202,6 +321,9 @@ func validateFilterPolicy(policy *FilterPolicy, strict bool) error {
 			//
 			// TODO: implement the Gateway support via RDS, so it matches the model 100%.
 			validTarget = true
+		case "ServiceEntry", "DestinationRule":
+			// The policy targeting an upstream applies to all the VirtualService routes going to the upstream.
+			validTarget = true
 		}
 	} else if ref.Group == "gateway.networking.k8s.io" {
 		switch ref.Kind {
@@ -215,32 +337,107 @@ func validateFilterPolicy(policy *FilterPolicy, strict bool) error {
 
 	targetGateway = ref.Kind == "Gateway"
 
+	if policy.Spec.MergeStrategy != "" && !plugins.IsValidMergeStrategy(plugins.MergeStrategy(policy.Spec.MergeStrategy)) {
+		return fmt.Errorf("unknown merge strategy %s", policy.Spec.MergeStrategy)
+	}
+
 	if len(policy.Spec.SubPolicies) > 0 {
-		if ref.Kind != "VirtualService" {
+		switch ref.Kind {
+		case "VirtualService":
+		case "HTTPRoute":
+			for _, subPolicy := range policy.Spec.SubPolicies {
+				if _, err := HTTPRouteRuleIndex(string(subPolicy.SectionName)); err != nil {
+					return fmt.Errorf("subPolicies: %w", err)
+				}
+			}
+		default:
 			return errors.New("subPolicies can not be used with this referred target")
 		}
 	}
 
+	if len(policy.Spec.DisabledFilters) > 0 {
+		if targetGateway {
+			return errors.New("disabledFilters can not be used when targeting the Gateway")
+		}
+		for _, name := range policy.Spec.DisabledFilters {
+			if _, ok := policy.Spec.Filters[name]; ok {
+				return fmt.Errorf("spec.disabledFilters: filter %s is both configured and disabled", name)
+			}
+		}
+	}
+
+	if policy.Spec.Rollout != nil {
+		if targetGateway {
+			return errors.New("rollout can not be used when targeting the Gateway")
+		}
+		if err := validateRollout(policy.Spec.Rollout); err != nil {
+			return err
+		}
+	}
+
 	for name, filter := range policy.Spec.Filters {
-		err := validateFilter(name, filter, strict, targetGateway)
+		err := validateFilter("spec.filters."+name, name, filter, strict, targetGateway)
 		if err != nil {
 			return err
 		}
 	}
 
-	for _, policy := range policy.Spec.SubPolicies {
+	for i, policy := range policy.Spec.SubPolicies {
 		for name, filter := range policy.Filters {
-			err := validateFilter(name, filter, strict, targetGateway)
+			path := fmt.Sprintf("spec.subPolicies[%d].filters.%s", i, name)
+			err := validateFilter(path, name, filter, strict, targetGateway)
 			if err != nil {
 				return err
 			}
-
 		}
 	}
 
 	return nil
 }
 
+// ValidateFilterPolicyPlugins validates each plugin in the FilterPolicy separately, so that the users
+// can know which plugin is invalid. Like ValidateFilterPolicy, unknown plugins are skipped.
+func ValidateFilterPolicyPlugins(policy *FilterPolicy) []PluginStatus {
+	targetGateway := policy.Spec.TargetRef != nil && policy.Spec.TargetRef.Kind == "Gateway"
+	var statuses []PluginStatus
+	check := func(path string, sectionName gwapiv1.SectionName, filters map[string]Plugin) {
+		names := make([]string, 0, len(filters))
+		for name := range filters {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			status := PluginStatus{
+				Name:        name,
+				SectionName: sectionName,
+				Reason:      ReasonAccepted,
+			}
+			if err := validateFilter(path+name, name, filters[name], false, targetGateway); err != nil {
+				status.Reason = ReasonInvalid
+				status.Message = err.Error()
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	check("spec.filters.", "", policy.Spec.Filters)
+	for i, subPolicy := range policy.Spec.SubPolicies {
+		check(fmt.Sprintf("spec.subPolicies[%d].filters.", i), subPolicy.SectionName, subPolicy.Filters)
+	}
+	return statuses
+}
+
+// HTTPRouteRuleIndex parses the section name which refers to a rule of HTTPRoute. As the rules
+// in HTTPRoute don't have names, the section name is the index of the rule, starting from 0.
+func HTTPRouteRuleIndex(sectionName string) (int, error) {
+	idx, err := strconv.Atoi(sectionName)
+	if err != nil || idx < 0 || strconv.Itoa(idx) != sectionName {
+		return 0, fmt.Errorf("section name %q of HTTPRoute should be the index of the rule", sectionName)
+	}
+	return idx, nil
+}
+
 func ValidateVirtualService(vs *istiov1a3.VirtualService) error {
 	if len(vs.Spec.Http) == 0 {
 		return errors.New("only http route is supported")
@@ -285,50 +482,50 @@ func NormalizeK8sGatewayProtocol(protocol gwapiv1.ProtocolType) string {
 
 func ValidateConsumer(c *Consumer) error {
 	if len(c.Spec.Auth) == 0 {
-		return errors.New("authn filter is required")
+		return errors.New("spec.auth: authn filter is required")
 	}
 
 	for name, filter := range c.Spec.Auth {
+		path := "spec.auth." + name
 		plugin := plugins.LoadPluginType(name)
 		if plugin == nil {
 			// reject unknown filter in CP, ignore unknown filter in DP
-			return errors.New("unknown authn filter: " + name)
+			return fmt.Errorf("%s: unknown authn filter: %s", path, name)
 		}
 		p, ok := plugin.(plugins.ConsumerPlugin)
 		if !ok {
-			return errors.New("configured authn filter is not a consumer plugin: " + name)
+			return fmt.Errorf("%s: configured authn filter is not a consumer plugin: %s", path, name)
 		}
 
-		data := filter.Config.Raw
-		conf := p.ConsumerConfig()
-		if err := proto.UnmarshalJSON(data, conf); err != nil {
-			return fmt.Errorf("failed to unmarshal for filter %s: %w", name, err)
-		}
-
-		if err := conf.Validate(); err != nil {
-			return fmt.Errorf("invalid config for filter %s: %w", name, err)
+		if err := validatePluginConfig(path, filter.Config.Raw, p.ConsumerConfig(), false); err != nil {
+			return err
 		}
 	}
 
 	for name, filter := range c.Spec.Filters {
+		path := "spec.filters." + name
 		p := plugins.LoadPluginType(name)
 		if p == nil {
-			return errors.New("unknown http filter: " + name)
+			return fmt.Errorf("%s: unknown http filter: %s", path, name)
 		}
 
 		pos := p.Order().Position
 		if pos <= plugins.OrderPositionAuthn || pos >= plugins.OrderPositionInner {
-			return errors.New("this http filter can not be added by the consumer: " + name)
+			return fmt.Errorf("%s: this http filter can not be added by the consumer: %s", path, name)
 		}
 
-		data := filter.Config.Raw
-		conf := p.Config()
-		if err := proto.UnmarshalJSON(data, conf); err != nil {
-			return fmt.Errorf("failed to unmarshal for filter %s: %w", name, err)
+		if filter.Sampling != nil {
+			return fmt.Errorf("%s.sampling: sampling is not supported in the consumer: %s", path, name)
+		}
+		if filter.HeaderOps != nil {
+			return fmt.Errorf("%s.headerOps: headerOps is not supported in the consumer: %s", path, name)
+		}
+		if filter.Match != nil {
+			return fmt.Errorf("%s.match: match is not supported in the consumer: %s", path, name)
 		}
 
-		if err := conf.Validate(); err != nil {
-			return fmt.Errorf("invalid config for filter %s: %w", name, err)
+		if err := validatePluginConfig(path, filter.Config.Raw, p.Config(), false); err != nil {
+			return err
 		}
 	}
 
@@ -338,11 +535,14 @@ func ValidateConsumer(c *Consumer) error {
 func ValidateServiceRegistry(sr *ServiceRegistry) error {
 	reg := registry.GetRegistryType(sr.Spec.Type)
 	if reg == nil {
-		return fmt.Errorf("unknown registry type: %s", sr.Spec.Type)
+		return fmt.Errorf("spec.type: unknown registry type: %s", sr.Spec.Type)
 	}
 
 	_, err := registry.ParseConfig(reg, sr.Spec.Config.Raw)
-	return err
+	if err != nil {
+		return fieldError("spec.config", err)
+	}
+	return nil
 }
 
 func ValidateDynamicConfig(c *DynamicConfig) error {
@@ -363,3 +563,238 @@ func ValidateDynamicConfig(c *DynamicConfig) error {
 	}
 	return nil
 }
+
+func ValidatePluginConfigPolicy(p *PluginConfigPolicy) error {
+	for i, ns := range p.Spec.Namespaces {
+		if ns == "" {
+			return fmt.Errorf("spec.namespaces[%d]: namespace is empty", i)
+		}
+	}
+
+	seen := make(map[string]struct{}, len(p.Spec.Plugins))
+	for i, allowance := range p.Spec.Plugins {
+		path := fmt.Sprintf("spec.plugins[%d]", i)
+		name := allowance.Name
+		if plugins.LoadPluginType(name) == nil {
+			return fmt.Errorf("%s.name: unknown http filter: %s", path, name)
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("%s.name: duplicate plugin %s", path, name)
+		}
+		seen[name] = struct{}{}
+
+		for j, field := range allowance.Fields {
+			fieldPath := fmt.Sprintf("%s.fields[%d]", path, j)
+			if field.Path == "" || slices.Contains(strings.Split(field.Path, "."), "") {
+				return fmt.Errorf("%s.path: invalid path %q", fieldPath, field.Path)
+			}
+			if field.Minimum == nil && field.Maximum == nil && len(field.Values) == 0 {
+				return fmt.Errorf("%s: one of minimum, maximum and values is required", fieldPath)
+			}
+			if field.Minimum != nil && field.Maximum != nil && *field.Minimum > *field.Maximum {
+				return fmt.Errorf("%s: minimum should not be greater than maximum", fieldPath)
+			}
+		}
+	}
+	return nil
+}
+
+// restrictedBy returns the valid PluginConfigPolicies which restrict the namespace
+func restrictedBy(namespace string, policies []*PluginConfigPolicy) []*PluginConfigPolicy {
+	var res []*PluginConfigPolicy
+	for _, p := range policies {
+		if p.IsValid() && p.Restricts(namespace) {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// ValidateFilterPolicyAllowance checks if the plugins in the FilterPolicy are allowed by all the
+// PluginConfigPolicies which restrict the namespace of the FilterPolicy.
+func ValidateFilterPolicyAllowance(policy *FilterPolicy, policies []*PluginConfigPolicy) error {
+	restrictions := restrictedBy(policy.Namespace, policies)
+	if len(restrictions) == 0 {
+		return nil
+	}
+
+	var msgs []string
+	check := func(path string, filters map[string]Plugin) {
+		names := make([]string, 0, len(filters))
+		for name := range filters {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			msgs = append(msgs, checkPluginAllowance(path+name, name, filters[name].Config.Raw, restrictions)...)
+		}
+	}
+
+	check("spec.filters.", policy.Spec.Filters)
+	for i, subPolicy := range policy.Spec.SubPolicies {
+		check(fmt.Sprintf("spec.subPolicies[%d].filters.", i), subPolicy.Filters)
+	}
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// ValidateConsumerAllowance checks if the plugins in the Consumer are allowed by all the
+// PluginConfigPolicies which restrict the namespace of the Consumer.
+func ValidateConsumerAllowance(c *Consumer, policies []*PluginConfigPolicy) error {
+	restrictions := restrictedBy(c.Namespace, policies)
+	if len(restrictions) == 0 {
+		return nil
+	}
+
+	var msgs []string
+	names := make([]string, 0, len(c.Spec.Auth))
+	for name := range c.Spec.Auth {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		msgs = append(msgs, checkPluginAllowance("spec.auth."+name, name, c.Spec.Auth[name].Config.Raw, restrictions)...)
+	}
+
+	names = names[:0]
+	for name := range c.Spec.Filters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		msgs = append(msgs, checkPluginAllowance("spec.filters."+name, name, c.Spec.Filters[name].Config.Raw, restrictions)...)
+	}
+
+	if len(msgs) > 0 {
+		return errors.New(strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+func checkPluginAllowance(path string, name string, raw []byte, restrictions []*PluginConfigPolicy) []string {
+	var msgs []string
+	var conf any
+	parsed := false
+	for _, p := range restrictions {
+		allowance := p.Allowance(name)
+		if allowance == nil {
+			msgs = append(msgs, fmt.Sprintf("%s: plugin %s is not allowed by PluginConfigPolicy %s", path, name, p.Name))
+			continue
+		}
+
+		for _, field := range allowance.Fields {
+			if !parsed {
+				parsed = true
+				if len(raw) > 0 {
+					decoder := json.NewDecoder(bytes.NewReader(raw))
+					// keep the precision of the numbers
+					decoder.UseNumber()
+					if err := decoder.Decode(&conf); err != nil {
+						// the configuration is validated separately
+						return append(msgs, fmt.Sprintf("%s.config: %s", path, err))
+					}
+				}
+			}
+
+			if err := checkFieldConstraint(conf, strings.Split(field.Path, "."), &field); err != nil {
+				msgs = append(msgs, fmt.Sprintf("%s.config.%s: %s, which is restricted by PluginConfigPolicy %s",
+					path, field.Path, err, p.Name))
+			}
+		}
+	}
+	return msgs
+}
+
+// lookupField finds the field by name. As the plugin configuration is decoded as protobuf JSON,
+// both the lowerCamelCase and the snake_case names are accepted.
+func lookupField(m map[string]any, name string) (any, bool) {
+	if v, ok := m[name]; ok {
+		return v, true
+	}
+
+	var camel, snake strings.Builder
+	upper := false
+	for _, c := range name {
+		switch {
+		case c == '_':
+			snake.WriteByte('_')
+			upper = true
+			continue
+		case c >= 'A' && c <= 'Z':
+			snake.WriteByte('_')
+			snake.WriteRune(c - 'A' + 'a')
+		default:
+			snake.WriteRune(c)
+		}
+		if upper && c >= 'a' && c <= 'z' {
+			camel.WriteRune(c - 'a' + 'A')
+		} else {
+			camel.WriteRune(c)
+		}
+		upper = false
+	}
+	for _, alt := range []string{camel.String(), snake.String()} {
+		if v, ok := m[alt]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func checkFieldConstraint(v any, path []string, c *FieldConstraint) error {
+	switch val := v.(type) {
+	case []any:
+		for _, elem := range val {
+			if err := checkFieldConstraint(elem, path, c); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]any:
+		if len(path) == 0 {
+			return errors.New("should be a number, string or boolean")
+		}
+		child, ok := lookupField(val, path[0])
+		if !ok {
+			return nil
+		}
+		return checkFieldConstraint(child, path[1:], c)
+	case nil:
+		return nil
+	}
+
+	if len(path) > 0 {
+		// the field doesn't exist
+		return nil
+	}
+
+	var s string
+	switch val := v.(type) {
+	case json.Number:
+		s = val.String()
+		f, err := val.Float64()
+		if err != nil {
+			return err
+		}
+		if c.Minimum != nil && f < float64(*c.Minimum) {
+			return fmt.Errorf("value %s is less than the minimum %d", s, *c.Minimum)
+		}
+		if c.Maximum != nil && f > float64(*c.Maximum) {
+			return fmt.Errorf("value %s is greater than the maximum %d", s, *c.Maximum)
+		}
+	case string:
+		s = val
+	case bool:
+		s = strconv.FormatBool(val)
+	}
+	if _, ok := v.(json.Number); !ok && (c.Minimum != nil || c.Maximum != nil) {
+		return errors.New("should be a number")
+	}
+	if len(c.Values) > 0 && !slices.Contains(c.Values, s) {
+		return fmt.Errorf("value %s is not one of %s", s, strings.Join(c.Values, ", "))
+	}
+	return nil
+}
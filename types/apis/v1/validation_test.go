@@ -16,6 +16,7 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	istioapi "istio.io/api/networking/v1alpha3"
@@ -34,8 +35,11 @@ import (
 func TestValidateFilterPolicy(t *testing.T) {
 	plugins.RegisterPluginType("animal", &plugins.MockPlugin{})
 	plugins.RegisterPluginType("networkNative", &plugins.MockNetworkNativePlugin{})
+	plugins.RegisterPluginType("disabled", &plugins.MockPlugin{})
+	plugins.DisablePluginWithReason("disabled", "experimental plugin is disabled by configuration")
 	namespace := gwapiv1.Namespace("ns")
 	sectionName := gwapiv1.SectionName("test")
+	ruleIndex := gwapiv1.SectionName("1")
 
 	tests := []struct {
 		name      string
@@ -162,7 +166,7 @@ func TestValidateFilterPolicy(t *testing.T) {
 			},
 		},
 		{
-			name: "unsupported, HTTPRoute with sectionName",
+			name: "HTTPRoute with sectionName",
 			policy: &FilterPolicy{
 				Spec: FilterPolicySpec{
 					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
@@ -170,6 +174,91 @@ func TestValidateFilterPolicy(t *testing.T) {
 							Group: "gateway.networking.k8s.io",
 							Kind:  "HTTPRoute",
 						},
+						SectionName: &ruleIndex,
+					},
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "HTTPRoute with sectionName which is not a rule index",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "gateway.networking.k8s.io",
+							Kind:  "HTTPRoute",
+						},
+						SectionName: &sectionName,
+					},
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+						},
+					},
+				},
+			},
+			err: `targetRef.SectionName: section name "test" of HTTPRoute should be the index of the rule`,
+		},
+		{
+			name: "HTTPRoute with subPolicies",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "gateway.networking.k8s.io",
+							Kind:  "HTTPRoute",
+						},
+					},
+					SubPolicies: []FilterSubPolicy{
+						{
+							SectionName: ruleIndex,
+						},
+						{
+							SectionName: "01",
+						},
+					},
+				},
+			},
+			err: `subPolicies: section name "01" of HTTPRoute should be the index of the rule`,
+		},
+		{
+			name: "ServiceEntry",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "ServiceEntry",
+						},
+					},
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "DestinationRule with sectionName",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "DestinationRule",
+						},
 						SectionName: &sectionName,
 					},
 					Filters: map[string]Plugin{
@@ -181,7 +270,7 @@ func TestValidateFilterPolicy(t *testing.T) {
 					},
 				},
 			},
-			err: "targetRef.SectionName is not supported for HTTPRoute",
+			err: "targetRef.SectionName is not supported for DestinationRule",
 		},
 		{
 			name: "unknown fields, HTTPRoute",
@@ -196,16 +285,454 @@ func TestValidateFilterPolicy(t *testing.T) {
 					Filters: map[string]Plugin{
 						"animal": {
 							Config: runtime.RawExtension{
-								Raw: []byte(`{"pet":"cat", "unknown_fields":"should be ignored"}`),
+								Raw: []byte(`{"pet":"cat", "unknown_fields":"should be ignored"}`),
+							},
+						},
+					},
+				},
+			},
+			strictErr: "unknown field \"unknown_fields\"",
+		},
+		{
+			name: "unknown",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Filters: map[string]Plugin{
+						"property": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+						},
+					},
+				},
+			},
+			strictErr: "unknown http filter: property",
+		},
+		{
+			name: "invalid plugin name",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Filters: map[string]Plugin{
+						"vendor.pet.property": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+						},
+					},
+				},
+			},
+			strictErr: "invalid plugin name \"vendor.pet.property\"",
+		},
+		{
+			name: "unknown merge strategy",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+						},
+					},
+					MergeStrategy: "merge",
+				},
+			},
+			err: "unknown merge strategy merge",
+		},
+		{
+			name: "ok, disabled filters",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "gateway.networking.k8s.io",
+							Kind:  "HTTPRoute",
+						},
+					},
+					DisabledFilters: []string{"animal"},
+				},
+			},
+		},
+		{
+			name: "ok, rollout",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Rollout: &FilterPolicyRollout{
+						Selector: map[string]string{"canary": "true"},
+						Analysis: RolloutAnalysis{
+							Query:    "up == 0",
+							Interval: &metav1.Duration{Duration: time.Minute},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "rollout targeting Gateway",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "Gateway",
+						},
+					},
+					Rollout: &FilterPolicyRollout{
+						Selector: map[string]string{"canary": "true"},
+						Analysis: RolloutAnalysis{
+							Query: "up == 0",
+						},
+					},
+				},
+			},
+			err: "rollout can not be used when targeting the Gateway",
+		},
+		{
+			name: "rollout with bad interval",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Rollout: &FilterPolicyRollout{
+						Selector: map[string]string{"canary": "true"},
+						Analysis: RolloutAnalysis{
+							Query:    "up == 0",
+							Interval: &metav1.Duration{},
+						},
+					},
+				},
+			},
+			err: "spec.rollout.analysis.interval: should be positive",
+		},
+		{
+			name: "disabled filters targeting Gateway",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "gateway.networking.k8s.io",
+							Kind:  "Gateway",
+						},
+					},
+					DisabledFilters: []string{"animal"},
+				},
+			},
+			err: "disabledFilters can not be used when targeting the Gateway",
+		},
+		{
+			name: "filter both configured and disabled",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+						},
+					},
+					DisabledFilters: []string{"animal"},
+				},
+			},
+			err: "spec.disabledFilters: filter animal is both configured and disabled",
+		},
+		{
+			name: "disabled plugin",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Filters: map[string]Plugin{
+						"disabled": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+						},
+					},
+				},
+			},
+			strictErr: "http filter disabled is disabled: experimental plugin is disabled by configuration",
+		},
+		{
+			name: "cross namespace",
+			policy: &FilterPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "namespace",
+				},
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Namespace: &namespace,
+							Group:     "networking.istio.io",
+							Kind:      "VirtualService",
+						},
+					},
+				},
+			},
+			err: "namespace in TargetRef doesn't match FilterPolicy's namespace",
+		},
+		{
+			name: "Filters in SubPolicies",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					SubPolicies: []FilterSubPolicy{
+						{
+							SectionName: sectionName,
+							Filters: map[string]Plugin{
+								"property": {
+									Config: runtime.RawExtension{
+										Raw: []byte(`{"pet":"cat"}`),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			strictErr: "spec.subPolicies[0].filters.property: unknown http filter: property",
+		},
+		{
+			name: "bad nested configuration in SubPolicies",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					SubPolicies: []FilterSubPolicy{
+						{
+							SectionName: sectionName,
+							Filters: map[string]Plugin{
+								"accessLog": {
+									Config: runtime.RawExtension{
+										Raw: []byte(`{"sinks":[{"stdout":{}},{"syslog":{"address":""}}]}`),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			err: "spec.subPolicies[0].filters.accessLog.config.sinks[1].syslog.address: value length must be at least 1 runes",
+		},
+		{
+			name: "targetRef.SectionName and SubPolicies can not be used together",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+						SectionName: &sectionName,
+					},
+					SubPolicies: []FilterSubPolicy{
+						{
+							SectionName: sectionName,
+						},
+					},
+				},
+			},
+			err: "targetRef.SectionName and SubPolicies can not be used together",
+		},
+		{
+			name: "targetRef to Gateway and also use SubPolicies",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "Gateway",
+						},
+					},
+					SubPolicies: []FilterSubPolicy{
+						{
+							SectionName: sectionName,
+						},
+					},
+				},
+			},
+			err: "subPolicies can not be used with this referred target",
+		},
+		{
+			name: "ok, sampling",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+							Sampling: &PluginSampling{
+								Percentage: 10,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid sampling percentage",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+							Sampling: &PluginSampling{
+								Percentage: 101,
+							},
+						},
+					},
+				},
+			},
+			err: "spec.filters.animal.sampling.percentage: should be in [0, 100]",
+		},
+		{
+			name: "sampling with native plugin",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Filters: map[string]Plugin{
+						"localRatelimit": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"statPrefix":"local"}`),
+							},
+							Sampling: &PluginSampling{
+								Percentage: 10,
+							},
+						},
+					},
+				},
+			},
+			err: "sampling is not supported by native plugin localRatelimit",
+		},
+		{
+			name: "ok, headerOps",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+							HeaderOps: &PluginHeaderOps{
+								Request: []PluginHeaderOp{
+									{
+										Action: "set",
+										Name:   "x-tenant",
+										Value:  "${consumer.name}",
+										When: []PluginHeaderCondition{
+											{Header: "x-tenant", Regex: "^a"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid headerOps",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+							HeaderOps: &PluginHeaderOps{
+								Response: []PluginHeaderOp{
+									{
+										Action: "rename",
+										Name:   "x-tenant",
+									},
+								},
 							},
 						},
 					},
 				},
 			},
-			strictErr: "unknown field \"unknown_fields\"",
+			err: "spec.filters.animal.headerOps: response: new name is required to rename header x-tenant",
 		},
 		{
-			name: "unknown",
+			name: "headerOps with native plugin",
 			policy: &FilterPolicy{
 				Spec: FilterPolicySpec{
 					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
@@ -215,36 +742,45 @@ func TestValidateFilterPolicy(t *testing.T) {
 						},
 					},
 					Filters: map[string]Plugin{
-						"property": {
+						"localRatelimit": {
 							Config: runtime.RawExtension{
-								Raw: []byte(`{"pet":"cat"}`),
+								Raw: []byte(`{"statPrefix":"local"}`),
 							},
+							HeaderOps: &PluginHeaderOps{},
 						},
 					},
 				},
 			},
-			strictErr: "unknown http filter: property",
+			err: "headerOps is not supported by native plugin localRatelimit",
 		},
 		{
-			name: "cross namespace",
+			name: "match",
 			policy: &FilterPolicy{
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: "namespace",
-				},
 				Spec: FilterPolicySpec{
 					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
 						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
-							Namespace: &namespace,
-							Group:     "networking.istio.io",
-							Kind:      "VirtualService",
+							Group: "networking.istio.io",
+							Kind:  "VirtualService",
+						},
+					},
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+							Match: &PluginMatch{
+								Methods: []string{"GET", "POST"},
+								Headers: []PluginHeaderCondition{
+									{Header: "x-tenant", Regex: "^a"},
+								},
+							},
 						},
 					},
 				},
 			},
-			err: "namespace in TargetRef doesn't match FilterPolicy's namespace",
 		},
 		{
-			name: "Filters in SubPolicies",
+			name: "invalid match method",
 			policy: &FilterPolicy{
 				Spec: FilterPolicySpec{
 					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
@@ -253,24 +789,22 @@ func TestValidateFilterPolicy(t *testing.T) {
 							Kind:  "VirtualService",
 						},
 					},
-					SubPolicies: []FilterSubPolicy{
-						{
-							SectionName: sectionName,
-							Filters: map[string]Plugin{
-								"property": {
-									Config: runtime.RawExtension{
-										Raw: []byte(`{"pet":"cat"}`),
-									},
-								},
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+							Match: &PluginMatch{
+								Methods: []string{"get"},
 							},
 						},
 					},
 				},
 			},
-			strictErr: "unknown http filter: property",
+			err: "spec.filters.animal.match.methods[0]: method should be non-empty and uppercase",
 		},
 		{
-			name: "targetRef.SectionName and SubPolicies can not be used together",
+			name: "invalid match headers",
 			policy: &FilterPolicy{
 				Spec: FilterPolicySpec{
 					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
@@ -278,35 +812,44 @@ func TestValidateFilterPolicy(t *testing.T) {
 							Group: "networking.istio.io",
 							Kind:  "VirtualService",
 						},
-						SectionName: &sectionName,
 					},
-					SubPolicies: []FilterSubPolicy{
-						{
-							SectionName: sectionName,
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+							Match: &PluginMatch{
+								Headers: []PluginHeaderCondition{
+									{Header: "x-tenant", Regex: "("},
+								},
+							},
 						},
 					},
 				},
 			},
-			err: "targetRef.SectionName and SubPolicies can not be used together",
+			err: "spec.filters.animal.match.headers: invalid regex in the condition: error parsing regexp: missing closing ): `(`",
 		},
 		{
-			name: "targetRef to Gateway and also use SubPolicies",
+			name: "match with native plugin",
 			policy: &FilterPolicy{
 				Spec: FilterPolicySpec{
 					TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
 						PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
 							Group: "networking.istio.io",
-							Kind:  "Gateway",
+							Kind:  "VirtualService",
 						},
 					},
-					SubPolicies: []FilterSubPolicy{
-						{
-							SectionName: sectionName,
+					Filters: map[string]Plugin{
+						"localRatelimit": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"statPrefix":"local"}`),
+							},
+							Match: &PluginMatch{},
 						},
 					},
 				},
 			},
-			err: "subPolicies can not be used with this referred target",
+			err: "match is not supported by native plugin localRatelimit",
 		},
 		{
 			name: "bad configuration",
@@ -327,7 +870,7 @@ func TestValidateFilterPolicy(t *testing.T) {
 					},
 				},
 			},
-			err: "invalid LocalRateLimit.StatPrefix: value length must be at least 1 runes",
+			err: "spec.filters.localRatelimit.config.statPrefix: value length must be at least 1 runes",
 		},
 		{
 			name: "ok, Istio Gateway",
@@ -456,6 +999,52 @@ func TestValidateFilterPolicy(t *testing.T) {
 	}
 }
 
+func TestValidateFilterPolicyPlugins(t *testing.T) {
+	policy := &FilterPolicy{
+		Spec: FilterPolicySpec{
+			TargetRef: &gwapiv1a2.PolicyTargetReferenceWithSectionName{
+				PolicyTargetReference: gwapiv1a2.PolicyTargetReference{
+					Group: "networking.istio.io",
+					Kind:  "VirtualService",
+				},
+			},
+			Filters: map[string]Plugin{
+				"limitReq": {
+					Config: runtime.RawExtension{
+						Raw: []byte(`{"average":0}`),
+					},
+				},
+				"animal": {
+					Config: runtime.RawExtension{
+						Raw: []byte(`{"pet":"cat"}`),
+					},
+				},
+			},
+			SubPolicies: []FilterSubPolicy{
+				{
+					SectionName: "route",
+					Filters: map[string]Plugin{
+						"animal": {
+							Config: runtime.RawExtension{
+								Raw: []byte(`{"pet":"cat"}`),
+							},
+							Sampling: &PluginSampling{
+								Percentage: 101,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []PluginStatus{
+		{Name: "animal", Reason: ReasonAccepted},
+		{Name: "limitReq", Reason: ReasonInvalid, Message: "spec.filters.limitReq.config.average: value must be greater than 0"},
+		{Name: "animal", SectionName: "route", Reason: ReasonInvalid, Message: "spec.subPolicies[0].filters.animal.sampling.percentage: should be in [0, 100]"},
+	}, ValidateFilterPolicyPlugins(policy))
+}
+
 func TestValidateEmbeddedFilterPolicy(t *testing.T) {
 	plugins.RegisterPluginType("animal", &plugins.MockPlugin{})
 
@@ -511,7 +1100,7 @@ func TestValidateEmbeddedFilterPolicy(t *testing.T) {
 				},
 			},
 			gk:  schema.GroupKind{Group: "networking.istio.io", Kind: "VirtualService"},
-			err: "invalid LocalRateLimit.StatPrefix: value length must be at least 1 runes",
+			err: "spec.filters.localRatelimit.config.statPrefix: value length must be at least 1 runes",
 		},
 		{
 			name: "ok, Istio Gateway",
@@ -560,6 +1149,21 @@ func TestValidateEmbeddedFilterPolicy(t *testing.T) {
 			gk:  schema.GroupKind{Group: "gateways.gateway.networking.k8s.io", Kind: "Gateway"},
 			err: "embed policy to the gateways.gateway.networking.k8s.io/Gateway is not implemented",
 		},
+		{
+			name: "rollout",
+			policy: &FilterPolicy{
+				Spec: FilterPolicySpec{
+					Rollout: &FilterPolicyRollout{
+						Selector: map[string]string{"canary": "true"},
+						Analysis: RolloutAnalysis{
+							Query: "up == 0",
+						},
+					},
+				},
+			},
+			gk:  schema.GroupKind{Group: "networking.istio.io", Kind: "VirtualService"},
+			err: "rollout is not supported by embedded policy",
+		},
 	}
 
 	for _, tt := range tests {
@@ -678,7 +1282,7 @@ func TestValidateConsumer(t *testing.T) {
 					},
 				},
 			},
-			err: "unknown authn filter: property",
+			err: "spec.auth.property: unknown authn filter: property",
 		},
 		{
 			name: "bad configuration",
@@ -693,7 +1297,7 @@ func TestValidateConsumer(t *testing.T) {
 					},
 				},
 			},
-			err: "invalid value for string type",
+			err: "spec.auth.keyAuth.config: failed to unmarshal",
 		},
 		{
 			name: "invalid config for filter",
@@ -715,7 +1319,7 @@ func TestValidateConsumer(t *testing.T) {
 					},
 				},
 			},
-			err: "invalid config for filter opa",
+			err: "spec.filters.opa.config.configType: value is required",
 		},
 		{
 			name: "invalid filter",
@@ -799,7 +1403,7 @@ func TestValidateServiceRegistry(t *testing.T) {
 					},
 				},
 			},
-			err: "value must be absolute",
+			err: "spec.config.serverUrl: value must be absolute",
 		},
 	}
 
@@ -869,3 +1473,319 @@ func TestValidateDynamicConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePluginConfigPolicy(t *testing.T) {
+	max := int64(10)
+	min := int64(20)
+	tests := []struct {
+		name   string
+		policy *PluginConfigPolicy
+		err    string
+	}{
+		{
+			name: "ok",
+			policy: &PluginConfigPolicy{
+				Spec: PluginConfigPolicySpec{
+					Namespaces: []string{"team-a"},
+					Plugins: []PluginAllowance{
+						{Name: "animal"},
+						{
+							Name: "limitReq",
+							Fields: []FieldConstraint{
+								{Path: "burst", Maximum: &max},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "forbid all",
+			policy: &PluginConfigPolicy{
+				Spec: PluginConfigPolicySpec{},
+			},
+		},
+		{
+			name: "empty namespace",
+			policy: &PluginConfigPolicy{
+				Spec: PluginConfigPolicySpec{
+					Namespaces: []string{""},
+				},
+			},
+			err: "spec.namespaces[0]: namespace is empty",
+		},
+		{
+			name: "unknown plugin",
+			policy: &PluginConfigPolicy{
+				Spec: PluginConfigPolicySpec{
+					Plugins: []PluginAllowance{{Name: "unknown"}},
+				},
+			},
+			err: "spec.plugins[0].name: unknown http filter: unknown",
+		},
+		{
+			name: "duplicate plugin",
+			policy: &PluginConfigPolicy{
+				Spec: PluginConfigPolicySpec{
+					Plugins: []PluginAllowance{{Name: "animal"}, {Name: "animal"}},
+				},
+			},
+			err: "spec.plugins[1].name: duplicate plugin animal",
+		},
+		{
+			name: "bad path",
+			policy: &PluginConfigPolicy{
+				Spec: PluginConfigPolicySpec{
+					Plugins: []PluginAllowance{
+						{
+							Name: "limitReq",
+							Fields: []FieldConstraint{
+								{Path: "rules..burst", Maximum: &max},
+							},
+						},
+					},
+				},
+			},
+			err: `spec.plugins[0].fields[0].path: invalid path "rules..burst"`,
+		},
+		{
+			name: "no constraint",
+			policy: &PluginConfigPolicy{
+				Spec: PluginConfigPolicySpec{
+					Plugins: []PluginAllowance{
+						{
+							Name: "limitReq",
+							Fields: []FieldConstraint{
+								{Path: "burst"},
+							},
+						},
+					},
+				},
+			},
+			err: "spec.plugins[0].fields[0]: one of minimum, maximum and values is required",
+		},
+		{
+			name: "bad bounds",
+			policy: &PluginConfigPolicy{
+				Spec: PluginConfigPolicySpec{
+					Plugins: []PluginAllowance{
+						{
+							Name: "limitReq",
+							Fields: []FieldConstraint{
+								{Path: "burst", Minimum: &min, Maximum: &max},
+							},
+						},
+					},
+				},
+			},
+			err: "spec.plugins[0].fields[0]: minimum should not be greater than maximum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePluginConfigPolicy(tt.policy)
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateFilterPolicyAllowance(t *testing.T) {
+	min := int64(1)
+	max := int64(100)
+	allowances := []*PluginConfigPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+			Spec: PluginConfigPolicySpec{
+				Namespaces: []string{"team-a"},
+				Plugins: []PluginAllowance{
+					{
+						Name: "animal",
+						Fields: []FieldConstraint{
+							{Path: "pet", Values: []string{"cat", "dog"}},
+						},
+					},
+					{
+						Name: "limitReq",
+						Fields: []FieldConstraint{
+							{Path: "burst", Minimum: &min, Maximum: &max},
+						},
+					},
+					{
+						Name: "localRatelimit",
+						Fields: []FieldConstraint{
+							{Path: "token_bucket.max_tokens", Maximum: &max},
+						},
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "all"},
+			Spec: PluginConfigPolicySpec{
+				Plugins: []PluginAllowance{
+					{Name: "animal"},
+					{Name: "limitReq"},
+					{Name: "localRatelimit"},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid", Generation: 1},
+			Spec: PluginConfigPolicySpec{
+				Namespaces: []string{"team-a"},
+			},
+			Status: PluginConfigPolicyStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(ConditionAccepted),
+						Reason:             string(ReasonInvalid),
+						ObservedGeneration: 1,
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		filters   map[string]string
+		err       string
+	}{
+		{
+			name:      "ok",
+			namespace: "team-a",
+			filters: map[string]string{
+				"animal":         `{"pet":"cat"}`,
+				"limitReq":       `{"average":1,"burst":100}`,
+				"localRatelimit": `{"statPrefix":"http","tokenBucket":{"maxTokens":10}}`,
+			},
+		},
+		{
+			name:      "field not configured",
+			namespace: "team-a",
+			filters: map[string]string{
+				"limitReq": `{"average":1}`,
+			},
+		},
+		{
+			name:      "forbidden plugin",
+			namespace: "team-b",
+			filters: map[string]string{
+				"opa": `{"rego":"package test"}`,
+			},
+			err: "spec.filters.opa: plugin opa is not allowed by PluginConfigPolicy all",
+		},
+		{
+			name:      "exceed maximum",
+			namespace: "team-a",
+			filters: map[string]string{
+				"limitReq": `{"average":1,"burst":101}`,
+			},
+			err: "spec.filters.limitReq.config.burst: value 101 is greater than the maximum 100, which is restricted by PluginConfigPolicy team-a",
+		},
+		{
+			name:      "less than minimum",
+			namespace: "team-a",
+			filters: map[string]string{
+				"limitReq": `{"average":1,"burst":0}`,
+			},
+			err: "spec.filters.limitReq.config.burst: value 0 is less than the minimum 1",
+		},
+		{
+			name:      "camel case field",
+			namespace: "team-a",
+			filters: map[string]string{
+				"localRatelimit": `{"statPrefix":"http","tokenBucket":{"maxTokens":1000}}`,
+			},
+			err: "spec.filters.localRatelimit.config.token_bucket.max_tokens: value 1000 is greater than the maximum 100",
+		},
+		{
+			name:      "not allowed value",
+			namespace: "team-a",
+			filters: map[string]string{
+				"animal": `{"pet":"bird"}`,
+			},
+			err: "spec.filters.animal.config.pet: value bird is not one of cat, dog",
+		},
+		{
+			name:      "not a number",
+			namespace: "team-a",
+			filters: map[string]string{
+				"limitReq": `{"average":1,"burst":"1"}`,
+			},
+			err: "spec.filters.limitReq.config.burst: should be a number",
+		},
+		{
+			name:      "multiple errors",
+			namespace: "team-a",
+			filters: map[string]string{
+				"animal":   `{"pet":"bird"}`,
+				"limitReq": `{"average":1,"burst":101}`,
+			},
+			err: "spec.filters.animal.config.pet: value bird is not one of cat, dog, which is restricted by PluginConfigPolicy team-a; spec.filters.limitReq.config.burst",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &FilterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace},
+				Spec: FilterPolicySpec{
+					Filters: map[string]Plugin{},
+				},
+			}
+			for name, conf := range tt.filters {
+				policy.Spec.Filters[name] = Plugin{
+					Config: runtime.RawExtension{Raw: []byte(conf)},
+				}
+			}
+			err := ValidateFilterPolicyAllowance(policy, allowances)
+			if tt.err == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+
+			// unrestricted namespace
+			assert.Nil(t, ValidateFilterPolicyAllowance(policy, nil))
+		})
+	}
+}
+
+func TestValidateConsumerAllowance(t *testing.T) {
+	allowances := []*PluginConfigPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+			Spec: PluginConfigPolicySpec{
+				Plugins: []PluginAllowance{
+					{Name: "keyAuth"},
+				},
+			},
+		},
+	}
+	c := &Consumer{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+		Spec: ConsumerSpec{
+			Auth: map[string]ConsumerPlugin{
+				"keyAuth": {
+					Config: runtime.RawExtension{Raw: []byte(`{"key":"test"}`)},
+				},
+			},
+		},
+	}
+	assert.Nil(t, ValidateConsumerAllowance(c, allowances))
+
+	c.Spec.Filters = map[string]Plugin{
+		"limitReq": {
+			Config: runtime.RawExtension{Raw: []byte(`{"average":1}`)},
+		},
+	}
+	assert.EqualError(t, ValidateConsumerAllowance(c, allowances),
+		"spec.filters.limitReq: plugin limitReq is not allowed by PluginConfigPolicy policy")
+}
@@ -266,6 +266,36 @@ func (in *DynamicConfigStatus) DeepCopy() *DynamicConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldConstraint) DeepCopyInto(out *FieldConstraint) {
+	*out = *in
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldConstraint.
+func (in *FieldConstraint) DeepCopy() *FieldConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilterPolicy) DeepCopyInto(out *FilterPolicy) {
 	*out = *in
@@ -325,6 +355,50 @@ func (in *FilterPolicyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterPolicyRollout) DeepCopyInto(out *FilterPolicyRollout) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Analysis.DeepCopyInto(&out.Analysis)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterPolicyRollout.
+func (in *FilterPolicyRollout) DeepCopy() *FilterPolicyRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterPolicyRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterPolicyRolloutStatus) DeepCopyInto(out *FilterPolicyRolloutStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.Stable != nil {
+		in, out := &in.Stable, &out.Stable
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterPolicyRolloutStatus.
+func (in *FilterPolicyRolloutStatus) DeepCopy() *FilterPolicyRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterPolicyRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilterPolicySpec) DeepCopyInto(out *FilterPolicySpec) {
 	*out = *in
@@ -347,6 +421,16 @@ func (in *FilterPolicySpec) DeepCopyInto(out *FilterPolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DisabledFilters != nil {
+		in, out := &in.DisabledFilters, &out.DisabledFilters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(FilterPolicyRollout)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterPolicySpec.
@@ -369,6 +453,16 @@ func (in *FilterPolicyStatus) DeepCopyInto(out *FilterPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]PluginStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(FilterPolicyRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	out.ChangeDetector = in.ChangeDetector
 }
 
@@ -545,6 +639,21 @@ func (in *HTTPFilterSubPolicy) DeepCopy() *HTTPFilterSubPolicy {
 func (in *Plugin) DeepCopyInto(out *Plugin) {
 	*out = *in
 	in.Config.DeepCopyInto(&out.Config)
+	if in.Sampling != nil {
+		in, out := &in.Sampling, &out.Sampling
+		*out = new(PluginSampling)
+		**out = **in
+	}
+	if in.HeaderOps != nil {
+		in, out := &in.HeaderOps, &out.HeaderOps
+		*out = new(PluginHeaderOps)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = new(PluginMatch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Plugin.
@@ -557,6 +666,290 @@ func (in *Plugin) DeepCopy() *Plugin {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginAllowance) DeepCopyInto(out *PluginAllowance) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]FieldConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginAllowance.
+func (in *PluginAllowance) DeepCopy() *PluginAllowance {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginAllowance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginConfigPolicy) DeepCopyInto(out *PluginConfigPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginConfigPolicy.
+func (in *PluginConfigPolicy) DeepCopy() *PluginConfigPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginConfigPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PluginConfigPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginConfigPolicyList) DeepCopyInto(out *PluginConfigPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PluginConfigPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginConfigPolicyList.
+func (in *PluginConfigPolicyList) DeepCopy() *PluginConfigPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginConfigPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PluginConfigPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginConfigPolicySpec) DeepCopyInto(out *PluginConfigPolicySpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]PluginAllowance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginConfigPolicySpec.
+func (in *PluginConfigPolicySpec) DeepCopy() *PluginConfigPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginConfigPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginConfigPolicyStatus) DeepCopyInto(out *PluginConfigPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.ChangeDetector = in.ChangeDetector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginConfigPolicyStatus.
+func (in *PluginConfigPolicyStatus) DeepCopy() *PluginConfigPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginConfigPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginHeaderCondition) DeepCopyInto(out *PluginHeaderCondition) {
+	*out = *in
+	if in.Present != nil {
+		in, out := &in.Present, &out.Present
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginHeaderCondition.
+func (in *PluginHeaderCondition) DeepCopy() *PluginHeaderCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginHeaderCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginHeaderOp) DeepCopyInto(out *PluginHeaderOp) {
+	*out = *in
+	if in.When != nil {
+		in, out := &in.When, &out.When
+		*out = make([]PluginHeaderCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginHeaderOp.
+func (in *PluginHeaderOp) DeepCopy() *PluginHeaderOp {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginHeaderOp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginHeaderOps) DeepCopyInto(out *PluginHeaderOps) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = make([]PluginHeaderOp, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = make([]PluginHeaderOp, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginHeaderOps.
+func (in *PluginHeaderOps) DeepCopy() *PluginHeaderOps {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginHeaderOps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginMatch) DeepCopyInto(out *PluginMatch) {
+	*out = *in
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]PluginHeaderCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginMatch.
+func (in *PluginMatch) DeepCopy() *PluginMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginSampling) DeepCopyInto(out *PluginSampling) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginSampling.
+func (in *PluginSampling) DeepCopy() *PluginSampling {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginSampling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginStatus) DeepCopyInto(out *PluginStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginStatus.
+func (in *PluginStatus) DeepCopy() *PluginStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutAnalysis) DeepCopyInto(out *RolloutAnalysis) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutAnalysis.
+func (in *RolloutAnalysis) DeepCopy() *RolloutAnalysis {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutAnalysis)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceRegistry) DeepCopyInto(out *ServiceRegistry) {
 	*out = *in
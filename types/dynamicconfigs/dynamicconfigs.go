@@ -16,4 +16,5 @@ package dynamicconfigs
 
 import (
 	_ "mosn.io/htnn/types/dynamicconfigs/demo"
+	_ "mosn.io/htnn/types/dynamicconfigs/sentinel"
 )
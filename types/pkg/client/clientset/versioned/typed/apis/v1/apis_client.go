@@ -33,6 +33,7 @@ type ApisV1Interface interface {
 	DynamicConfigsGetter
 	FilterPoliciesGetter
 	HTTPFilterPoliciesGetter
+	PluginConfigPoliciesGetter
 	ServiceRegistriesGetter
 }
 
@@ -57,6 +58,10 @@ func (c *ApisV1Client) HTTPFilterPolicies(namespace string) HTTPFilterPolicyInte
 	return newHTTPFilterPolicies(c, namespace)
 }
 
+func (c *ApisV1Client) PluginConfigPolicies() PluginConfigPolicyInterface {
+	return newPluginConfigPolicies(c)
+}
+
 func (c *ApisV1Client) ServiceRegistries(namespace string) ServiceRegistryInterface {
 	return newServiceRegistries(c, namespace)
 }
@@ -45,6 +45,10 @@ func (c *FakeApisV1) HTTPFilterPolicies(namespace string) v1.HTTPFilterPolicyInt
 	return &FakeHTTPFilterPolicies{c, namespace}
 }
 
+func (c *FakeApisV1) PluginConfigPolicies() v1.PluginConfigPolicyInterface {
+	return &FakePluginConfigPolicies{c}
+}
+
 func (c *FakeApisV1) ServiceRegistries(namespace string) v1.ServiceRegistryInterface {
 	return &FakeServiceRegistries{c, namespace}
 }
@@ -26,4 +26,6 @@ type FilterPolicyExpansion interface{}
 
 type HTTPFilterPolicyExpansion interface{}
 
+type PluginConfigPolicyExpansion interface{}
+
 type ServiceRegistryExpansion interface{}
@@ -190,6 +190,11 @@ func defineRequest() cel.EnvOption {
 			parameterTypes: []*exprpb.Type{},
 			returnType:     decls.String,
 		},
+		{
+			method:         "consumer",
+			parameterTypes: []*exprpb.Type{},
+			returnType:     decls.String,
+		},
 	} {
 		declarations = append(declarations,
 			decls.NewFunction(dec.method,
@@ -238,6 +243,12 @@ func (r *request) Receive(function string, overload string, args []ref.Val) ref.
 		return types.String(r.Query(name))
 	case "id":
 		return fromProperty(r.callback, "request.id")
+	case "consumer":
+		c := r.callback.GetConsumer()
+		if c == nil {
+			return types.String("")
+		}
+		return types.String(c.Name())
 	}
 
 	return types.NewErr("no such function - %s", function)
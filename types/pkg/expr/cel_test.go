@@ -24,6 +24,7 @@ import (
 	"github.com/google/cel-go/common/types"
 	"github.com/stretchr/testify/require"
 
+	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/plugins/tests/pkg/envoy"
 )
 
@@ -199,6 +200,13 @@ func TestCelWithRequest(t *testing.T) {
 				require.Equal(t, "property.request.id", res)
 			},
 		},
+		{
+			name: "no consumer",
+			code: `request.consumer()`,
+			expect: func(t *testing.T, res any) {
+				require.Equal(t, "", res)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -217,6 +225,26 @@ func TestCelWithRequest(t *testing.T) {
 	}
 }
 
+type testConsumer struct {
+	api.Consumer
+
+	name string
+}
+
+func (c *testConsumer) Name() string {
+	return c.name
+}
+
+func TestCelWithConsumer(t *testing.T) {
+	s, err := CompileCel(`request.consumer()`, cel.StringType)
+	require.NoError(t, err)
+	cb := envoy.NewFilterCallbackHandler()
+	cb.SetConsumer(&testConsumer{name: "john"})
+	res, err := s.EvalWithRequest(cb, envoy.NewRequestHeaderMap(http.Header{}))
+	require.NoError(t, err)
+	require.Equal(t, "john", res)
+}
+
 func TestCelWithSource(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -17,6 +17,7 @@ package registry
 import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 
+	"mosn.io/htnn/api/pkg/plugins"
 	"mosn.io/htnn/types/pkg/proto"
 )
 
@@ -50,6 +51,19 @@ func GetRegistryType(name string) Registry {
 	return registryTypes[name]
 }
 
+// LoadSensitiveFields returns the sensitive fields of the registry's configuration. Like the plugin,
+// the configuration declares them by implementing plugins.SensitiveFielder.
+func LoadSensitiveFields(name string) []string {
+	reg := GetRegistryType(name)
+	if reg == nil {
+		return nil
+	}
+	if sf, ok := reg.Config().(plugins.SensitiveFielder); ok {
+		return sf.SensitiveFields()
+	}
+	return nil
+}
+
 // ParseConfig parses the given data and returns the configuration according to the registry
 func ParseConfig(reg Registry, data []byte) (RegistryConfig, error) {
 	conf := reg.Config()
@@ -0,0 +1,98 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sealed provides the encryption of the sensitive fields in the plugin configuration.
+// The sealed value is in the format of `sealed:$base64(nonce + AES-GCM ciphertext)`.
+package sealed
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ParseKey decodes the base64 encoded key
+func ParseKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sealed value key: %w", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, fmt.Errorf("invalid sealed value key: length should be 16, 24 or 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+// IsSealed returns whether the value is sealed
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, plugins.SealedValuePrefix)
+}
+
+// Seal encrypts the plaintext with the key
+func Seal(key []byte, plaintext string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	ciphertext := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return plugins.SealedValuePrefix + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Unseal decrypts the sealed value with the key
+func Unseal(key []byte, value string) (string, error) {
+	if !IsSealed(value) {
+		return "", errors.New("value is not sealed")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, plugins.SealedValuePrefix))
+	if err != nil {
+		return "", fmt.Errorf("invalid sealed value: %w", err)
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < aead.NonceSize() {
+		return "", errors.New("invalid sealed value: too short")
+	}
+
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to unseal value: %w", err)
+	}
+	return string(plaintext), nil
+}
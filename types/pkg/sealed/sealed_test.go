@@ -0,0 +1,57 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sealed
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeal(t *testing.T) {
+	key, err := ParseKey("MDEyMzQ1Njc4OWFiY2RlZg==")
+	require.NoError(t, err)
+
+	value, err := Seal(key, "password")
+	require.NoError(t, err)
+	assert.True(t, IsSealed(value))
+	assert.NotContains(t, value, "password")
+
+	plaintext, err := Unseal(key, value)
+	require.NoError(t, err)
+	assert.Equal(t, "password", plaintext)
+
+	another, _ := ParseKey("ZmVkY2JhOTg3NjU0MzIxMA==")
+	_, err = Unseal(another, value)
+	assert.ErrorContains(t, err, "failed to unseal value")
+
+	_, err = Unseal(key, "password")
+	assert.ErrorContains(t, err, "value is not sealed")
+	_, err = Unseal(key, "sealed:!!!")
+	assert.ErrorContains(t, err, "invalid sealed value")
+	_, err = Unseal(key, "sealed:YQ==")
+	assert.ErrorContains(t, err, "too short")
+	_, err = Unseal(key, strings.Replace(value, value[len(value)-4:], "AAA=", 1))
+	assert.Error(t, err)
+}
+
+func TestParseKey(t *testing.T) {
+	_, err := ParseKey("not base64")
+	assert.ErrorContains(t, err, "invalid sealed value key")
+	_, err = ParseKey("a2V5")
+	assert.ErrorContains(t, err, "length should be 16, 24 or 32 bytes, got 3")
+}
@@ -0,0 +1,101 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abtest
+
+import (
+	"fmt"
+	"net/textproto"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "abTest"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTraffic
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionTraffic,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+// HeaderName returns the header sent to the upstream with the variant of the user
+func (e *Experiment) HeaderName() string {
+	if e.Header != "" {
+		return e.Header
+	}
+	return "x-ab-" + e.Name
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]bool, len(conf.Experiments))
+	headers := make(map[string]bool, len(conf.Experiments))
+	for _, e := range conf.Experiments {
+		if names[e.Name] {
+			return fmt.Errorf("duplicate experiment %s", e.Name)
+		}
+		names[e.Name] = true
+
+		header := textproto.CanonicalMIMEHeaderKey(e.HeaderName())
+		if headers[header] {
+			return fmt.Errorf("duplicate header %s", e.HeaderName())
+		}
+		headers[header] = true
+
+		if src, ok := e.User.Source.(*UserSource_Consumer); ok && !src.Consumer {
+			return fmt.Errorf("experiment %s: consumer should be true", e.Name)
+		}
+
+		var total uint64
+		variants := make(map[string]bool, len(e.Variants))
+		for _, v := range e.Variants {
+			if variants[v.Name] {
+				return fmt.Errorf("experiment %s: duplicate variant %s", e.Name, v.Name)
+			}
+			variants[v.Name] = true
+			total += uint64(v.Weight)
+		}
+		if total == 0 {
+			return fmt.Errorf("experiment %s: the total weight of variants should be greater than 0", e.Name)
+		}
+	}
+	return nil
+}
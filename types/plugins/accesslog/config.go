@@ -0,0 +1,153 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accesslog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "accessLog"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeObservability
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionAccess,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+// VariablePrefixes are the prefixes of the variables which are only available in the access log,
+// in addition to the ones supported by the interpolation package.
+var VariablePrefixes = []string{"request.protocol", "response", "upstream", "duration", "start_time", "plugin_state"}
+
+// Facilities maps the names of the syslog facilities to their codes.
+var Facilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+func checkVariable(name string) error {
+	switch name {
+	case "request.protocol", "response.code", "response.code_details", "upstream.address",
+		"upstream.cluster", "duration", "start_time":
+		return nil
+	}
+	if header, ok := strings.CutPrefix(name, "response.header."); ok && header != "" {
+		return nil
+	}
+	if s, ok := strings.CutPrefix(name, "plugin_state."); ok {
+		ns, key, found := strings.Cut(s, ".")
+		if found && ns != "" && key != "" {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown variable: %s", name)
+}
+
+// CompileTemplate compiles the template used in the access log.
+func CompileTemplate(s string) (*interpolation.Template, error) {
+	tpl, err := interpolation.CompileWithPrefixes(s, VariablePrefixes...)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range tpl.CustomVariables() {
+		if err := checkVariable(name); err != nil {
+			return nil, err
+		}
+	}
+	return tpl, nil
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if conf.Format != Format_JSON && len(conf.Fields) > 0 {
+		return errors.New("fields is only used in JSON format")
+	}
+	if conf.Format == Format_TEXT {
+		if conf.Template == "" {
+			return errors.New("template is required in TEXT format")
+		}
+	} else if conf.Template != "" {
+		return errors.New("template is only used in TEXT format")
+	}
+
+	for name, field := range conf.Fields {
+		if _, err := CompileTemplate(field); err != nil {
+			return fmt.Errorf("bad template of field %s: %w", name, err)
+		}
+	}
+	if conf.Template != "" {
+		if _, err := CompileTemplate(conf.Template); err != nil {
+			return fmt.Errorf("bad template: %w", err)
+		}
+	}
+
+	for _, sink := range conf.Sinks {
+		if syslog := sink.GetSyslog(); syslog != nil && syslog.Facility != "" {
+			if _, ok := Facilities[syslog.Facility]; !ok {
+				return fmt.Errorf("unknown syslog facility %s", syslog.Facility)
+			}
+		}
+	}
+	return nil
+}
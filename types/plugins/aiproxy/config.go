@@ -0,0 +1,73 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aiproxy
+
+import (
+	"fmt"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "aiProxy"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeGeneral
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		// rewrite the request after it's authenticated and transformed
+		Position: plugins.OrderPositionBeforeUpstream,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]bool, len(conf.Providers))
+	for _, p := range conf.Providers {
+		if names[p.Name] {
+			return fmt.Errorf("duplicate provider %s", p.Name)
+		}
+		names[p.Name] = true
+	}
+	return nil
+}
+
+func (conf *CustomConfig) SensitiveFields() []string {
+	return []string{"providers.apiKey"}
+}
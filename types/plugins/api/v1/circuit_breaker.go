@@ -0,0 +1,35 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"mosn.io/htnn/api/pkg/circuitbreaker"
+)
+
+// ToConfig converts the CircuitBreaker to the configuration used by the circuit breaker utility.
+// Nil is returned if the CircuitBreaker is not configured.
+func (c *CircuitBreaker) ToConfig() *circuitbreaker.Config {
+	if c == nil {
+		return nil
+	}
+	conf := &circuitbreaker.Config{
+		FailureThreshold:    c.FailureThreshold,
+		HalfOpenMaxRequests: c.HalfOpenMaxRequests,
+	}
+	if c.OpenDuration != nil {
+		conf.OpenDuration = c.OpenDuration.AsDuration()
+	}
+	return conf
+}
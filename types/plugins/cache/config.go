@@ -0,0 +1,78 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"fmt"
+	"net"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "cache"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTraffic
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionTraffic,
+		// run after the rate limit plugins, so that the cached responses are also limited
+		Operation: plugins.OrderOperationInsertLast,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if redis := conf.GetRedis(); redis != nil {
+		_, _, err = net.SplitHostPort(redis.Address)
+		if err != nil {
+			return fmt.Errorf("bad address %s: %w", redis.Address, err)
+		}
+		if redis.Username != "" && redis.Password == "" {
+			return fmt.Errorf("password is required when username is set")
+		}
+	}
+
+	return nil
+}
+
+func (conf *CustomConfig) SensitiveFields() []string {
+	return []string{"redis.password", "purge.secret"}
+}
@@ -0,0 +1,104 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+	"mosn.io/htnn/types/pkg/expr"
+)
+
+const (
+	Name = "canary"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTraffic
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionBeforeUpstream,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+// Blocking returns true as the plugin proxies the requests to the canary upstream
+func (p *Plugin) Blocking() bool {
+	return true
+}
+
+type CustomConfig struct {
+	Config
+}
+
+// ClusterURL returns the base URL of the canary upstream
+func ClusterURL(cluster string) (*url.URL, error) {
+	base := cluster
+	if !strings.Contains(base, "://") {
+		base = "http://" + base
+	}
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %s", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+	return u, nil
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if _, err := ClusterURL(conf.Cluster); err != nil {
+		return fmt.Errorf("bad cluster %s: %w", conf.Cluster, err)
+	}
+	for _, rule := range conf.Rules {
+		if len(rule.Headers) == 0 && len(rule.Cookies) == 0 && len(rule.Consumers) == 0 {
+			return errors.New("rule should have at least one condition")
+		}
+		for _, matchers := range [][]*ValueMatcher{rule.Headers, rule.Cookies} {
+			for _, m := range matchers {
+				if _, err := expr.BuildStringMatcher(m.Value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
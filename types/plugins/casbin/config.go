@@ -15,6 +15,8 @@
 package casbin
 
 import (
+	"errors"
+
 	"mosn.io/htnn/api/pkg/filtermanager/api"
 	"mosn.io/htnn/api/pkg/plugins"
 )
@@ -42,5 +44,21 @@ func (p *Plugin) Order() plugins.PluginOrder {
 }
 
 func (p *Plugin) Config() api.PluginConfig {
-	return &Config{}
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if conf.Token.Source != Config_CONSUMER && conf.Token.Name == "" {
+		return errors.New("token name is required")
+	}
+	return nil
 }
@@ -27,6 +27,7 @@ import (
 	_ "github.com/envoyproxy/protoc-gen-validate/validate"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 )
 
 const (
@@ -39,16 +40,22 @@ const (
 type Config_Source int32
 
 const (
-	Config_HEADER Config_Source = 0
+	Config_HEADER    Config_Source = 0
+	Config_JWT_CLAIM Config_Source = 1
+	Config_CONSUMER  Config_Source = 2
 )
 
 // Enum value maps for Config_Source.
 var (
 	Config_Source_name = map[int32]string{
 		0: "HEADER",
+		1: "JWT_CLAIM",
+		2: "CONSUMER",
 	}
 	Config_Source_value = map[string]int32{
-		"HEADER": 0,
+		"HEADER":    0,
+		"JWT_CLAIM": 1,
+		"CONSUMER":  2,
 	}
 )
 
@@ -139,8 +146,20 @@ type Config_Rule struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Model  string `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
-	Policy string `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	// Types that are assignable to ModelSource:
+	//
+	//	*Config_Rule_Model
+	//	*Config_Rule_ModelText
+	//	*Config_Rule_ModelUrl
+	ModelSource isConfig_Rule_ModelSource `protobuf_oneof:"model_source"`
+	// Types that are assignable to PolicySource:
+	//
+	//	*Config_Rule_Policy
+	//	*Config_Rule_PolicyText
+	//	*Config_Rule_PolicyUrl
+	PolicySource isConfig_Rule_PolicySource `protobuf_oneof:"policy_source"`
+	// The interval to fetch the model and the policy from the URL again. Default to 60s.
+	RefreshInterval *durationpb.Duration `protobuf:"bytes,7,opt,name=refresh_interval,json=refreshInterval,proto3" json:"refresh_interval,omitempty"`
 }
 
 func (x *Config_Rule) Reset() {
@@ -175,27 +194,133 @@ func (*Config_Rule) Descriptor() ([]byte, []int) {
 	return file_types_plugins_casbin_config_proto_rawDescGZIP(), []int{0, 0}
 }
 
+func (m *Config_Rule) GetModelSource() isConfig_Rule_ModelSource {
+	if m != nil {
+		return m.ModelSource
+	}
+	return nil
+}
+
 func (x *Config_Rule) GetModel() string {
-	if x != nil {
+	if x, ok := x.GetModelSource().(*Config_Rule_Model); ok {
 		return x.Model
 	}
 	return ""
 }
 
+func (x *Config_Rule) GetModelText() string {
+	if x, ok := x.GetModelSource().(*Config_Rule_ModelText); ok {
+		return x.ModelText
+	}
+	return ""
+}
+
+func (x *Config_Rule) GetModelUrl() string {
+	if x, ok := x.GetModelSource().(*Config_Rule_ModelUrl); ok {
+		return x.ModelUrl
+	}
+	return ""
+}
+
+func (m *Config_Rule) GetPolicySource() isConfig_Rule_PolicySource {
+	if m != nil {
+		return m.PolicySource
+	}
+	return nil
+}
+
 func (x *Config_Rule) GetPolicy() string {
-	if x != nil {
+	if x, ok := x.GetPolicySource().(*Config_Rule_Policy); ok {
 		return x.Policy
 	}
 	return ""
 }
 
+func (x *Config_Rule) GetPolicyText() string {
+	if x, ok := x.GetPolicySource().(*Config_Rule_PolicyText); ok {
+		return x.PolicyText
+	}
+	return ""
+}
+
+func (x *Config_Rule) GetPolicyUrl() string {
+	if x, ok := x.GetPolicySource().(*Config_Rule_PolicyUrl); ok {
+		return x.PolicyUrl
+	}
+	return ""
+}
+
+func (x *Config_Rule) GetRefreshInterval() *durationpb.Duration {
+	if x != nil {
+		return x.RefreshInterval
+	}
+	return nil
+}
+
+type isConfig_Rule_ModelSource interface {
+	isConfig_Rule_ModelSource()
+}
+
+type Config_Rule_Model struct {
+	// The path to the model file. The file is reloaded once it's changed, so it can be
+	// mounted from a ConfigMap.
+	Model string `protobuf:"bytes,1,opt,name=model,proto3,oneof"`
+}
+
+type Config_Rule_ModelText struct {
+	// The content of the model.
+	ModelText string `protobuf:"bytes,3,opt,name=model_text,json=modelText,proto3,oneof"`
+}
+
+type Config_Rule_ModelUrl struct {
+	// The URL to fetch the model.
+	ModelUrl string `protobuf:"bytes,5,opt,name=model_url,json=modelUrl,proto3,oneof"`
+}
+
+func (*Config_Rule_Model) isConfig_Rule_ModelSource() {}
+
+func (*Config_Rule_ModelText) isConfig_Rule_ModelSource() {}
+
+func (*Config_Rule_ModelUrl) isConfig_Rule_ModelSource() {}
+
+type isConfig_Rule_PolicySource interface {
+	isConfig_Rule_PolicySource()
+}
+
+type Config_Rule_Policy struct {
+	// The path to the policy file. The file is reloaded once it's changed, so it can be
+	// mounted from a ConfigMap.
+	Policy string `protobuf:"bytes,2,opt,name=policy,proto3,oneof"`
+}
+
+type Config_Rule_PolicyText struct {
+	// The content of the policy.
+	PolicyText string `protobuf:"bytes,4,opt,name=policy_text,json=policyText,proto3,oneof"`
+}
+
+type Config_Rule_PolicyUrl struct {
+	// The URL to fetch the policy.
+	PolicyUrl string `protobuf:"bytes,6,opt,name=policy_url,json=policyUrl,proto3,oneof"`
+}
+
+func (*Config_Rule_Policy) isConfig_Rule_PolicySource() {}
+
+func (*Config_Rule_PolicyText) isConfig_Rule_PolicySource() {}
+
+func (*Config_Rule_PolicyUrl) isConfig_Rule_PolicySource() {}
+
 type Config_Token struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Source Config_Source `protobuf:"varint,1,opt,name=source,proto3,enum=types.plugins.casbin.Config_Source" json:"source,omitempty"`
-	Name   string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The name of the header when the source is HEADER, or the name of the claim when the source
+	// is JWT_CLAIM. Not used when the source is CONSUMER.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// The header which contains the JWT, only used when the source is JWT_CLAIM.
+	// Default to `Authorization`.
+	JwtHeader string `protobuf:"bytes,3,opt,name=jwt_header,json=jwtHeader,proto3" json:"jwt_header,omitempty"`
 }
 
 func (x *Config_Token) Reset() {
@@ -244,15 +369,24 @@ func (x *Config_Token) GetName() string {
 	return ""
 }
 
+func (x *Config_Token) GetJwtHeader() string {
+	if x != nil {
+		return x.JwtHeader
+	}
+	return ""
+}
+
 var File_types_plugins_casbin_config_proto protoreflect.FileDescriptor
 
 var file_types_plugins_casbin_config_proto_rawDesc = []byte{
 	0x0a, 0x21, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f,
 	0x63, 0x61, 0x73, 0x62, 0x69, 0x6e, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x12, 0x14, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x73, 0x2e, 0x63, 0x61, 0x73, 0x62, 0x69, 0x6e, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x6e, 0x73, 0x2e, 0x63, 0x61, 0x73, 0x62, 0x69, 0x6e, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64,
 	0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0xce, 0x02, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3f, 0x0a,
+	0x74, 0x6f, 0x22, 0xb8, 0x05, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3f, 0x0a,
 	0x04, 0x72, 0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x79,
 	0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x63, 0x61, 0x73, 0x62,
 	0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x42, 0x08,
@@ -261,21 +395,44 @@ var file_types_plugins_casbin_config_proto_rawDesc = []byte{
 	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x63, 0x61,
 	0x73, 0x62, 0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x54, 0x6f, 0x6b, 0x65,
 	0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x1a, 0x46, 0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x05, 0x6d, 0x6f,
-	0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02,
-	0x10, 0x01, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x1f, 0x0a, 0x06, 0x70, 0x6f, 0x6c,
-	0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02,
-	0x10, 0x01, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x1a, 0x61, 0x0a, 0x05, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x12, 0x3b, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67,
-	0x69, 0x6e, 0x73, 0x2e, 0x63, 0x61, 0x73, 0x62, 0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x12, 0x1b, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07,
-	0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x14, 0x0a,
-	0x06, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x48, 0x45, 0x41, 0x44, 0x45,
-	0x52, 0x10, 0x00, 0x42, 0x23, 0x5a, 0x21, 0x6d, 0x6f, 0x73, 0x6e, 0x2e, 0x69, 0x6f, 0x2f, 0x68,
-	0x74, 0x6e, 0x6e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x73, 0x2f, 0x63, 0x61, 0x73, 0x62, 0x69, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x6e, 0x1a, 0xf1, 0x02, 0x0a, 0x04, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72,
+	0x02, 0x10, 0x01, 0x48, 0x00, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x28, 0x0a, 0x0a,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x48, 0x00, 0x52, 0x09, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x54, 0x65, 0x78, 0x74, 0x12, 0x27, 0x0a, 0x09, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f,
+	0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03,
+	0x88, 0x01, 0x01, 0x48, 0x00, 0x52, 0x08, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x55, 0x72, 0x6c, 0x12,
+	0x21, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x48, 0x01, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x2a, 0x0a, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x74, 0x65, 0x78,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01,
+	0x48, 0x01, 0x52, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x54, 0x65, 0x78, 0x74, 0x12, 0x29,
+	0x0a, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x88, 0x01, 0x01, 0x48, 0x01, 0x52, 0x09,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x55, 0x72, 0x6c, 0x12, 0x50, 0x0a, 0x10, 0x72, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0a,
+	0xfa, 0x42, 0x07, 0xaa, 0x01, 0x04, 0x32, 0x02, 0x08, 0x01, 0x52, 0x0f, 0x72, 0x65, 0x66, 0x72,
+	0x65, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x42, 0x13, 0x0a, 0x0c, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x03, 0xf8, 0x42, 0x01,
+	0x42, 0x14, 0x0a, 0x0d, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x1a, 0x81, 0x01, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x45, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x23, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73,
+	0x2e, 0x63, 0x61, 0x73, 0x62, 0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6a,
+	0x77, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6a, 0x77, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x22, 0x31, 0x0a, 0x06, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x48, 0x45, 0x41, 0x44, 0x45, 0x52, 0x10, 0x00,
+	0x12, 0x0d, 0x0a, 0x09, 0x4a, 0x57, 0x54, 0x5f, 0x43, 0x4c, 0x41, 0x49, 0x4d, 0x10, 0x01, 0x12,
+	0x0c, 0x0a, 0x08, 0x43, 0x4f, 0x4e, 0x53, 0x55, 0x4d, 0x45, 0x52, 0x10, 0x02, 0x42, 0x23, 0x5a,
+	0x21, 0x6d, 0x6f, 0x73, 0x6e, 0x2e, 0x69, 0x6f, 0x2f, 0x68, 0x74, 0x6e, 0x6e, 0x2f, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x63, 0x61, 0x73, 0x62,
+	0x69, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -293,20 +450,22 @@ func file_types_plugins_casbin_config_proto_rawDescGZIP() []byte {
 var file_types_plugins_casbin_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
 var file_types_plugins_casbin_config_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_types_plugins_casbin_config_proto_goTypes = []interface{}{
-	(Config_Source)(0),   // 0: types.plugins.casbin.Config.Source
-	(*Config)(nil),       // 1: types.plugins.casbin.Config
-	(*Config_Rule)(nil),  // 2: types.plugins.casbin.Config.Rule
-	(*Config_Token)(nil), // 3: types.plugins.casbin.Config.Token
+	(Config_Source)(0),          // 0: types.plugins.casbin.Config.Source
+	(*Config)(nil),              // 1: types.plugins.casbin.Config
+	(*Config_Rule)(nil),         // 2: types.plugins.casbin.Config.Rule
+	(*Config_Token)(nil),        // 3: types.plugins.casbin.Config.Token
+	(*durationpb.Duration)(nil), // 4: google.protobuf.Duration
 }
 var file_types_plugins_casbin_config_proto_depIdxs = []int32{
 	2, // 0: types.plugins.casbin.Config.rule:type_name -> types.plugins.casbin.Config.Rule
 	3, // 1: types.plugins.casbin.Config.token:type_name -> types.plugins.casbin.Config.Token
-	0, // 2: types.plugins.casbin.Config.Token.source:type_name -> types.plugins.casbin.Config.Source
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 2: types.plugins.casbin.Config.Rule.refresh_interval:type_name -> google.protobuf.Duration
+	0, // 3: types.plugins.casbin.Config.Token.source:type_name -> types.plugins.casbin.Config.Source
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_types_plugins_casbin_config_proto_init() }
@@ -352,6 +511,14 @@ func file_types_plugins_casbin_config_proto_init() {
 			}
 		}
 	}
+	file_types_plugins_casbin_config_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*Config_Rule_Model)(nil),
+		(*Config_Rule_ModelText)(nil),
+		(*Config_Rule_ModelUrl)(nil),
+		(*Config_Rule_Policy)(nil),
+		(*Config_Rule_PolicyText)(nil),
+		(*Config_Rule_PolicyUrl)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
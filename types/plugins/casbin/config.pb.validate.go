@@ -235,10 +235,127 @@ func (m *Config_Rule) validate(all bool) error {
 
 	var errors []error
 
-	if utf8.RuneCountInString(m.GetModel()) < 1 {
+	if d := m.GetRefreshInterval(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = Config_RuleValidationError{
+				field:  "RefreshInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gte := time.Duration(1*time.Second + 0*time.Nanosecond)
+
+			if dur < gte {
+				err := Config_RuleValidationError{
+					field:  "RefreshInterval",
+					reason: "value must be greater than or equal to 1s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	oneofModelSourcePresent := false
+	switch v := m.ModelSource.(type) {
+	case *Config_Rule_Model:
+		if v == nil {
+			err := Config_RuleValidationError{
+				field:  "ModelSource",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofModelSourcePresent = true
+
+		if utf8.RuneCountInString(m.GetModel()) < 1 {
+			err := Config_RuleValidationError{
+				field:  "Model",
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	case *Config_Rule_ModelText:
+		if v == nil {
+			err := Config_RuleValidationError{
+				field:  "ModelSource",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofModelSourcePresent = true
+
+		if utf8.RuneCountInString(m.GetModelText()) < 1 {
+			err := Config_RuleValidationError{
+				field:  "ModelText",
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	case *Config_Rule_ModelUrl:
+		if v == nil {
+			err := Config_RuleValidationError{
+				field:  "ModelSource",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofModelSourcePresent = true
+
+		if uri, err := url.Parse(m.GetModelUrl()); err != nil {
+			err = Config_RuleValidationError{
+				field:  "ModelUrl",
+				reason: "value must be a valid URI",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else if !uri.IsAbs() {
+			err := Config_RuleValidationError{
+				field:  "ModelUrl",
+				reason: "value must be absolute",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofModelSourcePresent {
 		err := Config_RuleValidationError{
-			field:  "Model",
-			reason: "value length must be at least 1 runes",
+			field:  "ModelSource",
+			reason: "value is required",
 		}
 		if !all {
 			return err
@@ -246,10 +363,97 @@ func (m *Config_Rule) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if utf8.RuneCountInString(m.GetPolicy()) < 1 {
+	oneofPolicySourcePresent := false
+	switch v := m.PolicySource.(type) {
+	case *Config_Rule_Policy:
+		if v == nil {
+			err := Config_RuleValidationError{
+				field:  "PolicySource",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofPolicySourcePresent = true
+
+		if utf8.RuneCountInString(m.GetPolicy()) < 1 {
+			err := Config_RuleValidationError{
+				field:  "Policy",
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	case *Config_Rule_PolicyText:
+		if v == nil {
+			err := Config_RuleValidationError{
+				field:  "PolicySource",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofPolicySourcePresent = true
+
+		if utf8.RuneCountInString(m.GetPolicyText()) < 1 {
+			err := Config_RuleValidationError{
+				field:  "PolicyText",
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	case *Config_Rule_PolicyUrl:
+		if v == nil {
+			err := Config_RuleValidationError{
+				field:  "PolicySource",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofPolicySourcePresent = true
+
+		if uri, err := url.Parse(m.GetPolicyUrl()); err != nil {
+			err = Config_RuleValidationError{
+				field:  "PolicyUrl",
+				reason: "value must be a valid URI",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else if !uri.IsAbs() {
+			err := Config_RuleValidationError{
+				field:  "PolicyUrl",
+				reason: "value must be absolute",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofPolicySourcePresent {
 		err := Config_RuleValidationError{
-			field:  "Policy",
-			reason: "value length must be at least 1 runes",
+			field:  "PolicySource",
+			reason: "value is required",
 		}
 		if !all {
 			return err
@@ -356,12 +560,10 @@ func (m *Config_Token) validate(all bool) error {
 
 	var errors []error
 
-	// no validation rules for Source
-
-	if utf8.RuneCountInString(m.GetName()) < 1 {
+	if _, ok := Config_Source_name[int32(m.GetSource())]; !ok {
 		err := Config_TokenValidationError{
-			field:  "Name",
-			reason: "value length must be at least 1 runes",
+			field:  "Source",
+			reason: "value must be one of the defined enum values",
 		}
 		if !all {
 			return err
@@ -369,6 +571,10 @@ func (m *Config_Token) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	// no validation rules for Name
+
+	// no validation rules for JwtHeader
+
 	if len(errors) > 0 {
 		return Config_TokenMultiError(errors)
 	}
@@ -46,3 +46,8 @@ func (p *Plugin) Order() plugins.PluginOrder {
 func (p *Plugin) Config() api.PluginConfig {
 	return &Config{}
 }
+
+// Blocking returns true as the plugin calls the external authorization service
+func (p *Plugin) Blocking() bool {
+	return true
+}
@@ -52,3 +52,7 @@ func (p *Plugin) ConsumerConfig() api.PluginConsumerConfig {
 func (conf *ConsumerConfig) Index() string {
 	return conf.AccessKey
 }
+
+func (conf *ConsumerConfig) SensitiveFields() []string {
+	return []string{"secretKey"}
+}
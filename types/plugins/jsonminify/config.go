@@ -0,0 +1,49 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonminify
+
+import (
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "jsonMinify"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTransform
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	// As the response is processed in the reverse order, put this plugin at the beginning so
+	// that the body is minified after the other transform plugins modify it.
+	return plugins.PluginOrder{
+		Position:  plugins.OrderPositionTransform,
+		Operation: plugins.OrderOperationInsertFirst,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &Config{}
+}
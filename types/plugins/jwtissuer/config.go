@@ -0,0 +1,113 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jwtissuer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "jwtIssuer"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeSecurity
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	// run after the authentication, authorization and traffic control, so that the token is only
+	// minted for the requests which are going to the upstream
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionTransform,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+// reservedClaims are set by the plugin, so they can't be configured in the claims
+var reservedClaims = map[string]struct{}{
+	"iss": {},
+	"sub": {},
+	"aud": {},
+	"exp": {},
+	"nbf": {},
+	"iat": {},
+	"jti": {},
+}
+
+// CompileTemplate compiles the template of the subject and the claims. In addition to the
+// variables supported by the interpolation package, `oidc.$claim` is accepted, which refers to
+// the claim from the OIDC provider.
+func CompileTemplate(s string) (*interpolation.Template, error) {
+	tpl, err := interpolation.CompileWithPrefixes(s, "oidc")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range tpl.CustomVariables() {
+		if claim, ok := strings.CutPrefix(name, "oidc."); !ok || claim == "" {
+			return nil, fmt.Errorf("unknown variable: %s", name)
+		}
+	}
+	return tpl, nil
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) SensitiveFields() []string {
+	return []string{"signingKey"}
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if conf.Subject != "" {
+		if _, err := CompileTemplate(conf.Subject); err != nil {
+			return fmt.Errorf("bad subject: %w", err)
+		}
+	}
+	for name, value := range conf.Claims {
+		if _, ok := reservedClaims[name]; ok {
+			return fmt.Errorf("claim %s is reserved", name)
+		}
+		if name == "" {
+			return errors.New("claim name should not be empty")
+		}
+		if _, err := CompileTemplate(value); err != nil {
+			return fmt.Errorf("bad claim %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -68,14 +68,16 @@ func (conf *CustomConfig) Validate() error {
 			return fmt.Errorf("bad address %s: %w", addr, err)
 		}
 	}
-	cluster := conf.GetCluster()
-	if cluster != nil {
-		addrs := cluster.Addresses
-		for _, addr := range addrs {
-			_, _, err = net.SplitHostPort(addr)
-			if err != nil {
-				return fmt.Errorf("bad address %s: %w", addr, err)
-			}
+	var addrs []string
+	if cluster := conf.GetCluster(); cluster != nil {
+		addrs = cluster.Addresses
+	} else if sentinel := conf.GetSentinel(); sentinel != nil {
+		addrs = sentinel.Addresses
+	}
+	for _, addr := range addrs {
+		_, _, err = net.SplitHostPort(addr)
+		if err != nil {
+			return fmt.Errorf("bad address %s: %w", addr, err)
 		}
 	}
 
@@ -95,3 +97,7 @@ func (conf *CustomConfig) Validate() error {
 
 	return nil
 }
+
+func (conf *CustomConfig) SensitiveFields() []string {
+	return []string{"password", "sentinel.sentinelPassword"}
+}
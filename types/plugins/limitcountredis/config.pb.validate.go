@@ -114,6 +114,8 @@ func (m *Rule) validate(all bool) error {
 
 	// no validation rules for Key
 
+	// no validation rules for SlidingWindow
+
 	if len(errors) > 0 {
 		return RuleMultiError(errors)
 	}
@@ -300,6 +302,131 @@ var _ interface {
 	ErrorName() string
 } = ClusterValidationError{}
 
+// Validate checks the field values on Sentinel with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Sentinel) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Sentinel with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SentinelMultiError, or nil
+// if none found.
+func (m *Sentinel) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Sentinel) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetAddresses()) < 1 {
+		err := SentinelValidationError{
+			field:  "Addresses",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetMasterName()) < 1 {
+		err := SentinelValidationError{
+			field:  "MasterName",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for SentinelUsername
+
+	// no validation rules for SentinelPassword
+
+	if len(errors) > 0 {
+		return SentinelMultiError(errors)
+	}
+
+	return nil
+}
+
+// SentinelMultiError is an error wrapping multiple validation errors returned
+// by Sentinel.ValidateAll() if the designated constraints aren't met.
+type SentinelMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SentinelMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SentinelMultiError) AllErrors() []error { return m }
+
+// SentinelValidationError is the validation error returned by
+// Sentinel.Validate if the designated constraints aren't met.
+type SentinelValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SentinelValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SentinelValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SentinelValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SentinelValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SentinelValidationError) ErrorName() string { return "SentinelValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SentinelValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSentinel.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SentinelValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SentinelValidationError{}
+
 // Validate checks the field values on Config with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -450,6 +577,48 @@ func (m *Config) validate(all bool) error {
 			}
 		}
 
+	case *Config_Sentinel:
+		if v == nil {
+			err := ConfigValidationError{
+				field:  "Source",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofSourcePresent = true
+
+		if all {
+			switch v := interface{}(m.GetSentinel()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConfigValidationError{
+						field:  "Sentinel",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConfigValidationError{
+						field:  "Sentinel",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetSentinel()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ConfigValidationError{
+					field:  "Sentinel",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	default:
 		_ = v // ensures v is used
 	}
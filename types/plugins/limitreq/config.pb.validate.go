@@ -100,6 +100,8 @@ func (m *Config) validate(all bool) error {
 
 	// no validation rules for Key
 
+	// no validation rules for NoDelay
+
 	if len(errors) > 0 {
 		return ConfigMultiError(errors)
 	}
@@ -0,0 +1,77 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock
+
+import (
+	"errors"
+	"fmt"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "mock"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeGeneral
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionBeforeUpstream,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	for _, hdr := range conf.Headers {
+		_, err = interpolation.Compile(hdr.Value)
+		if err != nil {
+			return fmt.Errorf("bad header %s: %w", hdr.Key, err)
+		}
+	}
+	_, err = interpolation.Compile(conf.Body)
+	if err != nil {
+		return fmt.Errorf("bad body: %w", err)
+	}
+
+	if d := conf.Delay; d != nil && d.Min.AsDuration() > d.Max.AsDuration() {
+		return errors.New("delay min should not be greater than max")
+	}
+	return nil
+}
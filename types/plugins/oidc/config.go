@@ -44,3 +44,7 @@ func (p *Plugin) Order() plugins.PluginOrder {
 func (p *Plugin) Config() api.PluginConfig {
 	return &Config{}
 }
+
+func (conf *Config) SensitiveFields() []string {
+	return []string{"clientSecret"}
+}
@@ -28,6 +28,8 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
+
+	v1 "mosn.io/htnn/types/plugins/api/v1"
 )
 
 const (
@@ -37,6 +39,112 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Cookie_SameSite int32
+
+const (
+	// Don't set the SameSite attribute
+	Cookie_DEFAULT Cookie_SameSite = 0
+	Cookie_LAX     Cookie_SameSite = 1
+	Cookie_STRICT  Cookie_SameSite = 2
+	Cookie_NONE    Cookie_SameSite = 3
+)
+
+// Enum value maps for Cookie_SameSite.
+var (
+	Cookie_SameSite_name = map[int32]string{
+		0: "DEFAULT",
+		1: "LAX",
+		2: "STRICT",
+		3: "NONE",
+	}
+	Cookie_SameSite_value = map[string]int32{
+		"DEFAULT": 0,
+		"LAX":     1,
+		"STRICT":  2,
+		"NONE":    3,
+	}
+)
+
+func (x Cookie_SameSite) Enum() *Cookie_SameSite {
+	p := new(Cookie_SameSite)
+	*p = x
+	return p
+}
+
+func (x Cookie_SameSite) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Cookie_SameSite) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_plugins_oidc_config_proto_enumTypes[0].Descriptor()
+}
+
+func (Cookie_SameSite) Type() protoreflect.EnumType {
+	return &file_types_plugins_oidc_config_proto_enumTypes[0]
+}
+
+func (x Cookie_SameSite) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Cookie_SameSite.Descriptor instead.
+func (Cookie_SameSite) EnumDescriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type ClaimToHeader_Encoding int32
+
+const (
+	// The string is passed as is, and the array is joined with ",".
+	// Other values are encoded in JSON.
+	ClaimToHeader_PLAIN ClaimToHeader_Encoding = 0
+	// Encode the plain value in base64.
+	ClaimToHeader_BASE64 ClaimToHeader_Encoding = 1
+	// Encode the value in JSON.
+	ClaimToHeader_JSON ClaimToHeader_Encoding = 2
+)
+
+// Enum value maps for ClaimToHeader_Encoding.
+var (
+	ClaimToHeader_Encoding_name = map[int32]string{
+		0: "PLAIN",
+		1: "BASE64",
+		2: "JSON",
+	}
+	ClaimToHeader_Encoding_value = map[string]int32{
+		"PLAIN":  0,
+		"BASE64": 1,
+		"JSON":   2,
+	}
+)
+
+func (x ClaimToHeader_Encoding) Enum() *ClaimToHeader_Encoding {
+	p := new(ClaimToHeader_Encoding)
+	*p = x
+	return p
+}
+
+func (x ClaimToHeader_Encoding) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ClaimToHeader_Encoding) Descriptor() protoreflect.EnumDescriptor {
+	return file_types_plugins_oidc_config_proto_enumTypes[1].Descriptor()
+}
+
+func (ClaimToHeader_Encoding) Type() protoreflect.EnumType {
+	return &file_types_plugins_oidc_config_proto_enumTypes[1]
+}
+
+func (x ClaimToHeader_Encoding) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ClaimToHeader_Encoding.Descriptor instead.
+func (ClaimToHeader_Encoding) EnumDescriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{10, 0}
+}
+
 type Config struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -63,6 +171,76 @@ type Config struct {
 	// expired than its actual expiration time. It is used to avoid late
 	// expirations due to client-server time mismatches. Default to 10s.
 	AccessTokenRefreshLeeway *durationpb.Duration `protobuf:"bytes,10,opt,name=access_token_refresh_leeway,json=accessTokenRefreshLeeway,proto3" json:"access_token_refresh_leeway,omitempty"`
+	// When the access token refresh is enabled, the tokens are refreshed before the access token
+	// or the ID token expires. The session is kept for the grace period after the ID token expires,
+	// so that the tokens can still be refreshed with the refresh token. The user will be redirected
+	// to log in again if the refresh fails. Default to 1h.
+	RefreshGracePeriod *durationpb.Duration `protobuf:"bytes,11,opt,name=refresh_grace_period,json=refreshGracePeriod,proto3" json:"refresh_grace_period,omitempty"`
+	// The path to log out. When the request path matches it, the session cookie is cleared and
+	// the user is redirected to the end_session_endpoint of the OIDC provider.
+	LogoutPath string `protobuf:"bytes,12,opt,name=logout_path,json=logoutPath,proto3" json:"logout_path,omitempty"`
+	// The URL to redirect the user to after logging out. It is sent to the OIDC provider as the
+	// post_logout_redirect_uri, so it should be registered in the OIDC provider.
+	PostLogoutRedirectUrl string `protobuf:"bytes,13,opt,name=post_logout_redirect_url,json=postLogoutRedirectUrl,proto3" json:"post_logout_redirect_url,omitempty"`
+	// Store the tokens in the server side. Only an opaque session ID is kept in the cookie, so that
+	// the requests are smaller and the sessions can be revoked.
+	SessionStore *SessionStore `protobuf:"bytes,14,opt,name=session_store,json=sessionStore,proto3" json:"session_store,omitempty"`
+	// Pass the claims of the ID token or the userinfo to the upstream via the request headers, so
+	// the upstream can get the identity without parsing the tokens. The headers are removed from the
+	// request if the claims don't exist.
+	ClaimsToHeaders []*ClaimToHeader `protobuf:"bytes,15,rep,name=claims_to_headers,json=claimsToHeaders,proto3" json:"claims_to_headers,omitempty"`
+	// Fetch the claims from the userinfo endpoint after login, and again after the tokens are
+	// refreshed. The claims from the userinfo endpoint take precedence over the ones in the ID token.
+	// Only the claims in the `claims_to_headers` and the `authorization` are kept. In the bearer token
+	// mode, the userinfo is fetched with the bearer token.
+	FetchUserinfo bool `protobuf:"varint,16,opt,name=fetch_userinfo,json=fetchUserinfo,proto3" json:"fetch_userinfo,omitempty"`
+	// Validate the bearer token in the `Authorization` header against the JWKS of the OIDC provider,
+	// instead of redirecting the client to log in. It allows the same route to serve both browsers
+	// and API clients.
+	BearerToken *BearerToken `protobuf:"bytes,17,opt,name=bearer_token,json=bearerToken,proto3" json:"bearer_token,omitempty"`
+	// Configure multiple OIDC providers in one plugin. The first provider whose `match` matches
+	// the request is used. If none of them matches, the provider configured in the top-level
+	// fields is used.
+	Providers []*Provider `protobuf:"bytes,18,rep,name=providers,proto3" json:"providers,omitempty"`
+	// The header which carries the tenant ID to select the provider. It should be set by a trusted
+	// component, as it decides which provider the client logs in with. Default to "x-tenant-id".
+	TenantHeader string `protobuf:"bytes,19,opt,name=tenant_header,json=tenantHeader,proto3" json:"tenant_header,omitempty"`
+	// When the OIDC provider can't be discovered, like the issuer is unreachable, the discovery is
+	// retried in the background with exponential backoff, and the requests are rejected with the
+	// `unavailable_response` until the discovery succeeds. This is the max backoff between the
+	// retries. Default to 60s.
+	DiscoveryMaxBackoff *durationpb.Duration `protobuf:"bytes,20,opt,name=discovery_max_backoff,json=discoveryMaxBackoff,proto3" json:"discovery_max_backoff,omitempty"`
+	// The response returned when the OIDC provider is not discovered yet. Default to 503 with the
+	// message "OIDC provider is unavailable".
+	UnavailableResponse *CustomResponse `protobuf:"bytes,21,opt,name=unavailable_response,json=unavailableResponse,proto3" json:"unavailable_response,omitempty"`
+	// Discover the OIDC provider again periodically to refresh the metadata and the JWKS. By default,
+	// the JWKS is only fetched again when a token signed by an unknown key is received.
+	JwksRefreshInterval *durationpb.Duration `protobuf:"bytes,22,opt,name=jwks_refresh_interval,json=jwksRefreshInterval,proto3" json:"jwks_refresh_interval,omitempty"`
+	// Authorize the authenticated requests with the claims of the ID token, the userinfo or the
+	// bearer token.
+	Authorization *Authorization `protobuf:"bytes,23,opt,name=authorization,proto3" json:"authorization,omitempty"`
+	// Don't forward the access token, or the exchanged token if `token_exchange` is configured,
+	// to the upstream via the `Authorization` header.
+	DisableAccessTokenForwarding bool `protobuf:"varint,24,opt,name=disable_access_token_forwarding,json=disableAccessTokenForwarding,proto3" json:"disable_access_token_forwarding,omitempty"`
+	// Exchange the access token for a token scoped to the upstream via OAuth 2.0 Token Exchange
+	// (RFC 8693), and forward the exchanged token instead, so the upstream can call further APIs on
+	// behalf of the user.
+	TokenExchange *TokenExchange `protobuf:"bytes,25,opt,name=token_exchange,json=tokenExchange,proto3" json:"token_exchange,omitempty"`
+	// The attributes of the cookies set by the plugin.
+	Cookie *Cookie `protobuf:"bytes,26,opt,name=cookie,proto3" json:"cookie,omitempty"`
+	// Skip the authentication for the requests which match any of the rules, like the health checks,
+	// the webhooks and the static assets.
+	SkipAuthPaths []*SkipAuthPath `protobuf:"bytes,27,rep,name=skip_auth_paths,json=skipAuthPaths,proto3" json:"skip_auth_paths,omitempty"`
+	// The userinfo fetched with the bearer token is cached in memory for this duration, and at most
+	// until the token expires. Default to 5 minutes.
+	UserinfoCacheTtl *durationpb.Duration `protobuf:"bytes,28,opt,name=userinfo_cache_ttl,json=userinfoCacheTtl,proto3" json:"userinfo_cache_ttl,omitempty"`
+	// The max duration between redirecting the user to log in and receiving the callback. The
+	// callback is rejected after it. Default to 1 hour.
+	AuthFlowTimeout *durationpb.Duration `protobuf:"bytes,29,opt,name=auth_flow_timeout,json=authFlowTimeout,proto3" json:"auth_flow_timeout,omitempty"`
+	// The path to receive the logout tokens from the OIDC provider, as described in
+	// https://openid.net/specs/openid-connect-backchannel-1_0.html. The sessions identified by
+	// the logout token are revoked. It requires the `session_store`.
+	BackchannelLogoutPath string `protobuf:"bytes,30,opt,name=backchannel_logout_path,json=backchannelLogoutPath,proto3" json:"backchannel_logout_path,omitempty"`
 }
 
 func (x *Config) Reset() {
@@ -167,111 +345,1536 @@ func (x *Config) GetAccessTokenRefreshLeeway() *durationpb.Duration {
 	return nil
 }
 
-var File_types_plugins_oidc_config_proto protoreflect.FileDescriptor
+func (x *Config) GetRefreshGracePeriod() *durationpb.Duration {
+	if x != nil {
+		return x.RefreshGracePeriod
+	}
+	return nil
+}
 
-var file_types_plugins_oidc_config_proto_rawDesc = []byte{
-	0x0a, 0x1f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f,
-	0x6f, 0x69, 0x64, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x12, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73,
-	0x2e, 0x6f, 0x69, 0x64, 0x63, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f,
-	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfb,
-	0x03, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x0a, 0x09, 0x63, 0x6c, 0x69,
-	0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42,
-	0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
-	0x2c, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52,
-	0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x20, 0x0a,
-	0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa,
-	0x42, 0x05, 0x72, 0x03, 0x88, 0x01, 0x01, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12,
-	0x2b, 0x0a, 0x0c, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x88, 0x01, 0x01, 0x52,
-	0x0b, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x55, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63,
-	0x6f, 0x70, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x6e, 0x6f, 0x6e,
-	0x63, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0f, 0x73, 0x6b, 0x69, 0x70, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
-	0x12, 0x26, 0x0a, 0x0f, 0x69, 0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x68, 0x65, 0x61,
-	0x64, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x64, 0x54, 0x6f, 0x6b,
-	0x65, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65,
-	0x6f, 0x75, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x07,
-	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3f, 0x0a, 0x1c, 0x64, 0x69, 0x73, 0x61, 0x62,
-	0x6c, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f,
-	0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x19, 0x64,
-	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x62, 0x0a, 0x1b, 0x61, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
-	0x5f, 0x6c, 0x65, 0x65, 0x77, 0x61, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02,
-	0x32, 0x00, 0x52, 0x18, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52,
-	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4c, 0x65, 0x65, 0x77, 0x61, 0x79, 0x42, 0x21, 0x5a, 0x1f,
-	0x6d, 0x6f, 0x73, 0x6e, 0x2e, 0x69, 0x6f, 0x2f, 0x68, 0x74, 0x6e, 0x6e, 0x2f, 0x74, 0x79, 0x70,
-	0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x6f, 0x69, 0x64, 0x63, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *Config) GetLogoutPath() string {
+	if x != nil {
+		return x.LogoutPath
+	}
+	return ""
 }
 
-var (
-	file_types_plugins_oidc_config_proto_rawDescOnce sync.Once
-	file_types_plugins_oidc_config_proto_rawDescData = file_types_plugins_oidc_config_proto_rawDesc
-)
+func (x *Config) GetPostLogoutRedirectUrl() string {
+	if x != nil {
+		return x.PostLogoutRedirectUrl
+	}
+	return ""
+}
 
-func file_types_plugins_oidc_config_proto_rawDescGZIP() []byte {
-	file_types_plugins_oidc_config_proto_rawDescOnce.Do(func() {
-		file_types_plugins_oidc_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_types_plugins_oidc_config_proto_rawDescData)
-	})
-	return file_types_plugins_oidc_config_proto_rawDescData
+func (x *Config) GetSessionStore() *SessionStore {
+	if x != nil {
+		return x.SessionStore
+	}
+	return nil
 }
 
-var file_types_plugins_oidc_config_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
-var file_types_plugins_oidc_config_proto_goTypes = []interface{}{
-	(*Config)(nil),              // 0: types.plugins.oidc.Config
-	(*durationpb.Duration)(nil), // 1: google.protobuf.Duration
+func (x *Config) GetClaimsToHeaders() []*ClaimToHeader {
+	if x != nil {
+		return x.ClaimsToHeaders
+	}
+	return nil
 }
-var file_types_plugins_oidc_config_proto_depIdxs = []int32{
-	1, // 0: types.plugins.oidc.Config.timeout:type_name -> google.protobuf.Duration
-	1, // 1: types.plugins.oidc.Config.access_token_refresh_leeway:type_name -> google.protobuf.Duration
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+func (x *Config) GetFetchUserinfo() bool {
+	if x != nil {
+		return x.FetchUserinfo
+	}
+	return false
 }
 
-func init() { file_types_plugins_oidc_config_proto_init() }
-func file_types_plugins_oidc_config_proto_init() {
-	if File_types_plugins_oidc_config_proto != nil {
-		return
+func (x *Config) GetBearerToken() *BearerToken {
+	if x != nil {
+		return x.BearerToken
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_types_plugins_oidc_config_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Config); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+func (x *Config) GetProviders() []*Provider {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+func (x *Config) GetTenantHeader() string {
+	if x != nil {
+		return x.TenantHeader
+	}
+	return ""
+}
+
+func (x *Config) GetDiscoveryMaxBackoff() *durationpb.Duration {
+	if x != nil {
+		return x.DiscoveryMaxBackoff
+	}
+	return nil
+}
+
+func (x *Config) GetUnavailableResponse() *CustomResponse {
+	if x != nil {
+		return x.UnavailableResponse
+	}
+	return nil
+}
+
+func (x *Config) GetJwksRefreshInterval() *durationpb.Duration {
+	if x != nil {
+		return x.JwksRefreshInterval
+	}
+	return nil
+}
+
+func (x *Config) GetAuthorization() *Authorization {
+	if x != nil {
+		return x.Authorization
+	}
+	return nil
+}
+
+func (x *Config) GetDisableAccessTokenForwarding() bool {
+	if x != nil {
+		return x.DisableAccessTokenForwarding
+	}
+	return false
+}
+
+func (x *Config) GetTokenExchange() *TokenExchange {
+	if x != nil {
+		return x.TokenExchange
+	}
+	return nil
+}
+
+func (x *Config) GetCookie() *Cookie {
+	if x != nil {
+		return x.Cookie
+	}
+	return nil
+}
+
+func (x *Config) GetSkipAuthPaths() []*SkipAuthPath {
+	if x != nil {
+		return x.SkipAuthPaths
+	}
+	return nil
+}
+
+func (x *Config) GetUserinfoCacheTtl() *durationpb.Duration {
+	if x != nil {
+		return x.UserinfoCacheTtl
+	}
+	return nil
+}
+
+func (x *Config) GetAuthFlowTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.AuthFlowTimeout
+	}
+	return nil
+}
+
+func (x *Config) GetBackchannelLogoutPath() string {
+	if x != nil {
+		return x.BackchannelLogoutPath
+	}
+	return ""
+}
+
+type SkipAuthPath struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Match the path of the request, without the query string. All paths are matched if not set.
+	Path *v1.StringMatcher `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Match the method of the request, like "GET". All methods are matched if not set.
+	Methods []string `protobuf:"bytes,2,rep,name=methods,proto3" json:"methods,omitempty"`
+}
+
+func (x *SkipAuthPath) Reset() {
+	*x = SkipAuthPath{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SkipAuthPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkipAuthPath) ProtoMessage() {}
+
+func (x *SkipAuthPath) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkipAuthPath.ProtoReflect.Descriptor instead.
+func (*SkipAuthPath) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SkipAuthPath) GetPath() *v1.StringMatcher {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *SkipAuthPath) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+type Cookie struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The prefix of the cookie names. The cookie names are like "{prefix}_token_{id}". Default to
+	// "htnn_oidc".
+	NamePrefix string          `protobuf:"bytes,1,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	Domain     string          `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	Path       string          `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	SameSite   Cookie_SameSite `protobuf:"varint,4,opt,name=same_site,json=sameSite,proto3,enum=types.plugins.oidc.Cookie_SameSite" json:"same_site,omitempty"`
+	Secure     bool            `protobuf:"varint,5,opt,name=secure,proto3" json:"secure,omitempty"`
+	// The max age of the cookie which keeps the tokens. By default, it is calculated from the
+	// expiry of the tokens.
+	MaxAge *durationpb.Duration `protobuf:"bytes,6,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+}
+
+func (x *Cookie) Reset() {
+	*x = Cookie{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cookie) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cookie) ProtoMessage() {}
+
+func (x *Cookie) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cookie.ProtoReflect.Descriptor instead.
+func (*Cookie) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Cookie) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *Cookie) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *Cookie) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Cookie) GetSameSite() Cookie_SameSite {
+	if x != nil {
+		return x.SameSite
+	}
+	return Cookie_DEFAULT
+}
+
+func (x *Cookie) GetSecure() bool {
+	if x != nil {
+		return x.Secure
+	}
+	return false
+}
+
+func (x *Cookie) GetMaxAge() *durationpb.Duration {
+	if x != nil {
+		return x.MaxAge
+	}
+	return nil
+}
+
+type TokenExchange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The endpoint to exchange the token. Default to the token endpoint of the OIDC provider.
+	TokenEndpoint string `protobuf:"bytes,1,opt,name=token_endpoint,json=tokenEndpoint,proto3" json:"token_endpoint,omitempty"`
+	// The logical names of the target services, sent as the `audience` parameters.
+	Audiences []string `protobuf:"bytes,2,rep,name=audiences,proto3" json:"audiences,omitempty"`
+	// The URIs of the target services, sent as the `resource` parameters.
+	Resources []string `protobuf:"bytes,3,rep,name=resources,proto3" json:"resources,omitempty"`
+	Scopes    []string `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// The type of the requested token. Default to "urn:ietf:params:oauth:token-type:access_token".
+	RequestedTokenType string `protobuf:"bytes,5,opt,name=requested_token_type,json=requestedTokenType,proto3" json:"requested_token_type,omitempty"`
+}
+
+func (x *TokenExchange) Reset() {
+	*x = TokenExchange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TokenExchange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenExchange) ProtoMessage() {}
+
+func (x *TokenExchange) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenExchange.ProtoReflect.Descriptor instead.
+func (*TokenExchange) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TokenExchange) GetTokenEndpoint() string {
+	if x != nil {
+		return x.TokenEndpoint
+	}
+	return ""
+}
+
+func (x *TokenExchange) GetAudiences() []string {
+	if x != nil {
+		return x.Audiences
+	}
+	return nil
+}
+
+func (x *TokenExchange) GetResources() []string {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *TokenExchange) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *TokenExchange) GetRequestedTokenType() string {
+	if x != nil {
+		return x.RequestedTokenType
+	}
+	return ""
+}
+
+type Authorization struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The request is denied if any of the deny rules matches.
+	Deny []*ClaimRule `protobuf:"bytes,1,rep,name=deny,proto3" json:"deny,omitempty"`
+	// When the allow rules are configured, the request is allowed only if one of them matches.
+	// The deny rules take precedence over the allow rules.
+	Allow []*ClaimRule `protobuf:"bytes,2,rep,name=allow,proto3" json:"allow,omitempty"`
+	// The response returned when the request is denied. Default to 403 with the message
+	// "access denied".
+	DeniedResponse *CustomResponse `protobuf:"bytes,3,opt,name=denied_response,json=deniedResponse,proto3" json:"denied_response,omitempty"`
+}
+
+func (x *Authorization) Reset() {
+	*x = Authorization{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Authorization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Authorization) ProtoMessage() {}
+
+func (x *Authorization) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Authorization.ProtoReflect.Descriptor instead.
+func (*Authorization) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Authorization) GetDeny() []*ClaimRule {
+	if x != nil {
+		return x.Deny
+	}
+	return nil
+}
+
+func (x *Authorization) GetAllow() []*ClaimRule {
+	if x != nil {
+		return x.Allow
+	}
+	return nil
+}
+
+func (x *Authorization) GetDeniedResponse() *CustomResponse {
+	if x != nil {
+		return x.DeniedResponse
+	}
+	return nil
+}
+
+type ClaimRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the claim, like "email". Use "." to access the nested claim.
+	Claim string `protobuf:"bytes,1,opt,name=claim,proto3" json:"claim,omitempty"`
+	// The rule matches if the value of the claim matches. If the claim is an array, like "groups",
+	// the rule matches if any of its elements matches. The rule doesn't match if the claim doesn't
+	// exist.
+	Value *v1.StringMatcher `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ClaimRule) Reset() {
+	*x = ClaimRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaimRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimRule) ProtoMessage() {}
+
+func (x *ClaimRule) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimRule.ProtoReflect.Descriptor instead.
+func (*ClaimRule) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ClaimRule) GetClaim() string {
+	if x != nil {
+		return x.Claim
+	}
+	return ""
+}
+
+func (x *ClaimRule) GetValue() *v1.StringMatcher {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type CustomResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Default to 503 for the `unavailable_response`, and 403 for the `denied_response`.
+	StatusCode uint32            `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Headers    map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *CustomResponse) Reset() {
+	*x = CustomResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CustomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomResponse) ProtoMessage() {}
+
+func (x *CustomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomResponse.ProtoReflect.Descriptor instead.
+func (*CustomResponse) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CustomResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CustomResponse) GetStatusCode() uint32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *CustomResponse) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+type BearerToken struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The audiences which the token is issued for. The token is accepted if its `aud` claim contains
+	// one of them. Default to the client_id.
+	Audiences []string `protobuf:"bytes,1,rep,name=audiences,proto3" json:"audiences,omitempty"`
+	// The path prefixes which only accept the bearer token. The requests to these paths are never
+	// redirected to log in, and the ones without a valid bearer token are rejected with 401.
+	// For other paths, the requests without the bearer token go through the redirect flow.
+	PathPrefixes []string `protobuf:"bytes,2,rep,name=path_prefixes,json=pathPrefixes,proto3" json:"path_prefixes,omitempty"`
+}
+
+func (x *BearerToken) Reset() {
+	*x = BearerToken{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BearerToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BearerToken) ProtoMessage() {}
+
+func (x *BearerToken) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BearerToken.ProtoReflect.Descriptor instead.
+func (*BearerToken) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BearerToken) GetAudiences() []string {
+	if x != nil {
+		return x.Audiences
+	}
+	return nil
+}
+
+func (x *BearerToken) GetPathPrefixes() []string {
+	if x != nil {
+		return x.PathPrefixes
+	}
+	return nil
+}
+
+type Provider struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Match        *ProviderMatch `protobuf:"bytes,1,opt,name=match,proto3" json:"match,omitempty"`
+	ClientId     string         `protobuf:"bytes,2,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret string         `protobuf:"bytes,3,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"`
+	Issuer       string         `protobuf:"bytes,4,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	RedirectUrl  string         `protobuf:"bytes,5,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	// Default to the top-level scopes
+	Scopes []string `protobuf:"bytes,6,rep,name=scopes,proto3" json:"scopes,omitempty"`
+}
+
+func (x *Provider) Reset() {
+	*x = Provider{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Provider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Provider) ProtoMessage() {}
+
+func (x *Provider) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Provider.ProtoReflect.Descriptor instead.
+func (*Provider) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Provider) GetMatch() *ProviderMatch {
+	if x != nil {
+		return x.Match
+	}
+	return nil
+}
+
+func (x *Provider) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *Provider) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+func (x *Provider) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *Provider) GetRedirectUrl() string {
+	if x != nil {
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+func (x *Provider) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+// The request matches if all the configured conditions match.
+type ProviderMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Match the host of the request, case-insensitive.
+	Host string `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	// Match the prefix of the request path.
+	PathPrefix string `protobuf:"bytes,2,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"`
+	// Match the value of the tenant header.
+	Tenant string `protobuf:"bytes,3,opt,name=tenant,proto3" json:"tenant,omitempty"`
+}
+
+func (x *ProviderMatch) Reset() {
+	*x = ProviderMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProviderMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProviderMatch) ProtoMessage() {}
+
+func (x *ProviderMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProviderMatch.ProtoReflect.Descriptor instead.
+func (*ProviderMatch) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ProviderMatch) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ProviderMatch) GetPathPrefix() string {
+	if x != nil {
+		return x.PathPrefix
+	}
+	return ""
+}
+
+func (x *ProviderMatch) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type ClaimToHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The name of the claim, like "email". Use "." to access the nested claim, like
+	// "address.country".
+	Claim    string                 `protobuf:"bytes,1,opt,name=claim,proto3" json:"claim,omitempty"`
+	Header   string                 `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	Encoding ClaimToHeader_Encoding `protobuf:"varint,3,opt,name=encoding,proto3,enum=types.plugins.oidc.ClaimToHeader_Encoding" json:"encoding,omitempty"`
+}
+
+func (x *ClaimToHeader) Reset() {
+	*x = ClaimToHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaimToHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimToHeader) ProtoMessage() {}
+
+func (x *ClaimToHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimToHeader.ProtoReflect.Descriptor instead.
+func (*ClaimToHeader) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ClaimToHeader) GetClaim() string {
+	if x != nil {
+		return x.Claim
+	}
+	return ""
+}
+
+func (x *ClaimToHeader) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *ClaimToHeader) GetEncoding() ClaimToHeader_Encoding {
+	if x != nil {
+		return x.Encoding
+	}
+	return ClaimToHeader_PLAIN
+}
+
+type SessionStore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Store:
+	//
+	//	*SessionStore_Redis
+	Store isSessionStore_Store `protobuf_oneof:"store"`
+	// The session expires if it is not accessed within the idle timeout. Each access extends the
+	// session, but not beyond the expiry of the tokens. By default, the session is not expired until
+	// the tokens expire.
+	IdleTimeout *durationpb.Duration `protobuf:"bytes,2,opt,name=idle_timeout,json=idleTimeout,proto3" json:"idle_timeout,omitempty"`
+}
+
+func (x *SessionStore) Reset() {
+	*x = SessionStore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionStore) ProtoMessage() {}
+
+func (x *SessionStore) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionStore.ProtoReflect.Descriptor instead.
+func (*SessionStore) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{11}
+}
+
+func (m *SessionStore) GetStore() isSessionStore_Store {
+	if m != nil {
+		return m.Store
+	}
+	return nil
+}
+
+func (x *SessionStore) GetRedis() *RedisSessionStore {
+	if x, ok := x.GetStore().(*SessionStore_Redis); ok {
+		return x.Redis
+	}
+	return nil
+}
+
+func (x *SessionStore) GetIdleTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.IdleTimeout
+	}
+	return nil
+}
+
+type isSessionStore_Store interface {
+	isSessionStore_Store()
+}
+
+type SessionStore_Redis struct {
+	Redis *RedisSessionStore `protobuf:"bytes,1,opt,name=redis,proto3,oneof"`
+}
+
+func (*SessionStore_Redis) isSessionStore_Store() {}
+
+type RedisSessionStore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address       string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Username      string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Tls           bool   `protobuf:"varint,4,opt,name=tls,proto3" json:"tls,omitempty"`
+	TlsSkipVerify bool   `protobuf:"varint,5,opt,name=tls_skip_verify,json=tlsSkipVerify,proto3" json:"tls_skip_verify,omitempty"`
+	// The prefix of the keys stored in Redis. Default to "htnn_oidc".
+	Prefix string `protobuf:"bytes,6,opt,name=prefix,proto3" json:"prefix,omitempty"`
+}
+
+func (x *RedisSessionStore) Reset() {
+	*x = RedisSessionStore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_plugins_oidc_config_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedisSessionStore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedisSessionStore) ProtoMessage() {}
+
+func (x *RedisSessionStore) ProtoReflect() protoreflect.Message {
+	mi := &file_types_plugins_oidc_config_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedisSessionStore.ProtoReflect.Descriptor instead.
+func (*RedisSessionStore) Descriptor() ([]byte, []int) {
+	return file_types_plugins_oidc_config_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RedisSessionStore) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RedisSessionStore) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RedisSessionStore) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *RedisSessionStore) GetTls() bool {
+	if x != nil {
+		return x.Tls
+	}
+	return false
+}
+
+func (x *RedisSessionStore) GetTlsSkipVerify() bool {
+	if x != nil {
+		return x.TlsSkipVerify
+	}
+	return false
+}
+
+func (x *RedisSessionStore) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+var File_types_plugins_oidc_config_proto protoreflect.FileDescriptor
+
+var file_types_plugins_oidc_config_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f,
+	0x6f, 0x69, 0x64, 0x63, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x12, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73,
+	0x2e, 0x6f, 0x69, 0x64, 0x63, 0x1a, 0x22, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xd8, 0x0e, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x0a,
+	0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x0d, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72,
+	0x02, 0x10, 0x01, 0x52, 0x0c, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x12, 0x20, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x88, 0x01, 0x01, 0x52, 0x06, 0x69, 0x73, 0x73,
+	0x75, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x0c, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f,
+	0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03,
+	0x88, 0x01, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x55, 0x72, 0x6c,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x6b, 0x69, 0x70,
+	0x5f, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0f, 0x73, 0x6b, 0x69, 0x70, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x69,
+	0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x07,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02,
+	0x2a, 0x00, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3f, 0x0a, 0x1c, 0x64,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x19, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x62, 0x0a, 0x1b,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x72, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x5f, 0x6c, 0x65, 0x65, 0x77, 0x61, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42,
+	0x05, 0xaa, 0x01, 0x02, 0x32, 0x00, 0x52, 0x18, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x4c, 0x65, 0x65, 0x77, 0x61, 0x79,
+	0x12, 0x55, 0x0a, 0x14, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x67, 0x72, 0x61, 0x63,
+	0x65, 0x5f, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01,
+	0x02, 0x32, 0x00, 0x52, 0x12, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x47, 0x72, 0x61, 0x63,
+	0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x67, 0x6f, 0x75,
+	0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6c, 0x6f,
+	0x67, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x44, 0x0a, 0x18, 0x70, 0x6f, 0x73, 0x74,
+	0x5f, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x5f, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x42, 0x0b, 0xfa, 0x42, 0x08, 0x72,
+	0x06, 0xd0, 0x01, 0x01, 0x88, 0x01, 0x01, 0x52, 0x15, 0x70, 0x6f, 0x73, 0x74, 0x4c, 0x6f, 0x67,
+	0x6f, 0x75, 0x74, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x55, 0x72, 0x6c, 0x12, 0x45,
+	0x0a, 0x0d, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x0c, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x4d, 0x0a, 0x11, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x5f,
+	0x74, 0x6f, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73,
+	0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x54, 0x6f, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x52, 0x0f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73, 0x54, 0x6f, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x65, 0x74, 0x63, 0x68, 0x5f, 0x75, 0x73,
+	0x65, 0x72, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x66, 0x65,
+	0x74, 0x63, 0x68, 0x55, 0x73, 0x65, 0x72, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x42, 0x0a, 0x0c, 0x62,
+	0x65, 0x61, 0x72, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x52, 0x0b, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x3a, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x18, 0x12, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72,
+	0x52, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x74,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x57, 0x0a, 0x15, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x6d, 0x61,
+	0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa,
+	0x01, 0x02, 0x2a, 0x00, 0x52, 0x13, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x4d,
+	0x61, 0x78, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x55, 0x0a, 0x14, 0x75, 0x6e, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x13, 0x75, 0x6e, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x57, 0x0a, 0x15, 0x6a, 0x77, 0x6b, 0x73, 0x5f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa,
+	0x01, 0x02, 0x2a, 0x00, 0x52, 0x13, 0x6a, 0x77, 0x6b, 0x73, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73,
+	0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x47, 0x0a, 0x0d, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73,
+	0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x45, 0x0a, 0x1f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1c, 0x64, 0x69, 0x73,
+	0x61, 0x62, 0x6c, 0x65, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x46,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x48, 0x0a, 0x0e, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x5f, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x45, 0x78, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x0d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x45, 0x78, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x63, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x18, 0x1a, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67,
+	0x69, 0x6e, 0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x43, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x52,
+	0x06, 0x63, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x12, 0x48, 0x0a, 0x0f, 0x73, 0x6b, 0x69, 0x70, 0x5f,
+	0x61, 0x75, 0x74, 0x68, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x1b, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73,
+	0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x53, 0x6b, 0x69, 0x70, 0x41, 0x75, 0x74, 0x68, 0x50, 0x61,
+	0x74, 0x68, 0x52, 0x0d, 0x73, 0x6b, 0x69, 0x70, 0x41, 0x75, 0x74, 0x68, 0x50, 0x61, 0x74, 0x68,
+	0x73, 0x12, 0x51, 0x0a, 0x12, 0x75, 0x73, 0x65, 0x72, 0x69, 0x6e, 0x66, 0x6f, 0x5f, 0x63, 0x61,
+	0x63, 0x68, 0x65, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02,
+	0x2a, 0x00, 0x52, 0x10, 0x75, 0x73, 0x65, 0x72, 0x69, 0x6e, 0x66, 0x6f, 0x43, 0x61, 0x63, 0x68,
+	0x65, 0x54, 0x74, 0x6c, 0x12, 0x4f, 0x0a, 0x11, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x66, 0x6c, 0x6f,
+	0x77, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa,
+	0x01, 0x02, 0x2a, 0x00, 0x52, 0x0f, 0x61, 0x75, 0x74, 0x68, 0x46, 0x6c, 0x6f, 0x77, 0x54, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x62, 0x61, 0x63, 0x6b, 0x63, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x6c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x62, 0x61, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x6e,
+	0x6e, 0x65, 0x6c, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x50, 0x61, 0x74, 0x68, 0x22, 0x77, 0x0a,
+	0x0c, 0x53, 0x6b, 0x69, 0x70, 0x41, 0x75, 0x74, 0x68, 0x50, 0x61, 0x74, 0x68, 0x12, 0x37, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x2e, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x42, 0x14, 0xfa, 0x42, 0x11, 0x92, 0x01, 0x0e, 0x22,
+	0x0c, 0x72, 0x0a, 0x32, 0x08, 0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x5d, 0x2b, 0x24, 0x52, 0x07, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x22, 0xc1, 0x02, 0x0a, 0x06, 0x43, 0x6f, 0x6f, 0x6b, 0x69,
+	0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1a, 0xfa, 0x42, 0x17, 0x72, 0x15, 0x32, 0x10, 0x5e,
+	0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5f, 0x2d, 0x5d, 0x2a, 0x24, 0xd0,
+	0x01, 0x01, 0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x40, 0x0a, 0x09, 0x73, 0x61,
+	0x6d, 0x65, 0x5f, 0x73, 0x69, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x6f, 0x69,
+	0x64, 0x63, 0x2e, 0x43, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x2e, 0x53, 0x61, 0x6d, 0x65, 0x53, 0x69,
+	0x74, 0x65, 0x52, 0x08, 0x73, 0x61, 0x6d, 0x65, 0x53, 0x69, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x65,
+	0x63, 0x75, 0x72, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x41,
+	0x67, 0x65, 0x22, 0x36, 0x0a, 0x08, 0x53, 0x61, 0x6d, 0x65, 0x53, 0x69, 0x74, 0x65, 0x12, 0x0b,
+	0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4c,
+	0x41, 0x58, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x43, 0x54, 0x10, 0x02,
+	0x12, 0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x03, 0x22, 0xc9, 0x01, 0x0a, 0x0d, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x45, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x32, 0x0a, 0x0e,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x0b, 0xfa, 0x42, 0x08, 0x72, 0x06, 0xd0, 0x01, 0x01, 0x88, 0x01,
+	0x01, 0x52, 0x0d, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63,
+	0x6f, 0x70, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x22, 0xc4, 0x01, 0x0a, 0x0d, 0x41, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x04, 0x64, 0x65, 0x6e, 0x79,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x43, 0x6c, 0x61, 0x69,
+	0x6d, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x64, 0x65, 0x6e, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e,
+	0x43, 0x6c, 0x61, 0x69, 0x6d, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x12, 0x4b, 0x0a, 0x0f, 0x64, 0x65, 0x6e, 0x69, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x43,
+	0x75, 0x73, 0x74, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0e, 0x64,
+	0x65, 0x6e, 0x69, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x6f, 0x0a,
+	0x09, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x05, 0x63, 0x6c,
+	0x61, 0x69, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02,
+	0x10, 0x01, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x12, 0x43, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x42, 0x08, 0xfa,
+	0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xd2,
+	0x01, 0x0a, 0x0e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x49, 0x0a, 0x07,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x6f, 0x69,
+	0x64, 0x63, 0x2e, 0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x70, 0x0a, 0x0b, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x2c, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0e, 0xfa, 0x42, 0x0b, 0x92, 0x01, 0x08, 0x22, 0x04, 0x72,
+	0x02, 0x10, 0x01, 0x28, 0x01, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65, 0x73,
+	0x12, 0x33, 0x0a, 0x0d, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x42, 0x0e, 0xfa, 0x42, 0x0b, 0x92, 0x01, 0x08, 0x22,
+	0x04, 0x72, 0x02, 0x10, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x70, 0x61, 0x74, 0x68, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x65, 0x73, 0x22, 0x88, 0x02, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x72, 0x12, 0x41, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x05,
+	0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x24, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10,
+	0x01, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x0d, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x0c, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x20, 0x0a, 0x06, 0x69, 0x73, 0x73,
+	0x75, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03,
+	0x88, 0x01, 0x01, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x0c, 0x72,
+	0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0x88, 0x01, 0x01, 0x52, 0x0b, 0x72, 0x65, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x55, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73,
+	0x22, 0x5c, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x74, 0x68,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x22, 0xc4,
+	0x01, 0x0a, 0x0d, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x54, 0x6f, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x1d, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x12,
+	0x1f, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x46, 0x0a, 0x08, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x54, 0x6f, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x08,
+	0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x2b, 0x0a, 0x08, 0x45, 0x6e, 0x63, 0x6f,
+	0x64, 0x69, 0x6e, 0x67, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x4c, 0x41, 0x49, 0x4e, 0x10, 0x00, 0x12,
+	0x0a, 0x0a, 0x06, 0x42, 0x41, 0x53, 0x45, 0x36, 0x34, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4a,
+	0x53, 0x4f, 0x4e, 0x10, 0x02, 0x22, 0xa3, 0x01, 0x0a, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x3d, 0x0a, 0x05, 0x72, 0x65, 0x64, 0x69, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x73, 0x2e, 0x6f, 0x69, 0x64, 0x63, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x73,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x48, 0x00, 0x52, 0x05,
+	0x72, 0x65, 0x64, 0x69, 0x73, 0x12, 0x46, 0x0a, 0x0c, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x08, 0xfa, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x2a, 0x00,
+	0x52, 0x0b, 0x69, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x42, 0x0c, 0x0a,
+	0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x03, 0xf8, 0x42, 0x01, 0x22, 0xc0, 0x01, 0x0a, 0x11,
+	0x52, 0x65, 0x64, 0x69, 0x73, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x12, 0x21, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x74, 0x6c, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x74, 0x6c, 0x73, 0x5f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x74, 0x6c, 0x73, 0x53, 0x6b, 0x69, 0x70,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x42, 0x21,
+	0x5a, 0x1f, 0x6d, 0x6f, 0x73, 0x6e, 0x2e, 0x69, 0x6f, 0x2f, 0x68, 0x74, 0x6e, 0x6e, 0x2f, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2f, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x73, 0x2f, 0x6f, 0x69, 0x64,
+	0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_types_plugins_oidc_config_proto_rawDescOnce sync.Once
+	file_types_plugins_oidc_config_proto_rawDescData = file_types_plugins_oidc_config_proto_rawDesc
+)
+
+func file_types_plugins_oidc_config_proto_rawDescGZIP() []byte {
+	file_types_plugins_oidc_config_proto_rawDescOnce.Do(func() {
+		file_types_plugins_oidc_config_proto_rawDescData = protoimpl.X.CompressGZIP(file_types_plugins_oidc_config_proto_rawDescData)
+	})
+	return file_types_plugins_oidc_config_proto_rawDescData
+}
+
+var file_types_plugins_oidc_config_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_types_plugins_oidc_config_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_types_plugins_oidc_config_proto_goTypes = []interface{}{
+	(Cookie_SameSite)(0),        // 0: types.plugins.oidc.Cookie.SameSite
+	(ClaimToHeader_Encoding)(0), // 1: types.plugins.oidc.ClaimToHeader.Encoding
+	(*Config)(nil),              // 2: types.plugins.oidc.Config
+	(*SkipAuthPath)(nil),        // 3: types.plugins.oidc.SkipAuthPath
+	(*Cookie)(nil),              // 4: types.plugins.oidc.Cookie
+	(*TokenExchange)(nil),       // 5: types.plugins.oidc.TokenExchange
+	(*Authorization)(nil),       // 6: types.plugins.oidc.Authorization
+	(*ClaimRule)(nil),           // 7: types.plugins.oidc.ClaimRule
+	(*CustomResponse)(nil),      // 8: types.plugins.oidc.CustomResponse
+	(*BearerToken)(nil),         // 9: types.plugins.oidc.BearerToken
+	(*Provider)(nil),            // 10: types.plugins.oidc.Provider
+	(*ProviderMatch)(nil),       // 11: types.plugins.oidc.ProviderMatch
+	(*ClaimToHeader)(nil),       // 12: types.plugins.oidc.ClaimToHeader
+	(*SessionStore)(nil),        // 13: types.plugins.oidc.SessionStore
+	(*RedisSessionStore)(nil),   // 14: types.plugins.oidc.RedisSessionStore
+	nil,                         // 15: types.plugins.oidc.CustomResponse.HeadersEntry
+	(*durationpb.Duration)(nil), // 16: google.protobuf.Duration
+	(*v1.StringMatcher)(nil),    // 17: types.plugins.api.v1.StringMatcher
+}
+var file_types_plugins_oidc_config_proto_depIdxs = []int32{
+	16, // 0: types.plugins.oidc.Config.timeout:type_name -> google.protobuf.Duration
+	16, // 1: types.plugins.oidc.Config.access_token_refresh_leeway:type_name -> google.protobuf.Duration
+	16, // 2: types.plugins.oidc.Config.refresh_grace_period:type_name -> google.protobuf.Duration
+	13, // 3: types.plugins.oidc.Config.session_store:type_name -> types.plugins.oidc.SessionStore
+	12, // 4: types.plugins.oidc.Config.claims_to_headers:type_name -> types.plugins.oidc.ClaimToHeader
+	9,  // 5: types.plugins.oidc.Config.bearer_token:type_name -> types.plugins.oidc.BearerToken
+	10, // 6: types.plugins.oidc.Config.providers:type_name -> types.plugins.oidc.Provider
+	16, // 7: types.plugins.oidc.Config.discovery_max_backoff:type_name -> google.protobuf.Duration
+	8,  // 8: types.plugins.oidc.Config.unavailable_response:type_name -> types.plugins.oidc.CustomResponse
+	16, // 9: types.plugins.oidc.Config.jwks_refresh_interval:type_name -> google.protobuf.Duration
+	6,  // 10: types.plugins.oidc.Config.authorization:type_name -> types.plugins.oidc.Authorization
+	5,  // 11: types.plugins.oidc.Config.token_exchange:type_name -> types.plugins.oidc.TokenExchange
+	4,  // 12: types.plugins.oidc.Config.cookie:type_name -> types.plugins.oidc.Cookie
+	3,  // 13: types.plugins.oidc.Config.skip_auth_paths:type_name -> types.plugins.oidc.SkipAuthPath
+	16, // 14: types.plugins.oidc.Config.userinfo_cache_ttl:type_name -> google.protobuf.Duration
+	16, // 15: types.plugins.oidc.Config.auth_flow_timeout:type_name -> google.protobuf.Duration
+	17, // 16: types.plugins.oidc.SkipAuthPath.path:type_name -> types.plugins.api.v1.StringMatcher
+	0,  // 17: types.plugins.oidc.Cookie.same_site:type_name -> types.plugins.oidc.Cookie.SameSite
+	16, // 18: types.plugins.oidc.Cookie.max_age:type_name -> google.protobuf.Duration
+	7,  // 19: types.plugins.oidc.Authorization.deny:type_name -> types.plugins.oidc.ClaimRule
+	7,  // 20: types.plugins.oidc.Authorization.allow:type_name -> types.plugins.oidc.ClaimRule
+	8,  // 21: types.plugins.oidc.Authorization.denied_response:type_name -> types.plugins.oidc.CustomResponse
+	17, // 22: types.plugins.oidc.ClaimRule.value:type_name -> types.plugins.api.v1.StringMatcher
+	15, // 23: types.plugins.oidc.CustomResponse.headers:type_name -> types.plugins.oidc.CustomResponse.HeadersEntry
+	11, // 24: types.plugins.oidc.Provider.match:type_name -> types.plugins.oidc.ProviderMatch
+	1,  // 25: types.plugins.oidc.ClaimToHeader.encoding:type_name -> types.plugins.oidc.ClaimToHeader.Encoding
+	14, // 26: types.plugins.oidc.SessionStore.redis:type_name -> types.plugins.oidc.RedisSessionStore
+	16, // 27: types.plugins.oidc.SessionStore.idle_timeout:type_name -> google.protobuf.Duration
+	28, // [28:28] is the sub-list for method output_type
+	28, // [28:28] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_types_plugins_oidc_config_proto_init() }
+func file_types_plugins_oidc_config_proto_init() {
+	if File_types_plugins_oidc_config_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_types_plugins_oidc_config_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Config); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SkipAuthPath); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cookie); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokenExchange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Authorization); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClaimRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CustomResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BearerToken); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Provider); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProviderMatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClaimToHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_plugins_oidc_config_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RedisSessionStore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_types_plugins_oidc_config_proto_msgTypes[11].OneofWrappers = []interface{}{
+		(*SessionStore_Redis)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_types_plugins_oidc_config_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   1,
+			NumEnums:      2,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_types_plugins_oidc_config_proto_goTypes,
 		DependencyIndexes: file_types_plugins_oidc_config_proto_depIdxs,
+		EnumInfos:         file_types_plugins_oidc_config_proto_enumTypes,
 		MessageInfos:      file_types_plugins_oidc_config_proto_msgTypes,
 	}.Build()
 	File_types_plugins_oidc_config_proto = out.File
@@ -186,6 +186,467 @@ func (m *Config) validate(all bool) error {
 		}
 	}
 
+	if d := m.GetRefreshGracePeriod(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = ConfigValidationError{
+				field:  "RefreshGracePeriod",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gte := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur < gte {
+				err := ConfigValidationError{
+					field:  "RefreshGracePeriod",
+					reason: "value must be greater than or equal to 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	// no validation rules for LogoutPath
+
+	if m.GetPostLogoutRedirectUrl() != "" {
+
+		if uri, err := url.Parse(m.GetPostLogoutRedirectUrl()); err != nil {
+			err = ConfigValidationError{
+				field:  "PostLogoutRedirectUrl",
+				reason: "value must be a valid URI",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else if !uri.IsAbs() {
+			err := ConfigValidationError{
+				field:  "PostLogoutRedirectUrl",
+				reason: "value must be absolute",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetSessionStore()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "SessionStore",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "SessionStore",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSessionStore()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigValidationError{
+				field:  "SessionStore",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetClaimsToHeaders() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConfigValidationError{
+						field:  fmt.Sprintf("ClaimsToHeaders[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConfigValidationError{
+						field:  fmt.Sprintf("ClaimsToHeaders[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ConfigValidationError{
+					field:  fmt.Sprintf("ClaimsToHeaders[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for FetchUserinfo
+
+	if all {
+		switch v := interface{}(m.GetBearerToken()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "BearerToken",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "BearerToken",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetBearerToken()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigValidationError{
+				field:  "BearerToken",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetProviders() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConfigValidationError{
+						field:  fmt.Sprintf("Providers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConfigValidationError{
+						field:  fmt.Sprintf("Providers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ConfigValidationError{
+					field:  fmt.Sprintf("Providers[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for TenantHeader
+
+	if d := m.GetDiscoveryMaxBackoff(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = ConfigValidationError{
+				field:  "DiscoveryMaxBackoff",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := ConfigValidationError{
+					field:  "DiscoveryMaxBackoff",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUnavailableResponse()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "UnavailableResponse",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "UnavailableResponse",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUnavailableResponse()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigValidationError{
+				field:  "UnavailableResponse",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if d := m.GetJwksRefreshInterval(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = ConfigValidationError{
+				field:  "JwksRefreshInterval",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := ConfigValidationError{
+					field:  "JwksRefreshInterval",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetAuthorization()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "Authorization",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "Authorization",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAuthorization()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigValidationError{
+				field:  "Authorization",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for DisableAccessTokenForwarding
+
+	if all {
+		switch v := interface{}(m.GetTokenExchange()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "TokenExchange",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "TokenExchange",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTokenExchange()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigValidationError{
+				field:  "TokenExchange",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetCookie()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "Cookie",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ConfigValidationError{
+					field:  "Cookie",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCookie()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ConfigValidationError{
+				field:  "Cookie",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetSkipAuthPaths() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ConfigValidationError{
+						field:  fmt.Sprintf("SkipAuthPaths[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ConfigValidationError{
+						field:  fmt.Sprintf("SkipAuthPaths[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ConfigValidationError{
+					field:  fmt.Sprintf("SkipAuthPaths[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if d := m.GetUserinfoCacheTtl(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = ConfigValidationError{
+				field:  "UserinfoCacheTtl",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := ConfigValidationError{
+					field:  "UserinfoCacheTtl",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if d := m.GetAuthFlowTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = ConfigValidationError{
+				field:  "AuthFlowTimeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := ConfigValidationError{
+					field:  "AuthFlowTimeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	// no validation rules for BackchannelLogoutPath
+
 	if len(errors) > 0 {
 		return ConfigMultiError(errors)
 	}
@@ -262,3 +723,1766 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = ConfigValidationError{}
+
+// Validate checks the field values on SkipAuthPath with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SkipAuthPath) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SkipAuthPath with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SkipAuthPathMultiError, or
+// nil if none found.
+func (m *SkipAuthPath) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SkipAuthPath) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetPath()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SkipAuthPathValidationError{
+					field:  "Path",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SkipAuthPathValidationError{
+					field:  "Path",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPath()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SkipAuthPathValidationError{
+				field:  "Path",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetMethods() {
+		_, _ = idx, item
+
+		if !_SkipAuthPath_Methods_Pattern.MatchString(item) {
+			err := SkipAuthPathValidationError{
+				field:  fmt.Sprintf("Methods[%v]", idx),
+				reason: "value does not match regex pattern \"^[A-Z]+$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return SkipAuthPathMultiError(errors)
+	}
+
+	return nil
+}
+
+// SkipAuthPathMultiError is an error wrapping multiple validation errors
+// returned by SkipAuthPath.ValidateAll() if the designated constraints aren't met.
+type SkipAuthPathMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SkipAuthPathMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SkipAuthPathMultiError) AllErrors() []error { return m }
+
+// SkipAuthPathValidationError is the validation error returned by
+// SkipAuthPath.Validate if the designated constraints aren't met.
+type SkipAuthPathValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SkipAuthPathValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SkipAuthPathValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SkipAuthPathValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SkipAuthPathValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SkipAuthPathValidationError) ErrorName() string { return "SkipAuthPathValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SkipAuthPathValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSkipAuthPath.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SkipAuthPathValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SkipAuthPathValidationError{}
+
+var _SkipAuthPath_Methods_Pattern = regexp.MustCompile("^[A-Z]+$")
+
+// Validate checks the field values on Cookie with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Cookie) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Cookie with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in CookieMultiError, or nil if none found.
+func (m *Cookie) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Cookie) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetNamePrefix() != "" {
+
+		if !_Cookie_NamePrefix_Pattern.MatchString(m.GetNamePrefix()) {
+			err := CookieValidationError{
+				field:  "NamePrefix",
+				reason: "value does not match regex pattern \"^[A-Za-z0-9_-]*$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Domain
+
+	// no validation rules for Path
+
+	// no validation rules for SameSite
+
+	// no validation rules for Secure
+
+	if d := m.GetMaxAge(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = CookieValidationError{
+				field:  "MaxAge",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := CookieValidationError{
+					field:  "MaxAge",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return CookieMultiError(errors)
+	}
+
+	return nil
+}
+
+// CookieMultiError is an error wrapping multiple validation errors returned by
+// Cookie.ValidateAll() if the designated constraints aren't met.
+type CookieMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CookieMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CookieMultiError) AllErrors() []error { return m }
+
+// CookieValidationError is the validation error returned by Cookie.Validate if
+// the designated constraints aren't met.
+type CookieValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CookieValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CookieValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CookieValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CookieValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CookieValidationError) ErrorName() string { return "CookieValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CookieValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCookie.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CookieValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CookieValidationError{}
+
+var _Cookie_NamePrefix_Pattern = regexp.MustCompile("^[A-Za-z0-9_-]*$")
+
+// Validate checks the field values on TokenExchange with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TokenExchange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TokenExchange with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TokenExchangeMultiError, or
+// nil if none found.
+func (m *TokenExchange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TokenExchange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetTokenEndpoint() != "" {
+
+		if uri, err := url.Parse(m.GetTokenEndpoint()); err != nil {
+			err = TokenExchangeValidationError{
+				field:  "TokenEndpoint",
+				reason: "value must be a valid URI",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else if !uri.IsAbs() {
+			err := TokenExchangeValidationError{
+				field:  "TokenEndpoint",
+				reason: "value must be absolute",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for RequestedTokenType
+
+	if len(errors) > 0 {
+		return TokenExchangeMultiError(errors)
+	}
+
+	return nil
+}
+
+// TokenExchangeMultiError is an error wrapping multiple validation errors
+// returned by TokenExchange.ValidateAll() if the designated constraints
+// aren't met.
+type TokenExchangeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TokenExchangeMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TokenExchangeMultiError) AllErrors() []error { return m }
+
+// TokenExchangeValidationError is the validation error returned by
+// TokenExchange.Validate if the designated constraints aren't met.
+type TokenExchangeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TokenExchangeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TokenExchangeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TokenExchangeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TokenExchangeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TokenExchangeValidationError) ErrorName() string { return "TokenExchangeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TokenExchangeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTokenExchange.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TokenExchangeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TokenExchangeValidationError{}
+
+// Validate checks the field values on Authorization with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Authorization) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Authorization with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AuthorizationMultiError, or
+// nil if none found.
+func (m *Authorization) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Authorization) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetDeny() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, AuthorizationValidationError{
+						field:  fmt.Sprintf("Deny[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, AuthorizationValidationError{
+						field:  fmt.Sprintf("Deny[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AuthorizationValidationError{
+					field:  fmt.Sprintf("Deny[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	for idx, item := range m.GetAllow() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, AuthorizationValidationError{
+						field:  fmt.Sprintf("Allow[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, AuthorizationValidationError{
+						field:  fmt.Sprintf("Allow[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return AuthorizationValidationError{
+					field:  fmt.Sprintf("Allow[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetDeniedResponse()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AuthorizationValidationError{
+					field:  "DeniedResponse",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AuthorizationValidationError{
+					field:  "DeniedResponse",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeniedResponse()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AuthorizationValidationError{
+				field:  "DeniedResponse",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return AuthorizationMultiError(errors)
+	}
+
+	return nil
+}
+
+// AuthorizationMultiError is an error wrapping multiple validation errors
+// returned by Authorization.ValidateAll() if the designated constraints
+// aren't met.
+type AuthorizationMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AuthorizationMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AuthorizationMultiError) AllErrors() []error { return m }
+
+// AuthorizationValidationError is the validation error returned by
+// Authorization.Validate if the designated constraints aren't met.
+type AuthorizationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AuthorizationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AuthorizationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AuthorizationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AuthorizationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AuthorizationValidationError) ErrorName() string { return "AuthorizationValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AuthorizationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAuthorization.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AuthorizationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AuthorizationValidationError{}
+
+// Validate checks the field values on ClaimRule with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ClaimRule) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ClaimRule with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ClaimRuleMultiError, or nil
+// if none found.
+func (m *ClaimRule) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ClaimRule) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetClaim()) < 1 {
+		err := ClaimRuleValidationError{
+			field:  "Claim",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetValue() == nil {
+		err := ClaimRuleValidationError{
+			field:  "Value",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetValue()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ClaimRuleValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ClaimRuleValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ClaimRuleValidationError{
+				field:  "Value",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ClaimRuleMultiError(errors)
+	}
+
+	return nil
+}
+
+// ClaimRuleMultiError is an error wrapping multiple validation errors returned
+// by ClaimRule.ValidateAll() if the designated constraints aren't met.
+type ClaimRuleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ClaimRuleMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ClaimRuleMultiError) AllErrors() []error { return m }
+
+// ClaimRuleValidationError is the validation error returned by
+// ClaimRule.Validate if the designated constraints aren't met.
+type ClaimRuleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ClaimRuleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ClaimRuleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ClaimRuleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ClaimRuleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ClaimRuleValidationError) ErrorName() string { return "ClaimRuleValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ClaimRuleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sClaimRule.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ClaimRuleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ClaimRuleValidationError{}
+
+// Validate checks the field values on CustomResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CustomResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CustomResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CustomResponseMultiError, or nil if none found.
+func (m *CustomResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CustomResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Message
+
+	// no validation rules for StatusCode
+
+	// no validation rules for Headers
+
+	if len(errors) > 0 {
+		return CustomResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CustomResponseMultiError is an error wrapping multiple validation
+// errors returned by CustomResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CustomResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CustomResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CustomResponseMultiError) AllErrors() []error { return m }
+
+// CustomResponseValidationError is the validation error returned by
+// CustomResponse.Validate if the designated constraints aren't met.
+type CustomResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CustomResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CustomResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CustomResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CustomResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CustomResponseValidationError) ErrorName() string {
+	return "CustomResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CustomResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCustomResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CustomResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CustomResponseValidationError{}
+
+// Validate checks the field values on BearerToken with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BearerToken) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BearerToken with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BearerTokenMultiError, or
+// nil if none found.
+func (m *BearerToken) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BearerToken) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetAudiences()) > 0 {
+
+		for idx, item := range m.GetAudiences() {
+			_, _ = idx, item
+
+			if utf8.RuneCountInString(item) < 1 {
+				err := BearerTokenValidationError{
+					field:  fmt.Sprintf("Audiences[%v]", idx),
+					reason: "value length must be at least 1 runes",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+
+	}
+
+	if len(m.GetPathPrefixes()) > 0 {
+
+		for idx, item := range m.GetPathPrefixes() {
+			_, _ = idx, item
+
+			if utf8.RuneCountInString(item) < 1 {
+				err := BearerTokenValidationError{
+					field:  fmt.Sprintf("PathPrefixes[%v]", idx),
+					reason: "value length must be at least 1 runes",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BearerTokenMultiError(errors)
+	}
+
+	return nil
+}
+
+// BearerTokenMultiError is an error wrapping multiple validation errors
+// returned by BearerToken.ValidateAll() if the designated constraints aren't met.
+type BearerTokenMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BearerTokenMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BearerTokenMultiError) AllErrors() []error { return m }
+
+// BearerTokenValidationError is the validation error returned by
+// BearerToken.Validate if the designated constraints aren't met.
+type BearerTokenValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BearerTokenValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BearerTokenValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BearerTokenValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BearerTokenValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BearerTokenValidationError) ErrorName() string { return "BearerTokenValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BearerTokenValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBearerToken.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BearerTokenValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BearerTokenValidationError{}
+
+// Validate checks the field values on Provider with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Provider) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Provider with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ProviderMultiError, or nil
+// if none found.
+func (m *Provider) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Provider) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetMatch() == nil {
+		err := ProviderValidationError{
+			field:  "Match",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetMatch()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ProviderValidationError{
+					field:  "Match",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ProviderValidationError{
+					field:  "Match",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMatch()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ProviderValidationError{
+				field:  "Match",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if utf8.RuneCountInString(m.GetClientId()) < 1 {
+		err := ProviderValidationError{
+			field:  "ClientId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetClientSecret()) < 1 {
+		err := ProviderValidationError{
+			field:  "ClientSecret",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if uri, err := url.Parse(m.GetIssuer()); err != nil {
+		err = ProviderValidationError{
+			field:  "Issuer",
+			reason: "value must be a valid URI",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	} else if !uri.IsAbs() {
+		err := ProviderValidationError{
+			field:  "Issuer",
+			reason: "value must be absolute",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if uri, err := url.Parse(m.GetRedirectUrl()); err != nil {
+		err = ProviderValidationError{
+			field:  "RedirectUrl",
+			reason: "value must be a valid URI",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	} else if !uri.IsAbs() {
+		err := ProviderValidationError{
+			field:  "RedirectUrl",
+			reason: "value must be absolute",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ProviderMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProviderMultiError is an error wrapping multiple validation errors returned
+// by Provider.ValidateAll() if the designated constraints aren't met.
+type ProviderMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProviderMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProviderMultiError) AllErrors() []error { return m }
+
+// ProviderValidationError is the validation error returned by
+// Provider.Validate if the designated constraints aren't met.
+type ProviderValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProviderValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProviderValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProviderValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProviderValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProviderValidationError) ErrorName() string { return "ProviderValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ProviderValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProvider.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProviderValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProviderValidationError{}
+
+// Validate checks the field values on ProviderMatch with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ProviderMatch) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ProviderMatch with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ProviderMatchMultiError, or
+// nil if none found.
+func (m *ProviderMatch) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ProviderMatch) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Host
+
+	// no validation rules for PathPrefix
+
+	// no validation rules for Tenant
+
+	if len(errors) > 0 {
+		return ProviderMatchMultiError(errors)
+	}
+
+	return nil
+}
+
+// ProviderMatchMultiError is an error wrapping multiple validation errors
+// returned by ProviderMatch.ValidateAll() if the designated constraints
+// aren't met.
+type ProviderMatchMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ProviderMatchMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ProviderMatchMultiError) AllErrors() []error { return m }
+
+// ProviderMatchValidationError is the validation error returned by
+// ProviderMatch.Validate if the designated constraints aren't met.
+type ProviderMatchValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ProviderMatchValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ProviderMatchValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ProviderMatchValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ProviderMatchValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ProviderMatchValidationError) ErrorName() string { return "ProviderMatchValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ProviderMatchValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sProviderMatch.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ProviderMatchValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ProviderMatchValidationError{}
+
+// Validate checks the field values on ClaimToHeader with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ClaimToHeader) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ClaimToHeader with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ClaimToHeaderMultiError, or
+// nil if none found.
+func (m *ClaimToHeader) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ClaimToHeader) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetClaim()) < 1 {
+		err := ClaimToHeaderValidationError{
+			field:  "Claim",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetHeader()) < 1 {
+		err := ClaimToHeaderValidationError{
+			field:  "Header",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Encoding
+
+	if len(errors) > 0 {
+		return ClaimToHeaderMultiError(errors)
+	}
+
+	return nil
+}
+
+// ClaimToHeaderMultiError is an error wrapping multiple validation errors
+// returned by ClaimToHeader.ValidateAll() if the designated constraints
+// aren't met.
+type ClaimToHeaderMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ClaimToHeaderMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ClaimToHeaderMultiError) AllErrors() []error { return m }
+
+// ClaimToHeaderValidationError is the validation error returned by
+// ClaimToHeader.Validate if the designated constraints aren't met.
+type ClaimToHeaderValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ClaimToHeaderValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ClaimToHeaderValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ClaimToHeaderValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ClaimToHeaderValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ClaimToHeaderValidationError) ErrorName() string { return "ClaimToHeaderValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ClaimToHeaderValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sClaimToHeader.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ClaimToHeaderValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ClaimToHeaderValidationError{}
+
+// Validate checks the field values on SessionStore with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SessionStore) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SessionStore with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SessionStoreMultiError, or
+// nil if none found.
+func (m *SessionStore) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SessionStore) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if d := m.GetIdleTimeout(); d != nil {
+		dur, err := d.AsDuration(), d.CheckValid()
+		if err != nil {
+			err = SessionStoreValidationError{
+				field:  "IdleTimeout",
+				reason: "value is not a valid duration",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+
+			gt := time.Duration(0*time.Second + 0*time.Nanosecond)
+
+			if dur <= gt {
+				err := SessionStoreValidationError{
+					field:  "IdleTimeout",
+					reason: "value must be greater than 0s",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	oneofStorePresent := false
+	switch v := m.Store.(type) {
+	case *SessionStore_Redis:
+		if v == nil {
+			err := SessionStoreValidationError{
+				field:  "Store",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofStorePresent = true
+
+		if all {
+			switch v := interface{}(m.GetRedis()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SessionStoreValidationError{
+						field:  "Redis",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SessionStoreValidationError{
+						field:  "Redis",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetRedis()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SessionStoreValidationError{
+					field:  "Redis",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofStorePresent {
+		err := SessionStoreValidationError{
+			field:  "Store",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return SessionStoreMultiError(errors)
+	}
+
+	return nil
+}
+
+// SessionStoreMultiError is an error wrapping multiple validation errors
+// returned by SessionStore.ValidateAll() if the designated constraints aren't met.
+type SessionStoreMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SessionStoreMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SessionStoreMultiError) AllErrors() []error { return m }
+
+// SessionStoreValidationError is the validation error returned by
+// SessionStore.Validate if the designated constraints aren't met.
+type SessionStoreValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SessionStoreValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SessionStoreValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SessionStoreValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SessionStoreValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SessionStoreValidationError) ErrorName() string { return "SessionStoreValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SessionStoreValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSessionStore.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SessionStoreValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SessionStoreValidationError{}
+
+// Validate checks the field values on RedisSessionStore with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *RedisSessionStore) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RedisSessionStore with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RedisSessionStoreMultiError, or nil if none found.
+func (m *RedisSessionStore) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RedisSessionStore) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetAddress()) < 1 {
+		err := RedisSessionStoreValidationError{
+			field:  "Address",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Username
+
+	// no validation rules for Password
+
+	// no validation rules for Tls
+
+	// no validation rules for TlsSkipVerify
+
+	// no validation rules for Prefix
+
+	if len(errors) > 0 {
+		return RedisSessionStoreMultiError(errors)
+	}
+
+	return nil
+}
+
+// RedisSessionStoreMultiError is an error wrapping multiple validation errors
+// returned by RedisSessionStore.ValidateAll() if the designated constraints
+// aren't met.
+type RedisSessionStoreMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RedisSessionStoreMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RedisSessionStoreMultiError) AllErrors() []error { return m }
+
+// RedisSessionStoreValidationError is the validation error returned by
+// RedisSessionStore.Validate if the designated constraints aren't met.
+type RedisSessionStoreValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RedisSessionStoreValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RedisSessionStoreValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RedisSessionStoreValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RedisSessionStoreValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RedisSessionStoreValidationError) ErrorName() string {
+	return "RedisSessionStoreValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RedisSessionStoreValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRedisSessionStore.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RedisSessionStoreValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RedisSessionStoreValidationError{}
@@ -118,6 +118,35 @@ func (m *Remote) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetCircuitBreaker()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RemoteValidationError{
+					field:  "CircuitBreaker",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RemoteValidationError{
+					field:  "CircuitBreaker",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCircuitBreaker()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RemoteValidationError{
+				field:  "CircuitBreaker",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return RemoteMultiError(errors)
 	}
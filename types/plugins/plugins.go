@@ -16,26 +16,64 @@ package plugins
 
 import (
 	_ "mosn.io/htnn/types/dynamicconfigs"
+	_ "mosn.io/htnn/types/plugins/abtest"
+	_ "mosn.io/htnn/types/plugins/accesslog"
+	_ "mosn.io/htnn/types/plugins/aicache"
+	_ "mosn.io/htnn/types/plugins/aiproxy"
+	_ "mosn.io/htnn/types/plugins/aitokenlimit"
 	_ "mosn.io/htnn/types/plugins/bandwidthlimit"
 	_ "mosn.io/htnn/types/plugins/buffer"
+	_ "mosn.io/htnn/types/plugins/cache"
+	_ "mosn.io/htnn/types/plugins/canary"
 	_ "mosn.io/htnn/types/plugins/casbin"
 	_ "mosn.io/htnn/types/plugins/celscript"
+	_ "mosn.io/htnn/types/plugins/circuitbreaker"
+	_ "mosn.io/htnn/types/plugins/compression"
 	_ "mosn.io/htnn/types/plugins/consumerrestriction"
+	_ "mosn.io/htnn/types/plugins/correlationid"
 	_ "mosn.io/htnn/types/plugins/cors"
+	_ "mosn.io/htnn/types/plugins/csrf"
 	_ "mosn.io/htnn/types/plugins/debugmode"
 	_ "mosn.io/htnn/types/plugins/demo"
+	_ "mosn.io/htnn/types/plugins/dubboproxy"
 	_ "mosn.io/htnn/types/plugins/extauth"
 	_ "mosn.io/htnn/types/plugins/extproc"
 	_ "mosn.io/htnn/types/plugins/fault"
+	_ "mosn.io/htnn/types/plugins/filescan"
+	_ "mosn.io/htnn/types/plugins/fingerprint"
+	_ "mosn.io/htnn/types/plugins/graphql"
 	_ "mosn.io/htnn/types/plugins/hmacauth"
+	_ "mosn.io/htnn/types/plugins/idempotency"
+	_ "mosn.io/htnn/types/plugins/iprestriction"
+	_ "mosn.io/htnn/types/plugins/jsonminify"
+	_ "mosn.io/htnn/types/plugins/jwtissuer"
+	_ "mosn.io/htnn/types/plugins/kafkaevent"
 	_ "mosn.io/htnn/types/plugins/keyauth"
 	_ "mosn.io/htnn/types/plugins/limitcountredis"
 	_ "mosn.io/htnn/types/plugins/limitreq"
 	_ "mosn.io/htnn/types/plugins/listenerpatch"
 	_ "mosn.io/htnn/types/plugins/localratelimit"
 	_ "mosn.io/htnn/types/plugins/lua"
+	_ "mosn.io/htnn/types/plugins/maintenance"
+	_ "mosn.io/htnn/types/plugins/methodoverride"
+	_ "mosn.io/htnn/types/plugins/metrics"
+	_ "mosn.io/htnn/types/plugins/mirror"
+	_ "mosn.io/htnn/types/plugins/mock"
 	_ "mosn.io/htnn/types/plugins/networkrbac"
 	_ "mosn.io/htnn/types/plugins/oidc"
 	_ "mosn.io/htnn/types/plugins/opa"
+	_ "mosn.io/htnn/types/plugins/redirect"
+	_ "mosn.io/htnn/types/plugins/requestdecompression"
+	_ "mosn.io/htnn/types/plugins/requesthardening"
+	_ "mosn.io/htnn/types/plugins/responsesigning"
+	_ "mosn.io/htnn/types/plugins/responsetransformer"
+	_ "mosn.io/htnn/types/plugins/retry"
+	_ "mosn.io/htnn/types/plugins/sentinel"
+	_ "mosn.io/htnn/types/plugins/soap"
+	_ "mosn.io/htnn/types/plugins/sse"
+	_ "mosn.io/htnn/types/plugins/staleiferror"
+	_ "mosn.io/htnn/types/plugins/tenant"
 	_ "mosn.io/htnn/types/plugins/tlsinspector"
+	_ "mosn.io/htnn/types/plugins/traceenrichment"
+	_ "mosn.io/htnn/types/plugins/trafficsteering"
 )
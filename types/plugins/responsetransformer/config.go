@@ -0,0 +1,95 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package responsetransformer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "responseTransformer"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTransform
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionTransform,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func validatePath(path string) error {
+	for _, seg := range strings.Split(path, ".") {
+		if seg == "" {
+			return fmt.Errorf("bad field path %s: empty segment", path)
+		}
+	}
+	return nil
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	body := conf.Body
+	if body == nil {
+		return nil
+	}
+
+	paths := append([]string{}, body.KeepFields...)
+	paths = append(paths, body.RemoveFields...)
+	for _, f := range body.SetFields {
+		paths = append(paths, f.Path)
+	}
+	for _, p := range paths {
+		err = validatePath(p)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, r := range body.RegexReplaces {
+		_, err = regexp.Compile(r.Regex)
+		if err != nil {
+			return fmt.Errorf("bad regex %s: %w", r.Regex, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"errors"
+	"fmt"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "retry"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTraffic
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionBeforeUpstream,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+// retryConditions are the retry conditions supported by Envoy's x-envoy-retry-on header
+var retryConditions = map[string]bool{
+	"5xx":                        true,
+	"gateway-error":              true,
+	"reset":                      true,
+	"reset-before-request":       true,
+	"connect-failure":            true,
+	"envoy-ratelimited":          true,
+	"retriable-4xx":              true,
+	"refused-stream":             true,
+	"retriable-status-codes":     true,
+	"http3-post-connect-failure": true,
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	for _, cond := range conf.RetryOn {
+		if !retryConditions[cond] {
+			return fmt.Errorf("unknown retry condition %s", cond)
+		}
+	}
+	if conf.NumRetries > 0 && len(conf.RetryOn) == 0 && len(conf.RetriableStatusCodes) == 0 {
+		return errors.New("retryOn or retriableStatusCodes is required when numRetries is set")
+	}
+	if conf.PerTryTimeout != nil && conf.Timeout != nil &&
+		conf.PerTryTimeout.AsDuration() >= conf.Timeout.AsDuration() {
+		return errors.New("perTryTimeout should be less than timeout")
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traceenrichment
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/interpolation"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "traceEnrichment"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeObservability
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	// run after the authentication and authorization, so that the consumer and the decisions
+	// made by the plugins are known
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionBeforeUpstream,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+// CompileAttribute compiles the template of the span attribute. In addition to the variables
+// supported by the interpolation package, `plugin_state.$namespace.$key`, `response.code` and
+// `response.code_details` are accepted.
+func CompileAttribute(s string) (*interpolation.Template, error) {
+	tpl, err := interpolation.CompileWithPrefixes(s, "plugin_state", "response")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range tpl.CustomVariables() {
+		if name == "response.code" || name == "response.code_details" || isPluginStateVariable(name) {
+			continue
+		}
+		return nil, fmt.Errorf("unknown variable: %s", name)
+	}
+	return tpl, nil
+}
+
+// CompileBaggage compiles the template of the baggage entry. As the baggage is sent with the
+// request, only `plugin_state.$namespace.$key` is accepted in addition to the variables supported
+// by the interpolation package.
+func CompileBaggage(s string) (*interpolation.Template, error) {
+	tpl, err := interpolation.CompileWithPrefixes(s, "plugin_state")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range tpl.CustomVariables() {
+		if !isPluginStateVariable(name) {
+			return nil, fmt.Errorf("unknown variable: %s", name)
+		}
+	}
+	return tpl, nil
+}
+
+func isPluginStateVariable(name string) bool {
+	s, ok := strings.CutPrefix(name, "plugin_state.")
+	if !ok {
+		return false
+	}
+	ns, key, found := strings.Cut(s, ".")
+	return found && ns != "" && key != ""
+}
+
+// isToken reports whether the string is a token defined in RFC 7230, which is required by the
+// key of the baggage entry.
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c > '~' || strings.IndexByte(`"(),/:;<=>?@[\]{}`, c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if conf.StartTrace == nil && len(conf.Attributes) == 0 && len(conf.Baggage) == 0 {
+		return errors.New("one of startTrace, attributes and baggage is required")
+	}
+	for name, value := range conf.Attributes {
+		if name == "" {
+			return errors.New("attribute name should not be empty")
+		}
+		if _, err := CompileAttribute(value); err != nil {
+			return fmt.Errorf("bad attribute %s: %w", name, err)
+		}
+	}
+	for key, value := range conf.Baggage {
+		if !isToken(key) {
+			return fmt.Errorf("bad baggage key %q", key)
+		}
+		if _, err := CompileBaggage(value); err != nil {
+			return fmt.Errorf("bad baggage %s: %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright The HTNN Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trafficsteering
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"mosn.io/htnn/api/pkg/filtermanager/api"
+	"mosn.io/htnn/api/pkg/plugins"
+)
+
+const (
+	Name = "trafficSteering"
+)
+
+func init() {
+	plugins.RegisterPluginType(Name, &Plugin{})
+}
+
+type Plugin struct {
+	plugins.PluginMethodDefaultImpl
+}
+
+func (p *Plugin) Type() plugins.PluginType {
+	return plugins.TypeTraffic
+}
+
+func (p *Plugin) Order() plugins.PluginOrder {
+	return plugins.PluginOrder{
+		Position: plugins.OrderPositionTraffic,
+	}
+}
+
+func (p *Plugin) Config() api.PluginConfig {
+	return &CustomConfig{}
+}
+
+type CustomConfig struct {
+	Config
+}
+
+func (conf *CustomConfig) Validate() error {
+	err := conf.Config.Validate()
+	if err != nil {
+		return err
+	}
+
+	if conf.GeoIp == nil && conf.LatencyProbe == nil {
+		return errors.New("either geoIp or latencyProbe should be specified")
+	}
+
+	names := map[string]bool{}
+	countries := map[string]string{}
+	for _, u := range conf.Upstreams {
+		if names[u.Name] {
+			return fmt.Errorf("duplicate upstream %s", u.Name)
+		}
+		names[u.Name] = true
+
+		for _, c := range u.Countries {
+			if other, ok := countries[c]; ok {
+				return fmt.Errorf("country %s is served by both upstream %s and %s", c, other, u.Name)
+			}
+			countries[c] = u.Name
+		}
+
+		if conf.LatencyProbe != nil {
+			if u.ProbeAddress == "" {
+				return fmt.Errorf("probeAddress of upstream %s is required by latencyProbe", u.Name)
+			}
+			if _, _, err := net.SplitHostPort(u.ProbeAddress); err != nil {
+				return fmt.Errorf("bad probeAddress of upstream %s: %w", u.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -30,3 +30,7 @@ type RegistryType struct {
 func (reg *RegistryType) Config() registry.RegistryConfig {
 	return &Config{}
 }
+
+func (conf *Config) SensitiveFields() []string {
+	return []string{"token"}
+}
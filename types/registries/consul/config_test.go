@@ -18,10 +18,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"mosn.io/htnn/types/pkg/registry"
 )
 
 func TestConfig(t *testing.T) {
 	regType := &RegistryType{}
 	config := regType.Config()
 	assert.NotNil(t, config)
+	assert.Equal(t, []string{"token"}, registry.LoadSensitiveFields(Name))
 }